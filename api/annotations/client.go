@@ -5,6 +5,7 @@ package annotations
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -41,6 +42,42 @@ func (c *Client) Set(annotations map[string]map[string]string) ([]params.ErrorRe
 	return results.Results, nil
 }
 
+// Select returns the tags of the entities of the given kind whose
+// annotations match the selector. If kind is empty, all matching
+// entities are returned; units are also selected by the annotations
+// of their application or machine.
+func (c *Client) Select(selector, kind string) ([]names.Tag, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return nil, errors.NotImplementedf("Select in version %v", bestVer)
+	}
+	args := params.AnnotationsSelectArgs{
+		Selectors: []params.AnnotationsSelector{{
+			Selector: selector,
+			Kind:     kind,
+		}},
+	}
+	var results params.AnnotationsSelectResults
+	if err := c.facade.FacadeCall("Select", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	tags := make([]names.Tag, len(result.Entities))
+	for i, entity := range result.Entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tags[i] = tag
+	}
+	return tags, nil
+}
+
 func entitiesFromTags(tags []string) params.Entities {
 	entities := []params.Entity{}
 	for _, tag := range tags {
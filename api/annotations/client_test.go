@@ -4,6 +4,8 @@
 package annotations_test
 
 import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -91,3 +93,65 @@ func (s *annotationsMockSuite) TestGetEntitiesAnnotations(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 	c.Assert(found, gc.HasLen, 1)
 }
+
+func (s *annotationsMockSuite) TestSelect(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Annotations")
+			c.Check(request, gc.Equals, "Select")
+			c.Check(a, jc.DeepEquals, params.AnnotationsSelectArgs{
+				Selectors: []params.AnnotationsSelector{{
+					Selector: "team=payments",
+					Kind:     "unit",
+				}},
+			})
+			*(response.(*params.AnnotationsSelectResults)) = params.AnnotationsSelectResults{
+				Results: []params.AnnotationsSelectResult{{
+					Entities: []params.Entity{{Tag: "unit-mysql-0"}, {Tag: "unit-mysql-1"}},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	annotationsClient := annotations.NewClient(apiCaller)
+	tags, err := annotationsClient.Select("team=payments", "unit")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, jc.DeepEquals, []names.Tag{
+		names.NewUnitTag("mysql/0"), names.NewUnitTag("mysql/1"),
+	})
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *annotationsMockSuite) TestSelectError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			*(response.(*params.AnnotationsSelectResults)) = params.AnnotationsSelectResults{
+				Results: []params.AnnotationsSelectResult{{
+					Error: &params.Error{Message: "empty selector not valid"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	annotationsClient := annotations.NewClient(apiCaller)
+	_, err := annotationsClient.Select("", "")
+	c.Assert(err, gc.ErrorMatches, "empty selector not valid")
+}
+
+func (s *annotationsMockSuite) TestSelectNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 2,
+	}
+	annotationsClient := annotations.NewClient(apiCaller)
+	_, err := annotationsClient.Select("team=payments", "")
+	c.Assert(err, gc.ErrorMatches, "Select in version 2 not implemented")
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/storage"
 )
 
@@ -322,8 +323,12 @@ type SetCharmConfig struct {
 	EndpointBindings map[string]string
 }
 
-// SetCharm sets the charm for a given application.
+// SetCharm sets the charm for a given application. If the branch is not
+// master, the upgrade is staged in the branch.
 func (c *Client) SetCharm(branchName string, cfg SetCharmConfig) error {
+	if branchName != "" && branchName != model.GenerationMaster && c.BestAPIVersion() < 13 {
+		return errors.New("this juju controller does not support upgrading charms in a branch")
+	}
 	var storageConstraints map[string]params.StorageConstraints
 	if len(cfg.StorageConstraints) > 0 {
 		storageConstraints = make(map[string]params.StorageConstraints)
@@ -771,6 +776,21 @@ func (c *Client) SetConstraints(application string, constraints constraints.Valu
 	return c.facade.FacadeCall("SetConstraints", args, nil)
 }
 
+// SetBranchConstraints specifies the constraints for the given application
+// in the given branch. They are set on the application when the branch is
+// committed.
+func (c *Client) SetBranchConstraints(branchName, application string, constraints constraints.Value) error {
+	if c.BestAPIVersion() < 13 {
+		return errors.New("this juju controller does not support setting constraints in a branch")
+	}
+	args := params.SetConstraints{
+		ApplicationName: application,
+		Constraints:     constraints,
+		BranchName:      branchName,
+	}
+	return c.facade.FacadeCall("SetConstraints", args, nil)
+}
+
 // Expose changes the juju-managed firewall to expose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Expose(application string) error {
@@ -945,6 +965,73 @@ func (c *Client) UnsetApplicationConfig(branchName, application string, options
 	return results.OneError()
 }
 
+// ValidateApplicationConfig reports the changes that the given config
+// change would make to an application, without applying them. Validation
+// failures are returned in the result's Error field.
+func (c *Client) ValidateApplicationConfig(arg params.ApplicationConfigDryRun) (params.ConfigDryRunResult, error) {
+	if c.BestAPIVersion() < 14 {
+		return params.ConfigDryRunResult{}, errors.NotSupportedf("ValidateApplicationsConfig not supported by this version of Juju")
+	}
+	args := params.ApplicationConfigDryRunArgs{
+		Args: []params.ApplicationConfigDryRun{arg},
+	}
+	var results params.ConfigDryRunResults
+	err := c.facade.FacadeCall("ValidateApplicationsConfig", args, &results)
+	if err != nil {
+		return params.ConfigDryRunResult{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.ConfigDryRunResult{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	return results.Results[0], nil
+}
+
+// ConfigHistory returns the retained revisions of the charm and
+// application config of the given application, most recent first.
+func (c *Client) ConfigHistory(appName string) ([]params.ConfigRevision, error) {
+	if c.BestAPIVersion() < 15 {
+		return nil, errors.NotSupportedf("ApplicationConfigHistory not supported by this version of Juju")
+	}
+	if !names.IsValidApplication(appName) {
+		return nil, errors.NotValidf("application name %q", appName)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(appName).String()}},
+	}
+	var results params.ConfigHistoryResults
+	err := c.facade.FacadeCall("ApplicationConfigHistory", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Revisions, nil
+}
+
+// RevertConfig restores the charm and application config of the given
+// application to the values they had immediately after the given revision.
+func (c *Client) RevertConfig(appName string, revision int) error {
+	if c.BestAPIVersion() < 15 {
+		return errors.NotSupportedf("RevertApplicationConfig not supported by this version of Juju")
+	}
+	args := params.RevertApplicationConfigArgs{
+		Args: []params.RevertApplicationConfig{{
+			ApplicationName: appName,
+			Revision:        revision,
+		}},
+	}
+	var results params.ErrorResults
+	err := c.facade.FacadeCall("RevertApplicationConfig", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // ResolveUnitErrors clears errors on one or more units.
 // Either specify one or more units, or all.
 func (c *Client) ResolveUnitErrors(units []string, all, retry bool) error {
@@ -40,6 +40,10 @@ func newClient(f basetesting.APICallerFunc) *application.Client {
 	return application.NewClient(basetesting.BestVersionCaller{APICallerFunc: f, BestVersion: 8})
 }
 
+func newClientV13(f basetesting.APICallerFunc) *application.Client {
+	return application.NewClient(basetesting.BestVersionCaller{APICallerFunc: f, BestVersion: 13})
+}
+
 func newClientV4(f basetesting.APICallerFunc) *application.Client {
 	return application.NewClient(basetesting.BestVersionCaller{APICallerFunc: f, BestVersion: 4})
 }
@@ -259,7 +263,7 @@ func (s *applicationSuite) TestSetCharm(c *gc.C) {
 	toUint64Ptr := func(v uint64) *uint64 {
 		return &v
 	}
-	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+	client := newClientV13(func(objType string, version int, id, request string, a, response interface{}) error {
 		called = true
 		c.Assert(request, gc.Equals, "SetCharm")
 		args, ok := a.(params.ApplicationSetCharm)
@@ -307,6 +311,47 @@ func (s *applicationSuite) TestSetCharm(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestSetCharmBranchNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %q", request)
+		return nil
+	})
+	err := client.SetCharm(newBranchName, application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/application-1"),
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support upgrading charms in a branch")
+}
+
+func (s *applicationSuite) TestSetBranchConstraints(c *gc.C) {
+	var called bool
+	cons := constraints.MustParse("mem=4G")
+	client := newClientV13(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetConstraints")
+		c.Assert(a, jc.DeepEquals, params.SetConstraints{
+			ApplicationName: "application",
+			Constraints:     cons,
+			BranchName:      newBranchName,
+		})
+		return nil
+	})
+	err := client.SetBranchConstraints(newBranchName, "application", cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetBranchConstraintsNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %q", request)
+		return nil
+	})
+	err := client.SetBranchConstraints(newBranchName, "application", constraints.Value{})
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support setting constraints in a branch")
+}
+
 func (s *applicationSuite) TestDestroyDeprecated(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
@@ -1136,6 +1181,124 @@ func (s *applicationSuite) TestSetApplicationConfig(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "FAIL")
 }
 
+func (s *applicationSuite) TestValidateApplicationConfig(c *gc.C) {
+	arg := params.ApplicationConfigDryRun{
+		ApplicationName: "foo",
+		Generation:      newBranchName,
+		Config:          map[string]string{"level": "5"},
+		Reset:           []string{"foo"},
+	}
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "ValidateApplicationsConfig")
+				c.Assert(a, jc.DeepEquals, params.ApplicationConfigDryRunArgs{
+					Args: []params.ApplicationConfigDryRun{arg},
+				})
+				result, ok := response.(*params.ConfigDryRunResults)
+				c.Assert(ok, jc.IsTrue)
+				result.Results = []params.ConfigDryRunResult{{
+					Changes: []params.ConfigChange{{Key: "level", Old: 1, New: 5, Input: "5"}},
+					Units:   []string{"foo/0"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 14,
+	})
+
+	result, err := client.ValidateApplicationConfig(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ConfigDryRunResult{
+		Changes: []params.ConfigChange{{Key: "level", Old: 1, New: 5, Input: "5"}},
+		Units:   []string{"foo/0"},
+	})
+}
+
+func (s *applicationSuite) TestValidateApplicationConfigNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 13,
+	})
+	_, err := client.ValidateApplicationConfig(params.ApplicationConfigDryRun{ApplicationName: "foo"})
+	c.Assert(err, gc.ErrorMatches, "ValidateApplicationsConfig not supported by this version of Juju not supported")
+}
+
+func (s *applicationSuite) TestConfigHistory(c *gc.C) {
+	revisions := []params.ConfigRevision{{
+		Revision:  2,
+		Scope:     "charm",
+		CreatedBy: "bob",
+		Changes:   []params.ConfigChange{{Key: "level", Old: 1, New: 5}},
+	}}
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "ApplicationConfigHistory")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "application-foo"}},
+				})
+				result, ok := response.(*params.ConfigHistoryResults)
+				c.Assert(ok, jc.IsTrue)
+				result.Results = []params.ConfigHistoryResult{{Revisions: revisions}}
+				return nil
+			},
+		),
+		BestVersion: 15,
+	})
+
+	result, err := client.ConfigHistory("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, revisions)
+}
+
+func (s *applicationSuite) TestConfigHistoryNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 14,
+	})
+	_, err := client.ConfigHistory("foo")
+	c.Assert(err, gc.ErrorMatches, "ApplicationConfigHistory not supported by this version of Juju not supported")
+	err = client.RevertConfig("foo", 2)
+	c.Assert(err, gc.ErrorMatches, "RevertApplicationConfig not supported by this version of Juju not supported")
+}
+
+func (s *applicationSuite) TestRevertConfig(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "RevertApplicationConfig")
+				c.Assert(a, jc.DeepEquals, params.RevertApplicationConfigArgs{
+					Args: []params.RevertApplicationConfig{{
+						ApplicationName: "foo",
+						Revision:        2,
+					}},
+				})
+				result, ok := response.(*params.ErrorResults)
+				c.Assert(ok, jc.IsTrue)
+				result.Results = []params.ErrorResult{
+					{Error: &params.Error{Message: "config revision 2 not found"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 15,
+	})
+
+	err := client.RevertConfig("foo", 2)
+	c.Assert(err, gc.ErrorMatches, "config revision 2 not found")
+}
+
 func (s *applicationSuite) TestUnsetApplicationConfig(c *gc.C) {
 	client := application.NewClient(basetesting.BestVersionCaller{
 		APICallerFunc: basetesting.APICallerFunc(
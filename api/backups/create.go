@@ -11,13 +11,28 @@ import (
 
 // Create sends a request to create a backup of juju's state.  It
 // returns the metadata associated with the resulting backup and a
-// filename for download.
-func (c *Client) Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error) {
+// filename for download. If encryptTo is not empty, the backup archive
+// is encrypted to that public key. If stream is true, the database dump
+// is streamed into the archive, and if incremental is true, only the
+// changes since the most recent stored backup are backed up.
+func (c *Client) Create(notes string, keepCopy, noDownload bool, encryptTo string, stream, incremental bool) (*params.BackupsMetadataResult, error) {
+	if encryptTo != "" && c.facade.BestAPIVersion() < 3 {
+		// Older controllers would silently ignore the key and store
+		// the archive in the clear.
+		return nil, errors.NotSupportedf("encrypted backups on this controller")
+	}
+	if (stream || incremental) && c.facade.BestAPIVersion() < 4 {
+		// Older controllers would silently take a full staged backup.
+		return nil, errors.NotSupportedf("streaming or incremental backups on this controller")
+	}
 	var result params.BackupsMetadataResult
 	args := params.BackupsCreateArgs{
-		Notes:      notes,
-		KeepCopy:   keepCopy,
-		NoDownload: noDownload,
+		Notes:       notes,
+		KeepCopy:    keepCopy,
+		NoDownload:  noDownload,
+		EncryptTo:   encryptTo,
+		Stream:      stream,
+		Incremental: incremental,
 	}
 
 	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
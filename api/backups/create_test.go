@@ -41,9 +41,67 @@ func (s *createSuite) TestCreate(c *gc.C) {
 	)
 	defer cleanup()
 
-	result, err := s.client.Create("important", false, false)
+	result, err := s.client.Create("important", false, false, "", false, false)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Log(result)
 	meta := backupstesting.UpdateNotes(s.Meta, "important")
 	s.checkMetadataResult(c, result, meta)
 }
+
+func (s *createSuite) TestCreateEncrypted(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 3,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Create")
+			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsCreateArgs{})
+			c.Check(paramsIn.(params.BackupsCreateArgs).EncryptTo, gc.Equals, "juju-backup-public-key:abc")
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.Create("important", false, false, "juju-backup-public-key:abc", false, false)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *createSuite) TestCreateEncryptedNotSupported(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 2,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected call to %s", req)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.Create("important", false, false, "juju-backup-public-key:abc", false, false)
+	c.Assert(err, gc.ErrorMatches, "encrypted backups on this controller not supported")
+}
+
+func (s *createSuite) TestCreateStreamIncremental(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 4,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Create")
+			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsCreateArgs{})
+			p := paramsIn.(params.BackupsCreateArgs)
+			c.Check(p.Stream, jc.IsTrue)
+			c.Check(p.Incremental, jc.IsTrue)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.Create("important", false, false, "", true, true)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *createSuite) TestCreateStreamNotSupported(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 3,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected call to %s", req)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.Create("important", false, false, "", true, false)
+	c.Assert(err, gc.ErrorMatches, "streaming or incremental backups on this controller not supported")
+}
@@ -133,6 +133,7 @@ type UserModelSummary struct {
 	Error              error
 	Migration          *MigrationSummary
 	SLA                *SLASummary
+	Expiry             *time.Time
 }
 
 // EntityCount holds a count for a particular entity, for example machines or core count.
@@ -5,6 +5,7 @@ package block
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -75,3 +76,42 @@ func (c *Client) SwitchBlockOff(blockType string) error {
 	}
 	return nil
 }
+
+// SwitchEntityBlockOn blocks the given categories of operation on an
+// application, machine or relation in the current model. Valid
+// operations are "config", "refresh", "scale" and "remove".
+func (c *Client) SwitchEntityBlockOn(tag names.Tag, operations []string, msg string) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return errors.NotImplementedf("SwitchEntityBlockOn in version %v", bestVer)
+	}
+	args := params.EntityBlockSwitchParams{
+		Tag:        tag.String(),
+		Operations: operations,
+		Message:    msg,
+	}
+	var result params.ErrorResult
+	if err := c.facade.FacadeCall("SwitchEntityBlockOn", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
+
+// SwitchEntityBlockOff removes the block on an application, machine or
+// relation in the current model.
+func (c *Client) SwitchEntityBlockOff(tag names.Tag) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return errors.NotImplementedf("SwitchEntityBlockOff in version %v", bestVer)
+	}
+	args := params.Entity{Tag: tag.String()}
+	var result params.ErrorResult
+	if err := c.facade.FacadeCall("SwitchEntityBlockOff", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
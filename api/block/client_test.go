@@ -5,6 +5,7 @@ package block_test
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -168,3 +169,62 @@ func (s *blockMockSuite) TestList(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, errmsg)
 	c.Assert(found, gc.HasLen, 1)
 }
+
+func (s *blockMockSuite) TestSwitchEntityBlockOn(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Block")
+			c.Check(request, gc.Equals, "SwitchEntityBlockOn")
+			c.Check(a, jc.DeepEquals, params.EntityBlockSwitchParams{
+				Tag:        "application-mysql",
+				Operations: []string{"config", "remove"},
+				Message:    "production database",
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.ErrorResult{})
+			return nil
+		},
+		BestVersion: 3,
+	}
+	blockClient := block.NewClient(apiCaller)
+	err := blockClient.SwitchEntityBlockOn(
+		names.NewApplicationTag("mysql"), []string{"config", "remove"}, "production database")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *blockMockSuite) TestSwitchEntityBlockOff(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(request, gc.Equals, "SwitchEntityBlockOff")
+			c.Check(a, jc.DeepEquals, params.Entity{Tag: "machine-0"})
+			*(response.(*params.ErrorResult)) = params.ErrorResult{
+				Error: &params.Error{Message: "boom"},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	blockClient := block.NewClient(apiCaller)
+	err := blockClient.SwitchEntityBlockOff(names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *blockMockSuite) TestSwitchEntityBlockNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 2,
+	}
+	blockClient := block.NewClient(apiCaller)
+	err := blockClient.SwitchEntityBlockOn(names.NewApplicationTag("mysql"), []string{"config"}, "")
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	err = blockClient.SwitchEntityBlockOff(names.NewApplicationTag("mysql"))
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
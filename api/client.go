@@ -50,8 +50,21 @@ type Client struct {
 
 // Status returns the status of the juju model.
 func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
+	return c.status(params.StatusParams{Patterns: patterns})
+}
+
+// StatusWithSelector returns the status of the juju model, restricted
+// to the entities matching the patterns and whose annotations match
+// the selector.
+func (c *Client) StatusWithSelector(patterns []string, selector string) (*params.FullStatus, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return nil, errors.NotImplementedf("StatusWithSelector in version %v", bestVer)
+	}
+	return c.status(params.StatusParams{Patterns: patterns, Selector: selector})
+}
+
+func (c *Client) status(p params.StatusParams) (*params.FullStatus, error) {
 	var result params.FullStatus
-	p := params.StatusParams{Patterns: patterns}
 	if err := c.facade.FacadeCall("FullStatus", p, &result); err != nil {
 		return nil, err
 	}
@@ -67,50 +80,93 @@ func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
 // <kind:combined|agent|workload|machine|machineinstance|container|containerinstance> status
 // for <name> unit
 func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
-	var results params.StatusHistoryResults
-	args := params.StatusHistoryRequest{
-		Kind: string(kind),
-		Filter: params.StatusHistoryFilter{
-			Size:    filter.Size,
-			Date:    filter.FromDate,
-			Delta:   filter.Delta,
-			Exclude: filter.Exclude.Values(),
-		},
-		Tag: tag.String(),
+	results, err := c.StatusHistories(StatusHistoryRequest{
+		Kind:   kind,
+		Tag:    tag,
+		Filter: filter,
+	})
+	if err != nil {
+		return status.History{}, errors.Trace(err)
+	}
+	if results[0].Error != nil {
+		return status.History{}, results[0].Error
 	}
-	bulkArgs := params.StatusHistoryRequests{Requests: []params.StatusHistoryRequest{args}}
+	return results[0].History, nil
+}
+
+// StatusHistoryRequest identifies an entity and the kind of status
+// history to retrieve for it.
+type StatusHistoryRequest struct {
+	Kind   status.HistoryKind
+	Tag    names.Tag
+	Filter status.StatusHistoryFilter
+}
+
+// StatusHistoryResult holds the status history of an entity, or the
+// error encountered retrieving it.
+type StatusHistoryResult struct {
+	History status.History
+	Error   error
+}
+
+// StatusHistories retrieves the status history of several entities
+// in a single call. The results are returned in the same order as
+// the requests.
+func (c *Client) StatusHistories(requests ...StatusHistoryRequest) ([]StatusHistoryResult, error) {
+	bulkArgs := params.StatusHistoryRequests{
+		Requests: make([]params.StatusHistoryRequest, len(requests)),
+	}
+	for i, request := range requests {
+		bulkArgs.Requests[i] = params.StatusHistoryRequest{
+			Kind: string(request.Kind),
+			Filter: params.StatusHistoryFilter{
+				Size:    request.Filter.Size,
+				Date:    request.Filter.FromDate,
+				Delta:   request.Filter.Delta,
+				Exclude: request.Filter.Exclude.Values(),
+			},
+			Tag: request.Tag.String(),
+		}
+	}
+	var results params.StatusHistoryResults
 	err := c.facade.FacadeCall("StatusHistory", bulkArgs, &results)
 	if err != nil {
-		return status.History{}, errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
-	if len(results.Results) != 1 {
-		return status.History{}, errors.Errorf("expected 1 result got %d", len(results.Results))
-	}
-	if results.Results[0].Error != nil {
-		return status.History{}, errors.Annotatef(results.Results[0].Error, "while processing the request")
-	}
-	history := make(status.History, len(results.Results[0].History.Statuses))
-	if results.Results[0].History.Error != nil {
-		return status.History{}, results.Results[0].History.Error
-	}
-	for i, h := range results.Results[0].History.Statuses {
-		history[i] = status.DetailedStatus{
-			Status:  status.Status(h.Status),
-			Info:    h.Info,
-			Data:    h.Data,
-			Since:   h.Since,
-			Kind:    status.HistoryKind(h.Kind),
-			Version: h.Version,
-			// TODO(perrito666) make sure these are still used.
-			Life: h.Life,
-			Err:  h.Err,
+	if len(results.Results) != len(requests) {
+		return nil, errors.Errorf("expected %d result(s) got %d", len(requests), len(results.Results))
+	}
+	histories := make([]StatusHistoryResult, len(results.Results))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			histories[i].Error = errors.Annotatef(result.Error, "while processing the request")
+			continue
 		}
-		// TODO(perrito666) https://launchpad.net/bugs/1577589
-		if !history[i].Kind.Valid() {
-			logger.Errorf("history returned an unknown status kind %q", h.Kind)
+		if result.History.Error != nil {
+			histories[i].Error = result.History.Error
+			continue
 		}
+		history := make(status.History, len(result.History.Statuses))
+		for j, h := range result.History.Statuses {
+			history[j] = status.DetailedStatus{
+				Status:  status.Status(h.Status),
+				Info:    h.Info,
+				Data:    h.Data,
+				Since:   h.Since,
+				Kind:    status.HistoryKind(h.Kind),
+				Version: h.Version,
+				// TODO(perrito666) make sure these are still used.
+				Life: h.Life,
+				Err:  h.Err,
+			}
+			// TODO(perrito666) https://launchpad.net/bugs/1577589
+			if !history[j].Kind.Valid() {
+				logger.Errorf("history returned an unknown status kind %q", h.Kind)
+			}
+		}
+		histories[i].History = history
 	}
-	return history, nil
+	return histories, nil
 }
 
 // Resolved clears errors on a unit.
@@ -532,6 +588,13 @@ func (c *Client) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
 	return openURI(c.st, uri, query)
 }
 
+// OpenResource streams out the content of the named application
+// resource from the controller via the API.
+func (c *Client) OpenResource(application, name string) (io.ReadCloser, error) {
+	uri := fmt.Sprintf("/applications/%s/resources/%s", application, name)
+	return openURI(c.st, uri, nil)
+}
+
 func openURI(apiCaller base.APICaller, uri string, query url.Values) (io.ReadCloser, error) {
 	// The returned httpClient sets the base url to /model/<uuid> if it can.
 	httpClient, err := apiCaller.HTTPClient()
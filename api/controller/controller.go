@@ -319,16 +319,95 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
+	args, err := migrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// MigrationDryRunReport holds every problem a migration dry run found.
+// Blockers would stop the migration from starting; warnings would not.
+type MigrationDryRunReport struct {
+	Blockers []string
+	Warnings []string
+}
+
+// MigrationDryRun runs every check which would be made before
+// migrating the model in the spec, without starting the migration,
+// and returns a report of all the problems found.
+func (c *Client) MigrationDryRun(spec MigrationSpec) (*MigrationDryRunReport, error) {
+	if c.BestAPIVersion() < 10 {
+		return nil, errors.NotSupportedf("migration dry runs on this controller")
+	}
+	args, err := migrationArgs(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	response := params.MigrationDryRunResults{}
+	if err := c.facade.FacadeCall("MigrationDryRun", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return nil, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return &MigrationDryRunReport{
+		Blockers: result.Blockers,
+		Warnings: result.Warnings,
+	}, nil
+}
+
+// MigrationProgress returns the progress of the latest migration of
+// the model, including when it entered each phase, the entities
+// exported and imported, log transfer progress and the agents which
+// have and haven't reported for each phase that waits for them.
+func (c *Client) MigrationProgress(modelUUID string) (*params.MigrationProgress, error) {
+	if c.BestAPIVersion() < 11 {
+		return nil, errors.NotSupportedf("migration progress on this controller")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}},
+	}
+	var response params.MigrationProgressResults
+	if err := c.facade.FacadeCall("MigrationProgress", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return nil, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
+func migrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
 	if err := spec.Validate(); err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
 	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
-	args := params.InitiateMigrationArgs{
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
 			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
 			TargetInfo: params.MigrationTargetInfo{
@@ -341,19 +420,7 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Macaroons:       macsJSON,
 			},
 		}},
-	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
-		return "", errors.Trace(err)
-	}
-	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
-	}
-	result := response.Results[0]
-	if result.Error != nil {
-		return "", errors.Trace(result.Error)
-	}
-	return result.MigrationId, nil
+	}, nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
@@ -172,6 +172,122 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestMigrationDryRun(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.MigrationDryRunResults)) = params.MigrationDryRunResults{
+				Results: []params.MigrationDryRunResult{{
+					Blockers: []string{"machine 0 is dying"},
+					Warnings: []string{"target controller clock differs from source controller by 5m0s"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 10,
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	report, err := client.MigrationDryRun(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report, jc.DeepEquals, &controller.MigrationDryRunReport{
+		Blockers: []string{"machine 0 is dying"},
+		Warnings: []string{"target controller clock differs from source controller by 5m0s"},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.MigrationDryRun", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestMigrationDryRunError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.MigrationDryRunResults)) = params.MigrationDryRunResults{
+				Results: []params.MigrationDryRunResult{{
+					Error: apiservererrors.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		},
+		BestVersion: 10,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationDryRun(makeSpec())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestMigrationDryRunNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		BestVersion: 9,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationDryRun(makeSpec())
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestMigrationProgress(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.MigrationProgressResults)) = params.MigrationProgressResults{
+				Results: []params.MigrationProgressResult{{
+					Result: &params.MigrationProgress{
+						MigrationId: "uuid:0",
+						Phase:       "IMPORT",
+					},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 11,
+	}
+	client := controller.NewClient(apiCaller)
+	progress, err := client.MigrationProgress(randomUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(progress, jc.DeepEquals, &params.MigrationProgress{
+		MigrationId: "uuid:0",
+		Phase:       "IMPORT",
+	})
+	c.Assert(stub.Calls(), gc.HasLen, 1)
+	c.Check(stub.Calls()[0].FuncName, gc.Equals, "Controller.MigrationProgress")
+}
+
+func (s *Suite) TestMigrationProgressError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.MigrationProgressResults)) = params.MigrationProgressResults{
+				Results: []params.MigrationProgressResult{{
+					Error: apiservererrors.ServerError(errors.NotFoundf("migration")),
+				}},
+			}
+			return nil
+		},
+		BestVersion: 11,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationProgress(randomUUID())
+	c.Check(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *Suite) TestMigrationProgressNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		BestVersion: 10,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationProgress(randomUUID())
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
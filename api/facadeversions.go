@@ -17,12 +17,12 @@ var facadeVersions = map[string]int{
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
-	"Annotations":                  2,
-	"Application":                  12,
+	"Annotations":                  3,
+	"Application":                  15,
 	"ApplicationOffers":            2,
 	"ApplicationScaler":            1,
-	"Backups":                      2,
-	"Block":                        2,
+	"Backups":                      4,
+	"Block":                        3,
 	"Bundle":                       4,
 	"CAASAgent":                    1,
 	"CAASAdmission":                1,
@@ -36,9 +36,9 @@ var facadeVersions = map[string]int{
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       2,
+	"Client":                       3,
 	"Cloud":                        7,
-	"Controller":                   9,
+	"Controller":                   11,
 	"CredentialManager":            1,
 	"CredentialValidator":          2,
 	"CrossController":              1,
@@ -73,13 +73,14 @@ var facadeVersions = map[string]int{
 	"MetricsDebug":                 2,
 	"MetricsManager":               1,
 	"MigrationFlag":                1,
-	"MigrationMaster":              2,
+	"MigrationMaster":              3,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
-	"ModelConfig":                  2,
-	"ModelGeneration":              4,
-	"ModelManager":                 8,
+	"MigrationTarget":              3,
+	"ModelConfig":                  4,
+	"ModelExpiry":                  1,
+	"ModelGeneration":              6,
+	"ModelManager":                 10,
 	"ModelSummaryWatcher":          1,
 	"ModelUpgrader":                1,
 	"NotifyWatcher":                1,
@@ -90,6 +91,7 @@ var facadeVersions = map[string]int{
 	"Provisioner":                  11,
 	"ProxyUpdater":                 2,
 	"Reboot":                       2,
+	"RelationData":                 1,
 	"RelationStatusWatcher":        1,
 	"RelationUnitsWatcher":         1,
 	"RemoteRelations":              2,
@@ -130,6 +130,23 @@ func (c *Client) SetStatusMessage(message string) error {
 	return c.caller.FacadeCall("SetStatusMessage", args, nil)
 }
 
+// SetProgress records the counts of entities exported and imported
+// and of logs transferred so far by the migration.
+func (c *Client) SetProgress(progress migration.Progress) error {
+	if c.caller.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("recording migration progress on this controller")
+	}
+	args := params.SetMigrationProgressArgs{
+		ExportedEntities: progress.ExportedEntities,
+		ImportedEntities: progress.ImportedEntities,
+		LogsTransferred:  progress.LogsTransferred,
+	}
+	if !progress.LastLogTime.IsZero() {
+		args.LastLogTime = &progress.LastLogTime
+	}
+	return c.caller.FacadeCall("SetProgress", args, nil)
+}
+
 // ModelInfo return basic information about the model to migrated.
 func (c *Client) ModelInfo() (migration.ModelInfo, error) {
 	var info params.MigrationModelInfo
@@ -187,6 +204,7 @@ func (c *Client) Export() (migration.SerializedModel, error) {
 		Charms:    serialized.Charms,
 		Tools:     tools,
 		Resources: resources,
+		Entities:  serialized.Entities,
 	}, nil
 }
 
@@ -173,6 +173,46 @@ func (s *ClientSuite) TestSetStatusMessageError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestSetProgress(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return nil
+		},
+		BestVersion: 3,
+	}
+	lastLogTime := time.Date(2020, 3, 1, 12, 30, 0, 0, time.UTC)
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.SetProgress(migration.Progress{
+		ExportedEntities: map[string]int{"machines": 1},
+		LogsTransferred:  5,
+		LastLogTime:      lastLogTime,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expectedArg := params.SetMigrationProgressArgs{
+		ExportedEntities: map[string]int{"machines": 1},
+		LogsTransferred:  5,
+		LastLogTime:      &lastLogTime,
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.SetProgress", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestSetProgressNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.SetProgress(migration.Progress{LogsTransferred: 5})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *ClientSuite) TestModelInfo(c *gc.C) {
 	var stub jujutesting.Stub
 	owner := names.NewUserTag("owner")
@@ -296,6 +336,7 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 					},
 				},
 			}},
+			Entities: map[string]int{"machines": 1},
 		}
 		return nil
 	})
@@ -364,6 +405,7 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 				},
 			},
 		}},
+		Entities: map[string]int{"machines": 1},
 	})
 }
 
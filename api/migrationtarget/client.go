@@ -53,11 +53,56 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 	return errors.Trace(c.caller.FacadeCall("Prechecks", args, nil))
 }
 
+// DryRunReport holds the problems the target controller found with
+// migrating a model, and the time on its clock when it checked.
+type DryRunReport struct {
+	Blockers []string
+	Warnings []string
+	Time     time.Time
+}
+
+// DryRunPrechecks asks the target controller to run all its prechecks
+// for the model, and to check the serialized model for problems it
+// knows would stop the model being imported. The model isn't
+// imported, so an import may still fail for reasons not checked.
+func (c *Client) DryRunPrechecks(model coremigration.ModelInfo, bytes []byte) (*DryRunReport, error) {
+	if c.caller.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("migration dry runs on this controller")
+	}
+	args := params.MigrationDryRunPrecheckArgs{
+		ModelInfo: params.MigrationModelInfo{
+			UUID:                   model.UUID,
+			Name:                   model.Name,
+			OwnerTag:               model.Owner.String(),
+			AgentVersion:           model.AgentVersion,
+			ControllerAgentVersion: model.ControllerAgentVersion,
+		},
+		Bytes: bytes,
+	}
+	var result params.MigrationDryRunPrecheckResult
+	if err := c.caller.FacadeCall("DryRunPrechecks", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &DryRunReport{
+		Blockers: result.Blockers,
+		Warnings: result.Warnings,
+		Time:     result.Time,
+	}, nil
+}
+
 // Import takes a serialized model and imports it into the target
-// controller.
-func (c *Client) Import(bytes []byte) error {
+// controller. It returns the number of each kind of entity imported,
+// which older controllers don't report.
+func (c *Client) Import(bytes []byte) (map[string]int, error) {
 	serialized := params.SerializedModel{Bytes: bytes}
-	return errors.Trace(c.caller.FacadeCall("Import", serialized, nil))
+	if c.caller.BestAPIVersion() < 3 {
+		return nil, errors.Trace(c.caller.FacadeCall("Import", serialized, nil))
+	}
+	var result params.MigrationImportResult
+	if err := c.caller.FacadeCall("Import", serialized, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entities, nil
 }
 
 // Abort removes all data relating to a previously imported model.
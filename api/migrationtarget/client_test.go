@@ -81,7 +81,7 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
-	err := client.Import([]byte("foo"))
+	_, err := client.Import([]byte("foo"))
 
 	expectedArg := params.SerializedModel{Bytes: []byte("foo")}
 	stub.CheckCalls(c, []jujutesting.StubCall{
@@ -90,6 +90,25 @@ func (s *ClientSuite) TestImport(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestImportEntityCounts(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, args, response interface{}) error {
+			c.Check(objType, gc.Equals, "MigrationTarget")
+			c.Check(request, gc.Equals, "Import")
+			c.Check(args, jc.DeepEquals, params.SerializedModel{Bytes: []byte("foo")})
+			*(response.(*params.MigrationImportResult)) = params.MigrationImportResult{
+				Entities: map[string]int{"machines": 2},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+	entities, err := client.Import([]byte("foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entities, jc.DeepEquals, map[string]int{"machines": 2})
+}
+
 func (s *ClientSuite) TestAbort(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -275,6 +294,62 @@ func (s *ClientSuite) TestPlaceholderResource(c *gc.C) {
 	c.Assert(doer.body, gc.Equals, "")
 }
 
+func (s *ClientSuite) TestDryRunPrechecks(c *gc.C) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, args, response interface{}) error {
+			c.Check(objType, gc.Equals, "MigrationTarget")
+			c.Check(request, gc.Equals, "DryRunPrechecks")
+			c.Check(args, jc.DeepEquals, params.MigrationDryRunPrecheckArgs{
+				ModelInfo: params.MigrationModelInfo{
+					UUID:                   "uuid",
+					Name:                   "name",
+					OwnerTag:               ownerTag.String(),
+					AgentVersion:           vers,
+					ControllerAgentVersion: vers,
+				},
+				Bytes: []byte("model"),
+			})
+			*(response.(*params.MigrationDryRunPrecheckResult)) = params.MigrationDryRunPrecheckResult{
+				Blockers: []string{"model named \"name\" already exists"},
+				Warnings: []string{"unit app/0 is on machine 0 with series \"bionic\", not the application's series \"focal\""},
+				Time:     now,
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+	check, err := client.DryRunPrechecks(coremigration.ModelInfo{
+		UUID:                   "uuid",
+		Owner:                  ownerTag,
+		Name:                   "name",
+		AgentVersion:           vers,
+		ControllerAgentVersion: vers,
+	}, []byte("model"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(check, jc.DeepEquals, &migrationtarget.DryRunReport{
+		Blockers: []string{"model named \"name\" already exists"},
+		Warnings: []string{"unit app/0 is on machine 0 with series \"bionic\", not the application's series \"focal\""},
+		Time:     now,
+	})
+}
+
+func (s *ClientSuite) TestDryRunPrechecksNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+	_, err := client.DryRunPrechecks(coremigration.ModelInfo{}, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *ClientSuite) TestCACert(c *gc.C) {
 	call := func(objType string, version int, id, request string, args, response interface{}) error {
 		c.Check(objType, gc.Equals, "MigrationTarget")
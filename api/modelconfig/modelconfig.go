@@ -103,3 +103,46 @@ func (c *Client) Sequences() (map[string]int, error) {
 	}
 	return result.Sequences, nil
 }
+
+// ValidateModelConfig reports the changes that setting and resetting the
+// given attributes would make to the model config, without applying
+// them. Validation failures, including those reported by the provider,
+// are returned in the result's Error field.
+func (c *Client) ValidateModelConfig(set map[string]interface{}, reset []string) (params.ConfigDryRunResult, error) {
+	var result params.ConfigDryRunResult
+	if c.BestAPIVersion() < 3 {
+		return result, errors.NotSupportedf("ValidateModelConfig on v%d facade", c.BestAPIVersion())
+	}
+	args := params.ModelConfigDryRun{
+		Config: set,
+		Reset:  reset,
+	}
+	err := c.facade.FacadeCall("ValidateModelConfig", args, &result)
+	return result, errors.Trace(err)
+}
+
+// ModelConfigHistory returns the retained revisions of the model's
+// config, most recent first.
+func (c *Client) ModelConfigHistory() ([]params.ConfigRevision, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("ModelConfigHistory on v%d facade", c.BestAPIVersion())
+	}
+	var result params.ConfigHistoryResult
+	if err := c.facade.FacadeCall("ModelConfigHistory", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Revisions, nil
+}
+
+// RevertModelConfig restores the model's config to the values it had
+// immediately after the given revision.
+func (c *Client) RevertModelConfig(revision int) error {
+	if c.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("RevertModelConfig on v%d facade", c.BestAPIVersion())
+	}
+	args := params.RevertModelConfig{Revision: revision}
+	return errors.Trace(c.facade.FacadeCall("RevertModelConfig", args, nil))
+}
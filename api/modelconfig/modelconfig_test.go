@@ -220,3 +220,116 @@ func (s *modelconfigSuite) TestSequences(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 	c.Assert(sequences, jc.DeepEquals, map[string]int{"foo": 5, "bar": 2})
 }
+
+func (s *modelconfigSuite) TestValidateModelConfigV2(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(_ string, _ int, _, _ string, _, _ interface{}) error {
+				c.Errorf("shouldn't be called")
+				return nil
+			},
+		), 2}
+	client := modelconfig.NewClient(apiCaller)
+	_, err := client.ValidateModelConfig(map[string]interface{}{"foo": "bar"}, nil)
+	c.Assert(err, gc.ErrorMatches, "ValidateModelConfig on v2 facade not supported")
+}
+
+func (s *modelconfigSuite) TestValidateModelConfig(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelConfig")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ValidateModelConfig")
+				c.Check(a, jc.DeepEquals, params.ModelConfigDryRun{
+					Config: map[string]interface{}{"some-name": "value"},
+					Reset:  []string{"other-name"},
+				})
+				*(result.(*params.ConfigDryRunResult)) = params.ConfigDryRunResult{
+					Changes: []params.ConfigChange{{Key: "some-name", New: "value"}},
+					Error:   &params.Error{Message: "provider rejected config: boom"},
+				}
+				called = true
+				return nil
+			},
+		), 3}
+	client := modelconfig.NewClient(apiCaller)
+	result, err := client.ValidateModelConfig(map[string]interface{}{"some-name": "value"}, []string{"other-name"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result.Changes, jc.DeepEquals, []params.ConfigChange{{Key: "some-name", New: "value"}})
+	c.Assert(result.Error, gc.ErrorMatches, "provider rejected config: boom")
+}
+
+func (s *modelconfigSuite) TestModelConfigHistoryV3(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(_ string, _ int, _, _ string, _, _ interface{}) error {
+				c.Errorf("shouldn't be called")
+				return nil
+			},
+		), 3}
+	client := modelconfig.NewClient(apiCaller)
+	_, err := client.ModelConfigHistory()
+	c.Assert(err, gc.ErrorMatches, "ModelConfigHistory on v3 facade not supported")
+	err = client.RevertModelConfig(2)
+	c.Assert(err, gc.ErrorMatches, "RevertModelConfig on v3 facade not supported")
+}
+
+func (s *modelconfigSuite) TestModelConfigHistory(c *gc.C) {
+	revisions := []params.ConfigRevision{{
+		Revision:  2,
+		Scope:     "model",
+		CreatedBy: "bob",
+		Changes:   []params.ConfigChange{{Key: "some-name", Old: "old", New: "value"}},
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelConfig")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ModelConfigHistory")
+				c.Check(a, gc.IsNil)
+				*(result.(*params.ConfigHistoryResult)) = params.ConfigHistoryResult{
+					Revisions: revisions,
+				}
+				return nil
+			},
+		), 4}
+	client := modelconfig.NewClient(apiCaller)
+	result, err := client.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, revisions)
+}
+
+func (s *modelconfigSuite) TestRevertModelConfig(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelConfig")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "RevertModelConfig")
+				c.Check(a, jc.DeepEquals, params.RevertModelConfig{Revision: 2})
+				called = true
+				return nil
+			},
+		), 4}
+	client := modelconfig.NewClient(apiCaller)
+	err := client.RevertModelConfig(2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
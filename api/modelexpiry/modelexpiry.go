@@ -0,0 +1,82 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelexpiry
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+const apiName = "ModelExpiry"
+
+// Expiry holds when a model expires, and whether its storage is
+// destroyed when it does.
+type Expiry struct {
+	// Time is when the model expires. It is zero if the model
+	// never expires.
+	Time time.Time
+
+	// DestroyStorage is whether the storage of the model is destroyed,
+	// rather than released, when the model expires.
+	DestroyStorage bool
+}
+
+// Facade provides access to the ModelExpiry API, used by the model
+// expiry worker.
+type Facade struct {
+	facade base.FacadeCaller
+}
+
+// NewFacade returns a new ModelExpiry facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{facade: base.NewFacadeCaller(caller, apiName)}
+}
+
+// WatchModel returns a watcher that notifies of changes to the model,
+// including to when it expires.
+func (f *Facade) WatchModel() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := f.facade.FacadeCall("WatchModel", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(f.facade.RawAPICaller(), result), nil
+}
+
+// ModelExpiry returns when the model expires.
+func (f *Facade) ModelExpiry() (Expiry, error) {
+	var result params.ModelExpiryResult
+	if err := f.facade.FacadeCall("ModelExpiry", nil, &result); err != nil {
+		return Expiry{}, errors.Trace(err)
+	}
+	expiry := Expiry{DestroyStorage: result.DestroyStorage}
+	if result.Expiry != nil {
+		expiry.Time = *result.Expiry
+	}
+	return expiry, nil
+}
+
+// SetExpiryWarning warns in the model's status that the model will
+// be destroyed at the given time, or removes the warning if the time
+// is zero.
+func (f *Facade) SetExpiryWarning(destroy time.Time) error {
+	var args params.ModelExpiryWarning
+	if !destroy.IsZero() {
+		args.Destroy = &destroy
+	}
+	return f.facade.FacadeCall("SetExpiryWarning", args, nil)
+}
+
+// DestroyExpiredModel destroys the model, if it has expired.
+func (f *Facade) DestroyExpiredModel() error {
+	return f.facade.FacadeCall("DestroyExpiredModel", nil, nil)
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelexpiry_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelexpiry"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ModelExpirySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ModelExpirySuite{})
+
+func (s *ModelExpirySuite) TestModelExpiry(c *gc.C) {
+	expiry := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	var called bool
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "ModelExpiry")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ModelExpiry")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.ModelExpiryResult{})
+		*(result.(*params.ModelExpiryResult)) = params.ModelExpiryResult{
+			Expiry:         &expiry,
+			DestroyStorage: true,
+		}
+		return nil
+	})
+	result, err := modelexpiry.NewFacade(apiCaller).ModelExpiry()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, modelexpiry.Expiry{
+		Time:           expiry,
+		DestroyStorage: true,
+	})
+}
+
+func (s *ModelExpirySuite) TestModelExpiryNever(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return nil
+	})
+	result, err := modelexpiry.NewFacade(apiCaller).ModelExpiry()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Time.IsZero(), jc.IsTrue)
+}
+
+func (s *ModelExpirySuite) TestDestroyExpiredModel(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "ModelExpiry")
+		c.Check(request, gc.Equals, "DestroyExpiredModel")
+		c.Check(arg, gc.IsNil)
+		c.Check(result, gc.IsNil)
+		return &params.Error{Message: "not yet", Code: params.CodeOperationBlocked}
+	})
+	err := modelexpiry.NewFacade(apiCaller).DestroyExpiredModel()
+	c.Assert(called, jc.IsTrue)
+	c.Assert(err, jc.Satisfies, params.IsCodeOperationBlocked)
+}
+
+func (s *ModelExpirySuite) TestSetExpiryWarning(c *gc.C) {
+	destroy := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	var args []params.ModelExpiryWarning
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ModelExpiry")
+		c.Check(request, gc.Equals, "SetExpiryWarning")
+		c.Check(result, gc.IsNil)
+		c.Assert(arg, gc.FitsTypeOf, params.ModelExpiryWarning{})
+		args = append(args, arg.(params.ModelExpiryWarning))
+		return nil
+	})
+	facade := modelexpiry.NewFacade(apiCaller)
+	err := facade.SetExpiryWarning(destroy)
+	c.Assert(err, jc.ErrorIsNil)
+	err = facade.SetExpiryWarning(time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(args, jc.DeepEquals, []params.ModelExpiryWarning{{Destroy: &destroy}, {}})
+}
+
+func (s *ModelExpirySuite) TestWatchModelError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ModelExpiry")
+		c.Check(request, gc.Equals, "WatchModel")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResult{})
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	_, err := modelexpiry.NewFacade(apiCaller).WatchModel()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -96,14 +96,90 @@ func (c *Client) ShowCommit(generationId int) (model.GenerationCommit, error) {
 	return generationCommitFromResult(result), nil
 }
 
+// RevertCommit adds a new branch with the input name, holding the inverse of
+// the config changes made by the commit with the input generation ID.
+// If commit is true, the new branch is committed immediately and the new
+// generation ID of the model is returned.
+// Changes that could not be reverted are returned as conflicts.
+func (c *Client) RevertCommit(
+	generationId int, branchName string, commit bool,
+) (int, []model.RevertConflict, error) {
+	if c.facade.BestAPIVersion() < 5 {
+		return 0, nil, errors.NotSupportedf("reverting commits on this controller")
+	}
+
+	var result params.RevertCommitResult
+	arg := params.RevertCommitArg{
+		GenerationId: generationId,
+		BranchName:   branchName,
+		Commit:       commit,
+	}
+	err := c.facade.FacadeCall("RevertCommit", arg, &result)
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+
+	var conflicts []model.RevertConflict
+	for _, c := range result.Conflicts {
+		conflicts = append(conflicts, model.RevertConflict{
+			Application: c.ApplicationName,
+			Key:         c.Key,
+			Reason:      c.Reason,
+		})
+	}
+	if result.Error != nil {
+		return 0, conflicts, errors.Trace(result.Error)
+	}
+	return result.GenerationId, conflicts, nil
+}
+
 // TrackBranch sets the input units and/or applications
 // to track changes made under the input branch name.
 func (c *Client) TrackBranch(branchName string, entities []string, numUnits int) error {
-	var result params.ErrorResults
 	arg := params.BranchTrackArg{
 		BranchName: branchName,
 		NumUnits:   numUnits,
 	}
+	return errors.Trace(c.trackBranch(arg, entities))
+}
+
+// TrackBranchWithPolicy sets the units of the input applications selected
+// by the input policy to track changes made under the input branch name.
+func (c *Client) TrackBranchWithPolicy(branchName string, entities []string, policy model.TrackPolicy) error {
+	if c.facade.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("unit selection policies on this controller")
+	}
+	return errors.Trace(c.trackBranch(argForTrackPolicy(branchName, policy), entities))
+}
+
+// TrackBranchProgressively sets the units of the input applications
+// selected by the input progression's policy to track changes made under
+// the input branch name, and increases the percentage of units tracking
+// the branch in steps, for as long as the tracking units are healthy.
+func (c *Client) TrackBranchProgressively(branchName string, entities []string, progression model.BranchProgression) error {
+	if c.facade.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("progressive tracking on this controller")
+	}
+	arg := argForTrackPolicy(branchName, progression.Policy)
+	arg.Progression = &params.BranchProgression{
+		StepPercent: progression.StepPercent,
+		Interval:    progression.Interval,
+	}
+	return errors.Trace(c.trackBranch(arg, entities))
+}
+
+func argForTrackPolicy(branchName string, policy model.TrackPolicy) params.BranchTrackArg {
+	return params.BranchTrackArg{
+		BranchName:  branchName,
+		NumUnits:    policy.NumUnits,
+		Percent:     policy.Percent,
+		PerZone:     policy.PerZone,
+		Annotations: policy.Annotations,
+		Constraints: policy.Constraints.String(),
+	}
+}
+
+func (c *Client) trackBranch(arg params.BranchTrackArg, entities []string) error {
 	if len(entities) == 0 {
 		return errors.New("no units or applications specified")
 	}
@@ -119,6 +195,7 @@ func (c *Client) TrackBranch(branchName string, entities []string, numUnits int)
 			return errors.Errorf("%q is not an application or a unit", entity)
 		}
 	}
+	var result params.ErrorResults
 	err := c.facade.FacadeCall("TrackBranch", arg, &result)
 	if err != nil {
 		return errors.Trace(err)
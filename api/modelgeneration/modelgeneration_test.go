@@ -7,12 +7,14 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api/base/mocks"
 	"github.com/juju/juju/api/modelgeneration"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/model"
 )
 
@@ -98,6 +100,66 @@ func (s *modelGenerationSuite) TestTrackBranchError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `"machine-3" is not an application or a unit`)
 }
 
+func (s *modelGenerationSuite) TestTrackBranchWithPolicy(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultsSource := params.ErrorResults{Results: []params.ErrorResult{{Error: nil}}}
+	arg := params.BranchTrackArg{
+		BranchName:  s.branchName,
+		Entities:    []params.Entity{{Tag: "application-mysql"}},
+		Percent:     10,
+		PerZone:     true,
+		Annotations: map[string]string{"canary": "true"},
+		Constraints: "arch=arm64",
+	}
+	s.fCaller.EXPECT().BestAPIVersion().Return(6)
+	s.fCaller.EXPECT().FacadeCall("TrackBranch", arg, gomock.Any()).SetArg(2, resultsSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.TrackBranchWithPolicy(s.branchName, []string{"mysql"}, model.TrackPolicy{
+		Percent:     10,
+		PerZone:     true,
+		Annotations: map[string]string{"canary": "true"},
+		Constraints: constraints.MustParse("arch=arm64"),
+	})
+	c.Assert(err, gc.IsNil)
+}
+
+func (s *modelGenerationSuite) TestTrackBranchWithPolicyNotSupported(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	s.fCaller.EXPECT().BestAPIVersion().Return(5)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.TrackBranchWithPolicy(s.branchName, []string{"mysql"}, model.TrackPolicy{Percent: 10})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *modelGenerationSuite) TestTrackBranchProgressively(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultsSource := params.ErrorResults{Results: []params.ErrorResult{{Error: nil}}}
+	arg := params.BranchTrackArg{
+		BranchName: s.branchName,
+		Entities:   []params.Entity{{Tag: "application-mysql"}},
+		Percent:    10,
+		Progression: &params.BranchProgression{
+			StepPercent: 20,
+			Interval:    time.Hour,
+		},
+	}
+	s.fCaller.EXPECT().BestAPIVersion().Return(6)
+	s.fCaller.EXPECT().FacadeCall("TrackBranch", arg, gomock.Any()).SetArg(2, resultsSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.TrackBranchProgressively(s.branchName, []string{"mysql"}, model.BranchProgression{
+		Policy:      model.TrackPolicy{Percent: 10},
+		StepPercent: 20,
+		Interval:    time.Hour,
+	})
+	c.Assert(err, gc.IsNil)
+}
+
 func (s *modelGenerationSuite) TestCommitBranch(c *gc.C) {
 	defer s.setUpMocks(c).Finish()
 
@@ -111,6 +173,38 @@ func (s *modelGenerationSuite) TestCommitBranch(c *gc.C) {
 	c.Check(newGenID, gc.Equals, 2)
 }
 
+func (s *modelGenerationSuite) TestRevertCommit(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultSource := params.RevertCommitResult{
+		GenerationId: 4,
+		Conflicts: []params.RevertConflict{
+			{ApplicationName: "redis", Key: "port", Reason: "changed by commit 3"},
+		},
+	}
+	arg := params.RevertCommitArg{GenerationId: 2, BranchName: s.branchName, Commit: true}
+	s.fCaller.EXPECT().BestAPIVersion().Return(5)
+	s.fCaller.EXPECT().FacadeCall("RevertCommit", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	newGenID, conflicts, err := api.RevertCommit(2, s.branchName, true)
+	c.Assert(err, gc.IsNil)
+	c.Check(newGenID, gc.Equals, 4)
+	c.Check(conflicts, gc.DeepEquals, []model.RevertConflict{
+		{Application: "redis", Key: "port", Reason: "changed by commit 3"},
+	})
+}
+
+func (s *modelGenerationSuite) TestRevertCommitNotSupported(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	s.fCaller.EXPECT().BestAPIVersion().Return(4)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	_, _, err := api.RevertCommit(2, s.branchName, false)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *modelGenerationSuite) TestHasActiveBranch(c *gc.C) {
 	defer s.setUpMocks(c).Finish()
 
@@ -48,6 +48,29 @@ func (c *Client) CreateModel(
 	name, owner, cloud, cloudRegion string,
 	cloudCredential names.CloudCredentialTag,
 	config map[string]interface{},
+) (base.ModelInfo, error) {
+	return c.createModel("", name, owner, cloud, cloudRegion, cloudCredential, config)
+}
+
+// CreateModelFromTemplate creates a new model from the named model
+// template on the controller. The config specified takes precedence
+// over that of the template. Any bundle held by the template is not
+// deployed; that is left to the caller.
+func (c *Client) CreateModelFromTemplate(
+	template, name, owner, cloud, cloudRegion string,
+	cloudCredential names.CloudCredentialTag,
+	config map[string]interface{},
+) (base.ModelInfo, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 10 {
+		return base.ModelInfo{}, errors.NotImplementedf("CreateModelFromTemplate in version %v", bestVer)
+	}
+	return c.createModel(template, name, owner, cloud, cloudRegion, cloudCredential, config)
+}
+
+func (c *Client) createModel(
+	template, name, owner, cloud, cloudRegion string,
+	cloudCredential names.CloudCredentialTag,
+	config map[string]interface{},
 ) (base.ModelInfo, error) {
 	var result base.ModelInfo
 	if !names.IsValidUser(owner) {
@@ -71,6 +94,7 @@ func (c *Client) CreateModel(
 		CloudTag:           cloudTag,
 		CloudRegion:        cloudRegion,
 		CloudCredentialTag: cloudCredentialTag,
+		Template:           template,
 	}
 	var modelInfo params.ModelInfo
 	err := c.facade.FacadeCall("CreateModel", createArgs, &modelInfo)
@@ -236,6 +260,7 @@ func (c *Client) ListModelSummaries(user string, all bool) ([]base.UserModelSumm
 			UserLastConnection: summary.UserLastConnection,
 			Counts:             make([]base.EntityCount, len(summary.Counts)),
 			AgentVersion:       summary.AgentVersion,
+			Expiry:             summary.Expiry,
 		}
 		for pos, count := range summary.Counts {
 			summaries[i].Counts[pos] = base.EntityCount{string(count.Entity), count.Count}
@@ -324,6 +349,34 @@ func (c *Client) DumpModel(model names.ModelTag, simplified bool) (map[string]in
 		return c.dumpModelV2(model)
 	}
 
+	serialized, err := c.dumpModel(model, simplified)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Parse back into a map.
+	var asMap map[string]interface{}
+	err = yaml.Unmarshal([]byte(serialized), &asMap)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return asMap, nil
+}
+
+// ExportModel returns the full serialized description of the model, in
+// the format used by model migrations.
+func (c *Client) ExportModel(model names.ModelTag) ([]byte, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return nil, errors.NotSupportedf("exporting models on this controller")
+	}
+	serialized, err := c.dumpModel(model, false)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []byte(serialized), nil
+}
+
+func (c *Client) dumpModel(model names.ModelTag, simplified bool) (string, error) {
 	var results params.StringResults
 	entities := params.DumpModelRequest{
 		Entities:   []params.Entity{{Tag: model.String()}},
@@ -332,23 +385,16 @@ func (c *Client) DumpModel(model names.ModelTag, simplified bool) (map[string]in
 
 	err := c.facade.FacadeCall("DumpModels", entities, &results)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return "", errors.Trace(err)
 	}
 	if count := len(results.Results); count != 1 {
-		return nil, errors.Errorf("unexpected result count: %d", count)
+		return "", errors.Errorf("unexpected result count: %d", count)
 	}
 	result := results.Results[0]
 	if result.Error != nil {
-		return nil, result.Error
-	}
-	// Parse back into a map.
-	var asMap map[string]interface{}
-	err = yaml.Unmarshal([]byte(result.Result), &asMap)
-	if err != nil {
-		return nil, errors.Trace(err)
+		return "", result.Error
 	}
-
-	return asMap, nil
+	return result.Result, nil
 }
 
 func (c *Client) dumpModelV2(model names.ModelTag) (map[string]interface{}, error) {
@@ -603,3 +649,76 @@ func (c *Client) ChangeModelCredential(model names.ModelTag, credential names.Cl
 	}
 	return out.OneError()
 }
+
+// SetModelQuota sets the resource limits of the given model. Limits of
+// zero remove the corresponding quota.
+func (c *Client) SetModelQuota(model names.ModelTag, limits params.QuotaResources) error {
+	return c.setQuota("SetModelQuota", model, limits)
+}
+
+// SetUserQuota sets the resource limits shared by all the models owned
+// by the given user. Limits of zero remove the corresponding quota.
+func (c *Client) SetUserQuota(user names.UserTag, limits params.QuotaResources) error {
+	return c.setQuota("SetUserQuota", user, limits)
+}
+
+func (c *Client) setQuota(method string, tag names.Tag, limits params.QuotaResources) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 9 {
+		return errors.NotImplementedf("%s in version %v", method, bestVer)
+	}
+
+	var out params.ErrorResults
+	in := params.SetQuotaArgs{
+		Args: []params.SetQuotaArg{{Tag: tag.String(), Limits: limits}},
+	}
+	err := c.facade.FacadeCall("SetQuotas", in, &out)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return out.OneError()
+}
+
+// AddModelTemplate stores a new model template on the controller.
+func (c *Client) AddModelTemplate(template params.ModelTemplate) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 10 {
+		return errors.NotImplementedf("AddModelTemplate in version %v", bestVer)
+	}
+
+	var out params.ErrorResults
+	in := params.ModelTemplates{Templates: []params.ModelTemplate{template}}
+	err := c.facade.FacadeCall("AddModelTemplates", in, &out)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return out.OneError()
+}
+
+// ModelTemplates returns all of the model templates on the controller.
+func (c *Client) ModelTemplates() ([]params.ModelTemplate, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 10 {
+		return nil, errors.NotImplementedf("ModelTemplates in version %v", bestVer)
+	}
+
+	var out params.ModelTemplates
+	err := c.facade.FacadeCall("ListModelTemplates", nil, &out)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Templates, nil
+}
+
+// RemoveModelTemplate removes the named model template from the
+// controller.
+func (c *Client) RemoveModelTemplate(name string) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 10 {
+		return errors.NotImplementedf("RemoveModelTemplate in version %v", bestVer)
+	}
+
+	var out params.ErrorResults
+	in := params.ModelTemplateNames{Names: []string{name}}
+	err := c.facade.FacadeCall("RemoveModelTemplates", in, &out)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return out.OneError()
+}
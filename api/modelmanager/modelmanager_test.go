@@ -711,6 +711,191 @@ func (s *modelmanagerSuite) TestChangeModelCredentialV4(c *gc.C) {
 	c.Assert(called, jc.IsFalse)
 }
 
+func (s *modelmanagerSuite) TestSetModelQuota(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "SetQuotas")
+			c.Check(arg, jc.DeepEquals, params.SetQuotaArgs{
+				Args: []params.SetQuotaArg{{
+					Tag:    coretesting.ModelTag.String(),
+					Limits: params.QuotaResources{Machines: 3, StorageMiB: 1024},
+				}},
+			})
+			c.Check(result, gc.FitsTypeOf, &params.ErrorResults{})
+			called = true
+			out := result.(*params.ErrorResults)
+			out.Results = []params.ErrorResult{{}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	err := client.SetModelQuota(coretesting.ModelTag, params.QuotaResources{Machines: 3, StorageMiB: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelmanagerSuite) TestSetUserQuota(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(request, gc.Equals, "SetQuotas")
+			c.Check(arg, jc.DeepEquals, params.SetQuotaArgs{
+				Args: []params.SetQuotaArg{{
+					Tag:    "user-bob",
+					Limits: params.QuotaResources{Units: 10},
+				}},
+			})
+			called = true
+			out := result.(*params.ErrorResults)
+			out.Results = []params.ErrorResult{{Error: apiservererrors.ServerError(errors.New("boom"))}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	err := client.SetUserQuota(names.NewUserTag("bob"), params.QuotaResources{Units: 10})
+	c.Assert(err, gc.ErrorMatches, `boom`)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelmanagerSuite) TestSetQuotaV8(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	err := client.SetModelQuota(coretesting.ModelTag, params.QuotaResources{Machines: 1})
+	c.Assert(err, gc.ErrorMatches, `SetModelQuota in version 8 not implemented`)
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *modelmanagerSuite) TestCreateModelFromTemplate(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(request, gc.Equals, "CreateModel")
+			c.Check(arg, jc.DeepEquals, params.ModelCreateArgs{
+				Name:     "new-model",
+				OwnerTag: "user-bob",
+				Config:   map[string]interface{}{"abc": 123},
+				Template: "team",
+			})
+			called = true
+			out := result.(*params.ModelInfo)
+			out.Name = "new-model"
+			out.Type = "iaas"
+			out.CloudTag = "cloud-nimbus"
+			out.OwnerTag = "user-bob"
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	newModel, err := client.CreateModelFromTemplate(
+		"team", "new-model", "bob", "", "", names.CloudCredentialTag{},
+		map[string]interface{}{"abc": 123},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(newModel.Name, gc.Equals, "new-model")
+}
+
+func (s *modelmanagerSuite) TestAddModelTemplate(c *gc.C) {
+	template := params.ModelTemplate{
+		Name:   "team",
+		Config: map[string]interface{}{"abc": 123},
+		Users:  []params.ModelTemplateUser{{UserTag: "user-bob", Access: "read"}},
+	}
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(request, gc.Equals, "AddModelTemplates")
+			c.Check(arg, jc.DeepEquals, params.ModelTemplates{
+				Templates: []params.ModelTemplate{template},
+			})
+			called = true
+			out := result.(*params.ErrorResults)
+			out.Results = []params.ErrorResult{{Error: apiservererrors.ServerError(errors.AlreadyExistsf(`model template "team"`))}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	err := client.AddModelTemplate(template)
+	c.Assert(err, gc.ErrorMatches, `model template "team" already exists`)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelmanagerSuite) TestModelTemplates(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(request, gc.Equals, "ListModelTemplates")
+			c.Check(arg, gc.IsNil)
+			out := result.(*params.ModelTemplates)
+			out.Templates = []params.ModelTemplate{{Name: "team"}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	templates, err := client.ModelTemplates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(templates, jc.DeepEquals, []params.ModelTemplate{{Name: "team"}})
+}
+
+func (s *modelmanagerSuite) TestRemoveModelTemplate(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(request, gc.Equals, "RemoveModelTemplates")
+			c.Check(arg, jc.DeepEquals, params.ModelTemplateNames{Names: []string{"team"}})
+			called = true
+			out := result.(*params.ErrorResults)
+			out.Results = []params.ErrorResult{{}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	err := client.RemoveModelTemplate("team")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelmanagerSuite) TestModelTemplatesV9(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.ModelTemplates()
+	c.Assert(err, gc.ErrorMatches, `ModelTemplates in version 9 not implemented`)
+	_, err = client.CreateModelFromTemplate("team", "new-model", "bob", "", "", names.CloudCredentialTag{}, nil)
+	c.Assert(err, gc.ErrorMatches, `CreateModelFromTemplate in version 9 not implemented`)
+	c.Assert(called, jc.IsFalse)
+}
+
 type dumpModelSuite struct {
 	coretesting.BaseSuite
 }
@@ -814,6 +999,45 @@ func (s *dumpModelSuite) TestDumpModelErrorV2(c *gc.C) {
 	c.Assert(out, gc.IsNil)
 }
 
+func (s *dumpModelSuite) TestExportModel(c *gc.C) {
+	results := params.StringResults{Results: []params.StringResult{{
+		Result: "model-uuid: some-uuid\n",
+	}}}
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, args, result interface{}) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(request, gc.Equals, "DumpModels")
+				c.Assert(args, gc.DeepEquals, params.DumpModelRequest{
+					Entities: []params.Entity{{coretesting.ModelTag.String()}}})
+				res, ok := result.(*params.StringResults)
+				c.Assert(ok, jc.IsTrue)
+				*res = results
+				return nil
+			}),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	out, err := client.ExportModel(coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, "model-uuid: some-uuid\n")
+}
+
+func (s *dumpModelSuite) TestExportModelV2(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, args, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			}),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.ExportModel(coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "exporting models on this controller not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *dumpModelSuite) TestDumpModelDB(c *gc.C) {
 	expected := map[string]interface{}{
 		"models": []map[string]interface{}{{
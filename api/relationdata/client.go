@@ -0,0 +1,47 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relationdata
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the settings that applications and units
+// have published to their relations.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new relation data client.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "RelationData")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// RelationData returns the application and unit settings of each
+// relation the application endpoint participates in. If units are
+// specified, only the settings of those units are returned.
+func (c *Client) RelationData(endpoint string, units ...string) ([]params.RelationSettingsData, error) {
+	args := params.RelationDataArgs{
+		Args: []params.RelationDataArg{{
+			Endpoint: endpoint,
+			Units:    units,
+		}},
+	}
+	var results params.RelationDataResults
+	if err := c.facade.FacadeCall("RelationData", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Relations, nil
+}
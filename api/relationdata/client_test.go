@@ -0,0 +1,72 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relationdata_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/relationdata"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type relationDataSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&relationDataSuite{})
+
+func (s *relationDataSuite) TestRelationData(c *gc.C) {
+	expected := []params.RelationSettingsData{{
+		RelationId: 7,
+		Key:        "wordpress:db mysql:db",
+		ApplicationSettings: map[string]map[string]interface{}{
+			"mysql": {"database": "wordpress"},
+		},
+		UnitSettings: map[string]map[string]interface{}{
+			"mysql/0": {"host": "10.0.0.1"},
+		},
+	}}
+	called := false
+	apiCaller := testing.APICallerFunc(
+		func(objType string, version int, id, request string, arg, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "RelationData")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RelationData")
+			c.Check(arg, jc.DeepEquals, params.RelationDataArgs{
+				Args: []params.RelationDataArg{{
+					Endpoint: "mysql:db",
+					Units:    []string{"mysql/0"},
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.RelationDataResults{})
+			*(result.(*params.RelationDataResults)) = params.RelationDataResults{
+				Results: []params.RelationDataResult{{Relations: expected}},
+			}
+			return nil
+		})
+	client := relationdata.NewClient(apiCaller)
+	relations, err := client.RelationData("mysql:db", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(relations, jc.DeepEquals, expected)
+}
+
+func (s *relationDataSuite) TestRelationDataError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(
+		func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.RelationDataResults)) = params.RelationDataResults{
+				Results: []params.RelationDataResult{{
+					Error: &params.Error{Message: `application "mysql" not found`, Code: params.CodeNotFound},
+				}},
+			}
+			return nil
+		})
+	client := relationdata.NewClient(apiCaller)
+	_, err := client.RelationData("mysql:db")
+	c.Assert(err, gc.ErrorMatches, `application "mysql" not found`)
+}
@@ -66,6 +66,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelgeneration"
 	"github.com/juju/juju/apiserver/facades/client/modelmanager" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/payloads"
+	"github.com/juju/juju/apiserver/facades/client/relationdata"
 	"github.com/juju/juju/apiserver/facades/client/resources"
 	"github.com/juju/juju/apiserver/facades/client/spaces"    // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/sshclient" // ModelUser Write
@@ -94,6 +95,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/metricsmanager"
 	"github.com/juju/juju/apiserver/facades/controller/migrationmaster"
 	"github.com/juju/juju/apiserver/facades/controller/migrationtarget"
+	"github.com/juju/juju/apiserver/facades/controller/modelexpiry"
 	"github.com/juju/juju/apiserver/facades/controller/modelupgrader"
 	"github.com/juju/juju/apiserver/facades/controller/remoterelations"
 	"github.com/juju/juju/apiserver/facades/controller/resumer"
@@ -139,7 +141,8 @@ func AllFacades() *facade.Registry {
 	reg("ActionPruner", 1, actionpruner.NewAPI)
 	reg("Agent", 2, agent.NewAgentAPIV2)
 	reg("AgentTools", 1, agenttools.NewFacade)
-	reg("Annotations", 2, annotations.NewAPI)
+	reg("Annotations", 2, annotations.NewAPIV2)
+	reg("Annotations", 3, annotations.NewAPI) // Adds Select.
 
 	// Application facade versions 1-4 share NewFacadeV4 as
 	// the newer methodology for versioning wasn't started with
@@ -156,13 +159,19 @@ func AllFacades() *facade.Registry {
 	reg("Application", 10, application.NewFacadeV10) // --force and --no-wait parameters
 	reg("Application", 11, application.NewFacadeV11) // Get call returns the endpoint bindings
 	reg("Application", 12, application.NewFacadeV12) // Adds UnitsInfo()
+	reg("Application", 13, application.NewFacadeV13) // SetCharm and SetConstraints stage changes in branches
+	reg("Application", 14, application.NewFacadeV14) // Adds ValidateApplicationsConfig
+	reg("Application", 15, application.NewFacadeV15) // Adds ApplicationConfigHistory and RevertApplicationConfig
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
 	reg("Backups", 1, backups.NewFacade)
 	reg("Backups", 2, backups.NewFacadeV2)
-	reg("Block", 2, block.NewAPI)
+	reg("Backups", 3, backups.NewFacadeV3)
+	reg("Backups", 4, backups.NewFacadeV4)
+	reg("Block", 2, block.NewAPIV2)
+	reg("Block", 3, block.NewAPI) // Adds SwitchEntityBlockOn and SwitchEntityBlockOff.
 	reg("Bundle", 1, bundle.NewFacadeV1)
 	reg("Bundle", 2, bundle.NewFacadeV2)
 	reg("Bundle", 3, bundle.NewFacadeV3)
@@ -172,7 +181,8 @@ func AllFacades() *facade.Registry {
 	reg("Charms", 2, charms.NewFacade)
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
 	reg("Client", 1, client.NewFacadeV1)
-	reg("Client", 2, client.NewFacade)
+	reg("Client", 2, client.NewFacadeV2)
+	reg("Client", 3, client.NewFacade) // Adds selector to FullStatus.
 	reg("Cloud", 1, cloud.NewFacadeV1)
 	reg("Cloud", 2, cloud.NewFacadeV2) // adds AddCloud, AddCredentials, CredentialContents, RemoveClouds
 	reg("Cloud", 3, cloud.NewFacadeV3) // changes signature of UpdateCredentials, adds ModifyCloudAccess
@@ -199,6 +209,8 @@ func AllFacades() *facade.Registry {
 	reg("Controller", 7, controller.NewControllerAPIv7)
 	reg("Controller", 8, controller.NewControllerAPIv8)
 	reg("Controller", 9, controller.NewControllerAPIv9)
+	reg("Controller", 10, controller.NewControllerAPIv10)
+	reg("Controller", 11, controller.NewControllerAPIv11)
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPIV1)
 	reg("CrossModelRelations", 2, crossmodelrelations.NewStateCrossModelRelationsAPI) // Adds WatchRelationChanges, removes WatchRelationUnits
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
@@ -257,22 +269,32 @@ func AllFacades() *facade.Registry {
 	reg("MigrationFlag", 1, migrationflag.NewFacade)
 	reg("MigrationMaster", 1, migrationmaster.NewMigrationMasterFacade)
 	reg("MigrationMaster", 2, migrationmaster.NewMigrationMasterFacadeV2)
+	reg("MigrationMaster", 3, migrationmaster.NewMigrationMasterFacadeV3)
 	reg("MigrationMinion", 1, migrationminion.NewFacade)
 	reg("MigrationTarget", 1, migrationtarget.NewFacade)
+	reg("MigrationTarget", 2, migrationtarget.NewFacadeV2)
+	reg("MigrationTarget", 3, migrationtarget.NewFacadeV3)
 
 	reg("ModelConfig", 1, modelconfig.NewFacadeV1)
 	reg("ModelConfig", 2, modelconfig.NewFacadeV2)
+	reg("ModelConfig", 3, modelconfig.NewFacadeV3) // Adds ValidateModelConfig.
+	reg("ModelConfig", 4, modelconfig.NewFacadeV4) // Adds ModelConfigHistory and RevertModelConfig.
+	reg("ModelExpiry", 1, modelexpiry.NewFacade)
 	reg("ModelGeneration", 1, modelgeneration.NewModelGenerationFacade)
 	reg("ModelGeneration", 2, modelgeneration.NewModelGenerationFacadeV2)
 	reg("ModelGeneration", 3, modelgeneration.NewModelGenerationFacadeV3)
 	reg("ModelGeneration", 4, modelgeneration.NewModelGenerationFacadeV4)
+	reg("ModelGeneration", 5, modelgeneration.NewModelGenerationFacadeV5) // adds RevertCommit
+	reg("ModelGeneration", 6, modelgeneration.NewModelGenerationFacadeV6) // adds unit selection policies and progressive tracking
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
 	reg("ModelManager", 3, modelmanager.NewFacadeV3)
 	reg("ModelManager", 4, modelmanager.NewFacadeV4)
-	reg("ModelManager", 5, modelmanager.NewFacadeV5) // Adds ChangeModelCredential
-	reg("ModelManager", 6, modelmanager.NewFacadeV6) // Adds cloud specific default config
-	reg("ModelManager", 7, modelmanager.NewFacadeV7) // DestroyModels gains 'force' and max-wait' parameters.
-	reg("ModelManager", 8, modelmanager.NewFacadeV8) // ModelInfo gains credential validity in return.
+	reg("ModelManager", 5, modelmanager.NewFacadeV5)   // Adds ChangeModelCredential
+	reg("ModelManager", 6, modelmanager.NewFacadeV6)   // Adds cloud specific default config
+	reg("ModelManager", 7, modelmanager.NewFacadeV7)   // DestroyModels gains 'force' and max-wait' parameters.
+	reg("ModelManager", 8, modelmanager.NewFacadeV8)   // ModelInfo gains credential validity in return.
+	reg("ModelManager", 9, modelmanager.NewFacadeV9)   // Adds SetQuotas and quota usage in ModelInfo
+	reg("ModelManager", 10, modelmanager.NewFacadeV10) // Adds model templates
 	reg("ModelUpgrader", 1, modelupgrader.NewStateFacade)
 
 	reg("Payloads", 1, payloads.NewFacade)
@@ -296,6 +318,7 @@ func AllFacades() *facade.Registry {
 	reg("ProxyUpdater", 1, proxyupdater.NewFacadeV1)
 	reg("ProxyUpdater", 2, proxyupdater.NewFacadeV2)
 	reg("Reboot", 2, reboot.NewRebootAPI)
+	reg("RelationData", 1, relationdata.NewFacade)
 	reg("RemoteRelations", 1, remoterelations.NewAPIv1)
 	reg("RemoteRelations", 2, remoterelations.NewAPI) // Adds UpdateControllersForModels and WatchLocalRelationChanges.
 
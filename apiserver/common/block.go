@@ -5,8 +5,10 @@ package common
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 
 	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/state"
 )
 
@@ -14,6 +16,12 @@ type BlockGetter interface {
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
 
+// EntityBlockGetter is implemented by block getters that can also
+// report blocks on individual applications, machines and relations.
+type EntityBlockGetter interface {
+	GetEntityBlock(tag names.Tag) (state.Block, bool, error)
+}
+
 // BlockChecker checks for current blocks if any.
 type BlockChecker struct {
 	getter BlockGetter
@@ -55,6 +63,35 @@ func (c *BlockChecker) DestroyAllowed() error {
 	return c.checkBlock(state.ChangeBlock)
 }
 
+// EntityOperationAllowed checks that the given category of operation may
+// be run on each of the given applications, machines and relations. Model
+// wide blocks are not considered here; callers are expected to have
+// checked them with ChangeAllowed or RemoveAllowed first.
+func (c *BlockChecker) EntityOperationAllowed(op model.BlockOperation, tags ...names.Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	getter, ok := c.getter.(EntityBlockGetter)
+	if !ok {
+		return errors.NotSupportedf("checking entity blocks")
+	}
+	for _, tag := range tags {
+		aBlock, isEnabled, err := getter.GetEntityBlock(tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !isEnabled {
+			continue
+		}
+		for _, blocked := range aBlock.Operations() {
+			if blocked == op {
+				return apiservererrors.EntityOperationBlockedError(tag, op, aBlock.Message())
+			}
+		}
+	}
+	return nil
+}
+
 // checkBlock checks if specified operation must be blocked.
 // If it does, the method throws specific error that can be examined
 // to stop operation execution.
@@ -11,14 +11,16 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
 
 type mockBlock struct {
 	state.Block
-	t state.BlockType
-	m string
+	t   state.BlockType
+	m   string
+	ops []model.BlockOperation
 }
 
 func (m mockBlock) Id() string { return "" }
@@ -31,6 +33,8 @@ func (m mockBlock) Message() string { return m.m }
 
 func (m mockBlock) ModelUUID() string { return "" }
 
+func (m mockBlock) Operations() []model.BlockOperation { return m.ops }
+
 type blockCheckerSuite struct {
 	testing.FakeJujuXDGDataHomeSuite
 	aBlock                  state.Block
@@ -98,3 +102,72 @@ func (s *blockCheckerSuite) assertErrorBlocked(c *gc.C, blocked bool, err error,
 		c.Assert(errors.Cause(err), jc.ErrorIsNil)
 	}
 }
+
+type entityBlockCheckerSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	modelBlock   state.Block
+	entityBlocks map[names.Tag]state.Block
+
+	blockchecker *common.BlockChecker
+}
+
+var _ = gc.Suite(&entityBlockCheckerSuite{})
+
+func (s *entityBlockCheckerSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.modelBlock = nil
+	s.entityBlocks = map[names.Tag]state.Block{
+		names.NewApplicationTag("mysql"): mockBlock{
+			t:   state.ChangeBlock,
+			m:   "production database",
+			ops: []model.BlockOperation{model.BlockOperationConfig, model.BlockOperationRemove},
+		},
+	}
+	s.blockchecker = common.NewBlockChecker(s)
+}
+
+func (s *entityBlockCheckerSuite) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	if s.modelBlock != nil && s.modelBlock.Type() == t {
+		return s.modelBlock, true, nil
+	}
+	return nil, false, nil
+}
+
+func (s *entityBlockCheckerSuite) GetEntityBlock(tag names.Tag) (state.Block, bool, error) {
+	b, ok := s.entityBlocks[tag]
+	return b, ok, nil
+}
+
+func (s *entityBlockCheckerSuite) TestEntityOperationAllowed(c *gc.C) {
+	mysql := names.NewApplicationTag("mysql")
+	wordpress := names.NewApplicationTag("wordpress")
+
+	err := s.blockchecker.EntityOperationAllowed(model.BlockOperationConfig, wordpress)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.blockchecker.EntityOperationAllowed(model.BlockOperationScale, mysql)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.blockchecker.EntityOperationAllowed(model.BlockOperationConfig, wordpress, mysql)
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, `config operations on application mysql have been blocked: production database`)
+	c.Assert(err.(*params.Error).Info, jc.DeepEquals, map[string]interface{}{
+		"tag":       "application-mysql",
+		"operation": "config",
+	})
+
+	err = s.blockchecker.EntityOperationAllowed(model.BlockOperationRemove, mysql)
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue)
+}
+
+func (s *entityBlockCheckerSuite) TestEntityOperationAllowedIgnoresModelBlock(c *gc.C) {
+	s.modelBlock = mockBlock{t: state.ChangeBlock, m: "no changes"}
+
+	err := s.blockchecker.EntityOperationAllowed(model.BlockOperationScale, names.NewApplicationTag("wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *entityBlockCheckerSuite) TestEntityOperationAllowedNotSupported(c *gc.C) {
+	checker := common.NewBlockChecker(&blockCheckerSuite{aBlock: mockBlock{}})
+	err := checker.EntityOperationAllowed(model.BlockOperationConfig, names.NewApplicationTag("mysql"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/juju/collections/set"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ConfigChanges returns the keys that differ between oldAttrs and
+// newAttrs, in key order. Keys present in input whose value has a
+// different type in newAttrs are reported with the input value, so
+// that type coercions are visible to the user. Keys named in reset
+// that weren't also set are marked as reset.
+func ConfigChanges(
+	oldAttrs, newAttrs map[string]interface{}, input map[string]interface{}, reset []string,
+) []params.ConfigChange {
+	keys := set.NewStrings()
+	for k := range oldAttrs {
+		keys.Add(k)
+	}
+	for k := range newAttrs {
+		keys.Add(k)
+	}
+	resetKeys := set.NewStrings(reset...)
+
+	var changes []params.ConfigChange
+	for _, key := range keys.SortedValues() {
+		oldVal, newVal := oldAttrs[key], newAttrs[key]
+		if sameConfigValue(oldVal, newVal) {
+			continue
+		}
+		change := params.ConfigChange{
+			Key: key,
+			Old: oldVal,
+			New: newVal,
+		}
+		if raw, ok := input[key]; ok {
+			if newVal != nil && reflect.TypeOf(raw) != reflect.TypeOf(newVal) {
+				change.Input = fmt.Sprint(raw)
+			}
+		} else if resetKeys.Contains(key) {
+			change.Reset = true
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// ConfigRevisions converts config revisions recorded in state into
+// their API representation. Deleted keys are marked as reset.
+func ConfigRevisions(revisions []state.ConfigRevision) []params.ConfigRevision {
+	result := make([]params.ConfigRevision, len(revisions))
+	for i, rev := range revisions {
+		changes := make([]params.ConfigChange, len(rev.Changes))
+		for j, change := range rev.Changes {
+			changes[j] = params.ConfigChange{
+				Key:   change.Key,
+				Old:   change.OldValue,
+				New:   change.NewValue,
+				Reset: change.IsDeletion(),
+			}
+		}
+		result[i] = params.ConfigRevision{
+			Revision:  rev.Revision,
+			Scope:     string(rev.Scope),
+			Created:   rev.Created,
+			CreatedBy: rev.CreatedBy,
+			Changes:   changes,
+		}
+	}
+	return result
+}
+
+// sameConfigValue reports whether a and b hold the same value. Numbers
+// are compared by value, as their concrete type may change when they
+// are stored.
+func sameConfigValue(a, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	if !isNumber(a) || !isNumber(b) {
+		return false
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func isNumber(v interface{}) bool {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	}
+	return false
+}
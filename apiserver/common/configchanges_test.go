@@ -0,0 +1,84 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/settings"
+	"github.com/juju/juju/state"
+)
+
+type configChangesSuite struct{}
+
+var _ = gc.Suite(&configChangesSuite{})
+
+func (*configChangesSuite) TestConfigChanges(c *gc.C) {
+	oldAttrs := map[string]interface{}{
+		"name":     "foo",
+		"count":    1,
+		"debug":    true,
+		"same":     "value",
+		"obsolete": "gone",
+	}
+	newAttrs := map[string]interface{}{
+		"name":  "bar",
+		"count": int64(5),
+		"debug": false,
+		"same":  "value",
+		"added": "new",
+	}
+	input := map[string]interface{}{
+		"name":  "bar",
+		"count": "5",
+		"same":  "value",
+		"added": "new",
+	}
+	changes := common.ConfigChanges(oldAttrs, newAttrs, input, []string{"debug", "obsolete"})
+	c.Assert(changes, jc.DeepEquals, []params.ConfigChange{
+		{Key: "added", New: "new"},
+		{Key: "count", Old: 1, New: int64(5), Input: "5"},
+		{Key: "debug", Old: true, New: false, Reset: true},
+		{Key: "name", Old: "foo", New: "bar"},
+		{Key: "obsolete", Old: "gone", Reset: true},
+	})
+}
+
+func (*configChangesSuite) TestConfigChangesNone(c *gc.C) {
+	oldAttrs := map[string]interface{}{"name": "foo", "count": 5}
+	newAttrs := map[string]interface{}{"name": "foo", "count": int64(5)}
+	changes := common.ConfigChanges(oldAttrs, newAttrs, map[string]interface{}{"name": "foo", "count": "5"}, nil)
+	c.Assert(changes, gc.HasLen, 0)
+}
+
+func (*configChangesSuite) TestConfigRevisions(c *gc.C) {
+	created := time.Date(2020, 9, 1, 3, 0, 0, 0, time.UTC)
+	revisions := common.ConfigRevisions([]state.ConfigRevision{{
+		Revision:  2,
+		Scope:     state.CharmConfigScope,
+		Created:   created,
+		CreatedBy: "bob",
+		Changes: settings.ItemChanges{
+			settings.MakeAddition("added", "new"),
+			settings.MakeDeletion("deleted", "old"),
+			settings.MakeModification("modified", 1, 2),
+		},
+	}})
+	c.Assert(revisions, jc.DeepEquals, []params.ConfigRevision{{
+		Revision:  2,
+		Scope:     "charm",
+		Created:   created,
+		CreatedBy: "bob",
+		Changes: []params.ConfigChange{
+			{Key: "added", New: "new"},
+			{Key: "deleted", Old: "old", Reset: true},
+			{Key: "modified", Old: 1, New: 2},
+		},
+	}})
+}
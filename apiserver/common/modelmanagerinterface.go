@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
@@ -80,6 +81,13 @@ type ModelManagerBackend interface {
 	DumpAll() (map[string]interface{}, error)
 	Close() error
 	HAPrimaryMachine() (names.MachineTag, error)
+	UserQuota(names.UserTag) (quota.Resources, error)
+	UserQuotaUsage(names.UserTag) (quota.Resources, error)
+	SetUserQuota(names.UserTag, quota.Resources) error
+	AddModelTemplate(state.ModelTemplate) error
+	ModelTemplate(string) (state.ModelTemplate, error)
+	ModelTemplates() ([]state.ModelTemplate, error)
+	RemoveModelTemplate(string) error
 
 	// Methods required by the metricsender package.
 	MetricsManager() (*state.MetricsManager, error)
@@ -117,6 +125,10 @@ type Model interface {
 	AddUser(state.UserAccessSpec) (permission.UserAccess, error)
 	AutoConfigureContainerNetworking(environ environs.BootstrapEnviron) error
 	SetCloudCredential(tag names.CloudCredentialTag) (bool, error)
+	Quota() (quota.Resources, error)
+	QuotaUsage() (quota.Resources, error)
+	SetQuota(quota.Resources) error
+	Expiry() (time.Time, bool)
 }
 
 var _ ModelManagerBackend = (*modelManagerStateShim)(nil)
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	stateerrors "github.com/juju/juju/state/errors"
 )
@@ -150,6 +151,25 @@ func OperationBlockedError(msg string) error {
 	}
 }
 
+// EntityOperationBlockedError returns an error which signifies that the
+// given category of operation has been blocked on an application, machine
+// or relation. The entity and operation are included in the error info
+// so that clients can explain how to remove the block.
+func EntityOperationBlockedError(tag names.Tag, op model.BlockOperation, msg string) error {
+	message := fmt.Sprintf("%s operations on %s have been blocked", op, names.ReadableString(tag))
+	if msg != "" {
+		message += ": " + msg
+	}
+	return &params.Error{
+		Message: message,
+		Code:    params.CodeOperationBlocked,
+		Info: params.OperationBlockedErrorInfo{
+			Tag:       tag.String(),
+			Operation: string(op),
+		}.AsMap(),
+	}
+}
+
 var singletonErrorCodes = map[error]string{
 	stateerrors.ErrCannotEnterScopeYet: params.CodeCannotEnterScopeYet,
 	stateerrors.ErrCannotEnterScope:    params.CodeCannotEnterScope,
@@ -317,6 +337,9 @@ func ServerError(err error) *params.Error {
 		code = params.CodeQuotaLimitExceeded
 	default:
 		code = params.ErrCode(err)
+		if perr, ok := err.(*params.Error); ok {
+			info = perr.Info
+		}
 	}
 
 	return &params.Error{
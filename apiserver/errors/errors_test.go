@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	stateerrors "github.com/juju/juju/state/errors"
 	"github.com/juju/juju/testing"
@@ -301,6 +302,20 @@ func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 	}
 }
 
+func (s *errorsSuite) TestEntityOperationBlockedError(c *gc.C) {
+	err := apiservererrors.EntityOperationBlockedError(
+		names.NewApplicationTag("mysql"), model.BlockOperationConfig, "production database")
+	c.Assert(err, gc.ErrorMatches, "config operations on application mysql have been blocked: production database")
+
+	// The entity and operation survive the trip through ServerError.
+	serverErr := apiservererrors.ServerError(errors.Trace(err))
+	c.Assert(serverErr, jc.Satisfies, params.IsCodeOperationBlocked)
+	c.Assert(serverErr.Info, jc.DeepEquals, map[string]interface{}{
+		"tag":       "application-mysql",
+		"operation": "config",
+	})
+}
+
 func (s *errorsSuite) TestUnknownModel(c *gc.C) {
 	err := apiservererrors.UnknownModelError("dead-beef")
 	c.Check(err, gc.ErrorMatches, `unknown model: "dead-beef"`)
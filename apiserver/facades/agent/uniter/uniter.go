@@ -860,10 +860,21 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 			var unitOrApplication state.Entity
 			unitOrApplication, err = u.st.FindEntity(tag)
 			if err == nil {
-				charmURLer := unitOrApplication.(interface {
-					CharmURL() (*charm.URL, bool)
-				})
-				curl, ok := charmURLer.CharmURL()
+				var (
+					curl *charm.URL
+					ok   bool
+				)
+				// A unit asking for its application's charm gets the
+				// charm staged under the branch it tracks, if any.
+				app, isApp := unitOrApplication.(*state.Application)
+				if authTag, isUnit := u.auth.GetAuthTag().(names.UnitTag); isApp && isUnit {
+					curl, ok, err = app.CharmURLForUnit(authTag.Id())
+				} else {
+					charmURLer := unitOrApplication.(interface {
+						CharmURL() (*charm.URL, bool)
+					})
+					curl, ok = charmURLer.CharmURL()
+				}
 				if curl != nil {
 					result.Results[i].Result = curl.String()
 					result.Results[i].Ok = ok
@@ -6,10 +6,12 @@ package annotations
 import (
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
+	"github.com/juju/naturalsort"
 
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/state"
 )
@@ -22,6 +24,7 @@ var getState = func(st *state.State, m *state.Model) annotationAccess {
 type Annotations interface {
 	Get(args params.Entities) params.AnnotationsGetResults
 	Set(args params.AnnotationsSet) params.ErrorResults
+	Select(args params.AnnotationsSelectArgs) params.AnnotationsSelectResults
 }
 
 // API implements the service interface and is the concrete
@@ -31,6 +34,25 @@ type API struct {
 	authorizer facade.Authorizer
 }
 
+// APIV2 implements version 2 of the Annotations facade, which
+// cannot select entities by their annotations.
+type APIV2 struct {
+	*API
+}
+
+// NewAPIV2 returns a new version 2 Annotations API facade.
+func NewAPIV2(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV2, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV2{api}, nil
+}
+
 // NewAPI returns a new charm annotator API facade.
 func NewAPI(
 	st *state.State,
@@ -118,6 +140,79 @@ func (api *API) Set(args params.AnnotationsSet) params.ErrorResults {
 	return params.ErrorResults{Results: setErrors}
 }
 
+// Select returns the entities whose annotations match each of the given
+// selectors. Each selector is treated independently and, hence, will fail
+// or succeed independently.
+func (api *API) Select(args params.AnnotationsSelectArgs) params.AnnotationsSelectResults {
+	results := make([]params.AnnotationsSelectResult, len(args.Selectors))
+	if err := api.checkCanRead(); err != nil {
+		for i := range results {
+			results[i].Error = apiservererrors.ServerError(err)
+		}
+		return params.AnnotationsSelectResults{Results: results}
+	}
+	for i, arg := range args.Selectors {
+		tags, err := api.selectEntities(arg.Selector, arg.Kind)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		entities := make([]params.Entity, len(tags))
+		for j, tag := range tags {
+			entities[j] = params.Entity{Tag: tag}
+		}
+		results[i].Entities = entities
+	}
+	return params.AnnotationsSelectResults{Results: results}
+}
+
+// Select is not available in version 2.
+func (*APIV2) Select(_, _ struct{}) {}
+
+func (api *API) selectEntities(in, kind string) ([]string, error) {
+	selector, err := annotations.ParseSelector(in)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch kind {
+	case "", names.MachineTagKind, names.ApplicationTagKind, names.UnitTagKind:
+	default:
+		return nil, errors.NotValidf("entity kind %q", kind)
+	}
+	labelled, err := api.access.LabelledEntities(selector)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	selected := make(map[string]bool)
+	for _, tag := range labelled {
+		if kind == "" || tag.Kind() == kind {
+			selected[tag.String()] = true
+		}
+		if kind != names.UnitTagKind {
+			continue
+		}
+		// Units are also selected by the labels of their
+		// application or of the machine they are assigned to.
+		switch tag.(type) {
+		case names.ApplicationTag, names.MachineTag:
+			units, err := api.access.UnitTags(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, unit := range units {
+				selected[unit.String()] = true
+			}
+		}
+	}
+	result := make([]string, 0, len(selected))
+	for tag := range selected {
+		result = append(result, tag)
+	}
+	// Sort naturally, so that units are listed in order of their number.
+	naturalsort.Sort(result)
+	return result, nil
+}
+
 func annotateError(err error, tag, op string) *params.Error {
 	return apiservererrors.ServerError(
 		errors.Trace(
@@ -325,3 +325,58 @@ var clientAnnotationsTests = []struct {
 		err:   `.*: invalid key "invalid.key"`,
 	},
 }
+
+func (s *annotationSuite) TestSelect(c *gc.C) {
+	labelled := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobHostUnits},
+	})
+	other := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobHostUnits},
+	})
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	// wordpress/0 is selected by its machine, mysql/0 by its application.
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress, Machine: labelled})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress, Machine: other})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql, Machine: other})
+
+	annotations := map[string]string{"team": "payments"}
+	setResult := s.annotationsAPI.Set(params.AnnotationsSet{
+		Annotations: constructSetParameters(
+			[]string{labelled.Tag().String(), mysql.Tag().String()}, annotations),
+	})
+	c.Assert(setResult.Combine(), jc.ErrorIsNil)
+
+	result := s.annotationsAPI.Select(params.AnnotationsSelectArgs{
+		Selectors: []params.AnnotationsSelector{
+			{Selector: "team=payments"},
+			{Selector: "team=payments", Kind: "machine"},
+			{Selector: "team=payments", Kind: "application"},
+			{Selector: "team=payments", Kind: "unit"},
+			{Selector: "team=search"},
+			{Selector: "team=payments", Kind: "relation"},
+			{Selector: "team"},
+		},
+	})
+	c.Assert(result, jc.DeepEquals, params.AnnotationsSelectResults{
+		Results: []params.AnnotationsSelectResult{{
+			Entities: []params.Entity{{Tag: "application-mysql"}, {Tag: labelled.Tag().String()}},
+		}, {
+			Entities: []params.Entity{{Tag: labelled.Tag().String()}},
+		}, {
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		}, {
+			Entities: []params.Entity{{Tag: "unit-mysql-0"}, {Tag: "unit-wordpress-0"}},
+		}, {
+			Entities: []params.Entity{},
+		}, {
+			Error: &params.Error{Message: `entity kind "relation" not valid`},
+		}, {
+			Error: &params.Error{Message: `selector term "team": expected key=value not valid`},
+		}},
+	})
+}
@@ -4,8 +4,10 @@
 package annotations
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
+	"github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/state"
 )
 
@@ -14,6 +16,8 @@ type annotationAccess interface {
 	FindEntity(tag names.Tag) (state.Entity, error)
 	Annotations(entity state.GlobalEntity) (map[string]string, error)
 	SetAnnotations(entity state.GlobalEntity, annotations map[string]string) error
+	LabelledEntities(selector annotations.Selector) ([]names.Tag, error)
+	UnitTags(tag names.Tag) ([]names.UnitTag, error)
 }
 
 // TODO - CAAS(externalreality): After all relevant methods are moved from
@@ -29,3 +33,34 @@ type stateShim struct {
 func (s stateShim) ModelTag() names.ModelTag {
 	return s.Model.ModelTag()
 }
+
+// UnitTags returns the tags of the units of the application, or
+// assigned to the machine, with the given tag.
+func (s stateShim) UnitTags(tag names.Tag) ([]names.UnitTag, error) {
+	var units []*state.Unit
+	switch tag := tag.(type) {
+	case names.ApplicationTag:
+		app, err := s.State.Application(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if units, err = app.AllUnits(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	case names.MachineTag:
+		machine, err := s.State.Machine(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if units, err = machine.Units(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	default:
+		return nil, errors.NotSupportedf("units of %s", names.ReadableString(tag))
+	}
+	tags := make([]names.UnitTag, len(units))
+	for i, unit := range units {
+		tags[i] = unit.UnitTag()
+	}
+	return tags, nil
+}
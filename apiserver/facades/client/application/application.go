@@ -100,6 +100,24 @@ type APIv11 struct {
 // APIv12 provides the Application API facade for version 12.
 // It adds the UnitsInfo method.
 type APIv12 struct {
+	*APIv13
+}
+
+// APIv13 provides the Application API facade for version 13.
+// SetCharm and SetConstraints stage changes in the given branch.
+type APIv13 struct {
+	*APIv14
+}
+
+// APIv14 provides the Application API facade for version 14.
+// It adds the ValidateApplicationsConfig method.
+type APIv14 struct {
+	*APIv15
+}
+
+// APIv15 provides the Application API facade for version 15.
+// It adds the ApplicationConfigHistory and RevertApplicationConfig methods.
+type APIv15 struct {
 	*APIBase
 }
 
@@ -207,13 +225,37 @@ func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
 }
 
 func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV13(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv12{api}, nil
 }
 
+func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
+	api, err := NewFacadeV14(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv13{api}, nil
+}
+
+func NewFacadeV14(ctx facade.Context) (*APIv14, error) {
+	api, err := NewFacadeV15(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv14{api}, nil
+}
+
+func NewFacadeV15(ctx facade.Context) (*APIv15, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv15{api}, nil
+}
+
 type caasBrokerInterface interface {
 	ValidateStorageClass(config map[string]interface{}) error
 	Version() (*version.Number, error)
@@ -322,6 +364,22 @@ func (api *APIBase) checkCanWrite() error {
 	return api.checkPermission(api.model.ModelTag(), permission.WriteAccess)
 }
 
+// userName returns the name of the authenticated user, which
+// is recorded against the config changes they make.
+func (api *APIBase) userName() string {
+	return api.authorizer.GetAuthTag().Id()
+}
+
+// checkApplicationOperation checks that the named application does not
+// have a block on the given category of operation. Invalid names are left
+// for the subsequent application lookup to report.
+func (api *APIBase) checkApplicationOperation(appName string, op model.BlockOperation) error {
+	if !names.IsValidApplication(appName) {
+		return nil
+	}
+	return api.check.EntityOperationAllowed(op, names.NewApplicationTag(appName))
+}
+
 // SetMetricCredentials sets credentials on the application.
 func (api *APIBase) SetMetricCredentials(args params.ApplicationMetricCredentials) (params.ErrorResults, error) {
 	if err := api.checkCanWrite(); err != nil {
@@ -797,7 +855,7 @@ func checkMachinePlacement(backend Backend, args params.ApplicationDeploy) error
 // applicationSetSettingsStrings updates the settings for the given application,
 // taking the configuration from a map of strings.
 func applicationSetSettingsStrings(
-	application Application, gen string, settings map[string]string,
+	application Application, userName, gen string, settings map[string]string,
 ) error {
 	ch, _, err := application.Charm()
 	if err != nil {
@@ -808,7 +866,7 @@ func applicationSetSettingsStrings(
 	if err != nil {
 		return errors.Trace(err)
 	}
-	return application.UpdateCharmConfig(gen, changes)
+	return application.UpdateCharmConfigByUser(userName, gen, changes)
 }
 
 // parseSettingsCompatible parses setting strings in a way that is
@@ -847,6 +905,7 @@ func parseSettingsCompatible(charmConfig *charm.Config, settings map[string]stri
 type setCharmParams struct {
 	AppName               string
 	Application           Application
+	BranchName            string
 	Channel               csparams.Channel
 	ConfigSettingsStrings map[string]string
 	ConfigSettingsYAML    string
@@ -872,6 +931,14 @@ func (api *APIBase) Update(args params.ApplicationUpdate) error {
 			return errors.Trace(err)
 		}
 	}
+	if err := api.checkApplicationOperation(args.ApplicationName, model.BlockOperationConfig); err != nil {
+		return errors.Trace(err)
+	}
+	if args.CharmURL != "" {
+		if err := api.checkApplicationOperation(args.ApplicationName, model.BlockOperationRefresh); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	app, err := api.backend.Application(args.ApplicationName)
 	if err != nil {
 		return errors.Trace(err)
@@ -915,13 +982,13 @@ func (api *APIBase) Update(args params.ApplicationUpdate) error {
 	// If the config change is generational, add the app to the generation.
 	configChange := false
 	if args.SettingsYAML != "" {
-		err = applicationSetCharmConfigYAML(args.ApplicationName, app, args.Generation, args.SettingsYAML)
+		err = applicationSetCharmConfigYAML(args.ApplicationName, app, api.userName(), args.Generation, args.SettingsYAML)
 		if err != nil {
 			return errors.Annotate(err, "setting configuration from YAML")
 		}
 		configChange = true
 	} else if len(args.SettingsStrings) > 0 {
-		if err = applicationSetSettingsStrings(app, args.Generation, args.SettingsStrings); err != nil {
+		if err = applicationSetSettingsStrings(app, api.userName(), args.Generation, args.SettingsStrings); err != nil {
 			return errors.Trace(err)
 		}
 		configChange = true
@@ -1003,7 +1070,18 @@ func (api *APIBase) updateOneApplicationSeries(arg params.UpdateSeriesArg) error
 	return app.UpdateApplicationSeries(arg.Series, arg.Force)
 }
 
+// SetCharm sets the charm for a given application. Before version 13 the
+// branch in the arguments was ignored, so the charm is always set for the
+// application rather than staged in a branch.
+func (api *APIv12) SetCharm(args params.ApplicationSetCharm) error {
+	args.Generation = ""
+	return api.APIv13.SetCharm(args)
+}
+
 // SetCharm sets the charm for a given for the application.
+// If a branch other than master is given, the upgrade is staged in the
+// branch: units tracking the branch are upgraded and the others follow
+// when the branch is committed.
 func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -1014,6 +1092,9 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 			return errors.Trace(err)
 		}
 	}
+	if err := api.checkApplicationOperation(args.ApplicationName, model.BlockOperationRefresh); err != nil {
+		return errors.Trace(err)
+	}
 	oneApplication, err := api.backend.Application(args.ApplicationName)
 	if err != nil {
 		return errors.Trace(err)
@@ -1023,6 +1104,7 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 		setCharmParams{
 			AppName:               args.ApplicationName,
 			Application:           oneApplication,
+			BranchName:            args.Generation,
 			Channel:               channel,
 			ConfigSettingsStrings: args.ConfigSettings,
 			ConfigSettingsYAML:    args.ConfigSettingsYAML,
@@ -1167,7 +1249,13 @@ func (api *APIBase) applicationSetCharm(
 		StorageConstraints: stateStorageConstraints,
 		EndpointBindings:   params.EndpointBindings,
 	}
-	return params.Application.SetCharm(cfg)
+	if params.BranchName == "" || params.BranchName == model.GenerationMaster {
+		return params.Application.SetCharm(cfg)
+	}
+	if err := params.Application.SetBranchCharm(params.BranchName, cfg); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.addAppToBranch(params.BranchName, params.AppName))
 }
 
 // charmConfigFromGetYaml will parse a yaml produced by juju get and generate
@@ -1201,7 +1289,7 @@ func charmConfigFromGetYaml(yamlContents map[string]interface{}) (charm.Settings
 // applicationSetCharmConfigYAML updates the charm config for the
 // given application, taking the configuration from a YAML string.
 func applicationSetCharmConfigYAML(
-	appName string, application Application, gen string, settings string,
+	appName string, application Application, userName, gen string, settings string,
 ) error {
 	b := []byte(settings)
 	var all map[string]interface{}
@@ -1214,7 +1302,7 @@ func applicationSetCharmConfigYAML(
 		if err != nil {
 			return errors.Annotate(err, "processing YAML generated by get")
 		}
-		return errors.Annotate(application.UpdateCharmConfig(gen, changes), "updating settings with application YAML")
+		return errors.Annotate(application.UpdateCharmConfigByUser(userName, gen, changes), "updating settings with application YAML")
 	}
 
 	ch, _, err := application.Charm()
@@ -1226,7 +1314,7 @@ func applicationSetCharmConfigYAML(
 	if err != nil {
 		return errors.Annotate(err, "creating config from YAML")
 	}
-	return errors.Annotate(application.UpdateCharmConfig(gen, changes), "updating settings")
+	return errors.Annotate(application.UpdateCharmConfigByUser(userName, gen, changes), "updating settings")
 }
 
 // GetCharmURL returns the charm URL the given application is
@@ -1240,6 +1328,15 @@ func (api *APIBase) GetCharmURL(args params.ApplicationGet) (params.StringResult
 		return params.StringResult{}, errors.Trace(err)
 	}
 	charmURL, _ := oneApplication.CharmURL()
+	if args.BranchName != "" && args.BranchName != model.GenerationMaster {
+		branch, err := api.backend.Branch(args.BranchName)
+		if err != nil {
+			return params.StringResult{}, errors.Trace(err)
+		}
+		if staged, ok := branch.CharmFor(args.ApplicationName); ok {
+			charmURL = staged.URL
+		}
+	}
 	return params.StringResult{Result: charmURL.String()}, nil
 }
 
@@ -1253,6 +1350,9 @@ func (api *APIBase) Set(p params.ApplicationSet) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.checkApplicationOperation(p.ApplicationName, model.BlockOperationConfig); err != nil {
+		return errors.Trace(err)
+	}
 	app, err := api.backend.Application(p.ApplicationName)
 	if err != nil {
 		return err
@@ -1267,7 +1367,7 @@ func (api *APIBase) Set(p params.ApplicationSet) error {
 		return err
 	}
 
-	return app.UpdateCharmConfig(model.GenerationMaster, changes)
+	return app.UpdateCharmConfigByUser(api.userName(), model.GenerationMaster, changes)
 }
 
 // Unset implements the server side of Client.Unset.
@@ -1278,6 +1378,9 @@ func (api *APIBase) Unset(p params.ApplicationUnset) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.checkApplicationOperation(p.ApplicationName, model.BlockOperationConfig); err != nil {
+		return errors.Trace(err)
+	}
 	app, err := api.backend.Application(p.ApplicationName)
 	if err != nil {
 		return err
@@ -1292,7 +1395,7 @@ func (api *APIBase) Unset(p params.ApplicationUnset) error {
 	if p.BranchName == "" {
 		p.BranchName = model.GenerationMaster
 	}
-	return app.UpdateCharmConfig(p.BranchName, settings)
+	return app.UpdateCharmConfigByUser(api.userName(), p.BranchName, settings)
 }
 
 // CharmRelations implements the server side of Application.CharmRelations.
@@ -1326,6 +1429,9 @@ func (api *APIBase) Expose(args params.ApplicationExpose) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.checkApplicationOperation(args.ApplicationName, model.BlockOperationConfig); err != nil {
+		return errors.Trace(err)
+	}
 	app, err := api.backend.Application(args.ApplicationName)
 	if err != nil {
 		return errors.Trace(err)
@@ -1353,6 +1459,9 @@ func (api *APIBase) Unexpose(args params.ApplicationUnexpose) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.checkApplicationOperation(args.ApplicationName, model.BlockOperationConfig); err != nil {
+		return errors.Trace(err)
+	}
 	app, err := api.backend.Application(args.ApplicationName)
 	if err != nil {
 		return err
@@ -1383,6 +1492,9 @@ func (api *APIBase) AddUnits(args params.AddApplicationUnits) (params.AddApplica
 	if err := api.check.ChangeAllowed(); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
+	if err := api.checkApplicationOperation(args.ApplicationName, model.BlockOperationScale); err != nil {
+		return params.AddApplicationUnitsResults{}, errors.Trace(err)
+	}
 	units, err := addApplicationUnits(api.backend, api.modelType, args)
 	if err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
@@ -1521,6 +1633,9 @@ func (api *APIBase) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyU
 		if !unit.IsPrincipal() {
 			return nil, errors.Errorf("unit %q is a subordinate", name)
 		}
+		if err := api.checkApplicationOperation(unit.ApplicationName(), model.BlockOperationScale); err != nil {
+			return nil, errors.Trace(err)
+		}
 		var info params.DestroyUnitInfo
 		unitStorage, err := storagecommon.UnitStorage(api.storageAccess, unit.UnitTag())
 		if err != nil {
@@ -1623,6 +1738,9 @@ func (api *APIBase) DestroyApplication(args params.DestroyApplicationsParams) (p
 		if err != nil {
 			return nil, err
 		}
+		if err := api.check.EntityOperationAllowed(model.BlockOperationRemove, tag); err != nil {
+			return nil, errors.Trace(err)
+		}
 		var info params.DestroyApplicationInfo
 		app, err := api.backend.Application(tag.Id())
 		if err != nil {
@@ -1765,6 +1883,9 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if err := api.check.EntityOperationAllowed(model.BlockOperationScale, appTag); err != nil {
+			return nil, errors.Trace(err)
+		}
 		name := appTag.Id()
 		app, err := api.backend.Application(name)
 		if errors.IsNotFound(err) {
@@ -1845,7 +1966,16 @@ func (api *APIBase) getConstraints(entity string) (constraints.Value, error) {
 	}
 }
 
+// SetConstraints sets the constraints for a given application, ignoring
+// any branch, which is only understood from version 13.
+func (api *APIv12) SetConstraints(args params.SetConstraints) error {
+	args.BranchName = ""
+	return api.APIv13.SetConstraints(args)
+}
+
 // SetConstraints sets the constraints for a given application.
+// If a branch other than master is given, the constraints are staged in
+// the branch and set when it is committed.
 func (api *APIBase) SetConstraints(args params.SetConstraints) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -1853,11 +1983,20 @@ func (api *APIBase) SetConstraints(args params.SetConstraints) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.checkApplicationOperation(args.ApplicationName, model.BlockOperationConfig); err != nil {
+		return errors.Trace(err)
+	}
 	app, err := api.backend.Application(args.ApplicationName)
 	if err != nil {
 		return err
 	}
-	return app.SetConstraints(args.Constraints)
+	if args.BranchName == "" || args.BranchName == model.GenerationMaster {
+		return app.SetConstraints(args.Constraints)
+	}
+	if err := app.SetBranchConstraints(args.BranchName, args.Constraints); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.addAppToBranch(args.BranchName, args.ApplicationName))
 }
 
 // AddRelation adds a relation between the specified endpoints and returns the relation info.
@@ -1942,6 +2081,9 @@ func (api *APIBase) DestroyRelation(args params.DestroyRelation) (err error) {
 	if err != nil {
 		return err
 	}
+	if err := api.check.EntityOperationAllowed(model.BlockOperationRemove, rel.Tag()); err != nil {
+		return errors.Trace(err)
+	}
 	force := args.Force != nil && *args.Force
 	errs, err := rel.DestroyWithForce(force, common.MaxWait(args.MaxWait))
 	if len(errs) != 0 {
@@ -1968,6 +2110,9 @@ func (api *APIBase) SetRelationsSuspended(args params.RelationSuspendedArgs) (pa
 		if rel.Suspended() == arg.Suspended {
 			return nil
 		}
+		if err := api.check.EntityOperationAllowed(model.BlockOperationConfig, rel.Tag()); err != nil {
+			return errors.Trace(err)
+		}
 		_, err = api.backend.OfferConnectionForRelation(rel.Tag().Id())
 		if errors.IsNotFound(err) {
 			return errors.Errorf("cannot set suspend status for %q which is not associated with an offer", rel.Tag().Id())
@@ -2302,6 +2447,9 @@ func (api *APIBase) SetApplicationsConfig(args params.ApplicationConfigSetArgs)
 }
 
 func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error {
+	if err := api.checkApplicationOperation(arg.ApplicationName, model.BlockOperationConfig); err != nil {
+		return errors.Trace(err)
+	}
 	app, err := api.backend.Application(arg.ApplicationName)
 	if err != nil {
 		return errors.Trace(err)
@@ -2317,7 +2465,7 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 	}
 
 	if len(appConfigAttrs) > 0 {
-		if err := app.UpdateApplicationConfig(appConfigAttrs, nil, configSchema, defaults); err != nil {
+		if err := app.UpdateApplicationConfigByUser(api.userName(), appConfigAttrs, nil, configSchema, defaults); err != nil {
 			return errors.Annotate(err, "updating application config values")
 		}
 	}
@@ -2338,7 +2486,7 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 		if arg.Generation == "" {
 			arg.Generation = model.GenerationMaster
 		}
-		if err := app.UpdateCharmConfig(arg.Generation, charmConfigChanges); err != nil {
+		if err := app.UpdateCharmConfigByUser(api.userName(), arg.Generation, charmConfigChanges); err != nil {
 			return errors.Annotate(err, "updating application charm settings")
 		}
 		if arg.Generation != model.GenerationMaster {
@@ -2380,6 +2528,9 @@ func (api *APIBase) UnsetApplicationsConfig(args params.ApplicationConfigUnsetAr
 }
 
 func (api *APIBase) unsetApplicationConfig(arg params.ApplicationUnset) error {
+	if err := api.checkApplicationOperation(arg.ApplicationName, model.BlockOperationConfig); err != nil {
+		return errors.Trace(err)
+	}
 	app, err := api.backend.Application(arg.ApplicationName)
 	if err != nil {
 		return errors.Trace(err)
@@ -2402,7 +2553,7 @@ func (api *APIBase) unsetApplicationConfig(arg params.ApplicationUnset) error {
 	}
 
 	if len(appConfigKeys) > 0 {
-		if err := app.UpdateApplicationConfig(nil, appConfigKeys, configSchema, defaults); err != nil {
+		if err := app.UpdateApplicationConfigByUser(api.userName(), nil, appConfigKeys, configSchema, defaults); err != nil {
 			return errors.Annotate(err, "updating application config values")
 		}
 	}
@@ -2414,7 +2565,7 @@ func (api *APIBase) unsetApplicationConfig(arg params.ApplicationUnset) error {
 		if arg.BranchName == "" {
 			arg.BranchName = model.GenerationMaster
 		}
-		if err := app.UpdateCharmConfig(arg.BranchName, charmSettings); err != nil {
+		if err := app.UpdateCharmConfigByUser(api.userName(), arg.BranchName, charmSettings); err != nil {
 			return errors.Annotate(err, "updating application charm settings")
 		}
 	}
@@ -2539,6 +2690,10 @@ func (api *APIBase) MergeBindings(in params.ApplicationMergeBindingsArgs) (param
 			res[i].Error = apiservererrors.ServerError(err)
 			continue
 		}
+		if err := api.check.EntityOperationAllowed(model.BlockOperationConfig, tag); err != nil {
+			res[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
 		app, err := api.backend.Application(tag.Name)
 		if err != nil {
 			res[i].Error = apiservererrors.ServerError(err)
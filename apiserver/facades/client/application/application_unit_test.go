@@ -32,6 +32,7 @@ import (
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/state"
@@ -59,7 +60,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv12
+	api          *application.APIv15
 	deployParams map[string]application.DeployApplicationParams
 }
 
@@ -90,7 +91,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.caasBroker,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv12{api}
+	s.api = &application.APIv15{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -396,6 +397,66 @@ postgresql:
 	})
 }
 
+func (s *ApplicationSuite) TestSetCharmBranch(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		Generation:      "new-branch",
+		ResourceIDs:     map[string]string{"data": "pending-id"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "Application", "Charm")
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 2, "SetBranchCharm", "new-branch", state.SetCharmConfig{
+		Charm:       &state.Charm{},
+		ResourceIDs: map[string]string{"data": "pending-id"},
+	})
+	s.backend.generation.CheckCall(c, 0, "AssignApplication", "postgresql")
+}
+
+func (s *ApplicationSuite) TestSetCharmBranchV12(c *gc.C) {
+	apiV12 := &application.APIv12{&application.APIv13{&application.APIv14{s.api}}}
+	err := apiV12.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		Generation:      "new-branch",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "Application", "Charm")
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 2, "SetCharm", state.SetCharmConfig{
+		Charm: &state.Charm{},
+	})
+}
+
+func (s *ApplicationSuite) TestGetCharmURLBranch(c *gc.C) {
+	s.backend.generation = &mockGeneration{
+		charms: map[string]state.BranchCharm{
+			"postgresql": {URL: charm.MustParseURL("cs:postgresql-42")},
+		},
+	}
+	result, err := s.api.GetCharmURL(params.ApplicationGet{
+		ApplicationName: "postgresql",
+		BranchName:      "new-branch",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.Equals, "cs:postgresql-42")
+}
+
+func (s *ApplicationSuite) TestSetConstraintsBranch(c *gc.C) {
+	cons := constraints.MustParse("mem=4G")
+	err := s.api.SetConstraints(params.SetConstraints{
+		ApplicationName: "postgresql",
+		Constraints:     cons,
+		BranchName:      "new-branch",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "SetBranchConstraints")
+	app.CheckCall(c, 0, "SetBranchConstraints", "new-branch", cons)
+	s.backend.generation.CheckCall(c, 0, "AssignApplication", "postgresql")
+}
+
 func (s *ApplicationSuite) TestLXDProfileSetCharmWithNewerAgentVersion(c *gc.C) {
 	err := s.api.SetCharm(params.ApplicationSetCharm{
 		ApplicationName: "postgresql",
@@ -454,7 +515,8 @@ func (s *ApplicationSuite) TestLXDProfileSetCharmWithEmptyProfile(c *gc.C) {
 func (s *ApplicationSuite) TestDestroyRelation(c *gc.C) {
 	err := s.api.DestroyRelation(params.DestroyRelation{Endpoints: []string{"a", "b"}})
 	c.Assert(err, jc.ErrorIsNil)
-	s.blockChecker.CheckCallNames(c, "RemoveAllowed")
+	s.blockChecker.CheckCallNames(c, "RemoveAllowed", "EntityOperationAllowed")
+	s.blockChecker.CheckCall(c, 1, "EntityOperationAllowed", model.BlockOperationRemove, []names.Tag{s.relation.tag})
 	s.backend.CheckCallNames(c, "InferEndpoints", "EndpointsRelation")
 	s.backend.CheckCall(c, 0, "InferEndpoints", []string{"a", "b"})
 	s.relation.CheckCallNames(c, "DestroyWithForce")
@@ -481,10 +543,18 @@ func (s *ApplicationSuite) TestBlockRemoveDestroyRelation(c *gc.C) {
 	s.relation.CheckNoCalls(c)
 }
 
+func (s *ApplicationSuite) TestEntityBlockDestroyRelation(c *gc.C) {
+	s.blockChecker.SetErrors(nil, errors.New("relation blocked"))
+	err := s.api.DestroyRelation(params.DestroyRelation{RelationId: 123})
+	c.Assert(err, gc.ErrorMatches, "relation blocked")
+	s.blockChecker.CheckCallNames(c, "RemoveAllowed", "EntityOperationAllowed")
+	s.relation.CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestDestroyRelationId(c *gc.C) {
 	err := s.api.DestroyRelation(params.DestroyRelation{RelationId: 123})
 	c.Assert(err, jc.ErrorIsNil)
-	s.blockChecker.CheckCallNames(c, "RemoveAllowed")
+	s.blockChecker.CheckCallNames(c, "RemoveAllowed", "EntityOperationAllowed")
 	s.backend.CheckCallNames(c, "Relation")
 	s.backend.CheckCall(c, 0, "Relation", 123)
 	s.relation.CheckCallNames(c, "DestroyWithForce")
@@ -500,6 +570,21 @@ func (s *ApplicationSuite) TestDestroyApplication(c *gc.C) {
 	s.assertDestroyApplication(c, false, nil)
 }
 
+func (s *ApplicationSuite) TestEntityBlockDestroyApplication(c *gc.C) {
+	s.blockChecker.SetErrors(nil, errors.New("application blocked"))
+	results, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{{
+			ApplicationTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "application blocked")
+	s.blockChecker.CheckCall(c, 1, "EntityOperationAllowed",
+		model.BlockOperationRemove, []names.Tag{names.NewApplicationTag("postgresql")})
+	s.backend.CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestForceDestroyApplication(c *gc.C) {
 	zero := time.Duration(0)
 	s.assertDestroyApplication(c, true, &zero)
@@ -979,6 +1064,18 @@ func (s *ApplicationSuite) TestAddUnits(c *gc.C) {
 	app.addedUnit.CheckCall(c, 0, "AssignWithPolicy", state.AssignCleanEmpty)
 }
 
+func (s *ApplicationSuite) TestEntityBlockAddUnits(c *gc.C) {
+	s.blockChecker.SetErrors(nil, errors.New("scaling blocked"))
+	_, err := s.api.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        1,
+	})
+	c.Assert(err, gc.ErrorMatches, "scaling blocked")
+	s.blockChecker.CheckCall(c, 1, "EntityOperationAllowed",
+		model.BlockOperationScale, []names.Tag{names.NewApplicationTag("postgresql")})
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestAddUnitsCAASModel(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	_, err := s.api.AddUnits(params.AddApplicationUnits{
@@ -1660,7 +1757,7 @@ func (s *ApplicationSuite) testSetApplicationConfig(c *gc.C, branchName string)
 	c.Assert(result.OneError(), jc.ErrorIsNil)
 	s.backend.CheckCallNames(c, "Application")
 	app := s.backend.applications["postgresql"]
-	app.CheckCallNames(c, "UpdateApplicationConfig", "Charm", "UpdateCharmConfig")
+	app.CheckCallNames(c, "UpdateApplicationConfigByUser", "Charm", "UpdateCharmConfigByUser")
 
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
@@ -1668,10 +1765,10 @@ func (s *ApplicationSuite) testSetApplicationConfig(c *gc.C, branchName string)
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
-	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes{
+	app.CheckCall(c, 0, "UpdateApplicationConfigByUser", "admin", coreapplication.ConfigAttributes{
 		"juju-external-hostname": "value",
 	}, []string(nil), schema, defaults)
-	app.CheckCall(c, 2, "UpdateCharmConfig", model.GenerationMaster, charm.Settings{"stringOption": "stringVal"})
+	app.CheckCall(c, 2, "UpdateCharmConfigByUser", "admin", model.GenerationMaster, charm.Settings{"stringOption": "stringVal"})
 
 	// We should never have accessed the generation.
 	c.Check(s.backend.generation, gc.IsNil)
@@ -1692,7 +1789,7 @@ func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	c.Assert(result.OneError(), jc.ErrorIsNil)
 	s.backend.CheckCallNames(c, "Application")
 	app := s.backend.applications["postgresql"]
-	app.CheckCallNames(c, "UpdateApplicationConfig", "Charm", "UpdateCharmConfig")
+	app.CheckCallNames(c, "UpdateApplicationConfigByUser", "Charm", "UpdateCharmConfigByUser")
 
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
@@ -1700,10 +1797,10 @@ func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
-	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes{
+	app.CheckCall(c, 0, "UpdateApplicationConfigByUser", "admin", coreapplication.ConfigAttributes{
 		"juju-external-hostname": "value",
 	}, []string(nil), schema, defaults)
-	app.CheckCall(c, 2, "UpdateCharmConfig", "new-branch", charm.Settings{"stringOption": "stringVal"})
+	app.CheckCall(c, 2, "UpdateCharmConfigByUser", "admin", "new-branch", charm.Settings{"stringOption": "stringVal"})
 
 	s.backend.generation.CheckCall(c, 0, "AssignApplication", "postgresql")
 }
@@ -1716,6 +1813,20 @@ func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 	s.relation.CheckNoCalls(c)
 }
 
+func (s *ApplicationSuite) TestEntityBlockSetApplicationConfig(c *gc.C) {
+	s.blockChecker.SetErrors(nil, errors.New("config blocked"))
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"stringOption": "stringVal"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "config blocked")
+	s.blockChecker.CheckCall(c, 1, "EntityOperationAllowed",
+		model.BlockOperationConfig, []names.Tag{names.NewApplicationTag("postgresql")})
+	s.backend.CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestSetApplicationConfigPermissionDenied(c *gc.C) {
 	s.setAPIUser(c, names.NewUserTag("fred"))
 	_, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
@@ -1739,7 +1850,7 @@ func (s *ApplicationSuite) TestUnsetApplicationConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.backend.CheckCallNames(c, "Application")
 	app := s.backend.applications["postgresql"]
-	app.CheckCallNames(c, "UpdateApplicationConfig", "UpdateCharmConfig")
+	app.CheckCallNames(c, "UpdateApplicationConfigByUser", "UpdateCharmConfigByUser")
 
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
@@ -1747,9 +1858,9 @@ func (s *ApplicationSuite) TestUnsetApplicationConfig(c *gc.C) {
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
-	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes(nil),
+	app.CheckCall(c, 0, "UpdateApplicationConfigByUser", "admin", coreapplication.ConfigAttributes(nil),
 		[]string{"juju-external-hostname"}, schema, defaults)
-	app.CheckCall(c, 1, "UpdateCharmConfig", "new-branch", charm.Settings{"stringVal": nil})
+	app.CheckCall(c, 1, "UpdateCharmConfigByUser", "admin", "new-branch", charm.Settings{"stringVal": nil})
 }
 
 func (s *ApplicationSuite) TestBlockUnsetApplicationConfig(c *gc.C) {
@@ -1771,6 +1882,193 @@ func (s *ApplicationSuite) TestUnsetApplicationConfigPermissionDenied(c *gc.C) {
 	s.application.CheckNoCalls(c)
 }
 
+func (s *ApplicationSuite) TestValidateApplicationsConfig(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	result, err := s.api.ValidateApplicationsConfig(params.ApplicationConfigDryRunArgs{
+		Args: []params.ApplicationConfigDryRun{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"juju-external-hostname": "value",
+				"intOption":              "5",
+				"stringOption":           "stringVal",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.ConfigDryRunResult{{
+		Changes: []params.ConfigChange{
+			{Key: "intOption", Old: 123, New: int64(5), Input: "5"},
+			{Key: "juju-external-hostname", New: "value"},
+			{Key: "stringOption", New: "stringVal"},
+		},
+		Units: []string{"postgresql/0", "postgresql/1"},
+	}})
+
+	// Nothing is written.
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "ApplicationConfig", "Charm", "CharmConfig", "AllUnits")
+	app.CheckCall(c, 2, "CharmConfig", model.GenerationMaster)
+	c.Check(s.backend.generation, gc.IsNil)
+}
+
+func (s *ApplicationSuite) TestValidateApplicationsConfigBranch(c *gc.C) {
+	s.backend.generation = &mockGeneration{
+		units: map[string][]string{"postgresql": {"postgresql/1"}},
+	}
+	result, err := s.api.ValidateApplicationsConfig(params.ApplicationConfigDryRunArgs{
+		Args: []params.ApplicationConfigDryRun{{
+			ApplicationName: "postgresql",
+			Generation:      "new-branch",
+			ConfigYAML:      "postgresql:\n  stringOption: stringVal\n",
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.ConfigDryRunResult{{
+		Changes: []params.ConfigChange{{Key: "stringOption", New: "stringVal"}},
+		Units:   []string{"postgresql/1"},
+	}})
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "Charm", "CharmConfig")
+	app.CheckCall(c, 1, "CharmConfig", "new-branch")
+	s.backend.generation.CheckCallNames(c, "AssignedUnits")
+}
+
+func (s *ApplicationSuite) TestValidateApplicationsConfigNoChange(c *gc.C) {
+	result, err := s.api.ValidateApplicationsConfig(params.ApplicationConfigDryRunArgs{
+		Args: []params.ApplicationConfigDryRun{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"intOption": "123"},
+			Reset:           []string{"stringOption"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.ConfigDryRunResult{{}})
+}
+
+func (s *ApplicationSuite) TestValidateApplicationsConfigInvalid(c *gc.C) {
+	result, err := s.api.ValidateApplicationsConfig(params.ApplicationConfigDryRunArgs{
+		Args: []params.ApplicationConfigDryRun{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"intOption": "lots"},
+		}, {
+			ApplicationName: "postgresql",
+			Reset:           []string{"unknown"},
+		}, {
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"intOption": "5"},
+			ConfigYAML:      "postgresql:\n  intOption: 5\n",
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, `validating application charm settings: option "intOption" expected int, got "lots"`)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `validating application charm settings: unknown option "unknown"`)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, "cannot validate config and config YAML simultaneously")
+	s.backend.applications["postgresql"].CheckCallNames(c, "Charm", "Charm")
+}
+
+func (s *ApplicationSuite) TestBlockValidateApplicationsConfig(c *gc.C) {
+	s.blockChecker.SetErrors(nil, errors.New("config blocked"))
+	result, err := s.api.ValidateApplicationsConfig(params.ApplicationConfigDryRunArgs{
+		Args: []params.ApplicationConfigDryRun{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"stringOption": "stringVal"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "config blocked")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestValidateApplicationsConfigPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.ValidateApplicationsConfig(params.ApplicationConfigDryRunArgs{
+		Args: []params.ApplicationConfigDryRun{{
+			ApplicationName: "postgresql",
+		}}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestApplicationConfigHistory(c *gc.C) {
+	created := time.Date(2020, 9, 1, 3, 0, 0, 0, time.UTC)
+	s.backend.applications["postgresql"].configHistory = []state.ConfigRevision{{
+		Revision:  2,
+		Scope:     state.CharmConfigScope,
+		Created:   created,
+		CreatedBy: "bob",
+		Changes: settings.ItemChanges{
+			settings.MakeDeletion("stringOption", "stringVal"),
+		},
+	}}
+	result, err := s.api.ApplicationConfigHistory(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "unit-postgresql-0"},
+		}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0], jc.DeepEquals, params.ConfigHistoryResult{
+		Revisions: []params.ConfigRevision{{
+			Revision:  2,
+			Scope:     "charm",
+			Created:   created,
+			CreatedBy: "bob",
+			Changes: []params.ConfigChange{{
+				Key:   "stringOption",
+				Old:   "stringVal",
+				Reset: true,
+			}},
+		}},
+	})
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+}
+
+func (s *ApplicationSuite) TestApplicationConfigHistoryPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.ApplicationConfigHistory(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestRevertApplicationConfig(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	result, err := s.api.RevertApplicationConfig(params.RevertApplicationConfigArgs{
+		Args: []params.RevertApplicationConfig{{
+			ApplicationName: "postgresql",
+			Revision:        2,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "Application")
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "RevertConfig")
+
+	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
+	c.Assert(err, jc.ErrorIsNil)
+	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
+	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
+	c.Assert(err, jc.ErrorIsNil)
+	app.CheckCall(c, 0, "RevertConfig", 2, "admin", schema, defaults)
+}
+
+func (s *ApplicationSuite) TestBlockRevertApplicationConfig(c *gc.C) {
+	s.blockChecker.SetErrors(nil, errors.New("config blocked"))
+	result, err := s.api.RevertApplicationConfig(params.RevertApplicationConfigArgs{
+		Args: []params.RevertApplicationConfig{{
+			ApplicationName: "postgresql",
+			Revision:        2,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "config blocked")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestRevertApplicationConfigPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.RevertApplicationConfig(params.RevertApplicationConfigArgs{
+		Args: []params.RevertApplicationConfig{{
+			ApplicationName: "postgresql",
+			Revision:        2,
+		}}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *ApplicationSuite) TestResolveUnitErrors(c *gc.C) {
 	entities := []params.Entity{{Tag: "unit-postgresql-0"}, {Tag: "unit-postgresql-1"}}
 	p := params.UnitsResolved{
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
@@ -61,6 +62,7 @@ type Backend interface {
 type BlockChecker interface {
 	ChangeAllowed() error
 	RemoveAllowed() error
+	EntityOperationAllowed(op model.BlockOperation, tags ...names.Tag) error
 }
 
 // Application defines a subset of the functionality provided by the
@@ -87,13 +89,17 @@ type Application interface {
 	IsRemote() bool
 	Series() string
 	SetCharm(state.SetCharmConfig) error
+	SetBranchCharm(string, state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
+	SetBranchConstraints(string, constraints.Value) error
 	SetExposed() error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
 	UpdateApplicationSeries(string, bool) error
-	UpdateCharmConfig(string, charm.Settings) error
-	UpdateApplicationConfig(application.ConfigAttributes, []string, environschema.Fields, schema.Defaults) error
+	UpdateCharmConfigByUser(string, string, charm.Settings) error
+	UpdateApplicationConfigByUser(string, application.ConfigAttributes, []string, environschema.Fields, schema.Defaults) error
+	ConfigHistory() ([]state.ConfigRevision, error)
+	RevertConfig(int, string, environschema.Fields, schema.Defaults) error
 	SetScale(int, int64, bool) error
 	ChangeScale(int) (int, error)
 	AgentTools() (*tools.Tools, error)
@@ -201,6 +207,8 @@ type Resources interface {
 
 type Generation interface {
 	AssignApplication(string) error
+	AssignedUnits() map[string][]string
+	CharmFor(string) (state.BranchCharm, bool)
 }
 
 type stateShim struct {
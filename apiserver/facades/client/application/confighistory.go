@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+)
+
+// ApplicationConfigHistory isn't on the v14 API.
+func (u *APIv14) ApplicationConfigHistory(_, _ struct{}) {}
+
+// RevertApplicationConfig isn't on the v14 API.
+func (u *APIv14) RevertApplicationConfig(_, _ struct{}) {}
+
+// ApplicationConfigHistory returns the retained revisions of the charm and
+// application config of each of the input applications, most recent first.
+func (api *APIBase) ApplicationConfigHistory(args params.Entities) (params.ConfigHistoryResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ConfigHistoryResults{}, err
+	}
+	results := params.ConfigHistoryResults{
+		Results: make([]params.ConfigHistoryResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		revisions, err := api.applicationConfigHistory(arg.Tag)
+		results.Results[i].Revisions = revisions
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *APIBase) applicationConfigHistory(tagString string) ([]params.ConfigRevision, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	revisions, err := app.ConfigHistory()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.ConfigRevisions(revisions), nil
+}
+
+// RevertApplicationConfig restores the charm and application config of
+// each of the input applications to the values they had immediately after
+// the given revision. Each restoration is recorded as a new revision.
+func (api *APIBase) RevertApplicationConfig(args params.RevertApplicationConfigArgs) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := api.checkCanWrite(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		err := api.revertApplicationConfig(arg)
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *APIBase) revertApplicationConfig(arg params.RevertApplicationConfig) error {
+	if err := api.checkApplicationOperation(arg.ApplicationName, model.BlockOperationConfig); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(arg.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	configSchema, defaults, err := applicationConfigSchema(api.modelType)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(app.RevertConfig(arg.Revision, api.userName(), configSchema, defaults))
+}
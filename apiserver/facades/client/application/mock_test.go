@@ -30,6 +30,7 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
@@ -88,22 +89,23 @@ type mockApplication struct {
 	jtesting.Stub
 	application.Application
 
-	bindings    map[string]string
-	charm       *mockCharm
-	curl        *charm.URL
-	endpoints   []state.Endpoint
-	name        string
-	scale       int
-	subordinate bool
-	series      string
-	units       []*mockUnit
-	addedUnit   mockUnit
-	config      coreapplication.ConfigAttributes
-	constraints constraints.Value
-	channel     csparams.Channel
-	exposed     bool
-	remote      bool
-	agentTools  *tools.Tools
+	bindings      map[string]string
+	charm         *mockCharm
+	curl          *charm.URL
+	endpoints     []state.Endpoint
+	name          string
+	scale         int
+	subordinate   bool
+	series        string
+	units         []*mockUnit
+	addedUnit     mockUnit
+	config        coreapplication.ConfigAttributes
+	constraints   constraints.Value
+	channel       csparams.Channel
+	exposed       bool
+	remote        bool
+	agentTools    *tools.Tools
+	configHistory []state.ConfigRevision
 }
 
 func (m *mockApplication) Name() string {
@@ -163,6 +165,16 @@ func (a *mockApplication) SetCharm(cfg state.SetCharmConfig) error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) SetBranchConstraints(branchName string, cons constraints.Value) error {
+	a.MethodCall(a, "SetBranchConstraints", branchName, cons)
+	return a.NextErr()
+}
+
+func (a *mockApplication) SetBranchCharm(branchName string, cfg state.SetCharmConfig) error {
+	a.MethodCall(a, "SetBranchCharm", branchName, cfg)
+	return a.NextErr()
+}
+
 func (a *mockApplication) DestroyOperation() *state.DestroyApplicationOperation {
 	a.MethodCall(a, "DestroyOperation")
 	return &state.DestroyApplicationOperation{}
@@ -219,18 +231,34 @@ func (a *mockApplication) ApplicationConfig() (coreapplication.ConfigAttributes,
 	return a.config, a.NextErr()
 }
 
-func (a *mockApplication) UpdateApplicationConfig(
+func (a *mockApplication) UpdateApplicationConfigByUser(
+	userName string,
 	changes coreapplication.ConfigAttributes,
 	reset []string,
 	extra environschema.Fields,
 	defaults schema.Defaults,
 ) error {
-	a.MethodCall(a, "UpdateApplicationConfig", changes, reset, extra, defaults)
+	a.MethodCall(a, "UpdateApplicationConfigByUser", userName, changes, reset, extra, defaults)
 	return a.NextErr()
 }
 
-func (a *mockApplication) UpdateCharmConfig(branchName string, settings charm.Settings) error {
-	a.MethodCall(a, "UpdateCharmConfig", branchName, settings)
+func (a *mockApplication) UpdateCharmConfigByUser(userName, branchName string, settings charm.Settings) error {
+	a.MethodCall(a, "UpdateCharmConfigByUser", userName, branchName, settings)
+	return a.NextErr()
+}
+
+func (a *mockApplication) ConfigHistory() ([]state.ConfigRevision, error) {
+	a.MethodCall(a, "ConfigHistory")
+	return a.configHistory, a.NextErr()
+}
+
+func (a *mockApplication) RevertConfig(
+	revision int,
+	userName string,
+	extra environschema.Fields,
+	defaults schema.Defaults,
+) error {
+	a.MethodCall(a, "RevertConfig", revision, userName, extra, defaults)
 	return a.NextErr()
 }
 
@@ -780,6 +808,11 @@ func (c *mockBlockChecker) RemoveAllowed() error {
 	return c.NextErr()
 }
 
+func (c *mockBlockChecker) EntityOperationAllowed(op model.BlockOperation, tags ...names.Tag) error {
+	c.MethodCall(c, "EntityOperationAllowed", op, tags)
+	return c.NextErr()
+}
+
 type mockRelation struct {
 	application.Relation
 	jtesting.Stub
@@ -1133,6 +1166,8 @@ func (m *mockCaasBroker) Version() (*version.Number, error) {
 
 type mockGeneration struct {
 	jtesting.Stub
+	charms map[string]state.BranchCharm
+	units  map[string][]string
 }
 
 func (g *mockGeneration) AssignApplication(appName string) error {
@@ -1140,6 +1175,17 @@ func (g *mockGeneration) AssignApplication(appName string) error {
 	return g.NextErr()
 }
 
+func (g *mockGeneration) AssignedUnits() map[string][]string {
+	g.MethodCall(g, "AssignedUnits")
+	return g.units
+}
+
+func (g *mockGeneration) CharmFor(appName string) (state.BranchCharm, bool) {
+	g.MethodCall(g, "CharmFor", appName)
+	staged, ok := g.charms[appName]
+	return staged, ok
+}
+
 type mockRepo struct {
 	charmrepo.Interface
 	*jtesting.CallMocker
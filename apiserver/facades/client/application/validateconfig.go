@@ -0,0 +1,266 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"sort"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/model"
+)
+
+// ValidateApplicationsConfig isn't on the v13 API.
+func (u *APIv13) ValidateApplicationsConfig(_, _ struct{}) {}
+
+// ValidateApplicationsConfig reports the effect that each of the given
+// config changes would have on its application, without applying them.
+// Each result holds the keys whose effective value would change, noting
+// values that were coerced from the supplied strings, and the units that
+// would run the config-changed hook as a result.
+func (api *APIBase) ValidateApplicationsConfig(args params.ApplicationConfigDryRunArgs) (params.ConfigDryRunResults, error) {
+	var result params.ConfigDryRunResults
+	if err := api.checkCanWrite(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ConfigDryRunResult, len(args.Args))
+	for i, arg := range args.Args {
+		res, err := api.validateApplicationConfig(arg)
+		if err != nil {
+			res.Error = apiservererrors.ServerError(err)
+		}
+		result.Results[i] = res
+	}
+	return result, nil
+}
+
+func (api *APIBase) validateApplicationConfig(arg params.ApplicationConfigDryRun) (params.ConfigDryRunResult, error) {
+	var result params.ConfigDryRunResult
+	if arg.ConfigYAML != "" && len(arg.Config) > 0 {
+		return result, errors.New("cannot validate config and config YAML simultaneously")
+	}
+	if err := api.checkApplicationOperation(arg.ApplicationName, model.BlockOperationConfig); err != nil {
+		return result, errors.Trace(err)
+	}
+	app, err := api.backend.Application(arg.ApplicationName)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	// We need a guard on the API server-side for direct API callers such as
+	// python-libjuju, and for older clients.
+	// Always default to the master branch.
+	if arg.Generation == "" {
+		arg.Generation = model.GenerationMaster
+	}
+
+	appConfigAttrs, charmConfig, err := splitApplicationAndCharmConfig(api.modelType, arg.Config)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	configSchema, defaults, err := applicationConfigSchema(api.modelType)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	appConfigFields := application.KnownConfigKeys(configSchema)
+
+	var appReset, charmReset []string
+	for _, name := range arg.Reset {
+		if appConfigFields.Contains(name) {
+			appReset = append(appReset, name)
+		} else {
+			charmReset = append(charmReset, name)
+		}
+	}
+
+	var appChanges, charmChanges []params.ConfigChange
+	if len(appConfigAttrs)+len(appReset) > 0 {
+		appChanges, err = applicationConfigChanges(app, appConfigAttrs, appReset, configSchema, defaults)
+		if err != nil {
+			return result, errors.Annotate(err, "validating application config values")
+		}
+	}
+	if arg.ConfigYAML != "" || len(charmConfig)+len(charmReset) > 0 {
+		charmChanges, err = charmConfigChanges(app, arg, charmConfig, charmReset)
+		if err != nil {
+			return result, errors.Annotate(err, "validating application charm settings")
+		}
+	}
+
+	result.Changes = append(appChanges, charmChanges...)
+	sort.Slice(result.Changes, func(i, j int) bool {
+		return result.Changes[i].Key < result.Changes[j].Key
+	})
+
+	// Application config isn't branched, so changing it affects
+	// every unit. Charm config changes affect all units when made
+	// on master, and only the units tracking the branch otherwise.
+	switch {
+	case len(appChanges) > 0 || (len(charmChanges) > 0 && arg.Generation == model.GenerationMaster):
+		result.Units, err = applicationUnitNames(app)
+	case len(charmChanges) > 0:
+		result.Units, err = api.branchUnitNames(arg.Generation, arg.ApplicationName)
+	}
+	return result, errors.Trace(err)
+}
+
+// applicationConfigChanges returns the changes that updating the
+// application config would make, validated and coerced against the
+// given schema as UpdateApplicationConfig would do.
+func applicationConfigChanges(
+	app Application,
+	changes map[string]interface{},
+	reset []string,
+	configSchema environschema.Fields,
+	defaults schema.Defaults,
+) ([]params.ConfigChange, error) {
+	current, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	oldConfig, err := application.NewConfig(current, configSchema, defaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	attrs := make(map[string]interface{})
+	for k, v := range current {
+		attrs[k] = v
+	}
+	for k, v := range changes {
+		attrs[k] = v
+	}
+	for _, k := range reset {
+		if _, ok := changes[k]; !ok {
+			delete(attrs, k)
+		}
+	}
+	newConfig, err := application.NewConfig(attrs, configSchema, defaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := newConfig.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.ConfigChanges(oldConfig.Attributes(), newConfig.Attributes(), changes, reset), nil
+}
+
+// charmConfigChanges returns the changes that updating the charm config
+// of the application in the requested branch would make, after
+// validating them against the charm's config options.
+func charmConfigChanges(
+	app Application,
+	arg params.ApplicationConfigDryRun,
+	charmConfig map[string]string,
+	reset []string,
+) ([]params.ConfigChange, error) {
+	ch, _, err := app.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var (
+		changes charm.Settings
+		input   map[string]interface{}
+	)
+	if arg.ConfigYAML != "" {
+		changes, err = charmConfigFromYAML(arg.ApplicationName, ch, arg.ConfigYAML)
+	} else {
+		changes, err = ch.Config().ParseSettingsStrings(charmConfig)
+		input = make(map[string]interface{}, len(charmConfig))
+		for k, v := range charmConfig {
+			input[k] = v
+		}
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if changes == nil {
+		changes = make(charm.Settings)
+	}
+	for _, k := range reset {
+		if _, ok := changes[k]; !ok {
+			changes[k] = nil
+		}
+	}
+	if changes, err = ch.Config().ValidateSettings(changes); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	current, err := app.CharmConfig(arg.Generation)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	charmDefaults := ch.Config().DefaultSettings()
+	oldAttrs := make(map[string]interface{}, len(current))
+	newAttrs := make(map[string]interface{}, len(current))
+	for k, v := range current {
+		oldAttrs[k] = v
+		newAttrs[k] = v
+	}
+	for k, v := range changes {
+		if v == nil {
+			v = charmDefaults[k]
+		}
+		if v == nil {
+			delete(newAttrs, k)
+		} else {
+			newAttrs[k] = v
+		}
+	}
+	return common.ConfigChanges(oldAttrs, newAttrs, input, reset), nil
+}
+
+// charmConfigFromYAML parses charm settings for the named application
+// from YAML, accepting either the output of "juju config" or settings
+// keyed by application name.
+func charmConfigFromYAML(appName string, ch Charm, settings string) (charm.Settings, error) {
+	b := []byte(settings)
+	var all map[string]interface{}
+	if err := goyaml.Unmarshal(b, &all); err != nil {
+		return nil, errors.Annotate(err, "parsing settings data")
+	}
+	if _, ok := all[appName]; !ok {
+		changes, err := charmConfigFromGetYaml(all)
+		return changes, errors.Annotate(err, "processing YAML generated by get")
+	}
+	changes, err := ch.Config().ParseSettingsYAML(b, appName)
+	return changes, errors.Annotate(err, "creating config from YAML")
+}
+
+// applicationUnitNames returns the sorted names of the application's units.
+func applicationUnitNames(app Application) ([]string, error) {
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(units))
+	for i, u := range units {
+		names[i] = u.Name()
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// branchUnitNames returns the sorted names of the application's units
+// that track the named branch.
+func (api *APIBase) branchUnitNames(branchName, appName string) ([]string, error) {
+	branch, err := api.backend.Branch(branchName)
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving branch")
+	}
+	names := append([]string(nil), branch.AssignedUnits()[appName]...)
+	sort.Strings(names)
+	return names, nil
+}
@@ -28,7 +28,6 @@ var logger = loggo.GetLogger("juju.apiserver.backups")
 type Backend interface {
 	IsController() bool
 	Machine(id string) (Machine, error)
-	MachineSeries(id string) (string, error)
 	MongoSession() *mgo.Session
 	MongoVersion() (string, error)
 	ModelTag() names.ModelTag
@@ -55,6 +54,18 @@ type APIv2 struct {
 	*API
 }
 
+// APIv3 serves backup-specific API methods for version 3, which
+// adds encryption of backup archives.
+type APIv3 struct {
+	*APIv2
+}
+
+// APIv4 serves backup-specific API methods for version 4, which
+// adds streaming and incremental backups.
+type APIv4 struct {
+	*APIv3
+}
+
 func NewAPIv2(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv2, error) {
 	api, err := NewAPI(backend, resources, authorizer)
 	if err != nil {
@@ -63,6 +74,24 @@ func NewAPIv2(backend Backend, resources facade.Resources, authorizer facade.Aut
 	return &APIv2{api}, nil
 }
 
+// NewAPIv3 creates a new instance of the version 3 Backups API facade.
+func NewAPIv3(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv3, error) {
+	api, err := NewAPIv2(backend, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{api}, nil
+}
+
+// NewAPIv4 creates a new instance of the version 4 Backups API facade.
+func NewAPIv4(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv4, error) {
+	api, err := NewAPIv3(backend, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv4{api}, nil
+}
+
 // NewAPI creates a new instance of the Backups API facade.
 func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
 	isControllerAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
@@ -176,6 +205,12 @@ func CreateResult(meta *backups.Metadata, filename string) params.BackupsMetadat
 	result.CACert = meta.CACert
 	result.CAPrivateKey = meta.CAPrivateKey
 	result.Filename = filename
+	if meta.Encryption != nil {
+		result.EncryptionScheme = meta.Encryption.Scheme
+		result.EncryptionRecipient = meta.Encryption.Recipient
+	}
+	result.OplogTimestamp = meta.OplogTimestamp
+	result.BaseID = meta.BaseID
 
 	return result
 }
@@ -202,6 +237,14 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 		MachineInstanceID: result.ControllerMachineInstanceID,
 		HANodes:           result.HANodes,
 	}
+	if result.EncryptionScheme != "" {
+		meta.Encryption = &backups.Encryption{
+			Scheme:    result.EncryptionScheme,
+			Recipient: result.EncryptionRecipient,
+		}
+	}
+	meta.OplogTimestamp = result.OplogTimestamp
+	meta.BaseID = result.BaseID
 	meta.SetFileInfo(result.Size, result.Checksum, result.ChecksumFormat)
 	return meta
 }
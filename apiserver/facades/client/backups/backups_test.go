@@ -58,15 +58,18 @@ func (s *backupsSuite) SetUpTest(c *gc.C) {
 
 	tag := names.NewLocalUserTag("admin")
 	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: tag}
-	shim := &stateShim{
+	s.api, err = backupsAPI.NewAPIv2(s.shim(), s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.meta = backupstesting.NewMetadataStarted()
+}
+
+func (s *backupsSuite) shim() *stateShim {
+	return &stateShim{
 		State:            s.State,
 		Model:            s.Model,
 		controllerNodesF: func() ([]state.ControllerNode, error) { return nil, nil },
 		machineF:         func(id string) (backupsAPI.Machine, error) { return &testMachine{}, nil },
 	}
-	s.api, err = backupsAPI.NewAPIv2(shim, s.resources, s.authorizer)
-	c.Assert(err, jc.ErrorIsNil)
-	s.meta = backupstesting.NewMetadataStarted()
 }
 
 func (s *backupsSuite) setBackups(c *gc.C, meta *backups.Metadata, err string) *backupstesting.FakeBackups {
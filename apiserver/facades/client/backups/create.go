@@ -8,11 +8,13 @@ import (
 	"github.com/juju/replicaset"
 
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/backups"
 )
 
-var waitUntilReady = replicaset.WaitUntilReady
+var (
+	waitUntilReady = replicaset.WaitUntilReady
+	prepareCreate  = backups.PrepareCreate
+)
 
 // Create is the API method that requests juju to create a new backup
 // of its state.  It returns the metadata for that backup.
@@ -21,71 +23,80 @@ var waitUntilReady = replicaset.WaitUntilReady
 func (a *API) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
 	args.KeepCopy = true
 	args.NoDownload = true
-
-	apiv2 := APIv2{a}
-	result, err := apiv2.Create(args)
+	args.EncryptTo = ""
+	args.Stream = false
+	args.Incremental = false
+	result, err := a.create(args)
 	if err != nil {
 		return result, errors.Trace(err)
 	}
 	return result, nil
 }
 
+// Create is the API method that requests juju to create a new backup
+// of its state.  It returns the metadata for that backup.
+//
+// NOTE version 2 does not support encrypted backups.
 func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
-	backupsMethods, closer := newBackups(a.backend)
-	defer closer.Close()
+	args.EncryptTo = ""
+	args.Stream = false
+	args.Incremental = false
+	result, err := a.create(args)
+	return result, errors.Trace(err)
+}
 
-	session := a.backend.MongoSession().Copy()
-	defer session.Close()
+// Create is the API method that requests juju to create a new backup
+// of its state, encrypted to the public key in the args if one is
+// given.  It returns the metadata for that backup.
+//
+// NOTE version 3 does not support streaming or incremental backups.
+func (a *APIv3) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	args.Stream = false
+	args.Incremental = false
+	result, err := a.create(args)
+	return result, errors.Trace(err)
+}
 
+// Create is the API method that requests juju to create a new backup
+// of its state, optionally encrypted, streamed or incremental as the
+// args request.  It returns the metadata for that backup.
+func (a *APIv4) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	result, err := a.create(args)
+	return result, errors.Trace(err)
+}
+
+func (a *API) create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
 	result := params.BackupsMetadataResult{}
-	// Don't go if HA isn't ready.
-	err := waitUntilReady(session, 60)
-	if err != nil {
-		return result, errors.Annotatef(err, "HA not ready; try again later")
+	if args.EncryptTo != "" {
+		// Check the key before doing any of the work of a backup.
+		if _, err := backups.ParsePublicKey(args.EncryptTo); err != nil {
+			return result, errors.Trace(err)
+		}
 	}
 
+	backupsMethods, closer := newBackups(a.backend)
+	defer closer.Close()
+
 	mgoInfo, err := mongoInfo(a.paths.DataDir, a.machineID)
 	if err != nil {
 		return result, errors.Annotatef(err, "getting mongo info")
 	}
-	v, err := a.backend.MongoVersion()
-	if err != nil {
-		return result, errors.Annotatef(err, "discovering mongo version")
-	}
-	mongoVersion, err := mongo.NewVersion(v)
-	if err != nil {
-		return result, errors.Trace(err)
-	}
-	dbInfo, err := backups.NewDBInfo(mgoInfo, session, mongoVersion)
-	if err != nil {
-		return result, errors.Trace(err)
-	}
-	mSeries, err := a.backend.MachineSeries(a.machineID)
-	if err != nil {
-		return result, errors.Trace(err)
-	}
-
-	meta, err := backups.NewMetadataState(a.backend, a.machineID, mSeries)
-	if err != nil {
-		return result, errors.Trace(err)
-	}
-	meta.Notes = args.Notes
-	meta.Controller.MachineID = a.machineID
 	m, err := a.backend.Machine(a.machineID)
 	if err != nil {
 		return result, errors.Trace(err)
 	}
-	instanceID, err := m.InstanceId()
-	if err != nil {
-		return result, errors.Trace(err)
-	}
-	meta.Controller.MachineInstanceID = string(instanceID)
-
-	nodes, err := a.backend.ControllerNodes()
+	meta, dbInfo, err := prepareCreate(a.backend, backups.CreateArgs{
+		MongoInfo:   mgoInfo,
+		MachineID:   a.machineID,
+		Machine:     m,
+		Notes:       args.Notes,
+		EncryptTo:   args.EncryptTo,
+		Stream:      args.Stream,
+		Incremental: args.Incremental,
+	})
 	if err != nil {
 		return result, errors.Trace(err)
 	}
-	meta.Controller.HANodes = int64(len(nodes))
 
 	fileName, err := backupsMethods.Create(meta, a.paths, dbInfo, args.KeepCopy, args.NoDownload)
 	if err != nil {
@@ -6,16 +6,28 @@ package backups_test
 import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
-	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/apiserver/facades/client/backups"
 	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
 )
 
-func (s *backupsSuite) TestCreateOkay(c *gc.C) {
-	s.PatchValue(backups.WaitUntilReady,
-		func(*mgo.Session, int) error { return nil },
+// patchPrepareCreate stands in for the preparation of a backup, which
+// needs a replica set, and returns the args it is called with.
+func (s *backupsSuite) patchPrepareCreate() *statebackups.CreateArgs {
+	var received statebackups.CreateArgs
+	s.PatchValue(backups.PrepareCreate,
+		func(_ statebackups.ControllerBackend, args statebackups.CreateArgs) (*statebackups.Metadata, *statebackups.DBInfo, error) {
+			received = args
+			return backupstesting.NewMetadataStarted(), &statebackups.DBInfo{}, nil
+		},
 	)
+	return &received
+}
+
+func (s *backupsSuite) TestCreateOkay(c *gc.C) {
+	s.patchPrepareCreate()
 	s.setBackups(c, s.meta, "")
 	var args params.BackupsCreateArgs
 	result, err := s.api.Create(args)
@@ -26,9 +38,7 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 }
 
 func (s *backupsSuite) TestCreateNotes(c *gc.C) {
-	s.PatchValue(backups.WaitUntilReady,
-		func(*mgo.Session, int) error { return nil },
-	)
+	received := s.patchPrepareCreate()
 	s.meta.Notes = "this backup is important"
 	s.setBackups(c, s.meta, "")
 	args := params.BackupsCreateArgs{
@@ -41,13 +51,12 @@ func (s *backupsSuite) TestCreateNotes(c *gc.C) {
 	expected.Notes = "this backup is important"
 
 	c.Check(result, gc.DeepEquals, expected)
+	c.Check(received.Notes, gc.Equals, "this backup is important")
 }
 
 func (s *backupsSuite) TestCreateError(c *gc.C) {
 	s.setBackups(c, nil, "failed!")
-	s.PatchValue(backups.WaitUntilReady,
-		func(*mgo.Session, int) error { return nil },
-	)
+	s.patchPrepareCreate()
 	var args params.BackupsCreateArgs
 	_, err := s.api.Create(args)
 
@@ -56,9 +65,7 @@ func (s *backupsSuite) TestCreateError(c *gc.C) {
 }
 
 func (s *backupsSuite) TestCreateController(c *gc.C) {
-	s.PatchValue(backups.WaitUntilReady,
-		func(*mgo.Session, int) error { return nil },
-	)
+	s.patchPrepareCreate()
 	s.meta.Controller.UUID = "controller-uuid"
 	s.meta.Controller.MachineID = "11"
 	s.meta.Controller.MachineInstanceID = "instance-12"
@@ -70,3 +77,59 @@ func (s *backupsSuite) TestCreateController(c *gc.C) {
 	expected := backups.CreateResult(s.meta, "test-filename")
 	c.Check(result, gc.DeepEquals, expected)
 }
+
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	received := s.patchPrepareCreate()
+	s.setBackups(c, nil, "")
+	api, err := backups.NewAPIv3(s.shim(), s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	pub, _, err := statebackups.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.Create(params.BackupsCreateArgs{EncryptTo: pub.String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(received.EncryptTo, gc.Equals, pub.String())
+}
+
+func (s *backupsSuite) TestCreateEncryptedInvalidKey(c *gc.C) {
+	fake := s.setBackups(c, nil, "")
+	api, err := backups.NewAPIv3(s.shim(), s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.Create(params.BackupsCreateArgs{EncryptTo: "not-a-key"})
+	c.Assert(err, gc.ErrorMatches, `invalid backup public key: malformed recipient "not-a-key": .*`)
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestCreateV2IgnoresEncryption(c *gc.C) {
+	received := s.patchPrepareCreate()
+	s.setBackups(c, nil, "")
+
+	_, err := s.api.Create(params.BackupsCreateArgs{EncryptTo: "juju-backup-public-key:abc"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(received.EncryptTo, gc.Equals, "")
+}
+
+func (s *backupsSuite) TestCreateStreamIncremental(c *gc.C) {
+	received := s.patchPrepareCreate()
+	s.setBackups(c, nil, "")
+	api, err := backups.NewAPIv4(s.shim(), s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.Create(params.BackupsCreateArgs{Stream: true, Incremental: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(received.Stream, jc.IsTrue)
+	c.Check(received.Incremental, jc.IsTrue)
+}
+
+func (s *backupsSuite) TestCreateV3IgnoresStreamIncremental(c *gc.C) {
+	received := s.patchPrepareCreate()
+	s.setBackups(c, nil, "")
+	api, err := backups.NewAPIv3(s.shim(), s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.Create(params.BackupsCreateArgs{Stream: true, Incremental: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(received.Stream, jc.IsFalse)
+	c.Check(received.Incremental, jc.IsFalse)
+}
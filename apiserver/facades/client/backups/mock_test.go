@@ -29,10 +29,6 @@ func (s *stateShim) IsController() bool {
 	return *s.isController
 }
 
-func (s *stateShim) MachineSeries(id string) (string, error) {
-	return "xenial", nil
-}
-
 func (s *stateShim) ControllerTag() names.ControllerTag {
 	return s.State.ControllerTag()
 }
@@ -4,18 +4,50 @@
 package backups
 
 import (
+	"sort"
+
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/backups"
 )
 
 // Remove deletes the backups defined by ID from the database.
+// Incremental backups are removed before the backups they follow, so
+// that a whole chain can be removed at once.
 func (a *APIv2) Remove(args params.BackupsRemoveArgs) (params.ErrorResults, error) {
 	backups, closer := newBackups(a.backend)
 	defer closer.Close()
 	results := make([]params.ErrorResult, len(args.IDs))
-	for i, id := range args.IDs {
-		err := backups.Remove(id)
+	// If the backups cannot be listed, they are removed in the order
+	// given, and removing a backup still needed by another fails.
+	all, _ := backups.List()
+	for _, i := range removalOrder(all, args.IDs) {
+		err := backups.Remove(args.IDs[i])
 		results[i].Error = apiservererrors.ServerError(err)
 	}
 	return params.ErrorResults{results}, nil
 }
+
+// removalOrder returns the indices of ids ordered so that each
+// incremental backup comes before the backups it follows.
+func removalOrder(all []*backups.Metadata, ids []string) []int {
+	bases := make(map[string]string)
+	for _, meta := range all {
+		bases[meta.ID()] = meta.BaseID
+	}
+	depth := func(id string) int {
+		n := 0
+		for base := bases[id]; base != "" && n < len(bases); base = bases[base] {
+			n++
+		}
+		return n
+	}
+	order := make([]int, len(ids))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return depth(ids[order[i]]) > depth(ids[order[j]])
+	})
+	return order
+}
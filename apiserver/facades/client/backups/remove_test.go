@@ -8,6 +8,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	backupstesting "github.com/juju/juju/state/backups/testing"
 )
 
 func (s *backupsSuite) TestRemoveOkay(c *gc.C) {
@@ -30,3 +31,21 @@ func (s *backupsSuite) TestRemoveError(c *gc.C) {
 	c.Assert(results.Results, gc.HasLen, 1)
 	c.Check(results.Results[0].Error, gc.ErrorMatches, "failed!")
 }
+
+func (s *backupsSuite) TestRemoveIncrementalFirst(c *gc.C) {
+	full := backupstesting.NewMetadataStarted()
+	full.SetID("full")
+	incr := backupstesting.NewMetadataStarted()
+	incr.SetID("incr")
+	incr.BaseID = "full"
+	fake := s.setBackups(c, full, "")
+	fake.MetaList = append(fake.MetaList, incr)
+
+	results, err := s.api.Remove(params.BackupsRemoveArgs{
+		IDs: []string{"full", "incr"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(fake.Calls, jc.DeepEquals, []string{"List", "Remove", "Remove"})
+	c.Check(fake.IDArg, gc.Equals, "full")
+}
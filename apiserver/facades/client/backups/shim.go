@@ -25,13 +25,22 @@ type stateShim struct {
 	*state.Model
 }
 
-// MachineSeries implements backups.Backend
-func (s *stateShim) MachineSeries(id string) (string, error) {
-	m, err := s.State.Machine(id)
+// NewFacadeV4 provides the required signature for version 4 facade registration.
+func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv4, error) {
+	model, err := st.Model()
 	if err != nil {
-		return "", errors.Trace(err)
+		return nil, errors.Trace(err)
+	}
+	return NewAPIv4(&stateShim{st, model}, resources, authorizer)
+}
+
+// NewFacadeV3 provides the required signature for version 3 facade registration.
+func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv3, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
-	return m.Series(), nil
+	return NewAPIv3(&stateShim{st, model}, resources, authorizer)
 }
 
 // NewFacadeV2 provides the required signature for version 2 facade registration.
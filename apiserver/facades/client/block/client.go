@@ -5,10 +5,12 @@ package block
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/state"
 )
@@ -25,6 +27,14 @@ type Block interface {
 	// SwitchBlockOff switches desired block type off for this
 	// model.
 	SwitchBlockOff(params.BlockSwitchParams) params.ErrorResult
+
+	// SwitchEntityBlockOn blocks categories of operation on an
+	// application, machine or relation in this model.
+	SwitchEntityBlockOn(params.EntityBlockSwitchParams) params.ErrorResult
+
+	// SwitchEntityBlockOff removes the block on an application,
+	// machine or relation in this model.
+	SwitchEntityBlockOff(params.Entity) params.ErrorResult
 }
 
 // API implements Block interface and is the concrete
@@ -34,6 +44,25 @@ type API struct {
 	authorizer facade.Authorizer
 }
 
+// APIV2 implements version 2 of the Block facade, which does
+// not know about blocks on individual entities.
+type APIV2 struct {
+	*API
+}
+
+// NewAPIV2 returns a new version 2 Block API facade.
+func NewAPIV2(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIV2, error) {
+	api, err := NewAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV2{api}, nil
+}
+
 // NewAPI returns a new block API facade.
 func NewAPI(
 	st *state.State,
@@ -112,9 +141,29 @@ func convertBlock(b state.Block) params.BlockResult {
 		Type:    b.Type().String(),
 		Message: b.Message(),
 	}
+	for _, op := range b.Operations() {
+		result.Result.Operations = append(result.Result.Operations, string(op))
+	}
 	return result
 }
 
+// List returns the blocks on this model. Blocks on individual
+// entities are omitted as version 2 clients cannot represent them.
+func (a *APIV2) List() (params.BlockResults, error) {
+	all, err := a.API.List()
+	if err != nil {
+		return all, err
+	}
+	var modelBlocks []params.BlockResult
+	for _, one := range all.Results {
+		if len(one.Result.Operations) == 0 {
+			modelBlocks = append(modelBlocks, one)
+		}
+	}
+	all.Results = modelBlocks
+	return all, nil
+}
+
 // SwitchBlockOn implements Block.SwitchBlockOn().
 func (a *API) SwitchBlockOn(args params.BlockSwitchParams) params.ErrorResult {
 	if err := a.checkCanWrite(); err != nil {
@@ -134,3 +183,43 @@ func (a *API) SwitchBlockOff(args params.BlockSwitchParams) params.ErrorResult {
 	err := a.access.SwitchBlockOff(state.ParseBlockType(args.Type))
 	return params.ErrorResult{Error: apiservererrors.ServerError(err)}
 }
+
+// SwitchEntityBlockOn blocks the given categories of operation on an
+// application, machine or relation.
+func (a *API) SwitchEntityBlockOn(args params.EntityBlockSwitchParams) params.ErrorResult {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResult{Error: apiservererrors.ServerError(err)}
+	}
+
+	tag, err := names.ParseTag(args.Tag)
+	if err != nil {
+		return params.ErrorResult{Error: apiservererrors.ServerError(err)}
+	}
+	ops := make([]model.BlockOperation, len(args.Operations))
+	for i, op := range args.Operations {
+		ops[i] = model.BlockOperation(op)
+	}
+	err = a.access.SwitchEntityBlockOn(tag, ops, args.Message)
+	return params.ErrorResult{Error: apiservererrors.ServerError(err)}
+}
+
+// SwitchEntityBlockOff removes the block on an application, machine
+// or relation.
+func (a *API) SwitchEntityBlockOff(args params.Entity) params.ErrorResult {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResult{Error: apiservererrors.ServerError(err)}
+	}
+
+	tag, err := names.ParseTag(args.Tag)
+	if err != nil {
+		return params.ErrorResult{Error: apiservererrors.ServerError(err)}
+	}
+	err = a.access.SwitchEntityBlockOff(tag)
+	return params.ErrorResult{Error: apiservererrors.ServerError(err)}
+}
+
+// SwitchEntityBlockOn is not available in version 2.
+func (*APIV2) SwitchEntityBlockOn(_, _ struct{}) {}
+
+// SwitchEntityBlockOff is not available in version 2.
+func (*APIV2) SwitchEntityBlockOff(_, _ struct{}) {}
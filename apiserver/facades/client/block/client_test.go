@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/block"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/model"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 )
@@ -79,3 +80,60 @@ func (s *blockSuite) TestSwitchBlockOff(c *gc.C) {
 	c.Assert(err.Error, gc.IsNil)
 	s.assertBlockList(c, 0)
 }
+
+func (s *blockSuite) TestSwitchEntityBlockOn(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	on := params.EntityBlockSwitchParams{
+		Tag:        app.Tag().String(),
+		Operations: []string{"config", "remove"},
+		Message:    "production database",
+	}
+	err := s.api.SwitchEntityBlockOn(on)
+	c.Assert(err.Error, gc.IsNil)
+
+	all, listErr := s.api.List()
+	c.Assert(listErr, jc.ErrorIsNil)
+	c.Assert(all.Results, gc.HasLen, 1)
+	c.Assert(all.Results[0].Result.Tag, gc.Equals, app.Tag().String())
+	c.Assert(all.Results[0].Result.Operations, jc.DeepEquals, []string{"config", "remove"})
+	c.Assert(all.Results[0].Result.Message, gc.Equals, "production database")
+}
+
+func (s *blockSuite) TestSwitchEntityBlockOnInvalidOperation(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	on := params.EntityBlockSwitchParams{
+		Tag:        app.Tag().String(),
+		Operations: []string{"deploy"},
+	}
+	err := s.api.SwitchEntityBlockOn(on)
+	c.Assert(err.Error, gc.ErrorMatches, `block operation "deploy" not valid`)
+	s.assertBlockList(c, 0)
+}
+
+func (s *blockSuite) TestSwitchEntityBlockOff(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.State.SwitchEntityBlockOn(app.Tag(), []model.BlockOperation{model.BlockOperationScale}, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := s.api.SwitchEntityBlockOff(params.Entity{Tag: app.Tag().String()})
+	c.Assert(result.Error, gc.IsNil)
+	s.assertBlockList(c, 0)
+}
+
+func (s *blockSuite) TestListV2OmitsEntityBlocks(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.State.SwitchEntityBlockOn(app.Tag(), []model.BlockOperation{model.BlockOperationScale}, "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SwitchBlockOn(state.DestroyBlock, "model block")
+	c.Assert(err, jc.ErrorIsNil)
+
+	apiV2, err := block.NewAPIV2(s.State, common.NewResources(), testing.FakeAuthorizer{
+		Tag:        s.AdminUserTag(c),
+		Controller: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	all, err := apiV2.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all.Results, gc.HasLen, 1)
+	c.Assert(all.Results[0].Result.Type, gc.Equals, state.DestroyBlock.String())
+}
@@ -4,14 +4,18 @@
 package block
 
 import (
-	"github.com/juju/juju/state"
 	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/state"
 )
 
 type blockAccess interface {
 	AllBlocks() ([]state.Block, error)
 	SwitchBlockOn(t state.BlockType, msg string) error
 	SwitchBlockOff(t state.BlockType) error
+	SwitchEntityBlockOn(tag names.Tag, ops []model.BlockOperation, msg string) error
+	SwitchEntityBlockOff(tag names.Tag) error
 	ModelTag() names.ModelTag
 }
 
@@ -29,6 +29,7 @@ import (
 	"github.com/juju/juju/core/multiwatcher"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
@@ -79,9 +80,15 @@ type Client struct {
 	openCSRepo  application.OpenCSRepoFunc
 }
 
+// ClientV2 serves the (v2) client-specific API methods. It does
+// not support filtering status by selector.
+type ClientV2 struct {
+	*Client
+}
+
 // ClientV1 serves the (v1) client-specific API methods.
 type ClientV1 struct {
-	*Client
+	*ClientV2
 }
 
 func (c *Client) checkCanRead() error {
@@ -137,9 +144,18 @@ func NewFacade(ctx facade.Context) (*Client, error) {
 	return newFacade(ctx)
 }
 
+// NewFacadeV2 creates a version 2 Client facade to handle API requests.
+func NewFacadeV2(ctx facade.Context) (*ClientV2, error) {
+	client, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV2{client}, nil
+}
+
 // NewFacadeV1 creates a version 1 Client facade to handle API requests.
 func NewFacadeV1(ctx facade.Context) (*ClientV1, error) {
-	client, err := newFacade(ctx)
+	client, err := NewFacadeV2(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -186,7 +202,7 @@ func newFacade(ctx facade.Context) (*Client, error) {
 	return NewClient(
 		&stateShim{st, model, nil},
 		&poolShim{ctx.StatePool()},
-		&modelconfig.ModelConfigAPIV1{modelConfigAPI},
+		&modelconfig.ModelConfigAPIV1{&modelconfig.ModelConfigAPIV2{&modelconfig.ModelConfigAPIV3{modelConfigAPI}}},
 		resources,
 		authorizer,
 		presence,
@@ -825,9 +841,13 @@ func (c *Client) RetryProvisioning(p params.Entities) (params.ErrorResults, erro
 	if err := c.check.ChangeAllowed(); err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
+	data := map[string]interface{}{"transient": true}
+	if user, ok := c.api.auth.GetAuthTag().(names.UserTag); ok {
+		data[status.DataUser] = user.Id()
+	}
 	entityStatus := make([]params.EntityStatusArgs, len(p.Entities))
 	for i, entity := range p.Entities {
-		entityStatus[i] = params.EntityStatusArgs{Tag: entity.Tag, Data: map[string]interface{}{"transient": true}}
+		entityStatus[i] = params.EntityStatusArgs{Tag: entity.Tag, Data: data}
 	}
 	return c.api.statusSetter.UpdateStatus(params.SetStatus{
 		Entities: entityStatus,
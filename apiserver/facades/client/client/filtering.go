@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
@@ -122,6 +123,53 @@ func BuildPredicateFor(patterns []string) Predicate {
 // service, will determine whether the unit meets some criteria.
 type Predicate func(interface{}) (matches bool, _ error)
 
+// BuildSelectorPredicate returns a Predicate which will match the
+// machines, applications and units among the given labelled entities.
+// A unit also matches if its application or its machine is labelled.
+func BuildSelectorPredicate(labelled []names.Tag) Predicate {
+	tags := set.NewStrings()
+	for _, tag := range labelled {
+		tags.Add(tag.String())
+	}
+	return func(i interface{}) (bool, error) {
+		switch e := i.(type) {
+		case *state.Machine:
+			return tags.Contains(e.Tag().String()), nil
+		case *state.Application:
+			return tags.Contains(e.Tag().String()), nil
+		case *state.Unit:
+			if tags.Contains(e.Tag().String()) ||
+				tags.Contains(names.NewApplicationTag(e.ApplicationName()).String()) {
+				return true, nil
+			}
+			machineId, err := e.AssignedMachineId()
+			if errors.IsNotAssigned(err) {
+				return false, nil
+			} else if err != nil {
+				return false, errors.Trace(err)
+			}
+			return tags.Contains(names.NewMachineTag(machineId).String()), nil
+		}
+		return false, errors.Errorf("expected a machine or an application or a unit, got %T", i)
+	}
+}
+
+// allPredicates returns a Predicate which matches only if all of the
+// given non-nil predicates match.
+func allPredicates(predicates ...Predicate) Predicate {
+	return func(i interface{}) (bool, error) {
+		for _, p := range predicates {
+			if p == nil {
+				continue
+			}
+			if matches, err := p(i); err != nil || !matches {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+}
+
 // closurePredicate is a function which has at some point been closed
 // around an element so that it can examine whether this element
 // matches some criteria.
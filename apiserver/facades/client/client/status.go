@@ -18,6 +18,7 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/params"
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	"github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/life"
@@ -166,6 +167,13 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 	return results
 }
 
+// FullStatus gives the information needed for juju status over the
+// api. Version 2 ignores the selector.
+func (c *ClientV2) FullStatus(args params.StatusParams) (params.FullStatus, error) {
+	args.Selector = ""
+	return c.Client.FullStatus(args)
+}
+
 // FullStatus gives the information needed for juju status over the api
 func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error) {
 	if err := c.checkCanRead(); err != nil {
@@ -252,8 +260,24 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	logger.Tracef("Offers: %v", context.offers)
 	logger.Tracef("Relations: %v", context.relations)
 
+	var patternPredicate, selectorPredicate Predicate
 	if len(args.Patterns) > 0 {
-		predicate := BuildPredicateFor(args.Patterns)
+		patternPredicate = BuildPredicateFor(args.Patterns)
+	}
+	if args.Selector != "" {
+		selector, err := annotations.ParseSelector(args.Selector)
+		if err != nil {
+			return noStatus, errors.Trace(err)
+		}
+		labelled, err := context.model.LabelledEntities(selector)
+		if err != nil {
+			return noStatus, errors.Annotate(err, "could not fetch labelled entities")
+		}
+		selectorPredicate = BuildSelectorPredicate(labelled)
+	}
+
+	if patternPredicate != nil || selectorPredicate != nil {
+		predicate := allPredicates(patternPredicate, selectorPredicate)
 
 		// First, attempt to match machines. Any units on those
 		// machines are implicitly matched.
@@ -102,6 +102,45 @@ func (s *statusSuite) TestFullStatusUnitLeadership(c *gc.C) {
 	c.Assert(unit.Leader, jc.IsTrue)
 }
 
+func (s *statusSuite) TestFullStatusSelector(c *gc.C) {
+	labelled := s.Factory.MakeMachine(c, nil)
+	other := s.Factory.MakeMachine(c, nil)
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	onLabelled := s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress, Machine: labelled})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress, Machine: other})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql, Machine: other})
+
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetAnnotations(labelled, map[string]string{"team": "payments"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.StatusWithSelector(nil, "team=payments")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Machines, gc.HasLen, 1)
+	c.Check(status.Machines[labelled.Id()].Id, gc.Equals, labelled.Id())
+	c.Assert(status.Applications, gc.HasLen, 1)
+	units := status.Applications[wordpress.Name()].Units
+	c.Assert(units, gc.HasLen, 1)
+	_, ok := units[onLabelled.Name()]
+	c.Check(ok, jc.IsTrue)
+
+	// Patterns and selectors must both match.
+	status, err = client.StatusWithSelector([]string{mysql.Name()}, "team=payments")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Applications, gc.HasLen, 0)
+	c.Check(status.Machines, gc.HasLen, 0)
+
+	_, err = client.StatusWithSelector(nil, "team")
+	c.Assert(err, gc.ErrorMatches, `selector term "team": expected key=value not valid`)
+}
+
 func (s *statusSuite) TestFullStatusUnitScaling(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, nil)
 	unit := s.Factory.MakeUnit(c, &factory.UnitParams{
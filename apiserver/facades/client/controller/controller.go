@@ -57,10 +57,24 @@ type ControllerAPI struct {
 	multiwatcherFactory multiwatcher.Factory
 }
 
+// ControllerAPIv10 provides the v10 Controller API. The only
+// difference between this and v11 is that v10 doesn't have the
+// MigrationProgress method.
+type ControllerAPIv10 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv9 provides the v9 Controller API. The only difference
+// between this and v10 is that v9 doesn't have the MigrationDryRun
+// method.
+type ControllerAPIv9 struct {
+	*ControllerAPIv10
+}
+
 // ControllerAPIv8 provides the v8 Controller API. The only difference
 // between this and v9 is that v8 doesn't have the model summary watchers.
 type ControllerAPIv8 struct {
-	*ControllerAPI
+	*ControllerAPIv9
 }
 
 // ControllerAPIv7 provides the v7 Controller API. The only difference
@@ -95,10 +109,10 @@ type ControllerAPIv3 struct {
 
 // LatestAPI is used for testing purposes to create the latest
 // controller API.
-var LatestAPI = NewControllerAPIv9
+var LatestAPI = NewControllerAPIv11
 
-// NewControllerAPIv9 creates a new ControllerAPIv9.
-func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv11 creates a new ControllerAPIv11.
+func NewControllerAPIv11(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -120,6 +134,24 @@ func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv10 creates a new ControllerAPIv10.
+func NewControllerAPIv10(ctx facade.Context) (*ControllerAPIv10, error) {
+	v11, err := NewControllerAPIv11(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv10{v11}, nil
+}
+
+// NewControllerAPIv9 creates a new ControllerAPIv9.
+func NewControllerAPIv9(ctx facade.Context) (*ControllerAPIv9, error) {
+	v10, err := NewControllerAPIv10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv9{v10}, nil
+}
+
 // NewControllerAPIv8 creates a new ControllerAPIv8.
 func NewControllerAPIv8(ctx facade.Context) (*ControllerAPIv8, error) {
 	v9, err := NewControllerAPIv9(ctx)
@@ -377,6 +409,11 @@ func (c *ControllerAPI) ListBlockedModels() (params.ModelBlockInfoList, error) {
 
 	modelBlocks := make(map[string][]string)
 	for _, block := range blocks {
+		// Blocks on a single application, machine or relation
+		// do not disable any command set for the model.
+		if len(block.Operations()) > 0 {
+			continue
+		}
 		uuid := block.ModelUUID()
 		types, ok := modelBlocks[uuid]
 		if !ok {
@@ -601,41 +638,243 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 }
 
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
-	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	modelTag, targetInfo, err := c.parseMigrationSpec(spec)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return "", errors.Trace(err)
 	}
 
-	// Ensure the model exists.
-	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
-		return "", errors.Annotate(err, "reading model")
-	} else if !modelExists {
-		return "", errors.NotFoundf("model")
+	hostedState, err := c.statePool.Get(modelTag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
 	}
+	defer hostedState.Release()
 
-	hostedState, err := c.statePool.Get(modelTag.Id())
+	// Check if the migration is likely to succeed.
+	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+	})
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	return mig.Id(), nil
+}
+
+// MigrationDryRun runs every check which would be made before
+// migrating each of the models, without starting any migrations. As
+// well as the source and target prechecks, the model is exported and
+// the target controller checks its description for problems known to
+// stop an import. The model isn't imported, so a dry run can't catch
+// every import failure. Every problem found is reported, rather than
+// just the first.
+func (c *ControllerAPI) MigrationDryRun(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationDryRunResults, error,
+) {
+	out := params.MigrationDryRunResults{
+		Results: make([]params.MigrationDryRunResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkIsSuperUser(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		report, err := c.oneMigrationDryRun(spec)
+		if err != nil {
+			result.Error = apiservererrors.ServerError(err)
+		} else {
+			result.Blockers = report.Blockers
+			result.Warnings = report.Warnings
+		}
+	}
+	return out, nil
+}
+
+// MigrationDryRun isn't on the v9 API.
+func (c *ControllerAPIv9) MigrationDryRun(_, _ struct{}) {}
+
+func (c *ControllerAPI) oneMigrationDryRun(spec params.MigrationSpec) (*migration.PrecheckReport, error) {
+	modelTag, targetInfo, err := c.parseMigrationSpec(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	hostedState, err := c.statePool.Get(modelTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer hostedState.Release()
+
+	return runMigrationDryRun(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence)
+}
+
+// MigrationProgress returns the progress of the latest migration of
+// each of the models: when it entered each phase, how many entities
+// were exported and imported, how many logs have been transferred
+// and, while the model is still on this controller, which agents
+// have and haven't reported for each phase that waits for them. A
+// model which has been migrated away reports its completed migration.
+func (c *ControllerAPI) MigrationProgress(args params.Entities) (params.MigrationProgressResults, error) {
+	results := params.MigrationProgressResults{
+		Results: make([]params.MigrationProgressResult, len(args.Entities)),
+	}
+	if err := c.checkIsSuperUser(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Entities {
+		progress, err := c.oneMigrationProgress(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = progress
+	}
+	return results, nil
+}
+
+// MigrationProgress isn't on the v10 API.
+func (c *ControllerAPIv10) MigrationProgress(_, _ struct{}) {}
+
+func (c *ControllerAPI) oneMigrationProgress(tag string) (*params.MigrationProgress, error) {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelExists, err := c.state.ModelExists(modelTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !modelExists {
+		// Once a model has been migrated away its migration is still
+		// recorded here.
+		mig, err := c.state.CompletedMigrationForModel(modelTag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return migrationProgress(mig, false)
+	}
+
+	hostedState, err := c.statePool.Get(modelTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	defer hostedState.Release()
+	mig, err := hostedState.LatestMigration()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return migrationProgress(mig, true)
+}
+
+// minionReportPhases are the phases in which a migration waits for
+// the model's agents to report.
+var minionReportPhases = set.NewStrings(
+	coremigration.QUIESCE.String(),
+	coremigration.VALIDATION.String(),
+	coremigration.SUCCESS.String(),
+)
+
+func migrationProgress(mig state.ModelMigration, withReports bool) (*params.MigrationProgress, error) {
+	phase, err := mig.Phase()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	targetInfo, err := mig.TargetInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	target := targetInfo.ControllerAlias
+	if target == "" {
+		target = targetInfo.ControllerTag.Id()
+	}
+	startTime := mig.StartTime()
+	result := &params.MigrationProgress{
+		MigrationId:      mig.Id(),
+		ModelTag:         names.NewModelTag(mig.ModelUUID()).String(),
+		TargetController: target,
+		Phase:            phase.String(),
+		Status:           mig.StatusMessage(),
+		Start:            &startTime,
+	}
+	if endTime := mig.EndTime(); !endTime.IsZero() {
+		result.End = &endTime
+	}
+	for _, phaseTime := range mig.PhaseTimes() {
+		result.PhaseTimes = append(result.PhaseTimes, params.MigrationPhaseTime{
+			Phase: phaseTime.Phase.String(),
+			Time:  phaseTime.Time,
+		})
+		if withReports && minionReportPhases.Contains(phaseTime.Phase.String()) {
+			reports, err := mig.MinionReportsForPhase(phaseTime.Phase)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			result.MinionReports = append(result.MinionReports, params.MigrationMinionReports{
+				Phase:     phaseTime.Phase.String(),
+				Succeeded: tagStrings(reports.Succeeded),
+				Failed:    tagStrings(reports.Failed),
+				Waiting:   tagStrings(reports.Unknown),
+			})
+		}
+	}
+	progress := mig.Progress()
+	result.ExportedEntities = progress.ExportedEntities
+	result.ImportedEntities = progress.ImportedEntities
+	result.LogsTransferred = progress.LogsTransferred
+	if !progress.LastLogTime.IsZero() {
+		result.LastLogTime = &progress.LastLogTime
+	}
+	return result, nil
+}
+
+func tagStrings(tags []names.Tag) []string {
+	var out []string
+	for _, tag := range tags {
+		out = append(out, tag.String())
+	}
+	sort.Strings(out)
+	return out
+}
+
+// parseMigrationSpec checks the model to be migrated exists, and
+// returns its tag along with the details of the target controller.
+func (c *ControllerAPI) parseMigrationSpec(spec params.MigrationSpec) (names.ModelTag, coremigration.TargetInfo, error) {
+	var targetInfo coremigration.TargetInfo
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return modelTag, targetInfo, errors.Annotate(err, "model tag")
+	}
+
+	// Ensure the model exists.
+	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
+		return modelTag, targetInfo, errors.Annotate(err, "reading model")
+	} else if !modelExists {
+		return modelTag, targetInfo, errors.NotFoundf("model")
+	}
 
 	// Construct target info.
 	specTarget := spec.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return modelTag, targetInfo, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return modelTag, targetInfo, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return modelTag, targetInfo, errors.Annotate(err, "invalid macaroons")
 		}
 	}
-	targetInfo := coremigration.TargetInfo{
+	targetInfo = coremigration.TargetInfo{
 		ControllerTag:   controllerTag,
 		ControllerAlias: specTarget.ControllerAlias,
 		Addrs:           specTarget.Addrs,
@@ -644,21 +883,7 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		Password:        specTarget.Password,
 		Macaroons:       macs,
 	}
-
-	// Check if the migration is likely to succeed.
-	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
-		return "", errors.Trace(err)
-	}
-
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
-	if err != nil {
-		return "", errors.Trace(err)
-	}
-	return mig.Id(), nil
+	return modelTag, targetInfo, nil
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -783,6 +1008,92 @@ var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigrat
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+// runMigrationDryRun runs the source prechecks, exports the model and
+// asks the target controller to run its prechecks and check the
+// model's description, returning every problem found.
+var runMigrationDryRun = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence) (*migration.PrecheckReport, error) {
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st, ctlrSt)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating backend")
+	}
+	modelPresence := presence.ModelPresence(st.ModelUUID())
+	controllerPresence := presence.ModelPresence(ctlrSt.ModelUUID())
+	report, err := migration.SourcePrecheckReport(backend, modelPresence, controllerPresence)
+	if err != nil {
+		return nil, errors.Annotate(err, "running source prechecks")
+	}
+	serialized, err := migration.ExportModel(st)
+	if err != nil {
+		report.AddBlocker("model can't be exported: %v", err)
+	}
+
+	// Check target controller.
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return nil, errors.Annotate(err, "connect to target controller")
+	}
+	defer conn.Close()
+	modelInfo, srcUserList, err := makeModelInfo(st, ctlrSt)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dstUserList, err := getTargetControllerUsers(conn)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = srcUserList.checkCompatibilityWith(dstUserList); err != nil {
+		report.AddBlocker("%v", err)
+	}
+
+	targetReport, err := targetDryRun(migrationtarget.NewClient(conn), ctlrSt, modelInfo, serialized, report)
+	if err != nil {
+		return nil, errors.Annotate(err, "running target prechecks")
+	}
+	report.Merge("target controller", targetReport)
+	return report, nil
+}
+
+// targetDryRun asks the target controller to run its prechecks and
+// check the model import. If the model couldn't be exported, or the
+// target controller can't check imports, only its prechecks are run.
+// Any difference between the clocks of the source and target
+// controllers is added to report as a warning.
+func targetDryRun(
+	client *migrationtarget.Client,
+	ctlrSt *state.State,
+	modelInfo coremigration.ModelInfo,
+	serialized []byte,
+	report *migration.PrecheckReport,
+) (*migration.PrecheckReport, error) {
+	targetReport := &migration.PrecheckReport{}
+	if serialized != nil {
+		before, err := ctlrSt.ControllerTimestamp()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		check, err := client.DryRunPrechecks(modelInfo, serialized)
+		if err == nil {
+			after, err := ctlrSt.ControllerTimestamp()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			migration.CheckClockSkew(report, *before, *after, check.Time)
+			targetReport.Blockers = check.Blockers
+			targetReport.Warnings = check.Warnings
+			return targetReport, nil
+		}
+		if !errors.IsNotSupported(err) {
+			return nil, errors.Trace(err)
+		}
+		report.AddWarning("target controller is too old to check the model import or its clock")
+	}
+	if err := client.Prechecks(modelInfo); err != nil {
+		targetReport.AddBlocker("%v", err)
+	}
+	return targetReport, nil
+}
+
 // userList encapsulates information about the users who have been granted
 // access to a model or the users known to a particular controller.
 type userList struct {
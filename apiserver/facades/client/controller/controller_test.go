@@ -29,10 +29,12 @@ import (
 	"github.com/juju/juju/cloud"
 	corecontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/environs"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/migration"
 	pscontroller "github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -617,6 +619,164 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestMigrationDryRun(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetDryRunResult(s, &migration.PrecheckReport{
+		Blockers: []string{"machine 0 is dying", "target controller: upgrade in progress"},
+		Warnings: []string{"target controller clock differs from source controller by 5m0s"},
+	}, nil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{
+			{
+				ModelTag: m.ModelTag().String(),
+				TargetInfo: params.MigrationTargetInfo{
+					ControllerTag: randomControllerTag(),
+					Addrs:         []string{"1.1.1.1:1111"},
+					CACert:        "cert1",
+					AuthTag:       names.NewUserTag("admin1").String(),
+					Password:      "secret1",
+				},
+			}, {
+				ModelTag: randomModelTag(), // Doesn't exist.
+			},
+		},
+	}
+	out, err := s.controller.MigrationDryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+	c.Check(out.Results[0], jc.DeepEquals, params.MigrationDryRunResult{
+		ModelTag: m.ModelTag().String(),
+		Blockers: []string{"machine 0 is dying", "target controller: upgrade in progress"},
+		Warnings: []string{"target controller clock differs from source controller by 5m0s"},
+	})
+	c.Check(out.Results[1].ModelTag, gc.Equals, args.Specs[1].ModelTag)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "model not found")
+
+	// No migration was started.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestMigrationDryRunError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetDryRunResult(s, nil, errors.New("connect to target controller: boom"))
+
+	out, err := s.controller.MigrationDryRun(params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "connect to target controller: boom")
+}
+
+func (s *controllerSuite) TestMigrationDryRunNotSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.LatestAPI(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.MigrationDryRun(params.InitiateMigrationArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestMigrationProgress(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	m0 := f.MakeMachine(c, nil)
+	m1 := f.MakeMachine(c, nil)
+
+	mig, err := st.CreateMigration(state.MigrationSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		TargetInfo: coremigration.TargetInfo{
+			ControllerTag:   names.NewControllerTag(utils.MustNewUUID().String()),
+			ControllerAlias: "target",
+			Addrs:           []string{"1.1.1.1:1111"},
+			CACert:          "cert",
+			AuthTag:         names.NewUserTag("admin"),
+			Password:        "secret",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SubmitMinionReport(m0.Tag(), coremigration.QUIESCE, true), jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(coremigration.IMPORT), jc.ErrorIsNil)
+	c.Assert(mig.SetProgress(coremigration.Progress{
+		ExportedEntities: map[string]int{"machines": 2},
+	}), jc.ErrorIsNil)
+
+	out, err := s.controller.MigrationProgress(params.Entities{
+		Entities: []params.Entity{
+			{Tag: names.NewModelTag(st.ModelUUID()).String()},
+			{Tag: randomModelTag()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+	progress := out.Results[0].Result
+	c.Check(progress.MigrationId, gc.Equals, mig.Id())
+	c.Check(progress.TargetController, gc.Equals, "target")
+	c.Check(progress.Phase, gc.Equals, "IMPORT")
+	c.Check(progress.End, gc.IsNil)
+	c.Assert(progress.PhaseTimes, gc.HasLen, 2)
+	c.Check(progress.PhaseTimes[0].Phase, gc.Equals, "QUIESCE")
+	c.Check(progress.PhaseTimes[1].Phase, gc.Equals, "IMPORT")
+	c.Check(progress.ExportedEntities, jc.DeepEquals, map[string]int{"machines": 2})
+	c.Check(progress.MinionReports, jc.DeepEquals, []params.MigrationMinionReports{{
+		Phase:     "QUIESCE",
+		Succeeded: []string{m0.Tag().String()},
+		Waiting:   []string{m1.Tag().String()},
+	}})
+	c.Check(out.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *controllerSuite) TestMigrationProgressNotSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.LatestAPI(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.MigrationProgress(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
@@ -1091,6 +1251,7 @@ func (s *controllerSuite) TestWatchAllModelSummariesByAdmin(c *gc.C) {
 				{
 					UUID:       "deadbeef-0bad-400d-8000-4b1d0d06f00d",
 					Controller: "", // TODO(thumper): add controller name next branch
+					Namespace:  "test-admin",
 					Name:       "controller",
 					Admins:     []string{"test-admin"},
 					Cloud:      "dummy",
@@ -1158,6 +1319,7 @@ func (s *controllerSuite) TestWatchModelSummariesByNonAdmin(c *gc.C) {
 				{
 					UUID:       "deadbeef-0bad-400d-8000-4b1d0d06f00d",
 					Controller: "", // TODO(thumper): add controller name next branch
+					Namespace:  "test-admin",
 					Name:       "controller",
 					Admins:     []string{"test-admin"},
 					Cloud:      "dummy",
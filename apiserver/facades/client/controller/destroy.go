@@ -79,7 +79,13 @@ func ensureNotBlocked(st *state.State) error {
 		logger.Debugf("Unable to get blocks for controller: %s", err)
 		return errors.Trace(err)
 	}
-	if len(blocks) > 0 {
+	for _, block := range blocks {
+		// Blocks on a single application, machine or relation
+		// are not reported by ListBlockedModels, so they must
+		// not stop the controller from being destroyed either.
+		if len(block.Operations()) > 0 {
+			continue
+		}
 		return apiservererrors.OperationBlockedError("found blocks in controller models")
 	}
 	return nil
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/controller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/model"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
@@ -55,7 +56,7 @@ func (s *destroyControllerSuite) SetUpTest(c *gc.C) {
 	s.authorizer = apiservertesting.FakeAuthorizer{
 		Tag: s.AdminUserTag(c),
 	}
-	testController, err := controller.NewControllerAPIv9(
+	testController, err := controller.NewControllerAPIv11(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.StatePool,
@@ -115,6 +116,26 @@ func (s *destroyControllerSuite) TestDestroyControllerReturnsBlockedModelErr(c *
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *destroyControllerSuite) TestDestroyControllerIgnoresEntityBlocks(c *gc.C) {
+	f := factory.NewFactory(s.otherState, s.StatePool)
+	app := f.MakeApplication(c, nil)
+	err := s.otherState.SwitchEntityBlockOn(app.ApplicationTag(), []model.BlockOperation{model.BlockOperationRemove}, "frozen")
+	c.Assert(err, jc.ErrorIsNil)
+
+	blocked, err := s.controller.ListBlockedModels()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blocked.Models, gc.HasLen, 0)
+
+	err = s.controller.DestroyController(params.DestroyControllerArgs{
+		DestroyModels: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	controllerModel, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerModel.Life(), gc.Equals, state.Dying)
+}
+
 func (s *destroyControllerSuite) TestDestroyControllerKillsHostedModels(c *gc.C) {
 	err := s.controller.DestroyController(params.DestroyControllerArgs{
 		DestroyModels: true,
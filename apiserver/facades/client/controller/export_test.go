@@ -6,6 +6,7 @@ package controller
 import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/core/migration"
+	migrationpkg "github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
 
@@ -18,3 +19,9 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetDryRunResult(p patcher, report *migrationpkg.PrecheckReport, err error) {
+	p.PatchValue(&runMigrationDryRun, func(*state.State, *state.State, *migration.TargetInfo, facade.Presence) (*migrationpkg.PrecheckReport, error) {
+		return report, err
+	})
+}
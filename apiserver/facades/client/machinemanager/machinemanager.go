@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/instance"
+	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
@@ -349,6 +350,9 @@ func (mm *MachineManagerAPI) destroyMachine(args params.Entities, force, keep bo
 		if err != nil {
 			return fail(err)
 		}
+		if err := mm.check.EntityOperationAllowed(coremodel.BlockOperationRemove, machineTag); err != nil {
+			return fail(err)
+		}
 		machine, err := mm.st.Machine(machineTag.Id())
 		if err != nil {
 			return fail(err)
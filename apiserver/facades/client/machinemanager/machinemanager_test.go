@@ -252,6 +252,27 @@ func (s *MachineManagerSuite) assertMachinesDestroyed(c *gc.C, in []params.Entit
 
 }
 
+func (s *MachineManagerSuite) TestDestroyMachineEntityBlocked(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.st.machines["0"] = &mockMachine{}
+	s.st.entityBlocks = map[names.Tag]state.Block{
+		names.NewMachineTag("0"): &mockBlock{
+			t:   state.ChangeBlock,
+			m:   "database host",
+			ops: []model.BlockOperation{model.BlockOperationRemove},
+		},
+	}
+	results, err := s.api.DestroyMachine(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "remove operations on machine 0 have been blocked: database host")
+	c.Assert(params.IsCodeOperationBlocked(results.Results[0].Error), jc.IsTrue)
+	s.st.CheckCallNames(c, "ModelTag", "GetBlockForType", "GetBlockForType", "GetEntityBlock")
+}
+
 func (s *MachineManagerSuite) TestDestroyMachineFailedAllStorageRetrieval(c *gc.C) {
 	defer s.setup(c).Finish()
 
@@ -269,6 +290,7 @@ func (s *MachineManagerSuite) TestDestroyMachineFailedAllStorageRetrieval(c *gc.
 		"ModelTag",
 		"GetBlockForType",
 		"GetBlockForType",
+		"GetEntityBlock",
 		"Machine",
 		"UnitStorageAttachments",
 		"UnitStorageAttachments",
@@ -293,6 +315,7 @@ func (s *MachineManagerSuite) TestDestroyMachineFailedAllStorageClassification(c
 		"ModelTag",
 		"GetBlockForType",
 		"GetBlockForType",
+		"GetEntityBlock",
 		"Machine",
 		"UnitStorageAttachments",
 		"StorageInstance",
@@ -330,6 +353,7 @@ func (s *MachineManagerSuite) TestDestroyMachineFailedSomeUnitStorageRetrieval(c
 		"ModelTag",
 		"GetBlockForType",
 		"GetBlockForType",
+		"GetEntityBlock",
 		"Machine",
 		"UnitStorageAttachments",
 		"VolumeAccess",
@@ -387,6 +411,7 @@ func (s *MachineManagerSuite) TestDestroyMachineFailedSomeStorageRetrievalManyMa
 		"ModelTag",
 		"GetBlockForType",
 		"GetBlockForType",
+		"GetEntityBlock",
 		"Machine",
 		"UnitStorageAttachments",
 		"VolumeAccess",
@@ -395,6 +420,7 @@ func (s *MachineManagerSuite) TestDestroyMachineFailedSomeStorageRetrievalManyMa
 		"UnitStorageAttachments",
 		"VolumeAccess",
 		"FilesystemAccess",
+		"GetEntityBlock",
 		"Machine",
 		"UnitStorageAttachments",
 		"StorageInstance",
@@ -894,6 +920,7 @@ type mockState struct {
 	err              error
 	blockMsg         string
 	block            state.BlockType
+	entityBlocks     map[names.Tag]state.Block
 
 	unitStorageAttachmentsF func(tag names.UnitTag) ([]state.StorageAttachment, error)
 }
@@ -953,6 +980,14 @@ func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, erro
 	}
 }
 
+func (st *mockState) GetEntityBlock(tag names.Tag) (state.Block, bool, error) {
+	st.MethodCall(st, "GetEntityBlock", tag)
+	if b, ok := st.entityBlocks[tag]; ok {
+		return b, true, nil
+	}
+	return nil, false, nil
+}
+
 func (st *mockState) ModelTag() names.ModelTag {
 	st.MethodCall(st, "ModelTag")
 	return names.NewModelTag("deadbeef-2f18-4fd2-967d-db9663db7bea")
@@ -997,8 +1032,9 @@ func (st *mockState) UnitStorageAttachments(tag names.UnitTag) ([]state.StorageA
 
 type mockBlock struct {
 	state.Block
-	t state.BlockType
-	m string
+	t   state.BlockType
+	m   string
+	ops []model.BlockOperation
 }
 
 func (st *mockBlock) Id() string {
@@ -1021,6 +1057,10 @@ func (st *mockBlock) ModelUUID() string {
 	return "uuid"
 }
 
+func (st *mockBlock) Operations() []model.BlockOperation {
+	return st.ops
+}
+
 type mockMachine struct {
 	jtesting.Stub
 	machinemanager.Machine
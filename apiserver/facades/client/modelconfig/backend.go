@@ -7,8 +7,11 @@ import (
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
 )
 
 // Backend contains the state.State methods used in this package,
@@ -18,7 +21,11 @@ type Backend interface {
 	ControllerTag() names.ControllerTag
 	ModelTag() names.ModelTag
 	ModelConfigValues() (config.ConfigValues, error)
-	UpdateModelConfig(map[string]interface{}, []string, ...state.ValidateConfigFunc) error
+	UpdateModelConfigByUser(string, map[string]interface{}, []string, ...state.ValidateConfigFunc) error
+	ModelConfigHistory() ([]state.ConfigRevision, error)
+	RevertModelConfig(int, string, ...state.ValidateConfigFunc) error
+	ValidateModelConfigUpdate(map[string]interface{}, []string, ...state.ValidateConfigFunc) (*config.Config, *config.Config, error)
+	ConfigSetter() (environs.ConfigSetter, error)
 	Sequences() (map[string]int, error)
 	SetSLA(level, owner string, credentials []byte) error
 	SLALevel() (string, error)
@@ -30,8 +37,31 @@ type stateShim struct {
 	model *state.Model
 }
 
-func (st stateShim) UpdateModelConfig(u map[string]interface{}, r []string, a ...state.ValidateConfigFunc) error {
-	return st.model.UpdateModelConfig(u, r, a...)
+func (st stateShim) UpdateModelConfigByUser(user string, u map[string]interface{}, r []string, a ...state.ValidateConfigFunc) error {
+	return st.model.UpdateModelConfigByUser(user, u, r, a...)
+}
+
+func (st stateShim) ModelConfigHistory() ([]state.ConfigRevision, error) {
+	return st.model.ConfigHistory()
+}
+
+func (st stateShim) RevertModelConfig(revision int, user string, a ...state.ValidateConfigFunc) error {
+	return st.model.RevertConfig(revision, user, a...)
+}
+
+func (st stateShim) ValidateModelConfigUpdate(
+	u map[string]interface{}, r []string, a ...state.ValidateConfigFunc,
+) (*config.Config, *config.Config, error) {
+	return st.model.ValidateModelConfigUpdate(u, r, a...)
+}
+
+// ConfigSetter opens a new environ, or CAAS broker, for the model.
+// Changes made through it are not persisted.
+func (st stateShim) ConfigSetter() (environs.ConfigSetter, error) {
+	if st.model.Type() == state.ModelTypeCAAS {
+		return stateenvirons.GetNewCAASBrokerFunc(caas.New)(st.model)
+	}
+	return stateenvirons.GetNewEnvironFunc(environs.New)(st.model)
 }
 
 func (st stateShim) ModelConfigValues() (config.ConfigValues, error) {
@@ -16,8 +16,8 @@ import (
 	"github.com/juju/juju/state"
 )
 
-// NewFacade is used for API registration.
-func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
+// NewFacadeV4 is used for API registration.
+func NewFacadeV4(ctx facade.Context) (*ModelConfigAPIV4, error) {
 	auth := ctx.Auth()
 
 	model, err := ctx.State().Model()
@@ -27,6 +27,24 @@ func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
 	return NewModelConfigAPI(NewStateBackend(model), auth)
 }
 
+// NewFacadeV3 is used for API registration.
+func NewFacadeV3(ctx facade.Context) (*ModelConfigAPIV3, error) {
+	api, err := NewFacadeV4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelConfigAPIV3{api}, nil
+}
+
+// NewFacadeV2 is used for API registration.
+func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
+	api, err := NewFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelConfigAPIV2{api}, nil
+}
+
 // NewFacadeV1 is used for API registration.
 func NewFacadeV1(ctx facade.Context) (*ModelConfigAPIV1, error) {
 	api, err := NewFacadeV2(ctx)
@@ -37,25 +55,35 @@ func NewFacadeV1(ctx facade.Context) (*ModelConfigAPIV1, error) {
 }
 
 // ModelConfigAPI provides the base implementation of the methods
-// for the V2 and V1 api calls.
+// for the V4, V3, V2 and V1 api calls.
 type ModelConfigAPI struct {
 	backend Backend
 	auth    facade.Authorizer
 	check   *common.BlockChecker
 }
 
-// ModelConfigAPIV2 is currently the latest.
-type ModelConfigAPIV2 struct {
+// ModelConfigAPIV4 is currently the latest.
+type ModelConfigAPIV4 struct {
 	*ModelConfigAPI
 }
 
+// ModelConfigAPIV3 hides V4 functionality
+type ModelConfigAPIV3 struct {
+	*ModelConfigAPIV4
+}
+
+// ModelConfigAPIV2 hides V3 functionality
+type ModelConfigAPIV2 struct {
+	*ModelConfigAPIV3
+}
+
 // ModelConfigAPIV1 hides V2 functionality
 type ModelConfigAPIV1 struct {
 	*ModelConfigAPIV2
 }
 
 // NewModelConfigAPI creates a new instance of the ModelConfig Facade.
-func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelConfigAPIV2, error) {
+func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelConfigAPIV4, error) {
 	if !authorizer.AuthClient() {
 		return nil, apiservererrors.ErrPerm
 	}
@@ -64,7 +92,7 @@ func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelCon
 		auth:    authorizer,
 		check:   common.NewBlockChecker(backend),
 	}
-	return &ModelConfigAPIV2{client}, nil
+	return &ModelConfigAPIV4{client}, nil
 }
 
 func (c *ModelConfigAPI) checkCanWrite() error {
@@ -144,21 +172,30 @@ func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
 		return errors.Trace(err)
 	}
 
-	// Make sure we don't allow changing agent-version.
-	checkAgentVersion := c.checkAgentVersion()
-
-	// Make sure we don't allow changing of the charmhub-url.
-	checkCharmhubURL := c.checkCharmhubURL()
-
-	// Only controller admins can set trace level debugging on a model.
-	checkLogTrace := c.checkLogTrace()
-
-	// Make sure DefaultSpace exists.
-	checkDefaultSpace := c.checkDefaultSpace()
-
 	// Replace any deprecated attributes with their new values.
 	attrs := config.ProcessDeprecatedAttributes(args.Config)
-	return c.backend.UpdateModelConfig(attrs, nil, checkAgentVersion, checkLogTrace, checkDefaultSpace, checkCharmhubURL)
+	return c.backend.UpdateModelConfigByUser(c.userName(), attrs, nil, c.setValidators()...)
+}
+
+// userName returns the name of the user making the API call,
+// to be recorded in the model's config history.
+func (c *ModelConfigAPI) userName() string {
+	return c.auth.GetAuthTag().Id()
+}
+
+// setValidators returns the checks applied to attributes being set,
+// beyond the validation done by state and the provider.
+func (c *ModelConfigAPI) setValidators() []state.ValidateConfigFunc {
+	return []state.ValidateConfigFunc{
+		// Make sure we don't allow changing agent-version.
+		c.checkAgentVersion(),
+		// Only controller admins can set trace level debugging on a model.
+		c.checkLogTrace(),
+		// Make sure DefaultSpace exists.
+		c.checkDefaultSpace(),
+		// Make sure we don't allow changing of the charmhub-url.
+		c.checkCharmhubURL(),
+	}
 }
 
 func (c *ModelConfigAPI) checkLogTrace() state.ValidateConfigFunc {
@@ -244,7 +281,72 @@ func (c *ModelConfigAPI) ModelUnset(args params.ModelUnset) error {
 	if err := c.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
-	return c.backend.UpdateModelConfig(nil, args.Keys)
+	return c.backend.UpdateModelConfigByUser(c.userName(), nil, args.Keys)
+}
+
+// ModelConfigHistory returns the retained revisions of the model's
+// config, most recent first.
+func (c *ModelConfigAPI) ModelConfigHistory() (params.ConfigHistoryResult, error) {
+	result := params.ConfigHistoryResult{}
+	if err := c.canReadModel(); err != nil {
+		return result, errors.Trace(err)
+	}
+	revisions, err := c.backend.ModelConfigHistory()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Revisions = common.ConfigRevisions(revisions)
+	return result, nil
+}
+
+// RevertModelConfig restores the model's config to the values it had
+// immediately after the given revision. The restored values are checked
+// as they would be by ModelSet.
+func (c *ModelConfigAPI) RevertModelConfig(args params.RevertModelConfig) error {
+	if err := c.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return c.backend.RevertModelConfig(args.Revision, c.userName(), c.setValidators()...)
+}
+
+// ValidateModelConfig reports the effect that setting and resetting the
+// given attributes would have on the model config, without persisting
+// anything. The change is checked as ModelSet and ModelUnset would check
+// it, and the resulting config is also passed to the SetConfig method of
+// a freshly opened environ, so that provider-level errors are reported.
+func (c *ModelConfigAPI) ValidateModelConfig(args params.ModelConfigDryRun) (params.ConfigDryRunResult, error) {
+	result := params.ConfigDryRunResult{}
+	if err := c.checkCanWrite(); err != nil {
+		return result, err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		result.Error = apiservererrors.ServerError(err)
+		return result, nil
+	}
+
+	attrs := config.ProcessDeprecatedAttributes(args.Config)
+	var validators []state.ValidateConfigFunc
+	if len(attrs) > 0 {
+		validators = c.setValidators()
+	}
+	oldCfg, newCfg, err := c.backend.ValidateModelConfigUpdate(attrs, args.Reset, validators...)
+	if err != nil {
+		result.Error = apiservererrors.ServerError(err)
+		return result, nil
+	}
+	result.Changes = common.ConfigChanges(oldCfg.AllAttrs(), newCfg.AllAttrs(), attrs, args.Reset)
+
+	env, err := c.backend.ConfigSetter()
+	if err != nil {
+		return result, errors.Annotate(err, "opening environ")
+	}
+	if err := env.SetConfig(newCfg); err != nil {
+		result.Error = apiservererrors.ServerError(errors.Annotate(err, "provider rejected config"))
+	}
+	return result, nil
 }
 
 // SetSLALevel sets the sla level on the model.
@@ -287,5 +389,14 @@ func (c *ModelConfigAPI) Sequences() (params.ModelSequencesResult, error) {
 // rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
 // removes the method as far as the RPC machinery is concerned.
 
+// ModelConfigHistory isn't on the V3 API.
+func (a *ModelConfigAPIV3) ModelConfigHistory(_, _ struct{}) {}
+
+// RevertModelConfig isn't on the V3 API.
+func (a *ModelConfigAPIV3) RevertModelConfig(_, _ struct{}) {}
+
+// ValidateModelConfig isn't on the V2 API.
+func (a *ModelConfigAPIV2) ValidateModelConfig(_, _ struct{}) {}
+
 // Sequences isn't on the V1 API.
 func (a *ModelConfigAPIV1) Sequences(_, _ struct{}) {}
@@ -4,6 +4,8 @@
 package modelconfig_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	gitjujutesting "github.com/juju/testing"
@@ -13,6 +15,8 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/settings"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/dummy"
@@ -24,7 +28,7 @@ type modelconfigSuite struct {
 	gitjujutesting.IsolationSuite
 	backend    *mockBackend
 	authorizer apiservertesting.FakeAuthorizer
-	api        *modelconfig.ModelConfigAPIV2
+	api        *modelconfig.ModelConfigAPIV4
 }
 
 var _ = gc.Suite(&modelconfigSuite{})
@@ -199,7 +203,7 @@ func (s *modelconfigSuite) TestUserCannotSetLogTrace(c *gc.C) {
 }
 
 func (s *modelconfigSuite) TestModelUnset(c *gc.C) {
-	err := s.backend.UpdateModelConfig(map[string]interface{}{"abc": 123}, nil)
+	err := s.backend.UpdateModelConfigByUser("", map[string]interface{}{"abc": 123}, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	args := params.ModelUnset{[]string{"abc"}}
@@ -209,7 +213,7 @@ func (s *modelconfigSuite) TestModelUnset(c *gc.C) {
 }
 
 func (s *modelconfigSuite) TestBlockModelUnset(c *gc.C) {
-	err := s.backend.UpdateModelConfig(map[string]interface{}{"abc": 123}, nil)
+	err := s.backend.UpdateModelConfigByUser("", map[string]interface{}{"abc": 123}, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	s.blockAllChanges(c, "TestBlockModelUnset")
 
@@ -230,11 +234,198 @@ func (s *modelconfigSuite) TestSetSupportCredentals(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) setOldConfig(c *gc.C) {
+	old, err := config.New(config.UseDefaults, dummy.SampleConfig().Merge(testing.Attrs{
+		"agent-version": "1.2.3.4",
+		"arbitrary-key": "shazam!",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.old = old
+}
+
+func (s *modelconfigSuite) TestValidateModelConfig(c *gc.C) {
+	s.setOldConfig(c)
+	result, err := s.api.ValidateModelConfig(params.ModelConfigDryRun{
+		Config: map[string]interface{}{
+			"ftp-proxy": "http://another-proxy",
+			"test-mode": "true",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Changes, jc.DeepEquals, []params.ConfigChange{
+		{Key: "ftp-proxy", Old: "", New: "http://another-proxy"},
+		{Key: "test-mode", Old: false, New: true, Input: "true"},
+	})
+
+	// The provider saw the new config, but nothing was written.
+	c.Assert(s.backend.env.cfg, gc.NotNil)
+	c.Assert(s.backend.env.cfg.AllAttrs()["ftp-proxy"], gc.Equals, "http://another-proxy")
+	s.assertConfigValue(c, "ftp-proxy", "http://proxy")
+}
+
+func (s *modelconfigSuite) TestValidateModelConfigReset(c *gc.C) {
+	s.setOldConfig(c)
+	result, err := s.api.ValidateModelConfig(params.ModelConfigDryRun{
+		Reset: []string{"arbitrary-key"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Changes, jc.DeepEquals, []params.ConfigChange{
+		{Key: "arbitrary-key", Old: "shazam!", Reset: true},
+	})
+}
+
+func (s *modelconfigSuite) TestValidateModelConfigProviderError(c *gc.C) {
+	s.setOldConfig(c)
+	s.backend.env.err = errors.New("boom")
+	result, err := s.api.ValidateModelConfig(params.ModelConfigDryRun{
+		Config: map[string]interface{}{"ftp-proxy": "http://another-proxy"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "provider rejected config: boom")
+	c.Assert(result.Changes, gc.HasLen, 1)
+}
+
+func (s *modelconfigSuite) TestValidateModelConfigRunsSetChecks(c *gc.C) {
+	s.setOldConfig(c)
+	result, err := s.api.ValidateModelConfig(params.ModelConfigDryRun{
+		Config: map[string]interface{}{"agent-version": "9.9.9"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "agent-version cannot be changed")
+	c.Assert(result.Changes, gc.HasLen, 0)
+	c.Assert(s.backend.env.cfg, gc.IsNil)
+}
+
+func (s *modelconfigSuite) TestValidateModelConfigBlocked(c *gc.C) {
+	s.setOldConfig(c)
+	s.blockAllChanges(c, "TestValidateModelConfigBlocked")
+	result, err := s.api.ValidateModelConfig(params.ModelConfigDryRun{
+		Config: map[string]interface{}{"ftp-proxy": "http://another-proxy"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertBlocked(c, result.Error, "TestValidateModelConfigBlocked")
+}
+
+func (s *modelconfigSuite) TestValidateModelConfigReadAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	_, err := s.api.ValidateModelConfig(params.ModelConfigDryRun{})
+	c.Assert(errors.Cause(err), gc.ErrorMatches, "permission denied")
+}
+
 type mockBackend struct {
-	cfg config.ConfigValues
-	old *config.Config
-	b   state.BlockType
-	msg string
+	cfg     config.ConfigValues
+	old     *config.Config
+	b       state.BlockType
+	msg     string
+	env     mockEnviron
+	user    string
+	history []state.ConfigRevision
+
+	revertRevision   int
+	revertValidators int
+}
+
+func (s *modelconfigSuite) TestModelSetRecordsUser(c *gc.C) {
+	err := s.api.ModelSet(params.ModelSet{Config: map[string]interface{}{"some-key": "value"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.backend.user, gc.Equals, "bruce")
+
+	s.backend.user = ""
+	err = s.api.ModelUnset(params.ModelUnset{Keys: []string{"some-key"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.backend.user, gc.Equals, "bruce")
+}
+
+func (s *modelconfigSuite) TestModelConfigHistory(c *gc.C) {
+	created := time.Date(2020, 9, 1, 3, 0, 0, 0, time.UTC)
+	s.backend.history = []state.ConfigRevision{{
+		Revision:  3,
+		Scope:     state.ModelConfigScope,
+		Created:   created,
+		CreatedBy: "bob",
+		Changes: settings.ItemChanges{
+			settings.MakeModification("ftp-proxy", "http://proxy", "http://another-proxy"),
+		},
+	}}
+	result, err := s.api.ModelConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ConfigHistoryResult{
+		Revisions: []params.ConfigRevision{{
+			Revision:  3,
+			Scope:     "model",
+			Created:   created,
+			CreatedBy: "bob",
+			Changes: []params.ConfigChange{{
+				Key: "ftp-proxy",
+				Old: "http://proxy",
+				New: "http://another-proxy",
+			}},
+		}},
+	})
+}
+
+func (s *modelconfigSuite) TestModelConfigHistoryNoAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("someone")
+	s.authorizer.AdminTag = names.NewUserTag("someone-else")
+	_, err := s.api.ModelConfigHistory()
+	c.Assert(errors.Cause(err), gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelconfigSuite) TestRevertModelConfig(c *gc.C) {
+	err := s.api.RevertModelConfig(params.RevertModelConfig{Revision: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.backend.revertRevision, gc.Equals, 2)
+	c.Check(s.backend.user, gc.Equals, "bruce")
+	// The restored values are subject to the same checks as ModelSet.
+	c.Check(s.backend.revertValidators, gc.Equals, 4)
+}
+
+func (s *modelconfigSuite) TestBlockRevertModelConfig(c *gc.C) {
+	s.blockAllChanges(c, "TestBlockRevertModelConfig")
+	err := s.api.RevertModelConfig(params.RevertModelConfig{Revision: 2})
+	s.assertBlocked(c, err, "TestBlockRevertModelConfig")
+	c.Check(s.backend.revertRevision, gc.Equals, 0)
+}
+
+func (s *modelconfigSuite) TestRevertModelConfigReadAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	err := s.api.RevertModelConfig(params.RevertModelConfig{Revision: 2})
+	c.Assert(errors.Cause(err), gc.ErrorMatches, "permission denied")
+}
+
+type mockEnviron struct {
+	cfg *config.Config
+	err error
+}
+
+func (m *mockEnviron) SetConfig(cfg *config.Config) error {
+	m.cfg = cfg
+	return m.err
+}
+
+func (m *mockBackend) ValidateModelConfigUpdate(
+	update map[string]interface{}, remove []string, validate ...state.ValidateConfigFunc,
+) (*config.Config, *config.Config, error) {
+	for _, validateFunc := range validate {
+		if err := validateFunc(update, remove, m.old); err != nil {
+			return nil, nil, err
+		}
+	}
+	newCfg, err := m.old.Apply(update)
+	if err != nil {
+		return nil, nil, err
+	}
+	newCfg, err = newCfg.Remove(remove)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m.old, newCfg, nil
+}
+
+func (m *mockBackend) ConfigSetter() (environs.ConfigSetter, error) {
+	return &m.env, nil
 }
 
 func (m *mockBackend) ModelConfigValues() (config.ConfigValues, error) {
@@ -245,7 +436,10 @@ func (m *mockBackend) Sequences() (map[string]int, error) {
 	return nil, nil
 }
 
-func (m *mockBackend) UpdateModelConfig(update map[string]interface{}, remove []string, validate ...state.ValidateConfigFunc) error {
+func (m *mockBackend) UpdateModelConfigByUser(
+	user string, update map[string]interface{}, remove []string, validate ...state.ValidateConfigFunc,
+) error {
+	m.user = user
 	for _, validateFunc := range validate {
 		if err := validateFunc(update, remove, m.old); err != nil {
 			return err
@@ -260,6 +454,17 @@ func (m *mockBackend) UpdateModelConfig(update map[string]interface{}, remove []
 	return nil
 }
 
+func (m *mockBackend) ModelConfigHistory() ([]state.ConfigRevision, error) {
+	return m.history, nil
+}
+
+func (m *mockBackend) RevertModelConfig(revision int, user string, validate ...state.ValidateConfigFunc) error {
+	m.revertRevision = revision
+	m.revertValidators = len(validate)
+	m.user = user
+	return nil
+}
+
 func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	if m.b == t {
 		return &mockBlock{t: t, m: m.msg}, true, nil
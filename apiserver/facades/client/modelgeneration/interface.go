@@ -8,6 +8,7 @@ import (
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 )
 
@@ -28,6 +29,7 @@ type Model interface {
 	Branches() ([]Generation, error)
 	Generation(int) (Generation, error)
 	Generations() ([]Generation, error)
+	RevertCommit(int, string, string) ([]model.RevertConflict, error)
 }
 
 // ModelCache describes a cached model used by the model generation API.
@@ -44,7 +46,9 @@ type Generation interface {
 	CompletedBy() string
 	AssignAllUnits(string) error
 	AssignUnits(string, int) error
+	AssignUnitsWithPolicy(string, model.TrackPolicy) error
 	AssignUnit(string) error
+	SetProgression(model.BranchProgression) error
 	AssignedUnits() map[string][]string
 	Commit(string) (int, error)
 	Abort(string) error
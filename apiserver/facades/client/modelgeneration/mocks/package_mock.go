@@ -11,6 +11,7 @@ import (
 	charm_v6 "github.com/juju/charm/v7"
 	modelgeneration "github.com/juju/juju/apiserver/facades/client/modelgeneration"
 	cache "github.com/juju/juju/core/cache"
+	model "github.com/juju/juju/core/model"
 	settings "github.com/juju/juju/core/settings"
 	names_v3 "github.com/juju/names/v4"
 )
@@ -193,6 +194,21 @@ func (mr *MockModelMockRecorder) ModelTag() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelTag", reflect.TypeOf((*MockModel)(nil).ModelTag))
 }
 
+// RevertCommit mocks base method
+func (m *MockModel) RevertCommit(arg0 int, arg1, arg2 string) ([]model.RevertConflict, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertCommit", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.RevertConflict)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevertCommit indicates an expected call of RevertCommit
+func (mr *MockModelMockRecorder) RevertCommit(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertCommit", reflect.TypeOf((*MockModel)(nil).RevertCommit), arg0, arg1, arg2)
+}
+
 // MockGeneration is a mock of Generation interface
 type MockGeneration struct {
 	ctrl     *gomock.Controller
@@ -272,6 +288,20 @@ func (mr *MockGenerationMockRecorder) AssignUnits(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignUnits", reflect.TypeOf((*MockGeneration)(nil).AssignUnits), arg0, arg1)
 }
 
+// AssignUnitsWithPolicy mocks base method
+func (m *MockGeneration) AssignUnitsWithPolicy(arg0 string, arg1 model.TrackPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignUnitsWithPolicy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignUnitsWithPolicy indicates an expected call of AssignUnitsWithPolicy
+func (mr *MockGenerationMockRecorder) AssignUnitsWithPolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignUnitsWithPolicy", reflect.TypeOf((*MockGeneration)(nil).AssignUnitsWithPolicy), arg0, arg1)
+}
+
 // AssignedUnits mocks base method
 func (m *MockGeneration) AssignedUnits() map[string][]string {
 	m.ctrl.T.Helper()
@@ -399,6 +429,20 @@ func (mr *MockGenerationMockRecorder) GenerationId() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerationId", reflect.TypeOf((*MockGeneration)(nil).GenerationId))
 }
 
+// SetProgression mocks base method
+func (m *MockGeneration) SetProgression(arg0 model.BranchProgression) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProgression", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProgression indicates an expected call of SetProgression
+func (mr *MockGenerationMockRecorder) SetProgression(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProgression", reflect.TypeOf((*MockGeneration)(nil).SetProgression), arg0)
+}
+
 // MockApplication is a mock of Application interface
 type MockApplication struct {
 	ctrl     *gomock.Controller
@@ -15,6 +15,7 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/permission"
 )
@@ -32,10 +33,18 @@ type API struct {
 	modelCache        ModelCache
 }
 
-type APIV3 struct {
+type APIV5 struct {
 	*API
 }
 
+type APIV4 struct {
+	*APIV5
+}
+
+type APIV3 struct {
+	*APIV4
+}
+
 type APIV2 struct {
 	*APIV3
 }
@@ -44,8 +53,8 @@ type APIV1 struct {
 	*APIV2
 }
 
-// NewModelGenerationFacadeV4 provides the signature required for facade registration.
-func NewModelGenerationFacadeV4(ctx facade.Context) (*API, error) {
+// NewModelGenerationFacadeV6 provides the signature required for facade registration.
+func NewModelGenerationFacadeV6(ctx facade.Context) (*API, error) {
 	authorizer := ctx.Auth()
 	st := &stateShim{State: ctx.State()}
 	m, err := st.Model()
@@ -59,6 +68,24 @@ func NewModelGenerationFacadeV4(ctx facade.Context) (*API, error) {
 	return NewModelGenerationAPI(st, authorizer, m, &modelCacheShim{Model: mc})
 }
 
+// NewModelGenerationFacadeV5 provides the signature required for facade registration.
+func NewModelGenerationFacadeV5(ctx facade.Context) (*APIV5, error) {
+	v6, err := NewModelGenerationFacadeV6(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV5{v6}, nil
+}
+
+// NewModelGenerationFacadeV4 provides the signature required for facade registration.
+func NewModelGenerationFacadeV4(ctx facade.Context) (*APIV4, error) {
+	v5, err := NewModelGenerationFacadeV5(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV4{v5}, nil
+}
+
 // NewModelGenerationFacadeV3 provides the signature required for facade registration.
 func NewModelGenerationFacadeV3(ctx facade.Context) (*APIV3, error) {
 	v4, err := NewModelGenerationFacadeV4(ctx)
@@ -152,6 +179,20 @@ func (api *APIV2) TrackBranch(arg params.BranchTrackArg) (params.ErrorResults, e
 
 // TrackBranch marks the input units and/or applications as tracking the input
 // branch, causing them to realise changes made under that branch.
+func (api *APIV5) TrackBranch(arg params.BranchTrackArg) (params.ErrorResults, error) {
+	// Unit selection policies and progressive tracking were added in V6.
+	arg.Percent = 0
+	arg.PerZone = false
+	arg.Annotations = nil
+	arg.Progression = nil
+	return api.API.TrackBranch(arg)
+}
+
+// TrackBranch marks the input units and/or applications as tracking the input
+// branch, causing them to realise changes made under that branch.
+// Units of the input applications are selected by number, percentage,
+// availability zone, annotation and machine constraints, and may be
+// tracked progressively.
 func (api *API) TrackBranch(arg params.BranchTrackArg) (params.ErrorResults, error) {
 	isModelAdmin, err := api.hasAdminAccess()
 	if err != nil {
@@ -167,6 +208,37 @@ func (api *API) TrackBranch(arg params.BranchTrackArg) (params.ErrorResults, err
 	if arg.NumUnits > 0 && len(arg.Entities) > 1 {
 		return params.ErrorResults{}, errors.Errorf("number of units and unit IDs can not be specified at the same time")
 	}
+	cons, err := constraints.Parse(arg.Constraints)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	policy := model.TrackPolicy{
+		NumUnits:    arg.NumUnits,
+		Percent:     arg.Percent,
+		PerZone:     arg.PerZone,
+		Annotations: arg.Annotations,
+		Constraints: cons,
+	}
+	if err := policy.Validate(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	var progression *model.BranchProgression
+	if arg.Progression != nil {
+		// Without a starting percentage, progressive
+		// tracking starts with its first step.
+		if policy.Percent == 0 && policy.NumUnits == 0 {
+			policy.Percent = arg.Progression.StepPercent
+		}
+		progression = &model.BranchProgression{
+			Policy:      policy,
+			StepPercent: arg.Progression.StepPercent,
+			Interval:    arg.Progression.Interval,
+		}
+		if err := progression.Validate(); err != nil {
+			return params.ErrorResults{}, errors.Trace(err)
+		}
+	}
+	hasPolicy := arg.Percent > 0 || arg.PerZone || len(arg.Annotations) > 0 || arg.Constraints != "" || progression != nil
 
 	branch, err := api.model.Branch(arg.BranchName)
 	if err != nil {
@@ -184,14 +256,31 @@ func (api *API) TrackBranch(arg params.BranchTrackArg) (params.ErrorResults, err
 		}
 		switch tag.Kind() {
 		case names.ApplicationTagKind:
-			result.Results[i].Error = apiservererrors.ServerError(branch.AssignUnits(tag.Id(), arg.NumUnits))
+			if hasPolicy {
+				err = branch.AssignUnitsWithPolicy(tag.Id(), policy)
+			} else {
+				err = branch.AssignUnits(tag.Id(), arg.NumUnits)
+			}
+			result.Results[i].Error = apiservererrors.ServerError(err)
 		case names.UnitTagKind:
+			if hasPolicy {
+				result.Results[i].Error = apiservererrors.ServerError(
+					errors.Errorf("unit selection policies can not be used with unit %q", tag.Id()))
+				continue
+			}
 			result.Results[i].Error = apiservererrors.ServerError(branch.AssignUnit(tag.Id()))
 		default:
 			result.Results[i].Error = apiservererrors.ServerError(
 				errors.Errorf("expected names.UnitTag or names.ApplicationTag, got %T", tag))
 		}
 	}
+
+	// Only track progressively once the initial units are all tracking.
+	if progression != nil && result.Combine() == nil {
+		if err := branch.SetProgression(*progression); err != nil {
+			return params.ErrorResults{}, errors.Trace(err)
+		}
+	}
 	return result, nil
 }
 
@@ -420,6 +509,58 @@ func (api *API) getGenerationCommit(branch Generation) (params.Generation, error
 	}, nil
 }
 
+// RevertCommit adds a new branch holding the inverse of the charm config
+// changes made by the committed generation with the input ID, committing it
+// if requested. Changes that could not be reverted because they have since
+// been changed again are returned as conflicts.
+func (api *API) RevertCommit(arg params.RevertCommitArg) (params.RevertCommitResult, error) {
+	result := params.RevertCommitResult{}
+
+	isModelAdmin, err := api.hasAdminAccess()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isModelAdmin && !api.isControllerAdmin {
+		return result, apiservererrors.ErrPerm
+	}
+	if arg.GenerationId < 1 {
+		err := errors.Errorf("supplied generation id has to be higher than 0")
+		return revertCommitResultError(err)
+	}
+	if err := model.ValidateBranchName(arg.BranchName); err != nil {
+		return revertCommitResultError(err)
+	}
+
+	conflicts, err := api.model.RevertCommit(arg.GenerationId, arg.BranchName, api.apiUser.Name())
+	for _, c := range conflicts {
+		result.Conflicts = append(result.Conflicts, params.RevertConflict{
+			ApplicationName: c.Application,
+			Key:             c.Key,
+			Reason:          c.Reason,
+		})
+	}
+	if err != nil {
+		result.Error = apiservererrors.ServerError(err)
+		return result, nil
+	}
+	if !arg.Commit {
+		return result, nil
+	}
+
+	branch, err := api.model.Branch(arg.BranchName)
+	if err != nil {
+		result.Error = apiservererrors.ServerError(err)
+		return result, nil
+	}
+	if result.GenerationId, err = branch.Commit(api.apiUser.Name()); err != nil {
+		result.Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+// RevertCommit is not available before V5.
+func (*APIV4) RevertCommit(_, _ struct{}) {}
+
 // HasActiveBranch returns a true result if the input model has an "in-flight"
 // branch matching the input name.
 func (api *API) HasActiveBranch(arg params.BranchArg) (params.BoolResult, error) {
@@ -452,6 +593,10 @@ func generationResultError(err error) (params.GenerationResult, error) {
 	return params.GenerationResult{Error: apiservererrors.ServerError(err)}, nil
 }
 
+func revertCommitResultError(err error) (params.RevertCommitResult, error) {
+	return params.RevertCommitResult{Error: apiservererrors.ServerError(err)}, nil
+}
+
 func intResultsError(err error) (params.IntResult, error) {
 	return params.IntResult{Error: apiservererrors.ServerError(err)}, nil
 }
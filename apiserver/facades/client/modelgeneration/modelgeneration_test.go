@@ -4,9 +4,12 @@
 package modelgeneration_test
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
 	"github.com/juju/errors"
 	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -126,6 +129,110 @@ func (s *modelGenerationSuite) TestTrackBranchWithTooManyNumUnits(c *gc.C) {
 	c.Check(result.Results, gc.DeepEquals, []params.ErrorResult(nil))
 }
 
+func (s *modelGenerationSuite) TestTrackBranchWithPolicy(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	policy := model.TrackPolicy{
+		Percent:     10,
+		PerZone:     true,
+		Annotations: map[string]string{"canary": "true"},
+		Constraints: constraints.MustParse("arch=arm64"),
+	}
+	s.mockGen.EXPECT().AssignUnitsWithPolicy("ghost", policy).Return(nil)
+	s.mockGen.EXPECT().AssignUnitsWithPolicy("mysql", policy).Return(nil)
+	s.expectBranch()
+
+	arg := params.BranchTrackArg{
+		BranchName: s.newBranchName,
+		Entities: []params.Entity{
+			{Tag: names.NewApplicationTag("ghost").String()},
+			{Tag: names.NewApplicationTag("mysql").String()},
+			{Tag: names.NewUnitTag("mysql/0").String()},
+		},
+		Percent:     10,
+		PerZone:     true,
+		Annotations: map[string]string{"canary": "true"},
+		Constraints: "arch=arm64",
+	}
+	result, err := s.api.TrackBranch(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Results, gc.DeepEquals, []params.ErrorResult{
+		{Error: nil},
+		{Error: nil},
+		{Error: &params.Error{Message: `unit selection policies can not be used with unit "mysql/0"`}},
+	})
+}
+
+func (s *modelGenerationSuite) TestTrackBranchProgressive(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	policy := model.TrackPolicy{Percent: 10}
+	s.mockGen.EXPECT().AssignUnitsWithPolicy("ghost", policy).Return(nil)
+	s.mockGen.EXPECT().SetProgression(model.BranchProgression{
+		Policy:      policy,
+		StepPercent: 20,
+		Interval:    time.Hour,
+	}).Return(nil)
+	s.expectBranch()
+
+	arg := params.BranchTrackArg{
+		BranchName:  s.newBranchName,
+		Entities:    []params.Entity{{Tag: names.NewApplicationTag("ghost").String()}},
+		Percent:     10,
+		Progression: &params.BranchProgression{StepPercent: 20, Interval: time.Hour},
+	}
+	result, err := s.api.TrackBranch(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Results, gc.DeepEquals, []params.ErrorResult{{Error: nil}})
+}
+
+func (s *modelGenerationSuite) TestTrackBranchProgressiveStartsWithStep(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	policy := model.TrackPolicy{Percent: 25}
+	s.mockGen.EXPECT().AssignUnitsWithPolicy("ghost", policy).Return(nil)
+	s.mockGen.EXPECT().SetProgression(model.BranchProgression{
+		Policy:      policy,
+		StepPercent: 25,
+		Interval:    time.Hour,
+	}).Return(nil)
+	s.expectBranch()
+
+	arg := params.BranchTrackArg{
+		BranchName:  s.newBranchName,
+		Entities:    []params.Entity{{Tag: names.NewApplicationTag("ghost").String()}},
+		Progression: &params.BranchProgression{StepPercent: 25, Interval: time.Hour},
+	}
+	result, err := s.api.TrackBranch(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Results, gc.DeepEquals, []params.ErrorResult{{Error: nil}})
+}
+
+func (s *modelGenerationSuite) TestTrackBranchProgressiveInvalid(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+
+	arg := params.BranchTrackArg{
+		BranchName:  s.newBranchName,
+		Entities:    []params.Entity{{Tag: names.NewApplicationTag("ghost").String()}},
+		Progression: &params.BranchProgression{StepPercent: 20},
+	}
+	_, err := s.api.TrackBranch(arg)
+	c.Assert(err, gc.ErrorMatches, "step interval 0s not valid")
+}
+
+func (s *modelGenerationSuite) TestTrackBranchV5IgnoresPolicy(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectAssignUnits("ghost", 0)
+	s.expectBranch()
+
+	arg := params.BranchTrackArg{
+		BranchName: s.newBranchName,
+		Entities:   []params.Entity{{Tag: names.NewApplicationTag("ghost").String()}},
+		Percent:    10,
+	}
+	api := &modelgeneration.APIV5{API: s.api}
+	result, err := api.TrackBranch(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Results, gc.DeepEquals, []params.ErrorResult{{Error: nil}})
+}
+
 func (s *modelGenerationSuite) TestCommitBranchSuccess(c *gc.C) {
 	defer s.setupModelGenerationAPI(c).Finish()
 	s.expectCommit()
@@ -146,6 +253,49 @@ func (s *modelGenerationSuite) TestAbortBranchSuccess(c *gc.C) {
 	c.Assert(result, gc.DeepEquals, params.ErrorResult{Error: nil})
 }
 
+func (s *modelGenerationSuite) TestRevertCommitSuccess(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	conflicts := []model.RevertConflict{{Application: "redis", Key: "port", Reason: "changed by commit 4"}}
+	s.mockModel.EXPECT().RevertCommit(3, s.newBranchName, s.apiUser).Return(conflicts, nil)
+
+	result, err := s.api.RevertCommit(params.RevertCommitArg{GenerationId: 3, BranchName: s.newBranchName})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.RevertCommitResult{
+		Conflicts: []params.RevertConflict{{ApplicationName: "redis", Key: "port", Reason: "changed by commit 4"}},
+	})
+}
+
+func (s *modelGenerationSuite) TestRevertCommitAndCommit(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.mockModel.EXPECT().RevertCommit(2, s.newBranchName, s.apiUser).Return(nil, nil)
+	s.expectBranch()
+	s.expectCommit()
+
+	result, err := s.api.RevertCommit(params.RevertCommitArg{GenerationId: 2, BranchName: s.newBranchName, Commit: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.RevertCommitResult{GenerationId: 3})
+}
+
+func (s *modelGenerationSuite) TestRevertCommitNothingToRevert(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	conflicts := []model.RevertConflict{{Application: "redis", Key: "port", Reason: "changed since commit 2"}}
+	s.mockModel.EXPECT().RevertCommit(2, s.newBranchName, s.apiUser).Return(
+		conflicts, errors.New("commit 2 has no changes that can be reverted"))
+
+	result, err := s.api.RevertCommit(params.RevertCommitArg{GenerationId: 2, BranchName: s.newBranchName, Commit: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "commit 2 has no changes that can be reverted")
+	c.Check(result.Conflicts, gc.HasLen, 1)
+}
+
+func (s *modelGenerationSuite) TestRevertCommitInvalidGenerationId(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+
+	result, err := s.api.RevertCommit(params.RevertCommitArg{BranchName: s.newBranchName})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "supplied generation id has to be higher than 0")
+}
+
 func (s *modelGenerationSuite) TestHasActiveBranchTrue(c *gc.C) {
 	defer s.setupModelGenerationAPI(c).Finish()
 	s.expectHasActiveBranch(nil)
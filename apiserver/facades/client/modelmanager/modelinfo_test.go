@@ -25,8 +25,10 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
@@ -183,7 +185,7 @@ func (s *modelInfoSuite) setAPIUser(c *gc.C, user names.UserTag) {
 }
 
 func (s *modelInfoSuite) TestModelInfoV7(c *gc.C) {
-	api := &modelmanager.ModelManagerAPIV7{s.modelmanager}
+	api := &modelmanager.ModelManagerAPIV7{&modelmanager.ModelManagerAPIV8{&modelmanager.ModelManagerAPIV9{s.modelmanager}}}
 
 	results, err := api.ModelInfo(params.Entities{
 		Entities: []params.Entity{{
@@ -271,7 +273,9 @@ func (s *modelInfoSuite) expectedModelInfo(c *gc.C, credentialValidity *bool) pa
 func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 	info := s.getModelInfo(c, s.st.model.cfg.UUID())
 	_true := true
-	s.assertModelInfo(c, info, s.expectedModelInfo(c, &_true))
+	expected := s.expectedModelInfo(c, &_true)
+	expected.Quota = &params.ModelQuotaInfo{}
+	s.assertModelInfo(c, info, expected)
 	s.st.CheckCalls(c, []gitjujutesting.StubCall{
 		{"ControllerTag", nil},
 		{"ModelUUID", nil},
@@ -282,14 +286,26 @@ func (s *modelInfoSuite) TestModelInfo(c *gc.C) {
 		{"AllMachines", nil},
 		{"ControllerNodes", nil},
 		{"HAPrimaryMachine", nil},
+		{"UserQuota", []interface{}{names.NewLocalUserTag("bob")}},
 		{"LatestMigration", nil},
 		{"CloudCredential", []interface{}{names.NewCloudCredentialTag("some-cloud/bob/some-credential")}},
 	})
 }
 
+func (s *modelInfoSuite) TestModelInfoExpiry(c *gc.C) {
+	expiry := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.st.model.expiry = expiry
+	info := s.getModelInfo(c, s.st.model.cfg.UUID())
+	_true := true
+	expected := s.expectedModelInfo(c, &_true)
+	expected.Quota = &params.ModelQuotaInfo{}
+	expected.Expiry = &expiry
+	s.assertModelInfo(c, info, expected)
+}
+
 func (s *modelInfoSuite) assertModelInfo(c *gc.C, got, expected params.ModelInfo) {
 	c.Assert(got, jc.DeepEquals, expected)
-	s.st.model.CheckCalls(c, []gitjujutesting.StubCall{
+	calls := []gitjujutesting.StubCall{
 		{"UUID", nil},
 		{"Type", nil},
 		{"Name", nil},
@@ -307,6 +323,7 @@ func (s *modelInfoSuite) assertModelInfo(c *gc.C, got, expected params.ModelInfo
 		{"Life", nil},
 		{"Config", nil},
 		{"Status", nil},
+		{"Expiry", nil},
 		{"Users", nil},
 		{"ModelTag", nil},
 		{"ModelTag", nil},
@@ -316,8 +333,45 @@ func (s *modelInfoSuite) assertModelInfo(c *gc.C, got, expected params.ModelInfo
 		{"LastModelConnection", []interface{}{names.NewLocalUserTag("bob")}},
 		{"LastModelConnection", []interface{}{names.NewLocalUserTag("charlotte")}},
 		{"LastModelConnection", []interface{}{names.NewLocalUserTag("mary")}},
-		{"Type", nil},
+	}
+	if expected.Quota != nil {
+		calls = append(calls, []gitjujutesting.StubCall{
+			{"Quota", nil},
+			{"QuotaUsage", nil},
+			{"Owner", nil},
+		}...)
+	}
+	calls = append(calls, gitjujutesting.StubCall{"Type", nil})
+	s.st.model.CheckCalls(c, calls)
+}
+
+func (s *modelInfoSuite) TestModelInfoQuota(c *gc.C) {
+	s.st.model.quota = quota.Resources{Machines: 10, StorageMiB: 2048}
+	s.st.model.quotaUsage = quota.Resources{Machines: 2, Units: 4, Applications: 1, StorageMiB: 512}
+	s.st.userQuota = quota.Resources{Units: 50}
+	info := s.getModelInfo(c, s.st.model.cfg.UUID())
+	c.Assert(info.Quota, jc.DeepEquals, &params.ModelQuotaInfo{
+		Limits:      params.QuotaResources{Machines: 10, StorageMiB: 2048},
+		Usage:       params.QuotaResources{Machines: 2, Units: 4, Applications: 1, StorageMiB: 512},
+		OwnerLimits: params.QuotaResources{Units: 50},
+		OwnerUsage:  params.QuotaResources{Machines: 3, Units: 5},
 	})
+	s.st.CheckCall(c, 9, "UserQuota", names.NewLocalUserTag("bob"))
+	s.st.CheckCall(c, 10, "UserQuotaUsage", names.NewLocalUserTag("bob"))
+}
+
+func (s *modelInfoSuite) TestModelInfoV8NoQuota(c *gc.C) {
+	s.st.model.quota = quota.Resources{Machines: 10}
+	api := &modelmanager.ModelManagerAPIV8{&modelmanager.ModelManagerAPIV9{s.modelmanager}}
+	results, err := api.ModelInfo(params.Entities{
+		Entities: []params.Entity{{
+			names.NewModelTag(s.st.model.cfg.UUID()).String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result.Quota, gc.IsNil)
 }
 
 func (s *modelInfoSuite) TestModelInfoWriteAccess(c *gc.C) {
@@ -336,6 +390,7 @@ func (s *modelInfoSuite) TestModelInfoNonOwner(c *gc.C) {
 	c.Assert(info.Users, gc.HasLen, 1)
 	c.Assert(info.Users[0].UserName, gc.Equals, "charlotte")
 	c.Assert(info.Machines, gc.HasLen, 0)
+	c.Assert(info.Quota, gc.IsNil)
 }
 
 func (s *modelInfoSuite) getModelInfo(c *gc.C, modelUUID string) params.ModelInfo {
@@ -388,6 +443,7 @@ func (s *modelInfoSuite) TestRunningMigration(c *gc.C) {
 	start := time.Now().Add(-20 * time.Minute)
 	s.st.migration = &mockMigration{
 		status: "computing optimal bin packing",
+		phase:  migration.IMPORT,
 		start:  start,
 	}
 
@@ -398,6 +454,7 @@ func (s *modelInfoSuite) TestRunningMigration(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	migrationResult := results.Results[0].Result.Migration
 	c.Assert(migrationResult.Status, gc.Equals, "computing optimal bin packing")
+	c.Assert(migrationResult.Phase, gc.Equals, "IMPORT")
 	c.Assert(*migrationResult.Start, gc.Equals, start)
 	c.Assert(migrationResult.End, gc.IsNil)
 }
@@ -407,6 +464,7 @@ func (s *modelInfoSuite) TestFailedMigration(c *gc.C) {
 	end := time.Now().Add(-10 * time.Minute)
 	s.st.migration = &mockMigration{
 		status: "couldn't realign alternate time frames",
+		phase:  migration.ABORTDONE,
 		start:  start,
 		end:    end,
 	}
@@ -418,6 +476,7 @@ func (s *modelInfoSuite) TestFailedMigration(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	migrationResult := results.Results[0].Result.Migration
 	c.Assert(migrationResult.Status, gc.Equals, "couldn't realign alternate time frames")
+	c.Assert(migrationResult.Phase, gc.Equals, "ABORTDONE")
 	c.Assert(*migrationResult.Start, gc.Equals, start)
 	c.Assert(*migrationResult.End, gc.Equals, end)
 }
@@ -648,6 +707,8 @@ type mockState struct {
 	block           state.BlockType
 	migration       *mockMigration
 	modelConfig     *config.Config
+	userQuota       quota.Resources
+	templates       []state.ModelTemplate
 
 	modelDetailsForUser func() ([]state.ModelSummary, error)
 }
@@ -706,6 +767,57 @@ func (st *mockState) GetModel(modelUUID string) (common.Model, func() bool, erro
 	return st.model, func() bool { return true }, st.NextErr()
 }
 
+func (st *mockState) UserQuota(user names.UserTag) (quota.Resources, error) {
+	st.MethodCall(st, "UserQuota", user)
+	return st.userQuota, st.NextErr()
+}
+
+func (st *mockState) UserQuotaUsage(user names.UserTag) (quota.Resources, error) {
+	st.MethodCall(st, "UserQuotaUsage", user)
+	return quota.Resources{Machines: 3, Units: 5}, st.NextErr()
+}
+
+func (st *mockState) SetUserQuota(user names.UserTag, limits quota.Resources) error {
+	st.MethodCall(st, "SetUserQuota", user, limits)
+	if err := st.NextErr(); err != nil {
+		return err
+	}
+	st.userQuota = limits
+	return nil
+}
+
+func (st *mockState) AddModelTemplate(t state.ModelTemplate) error {
+	st.MethodCall(st, "AddModelTemplate", t)
+	if err := st.NextErr(); err != nil {
+		return err
+	}
+	st.templates = append(st.templates, t)
+	return nil
+}
+
+func (st *mockState) ModelTemplate(name string) (state.ModelTemplate, error) {
+	st.MethodCall(st, "ModelTemplate", name)
+	if err := st.NextErr(); err != nil {
+		return state.ModelTemplate{}, err
+	}
+	for _, t := range st.templates {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return state.ModelTemplate{}, errors.NotFoundf("model template %q", name)
+}
+
+func (st *mockState) ModelTemplates() ([]state.ModelTemplate, error) {
+	st.MethodCall(st, "ModelTemplates")
+	return st.templates, st.NextErr()
+}
+
+func (st *mockState) RemoveModelTemplate(name string) error {
+	st.MethodCall(st, "RemoveModelTemplate", name)
+	return st.NextErr()
+}
+
 func (st *mockState) ModelUUIDsForUser(user names.UserTag) ([]string, error) {
 	st.MethodCall(st, "ModelUUIDsForUser", user)
 	return nil, st.NextErr()
@@ -1082,6 +1194,9 @@ type mockModel struct {
 	cloud               cloud.Cloud
 	cred                state.Credential
 	setCloudCredentialF func(tag names.CloudCredentialTag) (bool, error)
+	quota               quota.Resources
+	quotaUsage          quota.Resources
+	expiry              time.Time
 }
 
 func (m *mockModel) Config() (*config.Config, error) {
@@ -1229,6 +1344,30 @@ func (m *mockModel) SetCloudCredential(tag names.CloudCredentialTag) (bool, erro
 	return m.setCloudCredentialF(tag)
 }
 
+func (m *mockModel) Quota() (quota.Resources, error) {
+	m.MethodCall(m, "Quota")
+	return m.quota, m.NextErr()
+}
+
+func (m *mockModel) QuotaUsage() (quota.Resources, error) {
+	m.MethodCall(m, "QuotaUsage")
+	return m.quotaUsage, m.NextErr()
+}
+
+func (m *mockModel) Expiry() (time.Time, bool) {
+	m.MethodCall(m, "Expiry")
+	return m.expiry, !m.expiry.IsZero()
+}
+
+func (m *mockModel) SetQuota(limits quota.Resources) error {
+	m.MethodCall(m, "SetQuota", limits)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.quota = limits
+	return nil
+}
+
 type mockModelUser struct {
 	gitjujutesting.Stub
 	userName       string
@@ -1241,10 +1380,15 @@ type mockMigration struct {
 	state.ModelMigration
 
 	status string
+	phase  migration.Phase
 	start  time.Time
 	end    time.Time
 }
 
+func (m *mockMigration) Phase() (migration.Phase, error) {
+	return m.phase, nil
+}
+
 func (m *mockMigration) StatusMessage() string {
 	return m.status
 }
@@ -30,6 +30,7 @@ import (
 	"github.com/juju/juju/controller/modelmanager"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/environs"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/environs/config"
@@ -42,6 +43,24 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
+// ModelManagerV10 defines the methods on the version 10 facade for the
+// modelmanager API endpoint.
+type ModelManagerV10 interface {
+	ModelManagerV9
+	AddModelTemplates(args params.ModelTemplates) (params.ErrorResults, error)
+	ListModelTemplates() (params.ModelTemplates, error)
+	RemoveModelTemplates(args params.ModelTemplateNames) (params.ErrorResults, error)
+	// CreateModel gains a model template.
+}
+
+// ModelManagerV9 defines the methods on the version 9 facade for the
+// modelmanager API endpoint.
+type ModelManagerV9 interface {
+	ModelManagerV8
+	SetQuotas(args params.SetQuotaArgs) (params.ErrorResults, error)
+	// ModelInfo gains quota limits and usage in return.
+}
+
 // ModelManagerV8 defines the methods on the version 8 facade for the
 // modelmanager API endpoint.
 type ModelManagerV8 interface {
@@ -131,10 +150,22 @@ type ModelManagerAPI struct {
 	callContext context.ProviderCallContext
 }
 
+// ModelManagerAPIV9 provides a way to wrap the different calls between
+// version 10 and version 9 of the model manager API
+type ModelManagerAPIV9 struct {
+	*ModelManagerAPI
+}
+
+// ModelManagerAPIV8 provides a way to wrap the different calls between
+// version 9 and version 8 of the model manager API
+type ModelManagerAPIV8 struct {
+	*ModelManagerAPIV9
+}
+
 // ModelManagerAPIV7 provides a way to wrap the different calls between
 // version 8 and version 7 of the model manager API
 type ModelManagerAPIV7 struct {
-	*ModelManagerAPI
+	*ModelManagerAPIV8
 }
 
 // ModelManagerAPIV6 provides a way to wrap the different calls between
@@ -168,17 +199,19 @@ type ModelManagerAPIV2 struct {
 }
 
 var (
-	_ ModelManagerV8 = (*ModelManagerAPI)(nil)
-	_ ModelManagerV7 = (*ModelManagerAPIV7)(nil)
-	_ ModelManagerV6 = (*ModelManagerAPIV6)(nil)
-	_ ModelManagerV5 = (*ModelManagerAPIV5)(nil)
-	_ ModelManagerV4 = (*ModelManagerAPIV4)(nil)
-	_ ModelManagerV3 = (*ModelManagerAPIV3)(nil)
-	_ ModelManagerV2 = (*ModelManagerAPIV2)(nil)
+	_ ModelManagerV10 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV9  = (*ModelManagerAPIV9)(nil)
+	_ ModelManagerV8  = (*ModelManagerAPIV8)(nil)
+	_ ModelManagerV7  = (*ModelManagerAPIV7)(nil)
+	_ ModelManagerV6  = (*ModelManagerAPIV6)(nil)
+	_ ModelManagerV5  = (*ModelManagerAPIV5)(nil)
+	_ ModelManagerV4  = (*ModelManagerAPIV4)(nil)
+	_ ModelManagerV3  = (*ModelManagerAPIV3)(nil)
+	_ ModelManagerV2  = (*ModelManagerAPIV2)(nil)
 )
 
-// NewFacadeV8 is used for API registration.
-func NewFacadeV8(ctx facade.Context) (*ModelManagerAPI, error) {
+// NewFacadeV10 is used for API registration.
+func NewFacadeV10(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt := pool.SystemState()
@@ -215,6 +248,24 @@ func NewFacadeV8(ctx facade.Context) (*ModelManagerAPI, error) {
 	)
 }
 
+// NewFacadeV9 is used for API registration.
+func NewFacadeV9(ctx facade.Context) (*ModelManagerAPIV9, error) {
+	v10, err := NewFacadeV10(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV9{v10}, nil
+}
+
+// NewFacadeV8 is used for API registration.
+func NewFacadeV8(ctx facade.Context) (*ModelManagerAPIV8, error) {
+	v9, err := NewFacadeV9(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV8{v9}, nil
+}
+
 // NewFacadeV7 is used for API registration.
 func NewFacadeV7(ctx facade.Context) (*ModelManagerAPIV7, error) {
 	v8, err := NewFacadeV8(ctx)
@@ -460,6 +511,16 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 		return result, errors.Annotatef(apiservererrors.ErrPerm, "%q permission does not permit creation of models for different owners", permission.AddModelAccess)
 	}
 
+	var template *state.ModelTemplate
+	if args.Template != "" {
+		t, err := m.state.ModelTemplate(args.Template)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		template = &t
+		args.Config = templateModelConfig(template, args.Config)
+	}
+
 	cloud, err := m.state.Cloud(cloudTag.Id())
 	if err != nil {
 		if errors.IsNotFound(err) && args.CloudTag != "" {
@@ -540,7 +601,8 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 			cloudTag,
 			cloudRegionName,
 			cloudCredentialTag,
-			ownerTag)
+			ownerTag,
+			template)
 	} else {
 		model, err = m.newModel(
 			cloudSpec,
@@ -549,12 +611,13 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 			cloudTag,
 			cloudRegionName,
 			cloudCredentialTag,
-			ownerTag)
+			ownerTag,
+			template)
 	}
 	if err != nil {
 		return result, errors.Trace(err)
 	}
-	return m.getModelInfo(model.ModelTag())
+	return m.getModelInfo(model.ModelTag(), false)
 }
 
 func (m *ModelManagerAPI) newCAASModel(
@@ -565,6 +628,7 @@ func (m *ModelManagerAPI) newCAASModel(
 	cloudRegionName string,
 	cloudCredentialTag names.CloudCredentialTag,
 	ownerTag names.UserTag,
+	template *state.ModelTemplate,
 ) (common.Model, error) {
 	newConfig, err := m.newModelConfig(cloudSpec, createArgs, controllerModel)
 	if err != nil {
@@ -602,7 +666,7 @@ Please choose a different model name.
 
 	storageProviderRegistry := stateenvirons.NewStorageProviderRegistry(broker)
 
-	model, st, err := m.state.NewModel(state.ModelArgs{
+	modelArgs := state.ModelArgs{
 		Type:                    state.ModelTypeCAAS,
 		CloudName:               cloudTag.Id(),
 		CloudRegion:             cloudRegionName,
@@ -610,7 +674,9 @@ Please choose a different model name.
 		Config:                  newConfig,
 		Owner:                   ownerTag,
 		StorageProviderRegistry: storageProviderRegistry,
-	})
+	}
+	m.applyModelTemplate(template, &modelArgs)
+	model, st, err := m.state.NewModel(modelArgs)
 	if err != nil {
 		return nil, errors.Annotate(err, "failed to create new model")
 	}
@@ -627,6 +693,7 @@ func (m *ModelManagerAPI) newModel(
 	cloudRegionName string,
 	cloudCredentialTag names.CloudCredentialTag,
 	ownerTag names.UserTag,
+	template *state.ModelTemplate,
 ) (common.Model, error) {
 	newConfig, err := m.newModelConfig(cloudSpec, createArgs, controllerModel)
 	if err != nil {
@@ -662,7 +729,7 @@ func (m *ModelManagerAPI) newModel(
 	// NOTE: check the agent-version of the config, and if it is > the current
 	// version, it is not supported, also check existing tools, and if we don't
 	// have tools for that version, also die.
-	model, st, err := m.state.NewModel(state.ModelArgs{
+	modelArgs := state.ModelArgs{
 		Type:                    state.ModelTypeIAAS,
 		CloudName:               cloudTag.Id(),
 		CloudRegion:             cloudRegionName,
@@ -671,7 +738,9 @@ func (m *ModelManagerAPI) newModel(
 		Owner:                   ownerTag,
 		StorageProviderRegistry: storageProviderRegistry,
 		EnvironVersion:          env.Provider().Version(),
-	})
+	}
+	m.applyModelTemplate(template, &modelArgs)
+	model, st, err := m.state.NewModel(modelArgs)
 	if err != nil {
 		// Clean up the environ.
 		if e := env.Destroy(m.callContext); e != nil {
@@ -902,6 +971,7 @@ func (m *ModelManagerAPI) ListModelSummaries(req params.ModelSummariesRequest) (
 			Status:             common.EntityStatusFromState(mi.Status),
 			Counts:             []params.ModelEntityCount{},
 			UserLastConnection: mi.UserLastConnection,
+			Expiry:             mi.Expiry,
 		}
 
 		if mi.MachineCount > 0 {
@@ -935,6 +1005,9 @@ func (m *ModelManagerAPI) ListModelSummaries(req params.ModelSummariesRequest) (
 				Start:  &startTime,
 				End:    endTime,
 			}
+			if phase, err := migration.Phase(); err == nil {
+				summary.Migration.Phase = phase.String()
+			}
 		}
 
 		result.Results = append(result.Results, params.ModelSummaryResult{Result: summary})
@@ -1053,15 +1126,20 @@ func (m *ModelManagerAPI) DestroyModels(args params.DestroyModelsParams) (params
 
 // ModelInfo returns information about the specified models.
 func (m *ModelManagerAPIV7) ModelInfo(args params.Entities) (params.ModelInfoResults, error) {
-	return m.internalModelInfo(args, false)
+	return m.internalModelInfo(args, false, false)
+}
+
+// ModelInfo returns information about the specified models.
+func (m *ModelManagerAPIV8) ModelInfo(args params.Entities) (params.ModelInfoResults, error) {
+	return m.internalModelInfo(args, true, false)
 }
 
 // ModelInfo returns information about the specified models.
 func (m *ModelManagerAPI) ModelInfo(args params.Entities) (params.ModelInfoResults, error) {
-	return m.internalModelInfo(args, true)
+	return m.internalModelInfo(args, true, true)
 }
 
-func (m *ModelManagerAPI) internalModelInfo(args params.Entities, includeCredentialValidity bool) (params.ModelInfoResults, error) {
+func (m *ModelManagerAPI) internalModelInfo(args params.Entities, includeCredentialValidity, includeQuota bool) (params.ModelInfoResults, error) {
 	results := params.ModelInfoResults{
 		Results: make([]params.ModelInfoResult, len(args.Entities)),
 	}
@@ -1071,7 +1149,7 @@ func (m *ModelManagerAPI) internalModelInfo(args params.Entities, includeCredent
 		if err != nil {
 			return params.ModelInfo{}, errors.Trace(err)
 		}
-		modelInfo, err := m.getModelInfo(tag)
+		modelInfo, err := m.getModelInfo(tag, includeQuota)
 		if err != nil {
 			return params.ModelInfo{}, errors.Trace(err)
 		}
@@ -1101,7 +1179,7 @@ func (m *ModelManagerAPI) internalModelInfo(args params.Entities, includeCredent
 	return results, nil
 }
 
-func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, error) {
+func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag, includeQuota bool) (params.ModelInfo, error) {
 	st, release, err := m.state.GetBackend(tag.Id())
 	if errors.IsNotFound(err) {
 		return params.ModelInfo{}, errors.Trace(apiservererrors.ErrPerm)
@@ -1174,6 +1252,10 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 		info.Status = entityStatus
 	}
 
+	if expiry, expires := model.Expiry(); expires {
+		info.Expiry = &expiry
+	}
+
 	// If the user is a controller superuser, they are considered a model
 	// admin.
 	modelAdmin := m.isAdmin
@@ -1221,6 +1303,11 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 		if info.Machines, err = common.ModelMachineInfo(st); shouldErr(err) {
 			return params.ModelInfo{}, err
 		}
+		if includeQuota {
+			if info.Quota, err = modelQuotaInfo(st, model); shouldErr(err) {
+				return params.ModelInfo{}, errors.Trace(err)
+			}
+		}
 	}
 
 	migration, err := st.LatestMigration()
@@ -1235,8 +1322,13 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 		if *endTime == zero {
 			endTime = nil
 		}
+		phase, err := migration.Phase()
+		if err != nil {
+			return params.ModelInfo{}, errors.Trace(err)
+		}
 		info.Migration = &params.ModelMigrationStatus{
 			Status: migration.StatusMessage(),
+			Phase:  phase.String(),
 			Start:  &startTime,
 			End:    endTime,
 		}
@@ -1244,6 +1336,92 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 	return info, nil
 }
 
+// modelQuotaInfo returns the quota limits and resource usage of the
+// model, along with those of the model's owner if the owner has a
+// quota set.
+func modelQuotaInfo(st common.ModelManagerBackend, model common.Model) (*params.ModelQuotaInfo, error) {
+	limits, err := model.Quota()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	usage, err := model.QuotaUsage()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info := &params.ModelQuotaInfo{
+		Limits: quotaResourcesToParams(limits),
+		Usage:  quotaResourcesToParams(usage),
+	}
+	ownerLimits, err := st.UserQuota(model.Owner())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !ownerLimits.IsZero() {
+		ownerUsage, err := st.UserQuotaUsage(model.Owner())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info.OwnerLimits = quotaResourcesToParams(ownerLimits)
+		info.OwnerUsage = quotaResourcesToParams(ownerUsage)
+	}
+	return info, nil
+}
+
+func quotaResourcesToParams(r quota.Resources) params.QuotaResources {
+	return params.QuotaResources{
+		Machines:     r.Machines,
+		Units:        r.Units,
+		Applications: r.Applications,
+		StorageMiB:   r.StorageMiB,
+	}
+}
+
+// SetQuotas sets the resource quotas of the specified models or users.
+// Only controller superusers may change quotas. Limits of zero remove
+// the corresponding quota.
+func (m *ModelManagerAPI) SetQuotas(args params.SetQuotaArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	if !m.isAdmin {
+		return results, apiservererrors.ErrPerm
+	}
+	if err := m.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		err := m.setQuota(arg)
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+func (m *ModelManagerAPI) setQuota(arg params.SetQuotaArg) error {
+	limits := quota.Resources{
+		Machines:     arg.Limits.Machines,
+		Units:        arg.Limits.Units,
+		Applications: arg.Limits.Applications,
+		StorageMiB:   arg.Limits.StorageMiB,
+	}
+	tag, err := names.ParseTag(arg.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch tag := tag.(type) {
+	case names.ModelTag:
+		model, release, err := m.state.GetModel(tag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer release()
+		return errors.Trace(model.SetQuota(limits))
+	case names.UserTag:
+		return errors.Trace(m.state.SetUserQuota(tag, limits))
+	default:
+		return errors.NotValidf("quota target %q", arg.Tag)
+	}
+}
+
 // ModifyModelAccess changes the model access granted to users.
 func (m *ModelManagerAPI) ModifyModelAccess(args params.ModifyModelAccessRequest) (result params.ErrorResults, _ error) {
 	result = params.ErrorResults{
@@ -1626,5 +1804,8 @@ func (m *ModelManagerAPI) ChangeModelCredential(args params.ChangeModelCredentia
 // ChangeModelCredential did not exist prior to v5.
 func (*ModelManagerAPIV4) ChangeModelCredential(_, _ struct{}) {}
 
+// SetQuotas did not exist prior to v9.
+func (*ModelManagerAPIV8) SetQuotas(_, _ struct{}) {}
+
 // ModelDefaultsForClouds did not exist prior to v6.
 func (*ModelManagerAPIV5) ModelDefaultsForClouds(_, _ struct{}) {}
@@ -22,9 +22,12 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/firewall"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -581,6 +584,183 @@ func (s *modelManagerSuite) TestBlockChangesSetModelDefaults(c *gc.C) {
 	s.assertBlocked(c, err, "TestBlockChangesSetModelDefaults")
 }
 
+func (s *modelManagerSuite) TestSetQuotas(c *gc.C) {
+	result, err := s.api.SetQuotas(params.SetQuotaArgs{
+		Args: []params.SetQuotaArg{{
+			Tag:    coretesting.ModelTag.String(),
+			Limits: params.QuotaResources{Machines: 5, StorageMiB: 10240},
+		}, {
+			Tag:    names.NewUserTag("bob").String(),
+			Limits: params.QuotaResources{Units: 20, Applications: 4},
+		}, {
+			Tag:    names.NewCloudTag("dummy").String(),
+			Limits: params.QuotaResources{Units: 1},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `quota target "cloud-dummy" not valid`)
+	c.Assert(s.st.model.quota, jc.DeepEquals, quota.Resources{Machines: 5, StorageMiB: 10240})
+	c.Assert(s.st.userQuota, jc.DeepEquals, quota.Resources{Units: 20, Applications: 4})
+	calls := s.st.Calls()
+	c.Assert(calls[len(calls)-2:], jc.DeepEquals, []gitjujutesting.StubCall{
+		{"GetModel", []interface{}{coretesting.ModelTag.Id()}},
+		{"SetUserQuota", []interface{}{names.NewUserTag("bob"), quota.Resources{Units: 20, Applications: 4}}},
+	})
+}
+
+func (s *modelManagerSuite) TestSetQuotasAsNormalUser(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("charlie"))
+	_, err := s.api.SetQuotas(params.SetQuotaArgs{
+		Args: []params.SetQuotaArg{{
+			Tag:    coretesting.ModelTag.String(),
+			Limits: params.QuotaResources{Machines: 5},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(s.st.model.quota, jc.DeepEquals, quota.Resources{})
+}
+
+func (s *modelManagerSuite) teamTemplate() params.ModelTemplate {
+	return params.ModelTemplate{
+		Name:        "team",
+		Description: "standard team model",
+		Config:      map[string]interface{}{"bar": "template", "logging-config": "<root>=DEBUG"},
+		Constraints: constraints.MustParse("mem=4G"),
+		Users: []params.ModelTemplateUser{
+			{UserTag: "user-bob", Access: "write"},
+			{UserTag: "user-admin", Access: "read"},
+		},
+		FirewallRules: []params.FirewallRule{
+			{KnownService: params.SSHRule, WhitelistCIDRS: []string{"10.0.0.0/8"}},
+		},
+		Spaces: []string{"db"},
+		Blocks: map[string]string{"BlockDestroy": "keep me"},
+		Bundle: "applications: {}",
+	}
+}
+
+func (s *modelManagerSuite) TestAddModelTemplates(c *gc.C) {
+	bad := s.teamTemplate()
+	bad.Name = "bad"
+	bad.Blocks = map[string]string{"BlockEverything": ""}
+	result, err := s.api.AddModelTemplates(params.ModelTemplates{
+		Templates: []params.ModelTemplate{s.teamTemplate(), bad},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `block type "BlockEverything" not valid`)
+
+	c.Assert(s.st.templates, gc.HasLen, 1)
+	t := s.st.templates[0]
+	c.Check(t.Name, gc.Equals, "team")
+	c.Check(t.CreatedBy, gc.Equals, "admin")
+	c.Check(t.Users, jc.DeepEquals, []state.ModelTemplateUser{
+		{Name: "bob", Access: permission.WriteAccess},
+		{Name: "admin", Access: permission.ReadAccess},
+	})
+	c.Check(t.FirewallRules, jc.DeepEquals, []state.FirewallRule{
+		state.NewFirewallRule(firewall.SSHRule, []string{"10.0.0.0/8"}),
+	})
+	c.Check(t.Spaces, jc.DeepEquals, []string{"db"})
+	c.Check(t.Blocks, jc.DeepEquals, map[state.BlockType]string{state.DestroyBlock: "keep me"})
+}
+
+func (s *modelManagerSuite) TestAddModelTemplatesAsNormalUser(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("charlie"))
+	_, err := s.api.AddModelTemplates(params.ModelTemplates{
+		Templates: []params.ModelTemplate{s.teamTemplate()},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(s.st.templates, gc.HasLen, 0)
+}
+
+func (s *modelManagerSuite) TestListModelTemplates(c *gc.C) {
+	_, err := s.api.AddModelTemplates(params.ModelTemplates{
+		Templates: []params.ModelTemplate{s.teamTemplate()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.setAPIUser(c, names.NewUserTag("charlie"))
+	result, err := s.api.ListModelTemplates()
+	c.Assert(err, jc.ErrorIsNil)
+	expected := s.teamTemplate()
+	expected.CreatedBy = "admin"
+	c.Assert(result, jc.DeepEquals, params.ModelTemplates{
+		Templates: []params.ModelTemplate{expected},
+	})
+}
+
+func (s *modelManagerSuite) TestRemoveModelTemplates(c *gc.C) {
+	result, err := s.api.RemoveModelTemplates(params.ModelTemplateNames{Names: []string{"team"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.st.CheckCall(c, len(s.st.Calls())-1, "RemoveModelTemplate", "team")
+
+	s.setAPIUser(c, names.NewUserTag("charlie"))
+	_, err = s.api.RemoveModelTemplates(params.ModelTemplateNames{Names: []string{"team"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelManagerSuite) TestCreateModelFromTemplate(c *gc.C) {
+	_, err := s.api.AddModelTemplates(params.ModelTemplates{
+		Templates: []params.ModelTemplate{s.teamTemplate()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ModelCreateArgs{
+		Name:     "foo",
+		OwnerTag: "user-admin",
+		Config: map[string]interface{}{
+			"bar": "baz",
+		},
+		CloudRegion:        "qux",
+		CloudCredentialTag: "cloudcred-some-cloud_admin_some-credential",
+		Template:           "team",
+	}
+	_, err = s.api.CreateModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModelArgs := s.getModelArgs(c)
+	attrs := newModelArgs.Config.AllAttrs()
+	// Explicit config takes precedence over that of the template.
+	c.Check(attrs["bar"], gc.Equals, "baz")
+	c.Check(attrs["logging-config"], gc.Equals, "<root>=DEBUG")
+	c.Check(newModelArgs.Constraints, jc.DeepEquals, constraints.MustParse("mem=4G"))
+	// The owner is always a model admin, so is skipped.
+	c.Check(newModelArgs.Users, jc.DeepEquals, []state.UserAccessSpec{{
+		User:        names.NewUserTag("bob"),
+		CreatedBy:   names.NewUserTag("admin"),
+		DisplayName: "bob",
+		Access:      permission.WriteAccess,
+	}})
+	c.Check(newModelArgs.FirewallRules, jc.DeepEquals, []state.FirewallRule{
+		state.NewFirewallRule(firewall.SSHRule, []string{"10.0.0.0/8"}),
+	})
+	c.Check(newModelArgs.Spaces, jc.DeepEquals, []string{"db"})
+	c.Check(newModelArgs.Blocks, jc.DeepEquals, map[state.BlockType]string{state.DestroyBlock: "keep me"})
+}
+
+func (s *modelManagerSuite) TestCreateModelUnknownTemplate(c *gc.C) {
+	_, err := s.api.CreateModel(params.ModelCreateArgs{
+		Name:               "foo",
+		OwnerTag:           "user-admin",
+		CloudCredentialTag: "cloudcred-some-cloud_admin_some-credential",
+		Template:           "missing",
+	})
+	c.Assert(err, gc.ErrorMatches, `model template "missing" not found`)
+	s.st.CheckCallNames(c, "ControllerTag", "ModelUUID", "Model", "ControllerTag", "ModelTemplate")
+}
+
+func (s *modelManagerSuite) TestBlockChangesSetQuotas(c *gc.C) {
+	s.blockAllChanges(c, "TestBlockChangesSetQuotas")
+	_, err := s.api.SetQuotas(params.SetQuotaArgs{})
+	s.assertBlocked(c, err, "TestBlockChangesSetQuotas")
+}
+
 func (s *modelManagerSuite) TestUnsetModelDefaults(c *gc.C) {
 	args := params.UnsetModelDefaults{
 		Keys: []params.ModelUnsetKeys{{
@@ -690,7 +870,7 @@ func (s *modelManagerSuite) TestDumpModelV2(c *gc.C) {
 				&modelmanager.ModelManagerAPIV5{
 					&modelmanager.ModelManagerAPIV6{
 						&modelmanager.ModelManagerAPIV7{
-							s.api,
+							&modelmanager.ModelManagerAPIV8{&modelmanager.ModelManagerAPIV9{s.api}},
 						},
 					},
 				},
@@ -860,7 +1040,7 @@ func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
 			&modelmanager.ModelManagerAPIV5{
 				&modelmanager.ModelManagerAPIV6{
 					&modelmanager.ModelManagerAPIV7{
-						s.api,
+						&modelmanager.ModelManagerAPIV8{&modelmanager.ModelManagerAPIV9{s.api}},
 					},
 				},
 			},
@@ -1710,7 +1890,7 @@ func (s *modelManagerSuite) TestModelStatusV2(c *gc.C) {
 				&modelmanager.ModelManagerAPIV5{
 					&modelmanager.ModelManagerAPIV6{
 						&modelmanager.ModelManagerAPIV7{
-							s.api,
+							&modelmanager.ModelManagerAPIV8{&modelmanager.ModelManagerAPIV9{s.api}},
 						},
 					},
 				},
@@ -1749,7 +1929,7 @@ func (s *modelManagerSuite) TestModelStatusV3(c *gc.C) {
 			&modelmanager.ModelManagerAPIV5{
 				&modelmanager.ModelManagerAPIV6{
 					&modelmanager.ModelManagerAPIV7{
-						s.api,
+						&modelmanager.ModelManagerAPIV8{&modelmanager.ModelManagerAPIV9{s.api}},
 					},
 				},
 			},
@@ -0,0 +1,211 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/firewall"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/state"
+)
+
+// AddModelTemplates did not exist prior to v10.
+func (*ModelManagerAPIV9) AddModelTemplates(_, _ struct{}) {}
+
+// ListModelTemplates did not exist prior to v10.
+func (*ModelManagerAPIV9) ListModelTemplates(_, _ struct{}) {}
+
+// RemoveModelTemplates did not exist prior to v10.
+func (*ModelManagerAPIV9) RemoveModelTemplates(_, _ struct{}) {}
+
+// AddModelTemplates stores new model templates on the controller.
+// Only controller superusers may add templates.
+func (m *ModelManagerAPI) AddModelTemplates(args params.ModelTemplates) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Templates)),
+	}
+	if !m.isAdmin {
+		return results, apiservererrors.ErrPerm
+	}
+	if err := m.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Templates {
+		err := m.addModelTemplate(arg)
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+func (m *ModelManagerAPI) addModelTemplate(arg params.ModelTemplate) error {
+	t, err := modelTemplateFromParams(arg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	t.CreatedBy = m.apiUser.Id()
+	return errors.Trace(m.state.AddModelTemplate(t))
+}
+
+// ListModelTemplates returns all of the model templates on the
+// controller. Any user may list templates, so that they may add
+// models from them.
+func (m *ModelManagerAPI) ListModelTemplates() (params.ModelTemplates, error) {
+	templates, err := m.state.ModelTemplates()
+	if err != nil {
+		return params.ModelTemplates{}, errors.Trace(err)
+	}
+	result := params.ModelTemplates{
+		Templates: make([]params.ModelTemplate, len(templates)),
+	}
+	for i, t := range templates {
+		result.Templates[i] = modelTemplateToParams(t)
+	}
+	return result, nil
+}
+
+// RemoveModelTemplates removes the named model templates. Only
+// controller superusers may remove templates.
+func (m *ModelManagerAPI) RemoveModelTemplates(args params.ModelTemplateNames) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	if !m.isAdmin {
+		return results, apiservererrors.ErrPerm
+	}
+	if err := m.check.RemoveAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, name := range args.Names {
+		err := m.state.RemoveModelTemplate(name)
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+// applyModelTemplate adds the constraints, users, firewall rules, spaces
+// and blocks of the template to the args of a new model, so that they are
+// created along with it. Constraints passed explicitly are retained.
+func (m *ModelManagerAPI) applyModelTemplate(t *state.ModelTemplate, args *state.ModelArgs) {
+	if t == nil {
+		return
+	}
+	if constraints.IsEmpty(&args.Constraints) {
+		args.Constraints = t.Constraints
+	}
+	for _, user := range t.Users {
+		tag := names.NewUserTag(user.Name)
+		if tag.Id() == args.Owner.Id() {
+			// The owner is always a model admin.
+			continue
+		}
+		args.Users = append(args.Users, state.UserAccessSpec{
+			User:        tag,
+			CreatedBy:   m.apiUser,
+			DisplayName: tag.Name(),
+			Access:      user.Access,
+		})
+	}
+	args.FirewallRules = t.FirewallRules
+	args.Spaces = t.Spaces
+	args.Blocks = t.Blocks
+}
+
+// templateModelConfig returns the config of the template overlaid
+// with the config passed explicitly for the new model.
+func templateModelConfig(t *state.ModelTemplate, attrs map[string]interface{}) map[string]interface{} {
+	if t == nil {
+		return attrs
+	}
+	result := make(map[string]interface{}, len(t.Config)+len(attrs))
+	for k, v := range t.Config {
+		result[k] = v
+	}
+	for k, v := range attrs {
+		result[k] = v
+	}
+	return result
+}
+
+func modelTemplateFromParams(arg params.ModelTemplate) (state.ModelTemplate, error) {
+	t := state.ModelTemplate{
+		Name:        arg.Name,
+		Description: arg.Description,
+		Config:      arg.Config,
+		Constraints: arg.Constraints,
+		Spaces:      arg.Spaces,
+		Bundle:      arg.Bundle,
+	}
+	for _, user := range arg.Users {
+		tag, err := names.ParseUserTag(user.UserTag)
+		if err != nil {
+			return state.ModelTemplate{}, errors.Trace(err)
+		}
+		t.Users = append(t.Users, state.ModelTemplateUser{
+			Name:   tag.Id(),
+			Access: permission.Access(user.Access),
+		})
+	}
+	for _, rule := range arg.FirewallRules {
+		t.FirewallRules = append(t.FirewallRules, state.NewFirewallRule(
+			firewall.WellKnownServiceType(rule.KnownService), rule.WhitelistCIDRS,
+		))
+	}
+	if len(arg.Blocks) > 0 {
+		t.Blocks = make(map[state.BlockType]string, len(arg.Blocks))
+	}
+	for name, msg := range arg.Blocks {
+		blockType, ok := parseBlockType(name)
+		if !ok {
+			return state.ModelTemplate{}, errors.NotValidf("block type %q", name)
+		}
+		t.Blocks[blockType] = msg
+	}
+	return t, nil
+}
+
+func modelTemplateToParams(t state.ModelTemplate) params.ModelTemplate {
+	result := params.ModelTemplate{
+		Name:        t.Name,
+		Description: t.Description,
+		Config:      t.Config,
+		Constraints: t.Constraints,
+		Spaces:      t.Spaces,
+		Bundle:      t.Bundle,
+		CreatedBy:   t.CreatedBy,
+		Created:     t.Created,
+	}
+	for _, user := range t.Users {
+		result.Users = append(result.Users, params.ModelTemplateUser{
+			UserTag: names.NewUserTag(user.Name).String(),
+			Access:  string(user.Access),
+		})
+	}
+	for _, rule := range t.FirewallRules {
+		result.FirewallRules = append(result.FirewallRules, params.FirewallRule{
+			KnownService:   params.KnownServiceValue(rule.WellKnownService()),
+			WhitelistCIDRS: rule.WhitelistCIDRs(),
+		})
+	}
+	if len(t.Blocks) > 0 {
+		result.Blocks = make(map[string]string, len(t.Blocks))
+	}
+	for blockType, msg := range t.Blocks {
+		result.Blocks[string(blockType.ToParams())] = msg
+	}
+	return result
+}
+
+func parseBlockType(name string) (state.BlockType, bool) {
+	for _, t := range state.AllTypes() {
+		if string(t.ToParams()) == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,173 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relationdata
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the relationdata
+// facade. For details on the methods, see the methods on state.State
+// with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	Application(string) (Application, error)
+	Unit(string) (Unit, error)
+}
+
+// Unit defines the subset of state.Unit required by the relationdata
+// facade.
+type Unit interface {
+	Name() string
+}
+
+// Application defines the subset of state.Application required by the
+// relationdata facade.
+type Application interface {
+	Relations() ([]Relation, error)
+}
+
+// Relation defines the subset of state.Relation required by the
+// relationdata facade.
+type Relation interface {
+	Id() int
+	String() string
+	Endpoint(string) (state.Endpoint, error)
+	Endpoints() []state.Endpoint
+	ApplicationSettings(appName string) (map[string]interface{}, error)
+
+	// UnitsInScope returns the relation units of the named application
+	// that have entered the relation scope.
+	UnitsInScope(appName string) ([]RelationUnit, error)
+}
+
+// RelationUnit defines the subset of state.RelationUnit required by the
+// relationdata facade.
+type RelationUnit interface {
+	UnitName() string
+	Settings() (map[string]interface{}, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.ModelUUID())
+}
+
+func (s stateShim) Application(name string) (Application, error) {
+	app, err := s.State.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return stateApplicationShim{Application: app, st: s.State}, nil
+}
+
+func (s stateShim) Unit(name string) (Unit, error) {
+	unit, err := s.State.Unit(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unit, nil
+}
+
+type stateApplicationShim struct {
+	*state.Application
+	st *state.State
+}
+
+func (a stateApplicationShim) Relations() ([]Relation, error) {
+	rels, err := a.Application.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]Relation, len(rels))
+	for i, rel := range rels {
+		out[i] = stateRelationShim{Relation: rel, st: a.st}
+	}
+	return out, nil
+}
+
+type stateRelationShim struct {
+	*state.Relation
+	st *state.State
+}
+
+func (r stateRelationShim) ApplicationSettings(appName string) (map[string]interface{}, error) {
+	settings, err := r.Relation.ApplicationSettings(appName)
+	if errors.IsNotFound(err) {
+		// Remote applications have no settings until they are
+		// published by the offering model.
+		return map[string]interface{}{}, nil
+	}
+	return settings, errors.Trace(err)
+}
+
+func (r stateRelationShim) UnitsInScope(appName string) ([]RelationUnit, error) {
+	app, err := r.st.Application(appName)
+	if errors.IsNotFound(err) {
+		return r.remoteUnitsInScope(appName)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var out []RelationUnit
+	for _, u := range units {
+		ru, err := r.Relation.Unit(u)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		inScope, err := ru.InScope()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if inScope {
+			out = append(out, stateRelationUnitShim{ru})
+		}
+	}
+	return out, nil
+}
+
+func (r stateRelationShim) remoteUnitsInScope(appName string) ([]RelationUnit, error) {
+	rus, err := r.Relation.AllRemoteUnits(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var out []RelationUnit
+	for _, ru := range rus {
+		inScope, err := ru.InScope()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if inScope {
+			out = append(out, stateRelationUnitShim{ru})
+		}
+	}
+	return out, nil
+}
+
+type stateRelationUnitShim struct {
+	*state.RelationUnit
+}
+
+func (ru stateRelationUnitShim) Settings() (map[string]interface{}, error) {
+	s, err := ru.RelationUnit.Settings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.Map(), nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relationdata_test
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/facades/client/relationdata"
+	"github.com/juju/juju/state"
+)
+
+type mockBackend struct {
+	modelUUID    string
+	applications map[string]*mockApplication
+	units        []string
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return names.NewModelTag(b.modelUUID)
+}
+
+func (b *mockBackend) Application(name string) (relationdata.Application, error) {
+	app, ok := b.applications[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return app, nil
+}
+
+func (b *mockBackend) Unit(name string) (relationdata.Unit, error) {
+	for _, unit := range b.units {
+		if unit == name {
+			return &mockUnit{name: name}, nil
+		}
+	}
+	return nil, errors.NotFoundf("unit %q", name)
+}
+
+type mockUnit struct {
+	name string
+}
+
+func (u *mockUnit) Name() string {
+	return u.name
+}
+
+type mockApplication struct {
+	relations []relationdata.Relation
+}
+
+func (a *mockApplication) Relations() ([]relationdata.Relation, error) {
+	return a.relations, nil
+}
+
+type mockRelation struct {
+	id          int
+	endpoints   []state.Endpoint
+	appSettings map[string]map[string]interface{}
+	units       map[string][]relationdata.RelationUnit
+}
+
+func (r *mockRelation) Id() int {
+	return r.id
+}
+
+func (r *mockRelation) String() string {
+	return fmt.Sprintf("%s %s", r.endpoints[0], r.endpoints[1])
+}
+
+func (r *mockRelation) Endpoint(appName string) (state.Endpoint, error) {
+	for _, ep := range r.endpoints {
+		if ep.ApplicationName == appName {
+			return ep, nil
+		}
+	}
+	return state.Endpoint{}, errors.NotFoundf("endpoint for %q", appName)
+}
+
+func (r *mockRelation) Endpoints() []state.Endpoint {
+	return r.endpoints
+}
+
+func (r *mockRelation) ApplicationSettings(appName string) (map[string]interface{}, error) {
+	return r.appSettings[appName], nil
+}
+
+func (r *mockRelation) UnitsInScope(appName string) ([]relationdata.RelationUnit, error) {
+	return r.units[appName], nil
+}
+
+type mockRelationUnit struct {
+	name     string
+	settings map[string]interface{}
+}
+
+func (ru *mockRelationUnit) UnitName() string {
+	return ru.name
+}
+
+func (ru *mockRelationUnit) Settings() (map[string]interface{}, error) {
+	return ru.settings, nil
+}
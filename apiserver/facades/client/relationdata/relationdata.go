@@ -0,0 +1,152 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relationdata
+
+import (
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/permission"
+)
+
+// API provides the relationdata facade APIs for v1. It allows model
+// administrators to inspect the settings that units and applications
+// have published to their relations.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(NewStateBackend(ctx.State()), ctx.Auth())
+}
+
+// NewAPI returns a new relationdata API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkAdmin() error {
+	allowed, err := api.authorizer.HasPermission(permission.AdminAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
+// RelationData returns the application and unit settings of every
+// relation that the specified application endpoints participate in.
+func (api *API) RelationData(args params.RelationDataArgs) (params.RelationDataResults, error) {
+	if err := api.checkAdmin(); err != nil {
+		return params.RelationDataResults{}, err
+	}
+	results := make([]params.RelationDataResult, len(args.Args))
+	for i, arg := range args.Args {
+		relations, err := api.relationData(arg)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results[i].Relations = relations
+	}
+	return params.RelationDataResults{Results: results}, nil
+}
+
+func (api *API) relationData(arg params.RelationDataArg) ([]params.RelationSettingsData, error) {
+	appName, endpoint, err := parseEndpoint(arg.Endpoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, unit := range arg.Units {
+		if !names.IsValidUnit(unit) {
+			return nil, errors.NotValidf("unit name %q", unit)
+		}
+	}
+	wanted := set.NewStrings(arg.Units...)
+	seen := set.NewStrings()
+
+	app, err := api.backend.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rels, err := app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []params.RelationSettingsData
+	for _, rel := range rels {
+		ep, err := rel.Endpoint(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ep.Name != endpoint {
+			continue
+		}
+		data := params.RelationSettingsData{
+			RelationId:          rel.Id(),
+			Key:                 rel.String(),
+			ApplicationSettings: make(map[string]map[string]interface{}),
+			UnitSettings:        make(map[string]map[string]interface{}),
+		}
+		for _, relEp := range rel.Endpoints() {
+			settings, err := rel.ApplicationSettings(relEp.ApplicationName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			data.ApplicationSettings[relEp.ApplicationName] = settings
+
+			units, err := rel.UnitsInScope(relEp.ApplicationName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, ru := range units {
+				if !wanted.IsEmpty() && !wanted.Contains(ru.UnitName()) {
+					continue
+				}
+				settings, err := ru.Settings()
+				if errors.IsNotFound(err) {
+					continue
+				} else if err != nil {
+					return nil, errors.Trace(err)
+				}
+				data.UnitSettings[ru.UnitName()] = settings
+				seen.Add(ru.UnitName())
+			}
+		}
+		result = append(result, data)
+	}
+	// A unit that is not in scope of any of the relations has no data,
+	// but a unit that does not exist at all is an error.
+	for _, unit := range wanted.Difference(seen).SortedValues() {
+		if _, err := api.backend.Unit(unit); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return result, nil
+}
+
+// parseEndpoint splits an endpoint of the form <application>:<endpoint>.
+func parseEndpoint(value string) (string, string, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 || !names.IsValidApplication(parts[0]) || parts[1] == "" {
+		return "", "", errors.NotValidf("endpoint %q", value)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relationdata_test
+
+import (
+	"github.com/juju/charm/v7"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facades/client/relationdata"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RelationDataSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *relationdata.API
+}
+
+var _ = gc.Suite(&RelationDataSuite{})
+
+func (s *RelationDataSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	mysql := state.Endpoint{
+		ApplicationName: "mysql",
+		Relation:        charm.Relation{Name: "db", Interface: "mysql", Role: charm.RoleProvider},
+	}
+	wordpress := state.Endpoint{
+		ApplicationName: "wordpress",
+		Relation:        charm.Relation{Name: "db", Interface: "mysql", Role: charm.RoleRequirer},
+	}
+	rel := &mockRelation{
+		id:        7,
+		endpoints: []state.Endpoint{wordpress, mysql},
+		appSettings: map[string]map[string]interface{}{
+			"mysql":     {"database": "wordpress"},
+			"wordpress": {},
+		},
+		units: map[string][]relationdata.RelationUnit{
+			"mysql": {
+				&mockRelationUnit{name: "mysql/0", settings: map[string]interface{}{"host": "10.0.0.1"}},
+			},
+			"wordpress": {
+				&mockRelationUnit{name: "wordpress/0", settings: map[string]interface{}{"ingress-address": "10.0.0.2"}},
+				&mockRelationUnit{name: "wordpress/1", settings: map[string]interface{}{"ingress-address": "10.0.0.3"}},
+			},
+		},
+	}
+	s.backend = &mockBackend{
+		modelUUID: coretesting.ModelTag.Id(),
+		applications: map[string]*mockApplication{
+			"mysql":     {relations: []relationdata.Relation{rel}},
+			"wordpress": {relations: []relationdata.Relation{rel}},
+		},
+		units: []string{"mysql/0", "mysql/1", "wordpress/0", "wordpress/1"},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	api, err := relationdata.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *RelationDataSuite) TestRelationData(c *gc.C) {
+	results, err := s.api.RelationData(params.RelationDataArgs{
+		Args: []params.RelationDataArg{{Endpoint: "mysql:db"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.RelationDataResults{
+		Results: []params.RelationDataResult{{
+			Relations: []params.RelationSettingsData{{
+				RelationId: 7,
+				Key:        "wordpress:db mysql:db",
+				ApplicationSettings: map[string]map[string]interface{}{
+					"mysql":     {"database": "wordpress"},
+					"wordpress": {},
+				},
+				UnitSettings: map[string]map[string]interface{}{
+					"mysql/0":     {"host": "10.0.0.1"},
+					"wordpress/0": {"ingress-address": "10.0.0.2"},
+					"wordpress/1": {"ingress-address": "10.0.0.3"},
+				},
+			}},
+		}},
+	})
+}
+
+func (s *RelationDataSuite) TestRelationDataForUnits(c *gc.C) {
+	results, err := s.api.RelationData(params.RelationDataArgs{
+		Args: []params.RelationDataArg{{
+			Endpoint: "wordpress:db",
+			Units:    []string{"wordpress/1"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Relations, gc.HasLen, 1)
+	c.Assert(results.Results[0].Relations[0].UnitSettings, jc.DeepEquals, map[string]map[string]interface{}{
+		"wordpress/1": {"ingress-address": "10.0.0.3"},
+	})
+}
+
+func (s *RelationDataSuite) TestRelationDataUnitNotInScope(c *gc.C) {
+	results, err := s.api.RelationData(params.RelationDataArgs{
+		Args: []params.RelationDataArg{{
+			Endpoint: "mysql:db",
+			Units:    []string{"mysql/1"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Relations, gc.HasLen, 1)
+	c.Assert(results.Results[0].Relations[0].UnitSettings, gc.HasLen, 0)
+}
+
+func (s *RelationDataSuite) TestRelationDataUnitNotFound(c *gc.C) {
+	results, err := s.api.RelationData(params.RelationDataArgs{
+		Args: []params.RelationDataArg{{
+			Endpoint: "mysql:db",
+			Units:    []string{"mysql/0", "mysql/9"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `unit "mysql/9" not found`)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *RelationDataSuite) TestRelationDataOtherEndpoint(c *gc.C) {
+	results, err := s.api.RelationData(params.RelationDataArgs{
+		Args: []params.RelationDataArg{{Endpoint: "mysql:cluster"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.RelationDataResult{{}})
+}
+
+func (s *RelationDataSuite) TestRelationDataErrors(c *gc.C) {
+	results, err := s.api.RelationData(params.RelationDataArgs{
+		Args: []params.RelationDataArg{
+			{Endpoint: "mysql"},
+			{Endpoint: "mysql:db", Units: []string{"mysql-0"}},
+			{Endpoint: "postgresql:db"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `endpoint "mysql" not valid`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `unit name "mysql-0" not valid`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `application "postgresql" not found`)
+}
+
+func (s *RelationDataSuite) TestRelationDataRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	api, err := relationdata.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.RelationData(params.RelationDataArgs{
+		Args: []params.RelationDataArg{{Endpoint: "mysql:db"}},
+	})
+	c.Assert(err, gc.Equals, apiservererrors.ErrPerm)
+}
+
+func (s *RelationDataSuite) TestNonClientNotAllowed(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := relationdata.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, apiservererrors.ErrPerm)
+}
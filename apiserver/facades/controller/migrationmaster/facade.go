@@ -34,13 +34,21 @@ type API struct {
 	presence        facade.Presence
 }
 
-type APIV1 struct {
+// APIV2 implements version 2 of the MigrationMaster API, which
+// doesn't support SetProgress.
+type APIV2 struct {
 	*API
 }
 
-// NewMigrationMasterFacadeV2 exists to provide the required signature for API
+// APIV1 implements version 1 of the MigrationMaster API, which
+// doesn't support ProcessRelations.
+type APIV1 struct {
+	*APIV2
+}
+
+// NewMigrationMasterFacadeV3 exists to provide the required signature for API
 // registration, converting st to backend.
-func NewMigrationMasterFacadeV2(ctx facade.Context) (*API, error) {
+func NewMigrationMasterFacadeV3(ctx facade.Context) (*API, error) {
 	controllerState := ctx.StatePool().SystemState()
 	precheckBackend, err := migration.PrecheckShim(ctx.State(), controllerState)
 	if err != nil {
@@ -56,6 +64,16 @@ func NewMigrationMasterFacadeV2(ctx facade.Context) (*API, error) {
 	)
 }
 
+// NewMigrationMasterFacadeV2 exists to provide the required signature for API
+// registration, converting st to backend.
+func NewMigrationMasterFacadeV2(ctx facade.Context) (*APIV2, error) {
+	v3, err := NewMigrationMasterFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV2{v3}, nil
+}
+
 // NewMigrationMasterFacade exists to provide the required signature for API
 // registration, converting st to backend.
 func NewMigrationMasterFacade(ctx facade.Context) (*APIV1, error) {
@@ -223,6 +241,28 @@ func (api *API) SetStatusMessage(args params.SetMigrationStatusMessageArgs) erro
 	return errors.Annotate(err, "failed to set status message")
 }
 
+// SetProgress is masked on older versions of the migration master API.
+func (api *APIV2) SetProgress(_, _ struct{}) {}
+
+// SetProgress records the counts of entities exported and imported
+// and of logs transferred so far by the migration.
+func (api *API) SetProgress(args params.SetMigrationProgressArgs) error {
+	mig, err := api.backend.LatestMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	progress := coremigration.Progress{
+		ExportedEntities: args.ExportedEntities,
+		ImportedEntities: args.ImportedEntities,
+		LogsTransferred:  args.LogsTransferred,
+	}
+	if args.LastLogTime != nil {
+		progress.LastLogTime = *args.LastLogTime
+	}
+	err = mig.SetProgress(progress)
+	return errors.Annotate(err, "failed to set progress")
+}
+
 // Export serializes the model associated with the API connection.
 func (api *API) Export() (params.SerializedModel, error) {
 	var serialized params.SerializedModel
@@ -239,6 +279,7 @@ func (api *API) Export() (params.SerializedModel, error) {
 	serialized.Bytes = bytes
 	serialized.Charms = getUsedCharms(model)
 	serialized.Resources = getUsedResources(model)
+	serialized.Entities = migration.ModelEntityCounts(model)
 	if model.Type() == string(coremodel.IAAS) {
 		serialized.Tools = getUsedTools(model)
 	}
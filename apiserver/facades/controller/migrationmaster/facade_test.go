@@ -247,6 +247,43 @@ func (s *Suite) TestSetStatusMessageError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "failed to set status message: blam")
 }
 
+func (s *Suite) TestSetProgress(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	lastLogTime := time.Date(2020, 3, 1, 12, 30, 0, 0, time.UTC)
+	mig := mocks.NewMockModelMigration(ctrl)
+	mig.EXPECT().SetProgress(coremigration.Progress{
+		ExportedEntities: map[string]int{"machines": 2},
+		ImportedEntities: map[string]int{"machines": 2},
+		LogsTransferred:  10,
+		LastLogTime:      lastLogTime,
+	}).Return(nil)
+
+	s.backend.EXPECT().LatestMigration().Return(mig, nil)
+
+	err := s.mustMakeAPI(c).SetProgress(params.SetMigrationProgressArgs{
+		ExportedEntities: map[string]int{"machines": 2},
+		ImportedEntities: map[string]int{"machines": 2},
+		LogsTransferred:  10,
+		LastLogTime:      &lastLogTime,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *Suite) TestSetProgressError(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	mig := mocks.NewMockModelMigration(ctrl)
+	mig.EXPECT().SetProgress(coremigration.Progress{LogsTransferred: 10}).Return(errors.New("blam"))
+
+	s.backend.EXPECT().LatestMigration().Return(mig, nil)
+
+	err := s.mustMakeAPI(c).SetProgress(params.SetMigrationProgressArgs{LogsTransferred: 10})
+	c.Assert(err, gc.ErrorMatches, "failed to set progress: blam")
+}
+
 func (s *Suite) TestPrechecksModelError(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
@@ -395,7 +432,13 @@ func (s *Suite) assertExport(c *gc.C, modelType string) {
 			},
 		},
 	}})
-
+	c.Check(serialized.Entities, jc.DeepEquals, map[string]int{
+		"machines":            1,
+		"applications":        1,
+		"units":               1,
+		"relations":           0,
+		"remote-applications": 0,
+	})
 }
 
 func (s *Suite) TestReap(c *gc.C) {
@@ -68,6 +68,21 @@ func (mr *MockPrecheckBackendMockRecorder) AllApplications() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllApplications", reflect.TypeOf((*MockPrecheckBackend)(nil).AllApplications))
 }
 
+// AllBlocks mocks base method
+func (m *MockPrecheckBackend) AllBlocks() ([]migration.PrecheckBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllBlocks")
+	ret0, _ := ret[0].([]migration.PrecheckBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllBlocks indicates an expected call of AllBlocks
+func (mr *MockPrecheckBackendMockRecorder) AllBlocks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllBlocks", reflect.TypeOf((*MockPrecheckBackend)(nil).AllBlocks))
+}
+
 // AllMachines mocks base method
 func (m *MockPrecheckBackend) AllMachines() ([]migration.PrecheckMachine, error) {
 	m.ctrl.T.Helper()
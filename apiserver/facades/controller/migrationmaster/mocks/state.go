@@ -110,6 +110,21 @@ func (mr *MockModelMigrationMockRecorder) MinionReports() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinionReports", reflect.TypeOf((*MockModelMigration)(nil).MinionReports))
 }
 
+// MinionReportsForPhase mocks base method
+func (m *MockModelMigration) MinionReportsForPhase(arg0 migration.Phase) (*state.MinionReports, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MinionReportsForPhase", arg0)
+	ret0, _ := ret[0].(*state.MinionReports)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MinionReportsForPhase indicates an expected call of MinionReportsForPhase
+func (mr *MockModelMigrationMockRecorder) MinionReportsForPhase(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinionReportsForPhase", reflect.TypeOf((*MockModelMigration)(nil).MinionReportsForPhase), arg0)
+}
+
 // ModelUUID mocks base method
 func (m *MockModelMigration) ModelUUID() string {
 	m.ctrl.T.Helper()
@@ -167,6 +182,34 @@ func (mr *MockModelMigrationMockRecorder) PhaseChangedTime() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PhaseChangedTime", reflect.TypeOf((*MockModelMigration)(nil).PhaseChangedTime))
 }
 
+// PhaseTimes mocks base method
+func (m *MockModelMigration) PhaseTimes() []migration.PhaseTime {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PhaseTimes")
+	ret0, _ := ret[0].([]migration.PhaseTime)
+	return ret0
+}
+
+// PhaseTimes indicates an expected call of PhaseTimes
+func (mr *MockModelMigrationMockRecorder) PhaseTimes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PhaseTimes", reflect.TypeOf((*MockModelMigration)(nil).PhaseTimes))
+}
+
+// Progress mocks base method
+func (m *MockModelMigration) Progress() migration.Progress {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Progress")
+	ret0, _ := ret[0].(migration.Progress)
+	return ret0
+}
+
+// Progress indicates an expected call of Progress
+func (mr *MockModelMigrationMockRecorder) Progress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Progress", reflect.TypeOf((*MockModelMigration)(nil).Progress))
+}
+
 // Refresh mocks base method
 func (m *MockModelMigration) Refresh() error {
 	m.ctrl.T.Helper()
@@ -195,6 +238,20 @@ func (mr *MockModelMigrationMockRecorder) SetPhase(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPhase", reflect.TypeOf((*MockModelMigration)(nil).SetPhase), arg0)
 }
 
+// SetProgress mocks base method
+func (m *MockModelMigration) SetProgress(arg0 migration.Progress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProgress", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProgress indicates an expected call of SetProgress
+func (mr *MockModelMigrationMockRecorder) SetProgress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProgress", reflect.TypeOf((*MockModelMigration)(nil).SetProgress), arg0)
+}
+
 // SetStatusMessage mocks base method
 func (m *MockModelMigration) SetStatusMessage(arg0 string) error {
 	m.ctrl.T.Helper()
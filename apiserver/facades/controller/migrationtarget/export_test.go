@@ -0,0 +1,8 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migrationtarget
+
+var StateEntityCounts = &stateEntityCounts
+
+var FindTools = &findTools
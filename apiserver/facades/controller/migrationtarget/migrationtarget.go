@@ -7,8 +7,11 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
+	"github.com/juju/version"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/credentialcommon"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
@@ -24,6 +27,22 @@ import (
 	"github.com/juju/juju/state/stateenvirons"
 )
 
+var logger = loggo.GetLogger("juju.apiserver.migrationtarget")
+
+// stateEntityCounts is overridden by tests.
+var stateEntityCounts = migration.StateEntityCounts
+
+// findTools is overridden by tests.
+var findTools = func(st *state.State, model *state.Model, args params.FindToolsParams) (params.FindToolsResult, error) {
+	configGetter := stateenvirons.EnvironConfigGetter{Model: model}
+	finder := common.NewToolsFinder(
+		configGetter, st,
+		common.NewToolsURLGetter(model.UUID(), st),
+		common.EnvironFuncForModel(model, configGetter),
+	)
+	return finder.FindTools(args)
+}
+
 // API implements the API required for the model migration
 // master worker when communicating with the target controller.
 type API struct {
@@ -37,14 +56,44 @@ type API struct {
 	getCAASBroker stateenvirons.NewCAASBrokerFunc
 }
 
-// NewFacade is used for API registration.
-func NewFacade(ctx facade.Context) (*API, error) {
+// APIV2 implements the v2 MigrationTarget API, whose Import method
+// doesn't report the entities imported.
+type APIV2 struct {
+	*API
+}
+
+// APIV1 implements the v1 MigrationTarget API, which doesn't have
+// the DryRunPrechecks method.
+type APIV1 struct {
+	*APIV2
+}
+
+// NewFacadeV3 is used for API registration.
+func NewFacadeV3(ctx facade.Context) (*API, error) {
 	return NewAPI(
 		ctx,
 		stateenvirons.GetNewEnvironFunc(environs.New),
 		stateenvirons.GetNewCAASBrokerFunc(caas.New))
 }
 
+// NewFacadeV2 is used for API registration.
+func NewFacadeV2(ctx facade.Context) (*APIV2, error) {
+	v3, err := NewFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV2{v3}, nil
+}
+
+// NewFacade is used for API registration.
+func NewFacade(ctx facade.Context) (*APIV1, error) {
+	v2, err := NewFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{v2}, nil
+}
+
 // NewAPI returns a new API. Accepts a NewEnvironFunc and context.ProviderCallContext
 // for testing purposes.
 func NewAPI(ctx facade.Context, getEnviron stateenvirons.NewEnvironFunc, getCAASBroker stateenvirons.NewCAASBrokerFunc) (*API, error) {
@@ -82,10 +131,46 @@ func checkAuth(authorizer facade.Authorizer, st *state.State) error {
 // Prechecks ensure that the target controller is ready to accept a
 // model migration.
 func (api *API) Prechecks(model params.MigrationModelInfo) error {
-	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	report, err := api.precheckReport(model)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	return report.Err()
+}
+
+// DryRunPrechecks runs every target precheck for the model and checks
+// its serialized description for problems known to stop it being
+// imported. The model isn't imported, so this can't catch every
+// problem an import would meet. Every problem found is returned,
+// along with the time on the controller's clock, so a migration dry
+// run can check the clocks of the two controllers agree.
+func (api *API) DryRunPrechecks(args params.MigrationDryRunPrecheckArgs) (params.MigrationDryRunPrecheckResult, error) {
+	var result params.MigrationDryRunPrecheckResult
+	report, err := api.precheckReport(args.ModelInfo)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	descReport, err := migration.CheckModelDescription(migration.DescriptionCheckShim(api.pool.SystemState()), args.Bytes)
+	if err != nil {
+		return result, errors.Annotate(err, "checking model description")
+	}
+	report.Blockers = append(report.Blockers, descReport.Blockers...)
+	report.Warnings = append(report.Warnings, descReport.Warnings...)
+	now, err := api.state.ControllerTimestamp()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Blockers = report.Blockers
+	result.Warnings = report.Warnings
+	result.Time = *now
+	return result, nil
+}
+
+func (api *API) precheckReport(model params.MigrationModelInfo) (*migration.PrecheckReport, error) {
+	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	controllerState := api.pool.SystemState()
 	// NOTE (thumper): it isn't clear to me why api.state would be different
 	// from the controllerState as I had thought that the Precheck call was
@@ -93,9 +178,9 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	// controllerState.
 	backend, err := migration.PrecheckShim(api.state, controllerState)
 	if err != nil {
-		return errors.Annotate(err, "creating backend")
+		return nil, errors.Annotate(err, "creating backend")
 	}
-	return migration.TargetPrecheck(
+	report, err := migration.TargetPrecheckReport(
 		backend,
 		migration.PoolShim(api.pool),
 		coremigration.ModelInfo{
@@ -107,21 +192,36 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 		},
 		api.presence.ModelPresence(controllerState.ModelUUID()),
 	)
+	return report, errors.Trace(err)
 }
 
 // Import takes a serialized Juju model, deserializes it, and
 // recreates it in the receiving controller.
-func (api *API) Import(serialized params.SerializedModel) error {
+func (api *APIV2) Import(serialized params.SerializedModel) error {
+	_, err := api.API.Import(serialized)
+	return err
+}
+
+// Import takes a serialized Juju model, deserializes it, and
+// recreates it in the receiving controller. It returns the number of
+// each kind of entity imported, if they could be counted.
+func (api *API) Import(serialized params.SerializedModel) (params.MigrationImportResult, error) {
+	var result params.MigrationImportResult
 	controller := state.NewController(api.pool)
 	_, st, err := migration.ImportModel(controller, api.getClaimer, serialized.Bytes)
 	if err != nil {
-		return err
+		return result, err
 	}
 	defer st.Close()
 	// TODO(mjs) - post import checks
 	// NOTE(fwereade) - checks here would be sensible, but we will
 	// also need to check after the binaries are imported too.
-	return err
+	// The model has been imported by now, so failing to count its
+	// entities mustn't fail the import: the counts are only reported.
+	if result.Entities, err = stateEntityCounts(st); err != nil {
+		logger.Warningf("counting entities imported for model %q: %v", st.ModelUUID(), err)
+	}
+	return result, nil
 }
 
 func (api *API) getModel(modelTag string) (*state.Model, func(), error) {
@@ -175,14 +275,105 @@ func (api *API) Activate(args params.ModelArgs) error {
 	}
 	defer release()
 
+	// Agent binaries for the upgraded version must be available before
+	// the model is activated, otherwise its agents would be left unable
+	// to upgrade. Failing here aborts the migration.
+	if err := api.upgradeAgents(model); err != nil {
+		return errors.Annotatef(err, "upgrading agents of migrated model %q", model.Name())
+	}
+
 	if err := model.SetStatus(status.StatusInfo{Status: status.Available}); err != nil {
 		return errors.Trace(err)
 	}
-
-	// TODO(fwereade) - need to validate binaries here.
 	return model.SetMigrationMode(state.MigrationModeNone)
 }
 
+// upgradeAgents sets the agent version of a model migrated from a
+// controller running an older version of Juju to the version of this
+// controller, so that the model's agents upgrade once they have
+// connected here. An error is returned if there are no agent binaries
+// of that version for the machines in the model.
+func (api *API) upgradeAgents(model *state.Model) error {
+	modelVersion, err := model.AgentVersion()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerModel, err := api.state.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerVersion, err := controllerModel.AgentVersion()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newVersion, upgrade := migration.AgentUpgradeVersion(modelVersion, controllerVersion)
+	if !upgrade {
+		return nil
+	}
+
+	st, err := api.pool.Get(model.UUID())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Release()
+	if err := checkAgentBinaries(st.State, model, newVersion); err != nil {
+		return errors.Trace(err)
+	}
+	if err := st.SetModelAgentVersion(newVersion, false); err != nil {
+		return errors.Annotatef(err, "setting agent version to %s", newVersion)
+	}
+	logger.Infof("upgrading agents of migrated model %q from %s to %s", model.Name(), modelVersion, newVersion)
+	return nil
+}
+
+// checkAgentBinaries returns an error if agent binaries of the given
+// version can't be found for the series and architecture of any
+// machine in the model. CAAS models run operator images rather than
+// agent binaries, so there is nothing to check for them.
+func checkAgentBinaries(st *state.State, model *state.Model, agentVersion version.Number) error {
+	if model.Type() == state.ModelTypeCAAS {
+		return nil
+	}
+	machines, err := st.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	checked := make(map[string]bool)
+	for _, m := range machines {
+		var arch string
+		hw, err := m.HardwareCharacteristics()
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		} else if err == nil && hw.Arch != nil {
+			arch = *hw.Arch
+		}
+		platform := m.Series()
+		if arch != "" {
+			platform += "/" + arch
+		}
+		if checked[platform] {
+			continue
+		}
+		checked[platform] = true
+
+		result, err := findTools(st, model, params.FindToolsParams{
+			Number: agentVersion,
+			Series: m.Series(),
+			Arch:   arch,
+		})
+		if err == nil && result.Error != nil {
+			err = result.Error
+		}
+		if err != nil {
+			return errors.Annotatef(err, "finding agent binaries %s for %s", agentVersion, platform)
+		}
+		if len(result.List) == 0 {
+			return errors.NotFoundf("agent binaries %s for %s", agentVersion, platform)
+		}
+	}
+	return nil
+}
+
 // LatestLogTime returns the time of the most recent log record
 // received by the logtransfer endpoint. This can be used as the start
 // point for streaming logs from the source if the transfer was
@@ -280,3 +471,6 @@ func (api *API) CACert() (params.BytesResult, error) {
 	caCert, _ := cfg.CACert()
 	return params.BytesResult{Result: []byte(caCert)}, nil
 }
+
+// DryRunPrechecks isn't on the v1 API.
+func (api *APIV1) DryRunPrechecks(_, _ struct{}) {}
@@ -37,6 +37,7 @@ import (
 	statetesting "github.com/juju/juju/state/testing"
 	jujutesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
+	coretools "github.com/juju/juju/tools"
 )
 
 type Suite struct {
@@ -85,6 +86,28 @@ func (s *Suite) TestFacadeRegistered(c *gc.C) {
 		Auth_:      s.authorizer,
 	})
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV1))
+
+	aFactory, err = apiserver.AllFacades().GetFactory("MigrationTarget", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err = aFactory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV2))
+
+	aFactory, err = apiserver.AllFacades().GetFactory("MigrationTarget", 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err = aFactory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.API))
 }
 
@@ -102,7 +125,7 @@ func (s *Suite) TestNotControllerAdmin(c *gc.C) {
 
 func (s *Suite) importModel(c *gc.C, api *migrationtarget.API) names.ModelTag {
 	uuid, bytes := s.makeExportedModel(c)
-	err := api.Import(params.SerializedModel{Bytes: bytes})
+	_, err := api.Import(params.SerializedModel{Bytes: bytes})
 	c.Assert(err, jc.ErrorIsNil)
 	return names.NewModelTag(uuid)
 }
@@ -142,6 +165,52 @@ func (s *Suite) TestPrechecksFail(c *gc.C) {
 	c.Assert(err, gc.NotNil)
 }
 
+func (s *Suite) TestDryRunPrechecks(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	result, err := api.DryRunPrechecks(params.MigrationDryRunPrecheckArgs{
+		ModelInfo: params.MigrationModelInfo{
+			UUID:                   uuid,
+			Name:                   "some-model",
+			OwnerTag:               names.NewUserTag("someone").String(),
+			AgentVersion:           s.controllerVersion(c),
+			ControllerAgentVersion: s.controllerVersion(c),
+		},
+		Bytes: bytes,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Blockers, gc.HasLen, 0)
+	c.Check(result.Time.IsZero(), jc.IsFalse)
+
+	// Nothing was imported.
+	exists, err := s.State.ModelExists(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(exists, jc.IsFalse)
+}
+
+func (s *Suite) TestDryRunPrechecksReportsAllProblems(c *gc.C) {
+	controllerVersion := s.controllerVersion(c)
+	modelVersion := controllerVersion
+	modelVersion.Minor++
+
+	api := s.mustNewAPI(c)
+	result, err := api.DryRunPrechecks(params.MigrationDryRunPrecheckArgs{
+		ModelInfo: params.MigrationModelInfo{
+			UUID:                   s.State.ModelUUID(),
+			Name:                   "some-model",
+			OwnerTag:               names.NewUserTag("someone").String(),
+			AgentVersion:           modelVersion,
+			ControllerAgentVersion: controllerVersion,
+		},
+		Bytes: []byte("not a model"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Blockers, gc.HasLen, 3)
+	c.Check(result.Blockers[0], gc.Matches, `model has higher version than target controller .*`)
+	c.Check(result.Blockers[1], gc.Matches, `model with same UUID already exists .*`)
+	c.Check(result.Blockers[2], gc.Matches, `model description can't be read: .*`)
+}
+
 func (s *Suite) TestImport(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -153,6 +222,42 @@ func (s *Suite) TestImport(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
 }
 
+func (s *Suite) TestImportEntityCounts(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := s.mustNewAPI(c)
+	_, bytes := s.makeExportedModel(c)
+	result, err := api.Import(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Entities, jc.DeepEquals, map[string]int{
+		"machines":            len(machines),
+		"applications":        1,
+		"units":               1,
+		"relations":           0,
+		"remote-applications": 0,
+	})
+}
+
+func (s *Suite) TestImportEntityCountsFailure(c *gc.C) {
+	s.PatchValue(migrationtarget.StateEntityCounts, func(*state.State) (map[string]int, error) {
+		return nil, errors.New("boom")
+	})
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	result, err := api.Import(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Entities, gc.IsNil)
+	c.Check(c.GetTestLog(), jc.Contains, "counting entities imported for model \""+uuid+"\": boom")
+
+	// The model was still imported.
+	_, ph, err := s.StatePool.GetModel(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	ph.Release()
+}
+
 func (s *Suite) TestImportLeadership(c *gc.C) {
 	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
 		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{
@@ -230,6 +335,81 @@ func (s *Suite) TestActivate(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeNone)
 }
 
+func (s *Suite) TestActivateUpgradesOlderModel(c *gc.C) {
+	var found []params.FindToolsParams
+	s.PatchValue(migrationtarget.FindTools, func(_ *state.State, _ *state.Model, args params.FindToolsParams) (params.FindToolsResult, error) {
+		found = append(found, args)
+		return params.FindToolsResult{List: coretools.List{{
+			Version: version.Binary{Number: args.Number, Series: args.Series, Arch: args.Arch},
+		}}}, nil
+	})
+	s.Factory.MakeMachine(c, &factory.MachineParams{Series: "quantal"})
+	api := s.mustNewAPI(c)
+	controllerVersion := s.controllerVersion(c)
+	olderVersion := controllerVersion
+	olderVersion.Minor--
+	uuid, bytes := s.makeExportedModelAtVersion(c, olderVersion)
+	_, err := api.Import(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.Activate(params.ModelArgs{ModelTag: names.NewModelTag(uuid).String()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, ph, err := s.StatePool.GetModel(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	defer ph.Release()
+	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeNone)
+	agentVersion, err := model.AgentVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agentVersion, gc.Equals, controllerVersion)
+	c.Assert(found, gc.HasLen, 1)
+	c.Check(found[0].Number, gc.Equals, controllerVersion)
+	c.Check(found[0].Series, gc.Equals, "quantal")
+}
+
+func (s *Suite) TestActivateMissingAgentBinaries(c *gc.C) {
+	s.PatchValue(migrationtarget.FindTools, func(*state.State, *state.Model, params.FindToolsParams) (params.FindToolsResult, error) {
+		return params.FindToolsResult{}, nil
+	})
+	s.Factory.MakeMachine(c, &factory.MachineParams{Series: "quantal"})
+	api := s.mustNewAPI(c)
+	controllerVersion := s.controllerVersion(c)
+	olderVersion := controllerVersion
+	olderVersion.Minor--
+	uuid, bytes := s.makeExportedModelAtVersion(c, olderVersion)
+	_, err := api.Import(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.Activate(params.ModelArgs{ModelTag: names.NewModelTag(uuid).String()})
+	c.Assert(err, gc.ErrorMatches, `upgrading agents of migrated model "some-model": agent binaries `+controllerVersion.String()+` for quantal.* not found`)
+
+	// The model is left importing at its original version, so the
+	// migration can be aborted.
+	model, ph, err := s.StatePool.GetModel(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	defer ph.Release()
+	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
+	agentVersion, err := model.AgentVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agentVersion, gc.Equals, olderVersion)
+}
+
+func (s *Suite) TestImportUpgradesOlderModelDescription(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModelAtVersion(c, version.MustParse("2.8.0"))
+	_, err := api.Import(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, ph, err := s.StatePool.GetModel(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	defer ph.Release()
+	cfg, err := model.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	charmhubURL, ok := cfg.CharmhubURL()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(charmhubURL, gc.Not(gc.Equals), "")
+}
+
 func (s *Suite) TestActivateNotATag(c *gc.C) {
 	api := s.mustNewAPI(c)
 	err := api.Activate(params.ModelArgs{ModelTag: "not-a-tag"})
@@ -456,6 +636,26 @@ func (s *Suite) makeExportedModel(c *gc.C) (string, []byte) {
 	return newUUID, bytes
 }
 
+// makeExportedModelAtVersion exports a model as a controller running
+// an older version of Juju might, with the given agent version and
+// without the config added by later versions.
+func (s *Suite) makeExportedModelAtVersion(c *gc.C, agentVersion version.Number) (string, []byte) {
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	newUUID := utils.MustNewUUID().String()
+	model.UpdateConfig(map[string]interface{}{
+		"name":          "some-model",
+		"uuid":          newUUID,
+		"agent-version": agentVersion.String(),
+	})
+	delete(model.Config(), "charmhub-url")
+
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	return newUUID, bytes
+}
+
 func (s *Suite) controllerVersion(c *gc.C) version.Number {
 	cfg, err := s.Model.ModelConfig()
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,103 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelexpiry_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/apiserver/facades/controller/modelexpiry"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	testing.Stub
+	model        *mockModel
+	isController bool
+	blocks       map[state.BlockType]string
+}
+
+func (b *mockBackend) Model() (modelexpiry.Model, error) {
+	b.MethodCall(b, "Model")
+	return b.model, b.NextErr()
+}
+
+func (b *mockBackend) IsController() bool {
+	b.MethodCall(b, "IsController")
+	return b.isController
+}
+
+func (b *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	b.MethodCall(b, "GetBlockForType", t)
+	msg, ok := b.blocks[t]
+	if !ok {
+		return nil, false, b.NextErr()
+	}
+	return mockBlock{t: t, msg: msg}, true, b.NextErr()
+}
+
+type mockModel struct {
+	testing.Stub
+	state.ModelAccessor
+	expiry time.Time
+	attrs  coretesting.Attrs
+	status status.StatusInfo
+}
+
+func (m *mockModel) Refresh() error {
+	m.MethodCall(m, "Refresh")
+	return m.NextErr()
+}
+
+func (m *mockModel) Watch() state.NotifyWatcher {
+	m.MethodCall(m, "Watch")
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+func (m *mockModel) Expiry() (time.Time, bool) {
+	m.MethodCall(m, "Expiry")
+	return m.expiry, !m.expiry.IsZero()
+}
+
+func (m *mockModel) Status() (status.StatusInfo, error) {
+	m.MethodCall(m, "Status")
+	return m.status, m.NextErr()
+}
+
+func (m *mockModel) SetStatus(info status.StatusInfo) error {
+	m.MethodCall(m, "SetStatus", info)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.status = info
+	return nil
+}
+
+func (m *mockModel) ModelConfig() (*config.Config, error) {
+	m.MethodCall(m, "ModelConfig")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return config.New(config.NoDefaults, coretesting.FakeConfig().Merge(m.attrs))
+}
+
+func (m *mockModel) Destroy(args state.DestroyModelParams) error {
+	m.MethodCall(m, "Destroy", args)
+	return m.NextErr()
+}
+
+type mockBlock struct {
+	state.Block
+	t   state.BlockType
+	msg string
+}
+
+func (b mockBlock) Type() state.BlockType { return b.t }
+
+func (b mockBlock) Message() string { return b.msg }
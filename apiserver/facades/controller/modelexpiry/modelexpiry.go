@@ -0,0 +1,148 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelexpiry
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// expiryWarningKey is the key, in the data of the model's status,
+// of when a model that is warned about is to be destroyed.
+const expiryWarningKey = "expiry-destroy"
+
+// API implements the API used by the model expiry worker, which
+// destroys models once their TTL has passed.
+type API struct {
+	backend   Backend
+	model     Model
+	resources facade.Resources
+	check     *common.BlockChecker
+	clock     clock.Clock
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(backendShim{ctx.State()}, ctx.Resources(), ctx.Auth(), clock.WallClock)
+}
+
+// NewAPI returns a new model expiry API.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer, clock clock.Clock) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, apiservererrors.ErrPerm
+	}
+	model, err := backend.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{
+		backend:   backend,
+		model:     model,
+		resources: resources,
+		check:     common.NewBlockChecker(backend),
+		clock:     clock,
+	}, nil
+}
+
+// WatchModel returns a NotifyWatcher that observes changes to the
+// model, including to when it expires.
+func (api *API) WatchModel() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+	watch := api.model.Watch()
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		result.NotifyWatcherId = api.resources.Register(watch)
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
+	return result, nil
+}
+
+// ModelExpiry returns when the model expires, and whether its storage
+// is destroyed when it does. The controller model never expires.
+func (api *API) ModelExpiry() (params.ModelExpiryResult, error) {
+	var result params.ModelExpiryResult
+	if api.backend.IsController() {
+		return result, nil
+	}
+	if err := api.model.Refresh(); err != nil {
+		return result, errors.Trace(err)
+	}
+	expiry, ok := api.model.Expiry()
+	if !ok {
+		return result, nil
+	}
+	cfg, err := api.model.ModelConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Expiry = &expiry
+	result.DestroyStorage = cfg.ExpiryDestroyStorage()
+	return result, nil
+}
+
+// SetExpiryWarning warns in the model's status message that the model
+// will be destroyed at the given time, or removes the warning if no
+// time is given. The status of a model that isn't available, such as
+// one whose credential is invalid, is left alone.
+func (api *API) SetExpiryWarning(args params.ModelExpiryWarning) error {
+	current, err := api.model.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if current.Status != status.Available {
+		return nil
+	}
+	now := api.clock.Now()
+	if args.Destroy == nil {
+		if _, warned := current.Data[expiryWarningKey]; !warned {
+			return nil
+		}
+		return errors.Trace(api.model.SetStatus(status.StatusInfo{
+			Status: status.Available,
+			Since:  &now,
+		}))
+	}
+	destroy := args.Destroy.UTC().Format(time.RFC3339)
+	return errors.Trace(api.model.SetStatus(status.StatusInfo{
+		Status:  status.Available,
+		Message: fmt.Sprintf("model expires and will be destroyed at %s", destroy),
+		Data:    map[string]interface{}{expiryWarningKey: destroy},
+		Since:   &now,
+	}))
+}
+
+// DestroyExpiredModel destroys the model, if it has expired. The
+// model's storage is destroyed or released according to its config.
+// Blocks on destroying the model are honoured.
+func (api *API) DestroyExpiredModel() error {
+	result, err := api.ModelExpiry()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Expiry == nil {
+		return errors.New("model does not expire")
+	}
+	if now := api.clock.Now(); now.Before(*result.Expiry) {
+		return errors.Errorf("model does not expire until %s", result.Expiry.Format(time.RFC3339))
+	}
+	if err := api.check.DestroyAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	destroyStorage := result.DestroyStorage
+	return errors.Trace(api.model.Destroy(state.DestroyModelParams{
+		DestroyStorage: &destroyStorage,
+	}))
+}
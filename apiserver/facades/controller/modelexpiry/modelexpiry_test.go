@@ -0,0 +1,178 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelexpiry_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/modelexpiry"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelExpirySuite struct {
+	coretesting.BaseSuite
+
+	backend   *mockBackend
+	resources *common.Resources
+	clock     *testclock.Clock
+	api       *modelexpiry.API
+}
+
+var _ = gc.Suite(&modelExpirySuite{})
+
+var expiry = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *modelExpirySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		model: &mockModel{
+			expiry: expiry,
+			status: status.StatusInfo{Status: status.Available},
+		},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.clock = testclock.NewClock(expiry.Add(time.Minute))
+
+	var err error
+	s.api, err = modelexpiry.NewAPI(s.backend, s.resources, apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelExpirySuite) TestNoPerms(c *gc.C) {
+	_, err := modelexpiry.NewAPI(s.backend, nil, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("bob"),
+	}, s.clock)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelExpirySuite) TestModelExpiry(c *gc.C) {
+	s.backend.model.attrs = coretesting.Attrs{"expiry-destroy-storage": true}
+	result, err := s.api.ModelExpiry()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelExpiryResult{
+		Expiry:         &expiry,
+		DestroyStorage: true,
+	})
+}
+
+func (s *modelExpirySuite) TestModelExpiryNoTTL(c *gc.C) {
+	s.backend.model.expiry = time.Time{}
+	result, err := s.api.ModelExpiry()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelExpiryResult{})
+}
+
+func (s *modelExpirySuite) TestModelExpiryController(c *gc.C) {
+	s.backend.isController = true
+	result, err := s.api.ModelExpiry()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelExpiryResult{})
+	s.backend.model.CheckNoCalls(c)
+}
+
+func (s *modelExpirySuite) TestModelExpiryRefreshes(c *gc.C) {
+	s.backend.model.SetErrors(errors.NotFoundf("model"))
+	_, err := s.api.ModelExpiry()
+	c.Assert(err, gc.ErrorMatches, "model not found")
+}
+
+func (s *modelExpirySuite) TestWatchModel(c *gc.C) {
+	result, err := s.api.WatchModel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+}
+
+func (s *modelExpirySuite) TestSetExpiryWarning(c *gc.C) {
+	destroy := expiry.Add(time.Hour)
+	err := s.api.SetExpiryWarning(params.ModelExpiryWarning{Destroy: &destroy})
+	c.Assert(err, jc.ErrorIsNil)
+	now := s.clock.Now()
+	c.Assert(s.backend.model.status, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Available,
+		Message: "model expires and will be destroyed at 2020-06-01T13:00:00Z",
+		Data:    map[string]interface{}{"expiry-destroy": "2020-06-01T13:00:00Z"},
+		Since:   &now,
+	})
+
+	// Removing the warning restores the plain status.
+	err = s.api.SetExpiryWarning(params.ModelExpiryWarning{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backend.model.status, jc.DeepEquals, status.StatusInfo{
+		Status: status.Available,
+		Since:  &now,
+	})
+}
+
+func (s *modelExpirySuite) TestSetExpiryWarningNotWarned(c *gc.C) {
+	err := s.api.SetExpiryWarning(params.ModelExpiryWarning{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.model.CheckCallNames(c, "Status")
+}
+
+func (s *modelExpirySuite) TestSetExpiryWarningNotAvailable(c *gc.C) {
+	s.backend.model.status = status.StatusInfo{Status: status.Suspended, Message: "bad credential"}
+	destroy := expiry.Add(time.Hour)
+	err := s.api.SetExpiryWarning(params.ModelExpiryWarning{Destroy: &destroy})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.model.CheckCallNames(c, "Status")
+}
+
+func (s *modelExpirySuite) TestDestroyExpiredModel(c *gc.C) {
+	err := s.api.DestroyExpiredModel()
+	c.Assert(err, jc.ErrorIsNil)
+
+	destroyStorage := false
+	s.backend.model.CheckCall(c, 3, "Destroy", state.DestroyModelParams{
+		DestroyStorage: &destroyStorage,
+	})
+}
+
+func (s *modelExpirySuite) TestDestroyExpiredModelDestroyStorage(c *gc.C) {
+	s.backend.model.attrs = coretesting.Attrs{"expiry-destroy-storage": true}
+	err := s.api.DestroyExpiredModel()
+	c.Assert(err, jc.ErrorIsNil)
+
+	destroyStorage := true
+	s.backend.model.CheckCall(c, 3, "Destroy", state.DestroyModelParams{
+		DestroyStorage: &destroyStorage,
+	})
+}
+
+func (s *modelExpirySuite) TestDestroyExpiredModelNotExpired(c *gc.C) {
+	s.clock.Advance(-2 * time.Minute)
+	err := s.api.DestroyExpiredModel()
+	c.Assert(err, gc.ErrorMatches, "model does not expire until 2020-06-01T12:00:00Z")
+	s.backend.model.CheckCallNames(c, "Refresh", "Expiry", "ModelConfig")
+}
+
+func (s *modelExpirySuite) TestDestroyExpiredModelNoTTL(c *gc.C) {
+	s.backend.model.expiry = time.Time{}
+	err := s.api.DestroyExpiredModel()
+	c.Assert(err, gc.ErrorMatches, "model does not expire")
+	s.backend.model.CheckCallNames(c, "Refresh", "Expiry")
+}
+
+func (s *modelExpirySuite) TestDestroyExpiredModelBlocked(c *gc.C) {
+	s.backend.blocks = map[state.BlockType]string{state.DestroyBlock: "not yet"}
+	err := s.api.DestroyExpiredModel()
+	c.Assert(err, jc.Satisfies, params.IsCodeOperationBlocked)
+	c.Assert(err, gc.ErrorMatches, "not yet")
+	s.backend.model.CheckCallNames(c, "Refresh", "Expiry", "ModelConfig")
+}
@@ -0,0 +1,56 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelexpiry
+
+import (
+	"time"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state methods used by the model expiry API.
+type Backend interface {
+	// Model returns the model the API is serving.
+	Model() (Model, error)
+
+	// IsController returns whether the model is the controller
+	// model, which never expires.
+	IsController() bool
+
+	// GetBlockForType returns the block of the given type, if any.
+	GetBlockForType(t state.BlockType) (state.Block, bool, error)
+}
+
+// Model defines the methods of state.Model used by the model
+// expiry API.
+type Model interface {
+	state.ModelAccessor
+
+	// Refresh reads the model from the database again.
+	Refresh() error
+
+	// Watch returns a watcher notifying of changes to the model.
+	Watch() state.NotifyWatcher
+
+	// Expiry returns when the model expires, if it has a TTL.
+	Expiry() (time.Time, bool)
+
+	// Status returns the status of the model.
+	Status() (status.StatusInfo, error)
+
+	// SetStatus sets the status of the model.
+	SetStatus(status.StatusInfo) error
+
+	// Destroy sets the model's lifecycle to Dying.
+	Destroy(state.DestroyModelParams) error
+}
+
+type backendShim struct {
+	*state.State
+}
+
+func (b backendShim) Model() (Model, error) {
+	return b.State.Model()
+}
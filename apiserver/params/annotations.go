@@ -25,3 +25,31 @@ type EntityAnnotations struct {
 	EntityTag   string            `json:"entity"`
 	Annotations map[string]string `json:"annotations"`
 }
+
+// AnnotationsSelectArgs holds the selectors used to find entities by
+// their annotations.
+type AnnotationsSelectArgs struct {
+	Selectors []AnnotationsSelector `json:"selectors"`
+}
+
+// AnnotationsSelector selects entities of the given kind whose annotations
+// match all of the selector's key=value pairs. The kind may be "machine",
+// "application" or "unit"; if empty, all annotated entities are matched.
+// Units are selected if they, their application or their machine match.
+type AnnotationsSelector struct {
+	Selector string `json:"selector"`
+	Kind     string `json:"kind,omitempty"`
+}
+
+// AnnotationsSelectResults holds the results of selecting entities by
+// their annotations.
+type AnnotationsSelectResults struct {
+	Results []AnnotationsSelectResult `json:"results"`
+}
+
+// AnnotationsSelectResult holds the entities matched by a selector, or
+// an error.
+type AnnotationsSelectResult struct {
+	Entities []Entity `json:"entities"`
+	Error    *Error   `json:"error,omitempty"`
+}
@@ -123,6 +123,22 @@ func (e RedirectErrorInfo) AsMap() map[string]interface{} {
 	return serializeToMap(e)
 }
 
+// OperationBlockedErrorInfo provides additional information for
+// OperationBlocked errors caused by a block on a single entity.
+type OperationBlockedErrorInfo struct {
+	// Tag holds the tag of the application, machine or
+	// relation with the block.
+	Tag string `json:"tag"`
+
+	// Operation holds the category of operation that was blocked.
+	Operation string `json:"operation"`
+}
+
+// AsMap encodes the error info as a map that can be attached to an Error.
+func (e OperationBlockedErrorInfo) AsMap() map[string]interface{} {
+	return serializeToMap(e)
+}
+
 // serializeToMap is a convenience function for marshaling v into a
 // map[string]interface{}. It works by marshalling v into json and then
 // unmarshaling back to a map.
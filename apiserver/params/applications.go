@@ -107,6 +107,8 @@ type ApplicationSetCharm struct {
 
 	// Generation is the generation version that this
 	// request will set the application charm for.
+	// Branches other than master are only understood by Application
+	// facade version 13 and greater.
 	Generation string `json:"generation"`
 
 	// CharmURL is the new url for the charm.
@@ -233,6 +235,40 @@ type ApplicationConfigUnsetArgs struct {
 	Args []ApplicationUnset
 }
 
+// ApplicationConfigDryRunArgs holds the parameters for validating
+// config changes to applications without applying them.
+type ApplicationConfigDryRunArgs struct {
+	Args []ApplicationConfigDryRun `json:"args"`
+}
+
+// ApplicationConfigDryRun describes a config change to validate for an
+// application. Config and ConfigYAML are mutually exclusive; Reset lists
+// the keys to revert to their defaults.
+type ApplicationConfigDryRun struct {
+	ApplicationName string `json:"application"`
+
+	// Generation is the generation version that the change
+	// would be made in.
+	Generation string `json:"generation"`
+
+	Config     map[string]string `json:"config,omitempty"`
+	ConfigYAML string            `json:"config-yaml,omitempty"`
+	Reset      []string          `json:"reset,omitempty"`
+}
+
+// RevertApplicationConfigArgs holds the parameters for restoring the
+// config of applications to earlier revisions.
+type RevertApplicationConfigArgs struct {
+	Args []RevertApplicationConfig `json:"args"`
+}
+
+// RevertApplicationConfig identifies the config revision to restore
+// the named application's config to.
+type RevertApplicationConfig struct {
+	ApplicationName string `json:"application"`
+	Revision        int    `json:"revision"`
+}
+
 // ApplicationCharmRelations holds parameters for making the application CharmRelations call.
 type ApplicationCharmRelations struct {
 	ApplicationName string `json:"application"`
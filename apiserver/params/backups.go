@@ -14,6 +14,18 @@ type BackupsCreateArgs struct {
 	Notes      string `json:"notes"`
 	KeepCopy   bool   `json:"keep-copy"`
 	NoDownload bool   `json:"no-download"`
+
+	// EncryptTo is the public key to encrypt the backup archive to.
+	// If empty, the archive is not encrypted.
+	EncryptTo string `json:"encrypt-to,omitempty"`
+
+	// Stream requests that the database dump is streamed into the
+	// backup archive rather than first being staged on disk.
+	Stream bool `json:"stream,omitempty"`
+
+	// Incremental requests a backup of only the changes since the
+	// most recent stored backup.
+	Incremental bool `json:"incremental,omitempty"`
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
@@ -90,6 +102,22 @@ type BackupsMetadataResult struct {
 
 	// HANodes reflects HA configuration: number of controller nodes in HA.
 	HANodes int64 `json:"ha-nodes"`
+
+	// EncryptionScheme identifies how the backup archive was encrypted.
+	// It is empty if the archive is not encrypted.
+	EncryptionScheme string `json:"encryption-scheme,omitempty"`
+
+	// EncryptionRecipient is the public key the backup archive was
+	// encrypted to.
+	EncryptionRecipient string `json:"encryption-recipient,omitempty"`
+
+	// OplogTimestamp is the position in the database oplog the backup
+	// was taken at.
+	OplogTimestamp int64 `json:"oplog-timestamp,omitempty"`
+
+	// BaseID is the ID of the backup an incremental backup follows.
+	// It is empty for a full backup.
+	BaseID string `json:"base-id,omitempty"`
 }
 
 // RestoreArgs Holds the backup file or id
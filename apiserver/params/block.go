@@ -19,6 +19,11 @@ type Block struct {
 	// Message is a descriptive or an explanatory message
 	// that the block was created with.
 	Message string `json:"message,omitempty"`
+
+	// Operations holds the categories of operation blocked on
+	// an application, machine or relation. It is empty for
+	// blocks on the whole model.
+	Operations []string `json:"operations,omitempty"`
 }
 
 // BlockSwitchParams holds the parameters for switching
@@ -33,6 +38,21 @@ type BlockSwitchParams struct {
 	Message string `json:"message,omitempty"`
 }
 
+// EntityBlockSwitchParams holds the parameters for blocking
+// operations on an application, machine or relation.
+type EntityBlockSwitchParams struct {
+	// Tag is the tag of the entity to block.
+	Tag string `json:"tag"`
+
+	// Operations holds the categories of operation to block,
+	// as per model.BlockOperation.
+	Operations []string `json:"operations"`
+
+	// Message is a descriptive or an explanatory message
+	// that accompanies the switch.
+	Message string `json:"message,omitempty"`
+}
+
 // BlockResult holds the result of an API call to retrieve details
 // for a block.
 type BlockResult struct {
@@ -144,6 +144,11 @@ type ModelCreateArgs struct {
 	// and the owner is the controller owner, the same credential
 	// used for the controller model will be used.
 	CloudCredentialTag string `json:"credential,omitempty"`
+
+	// Template is the name of the model template to create the
+	// model from. Config specified above takes precedence over
+	// that of the template.
+	Template string `json:"template,omitempty"`
 }
 
 // Model holds the result of an API call returning a name and UUID
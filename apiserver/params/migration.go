@@ -53,6 +53,22 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// MigrationDryRunResults is used to return the results of one or more
+// migration dry runs.
+type MigrationDryRunResults struct {
+	Results []MigrationDryRunResult `json:"results"`
+}
+
+// MigrationDryRunResult holds every problem a migration dry run found
+// with migrating a single model. Blockers would stop the migration
+// from starting; warnings would not.
+type MigrationDryRunResult struct {
+	ModelTag string   `json:"model-tag"`
+	Blockers []string `json:"blockers,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+	Error    *Error   `json:"error,omitempty"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
@@ -65,6 +81,15 @@ type SetMigrationStatusMessageArgs struct {
 	Message string `json:"message"`
 }
 
+// SetMigrationProgressArgs provides the entity and log transfer
+// counts to the migrationmaster.SetProgress API method.
+type SetMigrationProgressArgs struct {
+	ExportedEntities map[string]int `json:"exported-entities,omitempty"`
+	ImportedEntities map[string]int `json:"imported-entities,omitempty"`
+	LogsTransferred  int            `json:"logs-transferred"`
+	LastLogTime      *time.Time     `json:"last-log-time,omitempty"`
+}
+
 // SerializedModel wraps a buffer contain a serialised Juju model. It
 // also contains lists of the charms and tools used in the model.
 type SerializedModel struct {
@@ -72,6 +97,7 @@ type SerializedModel struct {
 	Charms    []string                  `json:"charms"`
 	Tools     []SerializedModelTools    `json:"tools"`
 	Resources []SerializedModelResource `json:"resources"`
+	Entities  map[string]int            `json:"entities,omitempty"`
 }
 
 // SerializedModelTools holds the version and URI for a given tools
@@ -135,6 +161,28 @@ type MigrationModelInfo struct {
 	ControllerAgentVersion version.Number `json:"controller-agent-version"`
 }
 
+// MigrationDryRunPrecheckArgs holds a model to be checked by the target
+// controller of a migration dry run.
+type MigrationDryRunPrecheckArgs struct {
+	ModelInfo MigrationModelInfo `json:"model-info"`
+	Bytes     []byte             `json:"bytes"`
+}
+
+// MigrationDryRunPrecheckResult holds the problems the target controller
+// of a migration dry run found with migrating a model, and the time
+// on the target controller's clock when it checked.
+type MigrationDryRunPrecheckResult struct {
+	Blockers []string  `json:"blockers,omitempty"`
+	Warnings []string  `json:"warnings,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// MigrationImportResult holds the number of each kind of entity the
+// target controller imported for a migration.
+type MigrationImportResult struct {
+	Entities map[string]int `json:"entities,omitempty"`
+}
+
 // MigrationStatus reports the current status of a model migration.
 type MigrationStatus struct {
 	MigrationId string `json:"migration-id"`
@@ -204,6 +252,61 @@ type MinionReports struct {
 	Failed []string `json:"failed"`
 }
 
+// MigrationProgressResults holds the progress of one or more model
+// migrations.
+type MigrationProgressResults struct {
+	Results []MigrationProgressResult `json:"results"`
+}
+
+// MigrationProgressResult holds the progress of the latest migration
+// of a single model, or an error if it could not be determined.
+type MigrationProgressResult struct {
+	Result *MigrationProgress `json:"result,omitempty"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// MigrationProgress reports in detail how far a model migration has
+// got.
+type MigrationProgress struct {
+	MigrationId      string `json:"migration-id"`
+	ModelTag         string `json:"model-tag"`
+	TargetController string `json:"target-controller"`
+	Phase            string `json:"phase"`
+	Status           string `json:"status"`
+
+	Start *time.Time `json:"start"`
+	End   *time.Time `json:"end,omitempty"`
+
+	// PhaseTimes holds when the migration entered each phase it has
+	// been through, in order.
+	PhaseTimes []MigrationPhaseTime `json:"phase-times,omitempty"`
+
+	ExportedEntities map[string]int `json:"exported-entities,omitempty"`
+	ImportedEntities map[string]int `json:"imported-entities,omitempty"`
+	LogsTransferred  int            `json:"logs-transferred"`
+	LastLogTime      *time.Time     `json:"last-log-time,omitempty"`
+
+	// MinionReports holds the agents which have and haven't yet
+	// reported for each phase the migration waits for them in. They
+	// are only available while the model is on the source controller.
+	MinionReports []MigrationMinionReports `json:"minion-reports,omitempty"`
+}
+
+// MigrationPhaseTime records when a migration entered a phase.
+type MigrationPhaseTime struct {
+	Phase string    `json:"phase"`
+	Time  time.Time `json:"time"`
+}
+
+// MigrationMinionReports holds the tags of the agents which have
+// succeeded, failed or are yet to report for a migration phase.
+type MigrationMinionReports struct {
+	Phase     string   `json:"phase"`
+	Succeeded []string `json:"succeeded,omitempty"`
+	Failed    []string `json:"failed,omitempty"`
+	Waiting   []string `json:"waiting,omitempty"`
+}
+
 // AdoptResourcesArgs holds the information required to ask the
 // provider to update the controller tags for a model's
 // resources.
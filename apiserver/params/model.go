@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/version"
 
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/life"
 )
 
@@ -86,6 +87,19 @@ type ModelUnset struct {
 	Keys []string `json:"keys"`
 }
 
+// ModelConfigDryRun contains the arguments for the ValidateModelConfig
+// client API call.
+type ModelConfigDryRun struct {
+	Config map[string]interface{} `json:"config,omitempty"`
+	Reset  []string               `json:"reset,omitempty"`
+}
+
+// RevertModelConfig contains the arguments for the RevertModelConfig
+// client API call.
+type RevertModelConfig struct {
+	Revision int `json:"revision"`
+}
+
 // ModelSLA contains the arguments for the SetSLALevel client API
 // call.
 type ModelSLA struct {
@@ -132,6 +146,7 @@ type SetModelAgentVersion struct {
 // failed) migration.
 type ModelMigrationStatus struct {
 	Status string     `json:"status"`
+	Phase  string     `json:"phase,omitempty"`
 	Start  *time.Time `json:"start"`
 	End    *time.Time `json:"end,omitempty"`
 }
@@ -180,6 +195,88 @@ type ModelInfo struct {
 
 	// AgentVersion is the agent version for this model.
 	AgentVersion *version.Number `json:"agent-version"`
+
+	// Quota contains the resource limits and usage of the model and
+	// its owner. This information is available to owners and users
+	// with write access or greater.
+	Quota *ModelQuotaInfo `json:"quota,omitempty"`
+
+	// Expiry is when the model expires and is destroyed, if it
+	// has a TTL.
+	Expiry *time.Time `json:"expiry,omitempty"`
+}
+
+// QuotaResources holds amounts of the resources that may be limited
+// by a quota. A zero value means no limit.
+type QuotaResources struct {
+	Machines     int    `json:"machines,omitempty"`
+	Units        int    `json:"units,omitempty"`
+	Applications int    `json:"applications,omitempty"`
+	StorageMiB   uint64 `json:"storage-mib,omitempty"`
+}
+
+// ModelQuotaInfo holds the quota limits and current resource usage for
+// a model and for the user that owns it.
+type ModelQuotaInfo struct {
+	Limits      QuotaResources `json:"limits"`
+	Usage       QuotaResources `json:"usage"`
+	OwnerLimits QuotaResources `json:"owner-limits"`
+	OwnerUsage  QuotaResources `json:"owner-usage"`
+}
+
+// SetQuotaArg holds the quota limits to set on a model or user.
+type SetQuotaArg struct {
+	// Tag is the tag of the model or user the limits apply to.
+	Tag    string         `json:"tag"`
+	Limits QuotaResources `json:"limits"`
+}
+
+// SetQuotaArgs holds the arguments for setting quotas.
+type SetQuotaArgs struct {
+	Args []SetQuotaArg `json:"args"`
+}
+
+// ModelTemplate holds a named set of settings applied to models
+// created from it.
+type ModelTemplate struct {
+	Name          string                 `json:"name"`
+	Description   string                 `json:"description,omitempty"`
+	Config        map[string]interface{} `json:"config,omitempty"`
+	Constraints   constraints.Value      `json:"constraints"`
+	Users         []ModelTemplateUser    `json:"users,omitempty"`
+	FirewallRules []FirewallRule         `json:"firewall-rules,omitempty"`
+
+	// Spaces holds the names of the spaces created
+	// in models created from the template.
+	Spaces []string `json:"spaces,omitempty"`
+
+	// Blocks maps block types, as per model.BlockType,
+	// to the message of the block.
+	Blocks map[string]string `json:"blocks,omitempty"`
+
+	// Bundle holds the content of the bundle deployed to
+	// models created from the template.
+	Bundle string `json:"bundle,omitempty"`
+
+	CreatedBy string    `json:"created-by,omitempty"`
+	Created   time.Time `json:"created,omitempty"`
+}
+
+// ModelTemplateUser holds the access granted to a user on models
+// created from a template.
+type ModelTemplateUser struct {
+	UserTag string `json:"user-tag"`
+	Access  string `json:"access"`
+}
+
+// ModelTemplates holds model templates.
+type ModelTemplates struct {
+	Templates []ModelTemplate `json:"templates"`
+}
+
+// ModelTemplateNames holds the names of model templates.
+type ModelTemplateNames struct {
+	Names []string `json:"names"`
 }
 
 // ModelSummary holds summary about a Juju model.
@@ -224,6 +321,30 @@ type ModelSummary struct {
 
 	// AgentVersion is the agent version for this model.
 	AgentVersion *version.Number `json:"agent-version"`
+
+	// Expiry is when the model expires and is destroyed, if it
+	// has a TTL.
+	Expiry *time.Time `json:"expiry,omitempty"`
+}
+
+// ModelExpiryResult holds when a model expires, and whether its
+// storage is destroyed when it does.
+type ModelExpiryResult struct {
+	// Expiry is when the model expires. It is nil if the model
+	// never expires.
+	Expiry *time.Time `json:"expiry,omitempty"`
+
+	// DestroyStorage is whether the storage of the model is destroyed,
+	// rather than released, when the model expires.
+	DestroyStorage bool `json:"destroy-storage"`
+}
+
+// ModelExpiryWarning holds when an expiring model is to be destroyed,
+// for warning about it in the model's status.
+type ModelExpiryWarning struct {
+	// Destroy is when the model is to be destroyed. The warning is
+	// removed if it is nil.
+	Destroy *time.Time `json:"destroy,omitempty"`
 }
 
 // ModelEntityCount represent a count for a model entity where entities could be
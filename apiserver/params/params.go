@@ -167,6 +167,42 @@ type RelationSuspendedArg struct {
 	Suspended  bool   `json:"suspended"`
 }
 
+// RelationDataArgs holds the parameters for reading the relation data
+// of one or more application endpoints.
+type RelationDataArgs struct {
+	Args []RelationDataArg `json:"args"`
+}
+
+// RelationDataArg identifies an application endpoint, in the form
+// <application>:<endpoint>, whose relation data is read. If units are
+// specified, only the settings of those units are returned.
+type RelationDataArg struct {
+	Endpoint string   `json:"endpoint"`
+	Units    []string `json:"units,omitempty"`
+}
+
+// RelationDataResults holds the results of a RelationData call.
+type RelationDataResults struct {
+	Results []RelationDataResult `json:"results"`
+}
+
+// RelationDataResult holds the settings of every relation that an
+// application endpoint participates in.
+type RelationDataResult struct {
+	Relations []RelationSettingsData `json:"relations,omitempty"`
+	Error     *Error                 `json:"error,omitempty"`
+}
+
+// RelationSettingsData holds the application and unit settings buckets
+// of a single relation. Application settings are keyed by application
+// name, unit settings by unit name.
+type RelationSettingsData struct {
+	RelationId          int                               `json:"relation-id"`
+	Key                 string                            `json:"key"`
+	ApplicationSettings map[string]map[string]interface{} `json:"application-settings"`
+	UnitSettings        map[string]map[string]interface{} `json:"unit-settings"`
+}
+
 // ProcessRelations holds the information required to process series of
 // relations during a model migration.
 type ProcessRelations struct {
@@ -310,6 +346,60 @@ type ConfigResult struct {
 	Error  *Error                 `json:"error,omitempty"`
 }
 
+// ConfigChange describes the effect a proposed config change would
+// have on a single key.
+type ConfigChange struct {
+	Key string      `json:"key"`
+	Old interface{} `json:"old,omitempty"`
+	New interface{} `json:"new,omitempty"`
+
+	// Input holds the value as supplied by the user when it was
+	// coerced to a different type.
+	Input string `json:"input,omitempty"`
+
+	// Reset is true when the key reverts to its default or
+	// inherited value.
+	Reset bool `json:"reset,omitempty"`
+}
+
+// ConfigDryRunResult holds the outcome of validating a config change
+// without applying it. Units lists the units that would run the
+// config-changed hook.
+type ConfigDryRunResult struct {
+	Changes []ConfigChange `json:"changes,omitempty"`
+	Units   []string       `json:"units,omitempty"`
+	Error   *Error         `json:"error,omitempty"`
+}
+
+// ConfigDryRunResults holds the outcomes of multiple config dry runs.
+type ConfigDryRunResults struct {
+	Results []ConfigDryRunResult `json:"results"`
+}
+
+// ConfigRevision describes a change made to the config of an
+// application or model. Scope is one of "charm", "application"
+// or "model".
+type ConfigRevision struct {
+	Revision  int            `json:"revision"`
+	Scope     string         `json:"scope"`
+	Created   time.Time      `json:"created"`
+	CreatedBy string         `json:"created-by,omitempty"`
+	Changes   []ConfigChange `json:"changes"`
+}
+
+// ConfigHistoryResult holds the retained config revisions of an
+// application or model, most recent first.
+type ConfigHistoryResult struct {
+	Revisions []ConfigRevision `json:"revisions"`
+	Error     *Error           `json:"error,omitempty"`
+}
+
+// ConfigHistoryResults holds the config histories of multiple
+// applications.
+type ConfigHistoryResults struct {
+	Results []ConfigHistoryResult `json:"results"`
+}
+
 // ModelOperatorInfo
 type ModelOperatorInfo struct {
 	APIAddresses []string       `json:"api-addresses"`
@@ -561,6 +651,11 @@ type GetConstraintsResults struct {
 type SetConstraints struct {
 	ApplicationName string            `json:"application"` //optional, if empty, model constraints are set.
 	Constraints     constraints.Value `json:"constraints"`
+
+	// BranchName, if set to a branch other than master, stages the
+	// application constraints in the branch. It is only understood by
+	// Application facade version 13 and greater.
+	BranchName string `json:"branch,omitempty"`
 }
 
 // ResolveCharms stores charm references for a ResolveCharms call.
@@ -1219,6 +1314,41 @@ type GenerationId struct {
 	GenerationId int `json:"generation-id"`
 }
 
+// RevertCommitArg transports arguments to the RevertCommit method.
+type RevertCommitArg struct {
+	// GenerationId identifies the committed generation to revert.
+	GenerationId int `json:"generation-id"`
+
+	// BranchName is the name of the new branch that will hold
+	// the reverted changes.
+	BranchName string `json:"branch"`
+
+	// Commit indicates whether the new branch should be
+	// committed immediately.
+	Commit bool `json:"commit,omitempty"`
+}
+
+// RevertConflict describes a change made by a committed generation
+// that could not be reverted.
+type RevertConflict struct {
+	ApplicationName string `json:"application"`
+	Key             string `json:"key"`
+	Reason          string `json:"reason"`
+}
+
+// RevertCommitResult transports the result of reverting a commit.
+type RevertCommitResult struct {
+	// GenerationId is the ID of the new generation if the branch
+	// holding the reverted changes was committed.
+	GenerationId int `json:"generation-id,omitempty"`
+
+	// Conflicts holds the changes that could not be reverted.
+	Conflicts []RevertConflict `json:"conflicts,omitempty"`
+
+	// Error holds the value of any error that occurred processing the request.
+	Error *Error `json:"error,omitempty"`
+}
+
 // BranchInfoArgs transports arguments to the BranchInfo method
 type BranchInfoArgs struct {
 	// BranchNames is the names of branches for which info is being requested.
@@ -1235,6 +1365,35 @@ type BranchTrackArg struct {
 	BranchName string   `json:"branch"`
 	Entities   []Entity `json:"entities"`
 	NumUnits   int      `json:"num-units,omitempty"`
+
+	// Percent is the percentage of each application's units to track
+	// the branch.
+	Percent int `json:"percent,omitempty"`
+
+	// PerZone indicates that NumUnits or Percent apply separately to the
+	// units of each application in each availability zone.
+	PerZone bool `json:"per-zone,omitempty"`
+
+	// Annotations, if not empty, restricts tracking to units with all of
+	// these annotation values.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Constraints, if not empty, restricts tracking to units on machines
+	// satisfying these constraints.
+	Constraints string `json:"constraints,omitempty"`
+
+	// Progression, if set, causes the percentage of units tracking the
+	// branch to be increased in steps.
+	Progression *BranchProgression `json:"progression,omitempty"`
+}
+
+// BranchProgression describes progressive tracking of a branch.
+type BranchProgression struct {
+	// StepPercent is the percentage of units added at each step.
+	StepPercent int `json:"step-percent"`
+
+	// Interval is the time between steps.
+	Interval time.Duration `json:"interval"`
 }
 
 // GenerationApplication represents changes to an application
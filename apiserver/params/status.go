@@ -18,6 +18,10 @@ import (
 // StatusParams holds parameters for the Status call.
 type StatusParams struct {
 	Patterns []string `json:"patterns"`
+
+	// Selector, if set, restricts the status to the machines,
+	// applications and units whose annotations match it.
+	Selector string `json:"selector,omitempty"`
 }
 
 // TODO(ericsnow) Add FullStatusResult.
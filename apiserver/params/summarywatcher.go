@@ -20,6 +20,7 @@ type ModelAbstract struct {
 	Removed bool   `json:"removed,omitempty"`
 
 	Controller string   `json:"controller,omitempty"`
+	Namespace  string   `json:"namespace,omitempty"`
 	Name       string   `json:"name,omitempty"`
 	Admins     []string `json:"admins,omitempty"`
 
@@ -32,6 +33,8 @@ type ModelAbstract struct {
 	Status   string                `json:"status,omitempty"`
 	Messages []ModelSummaryMessage `json:"messages,omitempty"`
 
+	BlockedApplications []ModelSummaryMessage `json:"blocked-applications,omitempty"`
+
 	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
@@ -47,5 +50,6 @@ type ModelSummarySize struct {
 // ModelSummaryMessage represents a non-green status from an agent.
 type ModelSummaryMessage struct {
 	Agent   string `json:"agent"`
+	Status  string `json:"status,omitempty"`
 	Message string `json:"message"`
 }
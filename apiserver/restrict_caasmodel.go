@@ -40,6 +40,7 @@ var commonModelFacadeNames = set.NewStrings(
 	"MigrationStatusWatcher",
 	"MigrationTarget",
 	"ModelConfig",
+	"ModelExpiry",
 	"ModelUpgrader",
 	"NotifyWatcher",
 	"OfferStatusWatcher",
@@ -1169,6 +1169,7 @@ func (w *SrvModelSummaryWatcher) translate(summaries []cache.ModelSummary) []par
 		result := params.ModelAbstract{
 			UUID:       summary.UUID,
 			Controller: summary.Controller,
+			Namespace:  summary.Namespace,
 			Name:       summary.Name,
 			Admins:     summary.Admins,
 			Cloud:      summary.Cloud,
@@ -1184,6 +1185,8 @@ func (w *SrvModelSummaryWatcher) translate(summaries []cache.ModelSummary) []par
 			Status:      summary.Status,
 			Messages:    w.translateMessages(summary.Messages),
 			Annotations: summary.Annotations,
+
+			BlockedApplications: w.translateMessages(summary.BlockedApplications),
 		}
 		response = append(response, result)
 	}
@@ -1198,6 +1201,7 @@ func (w *SrvModelSummaryWatcher) translateMessages(messages []cache.ModelSummary
 	for i, m := range messages {
 		result[i] = params.ModelSummaryMessage{
 			Agent:   m.Agent,
+			Status:  m.Status,
 			Message: m.Message,
 		}
 	}
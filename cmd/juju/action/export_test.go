@@ -16,6 +16,7 @@ import (
 
 var (
 	NewActionAPIClient = &newAPIClient
+	NewSelectorAPI     = &newSelectorAPI
 	AddValueToMap      = addValueToMap
 )
 
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/status"
 )
 
 func NewListOperationsCommand() cmd.Command {
@@ -31,6 +32,7 @@ func NewListOperationsCommand() cmd.Command {
 type listOperationsCommand struct {
 	ActionCommandBase
 	out              cmd.Output
+	table            output.TableFlags
 	utc              bool
 	applicationNames []string
 	unitNames        []string
@@ -42,6 +44,10 @@ const listOperationsDoc = `
 List the operations with the specified query criteria.
 When an application is specified, all units from that application are relevant.
 
+The --columns and --sort options select and order the columns of the
+plain output; the names of the columns are their headers in lower case
+with spaces replaced by hyphens.
+
 Examples:
     juju operations
     juju operations --format yaml
@@ -50,6 +56,7 @@ Examples:
     juju operations --units mysql/0,mediawiki/1
     juju operations --status pending,completed
     juju operations --apps mysql --units mediawiki/0 --status running --actions backup
+    juju operations --columns id,status,summary --sort -id
 
 See also:
     run
@@ -66,6 +73,7 @@ func (c *listOperationsCommand) SetFlags(f *gnuflag.FlagSet) {
 		"json":  cmd.FormatJson,
 		"plain": c.formatTabular,
 	})
+	c.table.AddFlags(f)
 
 	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
 	f.Var(cmd.NewStringsValue(nil, &c.applicationNames), "applications", "Comma separated list of applications to filter on")
@@ -181,30 +189,27 @@ func (c *listOperationsCommand) formatTabular(writer io.Writer, value interface{
 	if !ok {
 		return errors.Errorf("expected value of type %T, got %T", results, value)
 	}
-	tw := output.TabWriter(writer)
-	w := output.Wrapper{tw}
-	w.SetColumnAlignRight(0)
-
-	printOperations := func(operations []operationLine, utc bool) {
-		for _, line := range operations {
-			numTasks := len(line.tasks)
-			if numTasks > maxTaskIDs {
-				numTasks = maxTaskIDs
-			}
-			tasks := strings.Join(line.tasks[:numTasks], ",")
-			if len(line.tasks) > maxTaskIDs {
-				tasks += "..."
-			}
-			w.Print(line.id, line.status)
-			w.Print(formatTimestamp(line.started, false, c.utc, true))
-			w.Print(formatTimestamp(line.finished, false, c.utc, true))
-			w.Print(tasks)
-			w.Println(line.operation)
+	table := output.NewTable("Id", "Status", "Started", "Finished", "Task IDs", "Summary")
+	table.AlignRight("Id")
+	for _, line := range actionOperationLinesFromResults(results) {
+		numTasks := len(line.tasks)
+		if numTasks > maxTaskIDs {
+			numTasks = maxTaskIDs
+		}
+		tasks := strings.Join(line.tasks[:numTasks], ",")
+		if len(line.tasks) > maxTaskIDs {
+			tasks += "..."
 		}
+		table.AddRow(
+			line.id,
+			status.Status(line.status),
+			formatTimestamp(line.started, false, c.utc, true),
+			formatTimestamp(line.finished, false, c.utc, true),
+			tasks,
+			line.operation,
+		)
 	}
-	w.Println("Id", "Status", "Started", "Finished", "Task IDs", "Summary")
-	printOperations(actionOperationLinesFromResults(results), c.utc)
-	return tw.Flush()
+	return table.Write(writer, c.table.Options())
 }
 
 func operationDisplayTime(r params.OperationResult) time.Time {
@@ -191,6 +191,27 @@ Id  Status     Started              Finished             Task IDs  Summary
 	}
 }
 
+func (s *ListOperationsSuite) TestRunPlainColumnsAndSort(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		operationResults: listOperationResults,
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	s.wrappedCommand, s.command = action.NewListOperationsCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "-m", "admin", "--columns", "id,status,summary", "--sort", "-id")
+	c.Assert(err, jc.ErrorIsNil)
+	expected := `
+Id  Status     Summary
+ 7  error      operation 7
+ 5  pending    operation 5
+ 3  running    operation 3
+ 1  completed  operation 1
+
+`[1:]
+	c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, expected)
+}
+
 var listOperationManyTasksResults = []params.OperationResult{
 	{
 		Actions: []params.ActionResult{{
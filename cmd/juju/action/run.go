@@ -18,10 +18,12 @@ import (
 	"github.com/juju/names/v4"
 	"gopkg.in/yaml.v2"
 
+	"github.com/juju/juju/api/annotations"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	coreannotations "github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -42,12 +44,27 @@ func NewRunCommand() cmd.Command {
 	})
 }
 
+// SelectorAPI selects entities by their annotations.
+type SelectorAPI interface {
+	Select(selector, kind string) ([]names.Tag, error)
+	Close() error
+}
+
+var newSelectorAPI = func(c *ActionCommandBase) (SelectorAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return annotations.NewClient(root), nil
+}
+
 // runCommand enqueues an Action for running on the given unit with given
 // params
 type runCommand struct {
 	ActionCommandBase
 	api               APIClient
 	unitReceivers     []string
+	selector          string
 	leaders           map[string]string
 	actionName        string
 	paramsYAML        cmd.FileVar
@@ -86,6 +103,10 @@ Valid unit identifiers are:
 If the leader syntax is used, the leader unit for the application will be
 resolved before the action is enqueued.
 
+Instead of naming units, the --selector option runs the action on every unit
+whose annotations, or whose application's or machine's annotations, match all
+of the given key=value pairs.
+
 Params are validated according to the charm for the unit's application.  The
 valid params can be seen using "juju actions <application> --schema".
 Params may be in a yaml file which is passed with the --params option, or they
@@ -106,6 +127,7 @@ Examples:
     juju run mysql/3 backup --utc
     juju run mysql/3 backup
     juju run mysql/leader backup
+    juju run --selector team=payments backup
     juju show-operation <ID>
     juju run mysql/3 backup --params parameters.yml
     juju run mysql/3 backup out=out.tar.bz2 file.kind=xz file.quality=high
@@ -134,12 +156,13 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.background, "background", false, "Run the action in the background")
 	f.DurationVar(&c.maxWait, "max-wait", 0, "Maximum wait time for a action to complete")
 	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
+	f.StringVar(&c.selector, "selector", "", "Run on the units whose annotations match key=value[,key=value...]")
 }
 
 func (c *runCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "run",
-		Args:    "<unit> [<unit> ...] <action-name> [<key>=<value> [<key>[.<key> ...]=<value>]]",
+		Args:    "(<unit> [<unit> ...] | --selector <key>=<value>[,...]) <action-name> [<key>=<value> [<key>[.<key> ...]=<value>]]",
 		Purpose: "Run a action on a specified unit.",
 		Doc:     runDoc,
 	})
@@ -157,7 +180,14 @@ func (c *runCommand) Init(args []string) (err error) {
 			return errors.Errorf("invalid unit or action name %q", arg)
 		}
 	}
-	if len(c.unitReceivers) == 0 {
+	if c.selector != "" {
+		if len(c.unitReceivers) > 0 {
+			return errors.New("cannot specify both units and --selector")
+		}
+		if _, err := coreannotations.ParseSelector(c.selector); err != nil {
+			return errors.Trace(err)
+		}
+	} else if len(c.unitReceivers) == 0 {
 		return errors.New("no unit specified")
 	}
 	if c.actionName == "" {
@@ -202,6 +232,11 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	if c.api.BestAPIVersion() < 6 {
 		return errors.Errorf("juju run action not supported on this version of Juju")
 	}
+	if c.selector != "" {
+		if err := c.selectUnits(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	operationId, results, err := c.enqueueActions(ctx)
 	if err != nil {
@@ -253,6 +288,27 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	return c.waitForTasks(ctx, results, info)
 }
 
+// selectUnits sets the units to run the action on to those matching
+// the selector.
+func (c *runCommand) selectUnits() error {
+	api, err := newSelectorAPI(&c.ActionCommandBase)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+	tags, err := api.Select(c.selector, names.UnitTagKind)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(tags) == 0 {
+		return errors.Errorf("no units match selector %q", c.selector)
+	}
+	for _, tag := range tags {
+		c.unitReceivers = append(c.unitReceivers, tag.Id())
+	}
+	return nil
+}
+
 func (c *runCommand) waitForTasks(ctx *cmd.Context, tasks []enqueuedAction, info map[string]interface{}) error {
 	var wait *time.Timer
 	if c.maxWait < 0 {
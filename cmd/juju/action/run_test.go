@@ -214,6 +214,19 @@ func (s *CallSuite) TestInit(c *gc.C) {
 		expectUnits:  []string{"mysql/leader"},
 		expectAction: "valid-action-name",
 		expectKVArgs: [][]string{},
+	}, {
+		should:       "work with selector",
+		args:         []string{"--selector", "team=payments", "valid-action-name", "foo=bar"},
+		expectAction: "valid-action-name",
+		expectKVArgs: [][]string{{"foo", "bar"}},
+	}, {
+		should:      "fail with both units and selector",
+		args:        []string{"--selector", "team=payments", validUnitId, "valid-action-name"},
+		expectError: "cannot specify both units and --selector",
+	}, {
+		should:      "fail with invalid selector",
+		args:        []string{"--selector", "team", "valid-action-name"},
+		expectError: `selector term "team": expected key=value not valid`,
 	}}
 
 	for i, t := range tests {
@@ -894,3 +907,65 @@ Check task status with 'juju show-task %s'`[1:],
 		}
 	}
 }
+
+type fakeSelectorAPI struct {
+	tags     []names.Tag
+	selector string
+	kind     string
+}
+
+func (f *fakeSelectorAPI) Select(selector, kind string) ([]names.Tag, error) {
+	f.selector, f.kind = selector, kind
+	return f.tags, nil
+}
+
+func (*fakeSelectorAPI) Close() error {
+	return nil
+}
+
+func (s *CallSuite) TestRunWithSelector(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		actionResults: []params.ActionResult{
+			{Action: &params.Action{Tag: validActionTagString}},
+			{Action: &params.Action{Tag: validActionTagString2}},
+		},
+		apiVersion: 6,
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+	selectorAPI := &fakeSelectorAPI{
+		tags: []names.Tag{names.NewUnitTag("mysql/0"), names.NewUnitTag("mysql/1")},
+	}
+	s.PatchValue(action.NewSelectorAPI, func(*action.ActionCommandBase) (action.SelectorAPI, error) {
+		return selectorAPI, nil
+	})
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store, nil)
+	_, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", "--selector", "team=payments", "--background", "some-action")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(selectorAPI.selector, gc.Equals, "team=payments")
+	c.Assert(selectorAPI.kind, gc.Equals, "unit")
+	c.Assert(fakeClient.EnqueuedActions().Actions, jc.DeepEquals, []params.Action{{
+		Receiver:   "unit-mysql-0",
+		Name:       "some-action",
+		Parameters: map[string]interface{}{},
+	}, {
+		Receiver:   "unit-mysql-1",
+		Name:       "some-action",
+		Parameters: map[string]interface{}{},
+	}})
+}
+
+func (s *CallSuite) TestRunWithSelectorNoMatches(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{apiVersion: 6})
+	defer restore()
+	s.PatchValue(action.NewSelectorAPI, func(*action.ActionCommandBase) (action.SelectorAPI, error) {
+		return &fakeSelectorAPI{}, nil
+	})
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store, nil)
+	_, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", "--selector", "team=payments", "some-action")
+	c.Assert(err, gc.ErrorMatches, `no units match selector "team=payments"`)
+}
@@ -85,6 +85,10 @@ Add a unit of mysql to LXD container on a new machine:
 
     juju add-unit mysql --to lxd
 
+Add a unit of mysql to an existing machine annotated with team=payments:
+
+    juju add-unit mysql --to label:team=payments
+
 See also:
     remove-unit
 `[1:]
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/core/model"
@@ -47,6 +48,18 @@ option and will output the value as plain text. This is provided to support
 scripts where the output of "juju config <application name> <setting name>" 
 can be used as an input to an expression or a function.
 
+The --dry-run option validates changes made with key=value arguments, --file
+or --reset without applying them. It reports the values that would change,
+any values that would be converted to the type declared by the charm, and the
+units that would run the config-changed hook.
+
+Each change to the charm or application config of an application is recorded
+as a numbered revision, noting when it was made, who made it and the values
+changed. Changes made in a branch are recorded when the branch is committed.
+The --history option displays the retained revisions, most recent first, and
+the --revert option restores the values the configuration had immediately
+after the given revision. The restoration is recorded as a new revision.
+
 Examples:
     juju config apache2
     juju config --format=json apache2
@@ -56,6 +69,9 @@ Examples:
     juju config mysql dataset-size=80% backup_dir=/vol1/mysql/backups
     juju config apache2 --model mymodel --file /home/ubuntu/mysql.yaml
     juju config redis --branch test-branch databases=32
+    juju config mysql --dry-run dataset-size=80% max-connections=200
+    juju config mysql --history
+    juju config mysql --revert 12
 
 See also:
     deploy
@@ -88,9 +104,12 @@ type configCommand struct {
 	applicationName string
 	branchName      string
 	configFile      cmd.FileVar
+	dryRun          bool
+	history         bool
 	keys            []string
 	reset           []string // Holds the keys to be reset until parsed.
 	resetKeys       []string // Holds the keys to be reset once parsed.
+	revert          int
 	useFile         bool
 	values          attributes
 }
@@ -107,13 +126,20 @@ type applicationAPI interface {
 	// These methods are on API V6.
 	SetApplicationConfig(branchName string, application string, config map[string]string) error
 	UnsetApplicationConfig(branchName string, application string, options []string) error
+
+	// This method is on API V14.
+	ValidateApplicationConfig(arg params.ApplicationConfigDryRun) (params.ConfigDryRunResult, error)
+
+	// These methods are on API V15.
+	ConfigHistory(application string) ([]params.ConfigRevision, error)
+	RevertConfig(application string, revision int) error
 }
 
 // Info is part of the cmd.Command interface.
 func (c *configCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "config",
-		Args:    "<application name> [--branch <branch-name>] [--reset <key[,key]>] [<attribute-key>][=<value>] ...]",
+		Args:    "<application name> [--branch <branch-name>] [--dry-run] [--reset <key[,key]>] [<attribute-key>][=<value>] ...] | [--history | --revert <revision>]",
 		Purpose: configSummary,
 		Doc:     configDetails,
 	})
@@ -125,6 +151,9 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.Var(&c.configFile, "file", "path to yaml-formatted application config")
 	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys")
+	f.BoolVar(&c.dryRun, "dry-run", false, "Report the effect of the changes without applying them")
+	f.BoolVar(&c.history, "history", false, "Display the revisions of the application's configuration")
+	f.IntVar(&c.revert, "revert", 0, "Restore the configuration to its values immediately after the given revision")
 
 	if featureflag.Enabled(feature.Branches) || featureflag.Enabled(feature.Generations) {
 		f.StringVar(&c.branchName, "branch", "", "Specifically target config for the supplied branch")
@@ -166,14 +195,27 @@ func (c *configCommand) Init(args []string) error {
 	c.applicationName = args[0]
 	args = args[1:]
 
+	if c.history || c.revert != 0 {
+		return c.parseHistory(args)
+	}
+
+	var err error
 	switch len(args) {
 	case 0:
-		return c.handleZeroArgs()
+		err = c.handleZeroArgs()
 	case 1:
-		return c.handleOneArg(args)
+		err = c.handleOneArg(args)
 	default:
-		return c.handleArgs(args)
+		err = c.handleArgs(args)
+	}
+	if err != nil {
+		return err
+	}
+	// Values or a file are only parsed when setting.
+	if c.dryRun && c.values == nil && !c.useFile && len(c.resetKeys) == 0 {
+		return errors.New("--dry-run can only be used when setting or resetting values")
 	}
+	return nil
 }
 
 func (c *configCommand) validateGeneration() error {
@@ -197,6 +239,26 @@ func (c *configCommand) validateGeneration() error {
 	return nil
 }
 
+// parseHistory handles the case where --history or --revert is specified,
+// neither of which may be combined with other changes.
+func (c *configCommand) parseHistory(args []string) error {
+	if c.history && c.revert != 0 {
+		return errors.New("cannot specify --history and --revert simultaneously")
+	}
+	if len(args) > 0 || len(c.resetKeys) > 0 || c.configFile.Path != "" || c.dryRun {
+		return errors.New("--history and --revert cannot be combined with other config changes")
+	}
+	if c.history {
+		c.action = c.getHistory
+		return nil
+	}
+	if c.revert < 0 {
+		return errors.Errorf("invalid revision %d", c.revert)
+	}
+	c.action = c.revertConfig
+	return nil
+}
+
 // handleZeroArgs handles the case where there are no positional args.
 func (c *configCommand) handleZeroArgs() error {
 	// If there's a path we're setting args from a file
@@ -297,6 +359,10 @@ func (c *configCommand) Run(ctx *cmd.Context) error {
 	}
 	defer func() { _ = client.Close() }()
 
+	if c.dryRun {
+		return c.validateConfig(client, ctx)
+	}
+
 	if len(c.resetKeys) > 0 {
 		if err := c.resetConfig(client, ctx); err != nil {
 			// We return this error naked as it is almost certainly going to be
@@ -364,21 +430,9 @@ func (c *configCommand) setConfig(client applicationAPI, ctx *cmd.Context) error
 // setConfigFromFile sets the application configuration from settings passed
 // in a YAML file.
 func (c *configCommand) setConfigFromFile(client applicationAPI, ctx *cmd.Context) error {
-	var (
-		b   []byte
-		err error
-	)
-	if c.configFile.Path == "-" {
-		buf := bytes.Buffer{}
-		if _, err := buf.ReadFrom(ctx.Stdin); err != nil {
-			return errors.Trace(err)
-		}
-		b = buf.Bytes()
-	} else {
-		b, err = c.configFile.Read(ctx)
-		if err != nil {
-			return errors.Trace(err)
-		}
+	b, err := c.readConfigFile(ctx)
+	if err != nil {
+		return errors.Trace(err)
 	}
 	return errors.Trace(block.ProcessBlockedError(
 		client.Update(
@@ -390,6 +444,84 @@ func (c *configCommand) setConfigFromFile(client applicationAPI, ctx *cmd.Contex
 		), block.BlockChange))
 }
 
+// readConfigFile reads the YAML config passed with --file, which may
+// be "-" for stdin.
+func (c *configCommand) readConfigFile(ctx *cmd.Context) ([]byte, error) {
+	if c.configFile.Path == "-" {
+		buf := bytes.Buffer{}
+		if _, err := buf.ReadFrom(ctx.Stdin); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return buf.Bytes(), nil
+	}
+	b, err := c.configFile.Read(ctx)
+	return b, errors.Trace(err)
+}
+
+// validateConfig is the run action when --dry-run is specified. It
+// reports the effect of the requested changes without applying them.
+func (c *configCommand) validateConfig(client applicationAPI, ctx *cmd.Context) error {
+	if client.BestAPIVersion() < 14 {
+		return errors.New("--dry-run is not supported by this controller")
+	}
+	arg := params.ApplicationConfigDryRun{
+		ApplicationName: c.applicationName,
+		Generation:      c.branchName,
+		Reset:           c.resetKeys,
+	}
+	if c.useFile {
+		b, err := c.readConfigFile(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		arg.ConfigYAML = string(b)
+	} else if len(c.values) > 0 {
+		settings, err := c.validateValues(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		arg.Config = settings
+	}
+
+	result, err := client.ValidateApplicationConfig(arg)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if result.Error != nil {
+		return block.ProcessBlockedError(result.Error, block.BlockChange)
+	}
+	if len(result.Changes) == 0 {
+		ctx.Infof("No changes to the configuration of %q.", c.applicationName)
+		return nil
+	}
+	return c.out.Write(ctx, common.FormatConfigDryRun(result))
+}
+
+// getHistory is the run action when --history is specified.
+func (c *configCommand) getHistory(client applicationAPI, ctx *cmd.Context) error {
+	if client.BestAPIVersion() < 15 {
+		return errors.New("--history is not supported by this controller")
+	}
+	revisions, err := client.ConfigHistory(c.applicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(revisions) == 0 {
+		ctx.Infof("No configuration history for %q.", c.applicationName)
+		return nil
+	}
+	return c.out.Write(ctx, common.FormatConfigHistory(revisions))
+}
+
+// revertConfig is the run action when --revert is specified.
+func (c *configCommand) revertConfig(client applicationAPI, ctx *cmd.Context) error {
+	if client.BestAPIVersion() < 15 {
+		return errors.New("--revert is not supported by this controller")
+	}
+	err := client.RevertConfig(c.applicationName, c.revert)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
 // getConfig is the run action to return one or all configuration values.
 func (c *configCommand) getConfig(client applicationAPI, ctx *cmd.Context) error {
 	results, err := client.Get(c.branchName, c.applicationName)
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd"
@@ -18,6 +19,7 @@ import (
 	goyaml "gopkg.in/yaml.v2"
 
 	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/application"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/feature"
@@ -272,6 +274,34 @@ var setCommandInitErrorTests = []struct {
 	about:       "--branch with no value",
 	args:        []string{"application", "key", "--branch"},
 	expectError: "option needs an argument: --branch",
+}, {
+	about:       "--dry-run when getting all values",
+	args:        []string{"application", "--dry-run"},
+	expectError: "--dry-run can only be used when setting or resetting values",
+}, {
+	about:       "--dry-run when getting a value",
+	args:        []string{"application", "key", "--dry-run"},
+	expectError: "--dry-run can only be used when setting or resetting values",
+}, {
+	about:       "--history and --revert",
+	args:        []string{"application", "--history", "--revert", "2"},
+	expectError: "cannot specify --history and --revert simultaneously",
+}, {
+	about:       "--history with a key",
+	args:        []string{"application", "--history", "key"},
+	expectError: "--history and --revert cannot be combined with other config changes",
+}, {
+	about:       "--revert with a value",
+	args:        []string{"application", "--revert", "2", "key=value"},
+	expectError: "--history and --revert cannot be combined with other config changes",
+}, {
+	about:       "--revert with --reset",
+	args:        []string{"application", "--revert", "2", "--reset", "key"},
+	expectError: "--history and --revert cannot be combined with other config changes",
+}, {
+	about:       "--revert with an invalid revision",
+	args:        []string{"application", "--revert", "-1"},
+	expectError: "invalid revision -1",
 }}
 
 func (s *configCommandSuite) TestSetCommandInitError(c *gc.C) {
@@ -441,6 +471,145 @@ func (s *configCommandSuite) TestBlockSetConfig(c *gc.C) {
 	c.Check(c.GetTestLog(), gc.Matches, "(.|\n)*TestBlockSetConfig(.|\n)*")
 }
 
+func (s *configCommandSuite) TestDryRunSet(c *gc.C) {
+	s.fake.version = 14
+	s.fake.dryRunResult = params.ConfigDryRunResult{
+		Changes: []params.ConfigChange{
+			{Key: "skill-level", Old: 100, New: 9000, Input: "9000"},
+			{Key: "username", Old: "admin001", New: "hello"},
+		},
+		Units: []string{"dummy-application/0", "dummy-application/1"},
+	}
+	ctx, err := cmdtesting.RunCommandInDir(c, application.NewConfigCommandForTest(s.fake, s.store), []string{
+		"dummy-application", "--dry-run", "skill-level=9000", "username=hello",
+	}, s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+changes:
+  skill-level:
+    old: 100
+    new: 9000
+    coerced-from: "9000"
+  username:
+    old: admin001
+    new: hello
+units-receiving-config-changed:
+- dummy-application/0
+- dummy-application/1
+`[1:])
+	c.Assert(s.fake.dryRunArg, jc.DeepEquals, &params.ApplicationConfigDryRun{
+		ApplicationName: "dummy-application",
+		Generation:      model.GenerationMaster,
+		Config:          map[string]string{"skill-level": "9000", "username": "hello"},
+	})
+
+	// Nothing was changed.
+	c.Assert(s.fake.charmValues, jc.DeepEquals, s.defaultCharmValues)
+}
+
+func (s *configCommandSuite) TestDryRunFileAndReset(c *gc.C) {
+	s.fake.version = 14
+	ctx, err := cmdtesting.RunCommandInDir(c, application.NewConfigCommandForTest(s.fake, s.store), []string{
+		"dummy-application", "--dry-run", "--file", "testconfig.yaml", "--reset", "title,outlook",
+	}, s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No changes to the configuration of \"dummy-application\".\n")
+	c.Assert(s.fake.dryRunArg, jc.DeepEquals, &params.ApplicationConfigDryRun{
+		ApplicationName: "dummy-application",
+		Generation:      model.GenerationMaster,
+		ConfigYAML:      yamlConfigValue,
+		Reset:           []string{"title", "outlook"},
+	})
+	c.Assert(s.fake.config, gc.Equals, "")
+	c.Assert(s.fake.charmValues, jc.DeepEquals, s.defaultCharmValues)
+}
+
+func (s *configCommandSuite) TestDryRunValidationError(c *gc.C) {
+	s.fake.version = 14
+	s.fake.dryRunResult = params.ConfigDryRunResult{
+		Error: &params.Error{Message: `option "skill-level" expected int, got "lots"`},
+	}
+	_, err := cmdtesting.RunCommandInDir(c, application.NewConfigCommandForTest(s.fake, s.store), []string{
+		"dummy-application", "--dry-run", "skill-level=lots",
+	}, s.dir)
+	c.Assert(err, gc.ErrorMatches, `option "skill-level" expected int, got "lots"`)
+}
+
+func (s *configCommandSuite) TestDryRunNotSupported(c *gc.C) {
+	_, err := cmdtesting.RunCommandInDir(c, application.NewConfigCommandForTest(s.fake, s.store), []string{
+		"dummy-application", "--dry-run", "username=hello",
+	}, s.dir)
+	c.Assert(err, gc.ErrorMatches, "--dry-run is not supported by this controller")
+	c.Assert(s.fake.charmValues, jc.DeepEquals, s.defaultCharmValues)
+}
+
+func (s *configCommandSuite) TestHistory(c *gc.C) {
+	s.fake.version = 15
+	s.fake.history = []params.ConfigRevision{{
+		Revision:  2,
+		Scope:     "charm",
+		Created:   time.Date(2020, 9, 1, 3, 0, 0, 0, time.UTC),
+		CreatedBy: "bob",
+		Changes:   []params.ConfigChange{{Key: "skill-level", Old: 100, New: 9000}},
+	}}
+	ctx, err := cmdtesting.RunCommandInDir(c, application.NewConfigCommandForTest(s.fake, s.store), []string{
+		"dummy-application", "--history",
+	}, s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- revision: 2
+  scope: charm
+  time: 2020-09-01T03:00:00Z
+  user: bob
+  changes:
+    skill-level:
+      old: 100
+      new: 9000
+`[1:])
+}
+
+func (s *configCommandSuite) TestHistoryEmpty(c *gc.C) {
+	s.fake.version = 15
+	ctx, err := cmdtesting.RunCommandInDir(c, application.NewConfigCommandForTest(s.fake, s.store), []string{
+		"dummy-application", "--history",
+	}, s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No configuration history for \"dummy-application\".\n")
+}
+
+func (s *configCommandSuite) TestRevert(c *gc.C) {
+	s.fake.version = 15
+	_, err := cmdtesting.RunCommandInDir(c, application.NewConfigCommandForTest(s.fake, s.store), []string{
+		"dummy-application", "--revert", "2",
+	}, s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.revertTo, gc.Equals, 2)
+}
+
+func (s *configCommandSuite) TestBlockRevert(c *gc.C) {
+	s.fake.version = 15
+	s.fake.err = apiservererrors.OperationBlockedError("TestBlockRevert")
+	_, err := cmdtesting.RunCommandInDir(c, application.NewConfigCommandForTest(s.fake, s.store), []string{
+		"dummy-application", "--revert", "2",
+	}, s.dir)
+	c.Assert(err, gc.ErrorMatches, `(.|\n)*All operations that change model have been disabled(.|\n)*`)
+	c.Check(c.GetTestLog(), gc.Matches, "(.|\n)*TestBlockRevert(.|\n)*")
+}
+
+func (s *configCommandSuite) TestHistoryNotSupported(c *gc.C) {
+	_, err := cmdtesting.RunCommandInDir(c, application.NewConfigCommandForTest(s.fake, s.store), []string{
+		"dummy-application", "--history",
+	}, s.dir)
+	c.Assert(err, gc.ErrorMatches, "--history is not supported by this controller")
+	_, err = cmdtesting.RunCommandInDir(c, application.NewConfigCommandForTest(s.fake, s.store), []string{
+		"dummy-application", "--revert", "2",
+	}, s.dir)
+	c.Assert(err, gc.ErrorMatches, "--revert is not supported by this controller")
+	c.Assert(s.fake.revertTo, gc.Equals, 0)
+}
+
 // assertSetSuccess sets configuration options and checks the expected settings.
 func (s *configCommandSuite) assertSetSuccess(
 	c *gc.C, dir string, args []string,
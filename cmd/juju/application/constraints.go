@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/featureflag"
 	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 
@@ -17,6 +18,8 @@ import (
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/feature"
 )
 
 var usageGetConstraintsSummary = `
@@ -58,6 +61,8 @@ This command requires that the application to have at least one unit. To apply
 constraints to
 the first unit set them at the model level or pass them as an argument
 when deploying.
+Constraints set with the --branch option are staged in that branch and only
+apply to the application once the branch is committed.
 
 Examples:
     juju set-constraints mysql mem=8G cores=4
@@ -77,6 +82,7 @@ type applicationConstraintsAPI interface {
 	Close() error
 	GetConstraints(...string) ([]constraints.Value, error)
 	SetConstraints(string, constraints.Value) error
+	SetBranchConstraints(string, string, constraints.Value) error
 }
 
 type applicationConstraintsCommand struct {
@@ -153,6 +159,7 @@ func (c *applicationGetConstraintsCommand) Run(ctx *cmd.Context) error {
 type applicationSetConstraintsCommand struct {
 	applicationConstraintsCommand
 	Constraints constraints.Value
+	BranchName  string
 }
 
 // NewApplicationSetConstraintsCommand returns a command which sets application constraints.
@@ -169,6 +176,13 @@ func (c *applicationSetConstraintsCommand) Info() *cmd.Info {
 	})
 }
 
+func (c *applicationSetConstraintsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	if featureflag.Enabled(feature.Branches) || featureflag.Enabled(feature.Generations) {
+		f.StringVar(&c.BranchName, "branch", "", "Stage the constraints in the supplied branch")
+	}
+}
+
 func (c *applicationSetConstraintsCommand) Init(args []string) (err error) {
 	if len(args) == 0 {
 		return errors.Errorf("no application name specified")
@@ -190,6 +204,10 @@ func (c *applicationSetConstraintsCommand) Run(_ *cmd.Context) (err error) {
 	}
 	defer apiclient.Close()
 
-	err = apiclient.SetConstraints(c.ApplicationName, c.Constraints)
+	if c.BranchName != "" && c.BranchName != model.GenerationMaster {
+		err = apiclient.SetBranchConstraints(c.BranchName, c.ApplicationName, c.Constraints)
+	} else {
+		err = apiclient.SetConstraints(c.ApplicationName, c.Constraints)
+	}
 	return block.ProcessBlockedError(err, block.BlockChange)
 }
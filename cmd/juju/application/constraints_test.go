@@ -9,6 +9,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	"github.com/juju/juju/testing"
 )
@@ -52,6 +53,19 @@ func (s *ApplicationConstraintsCommandsSuite) TestSetInit(c *gc.C) {
 	}
 }
 
+func (s *ApplicationConstraintsCommandsSuite) TestSetInitBranch(c *gc.C) {
+	cmd := application.NewApplicationSetConstraintsCommand()
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	err := cmdtesting.InitCommand(cmd, []string{"--branch", "canary", "mysql", "mem=4G"})
+	c.Check(err, gc.ErrorMatches, `option provided but not defined: --branch`)
+
+	s.SetFeatureFlags(feature.Branches)
+	cmd = application.NewApplicationSetConstraintsCommand()
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	err = cmdtesting.InitCommand(cmd, []string{"--branch", "canary", "mysql", "mem=4G"})
+	c.Check(err, jc.ErrorIsNil)
+}
+
 func (s *ApplicationConstraintsCommandsSuite) TestGetInit(c *gc.C) {
 	for _, test := range []struct {
 		args []string
@@ -481,6 +481,10 @@ behaviour use the ` + "`set-constraints`" + ` command to change the application'
 constraints or add a machine (` + "`add-machine`" + `) with a certain constraint and then
 target that machine with ` + "`add-unit`" + ` by using the '--to' option.
 
+The 'labels' constraint is a list of key=value annotations. Units are only
+placed on existing machines annotated with all of them, and new machines are
+annotated with them.
+
 Use the '--device' option to specify GPU device requirements (with Kubernetes).
 The below format is used for this option's value, where the 'label' is named in
 the charm metadata file:
@@ -537,7 +541,9 @@ Where 'bar' and 'baz' are named in the metadata file for charm 'foo'.
 Use the '--to' option to deploy to an existing machine or container by
 specifying a "placement directive". The ` + "`status`" + ` command should be used for
 guidance on how to refer to machines. A few placement directives are
-provider-dependent (e.g.: 'zone').
+provider-dependent (e.g.: 'zone'). The 'label' directive selects an existing
+machine by its annotations, preferring the matching machine with the fewest
+units.
 
 In more complex scenarios, "network spaces" are used to partition the cloud
 networking layer into sets of subnets. Instances hosting units inside the same
@@ -609,10 +615,18 @@ Deploy to a machine with at least 8 GiB of memory:
 
     juju deploy postgresql --constraints mem=8G
 
+Deploy to machines annotated with team=payments, annotating any new ones:
+
+    juju deploy postgresql --constraints labels=team=payments
+
 Deploy to a specific availability zone (provider-dependent):
 
     juju deploy mysql --to zone=us-east-1a
 
+Deploy to an existing machine annotated with team=payments:
+
+    juju deploy mysql --to label:team=payments
+
 Deploy to a specific MAAS node:
 
     juju deploy mysql --to host.maas
@@ -188,6 +188,14 @@ func NewRemoveUnitCommandForTest(api RemoveApplicationAPI, store jujuclient.Clie
 	return modelcmd.Wrap(cmd)
 }
 
+func NewRemoveUnitCommandWithSelectorForTest(
+	api RemoveApplicationAPI, selectorAPI unitSelectorAPI, store jujuclient.ClientStore,
+) modelcmd.ModelCommand {
+	cmd := &removeUnitCommand{api: api, selectorAPI: selectorAPI}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 type removeAPIFunc func() (RemoveApplicationAPI, int, error)
 
 // NewRemoveApplicationCommandForTest returns a RemoveApplicationCommand.
@@ -297,6 +305,14 @@ func NewShowUnitCommandForTest(api UnitsInfoAPI, store jujuclient.ClientStore) c
 	return modelcmd.Wrap(cmd)
 }
 
+func NewShowRelationDataCommandForTest(api RelationDataAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showRelationDataCommand{newAPIFunc: func() (RelationDataAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // RepoSuiteBaseSuite allows the patching of the supported juju suite for
 // each test.
 type RepoSuiteBaseSuite struct {
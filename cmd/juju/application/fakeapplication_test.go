@@ -23,6 +23,12 @@ type fakeApplicationAPI struct {
 	config      string
 	err         error
 	version     int
+
+	dryRunArg    *params.ApplicationConfigDryRun
+	dryRunResult params.ConfigDryRunResult
+
+	history  []params.ConfigRevision
+	revertTo int
 }
 
 func (f *fakeApplicationAPI) Update(args params.ApplicationUpdate) error {
@@ -144,3 +150,38 @@ func (f *fakeApplicationAPI) UnsetApplicationConfig(branchName, application stri
 	}
 	return f.Unset(application, options)
 }
+
+func (f *fakeApplicationAPI) ValidateApplicationConfig(arg params.ApplicationConfigDryRun) (params.ConfigDryRunResult, error) {
+	if f.err != nil {
+		return params.ConfigDryRunResult{}, f.err
+	}
+	if arg.Generation != f.branchName {
+		return params.ConfigDryRunResult{}, errors.Errorf("expected branch %q, got %q", f.branchName, arg.Generation)
+	}
+	if arg.ApplicationName != f.name {
+		return params.ConfigDryRunResult{}, errors.NotFoundf("application %q", arg.ApplicationName)
+	}
+	f.dryRunArg = &arg
+	return f.dryRunResult, nil
+}
+
+func (f *fakeApplicationAPI) ConfigHistory(application string) ([]params.ConfigRevision, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if application != f.name {
+		return nil, errors.NotFoundf("application %q", application)
+	}
+	return f.history, nil
+}
+
+func (f *fakeApplicationAPI) RevertConfig(application string, revision int) error {
+	if f.err != nil {
+		return f.err
+	}
+	if application != f.name {
+		return errors.NotFoundf("application %q", application)
+	}
+	f.revertTo = revision
+	return nil
+}
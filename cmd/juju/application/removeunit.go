@@ -12,12 +12,14 @@ import (
 	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 
+	"github.com/juju/juju/api/annotations"
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/api/storage"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	coreannotations "github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/core/model"
 )
 
@@ -26,13 +28,21 @@ func NewRemoveUnitCommand() modelcmd.ModelCommand {
 	return modelcmd.Wrap(&removeUnitCommand{})
 }
 
+// unitSelectorAPI selects units by their annotations.
+type unitSelectorAPI interface {
+	Select(selector, kind string) ([]names.Tag, error)
+	Close() error
+}
+
 // removeUnitCommand is responsible for destroying application units.
 type removeUnitCommand struct {
 	modelcmd.ModelCommandBase
 	DestroyStorage bool
 	NumUnits       int
 	EntityNames    []string
+	Selector       string
 	api            RemoveApplicationAPI
+	selectorAPI    unitSelectorAPI
 
 	unknownModel bool
 	Force        bool
@@ -67,6 +77,10 @@ model.
 Juju will also remove the machine if the removed unit was the only unit left
 on that machine (including units in containers).
 
+Instead of naming units, the --selector option removes every unit whose
+annotations, or whose application's or machine's annotations, match all of
+the given key=value pairs.
+
 Sometimes, the removal of the unit may fail as Juju encounters errors
 and failures that need to be dealt with before a unit can be removed.
 For example, Juju will not remove a unit if there are hook failures.
@@ -90,6 +104,8 @@ Examples:
 
     juju remove-unit wordpress/2 --force --no-wait
 
+    juju remove-unit --selector team=payments,env=staging
+
 See also:
     remove-application
     scale-application
@@ -98,7 +114,7 @@ See also:
 func (c *removeUnitCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "remove-unit",
-		Args:    "<unit> [...] | <application>",
+		Args:    "<unit> [...] | <application> | --selector <key>=<value>[,...]",
 		Purpose: "Remove application units from the model.",
 		Doc:     removeUnitDoc,
 	})
@@ -110,11 +126,22 @@ func (c *removeUnitCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.DestroyStorage, "destroy-storage", false, "Destroy storage attached to the unit")
 	f.BoolVar(&c.Force, "force", false, "Completely remove an application and all its dependencies")
 	f.BoolVar(&c.NoWait, "no-wait", false, "Rush through application removal without waiting for each individual step to complete")
+	f.StringVar(&c.Selector, "selector", "", "Remove the units whose annotations match key=value[,key=value...]")
 	c.fs = f
 }
 
 func (c *removeUnitCommand) Init(args []string) error {
 	c.EntityNames = args
+	if c.Selector != "" {
+		if len(c.EntityNames) > 0 {
+			return errors.New("cannot specify both units and --selector")
+		}
+		if c.NumUnits != 0 {
+			return errors.NotValidf("--num-units with --selector")
+		}
+		_, err := coreannotations.ParseSelector(c.Selector)
+		return errors.Trace(err)
+	}
 	if err := c.validateArgsByModelType(); err != nil {
 		if !errors.IsNotFound(err) {
 			return errors.Trace(err)
@@ -189,6 +216,45 @@ func (c *removeUnitCommand) getAPI() (RemoveApplicationAPI, int, error) {
 	return api, api.BestAPIVersion(), nil
 }
 
+func (c *removeUnitCommand) getSelectorAPI() (unitSelectorAPI, error) {
+	if c.selectorAPI != nil {
+		return c.selectorAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return annotations.NewClient(root), nil
+}
+
+// selectUnits sets the units to remove to those matching the selector.
+func (c *removeUnitCommand) selectUnits() error {
+	modelType, err := c.ModelType()
+	if err != nil {
+		return err
+	}
+	if modelType == model.CAAS {
+		return errors.New("k8s models do not support --selector")
+	}
+	api, err := c.getSelectorAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+	tags, err := api.Select(c.Selector, names.UnitTagKind)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(tags) == 0 {
+		return errors.Errorf("no units match selector %q", c.Selector)
+	}
+	c.EntityNames = make([]string, len(tags))
+	for i, tag := range tags {
+		c.EntityNames[i] = tag.Id()
+	}
+	return nil
+}
+
 func (c *removeUnitCommand) getStorageAPI() (storageAPI, error) {
 	root, err := c.NewAPIRoot()
 	if err != nil {
@@ -246,6 +312,12 @@ func (c *removeUnitCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.Selector != "" {
+		if err := c.selectUnits(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	if apiVersion < 4 {
 		return c.removeUnitsDeprecated(ctx, client)
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -213,3 +214,65 @@ func (s *RemoveUnitSuite) TestCAASAllowsNumUnitsOnly(c *gc.C) {
 	_, err = s.runRemoveUnit(c, "some-application-name", "--num-units", "2")
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+type fakeUnitSelectorAPI struct {
+	tags     []names.Tag
+	selector string
+	kind     string
+}
+
+func (f *fakeUnitSelectorAPI) Select(selector, kind string) ([]names.Tag, error) {
+	f.selector, f.kind = selector, kind
+	return f.tags, nil
+}
+
+func (*fakeUnitSelectorAPI) Close() error {
+	return nil
+}
+
+func (s *RemoveUnitSuite) runRemoveUnitWithSelector(c *gc.C, selectorAPI *fakeUnitSelectorAPI, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewRemoveUnitCommandWithSelectorForTest(s.fake, selectorAPI, s.store), args...)
+}
+
+func (s *RemoveUnitSuite) TestRemoveUnitSelector(c *gc.C) {
+	selectorAPI := &fakeUnitSelectorAPI{
+		tags: []names.Tag{names.NewUnitTag("unit/0"), names.NewUnitTag("unit/1")},
+	}
+	ctx, err := s.runRemoveUnitWithSelector(c, selectorAPI, "--selector", "team=payments")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(selectorAPI.selector, gc.Equals, "team=payments")
+	c.Assert(selectorAPI.kind, gc.Equals, "unit")
+	c.Assert(s.fake.units, jc.DeepEquals, []string{"unit/0", "unit/1"})
+
+	stderr := cmdtesting.Stderr(ctx)
+	c.Assert(stderr, gc.Equals, `
+removing unit unit/0
+- will detach storage data/0
+removing unit unit/1
+- will detach storage data/1
+`[1:])
+}
+
+func (s *RemoveUnitSuite) TestRemoveUnitSelectorNoMatches(c *gc.C) {
+	_, err := s.runRemoveUnitWithSelector(c, &fakeUnitSelectorAPI{}, "--selector", "team=payments")
+	c.Assert(err, gc.ErrorMatches, `no units match selector "team=payments"`)
+	c.Assert(s.fake.units, gc.HasLen, 0)
+}
+
+func (s *RemoveUnitSuite) TestRemoveUnitSelectorInvalid(c *gc.C) {
+	selectorAPI := &fakeUnitSelectorAPI{}
+	_, err := s.runRemoveUnitWithSelector(c, selectorAPI, "--selector", "team")
+	c.Assert(err, gc.ErrorMatches, `selector term "team": expected key=value not valid`)
+
+	_, err = s.runRemoveUnitWithSelector(c, selectorAPI, "unit/0", "--selector", "team=payments")
+	c.Assert(err, gc.ErrorMatches, "cannot specify both units and --selector")
+}
+
+func (s *RemoveUnitSuite) TestCAASRemoveUnitSelector(c *gc.C) {
+	m := s.store.Models["arthur"].Models["king/sword"]
+	m.ModelType = model.CAAS
+	s.store.Models["arthur"].Models["king/sword"] = m
+
+	_, err := s.runRemoveUnitWithSelector(c, &fakeUnitSelectorAPI{}, "--selector", "team=payments")
+	c.Assert(err, gc.ErrorMatches, "k8s models do not support --selector")
+}
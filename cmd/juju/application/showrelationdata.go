@@ -0,0 +1,239 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/relationdata"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const showRelationDataDoc = `
+Show the settings that applications and units have published to the
+relations of an application endpoint.
+
+For every relation the endpoint participates in, the application data
+bag of each application and the unit data bag of each unit in scope is
+shown. If a unit is specified, only the data published by that unit is
+shown.
+
+With --diff, the data published by two units is compared and only the
+keys whose values differ are shown. This is useful to find out why one
+unit of an application behaves differently from its peers. The data bag
+of the units' application is shown alongside; if the units belong to
+different applications, the application data bags are compared too.
+
+This command is restricted to model administrators.
+
+Examples:
+    juju show-relation-data mysql:db
+    juju show-relation-data mysql:db mysql/0
+    juju show-relation-data mysql:db mysql/0 --diff mysql/1
+    juju show-relation-data wordpress:db --format json
+
+See also:
+    show-unit
+    relate
+`
+
+// NewShowRelationDataCommand returns a command that displays the data
+// published to the relations of an application endpoint.
+func NewShowRelationDataCommand() cmd.Command {
+	s := &showRelationDataCommand{}
+	s.newAPIFunc = func() (RelationDataAPI, error) {
+		return s.newRelationDataAPI()
+	}
+	return modelcmd.Wrap(s)
+}
+
+// RelationDataAPI defines the API methods that the show-relation-data
+// command uses.
+type RelationDataAPI interface {
+	Close() error
+	RelationData(endpoint string, units ...string) ([]params.RelationSettingsData, error)
+}
+
+type showRelationDataCommand struct {
+	modelcmd.ModelCommandBase
+
+	out      cmd.Output
+	endpoint string
+	unit     string
+	diffUnit string
+
+	newAPIFunc func() (RelationDataAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *showRelationDataCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-relation-data",
+		Args:    "<application>:<endpoint> [<unit name>]",
+		Purpose: "Displays the data published to the relations of an endpoint.",
+		Doc:     showRelationDataDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showRelationDataCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters.Formatters())
+	f.StringVar(&c.diffUnit, "diff", "", "only show the data that differs from the specified unit")
+}
+
+// Init implements Command.Init.
+func (c *showRelationDataCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("an endpoint must be supplied")
+	}
+	c.endpoint, args = args[0], args[1:]
+	if parts := strings.Split(c.endpoint, ":"); len(parts) != 2 ||
+		!names.IsValidApplication(parts[0]) || parts[1] == "" {
+		return errors.NotValidf("endpoint %q, expected <application>:<endpoint>", c.endpoint)
+	}
+	if len(args) > 0 {
+		c.unit, args = args[0], args[1:]
+		if !names.IsValidUnit(c.unit) {
+			return errors.NotValidf("unit name %q", c.unit)
+		}
+	}
+	if c.diffUnit != "" {
+		if c.unit == "" {
+			return errors.New("--diff requires a unit to compare against")
+		}
+		if !names.IsValidUnit(c.diffUnit) {
+			return errors.NotValidf("unit name %q", c.diffUnit)
+		}
+		if c.diffUnit == c.unit {
+			return errors.New("cannot compare a unit with itself")
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *showRelationDataCommand) newRelationDataAPI() (RelationDataAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return relationdata.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *showRelationDataCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var units []string
+	if c.unit != "" {
+		units = append(units, c.unit)
+	}
+	if c.diffUnit != "" {
+		units = append(units, c.diffUnit)
+	}
+	relations, err := client.RelationData(c.endpoint, units...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(relations) == 0 {
+		ctx.Infof("Endpoint %q has no relations.", c.endpoint)
+		return nil
+	}
+
+	if c.diffUnit != "" {
+		output := make(map[string]RelationDataDiff)
+		for _, rel := range relations {
+			output[rel.Key] = diffRelationData(rel, c.unit, c.diffUnit)
+		}
+		return c.out.Write(ctx, output)
+	}
+	output := make(map[string]RelationSettings)
+	for _, rel := range relations {
+		output[rel.Key] = RelationSettings{
+			RelationId:      rel.RelationId,
+			ApplicationData: rel.ApplicationSettings,
+			UnitData:        rel.UnitSettings,
+		}
+	}
+	return c.out.Write(ctx, output)
+}
+
+// RelationSettings defines the serialization behaviour of the data
+// published to a relation.
+type RelationSettings struct {
+	RelationId      int                               `yaml:"relation-id" json:"relation-id"`
+	ApplicationData map[string]map[string]interface{} `yaml:"application-data" json:"application-data"`
+	UnitData        map[string]map[string]interface{} `yaml:"unit-data,omitempty" json:"unit-data,omitempty"`
+}
+
+// RelationDataDiff defines the serialization behaviour of the
+// differences between the data two units published to a relation.
+type RelationDataDiff struct {
+	RelationId             int                               `yaml:"relation-id" json:"relation-id"`
+	ApplicationData        map[string]map[string]interface{} `yaml:"application-data,omitempty" json:"application-data,omitempty"`
+	ApplicationDifferences map[string]map[string]interface{} `yaml:"application-differences,omitempty" json:"application-differences,omitempty"`
+	Differences            map[string]map[string]interface{} `yaml:"differences" json:"differences"`
+}
+
+// diffRelationData compares the data that two units published to the
+// relation. If the units belong to the same application, that
+// application's data is included as is; otherwise the data of the two
+// applications is compared as well.
+func diffRelationData(rel params.RelationSettingsData, unit, other string) RelationDataDiff {
+	diff := RelationDataDiff{
+		RelationId:  rel.RelationId,
+		Differences: diffSettings(rel.UnitSettings, unit, other),
+	}
+	app, _ := names.UnitApplication(unit)
+	otherApp, _ := names.UnitApplication(other)
+	if app == otherApp {
+		if settings, ok := rel.ApplicationSettings[app]; ok {
+			diff.ApplicationData = map[string]map[string]interface{}{app: settings}
+		}
+	} else {
+		diff.ApplicationDifferences = diffSettings(rel.ApplicationSettings, app, otherApp)
+	}
+	return diff
+}
+
+// diffSettings returns, for every key whose value differs between the
+// two named data bags, the value in each bag. Bags which do not hold a
+// key are omitted for that key.
+func diffSettings(settings map[string]map[string]interface{}, name, other string) map[string]map[string]interface{} {
+	left, right := settings[name], settings[other]
+	diff := make(map[string]map[string]interface{})
+	record := func(key string) {
+		values := make(map[string]interface{})
+		if v, ok := left[key]; ok {
+			values[name] = v
+		}
+		if v, ok := right[key]; ok {
+			values[other] = v
+		}
+		diff[key] = values
+	}
+	for key, value := range left {
+		if otherValue, ok := right[key]; !ok || !reflect.DeepEqual(value, otherValue) {
+			record(key)
+		}
+	}
+	for key := range right {
+		if _, ok := left[key]; !ok {
+			record(key)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,200 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type ShowRelationDataSuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+
+	mockAPI *mockRelationDataAPI
+}
+
+var _ = gc.Suite(&ShowRelationDataSuite{})
+
+func (s *ShowRelationDataSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	s.mockAPI = &mockRelationDataAPI{
+		relations: []params.RelationSettingsData{{
+			RelationId: 7,
+			Key:        "wordpress:db mysql:db",
+			ApplicationSettings: map[string]map[string]interface{}{
+				"mysql":     {"database": "wordpress"},
+				"wordpress": {},
+			},
+			UnitSettings: map[string]map[string]interface{}{
+				"mysql/0": {"host": "10.0.0.1", "port": "3306", "user": "admin"},
+				"mysql/1": {"host": "10.0.0.2", "port": "3306", "password": "secret"},
+			},
+		}},
+	}
+}
+
+func (s *ShowRelationDataSuite) runShow(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewShowRelationDataCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *ShowRelationDataSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "an endpoint must be supplied",
+	}, {
+		args: []string{"mysql"},
+		err:  `endpoint "mysql", expected <application>:<endpoint> not valid`,
+	}, {
+		args: []string{"mysql:db", "mysql-0"},
+		err:  `unit name "mysql-0" not valid`,
+	}, {
+		args: []string{"mysql:db", "--diff", "mysql/1"},
+		err:  "--diff requires a unit to compare against",
+	}, {
+		args: []string{"mysql:db", "mysql/0", "--diff", "mysql/0"},
+		err:  "cannot compare a unit with itself",
+	}, {
+		args: []string{"mysql:db", "mysql/0", "mysql/1"},
+		err:  `unrecognized args: \["mysql/1"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runShow(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ShowRelationDataSuite) TestShow(c *gc.C) {
+	ctx, err := s.runShow(c, "mysql:db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+wordpress:db mysql:db:
+  relation-id: 7
+  application-data:
+    mysql:
+      database: wordpress
+    wordpress: {}
+  unit-data:
+    mysql/0:
+      host: 10.0.0.1
+      port: "3306"
+      user: admin
+    mysql/1:
+      host: 10.0.0.2
+      password: secret
+      port: "3306"
+`[1:])
+	s.mockAPI.CheckCall(c, 0, "RelationData", "mysql:db", []string(nil))
+	s.mockAPI.CheckCall(c, 1, "Close")
+}
+
+func (s *ShowRelationDataSuite) TestShowUnit(c *gc.C) {
+	_, err := s.runShow(c, "mysql:db", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "RelationData", "mysql:db", []string{"mysql/0"})
+}
+
+func (s *ShowRelationDataSuite) TestShowJSON(c *gc.C) {
+	ctx, err := s.runShow(c, "mysql:db", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"wordpress:db mysql:db":{"relation-id":7,"application-data":{"mysql":{"database":"wordpress"},"wordpress":{}},"unit-data":{"mysql/0":{"host":"10.0.0.1","port":"3306","user":"admin"},"mysql/1":{"host":"10.0.0.2","password":"secret","port":"3306"}}}}`+"\n")
+}
+
+func (s *ShowRelationDataSuite) TestShowDiff(c *gc.C) {
+	ctx, err := s.runShow(c, "mysql:db", "mysql/0", "--diff", "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+wordpress:db mysql:db:
+  relation-id: 7
+  application-data:
+    mysql:
+      database: wordpress
+  differences:
+    host:
+      mysql/0: 10.0.0.1
+      mysql/1: 10.0.0.2
+    password:
+      mysql/1: secret
+    user:
+      mysql/0: admin
+`[1:])
+	s.mockAPI.CheckCall(c, 0, "RelationData", "mysql:db", []string{"mysql/0", "mysql/1"})
+}
+
+func (s *ShowRelationDataSuite) TestShowDiffApplications(c *gc.C) {
+	s.mockAPI.relations[0].ApplicationSettings["wordpress"] = map[string]interface{}{"database": "blog"}
+	s.mockAPI.relations[0].UnitSettings["wordpress/0"] = map[string]interface{}{"host": "10.0.0.1"}
+	ctx, err := s.runShow(c, "mysql:db", "mysql/0", "--diff", "wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+wordpress:db mysql:db:
+  relation-id: 7
+  application-differences:
+    database:
+      mysql: wordpress
+      wordpress: blog
+  differences:
+    port:
+      mysql/0: "3306"
+    user:
+      mysql/0: admin
+`[1:])
+}
+
+func (s *ShowRelationDataSuite) TestShowNoRelations(c *gc.C) {
+	s.mockAPI.relations = nil
+	ctx, err := s.runShow(c, "mysql:cluster")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Endpoint \"mysql:cluster\" has no relations.\n")
+}
+
+func (s *ShowRelationDataSuite) TestShowError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("permission denied"))
+	_, err := s.runShow(c, "mysql:db")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockRelationDataAPI struct {
+	testing.Stub
+	relations []params.RelationSettingsData
+}
+
+func (m *mockRelationDataAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockRelationDataAPI) RelationData(endpoint string, units ...string) ([]params.RelationSettingsData, error) {
+	m.MethodCall(m, "RelationData", endpoint, units)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.relations, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/featureflag"
 	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 	"github.com/juju/version"
@@ -34,6 +35,8 @@ import (
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/storage"
@@ -157,6 +160,10 @@ type upgradeCharmCommand struct {
 	// defined in charm storage metadata, to add or update during upgrade.
 	Storage map[string]storage.Constraints
 
+	// BranchName is the branch in which to stage the upgrade, overriding
+	// the active branch.
+	BranchName string
+
 	catacomb catacomb.Catacomb
 	plan     catacomb.Plan
 }
@@ -236,6 +243,15 @@ behavior.
 --force option for LXD Profiles is not generally recommended when upgrading an 
 application; overriding profiles on the container may cause unexpected 
 behavior. 
+
+When a branch other than master is active, or one is given with the --branch
+option, the upgrade is staged in that branch. Only the units tracking the
+branch are upgraded, along with any resources uploaded with the upgrade; the
+application's other units are upgraded when the branch is committed. Config
+settings, storage constraints and endpoint bindings cannot be changed with an
+upgrade in a branch.
+
+  juju upgrade-charm foo --branch canary
 `
 
 func (c *upgradeCharmCommand) Info() *cmd.Info {
@@ -260,6 +276,9 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure application endpoint bindings to spaces")
+	if featureflag.Enabled(feature.Branches) || featureflag.Enabled(feature.Generations) {
+		f.StringVar(&c.BranchName, "branch", "", "Stage the upgrade in the supplied branch")
+	}
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -283,6 +302,11 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.BranchName != "" && c.BranchName != model.GenerationMaster {
+		if c.Config.Path != "" || len(c.Storage) > 0 || c.BindToSpaces != "" {
+			return errors.Errorf("--config, --storage and --bind cannot be used with --branch")
+		}
+	}
 	return nil
 }
 
@@ -307,9 +331,11 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
-	generation, err := c.ActiveBranch()
-	if err != nil {
-		return errors.Trace(err)
+	generation := c.BranchName
+	if generation == "" {
+		if generation, err = c.ActiveBranch(); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	charmUpgradeClient := c.NewCharmUpgradeClient(apiRoot)
 	oldURL, err := charmUpgradeClient.GetCharmURL(generation, c.ApplicationName)
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
+	"github.com/juju/featureflag"
 	"github.com/juju/names/v4"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -36,6 +37,8 @@ import (
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/juju/osenv"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/resource/resourceadapters"
@@ -226,6 +229,35 @@ func (s *UpgradeCharmSuite) TestStorageConstraints(c *gc.C) {
 	})
 }
 
+func (s *UpgradeCharmSuite) enableBranches() {
+	s.AddCleanup(func(*gc.C) {
+		featureflag.SetFlagsFromEnvironment(osenv.JujuFeatureFlagEnvKey)
+	})
+	s.PatchEnvironment(osenv.JujuFeatureFlagEnvKey, feature.Branches)
+	featureflag.SetFlagsFromEnvironment(osenv.JujuFeatureFlagEnvKey)
+}
+
+func (s *UpgradeCharmSuite) TestUpgradeInBranch(c *gc.C) {
+	s.enableBranches()
+	_, err := s.runUpgradeCharm(c, "foo", "--branch", "canary")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmAPIClient.CheckCallNames(c, "GetCharmURL", "Get", "SetCharm")
+	s.charmAPIClient.CheckCall(c, 0, "GetCharmURL", "canary", "foo")
+	s.charmAPIClient.CheckCall(c, 2, "SetCharm", "canary", application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID: jujucharmstore.CharmID{
+			URL:     s.resolvedCharmURL,
+			Channel: csclientparams.StableChannel,
+		},
+	})
+}
+
+func (s *UpgradeCharmSuite) TestUpgradeInBranchWithStorage(c *gc.C) {
+	s.enableBranches()
+	_, err := s.runUpgradeCharm(c, "foo", "--branch", "canary", "--storage", "bar=baz")
+	c.Assert(err, gc.ErrorMatches, "--config, --storage and --bind cannot be used with --branch")
+}
+
 func (s *UpgradeCharmSuite) TestUseConfiguredCharmStoreURL(c *gc.C) {
 	_, err := s.runUpgradeCharm(c, "foo")
 	c.Assert(err, jc.ErrorIsNil)
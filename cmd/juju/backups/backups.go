@@ -4,9 +4,12 @@
 package backups
 
 import (
+	"bufio"
 	"bytes"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"text/template"
 	"time"
 
@@ -31,8 +34,10 @@ import (
 //go:generate go run github.com/golang/mock/mockgen -package backups_test -destination mock_test.go github.com/juju/juju/cmd/juju/backups ArchiveReader,APIClient
 type APIClient interface {
 	io.Closer
-	// Create sends an RPC request to create a new backup.
-	Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error)
+	// Create sends an RPC request to create a new backup, encrypted
+	// to the given public key if it is not empty, and streamed or
+	// incremental as requested.
+	Create(notes string, keepCopy, noDownload bool, encryptTo string, stream, incremental bool) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
@@ -136,7 +141,10 @@ machine ID:            {{.MachineID}}
 created on host:       {{.Hostname}} 
 
 checksum:              {{.Checksum}} 
-checksum format:       {{.ChecksumFormat}} 
+checksum format:       {{.ChecksumFormat}}{{if .EncryptionScheme}} 
+encryption:            {{.EncryptionScheme}} 
+encrypted to:          {{.EncryptionRecipient}}{{end}}{{if .BaseID}} 
+incremental from:      {{.BaseID}}{{end}} 
 size (B):              {{.Size}} 
 stored:                {{.Stored}} 
 started:               {{.Started}} 
@@ -162,6 +170,11 @@ type MetadataParams struct {
 	Hostname       string
 	JujuVersion    version.Number
 	Series         string
+
+	EncryptionScheme    string
+	EncryptionRecipient string
+
+	BaseID string
 }
 
 func (c *CommandBase) metadata(result *params.BackupsMetadataResult) string {
@@ -182,6 +195,9 @@ func (c *CommandBase) metadata(result *params.BackupsMetadataResult) string {
 		result.Hostname,
 		result.Version,
 		result.Series,
+		result.EncryptionScheme,
+		result.EncryptionRecipient,
+		result.BaseID,
 	}
 	t := template.Must(template.New("template").Parse(backupMetadataTemplate))
 	content := bytes.Buffer{}
@@ -199,15 +215,20 @@ type ArchiveReader interface {
 	io.Closer
 }
 
-var getArchive = func(filename string) (rc ArchiveReader, metaResult *params.BackupsMetadataResult, err error) {
+var getArchive = func(filename string) (_ ArchiveReader, metaResult *params.BackupsMetadataResult, err error) {
+	archive, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	defer func() {
-		if err != nil && rc != nil {
-			rc.Close()
+		if err != nil {
+			archive.Close()
 		}
 	}()
-	archive, err := os.Open(filename)
-	rc = archive
-	if err != nil {
+	if statebackups.IsEncrypted(bufio.NewReader(archive)) {
+		return nil, nil, errors.Annotatef(errArchiveEncrypted, "%q", filename)
+	}
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
 		return nil, nil, errors.Trace(err)
 	}
 
@@ -262,3 +283,69 @@ var getArchive = func(filename string) (rc ArchiveReader, metaResult *params.Bac
 
 	return archive, metaResult, nil
 }
+
+// writeArchiveFile writes the archive read from source to the named
+// file. The archive is written to a temporary file which is renamed once
+// it is complete, so that a failure part way through, such as the
+// archive failing to decrypt, leaves no partial archive behind.
+func writeArchiveFile(filename string, source io.Reader) error {
+	f, err := ioutil.TempFile(filepath.Dir(filename), "."+filepath.Base(filename)+".")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = io.Copy(f, source)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filename)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// getDecryptedArchive decrypts the archive read from r with the key to
+// a temporary file, and returns the decrypted archive. The temporary
+// file is removed when the archive is closed.
+func getDecryptedArchive(r io.Reader, key statebackups.PrivateKey) (ArchiveReader, *params.BackupsMetadataResult, error) {
+	plain, err := statebackups.NewDecryptingReader(r, key)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	f, err := ioutil.TempFile("", "juju-backup-")
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	_, err = io.Copy(f, plain)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return nil, nil, errors.Trace(err)
+	}
+	archive, meta, err := getArchive(f.Name())
+	if err != nil {
+		os.Remove(f.Name())
+		return nil, nil, errors.Trace(err)
+	}
+	return &tempArchive{ArchiveReader: archive, filename: f.Name()}, meta, nil
+}
+
+// tempArchive is an archive which removes its file when closed.
+type tempArchive struct {
+	ArchiveReader
+	filename string
+}
+
+// Close implements io.Closer.
+func (a *tempArchive) Close() error {
+	err := a.ArchiveReader.Close()
+	if removeErr := os.Remove(a.filename); err == nil {
+		err = removeErr
+	}
+	return errors.Trace(err)
+}
@@ -6,7 +6,6 @@ package backups
 import (
 	"fmt"
 	"io"
-	"os"
 	"time"
 
 	"github.com/juju/cmd"
@@ -38,6 +37,21 @@ Use --keep-copy option to store a copy of backup remotely on the controller.
 
 Use --verbose to see extra information about backup.
 
+Use --encrypt-to to have the controller encrypt the backup archive to a
+public key, given directly or as the name of a file containing it. Key
+pairs are generated with 'juju create-backup-key'. The archive is
+decrypted when it is downloaded if the private key is available, either
+in the default location or the file given with --decrypt-with.
+Otherwise the encrypted archive is downloaded as is.
+
+Use --stream to have the controller stream the database dump straight
+into the backup archive, rather than first staging a copy on disk. This
+needs less free disk space on the controller.
+
+Use --incremental to back up only the changes made since the most recent
+backup stored on the controller. Incremental backups are always kept on
+the controller, as restoring one needs the backups it follows.
+
 To access remote backups stored on the controller, see 'juju download-backup'.
 
 Examples:
@@ -46,9 +60,13 @@ Examples:
     juju create-backup --no-download --keep-copy=false // ignores --keep-copy
     juju create-backup --keep-copy
     juju create-backup --verbose
+    juju create-backup --encrypt-to backup-key.pub
+    juju create-backup --stream
+    juju create-backup --incremental
 
 See also:
     backups
+    create-backup-key
     download-backup
 `
 
@@ -68,6 +86,19 @@ type createCommand struct {
 	Notes string
 	// KeepCopy means the backup archive should be stored in the controller db.
 	KeepCopy bool
+	// EncryptTo is the public key, or a file containing it, to encrypt
+	// the backup archive to.
+	EncryptTo string
+	// DecryptWith is the file containing the private key used to
+	// decrypt the downloaded archive.
+	DecryptWith string
+	// Stream means the database dump is streamed into the archive.
+	Stream bool
+	// Incremental means only the changes since the most recent stored
+	// backup are backed up.
+	Incremental bool
+
+	encryptTo string
 }
 
 // Info implements Command.Info.
@@ -86,6 +117,10 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive, implies keep-copy")
 	f.BoolVar(&c.KeepCopy, "keep-copy", false, "Keep a copy of the archive on the controller")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
+	f.StringVar(&c.EncryptTo, "encrypt-to", "", "Encrypt the archive to this public key, or the key in this file")
+	f.StringVar(&c.DecryptWith, "decrypt-with", "", "Decrypt the downloaded archive with the private key in this file")
+	f.BoolVar(&c.Stream, "stream", false, "Stream the database dump into the archive without staging it on disk")
+	f.BoolVar(&c.Incremental, "incremental", false, "Back up only the changes since the most recent stored backup, implies keep-copy")
 	c.fs = f
 }
 
@@ -98,16 +133,23 @@ func (c *createCommand) Init(args []string) error {
 	// and they have EXPLICITLY not wanted to store a remote backup file copy
 	// (i.e keep-copy == false), then there is no point for us to proceed as
 	// all the backup will not be stored anywhere.
-	if c.NoDownload {
-		keepCopySet := false
-		c.fs.Visit(func(flag *gnuflag.Flag) {
-			if flag.Name == "keep-copy" {
-				keepCopySet = true
-			}
-		})
+	keepCopySet := false
+	c.fs.Visit(func(flag *gnuflag.Flag) {
+		if flag.Name == "keep-copy" {
+			keepCopySet = true
+		}
+	})
+	if c.NoDownload && keepCopySet && !c.KeepCopy {
+		return errors.Errorf("--no-download cannot be set when --keep-copy is not: the backup will not be created")
+	}
+	// Restoring an incremental backup needs the backups it follows,
+	// and a later incremental backup may follow this one, so it must
+	// be kept on the controller.
+	if c.Incremental {
 		if keepCopySet && !c.KeepCopy {
-			return errors.Errorf("--no-download cannot be set when --keep-copy is not: the backup will not be created")
+			return errors.Errorf("--incremental cannot be set when --keep-copy is not: the backup must be kept on the controller")
 		}
+		c.KeepCopy = true
 	}
 	notes, err := cmd.ZeroOrOneArgs(args)
 	if err != nil {
@@ -122,6 +164,14 @@ func (c *createCommand) Init(args []string) error {
 	if c.Filename == "" {
 		return errors.Errorf("missing filename")
 	}
+
+	if c.EncryptTo != "" {
+		key, err := readPublicKey(c.EncryptTo)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.encryptTo = key.String()
+	}
 	return nil
 }
 
@@ -167,7 +217,19 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 	// Handle download.
 	if !c.NoDownload {
 		filename := c.decideFilename(ctx, c.Filename, metadataResult.Started)
-		if err := c.download(ctx, client, copyFrom, filename); err != nil {
+		var key *backups.PrivateKey
+		if metadataResult.EncryptionScheme != "" {
+			if key, err = readPrivateKey(c.DecryptWith); err != nil {
+				return errors.Trace(err)
+			}
+			if key == nil {
+				ctx.Infof("No private key found, the archive will be downloaded encrypted.")
+				if c.Filename == notset {
+					filename += backups.EncryptedFilenameSuffix
+				}
+			}
+		}
+		if err := c.download(ctx, client, copyFrom, filename, key); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -183,21 +245,21 @@ func (c *createCommand) decideFilename(ctx *cmd.Context, filename string, timest
 	return timestamp.Format(backups.FilenameTemplate)
 }
 
-func (c *createCommand) download(ctx *cmd.Context, client APIClient, copyFrom string, archiveFilename string) error {
+func (c *createCommand) download(ctx *cmd.Context, client APIClient, copyFrom string, archiveFilename string, key *backups.PrivateKey) error {
 	resultArchive, err := client.Download(copyFrom)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer resultArchive.Close()
 
-	archive, err := os.Create(archiveFilename)
-	if err != nil {
-		return errors.Annotatef(err, "while creating local archive file %v", archiveFilename)
+	var source io.Reader = resultArchive
+	if key != nil {
+		if source, err = backups.NewDecryptingReader(resultArchive, *key); err != nil {
+			return errors.Trace(err)
+		}
 	}
-	defer archive.Close()
 
-	_, err = io.Copy(archive, resultArchive)
-	if err != nil {
+	if err := writeArchiveFile(archiveFilename, source); err != nil {
 		return errors.Annotatef(err, "while copying to local archive file %v", archiveFilename)
 	}
 	ctx.Infof("Downloaded to %v.", archiveFilename)
@@ -205,7 +267,7 @@ func (c *createCommand) download(ctx *cmd.Context, client APIClient, copyFrom st
 }
 
 func (c *createCommand) create(client APIClient, apiVersion int) (*params.BackupsMetadataResult, string, error) {
-	result, err := client.Create(c.Notes, c.KeepCopy, c.NoDownload)
+	result, err := client.Create(c.Notes, c.KeepCopy, c.NoDownload, c.encryptTo, c.Stream, c.Incremental)
 	if err != nil {
 		return nil, "", errors.Trace(err)
 	}
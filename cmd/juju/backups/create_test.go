@@ -4,10 +4,12 @@
 package backups_test
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -17,6 +19,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cmd/juju/backups"
+	statebackups "github.com/juju/juju/state/backups"
 )
 
 type createSuite struct {
@@ -159,6 +162,24 @@ var testCreateBackupArgParsing = []createBackupArgParsing{
 		noDownload: false,
 		notes:      "note for the backup",
 	},
+	{
+		title:      "incremental",
+		args:       []string{"--incremental"},
+		errMatch:   "",
+		filename:   backups.NotSet,
+		keepCopy:   true,
+		noDownload: false,
+		notes:      "",
+	},
+	{
+		title:      "incremental && keep-copy=false",
+		args:       []string{"--incremental", "--keep-copy=false"},
+		errMatch:   "--incremental cannot be set when --keep-copy is not: the backup must be kept on the controller",
+		filename:   backups.NotSet,
+		keepCopy:   false,
+		noDownload: false,
+		notes:      "",
+	},
 }
 
 func (s *createSuite) TestArgParsing(c *gc.C) {
@@ -302,3 +323,102 @@ func (s *createSuite) TestError(c *gc.C) {
 
 	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
 }
+
+func (s *createSuite) setEncryptedDownload(c *gc.C) (*fakeAPIClient, statebackups.PublicKey, statebackups.PrivateKey) {
+	public, private, err := statebackups.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	var buf bytes.Buffer
+	w, err := statebackups.NewEncryptingWriter(&buf, public)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write([]byte(s.data))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+
+	s.metaresult.EncryptionScheme = statebackups.EncryptionScheme
+	s.metaresult.EncryptionRecipient = public.String()
+	s.expectedOut = strings.Replace(MetaResultString, "checksum format:        \n",
+		"checksum format:        \nencryption:            "+statebackups.EncryptionScheme+" \nencrypted to:          "+public.String()+" \n", 1)
+	client := s.setSuccess()
+	client.archive = ioutil.NopCloser(&buf)
+	return client, public, private
+}
+
+func (s *createSuite) TestEncryptTo(c *gc.C) {
+	client, public, private := s.setEncryptedDownload(c)
+	writeDefaultKey(c, private)
+
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--encrypt-to", public.String())
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "Create", "Download")
+	c.Check(client.encryptTo, gc.Equals, public.String())
+	s.checkDownload(c, ctx)
+}
+
+func (s *createSuite) TestEncryptToFile(c *gc.C) {
+	client, public, private := s.setEncryptedDownload(c)
+	dir := c.MkDir()
+	keyFile := filepath.Join(dir, "backup-key")
+	err := ioutil.WriteFile(keyFile, []byte(private.String()), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	publicFile := filepath.Join(dir, "backup-key.pub")
+	err = ioutil.WriteFile(publicFile, []byte(public.String()+"\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--encrypt-to", publicFile, "--decrypt-with", keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(client.encryptTo, gc.Equals, public.String())
+	s.checkDownload(c, ctx)
+}
+
+func (s *createSuite) TestEncryptToNoPrivateKey(c *gc.C) {
+	client, public, _ := s.setEncryptedDownload(c)
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--encrypt-to", public.String())
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "Create", "Download")
+	s.filename = "juju-backup-00010101-000000.tar.gz" + statebackups.EncryptedFilenameSuffix
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Remote backup was not created.
+No private key found, the archive will be downloaded encrypted.
+Downloaded to `[1:]+s.filename+".\n")
+
+	data, err := ioutil.ReadFile(s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statebackups.IsEncrypted(bufio.NewReader(bytes.NewReader(data))), jc.IsTrue)
+}
+
+func (s *createSuite) TestEncryptToInvalid(c *gc.C) {
+	err := cmdtesting.InitCommand(s.wrappedCommand, []string{"--encrypt-to", "no-such-key"})
+	c.Assert(err, gc.ErrorMatches, `backup public key "no-such-key" not valid`)
+}
+
+func (s *createSuite) TestStream(c *gc.C) {
+	client := s.setDownload()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--stream")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "Create", "Download")
+	c.Check(client.stream, jc.IsTrue)
+	c.Check(client.incremental, jc.IsFalse)
+	s.checkDownload(c, ctx)
+}
+
+func (s *createSuite) TestIncremental(c *gc.C) {
+	s.metaresult.BaseID = "20200401-123000.full"
+	s.expectedOut = strings.Replace(MetaResultString, "checksum format:        \n",
+		"checksum format:        \nincremental from:      20200401-123000.full \n", 1)
+	client := s.setDownload()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--incremental")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "Create", "Download")
+	client.CheckArgs(c, "", "true", "false", "filename")
+	c.Check(client.incremental, jc.IsTrue)
+	s.expectedErr = `
+Remote backup stored on the controller as spam.
+Downloaded to juju-backup-00010101-000000.tar.gz.
+`[1:]
+	s.checkDownload(c, ctx)
+}
@@ -0,0 +1,166 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/juju/osenv"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+const createKeyDoc = `
+Generates a key pair for encrypting controller backups.
+
+The private key is written to a local file, readable only by the current
+user, and the public key is printed. Pass the public key to
+'juju create-backup --encrypt-to' to have the controller encrypt the
+backup archive so that only the holder of the private key can read it.
+
+By default the private key is written to $JUJU_DATA/backup-key, where
+the backup commands look for it when decrypting archives. Use --filename
+to write it elsewhere, and --decrypt-with to use it when downloading or
+restoring backups.
+
+Keep the private key safe: encrypted backups cannot be restored without it.
+
+Examples:
+    juju create-backup-key > backup-key.pub
+    juju create-backup-key --filename ~/secrets/backup-key
+
+See also:
+    create-backup
+    download-backup
+    restore-backup
+`
+
+// errArchiveEncrypted is returned when reading an archive which must be
+// decrypted first.
+var errArchiveEncrypted = errors.New("backup archive is encrypted")
+
+// defaultKeyFilename returns where backup private keys are written
+// and read by default.
+func defaultKeyFilename() string {
+	return osenv.JujuXDGDataHomePath("backup-key")
+}
+
+// readPublicKey returns the public key given either directly or as the
+// name of a file holding it.
+func readPublicKey(value string) (statebackups.PublicKey, error) {
+	if key, err := statebackups.ParsePublicKey(value); err == nil {
+		return key, nil
+	}
+	path, err := utils.NormalizePath(value)
+	if err != nil {
+		return statebackups.PublicKey{}, errors.Trace(err)
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return statebackups.PublicKey{}, errors.NotValidf("backup public key %q", value)
+	}
+	if err != nil {
+		return statebackups.PublicKey{}, errors.Trace(err)
+	}
+	key, err := statebackups.ParsePublicKey(string(data))
+	return key, errors.Annotatef(err, "reading %q", value)
+}
+
+// readPrivateKey returns the private key held in the named file. If no
+// file is named the default file is used if it exists, and nil is
+// returned if it does not.
+func readPrivateKey(filename string) (*statebackups.PrivateKey, error) {
+	path := defaultKeyFilename()
+	if filename != "" {
+		var err error
+		if path, err = utils.NormalizePath(filename); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && filename == "" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "reading backup private key")
+	}
+	key, err := statebackups.ParsePrivateKey(string(data))
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading %q", path)
+	}
+	return &key, nil
+}
+
+// NewCreateKeyCommand returns a command used to generate backup
+// encryption keys.
+func NewCreateKeyCommand() cmd.Command {
+	return &createKeyCommand{}
+}
+
+// createKeyCommand is the sub-command for generating a backup
+// encryption key pair.
+type createKeyCommand struct {
+	cmd.CommandBase
+	// Filename is where the private key is written.
+	Filename string
+	// Force allows an existing key file to be overwritten.
+	Force bool
+}
+
+// Info implements Command.Info.
+func (c *createKeyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "create-backup-key",
+		Purpose: "Generate a key pair for encrypting backups.",
+		Doc:     createKeyDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *createKeyCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.Filename, "filename", "", "Write the private key to this file")
+	f.BoolVar(&c.Force, "force", false, "Overwrite an existing private key file")
+}
+
+// Init implements Command.Init.
+func (c *createKeyCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *createKeyCommand) Run(ctx *cmd.Context) error {
+	path := defaultKeyFilename()
+	if c.Filename != "" {
+		var err error
+		if path, err = utils.NormalizePath(c.Filename); err != nil {
+			return errors.Trace(err)
+		}
+		path = ctx.AbsPath(path)
+	}
+	if _, err := os.Stat(path); err == nil && !c.Force {
+		return errors.Errorf("%q already exists, use --force to overwrite it", path)
+	}
+
+	public, private, err := statebackups.GenerateKeyPair()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Trace(err)
+	}
+	if err := utils.AtomicWriteFile(path, []byte(private.String()+"\n"), 0600); err != nil {
+		return errors.Annotate(err, "writing backup private key")
+	}
+	ctx.Infof("Private key written to %s.", path)
+	fmt.Fprintln(ctx.Stdout, public.String())
+	return nil
+}
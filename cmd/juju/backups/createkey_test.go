@@ -0,0 +1,78 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/juju/osenv"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+type createKeySuite struct {
+	BaseBackupsSuite
+}
+
+var _ = gc.Suite(&createKeySuite{})
+
+func (s *createKeySuite) checkKeyPair(c *gc.C, ctx *cmd.Context, path string) {
+	public, err := statebackups.ParsePublicKey(cmdtesting.Stdout(ctx))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Private key written to "+path+".\n")
+
+	info, err := os.Stat(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	private, err := statebackups.ParsePrivateKey(string(data))
+	c.Assert(err, jc.ErrorIsNil)
+	derived, err := private.PublicKey()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(derived, gc.Equals, public)
+}
+
+func (s *createKeySuite) TestDefaultFilename(c *gc.C) {
+	command := backups.NewCreateKeyCommandForTest()
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkKeyPair(c, ctx, osenv.JujuXDGDataHomePath("backup-key"))
+}
+
+func (s *createKeySuite) TestFilename(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "keys", "backup-key")
+	command := backups.NewCreateKeyCommandForTest()
+	ctx, err := cmdtesting.RunCommand(c, command, "--filename", path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkKeyPair(c, ctx, path)
+}
+
+func (s *createKeySuite) TestExisting(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "backup-key")
+	err := ioutil.WriteFile(path, []byte("existing"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	command := backups.NewCreateKeyCommandForTest()
+	_, err = cmdtesting.RunCommand(c, command, "--filename", path)
+	c.Assert(err, gc.ErrorMatches, `".*backup-key" already exists, use --force to overwrite it`)
+
+	command = backups.NewCreateKeyCommandForTest()
+	ctx, err := cmdtesting.RunCommand(c, command, "--filename", path, "--force")
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkKeyPair(c, ctx, path)
+}
+
+func (s *createKeySuite) TestArgs(c *gc.C) {
+	command := backups.NewCreateKeyCommandForTest()
+	err := cmdtesting.InitCommand(command, []string{"extra"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
@@ -4,9 +4,9 @@
 package backups
 
 import (
+	"bufio"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -22,6 +22,10 @@ download-backup retrieves a backup archive file.
 
 If --filename is not used, the archive is downloaded to a temporary
 location and the filename is printed to stdout.
+
+Encrypted archives are decrypted with the private key in the default
+location, or the file given with --decrypt-with. If there is no private
+key the archive is downloaded encrypted.
 `
 
 // NewDownloadCommand returns a commant used to download backups.
@@ -36,6 +40,9 @@ type downloadCommand struct {
 	Filename string
 	// ID is the backup ID to download.
 	ID string
+	// DecryptWith is the file containing the private key used to
+	// decrypt the archive.
+	DecryptWith string
 }
 
 // Info implements Command.Info.
@@ -52,6 +59,7 @@ func (c *downloadCommand) Info() *cmd.Info {
 func (c *downloadCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "filename", "", "Download target")
+	f.StringVar(&c.DecryptWith, "decrypt-with", "", "Decrypt the archive with the private key in this file")
 }
 
 // Init implements Command.Init.
@@ -85,17 +93,29 @@ func (c *downloadCommand) Run(ctx *cmd.Context) error {
 	}
 	defer resultArchive.Close()
 
-	// Prepare the local archive.
+	// Decrypt the archive if it is encrypted and we have the key.
 	filename := c.ResolveFilename()
-	archive, err := os.Create(filename)
-	if err != nil {
-		return errors.Annotate(err, "while creating local archive file")
+	source := bufio.NewReader(resultArchive)
+	var plain io.Reader = source
+	if backups.IsEncrypted(source) {
+		key, err := readPrivateKey(c.DecryptWith)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if key != nil {
+			if plain, err = backups.NewDecryptingReader(source, *key); err != nil {
+				return errors.Trace(err)
+			}
+		} else {
+			ctx.Infof("No private key found, the archive will be downloaded encrypted.")
+			if c.Filename == "" {
+				filename += backups.EncryptedFilenameSuffix
+			}
+		}
 	}
-	defer archive.Close()
 
 	// Write out the archive.
-	_, err = io.Copy(archive, resultArchive)
-	if err != nil {
+	if err := writeArchiveFile(filename, plain); err != nil {
 		return errors.Annotate(err, "while copying local archive file")
 	}
 
@@ -4,6 +4,11 @@
 package backups_test
 
 import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
@@ -11,6 +16,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cmd/juju/backups"
+	statebackups "github.com/juju/juju/state/backups"
 )
 
 type downloadSuite struct {
@@ -73,3 +79,69 @@ func (s *downloadSuite) TestError(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID)
 	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
 }
+
+func (s *downloadSuite) setEncrypted(c *gc.C) statebackups.PrivateKey {
+	public, private, err := statebackups.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	var buf bytes.Buffer
+	w, err := statebackups.NewEncryptingWriter(&buf, public)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write([]byte(s.data))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+
+	client := s.BaseBackupsSuite.setSuccess()
+	client.archive = ioutil.NopCloser(&buf)
+	return private
+}
+
+func (s *downloadSuite) TestDecrypt(c *gc.C) {
+	writeDefaultKey(c, s.setEncrypted(c))
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID)
+	c.Check(err, jc.ErrorIsNil)
+
+	s.filename = "juju-backup-" + s.metaresult.ID + ".tar.gz"
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, s.filename+"\n")
+	s.checkArchive(c)
+}
+
+func (s *downloadSuite) TestDecryptWith(c *gc.C) {
+	keyFile := filepath.Join(c.MkDir(), "key")
+	err := ioutil.WriteFile(keyFile, []byte(s.setEncrypted(c).String()), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID, "--decrypt-with", keyFile)
+	c.Check(err, jc.ErrorIsNil)
+
+	s.filename = "juju-backup-" + s.metaresult.ID + ".tar.gz"
+	s.checkArchive(c)
+}
+
+func (s *downloadSuite) TestDecryptFailureLeavesNoFile(c *gc.C) {
+	s.setEncrypted(c)
+	_, other, err := statebackups.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	writeDefaultKey(c, other)
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID)
+	c.Check(err, gc.ErrorMatches, ".*cannot decrypt backup archive: it was encrypted to a different key")
+
+	s.filename = "juju-backup-" + s.metaresult.ID + ".tar.gz"
+	entries, err := ioutil.ReadDir(".")
+	c.Assert(err, jc.ErrorIsNil)
+	for _, entry := range entries {
+		c.Check(entry.Name(), gc.Not(jc.Contains), s.filename)
+	}
+}
+
+func (s *downloadSuite) TestEncryptedNoKey(c *gc.C) {
+	s.setEncrypted(c)
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID)
+	c.Check(err, jc.ErrorIsNil)
+
+	s.filename = "juju-backup-" + s.metaresult.ID + ".tar.gz.enc"
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No private key found, the archive will be downloaded encrypted.\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, s.filename+"\n")
+	data, err := ioutil.ReadFile(s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statebackups.IsEncrypted(bufio.NewReader(bytes.NewReader(data))), jc.IsTrue)
+}
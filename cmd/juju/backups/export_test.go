@@ -18,6 +18,8 @@ var (
 	NewAPIClient = &newAPIClient
 	NewGetAPI    = &getAPI
 	GetArchive   = &getArchive
+
+	VerifyArchive = &verifyArchive
 )
 
 type CreateCommand struct {
@@ -56,6 +58,12 @@ func NewShowCommandForTest(store jujuclient.ClientStore) cmd.Command {
 	return modelcmd.Wrap(c)
 }
 
+func NewVerifyCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &verifyCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
 func NewUploadCommandForTest(store jujuclient.ClientStore) cmd.Command {
 	c := &uploadCommand{}
 	c.SetClientStore(store)
@@ -79,3 +87,9 @@ func NewRestoreCommandForTest(
 func (r *RestoreCommand) AssignGetModelStatusAPI(apiFunc func() (ModelStatusAPI, error)) {
 	r.getModelStatusAPI = apiFunc
 }
+
+var ErrArchiveEncrypted = errArchiveEncrypted
+
+func NewCreateKeyCommandForTest() cmd.Command {
+	return &createKeyCommand{}
+}
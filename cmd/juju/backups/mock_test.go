@@ -110,16 +110,16 @@ func (mr *MockAPIClientMockRecorder) Close() *gomock.Call {
 }
 
 // Create mocks base method
-func (m *MockAPIClient) Create(arg0 string, arg1, arg2 bool) (*params.BackupsMetadataResult, error) {
-	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+func (m *MockAPIClient) Create(arg0 string, arg1, arg2 bool, arg3 string, arg4, arg5 bool) (*params.BackupsMetadataResult, error) {
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(*params.BackupsMetadataResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create
-func (mr *MockAPIClientMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIClient)(nil).Create), arg0, arg1, arg2)
+func (mr *MockAPIClientMockRecorder) Create(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIClient)(nil).Create), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // Download mocks base method
@@ -9,6 +9,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/juju/cmd"
@@ -25,6 +26,7 @@ import (
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	statebackups "github.com/juju/juju/state/backups"
 	jujutesting "github.com/juju/juju/testing"
 )
 
@@ -164,6 +166,16 @@ func (s *BaseBackupsSuite) checkArchive(c *gc.C) {
 	c.Check(string(data), gc.Equals, s.data)
 }
 
+// writeDefaultKey writes the private key where the backup commands
+// look for it by default.
+func writeDefaultKey(c *gc.C, key statebackups.PrivateKey) {
+	path := osenv.JujuXDGDataHomePath("backup-key")
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path, []byte(key.String()+"\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 // TODO (hml) 2018-05-01
 // Replace this fakeAPIClient with MockAPIClient for all tests.
 type fakeAPIClient struct {
@@ -171,10 +183,13 @@ type fakeAPIClient struct {
 	archive    io.ReadCloser
 	err        error
 
-	calls []string
-	args  []string
-	idArg string
-	notes string
+	calls       []string
+	args        []string
+	idArg       string
+	notes       string
+	encryptTo   string
+	stream      bool
+	incremental bool
 }
 
 func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
@@ -191,10 +206,13 @@ func (f *fakeAPIClient) CheckArgs(c *gc.C, args ...string) {
 	c.Check(f.args, jc.DeepEquals, args)
 }
 
-func (c *fakeAPIClient) Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error) {
+func (c *fakeAPIClient) Create(notes string, keepCopy, noDownload bool, encryptTo string, stream, incremental bool) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Create")
 	c.args = append(c.args, notes, fmt.Sprintf("%t", keepCopy), fmt.Sprintf("%t", noDownload))
 	c.notes = notes
+	c.encryptTo = encryptTo
+	c.stream = stream
+	c.incremental = incremental
 	if c.err != nil {
 		return nil, c.err
 	}
@@ -5,6 +5,7 @@ package backups
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
@@ -47,7 +48,7 @@ func (c *removeCommand) Info() *cmd.Info {
 func (c *removeCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.KeepLatest, "keep-latest", false,
-		"Remove all backups on remote storage except for the latest.")
+		"Remove all backups on remote storage except for the latest and those it follows.")
 }
 
 // Init implements Command.Init.
@@ -129,24 +130,33 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 	return errors.Trace(params.ErrorResults{results}.Combine())
 }
 
-// parseList returns a list of IDs to be removed and the one ID to be kept.
-// Keep the latest ID based on Started.
+// parseList returns a list of IDs to be removed and the IDs to be kept.
+// Keep the latest ID based on Started, along with any backups it
+// follows if it is incremental.
 func parseList(list []params.BackupsMetadataResult) ([]string, string, error) {
 	if len(list) == 0 {
 		return nil, "", nil
 	}
 	latest := list[0]
-	retList := set.NewStrings()
+	bases := map[string]string{latest.ID: latest.BaseID}
 	// Start looking for a new latest with the 2nd item in the slice.
 	for _, entry := range list[1:] {
+		bases[entry.ID] = entry.BaseID
 		if entry.Started.After(latest.Started) {
-			// Found a new latest, add the old one to the set
-			retList.Add(latest.ID)
 			latest = entry
-			continue
 		}
-		// Not the latest, add to the set
-		retList.Add(entry.ID)
 	}
-	return retList.SortedValues(), latest.ID, nil
+	kept := []string{latest.ID}
+	keep := set.NewStrings(latest.ID)
+	for id := latest.BaseID; id != "" && !keep.Contains(id); id = bases[id] {
+		kept = append(kept, id)
+		keep.Add(id)
+	}
+	retList := set.NewStrings()
+	for id := range bases {
+		if !keep.Contains(id) {
+			retList.Add(id)
+		}
+	}
+	return retList.SortedValues(), strings.Join(kept, ", "), nil
 }
@@ -171,3 +171,32 @@ func (s *removeSuite) TestRemoveFail(c *gc.C) {
 func bufferString(w io.Writer) string {
 	return w.(*bytes.Buffer).String()
 }
+
+func (s *removeSuite) TestRemoveWithKeepLatestIncremental(c *gc.C) {
+	ctrl, client := s.patch(c)
+	defer ctrl.Finish()
+	now := time.Now()
+
+	gomock.InOrder(
+		client.EXPECT().List().Return(
+			&params.BackupsListResult{
+				List: []params.BackupsMetadataResult{
+					{ID: "one", Started: now},
+					{ID: "two", Started: now.Add(time.Hour)},
+					{ID: "three", Started: now.Add(2 * time.Hour), BaseID: "two"},
+					{ID: "four", Started: now.Add(3 * time.Hour), BaseID: "three"},
+				},
+			}, nil,
+		),
+		client.EXPECT().Remove([]string{"one"}).Return(
+			[]params.ErrorResult{{}}, nil,
+		),
+		client.EXPECT().Close(),
+	)
+	ctx, err := cmdtesting.RunCommand(c, s.command, "--keep-latest")
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, `
+successfully removed: one
+kept: four, three, two
+`[1:])
+}
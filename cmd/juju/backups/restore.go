@@ -6,6 +6,7 @@ package backups
 import (
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/bootstrap"
 	"github.com/juju/juju/jujuclient"
+	statebackups "github.com/juju/juju/state/backups"
 )
 
 // NewRestoreCommand returns a command used to restore a backup.
@@ -38,8 +40,9 @@ type restoreCommand struct {
 	CommandBase
 	getModelStatusAPI func() (ModelStatusAPI, error)
 
-	Filename string
-	BackupId string
+	Filename    string
+	BackupId    string
+	DecryptWith string
 }
 
 // RestoreAPI is used to invoke various API calls.
@@ -74,6 +77,10 @@ machines will be added or removed during the restore process.
 Note: Extra care is needed to restore in an HA environment, please see
 https://jaas.ai/docs/controller-backups for more information.
 
+Encrypted backups are decrypted locally before being restored, using
+the private key in the default location or the file given with
+--decrypt-with.
+
 If the provided state cannot be restored, this command will fail with
 an explanation.
 `
@@ -93,6 +100,7 @@ func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "file", "", "Provide a file to be used as the backup")
 	f.StringVar(&c.BackupId, "id", "", "Provide the name of the backup to be restored")
+	f.StringVar(&c.DecryptWith, "decrypt-with", "", "Decrypt the backup with the private key in this file")
 }
 
 // Init is where the preconditions for this command can be checked.
@@ -180,6 +188,9 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		target = c.Filename
 		var err error
 		archive, meta, err = getArchive(c.Filename)
+		if errors.Cause(err) == errArchiveEncrypted {
+			archive, meta, err = c.decryptFile(c.Filename)
+		}
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -192,9 +203,24 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.BackupId != "" {
+		// Encrypted backups can only be restored once decrypted
+		// here, so they are downloaded and restored from the file.
+		info, err := client.Info(c.BackupId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if info.EncryptionScheme != "" {
+			if archive, meta, err = c.decryptDownload(client, c.BackupId); err != nil {
+				return errors.Trace(err)
+			}
+			defer archive.Close()
+		}
+	}
+
 	// We have a backup client, now use the relevant method
 	// to restore the backup.
-	if c.Filename != "" {
+	if archive != nil {
 		err = client.RestoreReader(archive, meta, c.newClient)
 	} else {
 		err = client.Restore(c.BackupId, c.newClient)
@@ -205,3 +231,46 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	fmt.Fprintf(ctx.Stdout, "restore from %q completed\n", target)
 	return nil
 }
+
+// privateKey returns the key used to decrypt encrypted backups.
+func (c *restoreCommand) privateKey() (statebackups.PrivateKey, error) {
+	key, err := readPrivateKey(c.DecryptWith)
+	if err != nil {
+		return statebackups.PrivateKey{}, errors.Trace(err)
+	}
+	if key == nil {
+		return statebackups.PrivateKey{}, errors.Errorf(
+			"backup is encrypted and no private key was found, use --decrypt-with to specify one")
+	}
+	return *key, nil
+}
+
+// decryptFile returns the decrypted contents of the encrypted archive
+// file.
+func (c *restoreCommand) decryptFile(filename string) (ArchiveReader, *params.BackupsMetadataResult, error) {
+	key, err := c.privateKey()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	defer f.Close()
+	return getDecryptedArchive(f, key)
+}
+
+// decryptDownload downloads the encrypted backup and returns its
+// decrypted contents.
+func (c *restoreCommand) decryptDownload(client APIClient, id string) (ArchiveReader, *params.BackupsMetadataResult, error) {
+	key, err := c.privateKey()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	r, err := client.Download(id)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	defer r.Close()
+	return getDecryptedArchive(r, key)
+}
@@ -4,7 +4,10 @@
 package backups_test
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
 
 	"github.com/golang/mock/gomock"
@@ -23,6 +26,7 @@ import (
 	"github.com/juju/juju/jujuclient"
 	_ "github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/lxd"
+	statebackups "github.com/juju/juju/state/backups"
 	"github.com/juju/juju/testing"
 )
 
@@ -190,6 +194,7 @@ func (s *restoreSuite) TestRestoreFromBackupId(c *gc.C) {
 	defer ctlr.Finish()
 	expectModelStatus(modelStatusClient)
 	gomock.InOrder(
+		apiClient.EXPECT().Info("an_id").Return(&params.BackupsMetadataResult{ID: "an_id"}, nil),
 		apiClient.EXPECT().Restore("an_id", gomock.Any()).Return(
 			nil,
 		),
@@ -206,6 +211,7 @@ func (s *restoreSuite) TestRestoreFromBackupIdFail(c *gc.C) {
 	defer ctlr.Finish()
 	expectModelStatus(modelStatusClient)
 	gomock.InOrder(
+		apiClient.EXPECT().Info("an_id").Return(&params.BackupsMetadataResult{ID: "an_id"}, nil),
 		apiClient.EXPECT().Restore("an_id", gomock.Any()).Return(
 			errors.New("restore failed"),
 		),
@@ -262,3 +268,96 @@ func (s *restoreSuite) TestRestoreFromBackupHAFail(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id")
 	c.Assert(err, gc.ErrorMatches, "unable to restore backup in HA configuration.  For help see https://jaas.ai/docs/controller-backups")
 }
+
+func (s *restoreSuite) encrypt(c *gc.C) (statebackups.PrivateKey, []byte) {
+	public, private, err := statebackups.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	var buf bytes.Buffer
+	w, err := statebackups.NewEncryptingWriter(&buf, public)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write([]byte(s.data))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	return private, buf.Bytes()
+}
+
+func (s *restoreSuite) patchEncryptedArchive(c *gc.C, archive backups.ArchiveReader) *[]string {
+	var read []string
+	s.PatchValue(backups.GetArchive,
+		func(filename string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+			data, err := ioutil.ReadFile(filename)
+			c.Assert(err, jc.ErrorIsNil)
+			read = append(read, string(data))
+			if statebackups.IsEncrypted(bufio.NewReader(bytes.NewReader(data))) {
+				return nil, nil, backups.ErrArchiveEncrypted
+			}
+			return archive, &params.BackupsMetadataResult{}, nil
+		},
+	)
+	return &read
+}
+
+func (s *restoreSuite) TestRestoreEncryptedFile(c *gc.C) {
+	ctlr, apiClient, archiveReader, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	gomock.InOrder(
+		apiClient.EXPECT().RestoreReader(gomock.Any(), &params.BackupsMetadataResult{}, gomock.Any()).Return(nil),
+		apiClient.EXPECT().Close(),
+		archiveReader.EXPECT().Close(),
+	)
+	read := s.patchEncryptedArchive(c, archiveReader)
+
+	key, data := s.encrypt(c)
+	writeDefaultKey(c, key)
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz.enc")
+	err := ioutil.WriteFile(filename, data, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--file", filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*read, gc.HasLen, 2)
+	c.Check((*read)[1], gc.Equals, s.data)
+}
+
+func (s *restoreSuite) TestRestoreEncryptedFileNoKey(c *gc.C) {
+	ctlr, _, archiveReader, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	s.patchEncryptedArchive(c, archiveReader)
+
+	_, data := s.encrypt(c)
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz.enc")
+	err := ioutil.WriteFile(filename, data, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--file", filename)
+	c.Assert(err, gc.ErrorMatches, "backup is encrypted and no private key was found, use --decrypt-with to specify one")
+}
+
+func (s *restoreSuite) TestRestoreEncryptedBackupId(c *gc.C) {
+	ctlr, apiClient, archiveReader, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	read := s.patchEncryptedArchive(c, archiveReader)
+
+	key, data := s.encrypt(c)
+	keyFile := filepath.Join(c.MkDir(), "key")
+	err := ioutil.WriteFile(keyFile, []byte(key.String()), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	gomock.InOrder(
+		apiClient.EXPECT().Info("an_id").Return(&params.BackupsMetadataResult{
+			ID:               "an_id",
+			EncryptionScheme: statebackups.EncryptionScheme,
+		}, nil),
+		apiClient.EXPECT().Download("an_id").Return(ioutil.NopCloser(bytes.NewReader(data)), nil),
+		apiClient.EXPECT().RestoreReader(gomock.Any(), &params.BackupsMetadataResult{}, gomock.Any()).Return(nil),
+		archiveReader.EXPECT().Close(),
+		apiClient.EXPECT().Close(),
+	)
+
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id", "--decrypt-with", keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "restore from \"an_id\" completed\n")
+	c.Assert(*read, gc.DeepEquals, []string{s.data})
+}
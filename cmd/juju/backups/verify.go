@@ -0,0 +1,182 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	apiserverbackups "github.com/juju/juju/apiserver/facades/client/backups"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/state/backups"
+)
+
+const verifyDoc = `
+verify-backup checks that a backup archive is complete and could be
+restored, without restoring it onto a controller.
+
+The argument is either a local archive file or the ID of a backup
+stored on the controller, which is downloaded to be checked. For a
+stored backup the archive's size and checksum are also compared with
+those recorded when it was made.
+
+The checks made are:
+ - the archive can be read, decrypting it if it is encrypted;
+ - the archive holds valid backup metadata;
+ - the archive's size and checksum match those recorded;
+ - the database dump holds the juju database, made of whole documents;
+ - the archive holds the controller machine's agent config.
+
+With --restore the database dump is also restored into a scratch mongod
+started on this machine, and each restored collection is validated.
+This needs mongod and mongorestore to be installed locally.
+
+Encrypted archives are decrypted with the private key in the default
+location, or the file given with --decrypt-with.
+
+A summary of the checks is printed, and the command fails if any check
+failed.
+
+Examples:
+    juju verify-backup juju-backup-20200405-123456.tar.gz
+    juju verify-backup 20200405-123456.dc7b9c77-1c84-4f40-87c0-4e0a7a8d2b84
+    juju verify-backup --restore juju-backup-20200405-123456.tar.gz
+
+See also:
+    create-backup
+    download-backup
+    restore-backup
+`
+
+var verifyArchive = backups.Verify
+
+// NewVerifyCommand returns a command used to verify a backup archive.
+func NewVerifyCommand() cmd.Command {
+	return modelcmd.Wrap(&verifyCommand{})
+}
+
+// verifyCommand is the sub-command for verifying a backup archive.
+type verifyCommand struct {
+	CommandBase
+	// Source is the archive file or backup ID to verify.
+	Source string
+	// DecryptWith is the file containing the private key used to
+	// decrypt the archive.
+	DecryptWith string
+	// Restore indicates whether to restore the database dump into a
+	// scratch mongod to check it.
+	Restore bool
+}
+
+// Info implements Command.Info.
+func (c *verifyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "verify-backup",
+		Args:    "<file|ID>",
+		Purpose: "Check that a backup archive could be restored.",
+		Doc:     verifyDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *verifyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.DecryptWith, "decrypt-with", "", "Decrypt the archive with the private key in this file")
+	f.BoolVar(&c.Restore, "restore", false, "Restore the database dump into a scratch local mongod to check it")
+}
+
+// Init implements Command.Init.
+func (c *verifyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing archive file or backup ID")
+	}
+	source, args := args[0], args[1:]
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	c.Source = source
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *verifyCommand) Run(ctx *cmd.Context) error {
+	key, err := readPrivateKey(c.DecryptWith)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	args := backups.VerifyArgs{
+		Key:     key,
+		Restore: c.Restore,
+	}
+
+	var archive io.ReadCloser
+	if _, err := os.Stat(c.Source); err == nil {
+		if archive, err = os.Open(c.Source); err != nil {
+			return errors.Annotate(err, "opening archive file")
+		}
+	} else {
+		if archive, args.Expected, err = c.download(c.Source); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	defer archive.Close()
+
+	report, err := verifyArchive(archive, args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := printVerifyReport(ctx.Stdout, report); err != nil {
+		return errors.Trace(err)
+	}
+	if !report.Passed() {
+		return errors.New("backup verification failed")
+	}
+	return nil
+}
+
+// download fetches the stored backup with the given ID, along with the
+// metadata recorded when it was made.
+func (c *verifyCommand) download(id string) (io.ReadCloser, *backups.Metadata, error) {
+	if err := c.validateIaasController(c.Info().Name); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.Info(id)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	archive, err := client.Download(id)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return archive, apiserverbackups.MetadataFromResult(*result), nil
+}
+
+func printVerifyReport(writer io.Writer, report *backups.VerifyReport) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Check", "Result", "Detail")
+	for _, check := range report.Checks {
+		switch {
+		case check.Err != nil:
+			w.Println(check.Name, "failed", check.Err.Error())
+		case check.Skipped != "":
+			w.Println(check.Name, "skipped", check.Skipped)
+		default:
+			w.Println(check.Name, "passed", "")
+		}
+	}
+	return tw.Flush()
+}
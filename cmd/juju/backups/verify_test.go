@@ -0,0 +1,129 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+type verifySuite struct {
+	BaseBackupsSuite
+	wrappedCommand cmd.Command
+
+	verified string
+	args     statebackups.VerifyArgs
+	report   *statebackups.VerifyReport
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.wrappedCommand = backups.NewVerifyCommandForTest(s.store)
+	s.report = &statebackups.VerifyReport{
+		Checks: []statebackups.VerifyCheck{
+			{Name: statebackups.CheckArchive},
+			{Name: statebackups.CheckChecksum, Skipped: "no stored checksum to compare with"},
+		},
+	}
+	s.PatchValue(backups.VerifyArchive, func(archive io.Reader, args statebackups.VerifyArgs) (*statebackups.VerifyReport, error) {
+		data, err := ioutil.ReadAll(archive)
+		c.Assert(err, jc.ErrorIsNil)
+		s.verified = string(data)
+		s.args = args
+		return s.report, nil
+	})
+}
+
+func (s *verifySuite) TestArgParsing(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand)
+	c.Check(err, gc.ErrorMatches, "missing archive file or backup ID")
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, "a", "b")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+}
+
+func (s *verifySuite) TestFile(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz")
+	err := ioutil.WriteFile(filename, []byte(s.data), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	client := s.setSuccess()
+
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, filename, "--restore")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c)
+	c.Check(s.verified, gc.Equals, s.data)
+	c.Check(s.args.Expected, gc.IsNil)
+	c.Check(s.args.Key, gc.IsNil)
+	c.Check(s.args.Restore, jc.IsTrue)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Check     Result   Detail\n"+
+		"archive   passed   \n"+
+		"checksum  skipped  no stored checksum to compare with\n")
+}
+
+func (s *verifySuite) TestID(c *gc.C) {
+	s.metaresult.Checksum = "checksum"
+	s.metaresult.ChecksumFormat = "SHA-1, base64 encoded"
+	s.metaresult.Size = 25
+	client := s.setDownload()
+
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "Info", "Download")
+	client.CheckArgs(c, s.metaresult.ID, s.metaresult.ID)
+	c.Check(s.verified, gc.Equals, s.data)
+	c.Assert(s.args.Expected, gc.NotNil)
+	c.Check(s.args.Expected.Checksum(), gc.Equals, "checksum")
+	c.Check(s.args.Expected.Size(), gc.Equals, int64(25))
+	c.Check(s.args.Restore, jc.IsFalse)
+}
+
+func (s *verifySuite) TestDecryptWith(c *gc.C) {
+	_, private, err := statebackups.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	keyFile := filepath.Join(c.MkDir(), "key")
+	err = ioutil.WriteFile(keyFile, []byte(private.String()), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setDownload()
+
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID, "--decrypt-with", keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.args.Key, gc.NotNil)
+	c.Check(*s.args.Key, jc.DeepEquals, private)
+}
+
+func (s *verifySuite) TestFailed(c *gc.C) {
+	s.report.Checks = append(s.report.Checks, statebackups.VerifyCheck{
+		Name: statebackups.CheckDBDump,
+		Err:  errors.New("juju database not dumped"),
+	})
+	s.setDownload()
+
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID)
+	c.Check(err, gc.ErrorMatches, "backup verification failed")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Check          Result   Detail\n"+
+		"archive        passed   \n"+
+		"checksum       skipped  no stored checksum to compare with\n"+
+		"database dump  failed   juju database not dumped\n")
+}
+
+func (s *verifySuite) TestDownloadError(c *gc.C) {
+	s.setFailure("failed!")
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID)
+	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
+}
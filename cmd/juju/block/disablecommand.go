@@ -8,6 +8,8 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
 
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -28,10 +30,35 @@ type disableCommand struct {
 	apiFunc func(newAPIRoot) (blockClientAPI, error)
 	target  string
 	message string
+
+	entityFlags
+	entity     names.Tag
+	operations []string
+}
+
+// SetFlags implements Command.
+func (c *disableCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.entityFlags.addFlags(f, "Disable")
 }
 
 // Init implements Command.
 func (c *disableCommand) Init(args []string) error {
+	entity, err := c.entityFlags.tag()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if entity != nil {
+		if len(args) < 1 {
+			return errors.Errorf("missing operations (%s)", validOperations())
+		}
+		c.entity = entity
+		if c.operations, err = parseOperations(args[0]); err != nil {
+			return errors.Trace(err)
+		}
+		c.message = strings.Join(args[1:], " ")
+		return nil
+	}
 	if len(args) < 1 {
 		return errors.Errorf("missing command set (%s)", validTargets)
 	}
@@ -49,7 +76,7 @@ func (c *disableCommand) Init(args []string) error {
 func (c *disableCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "disable-command",
-		Args:    "<command set> [message...]",
+		Args:    "<command set>|<operations> [message...]",
 		Purpose: "Disable commands for the model.",
 		Doc:     disableCommandDoc,
 	})
@@ -58,6 +85,7 @@ func (c *disableCommand) Info() *cmd.Info {
 type blockClientAPI interface {
 	Close() error
 	SwitchBlockOn(blockType, msg string) error
+	SwitchEntityBlockOn(tag names.Tag, operations []string, msg string) error
 }
 
 // Run implements Command.Run
@@ -68,6 +96,9 @@ func (c *disableCommand) Run(ctx *cmd.Context) error {
 	}
 	defer api.Close()
 
+	if c.entity != nil {
+		return api.SwitchEntityBlockOn(c.entity, c.operations, c.message)
+	}
 	return api.SwitchBlockOn(c.target, c.message)
 }
 
@@ -79,7 +110,7 @@ This is done by disabling certain sets of commands from successful execution.
 Disabled commands must be manually enabled to proceed.
 
 Some commands offer a --force option that can be used to bypass the disabling.
-` + commandSets + `
+` + commandSets + entityOperations + `
 Examples:
     # To prevent the model from being destroyed:
     juju disable-command destroy-model "Check with SA before destruction."
@@ -90,6 +121,13 @@ Examples:
     # To prevent changes to the model:
     juju disable-command all "Model locked down"
 
+    # To freeze the configuration of an application and prevent its removal,
+    # while the rest of the model remains open to changes:
+    juju disable-command --application mysql config,remove "Production database"
+
+    # To prevent all changes to a machine:
+    juju disable-command --machine 0 all
+
 See also:
     disabled-commands
     enable-command
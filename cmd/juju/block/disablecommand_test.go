@@ -7,6 +7,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -44,6 +45,22 @@ func (s *disableCommandSuite) TestInit(c *gc.C) {
 			args: []string{"remove-object"},
 		}, {
 			args: []string{"all", "lots", "of", "args"},
+		}, {
+			args: []string{"--application", "mysql"},
+			err:  "missing operations (all, config, refresh, scale, remove)",
+		}, {
+			args: []string{"--application", "mysql", "deploy"},
+			err:  `bad operation "deploy", valid options: all, config, refresh, scale, remove`,
+		}, {
+			args: []string{"--application", "mysql", "--machine", "0", "all"},
+			err:  "only one of --application, --machine or --relation may be specified",
+		}, {
+			args: []string{"--machine", "foo", "all"},
+			err:  `machine "foo" not valid`,
+		}, {
+			args: []string{"--application", "mysql", "config,remove", "message"},
+		}, {
+			args: []string{"--relation", "wordpress:db mysql:server", "remove"},
 		},
 	} {
 		cmd := s.disableCommand(&mockBlockClient{}, nil)
@@ -89,6 +106,37 @@ func (s *disableCommandSuite) TestRun(c *gc.C) {
 	}
 }
 
+func (s *disableCommandSuite) TestRunEntity(c *gc.C) {
+	for _, test := range []struct {
+		args       []string
+		tag        names.Tag
+		operations []string
+		message    string
+	}{{
+		args:       []string{"--application", "mysql", "config,remove", "production", "database"},
+		tag:        names.NewApplicationTag("mysql"),
+		operations: []string{"config", "remove"},
+		message:    "production database",
+	}, {
+		args:       []string{"--machine", "0", "all"},
+		tag:        names.NewMachineTag("0"),
+		operations: []string{"config", "refresh", "scale", "remove"},
+	}, {
+		args:       []string{"--relation", "wordpress:db mysql:server", "remove,remove"},
+		tag:        names.NewRelationTag("wordpress:db mysql:server"),
+		operations: []string{"remove"},
+	}} {
+		mockClient := &mockBlockClient{}
+		cmd := s.disableCommand(mockClient, nil)
+		_, err := cmdtesting.RunCommand(c, cmd, test.args...)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(mockClient.tag, gc.Equals, test.tag)
+		c.Check(mockClient.operations, jc.DeepEquals, test.operations)
+		c.Check(mockClient.message, gc.Equals, test.message)
+		c.Check(mockClient.blockType, gc.Equals, "")
+	}
+}
+
 func (s *disableCommandSuite) TestRunError(c *gc.C) {
 	mockClient := &mockBlockClient{err: errors.New("boom")}
 	cmd := s.disableCommand(mockClient, nil)
@@ -97,9 +145,11 @@ func (s *disableCommandSuite) TestRunError(c *gc.C) {
 }
 
 type mockBlockClient struct {
-	blockType string
-	message   string
-	err       error
+	blockType  string
+	tag        names.Tag
+	operations []string
+	message    string
+	err        error
 }
 
 func (c *mockBlockClient) Close() error {
@@ -111,3 +161,10 @@ func (c *mockBlockClient) SwitchBlockOn(blockType, message string) error {
 	c.message = message
 	return c.err
 }
+
+func (c *mockBlockClient) SwitchEntityBlockOn(tag names.Tag, operations []string, message string) error {
+	c.tag = tag
+	c.operations = operations
+	c.message = message
+	return c.err
+}
@@ -62,3 +62,30 @@ Commands that can be disabled are grouped based on logical operations as follows
     upgrade-charm
     upgrade-model
 	`
+
+const entityOperations = `
+With --application, --machine or --relation, operations are disabled on that
+entity alone and the rest of the model is unaffected. Instead of a command set,
+a comma separated list of the following operations is given, or "all":
+
+"config" prevents changes to configuration, constraints, bindings and exposure:
+    config
+    expose
+    set-constraints
+    suspend-relation
+    resume-relation
+    unexpose
+
+"refresh" prevents charm upgrades:
+    upgrade-charm
+
+"scale" prevents adding and removing units:
+    add-unit
+    remove-unit
+    scale-application
+
+"remove" prevents removal of the entity:
+    remove-application
+    remove-machine
+    remove-relation
+`
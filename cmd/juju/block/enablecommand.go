@@ -6,6 +6,8 @@ package block
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
 
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -26,10 +28,27 @@ type enableCommand struct {
 	modelcmd.ModelCommandBase
 	apiFunc func(newAPIRoot) (unblockClientAPI, error)
 	target  string
+
+	entityFlags
+	entity names.Tag
+}
+
+// SetFlags implements Command.
+func (c *enableCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.entityFlags.addFlags(f, "Enable")
 }
 
 // Init implements Command.
 func (c *enableCommand) Init(args []string) error {
+	entity, err := c.entityFlags.tag()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if entity != nil {
+		c.entity = entity
+		return cmd.CheckEmpty(args)
+	}
 	if len(args) < 1 {
 		return errors.Errorf("missing command set (%s)", validTargets)
 	}
@@ -46,7 +65,7 @@ func (c *enableCommand) Init(args []string) error {
 func (c *enableCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "enable-command",
-		Args:    "<command set>",
+		Args:    "[<command set>]",
 		Purpose: "Enable commands that had been previously disabled.",
 		Doc:     enableDoc,
 	})
@@ -56,6 +75,7 @@ func (c *enableCommand) Info() *cmd.Info {
 type unblockClientAPI interface {
 	Close() error
 	SwitchBlockOff(blockType string) error
+	SwitchEntityBlockOff(tag names.Tag) error
 }
 
 // Run implements Command.
@@ -66,6 +86,9 @@ func (c *enableCommand) Run(_ *cmd.Context) error {
 	}
 	defer api.Close()
 
+	if c.entity != nil {
+		return api.SwitchEntityBlockOff(c.entity)
+	}
 	return api.SwitchBlockOff(c.target)
 }
 
@@ -77,7 +100,7 @@ This is done by disabling certain sets of commands from successful execution.
 Disabled commands must be manually enabled to proceed.
 
 Some commands offer a --force option that can be used to bypass a block.
-` + commandSets + `
+` + commandSets + entityOperations + `
 Examples:
     # To allow the model to be destroyed:
     juju enable-command destroy-model
@@ -88,6 +111,9 @@ Examples:
     # To allow changes to the model:
     juju enable-command all
 
+    # To lift all disabled operations on an application:
+    juju enable-command --application mysql
+
 See also:
     disable-command
     disabled-commands
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -46,6 +47,14 @@ func (s *enableCommandSuite) TestInit(c *gc.C) {
 		}, {
 			args: []string{"all", "extra"},
 			err:  `unrecognized args: ["extra"]`,
+		}, {
+			args: []string{"--application", "mysql"},
+		}, {
+			args: []string{"--application", "mysql", "all"},
+			err:  `unrecognized args: ["all"]`,
+		}, {
+			args: []string{"--application", "mysql", "--relation", "wordpress:db mysql:server"},
+			err:  "only one of --application, --machine or --relation may be specified",
 		},
 	} {
 		cmd := s.enableCommand(nil, nil)
@@ -86,6 +95,15 @@ func (s *enableCommandSuite) TestRun(c *gc.C) {
 	}
 }
 
+func (s *enableCommandSuite) TestRunEntity(c *gc.C) {
+	mockClient := &mockUnblockClient{}
+	cmd := s.enableCommand(mockClient, nil)
+	_, err := cmdtesting.RunCommand(c, cmd, "--application", "mysql")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(mockClient.tag, gc.Equals, names.NewApplicationTag("mysql"))
+	c.Check(mockClient.blockType, gc.Equals, "")
+}
+
 func (s *enableCommandSuite) TestRunError(c *gc.C) {
 	mockClient := &mockUnblockClient{err: errors.New("boom")}
 	cmd := s.enableCommand(mockClient, nil)
@@ -95,6 +113,7 @@ func (s *enableCommandSuite) TestRunError(c *gc.C) {
 
 type mockUnblockClient struct {
 	blockType string
+	tag       names.Tag
 	err       error
 }
 
@@ -106,3 +125,8 @@ func (c *mockUnblockClient) SwitchBlockOff(blockType string) error {
 	c.blockType = blockType
 	return c.err
 }
+
+func (c *mockUnblockClient) SwitchEntityBlockOff(tag names.Tag) error {
+	c.tag = tag
+	return c.err
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package block
+
+import (
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/core/model"
+)
+
+const opAll = "all"
+
+// entityFlags holds the flags that scope enable-command and
+// disable-command to a single application, machine or relation.
+type entityFlags struct {
+	application string
+	machine     string
+	relation    string
+}
+
+func (f *entityFlags) addFlags(fs *gnuflag.FlagSet, verb string) {
+	fs.StringVar(&f.application, "application", "", verb+" operations on this application only")
+	fs.StringVar(&f.machine, "machine", "", verb+" operations on this machine only")
+	fs.StringVar(&f.relation, "relation", "", verb+` operations on this relation only, given as "<application>:<endpoint> <application>:<endpoint>"`)
+}
+
+// tag returns the tag of the entity selected by the flags, or nil if
+// none was given.
+func (f *entityFlags) tag() (names.Tag, error) {
+	var tags []names.Tag
+	if f.application != "" {
+		if !names.IsValidApplication(f.application) {
+			return nil, errors.NotValidf("application name %q", f.application)
+		}
+		tags = append(tags, names.NewApplicationTag(f.application))
+	}
+	if f.machine != "" {
+		if !names.IsValidMachine(f.machine) {
+			return nil, errors.NotValidf("machine %q", f.machine)
+		}
+		tags = append(tags, names.NewMachineTag(f.machine))
+	}
+	if f.relation != "" {
+		if !names.IsValidRelation(f.relation) {
+			return nil, errors.NotValidf("relation %q", f.relation)
+		}
+		tags = append(tags, names.NewRelationTag(f.relation))
+	}
+	switch len(tags) {
+	case 0:
+		return nil, nil
+	case 1:
+		return tags[0], nil
+	}
+	return nil, errors.New("only one of --application, --machine or --relation may be specified")
+}
+
+// validOperations lists the operations that may be blocked on an entity.
+func validOperations() string {
+	ops := []string{opAll}
+	for _, op := range model.AllBlockOperations() {
+		ops = append(ops, string(op))
+	}
+	return strings.Join(ops, ", ")
+}
+
+// parseOperations parses a comma separated list of operations to block
+// on an entity. "all" stands for every operation.
+func parseOperations(arg string) ([]string, error) {
+	if arg == opAll {
+		var all []string
+		for _, op := range model.AllBlockOperations() {
+			all = append(all, string(op))
+		}
+		return all, nil
+	}
+	var ops []string
+	seen := set.NewStrings()
+	for _, op := range strings.Split(arg, ",") {
+		op = strings.TrimSpace(op)
+		if err := model.BlockOperation(op).Validate(); err != nil {
+			return nil, errors.Errorf("bad operation %q, valid options: %s", op, validOperations())
+		}
+		if !seen.Contains(op) {
+			seen.Add(op)
+			ops = append(ops, op)
+		}
+	}
+	return ops, nil
+}
@@ -151,14 +151,28 @@ type controllerListAPI interface {
 // BlockInfo defines the serialization behaviour of the block information.
 type BlockInfo struct {
 	Commands string `yaml:"command-set" json:"command-set"`
+	Scope    string `yaml:"scope,omitempty" json:"scope,omitempty"`
 	Message  string `yaml:"message,omitempty" json:"message,omitempty"`
 }
 
 // formatBlockInfo takes a set of Block and creates a
-// mapping to information structures.
+// mapping to information structures. Blocks on a single
+// entity list the disabled operations as the command set.
 func formatBlockInfo(all []params.Block) []BlockInfo {
 	output := make([]BlockInfo, len(all))
 	for i, one := range all {
+		if len(one.Operations) > 0 {
+			scope := one.Tag
+			if tag, err := names.ParseTag(one.Tag); err == nil {
+				scope = names.ReadableString(tag)
+			}
+			output[i] = BlockInfo{
+				Commands: strings.Join(one.Operations, ","),
+				Scope:    scope,
+				Message:  one.Message,
+			}
+			continue
+		}
 		set, ok := toCmdValue[one.Type]
 		if !ok {
 			set = "<unknown>"
@@ -183,11 +197,33 @@ func formatBlocks(writer io.Writer, value interface{}) error {
 		return nil
 	}
 
+	// Only show the scope column when some blocks
+	// apply to a single entity.
+	var scoped bool
+	for _, info := range blocks {
+		if info.Scope != "" {
+			scoped = true
+			break
+		}
+	}
+
 	tw := output.TabWriter(writer)
 	w := output.Wrapper{tw}
-	w.Println("Disabled commands", "Message")
+	if scoped {
+		w.Println("Disabled commands", "Scope", "Message")
+	} else {
+		w.Println("Disabled commands", "Message")
+	}
 	for _, info := range blocks {
-		w.Println(info.Commands, info.Message)
+		if !scoped {
+			w.Println(info.Commands, info.Message)
+			continue
+		}
+		scope := info.Scope
+		if scope == "" {
+			scope = "model"
+		}
+		w.Println(info.Commands, scope, info.Message)
 	}
 	tw.Flush()
 
@@ -106,6 +106,37 @@ func (s *listCommandSuite) TestListYAML(c *gc.C) {
 	)
 }
 
+func (s *listCommandSuite) TestListEntityBlocks(c *gc.C) {
+	api := s.mock()
+	api.blocks = append(api.blocks, params.Block{
+		Tag:        "application-mysql",
+		Type:       "BlockChange",
+		Message:    "production database",
+		Operations: []string{"config", "remove"},
+	})
+	ctx, err := cmdtesting.RunCommand(c, s.listCommand(api, nil))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Disabled commands  Scope              Message\n"+
+		"destroy-model      model              Sysadmins in control.\n"+
+		"all                model              just temporary\n"+
+		"config,remove      application mysql  production database\n"+
+		"\n",
+	)
+
+	ctx, err = cmdtesting.RunCommand(c, s.listCommand(api, nil), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"- command-set: destroy-model\n"+
+		"  message: Sysadmins in control.\n"+
+		"- command-set: all\n"+
+		"  message: just temporary\n"+
+		"- command-set: config,remove\n"+
+		"  scope: application mysql\n"+
+		"  message: production database\n",
+	)
+}
+
 func (s *listCommandSuite) TestListJSONEmpty(c *gc.C) {
 	ctx, err := cmdtesting.RunCommand(c, s.listCommand(&mockListClient{}, nil), "--format", "json")
 	c.Assert(err, jc.ErrorIsNil)
@@ -5,9 +5,12 @@ package block
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/api"
 	apiblock "github.com/juju/juju/api/block"
@@ -50,6 +53,22 @@ func operationFromType(blockType string) string {
 	return value
 }
 
+// BlockTypeForCommandSet returns the API block type that disables the
+// given command set, as named by disable-command.
+func BlockTypeForCommandSet(commandSet string) (string, error) {
+	value, ok := toAPIValue[commandSet]
+	if !ok {
+		return "", errors.Errorf("%q is not a valid command set, expected one of: %s", commandSet, validTargets)
+	}
+	return value, nil
+}
+
+// CommandSetForBlockType returns the name of the command set, as used
+// by disable-command, that the API block type disables.
+func CommandSetForBlockType(blockType string) string {
+	return operationFromType(blockType)
+}
+
 type newAPIRoot interface {
 	NewAPIRoot() (api.Connection, error)
 }
@@ -93,13 +112,48 @@ func ProcessBlockedError(err error, block Block) error {
 		return nil
 	}
 	if params.IsCodeOperationBlocked(err) {
-		msg := fmt.Sprintf("%v\n%v", err, blockedMessages[block])
+		advice := blockedMessages[block]
+		if entityAdvice, ok := entityBlockedMessage(err); ok {
+			advice = entityAdvice
+		}
+		msg := fmt.Sprintf("%v\n%v", err, advice)
 		logger.Infof(msg)
 		return errors.Errorf(msg)
 	}
 	return err
 }
 
+// entityBlockedMessage returns advice on lifting the block on a single
+// entity, if the error was caused by one.
+func entityBlockedMessage(err error) (string, bool) {
+	infoErr, ok := errors.Cause(err).(interface {
+		UnmarshalInfo(interface{}) error
+	})
+	if !ok {
+		return "", false
+	}
+	var info params.OperationBlockedErrorInfo
+	if err := infoErr.UnmarshalInfo(&info); err != nil || info.Tag == "" {
+		return "", false
+	}
+	tag, err := names.ParseTag(info.Tag)
+	if err != nil {
+		return "", false
+	}
+	id := tag.Id()
+	if strings.Contains(id, " ") {
+		id = strconv.Quote(id)
+	}
+	return fmt.Sprintf(entityMsg, info.Operation, names.ReadableString(tag), tag.Kind(), id), true
+}
+
+var entityMsg = `
+To enable %s operations on %s, run
+
+    juju enable-command --%s %s
+
+`
+
 var removeMsg = `
 All operations that remove machines, applications, units or
 relations have been disabled for the current model.
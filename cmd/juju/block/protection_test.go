@@ -0,0 +1,66 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package block_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&protectionSuite{})
+
+type protectionSuite struct {
+	testing.BaseSuite
+}
+
+func (s *protectionSuite) TestProcessBlockedErrorNil(c *gc.C) {
+	c.Assert(block.ProcessBlockedError(nil, block.BlockChange), jc.ErrorIsNil)
+}
+
+func (s *protectionSuite) TestProcessBlockedErrorNotBlocked(c *gc.C) {
+	err := errors.New("boom")
+	c.Assert(block.ProcessBlockedError(err, block.BlockChange), gc.Equals, err)
+}
+
+func (s *protectionSuite) TestProcessBlockedErrorModel(c *gc.C) {
+	err := &params.Error{Code: params.CodeOperationBlocked, Message: "locked down"}
+	err2 := block.ProcessBlockedError(errors.Trace(err), block.BlockRemove)
+	c.Assert(err2, gc.ErrorMatches, `(?s)locked down\n.*juju enable-command remove-object.*`)
+}
+
+func (s *protectionSuite) TestProcessBlockedErrorEntity(c *gc.C) {
+	err := &params.Error{
+		Code:    params.CodeOperationBlocked,
+		Message: "config operations on application mysql have been blocked",
+		Info: params.OperationBlockedErrorInfo{
+			Tag:       "application-mysql",
+			Operation: "config",
+		}.AsMap(),
+	}
+	err2 := block.ProcessBlockedError(errors.Trace(err), block.BlockChange)
+	c.Assert(err2, gc.ErrorMatches, `(?s)config operations on application mysql have been blocked
+
+To enable config operations on application mysql, run
+
+    juju enable-command --application mysql
+.*`)
+}
+
+func (s *protectionSuite) TestProcessBlockedErrorRelation(c *gc.C) {
+	err := &params.Error{
+		Code:    params.CodeOperationBlocked,
+		Message: "blocked",
+		Info: params.OperationBlockedErrorInfo{
+			Tag:       "relation-wordpress.db#mysql.server",
+			Operation: "remove",
+		}.AsMap(),
+	}
+	err2 := block.ProcessBlockedError(err, block.BlockRemove)
+	c.Assert(err2, gc.ErrorMatches, `(?s).*juju enable-command --relation "wordpress:db mysql:server".*`)
+}
@@ -17,11 +17,13 @@ import (
 	"github.com/juju/utils"
 
 	actionapi "github.com/juju/juju/api/action"
+	"github.com/juju/juju/api/annotations"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	coreannotations "github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/jujuclient"
 )
@@ -60,6 +62,7 @@ type execCommand struct {
 	machines     []string
 	applications []string
 	units        []string
+	selector     string
 	commands     string
 	timeAfter    func(time.Duration) <-chan time.Time
 }
@@ -102,6 +105,11 @@ the unit.
 in the model.  If you specify --all you cannot provide additional
 targets.
 
+--selector runs the command on the machines, applications and units whose
+annotations match all of the given key=value pairs, for example
+  --selector team=payments,env=prod
+If you specify --selector you cannot provide additional targets.
+
 Since juju exec creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
 
@@ -144,6 +152,7 @@ func (c *execCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewStringsValue(nil, &c.applications), "application", "")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "u", "One or more unit ids")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "")
+	f.StringVar(&c.selector, "selector", "", "Run the commands on the targets whose annotations match key=value[,key=value...]")
 }
 
 func (c *execCommand) Init(args []string) error {
@@ -162,6 +171,9 @@ func (c *execCommand) Init(args []string) error {
 	}
 
 	if c.all {
+		if c.selector != "" {
+			return errors.Errorf("You cannot specify --all and --selector")
+		}
 		if len(c.machines) != 0 {
 			return errors.Errorf("You cannot specify --all and individual machines")
 		}
@@ -171,9 +183,16 @@ func (c *execCommand) Init(args []string) error {
 		if len(c.units) != 0 {
 			return errors.Errorf("You cannot specify --all and individual units")
 		}
+	} else if c.selector != "" {
+		if len(c.machines) != 0 || len(c.applications) != 0 || len(c.units) != 0 {
+			return errors.Errorf("You cannot specify --selector and individual targets")
+		}
+		if _, err := coreannotations.ParseSelector(c.selector); err != nil {
+			return errors.Trace(err)
+		}
 	} else {
 		if len(c.machines) == 0 && len(c.applications) == 0 && len(c.units) == 0 {
-			return errors.Errorf("You must specify a target, either through --all, --machine, --application or --unit")
+			return errors.Errorf("You must specify a target, either through --all, --machine, --application, --unit or --selector")
 		}
 	}
 
@@ -259,6 +278,12 @@ func (c *execCommand) Run(ctx *cmd.Context) error {
 		return errors.Annotatef(err, "unable to get model type")
 	}
 
+	if c.selector != "" {
+		if err := c.selectTargets(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	if modelType == model.CAAS {
 		if client.BestAPIVersion() < 4 {
 			return errors.Errorf("k8s controller does not support juju exec" +
@@ -444,6 +469,34 @@ func (c *execCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// selectTargets sets the targets to run the commands on to the machines,
+// applications and units whose annotations match the selector.
+func (c *execCommand) selectTargets() error {
+	api, err := getSelectorAPIClient(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+	tags, err := api.Select(c.selector, "")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, tag := range tags {
+		switch tag.Kind() {
+		case names.MachineTagKind:
+			c.machines = append(c.machines, tag.Id())
+		case names.ApplicationTagKind:
+			c.applications = append(c.applications, tag.Id())
+		case names.UnitTagKind:
+			c.units = append(c.units, tag.Id())
+		}
+	}
+	if len(c.machines) == 0 && len(c.applications) == 0 && len(c.units) == 0 {
+		return errors.Errorf("no machines, applications or units match selector %q", c.selector)
+	}
+	return nil
+}
+
 type actionReceiver struct {
 	receiverType string
 	tag          names.Tag
@@ -471,6 +524,16 @@ var getExecAPIClient = func(c *execCommand) (ExecClient, error) {
 	return actionapi.NewClient(root), errors.Trace(err)
 }
 
+// getSelectorAPIClient returns the client used to select targets by
+// their annotations.
+var getSelectorAPIClient = func(c *execCommand) (action.SelectorAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return annotations.NewClient(root), nil
+}
+
 // getActionResult abstracts over the action CLI function that we use here to fetch results
 var getActionResult = func(c ExecClient, actionId string, wait *time.Timer) (params.ActionResult, error) {
 	return action.GetActionResult(c, actionId, wait, false)
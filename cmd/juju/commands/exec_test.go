@@ -67,6 +67,7 @@ func (*ExecSuite) TestTargetArgParsing(c *gc.C) {
 		machines     []string
 		units        []string
 		applications []string
+		selector     string
 		commands     string
 		errMatch     string
 		modeType     model.ModelType
@@ -77,7 +78,7 @@ func (*ExecSuite) TestTargetArgParsing(c *gc.C) {
 	}, {
 		message:  "no target",
 		args:     []string{"sudo reboot"},
-		errMatch: "You must specify a target, either through --all, --machine, --application or --unit",
+		errMatch: "You must specify a target, either through --all, --machine, --application, --unit or --selector",
 		modeType: model.IAAS,
 	}, {
 		message:  "command to all machines",
@@ -181,6 +182,27 @@ func (*ExecSuite) TestTargetArgParsing(c *gc.C) {
 		applications: []string{"mysql"},
 		units:        []string{"wordpress/0", "wordpress/1", "consul/leader"},
 		modeType:     model.IAAS,
+	}, {
+		message:  "command to selected targets",
+		args:     []string{"--selector", "team=payments", "sudo reboot"},
+		commands: "sudo reboot",
+		selector: "team=payments",
+		modeType: model.IAAS,
+	}, {
+		message:  "all and selector",
+		args:     []string{"--all", "--selector", "team=payments", "sudo reboot"},
+		errMatch: `You cannot specify --all and --selector`,
+		modeType: model.IAAS,
+	}, {
+		message:  "selector and defined units",
+		args:     []string{"--selector", "team=payments", "--unit=mysql/0", "sudo reboot"},
+		errMatch: `You cannot specify --selector and individual targets`,
+		modeType: model.IAAS,
+	}, {
+		message:  "bad selector",
+		args:     []string{"--selector", "team", "sudo reboot"},
+		errMatch: `selector term "team": expected key=value not valid`,
+		modeType: model.IAAS,
 	}, {
 		message:  "command to unit operator",
 		args:     []string{"--operator", "--unit", "mysql/0", "echo hello"},
@@ -198,6 +220,7 @@ func (*ExecSuite) TestTargetArgParsing(c *gc.C) {
 			c.Check(cmd.machines, gc.DeepEquals, test.machines)
 			c.Check(cmd.applications, gc.DeepEquals, test.applications)
 			c.Check(cmd.units, gc.DeepEquals, test.units)
+			c.Check(cmd.selector, gc.Equals, test.selector)
 			c.Check(cmd.commands, gc.Equals, test.commands)
 		}
 	}
@@ -359,6 +382,47 @@ func (s *ExecSuite) TestBlockExecForMachineAndUnit(c *gc.C) {
 	testing.AssertOperationWasBlocked(c, err, ".*To enable changes.*")
 }
 
+func (s *ExecSuite) TestExecForSelector(c *gc.C) {
+	mock := s.setupMockAPI()
+	selector := &mockSelectorAPI{tags: []names.Tag{
+		names.NewMachineTag("0"),
+		names.NewApplicationTag("mysql"),
+		names.NewUnitTag("unit/0"),
+	}}
+	s.PatchValue(&getSelectorAPIClient, func(_ *execCommand) (action.SelectorAPI, error) {
+		return selector, nil
+	})
+	mock.setResponse("0", mockResponse{stdout: "megatron\n", machineTag: "machine-0"})
+	mock.setResponse("unit/0", mockResponse{stdout: "bumblebee", unitTag: "unit-unit-0"})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]:      mock.execResponses["0"],
+		mock.receiverIdMap["unit/0"]: mock.execResponses["unit/0"],
+	}
+
+	_, err := cmdtesting.RunCommand(c, newTestExecCommand(&mockClock{}, model.IAAS),
+		"--format=json", "--selector", "team=payments", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(selector.selector, gc.Equals, "team=payments")
+	c.Check(selector.kind, gc.Equals, "")
+	c.Assert(mock.execParams, gc.NotNil)
+	c.Check(mock.execParams.Machines, jc.DeepEquals, []string{"0"})
+	c.Check(mock.execParams.Applications, jc.DeepEquals, []string{"mysql"})
+	c.Check(mock.execParams.Units, jc.DeepEquals, []string{"unit/0"})
+}
+
+func (s *ExecSuite) TestExecForSelectorNoMatch(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.PatchValue(&getSelectorAPIClient, func(_ *execCommand) (action.SelectorAPI, error) {
+		return &mockSelectorAPI{}, nil
+	})
+	_, err := cmdtesting.RunCommand(c, newTestExecCommand(&mockClock{}, model.IAAS),
+		"--selector", "team=payments", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, `no machines, applications or units match selector "team=payments"`)
+	c.Check(mock.execParams, gc.IsNil)
+}
+
 func (s *ExecSuite) TestAllMachines(c *gc.C) {
 	mock := s.setupMockAPI()
 	mock.setMachinesAlive("0", "1", "2")
@@ -880,5 +944,20 @@ func (m *mockExecAPI) BestAPIVersion() int {
 	return m.bestAPIVersion
 }
 
+type mockSelectorAPI struct {
+	tags     []names.Tag
+	selector string
+	kind     string
+}
+
+func (m *mockSelectorAPI) Select(selector, kind string) ([]names.Tag, error) {
+	m.selector, m.kind = selector, kind
+	return m.tags, nil
+}
+
+func (*mockSelectorAPI) Close() error {
+	return nil
+}
+
 // validUUID is a UUID used in tests
 var validUUID = "01234567-89ab-cdef-0123-456789abcdef"
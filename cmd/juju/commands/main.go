@@ -303,6 +303,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(status.NewEventsCommand())
 
 	// Error resolution and debugging commands.
 	if !featureflag.Enabled(feature.ActionsV2) {
@@ -334,12 +335,14 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage backups.
 	r.Register(backups.NewCreateCommand())
+	r.Register(backups.NewCreateKeyCommand())
 	r.Register(backups.NewDownloadCommand())
 	r.Register(backups.NewShowCommand())
 	r.Register(backups.NewListCommand())
 	r.Register(backups.NewRemoveCommand())
 	r.Register(backups.NewRestoreCommand())
 	r.Register(backups.NewUploadCommand())
+	r.Register(backups.NewVerifyCommand())
 
 	// Manage authorized ssh keys.
 	r.Register(NewAddKeysCommand())
@@ -379,6 +382,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
 	r.Register(model.NewModelCredentialCommand())
+	r.Register(model.NewSetQuotaCommand())
 	if featureflag.Enabled(feature.Branches) || featureflag.Enabled(feature.Generations) {
 		r.Register(model.NewAddBranchCommand())
 		r.Register(model.NewCommitCommand())
@@ -388,10 +392,14 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 		r.Register(model.NewAbortCommand())
 		r.Register(model.NewCommitsCommand())
 		r.Register(model.NewShowCommitCommand())
+		r.Register(model.NewRevertCommitCommand())
 	}
 
 	r.Register(newMigrateCommand())
+	r.Register(newShowMigrationCommand())
 	r.Register(model.NewExportBundleCommand())
+	r.Register(model.NewBackupModelCommand())
+	r.Register(model.NewRestoreModelCommand())
 
 	if featureflag.Enabled(feature.DeveloperMode) {
 		r.Register(model.NewDumpCommand())
@@ -427,6 +435,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewBundleDiffCommand())
 	r.Register(application.NewShowApplicationCommand())
 	r.Register(application.NewShowUnitCommand())
+	r.Register(application.NewShowRelationDataCommand())
 
 	// Operation protection commands
 	r.Register(block.NewDisableCommand())
@@ -469,6 +478,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewUnregisterCommand(jujuclient.NewFileClientStore()))
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
+	r.Register(controller.NewControllerStatusCommand())
+	r.Register(controller.NewAddModelTemplateCommand())
+	r.Register(controller.NewModelTemplatesCommand())
+	r.Register(controller.NewRemoveModelTemplateCommand())
 	r.Register(controller.NewConfigCommand())
 
 	// Debug Metrics
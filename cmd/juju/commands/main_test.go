@@ -470,6 +470,7 @@ var commandNames = []string{
 	"add-k8s",
 	"add-machine",
 	"add-model",
+	"add-model-template",
 	"add-relation",
 	"add-space",
 	"add-ssh-key",
@@ -483,6 +484,7 @@ var commandNames = []string{
 	"attach-resource",
 	"attach-storage",
 	"autoload-credentials",
+	"backup-model",
 	"backups",
 	"bind",
 	"bootstrap",
@@ -497,8 +499,10 @@ var commandNames = []string{
 	"config",
 	"consume",
 	"controller-config",
+	"controller-status",
 	"controllers",
 	"create-backup",
+	"create-backup-key",
 	"create-storage-pool",
 	"create-wallet",
 	"credentials",
@@ -522,8 +526,10 @@ var commandNames = []string{
 	"enable-destroy-controller",
 	"enable-ha",
 	"enable-user",
+	"events",
 	"exec",
 	"export-bundle",
+	"export-model",
 	"expose",
 	"find-offers",
 	"firewall-rules",
@@ -537,6 +543,7 @@ var commandNames = []string{
 	"hook-tool",
 	"hook-tools",
 	"import-filesystem",
+	"import-model",
 	"import-ssh-key",
 	"kill-controller",
 	"list-actions",
@@ -550,6 +557,7 @@ var commandNames = []string{
 	"list-disabled-commands",
 	"list-firewall-rules",
 	"list-machines",
+	"list-model-templates",
 	"list-models",
 	"list-offers",
 	"list-payloads",
@@ -571,6 +579,7 @@ var commandNames = []string{
 	"model-config",
 	"model-default",
 	"model-defaults",
+	"model-templates",
 	"models",
 	"move-to-space",
 	"offer",
@@ -589,6 +598,7 @@ var commandNames = []string{
 	"remove-credential",
 	"remove-k8s",
 	"remove-machine",
+	"remove-model-template",
 	"remove-offer",
 	"remove-relation",
 	"remove-saas",
@@ -603,6 +613,7 @@ var commandNames = []string{
 	"resolve",
 	"resources",
 	"restore-backup",
+	"restore-model",
 	"resume-relation",
 	"retry-provisioning",
 	"revoke",
@@ -618,6 +629,7 @@ var commandNames = []string{
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
+	"set-quota",
 	"set-series",
 	"set-wallet",
 	"show-action",
@@ -628,8 +640,10 @@ var commandNames = []string{
 	"show-credential",
 	"show-credentials",
 	"show-machine",
+	"show-migration",
 	"show-model",
 	"show-offer",
+	"show-relation-data",
 	"show-status",
 	"show-status-log",
 	"show-storage",
@@ -667,6 +681,7 @@ var commandNames = []string{
 	"upgrade-series",
 	"upload-backup",
 	"users",
+	"verify-backup",
 	"version",
 	"wallets",
 	"whoami",
@@ -4,16 +4,23 @@
 package commands
 
 import (
+	"fmt"
+	"io"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 	"gopkg.in/macaroon-bakery.v2/httpbakery"
 	"gopkg.in/macaroon.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/api/modelmanager"
 	"github.com/juju/juju/api/usermanager"
@@ -23,9 +30,15 @@ import (
 	"github.com/juju/juju/jujuclient"
 )
 
+// defaultMigrationPollInterval is how often the progress of the
+// migrations in a bulk migration is checked.
+const defaultMigrationPollInterval = 5 * time.Second
+
 func newMigrateCommand() modelcmd.ModelCommand {
 	var cmd migrateCommand
 	cmd.newAPIRoot = cmd.CommandBase.NewAPIRoot
+	cmd.clock = clock.WallClock
+	cmd.pollInterval = defaultMigrationPollInterval
 	return modelcmd.Wrap(&cmd, modelcmd.WrapSkipModelFlags)
 }
 
@@ -33,16 +46,28 @@ func newMigrateCommand() modelcmd.ModelCommand {
 type migrateCommand struct {
 	modelcmd.ModelCommandBase
 	targetController string
+	modelNames       []string
+	all              bool
+	dryRun           bool
+	concurrency      int
+	planFile         string
+
+	// plan is set by Init when resuming a bulk migration.
+	plan *migrationPlan
 
 	// Overridden by tests
-	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error)
-	migAPI     map[string]migrateAPI
-	modelAPI   modelInfoAPI
-	userAPI    userListAPI
+	newAPIRoot   func(jujuclient.ClientStore, string, string) (api.Connection, error)
+	migAPI       map[string]migrateAPI
+	modelAPI     modelInfoAPI
+	userAPI      userListAPI
+	clock        clock.Clock
+	pollInterval time.Duration
 }
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	MigrationDryRun(spec controller.MigrationSpec) (*controller.MigrationDryRunReport, error)
+	AllModels() ([]base.UserModel, error)
 	IdentityProviderURL() (string, error)
 	Close() error
 }
@@ -68,6 +93,13 @@ available at the source controller.
 Note that only hosted models can be migrated. Controller models can
 not be migrated.
 
+A model can be migrated to a controller running a newer Juju version
+without upgrading it first. The model is upgraded as it is imported,
+and if the target controller is on a newer major.minor release, the
+model's agents are upgraded to the target controller's version once
+the migration has succeeded. A model can't be migrated to a controller
+running an older Juju version than its source controller.
+
 If the migration fails for some reason, the model be returned to its
 original state with the model being managed by the original
 controller.
@@ -76,9 +108,37 @@ In order to start a migration, the target controller must be in the
 juju client's local configuration cache. See the juju "login" command
 for details of how to do this.
 
-This command only starts a model migration - it does not wait for its
-completion. The progress of a migration can be tracked using the
-"status" command and by consulting the logs.
+When migrating a single model, this command only starts the migration
+- it does not wait for its completion. The progress of a migration can
+be tracked using the "status" command and by consulting the logs.
+
+Several models may be migrated together by listing them all before the
+target controller, or every hosted model on the current controller by
+using --all. A bulk migration runs until every model has been migrated
+or has failed, with at most --concurrency migrations in progress at
+once, and reports the progress of each model through the migration
+phases as it goes.
+
+If --plan is given, the models to migrate and the progress of each are
+recorded in the named file as the bulk migration runs. If the command
+is interrupted, running it again with only --plan resumes the bulk
+migration from where it left off, retrying any models which failed.
+
+With --dry-run, no migration is started. Instead every check the
+source and target controllers would make before migrating the model is
+run, including exporting the model and having the target controller
+check its description for problems known to stop an import, and all
+problems found are listed. The model is not imported, so a migration
+may still fail after a clean dry run. Blockers would stop the
+migration; warnings would not, but may cause trouble after the model
+has been migrated.
+
+Examples:
+    juju migrate mymodel othercontroller
+    juju migrate mymodel othercontroller --dry-run
+    juju migrate model1 model2 model3 othercontroller
+    juju migrate --all othercontroller --concurrency 5 --plan migration.yaml
+    juju migrate --plan migration.yaml
 
 See also:
     login
@@ -90,34 +150,80 @@ See also:
 func (c *migrateCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "migrate",
-		Args:    "<model-name> <target-controller-name>",
+		Args:    "<model-name> [<model-name>...] <target-controller-name>",
 		Purpose: "Migrate a hosted model to another controller.",
 		Doc:     migrateDoc,
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the model could be migrated without migrating it")
+	f.BoolVar(&c.all, "all", false, "Migrate all hosted models on the current controller")
+	f.IntVar(&c.concurrency, "concurrency", 3, "The maximum number of models to migrate at once")
+	f.StringVar(&c.planFile, "plan", "", "Record the progress of a bulk migration in this file, or resume the one it records")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
+	if c.concurrency < 1 {
+		return errors.New("--concurrency must be at least 1")
+	}
+	if c.planFile != "" {
+		if c.dryRun {
+			return errors.New("--dry-run and --plan can't be used together")
+		}
+		plan, err := readMigrationPlan(c.planFile)
+		if err == nil {
+			if len(args) > 0 || c.all {
+				return errors.Errorf("plan %q already exists, specify only --plan to resume it", c.planFile)
+			}
+			c.plan = plan
+			c.targetController = plan.TargetController
+			return nil
+		} else if !os.IsNotExist(errors.Cause(err)) {
+			return errors.Trace(err)
+		}
+	}
+	if c.all {
+		if len(args) < 1 {
+			return errors.New("target controller not specified")
+		}
+		if len(args) > 1 {
+			return errors.New("too many arguments specified")
+		}
+		c.targetController = args[0]
+		return nil
+	}
+
 	if len(args) < 1 {
 		return errors.New("model not specified")
 	}
 	if len(args) < 2 {
 		return errors.New("target controller not specified")
 	}
-	if len(args) > 2 {
-		return errors.New("too many arguments specified")
-	}
 
 	if err := c.SetModelIdentifier(args[0], false); err != nil {
 		return errors.Trace(err)
 	}
 
-	c.targetController = args[1]
+	c.modelNames = args[:len(args)-1]
+	c.targetController = args[len(args)-1]
 	return nil
 }
 
+// isBulk returns whether the command migrates several models, waiting
+// for them all to finish, rather than starting a single migration.
+func (c *migrateCommand) isBulk() bool {
+	return c.all || c.plan != nil || c.planFile != "" || len(c.modelNames) > 1
+}
+
 // Run implements cmd.Command.
 func (c *migrateCommand) Run(ctx *cmd.Context) error {
+	if c.isBulk() {
+		return c.runBulk(ctx)
+	}
 	spec, err := c.getMigrationSpec()
 	if err != nil {
 		return err
@@ -131,13 +237,18 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	spec.ModelUUID = uuids[0]
-	if err := c.checkMigrationFeasibility(spec); err != nil {
-		return errors.Trace(err)
-	}
 	controllerName, err := c.ControllerName()
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		// The controller checks the model's users as part of the
+		// dry run, reporting any problems along with everything else.
+		return c.runDryRun(ctx, controllerName, modelName, spec)
+	}
+	if err := c.checkMigrationFeasibility(controllerName, spec); err != nil {
+		return errors.Trace(err)
+	}
 	api, err := c.getMigrationAPI(controllerName)
 	if err != nil {
 		return err
@@ -151,6 +262,40 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *migrateCommand) runDryRun(ctx *cmd.Context, controllerName, modelName string, spec *controller.MigrationSpec) error {
+	api, err := c.getMigrationAPI(controllerName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+	report, err := api.MigrationDryRun(*spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	printDryRunReport(ctx.Stdout, "", report)
+	if len(report.Blockers) > 0 {
+		return errors.Errorf("migration of model %q to controller %q would be blocked", modelName, c.targetController)
+	}
+	ctx.Infof("Model %q can be migrated to controller %q", modelName, c.targetController)
+	return nil
+}
+
+// printDryRunReport writes the blockers and warnings found by a
+// migration dry run, each line starting with indent.
+func printDryRunReport(w io.Writer, indent string, report *controller.MigrationDryRunReport) {
+	printProblems := func(heading string, problems []string) {
+		if len(problems) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "%s%s:\n", indent, heading)
+		for _, problem := range problems {
+			fmt.Fprintf(w, "%s  - %s\n", indent, problem)
+		}
+	}
+	printProblems("Blockers", report.Blockers)
+	printProblems("Warnings", report.Warnings)
+}
+
 func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 	store := c.ClientStore()
 
@@ -197,16 +342,11 @@ func (c *migrateCommand) getMigrationAPI(controllerName string) (migrateAPI, err
 	return controller.NewClient(apiRoot), nil
 }
 
-func (c *migrateCommand) getModelAPI() (modelInfoAPI, error) {
+func (c *migrateCommand) getModelAPI(controllerName string) (modelInfoAPI, error) {
 	if c.modelAPI != nil {
 		return c.modelAPI, nil
 	}
 
-	controllerName, err := c.ControllerName()
-	if err != nil {
-		return nil, err
-	}
-
 	apiRoot, err := c.newAPIRoot(c.ClientStore(), controllerName, "")
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -245,14 +385,13 @@ func (c *migrateCommand) getTargetControllerMacaroons() ([]macaroon.Slice, error
 	return httpbakery.MacaroonsForURL(jar, api.CookieURL()), nil
 }
 
-func (c *migrateCommand) checkMigrationFeasibility(spec *controller.MigrationSpec) error {
+func (c *migrateCommand) checkMigrationFeasibility(srcControllerName string, spec *controller.MigrationSpec) error {
 	var (
 		srcUsers, dstUsers set.Strings
-		srcControllerName  string
 		err                error
 	)
 
-	if srcUsers, err = c.getModelUsers(names.NewModelTag(spec.ModelUUID)); err != nil {
+	if srcUsers, err = c.getModelUsers(srcControllerName, names.NewModelTag(spec.ModelUUID)); err != nil {
 		return err
 	}
 	if dstUsers, err = c.getTargetControllerUsers(); err != nil {
@@ -268,9 +407,6 @@ func (c *migrateCommand) checkMigrationFeasibility(spec *controller.MigrationSpe
 	})
 
 	if srcExtUsers.Size() != 0 {
-		if srcControllerName, err = c.ControllerName(); err != nil {
-			return err
-		}
 		srcIdentityURL, err := c.getIdentityProviderURL(srcControllerName)
 		if err != nil {
 			return errors.Annotate(err, "looking up source controller identity provider URL")
@@ -333,8 +469,8 @@ func (c *migrateCommand) getIdentityProviderURL(controllerName string) (string,
 	return api.IdentityProviderURL()
 }
 
-func (c *migrateCommand) getModelUsers(modelTag names.ModelTag) (set.Strings, error) {
-	api, err := c.getModelAPI()
+func (c *migrateCommand) getModelUsers(controllerName string, modelTag names.ModelTag) (set.Strings, error) {
+	api, err := c.getModelAPI(controllerName)
 	if err != nil {
 		return nil, err
 	}
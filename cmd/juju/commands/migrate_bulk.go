@@ -0,0 +1,357 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/utils"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs/bootstrap"
+	"github.com/juju/juju/jujuclient"
+)
+
+// The statuses of a model in a bulk migration.
+const (
+	modelPending   = "pending"
+	modelMigrating = "migrating"
+	modelMigrated  = "migrated"
+	modelFailed    = "failed"
+)
+
+// migrationPlan records the models in a bulk migration and how far
+// each has got, so that an interrupted bulk migration can be resumed.
+type migrationPlan struct {
+	SourceController string          `yaml:"source-controller"`
+	TargetController string          `yaml:"target-controller"`
+	Models           []*plannedModel `yaml:"models"`
+}
+
+// plannedModel records the progress of one model in a bulk migration.
+type plannedModel struct {
+	Name        string `yaml:"name"`
+	UUID        string `yaml:"uuid"`
+	Status      string `yaml:"status"`
+	MigrationID string `yaml:"migration-id,omitempty"`
+	Phase       string `yaml:"phase,omitempty"`
+	Message     string `yaml:"message,omitempty"`
+}
+
+func (p *migrationPlan) modelsWithStatus(status string) []*plannedModel {
+	var models []*plannedModel
+	for _, m := range p.Models {
+		if m.Status == status {
+			models = append(models, m)
+		}
+	}
+	return models
+}
+
+func readMigrationPlan(path string) (*migrationPlan, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var plan migrationPlan
+	if err := yaml.Unmarshal(data, &plan); err != nil {
+		return nil, errors.Annotatef(err, "reading plan %q", path)
+	}
+	if plan.SourceController == "" || plan.TargetController == "" || len(plan.Models) == 0 {
+		return nil, errors.Errorf("plan %q is not valid", path)
+	}
+	return &plan, nil
+}
+
+func (c *migrateCommand) savePlan(plan *migrationPlan) error {
+	if c.planFile == "" {
+		return nil
+	}
+	data, err := yaml.Marshal(plan)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotatef(utils.AtomicWriteFile(c.planFile, data, 0644), "writing plan %q", c.planFile)
+}
+
+// runBulk migrates every model in the plan, waiting for them all to
+// finish.
+func (c *migrateCommand) runBulk(ctx *cmd.Context) error {
+	plan := c.plan
+	if plan == nil {
+		var err error
+		if plan, err = c.newMigrationPlan(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if c.dryRun {
+		return c.dryRunPlan(ctx, plan)
+	}
+	spec, err := c.getMigrationSpec()
+	if err != nil {
+		return err
+	}
+	api, err := c.getMigrationAPI(plan.SourceController)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+	modelAPI, err := c.getModelAPI(plan.SourceController)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = modelAPI.Close() }()
+
+	// Models which failed in an earlier run are retried.
+	for _, m := range plan.modelsWithStatus(modelFailed) {
+		*m = plannedModel{Name: m.Name, UUID: m.UUID, Status: modelPending}
+	}
+	for {
+		migrating := plan.modelsWithStatus(modelMigrating)
+		for _, m := range plan.modelsWithStatus(modelPending) {
+			if len(migrating) >= c.concurrency {
+				break
+			}
+			c.startMigration(ctx, api, plan.SourceController, *spec, m)
+			if m.Status == modelMigrating {
+				migrating = append(migrating, m)
+			}
+		}
+		if err := c.savePlan(plan); err != nil {
+			return errors.Trace(err)
+		}
+		if len(migrating) == 0 {
+			break
+		}
+		<-c.clock.After(c.pollInterval)
+		if err := updateMigrationProgress(ctx, modelAPI, migrating); err != nil {
+			// The migrations carry on without us, so keep
+			// checking on them rather than giving up.
+			ctx.Warningf("%v", err)
+		}
+	}
+
+	if err := printMigrationPlan(ctx, plan); err != nil {
+		return errors.Trace(err)
+	}
+	if failed := len(plan.modelsWithStatus(modelFailed)); failed > 0 {
+		return errors.Errorf("%d of %d models failed to migrate", failed, len(plan.Models))
+	}
+	return nil
+}
+
+// newMigrationPlan returns a plan for migrating the models given on
+// the command line, or every hosted model if --all was specified.
+func (c *migrateCommand) newMigrationPlan() (*migrationPlan, error) {
+	var sourceController string
+	var err error
+	if c.all {
+		sourceController, err = modelcmd.DetermineCurrentController(c.ClientStore())
+	} else {
+		sourceController, err = c.ControllerName()
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	plan := &migrationPlan{
+		SourceController: sourceController,
+		TargetController: c.targetController,
+	}
+	seen := make(map[string]bool)
+	addModel := func(name, uuid string) {
+		if !seen[uuid] {
+			seen[uuid] = true
+			plan.Models = append(plan.Models, &plannedModel{Name: name, UUID: uuid, Status: modelPending})
+		}
+	}
+
+	if c.all {
+		api, err := c.getMigrationAPI(sourceController)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = api.Close() }()
+		models, err := api.AllModels()
+		if err != nil {
+			return nil, errors.Annotate(err, "listing models")
+		}
+		sort.Slice(models, func(i, j int) bool {
+			if models[i].Owner != models[j].Owner {
+				return models[i].Owner < models[j].Owner
+			}
+			return models[i].Name < models[j].Name
+		})
+		for _, m := range models {
+			if m.Name == bootstrap.ControllerModelName {
+				continue
+			}
+			addModel(jujuclient.JoinOwnerModelName(names.NewUserTag(m.Owner), m.Name), m.UUID)
+		}
+		if len(plan.Models) == 0 {
+			return nil, errors.Errorf("controller %q has no hosted models to migrate", sourceController)
+		}
+		return plan, nil
+	}
+
+	store := modelcmd.QualifyingClientStore{ClientStore: c.ClientStore()}
+	modelNames := make([]string, len(c.modelNames))
+	for i, name := range c.modelNames {
+		controllerName, modelName := modelcmd.SplitModelName(name)
+		if controllerName != "" && controllerName != sourceController {
+			return nil, errors.Errorf("model %q isn't on controller %q", name, sourceController)
+		}
+		if modelNames[i], err = store.QualifiedModelName(sourceController, modelName); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	uuids, err := c.ModelUUIDs(modelNames)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for i, uuid := range uuids {
+		addModel(modelNames[i], uuid)
+	}
+	return plan, nil
+}
+
+// startMigration starts migrating the model, recording in it whether
+// the migration started or failed.
+func (c *migrateCommand) startMigration(
+	ctx *cmd.Context, api migrateAPI, sourceController string, spec controller.MigrationSpec, m *plannedModel,
+) {
+	spec.ModelUUID = m.UUID
+	id, err := func() (string, error) {
+		if err := c.checkMigrationFeasibility(sourceController, &spec); err != nil {
+			return "", errors.Trace(err)
+		}
+		return api.InitiateMigration(spec)
+	}()
+	if err != nil {
+		m.Status = modelFailed
+		m.Message = err.Error()
+		ctx.Infof("%s: migration failed to start: %v", m.Name, err)
+		return
+	}
+	m.Status = modelMigrating
+	m.MigrationID = id
+	ctx.Infof("%s: migration started with ID %q", m.Name, id)
+}
+
+// updateMigrationProgress checks the progress of the migrating models,
+// reporting each which has moved on since it was last checked.
+func updateMigrationProgress(ctx *cmd.Context, api modelInfoAPI, models []*plannedModel) error {
+	tags := make([]names.ModelTag, len(models))
+	for i, m := range models {
+		tags[i] = names.NewModelTag(m.UUID)
+	}
+	results, err := api.ModelInfo(tags)
+	if err != nil {
+		return errors.Annotate(err, "checking migration progress")
+	}
+	if len(results) != len(models) {
+		return errors.Errorf("expected %d results, got %d", len(models), len(results))
+	}
+	for i, result := range results {
+		m := models[i]
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// The model is removed from the source controller
+				// once it has been migrated.
+				m.Status = modelMigrated
+				m.Phase = migration.DONE.String()
+				m.Message = ""
+				ctx.Infof("%s: migrated", m.Name)
+				continue
+			}
+			ctx.Warningf("%s: checking migration progress: %v", m.Name, result.Error)
+			continue
+		}
+		status := result.Result.Migration
+		if status == nil {
+			continue
+		}
+		phase := status.Phase
+		if phase == "" && status.End != nil {
+			// Older controllers don't report the phase; a migration
+			// which has ended while the model is still on the source
+			// controller was aborted.
+			phase = migration.ABORTDONE.String()
+		}
+		if phase == m.Phase && status.Status == m.Message {
+			continue
+		}
+		m.Phase = phase
+		m.Message = status.Status
+		switch phase {
+		case migration.DONE.String(), migration.REAPFAILED.String():
+			m.Status = modelMigrated
+		case migration.ABORTDONE.String():
+			m.Status = modelFailed
+		}
+		if phase == "" {
+			ctx.Infof("%s: %s", m.Name, m.Message)
+		} else {
+			ctx.Infof("%s: %s (%s)", m.Name, m.Message, phase)
+		}
+	}
+	return nil
+}
+
+func printMigrationPlan(ctx *cmd.Context, plan *migrationPlan) error {
+	tw := output.TabWriter(ctx.Stdout)
+	fmt.Fprintln(tw, "Model\tStatus\tPhase\tMigration ID\tMessage")
+	for _, m := range plan.Models {
+		// Only the first line of long errors fits in the table.
+		message := strings.SplitN(m.Message, "\n", 2)[0]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", m.Name, m.Status, m.Phase, m.MigrationID, message)
+	}
+	return tw.Flush()
+}
+
+// dryRunPlan checks whether each model in the plan could be migrated.
+func (c *migrateCommand) dryRunPlan(ctx *cmd.Context, plan *migrationPlan) error {
+	spec, err := c.getMigrationSpec()
+	if err != nil {
+		return err
+	}
+	api, err := c.getMigrationAPI(plan.SourceController)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+	blocked := 0
+	for _, m := range plan.Models {
+		spec.ModelUUID = m.UUID
+		report, err := api.MigrationDryRun(*spec)
+		if err != nil {
+			return errors.Annotatef(err, "checking model %q", m.Name)
+		}
+		if len(report.Blockers) == 0 && len(report.Warnings) == 0 {
+			fmt.Fprintf(ctx.Stdout, "%s: no problems found\n", m.Name)
+			continue
+		}
+		fmt.Fprintf(ctx.Stdout, "%s:\n", m.Name)
+		printDryRunReport(ctx.Stdout, "  ", report)
+		if len(report.Blockers) > 0 {
+			blocked++
+		}
+	}
+	if blocked > 0 {
+		return errors.Errorf("migration of %d of %d models to controller %q would be blocked",
+			blocked, len(plan.Models), plan.TargetController)
+	}
+	ctx.Infof("All models can be migrated to controller %q", plan.TargetController)
+	return nil
+}
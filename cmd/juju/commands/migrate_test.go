@@ -4,12 +4,16 @@
 package commands
 
 import (
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -191,6 +195,14 @@ func (s *MigrateSuite) SetUpTest(c *gc.C) {
 		},
 	}
 
+	s.modelAPI.started = s.api.started
+	s.api.allModels = append([]base.UserModel{{
+		Name:  "controller",
+		UUID:  "controller-uuid",
+		Type:  model.IAAS,
+		Owner: "admin",
+	}}, s.modelAPI.models...)
+
 	s.userAPI = &fakeUserAPI{
 		users: []params.UserInfo{
 			{
@@ -242,10 +254,25 @@ func (s *MigrateSuite) TestMissingTargetController(c *gc.C) {
 }
 
 func (s *MigrateSuite) TestTooManyArgs(c *gc.C) {
-	_, err := s.makeAndRun(c, "one", "too", "many")
+	_, err := s.makeAndRun(c, "--all", "too", "many")
 	c.Assert(err, gc.ErrorMatches, "too many arguments specified")
 }
 
+func (s *MigrateSuite) TestAllMissingTargetController(c *gc.C) {
+	_, err := s.makeAndRun(c, "--all")
+	c.Assert(err, gc.ErrorMatches, "target controller not specified")
+}
+
+func (s *MigrateSuite) TestBadConcurrency(c *gc.C) {
+	_, err := s.makeAndRun(c, "model", "target", "--concurrency", "0")
+	c.Assert(err, gc.ErrorMatches, "--concurrency must be at least 1")
+}
+
+func (s *MigrateSuite) TestDryRunWithPlan(c *gc.C) {
+	_, err := s.makeAndRun(c, "model", "target", "--dry-run", "--plan", "plan.yaml")
+	c.Assert(err, gc.ErrorMatches, "--dry-run and --plan can't be used together")
+}
+
 func (s *MigrateSuite) TestSuccess(c *gc.C) {
 	ctx, err := s.makeAndRun(c, "model", "target")
 	c.Assert(err, jc.ErrorIsNil)
@@ -388,6 +415,8 @@ the current model:
 	for specIndex, spec := range specs {
 		c.Logf("test %d: %s", specIndex, spec.descr)
 
+		// Each test starts with none of the models migrated.
+		s.api.modelsStarted = nil
 		cmd := s.makeCommand()
 		inner := modelcmd.InnerCommand(cmd).(*migrateCommand)
 		inner.migAPI["source"].(*fakeMigrateAPI).identityURL = spec.srcIdentityURL
@@ -417,6 +446,251 @@ func (s *MigrateSuite) TestControllerDoesNotExist(c *gc.C) {
 	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "model", "target", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Model \"model\" can be migrated to controller \"target\"\n")
+	c.Check(s.api.specSeen, gc.IsNil) // no migration should have been started
+	c.Check(s.api.dryRunSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:             modelUUID,
+		TargetControllerUUID:  targetControllerUUID,
+		TargetControllerAlias: "target",
+		TargetAddrs:           []string{"1.2.3.4:5"},
+		TargetCACert:          "cert",
+		TargetUser:            "targetuser",
+		TargetPassword:        "secret",
+	})
+}
+
+func (s *MigrateSuite) TestDryRunWarnings(c *gc.C) {
+	s.api.dryRunReport.Warnings = []string{"target controller clock differs from source controller by 5m0s"}
+	ctx, err := s.makeAndRun(c, "model", "target", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Warnings:
+  - target controller clock differs from source controller by 5m0s
+`[1:])
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Model \"model\" can be migrated to controller \"target\"\n")
+}
+
+func (s *MigrateSuite) TestDryRunBlocked(c *gc.C) {
+	s.api.dryRunReport = controller.MigrationDryRunReport{
+		Blockers: []string{"machine 0 is dying", "target controller: cloud type \"dummy\" not supported"},
+		Warnings: []string{"unit app/1 is on machine 1 with series \"bionic\", not the application's series \"focal\""},
+	}
+	ctx, err := s.makeAndRun(c, "model-with-extra-users", "target", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, `migration of model "model-with-extra-users" to controller "target" would be blocked`)
+
+	// The users are checked by the controller, not the client.
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Blockers:
+  - machine 0 is dying
+  - target controller: cloud type "dummy" not supported
+Warnings:
+  - unit app/1 is on machine 1 with series "bionic", not the application's series "focal"
+`[1:])
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
+func (s *MigrateSuite) TestBulk(c *gc.C) {
+	end := time.Now()
+	s.modelAPI.migrations = map[string][]params.ModelMigrationStatus{
+		modelUUID: {
+			{Phase: "QUIESCE", Status: "migrating: performing source prechecks"},
+			{Phase: "IMPORT", Status: "migrating: importing model into target controller"},
+		},
+		"prod-2-uuid": {
+			{Phase: "ABORTDONE", Status: "aborted: target prechecks failed", End: &end},
+		},
+	}
+	ctx, err := s.makeAndRun(c, "model", "production", "target")
+	c.Assert(err, gc.ErrorMatches, "1 of 2 models failed to migrate")
+
+	c.Check(s.api.modelsStarted, jc.DeepEquals, []string{modelUUID, "prod-2-uuid"})
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+sourceuser/model: migration started with ID "uuid:0"
+sourceuser/production: migration started with ID "uuid:1"
+sourceuser/model: migrating: performing source prechecks (QUIESCE)
+sourceuser/production: aborted: target prechecks failed (ABORTDONE)
+sourceuser/model: migrating: importing model into target controller (IMPORT)
+sourceuser/model: migrated
+`[1:])
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model                  Status    Phase      Migration ID  Message
+sourceuser/model       migrated  DONE       uuid:0        
+sourceuser/production  failed    ABORTDONE  uuid:1        aborted: target prechecks failed
+`[1:])
+}
+
+func (s *MigrateSuite) TestBulkProgressError(c *gc.C) {
+	s.modelAPI.infoErrs = []error{errors.New("connection reset")}
+	ctx, err := s.makeAndRun(c, "model", "production", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The failure to check on the migrations doesn't stop them
+	// being followed to the end.
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+sourceuser/model: migration started with ID "uuid:0"
+sourceuser/production: migration started with ID "uuid:1"
+sourceuser/model: migrated
+sourceuser/production: migrated
+`[1:])
+	c.Check(c.GetTestLog(), jc.Contains, "checking migration progress: connection reset")
+}
+
+func (s *MigrateSuite) TestBulkConcurrency(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "model", "production", "target", "--concurrency", "1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The second migration only starts once the first has finished.
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+sourceuser/model: migration started with ID "uuid:0"
+sourceuser/model: migrated
+sourceuser/production: migration started with ID "uuid:1"
+sourceuser/production: migrated
+`[1:])
+}
+
+func (s *MigrateSuite) TestBulkStartFailure(c *gc.C) {
+	s.api.initiateErrs = map[string]error{modelUUID: errors.New("boom")}
+	ctx, err := s.makeAndRun(c, "model", "model-with-extra-local-users", "production", "target")
+	c.Assert(err, gc.ErrorMatches, "2 of 3 models failed to migrate")
+
+	c.Check(s.api.modelsStarted, jc.DeepEquals, []string{"prod-2-uuid"})
+	c.Check(cmdtesting.Stderr(ctx), gc.Matches, `
+sourceuser/model: migration failed to start: boom
+sourceuser/model-with-extra-local-users: migration failed to start: cannot initiate migration as the users (.|\n)*
+sourceuser/production: migration started with ID "uuid:0"
+sourceuser/production: migrated
+`[1:])
+}
+
+func (s *MigrateSuite) TestBulkAll(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--all", "target")
+	c.Assert(err, gc.ErrorMatches, "2 of 6 models failed to migrate")
+
+	// The controller model isn't migrated.
+	c.Check(s.api.modelsStarted, jc.DeepEquals, []string{
+		"prod-1-uuid",
+		modelUUID,
+		"extra-external-users-uuid",
+		"prod-2-uuid",
+	})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model                                       Status    Phase  Migration ID  Message
+alpha/production                            migrated  DONE   uuid:0        
+sourceuser/model                            migrated  DONE   uuid:1        
+sourceuser/model-with-extra-external-users  migrated  DONE   uuid:2        
+sourceuser/model-with-extra-local-users     failed                         cannot initiate migration as the users granted access to the model do not exist
+sourceuser/model-with-extra-users           failed                         cannot initiate migration as the users granted access to the model do not exist
+sourceuser/production                       migrated  DONE   uuid:3        
+`[1:])
+}
+
+func (s *MigrateSuite) TestBulkPlan(c *gc.C) {
+	planFile := filepath.Join(c.MkDir(), "plan.yaml")
+	s.modelAPI.migrations = map[string][]params.ModelMigrationStatus{
+		modelUUID: {{Phase: "IMPORT", Status: "migrating: importing model into target controller"}},
+	}
+	_, err := s.makeAndRun(c, "model", "production", "target", "--plan", planFile, "--concurrency", "1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(planFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, `
+source-controller: source
+target-controller: target
+models:
+- name: sourceuser/model
+  uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  status: migrated
+  migration-id: uuid:0
+  phase: DONE
+- name: sourceuser/production
+  uuid: prod-2-uuid
+  status: migrated
+  migration-id: uuid:1
+  phase: DONE
+`[1:])
+}
+
+func (s *MigrateSuite) TestBulkResume(c *gc.C) {
+	planFile := filepath.Join(c.MkDir(), "plan.yaml")
+	err := ioutil.WriteFile(planFile, []byte(`
+source-controller: source
+target-controller: target
+models:
+- name: alpha/production
+  uuid: prod-1-uuid
+  status: migrated
+  migration-id: uuid:7
+  phase: DONE
+- name: sourceuser/model
+  uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  status: migrating
+  migration-id: uuid:8
+  phase: IMPORT
+- name: sourceuser/production
+  uuid: prod-2-uuid
+  status: failed
+  migration-id: uuid:9
+  phase: ABORTDONE
+  message: 'aborted: target prechecks failed'
+`[1:]), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.modelsStarted = []string{modelUUID}
+
+	ctx, err := s.makeAndRun(c, "--plan", planFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the model which failed is started again.
+	c.Check(s.api.modelsStarted, jc.DeepEquals, []string{modelUUID, "prod-2-uuid"})
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+sourceuser/production: migration started with ID "uuid:1"
+sourceuser/model: migrated
+sourceuser/production: migrated
+`[1:])
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model                  Status    Phase  Migration ID  Message
+alpha/production       migrated  DONE   uuid:7        
+sourceuser/model       migrated  DONE   uuid:8        
+sourceuser/production  migrated  DONE   uuid:1        
+`[1:])
+}
+
+func (s *MigrateSuite) TestBulkResumeWithArgs(c *gc.C) {
+	planFile := filepath.Join(c.MkDir(), "plan.yaml")
+	_, err := s.makeAndRun(c, "model", "target", "--plan", planFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.makeAndRun(c, "model", "target", "--plan", planFile)
+	c.Assert(err, gc.ErrorMatches, `plan ".*plan.yaml" already exists, specify only --plan to resume it`)
+}
+
+func (s *MigrateSuite) TestBulkDryRun(c *gc.C) {
+	s.api.dryRunReports = map[string]controller.MigrationDryRunReport{
+		"prod-2-uuid": {
+			Blockers: []string{"machine 0 is dying"},
+			Warnings: []string{"target controller clock differs from source controller by 5m0s"},
+		},
+	}
+	ctx, err := s.makeAndRun(c, "model", "production", "target", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, `migration of 1 of 2 models to controller "target" would be blocked`)
+
+	c.Check(s.api.modelsStarted, gc.HasLen, 0)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+sourceuser/model: no problems found
+sourceuser/production:
+  Blockers:
+    - machine 0 is dying
+  Warnings:
+    - target controller clock differs from source controller by 5m0s
+`[1:])
+}
+
 func (s *MigrateSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, s.makeCommand(), args...)
 }
@@ -436,17 +710,50 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 	inner.newAPIRoot = func(jujuclient.ClientStore, string, string) (api.Connection, error) {
 		return s.targetControllerAPI, nil
 	}
+	inner.pollInterval = time.Millisecond
 	return cmd
 }
 
 type fakeMigrateAPI struct {
-	specSeen    *controller.MigrationSpec
-	identityURL string
+	specSeen      *controller.MigrationSpec
+	modelsStarted []string
+	initiateErrs  map[string]error
+	identityURL   string
+	allModels     []base.UserModel
+	dryRunReport  controller.MigrationDryRunReport
+	dryRunReports map[string]controller.MigrationDryRunReport
+	dryRunSeen    *controller.MigrationSpec
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
+	if err := a.initiateErrs[spec.ModelUUID]; err != nil {
+		return "", err
+	}
 	a.specSeen = &spec
-	return "uuid:0", nil
+	a.modelsStarted = append(a.modelsStarted, spec.ModelUUID)
+	return fmt.Sprintf("uuid:%d", len(a.modelsStarted)-1), nil
+}
+
+func (a *fakeMigrateAPI) started(modelUUID string) bool {
+	for _, uuid := range a.modelsStarted {
+		if uuid == modelUUID {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *fakeMigrateAPI) MigrationDryRun(spec controller.MigrationSpec) (*controller.MigrationDryRunReport, error) {
+	a.dryRunSeen = &spec
+	report, ok := a.dryRunReports[spec.ModelUUID]
+	if !ok {
+		report = a.dryRunReport
+	}
+	return &report, nil
+}
+
+func (a *fakeMigrateAPI) AllModels() ([]base.UserModel, error) {
+	return a.allModels, nil
 }
 
 func (a *fakeMigrateAPI) IdentityProviderURL() (string, error) {
@@ -460,6 +767,17 @@ func (*fakeMigrateAPI) Close() error {
 type fakeModelAPI struct {
 	models    []base.UserModel
 	modelInfo []params.ModelInfo
+
+	// migrations holds the statuses successively reported for the
+	// migration of each model once started returns true for it.
+	// When they run out the model is reported as not found, as it
+	// would be once migrated.
+	migrations map[string][]params.ModelMigrationStatus
+	started    func(modelUUID string) bool
+
+	// infoErrs holds errors returned by successive calls to
+	// ModelInfo checking on started migrations, before it succeeds.
+	infoErrs []error
 }
 
 func (m *fakeModelAPI) ListModels(user string) ([]base.UserModel, error) {
@@ -467,31 +785,44 @@ func (m *fakeModelAPI) ListModels(user string) ([]base.UserModel, error) {
 }
 
 func (m *fakeModelAPI) ModelInfo(tags []names.ModelTag) ([]params.ModelInfoResult, error) {
-	var (
-		mi  *params.ModelInfo
-		err *params.Error
-	)
+	if len(m.infoErrs) > 0 && m.started != nil && m.started(tags[0].Id()) {
+		err := m.infoErrs[0]
+		m.infoErrs = m.infoErrs[1:]
+		return nil, err
+	}
+	results := make([]params.ModelInfoResult, len(tags))
+	for i, tag := range tags {
+		results[i] = m.modelInfoResult(tag.Id())
+	}
+	return results, nil
+}
 
-	modelUUID := tags[0].Id()
+func (m *fakeModelAPI) modelInfoResult(modelUUID string) params.ModelInfoResult {
+	notFound := params.ModelInfoResult{
+		Error: &params.Error{
+			Code: params.CodeNotFound,
+		},
+	}
+	var mi *params.ModelInfo
 	for _, model := range m.modelInfo {
 		if model.UUID == modelUUID {
+			model := model
 			mi = &model
 			break
 		}
 	}
-
 	if mi == nil {
-		err = &params.Error{
-			Code: params.CodeNotFound,
+		return notFound
+	}
+	if m.started != nil && m.started(modelUUID) {
+		statuses := m.migrations[modelUUID]
+		if len(statuses) == 0 {
+			return notFound
 		}
+		mi.Migration = &statuses[0]
+		m.migrations[modelUUID] = statuses[1:]
 	}
-
-	return []params.ModelInfoResult{
-		{
-			Result: mi,
-			Error:  err,
-		},
-	}, nil
+	return params.ModelInfoResult{Result: mi}
 }
 
 func (m *fakeModelAPI) Close() error {
@@ -0,0 +1,357 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/juju/osenv"
+)
+
+func newShowMigrationCommand() cmd.Command {
+	return modelcmd.Wrap(&showMigrationCommand{
+		clock:        clock.WallClock,
+		pollInterval: defaultMigrationPollInterval,
+	})
+}
+
+// showMigrationCommand shows the progress of a model's latest
+// migration.
+type showMigrationCommand struct {
+	modelcmd.ModelCommandBase
+	out     cmd.Output
+	watch   bool
+	isoTime bool
+
+	// Overridden by tests
+	api          migrationProgressAPI
+	clock        clock.Clock
+	pollInterval time.Duration
+}
+
+type migrationProgressAPI interface {
+	MigrationProgress(modelUUID string) (*params.MigrationProgress, error)
+	Close() error
+}
+
+const showMigrationDoc = `
+show-migration reports the progress of the latest migration of a model:
+when the migration entered each phase and how long it spent there, how
+many of each kind of entity were exported from this controller and
+imported into the target controller, how many log records have been
+transferred, and which agents have and haven't reported back in each
+phase which waits for them.
+
+When a migration stalls waiting for agents, the agents listed as
+waiting in the current phase are the ones holding it up.
+
+With --watch, the progress is shown again each time it changes, until
+the migration has finished.
+
+Examples:
+    juju show-migration
+    juju show-migration mymodel --watch
+    juju show-migration mymodel --format yaml
+
+See also:
+    migrate
+    show-model
+`
+
+// Info implements cmd.Command.
+func (c *showMigrationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-migration",
+		Args:    "[<model-name>]",
+		Purpose: "Shows the progress of a model's latest migration.",
+		Doc:     showMigrationDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *showMigrationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.watch, "watch", false, "Keep showing the progress until the migration has finished")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *showMigrationCommand) Init(args []string) error {
+	modelName := ""
+	if len(args) > 0 {
+		modelName = args[0]
+		args = args[1:]
+	}
+	if err := c.SetModelIdentifier(modelName, true); err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.ModelCommandBase.Init(args); err != nil {
+		return err
+	}
+
+	// If use of ISO time not specified on command line, check env var.
+	if !c.isoTime {
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			var err error
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *showMigrationCommand) getAPI() (migrationProgressAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return controller.NewClient(root), nil
+}
+
+// Run implements cmd.Command.
+func (c *showMigrationCommand) Run(ctx *cmd.Context) error {
+	modelName, err := c.ModelIdentifier()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	uuids, err := c.ModelUUIDs([]string{modelName})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = api.Close() }()
+
+	var last *params.MigrationProgress
+	for {
+		progress, err := api.MigrationProgress(uuids[0])
+		if errors.IsNotFound(err) || params.IsCodeNotFound(err) {
+			return errors.Errorf("model %q has not been migrated", modelName)
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if !reflect.DeepEqual(progress, last) {
+			if err := c.out.Write(ctx, c.formatProgress(modelName, progress)); err != nil {
+				return errors.Trace(err)
+			}
+			last = progress
+		}
+		if !c.watch || progress.End != nil {
+			return nil
+		}
+		<-c.clock.After(c.pollInterval)
+	}
+}
+
+type migrationProgressOutput struct {
+	Model            string                 `yaml:"model" json:"model"`
+	MigrationID      string                 `yaml:"migration-id" json:"migration-id"`
+	TargetController string                 `yaml:"target-controller" json:"target-controller"`
+	Phase            string                 `yaml:"phase" json:"phase"`
+	Status           string                 `yaml:"status" json:"status"`
+	Start            string                 `yaml:"start" json:"start"`
+	End              string                 `yaml:"end,omitempty" json:"end,omitempty"`
+	Phases           []migrationPhaseOutput `yaml:"phases,omitempty" json:"phases,omitempty"`
+	ExportedEntities map[string]int         `yaml:"exported-entities,omitempty" json:"exported-entities,omitempty"`
+	ImportedEntities map[string]int         `yaml:"imported-entities,omitempty" json:"imported-entities,omitempty"`
+	LogsTransferred  int                    `yaml:"logs-transferred" json:"logs-transferred"`
+	LastLogTime      string                 `yaml:"last-log-time,omitempty" json:"last-log-time,omitempty"`
+	Agents           []agentReportsOutput   `yaml:"agents,omitempty" json:"agents,omitempty"`
+}
+
+type migrationPhaseOutput struct {
+	Phase    string `yaml:"phase" json:"phase"`
+	Started  string `yaml:"started" json:"started"`
+	Duration string `yaml:"duration,omitempty" json:"duration,omitempty"`
+}
+
+type agentReportsOutput struct {
+	Phase     string   `yaml:"phase" json:"phase"`
+	Succeeded []string `yaml:"succeeded,omitempty" json:"succeeded,omitempty"`
+	Failed    []string `yaml:"failed,omitempty" json:"failed,omitempty"`
+	Waiting   []string `yaml:"waiting,omitempty" json:"waiting,omitempty"`
+}
+
+func (c *showMigrationCommand) formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return common.FormatTime(t, c.isoTime)
+}
+
+func (c *showMigrationCommand) formatProgress(modelName string, progress *params.MigrationProgress) migrationProgressOutput {
+	out := migrationProgressOutput{
+		Model:            modelName,
+		MigrationID:      progress.MigrationId,
+		TargetController: progress.TargetController,
+		Phase:            progress.Phase,
+		Status:           progress.Status,
+		Start:            c.formatTime(progress.Start),
+		End:              c.formatTime(progress.End),
+		ExportedEntities: progress.ExportedEntities,
+		ImportedEntities: progress.ImportedEntities,
+		LogsTransferred:  progress.LogsTransferred,
+		LastLogTime:      c.formatTime(progress.LastLogTime),
+	}
+	for i, phaseTime := range progress.PhaseTimes {
+		phaseTime := phaseTime
+		phase := migrationPhaseOutput{
+			Phase:   phaseTime.Phase,
+			Started: c.formatTime(&phaseTime.Time),
+		}
+		if i+1 < len(progress.PhaseTimes) {
+			phase.Duration = formatPhaseDuration(progress.PhaseTimes[i+1].Time.Sub(phaseTime.Time))
+		} else if progress.End == nil {
+			// The migration is still in this phase.
+			phase.Duration = formatPhaseDuration(c.clock.Now().Sub(phaseTime.Time))
+		}
+		out.Phases = append(out.Phases, phase)
+	}
+	for _, reports := range progress.MinionReports {
+		out.Agents = append(out.Agents, agentReportsOutput{
+			Phase:     reports.Phase,
+			Succeeded: agentNames(reports.Succeeded),
+			Failed:    agentNames(reports.Failed),
+			Waiting:   agentNames(reports.Waiting),
+		})
+	}
+	return out
+}
+
+func formatPhaseDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return d.Round(time.Second).String()
+}
+
+// agentNames converts agent tags to the names shown to users, such
+// as "machine 0" or "unit mysql/0".
+func agentNames(tags []string) []string {
+	var out []string
+	for _, tagString := range tags {
+		tag, err := names.ParseTag(tagString)
+		if err != nil {
+			out = append(out, tagString)
+			continue
+		}
+		out = append(out, tag.Kind()+" "+tag.Id())
+	}
+	return out
+}
+
+func (c *showMigrationCommand) formatTabular(writer io.Writer, value interface{}) error {
+	progress, ok := value.(migrationProgressOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", progress, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.Println("Model:", progress.Model)
+	w.Println("Migration:", progress.MigrationID)
+	w.Println("Target:", progress.TargetController)
+	w.Println("Phase:", progress.Phase)
+	w.Println("Status:", progress.Status)
+	w.Println("Started:", progress.Start)
+	if progress.End != "" {
+		w.Println("Ended:", progress.End)
+	}
+
+	if len(progress.Phases) > 0 {
+		w.Println()
+		w.Println("Phase", "Started", "Duration")
+		for _, phase := range progress.Phases {
+			w.Println(phase.Phase, phase.Started, phase.Duration)
+		}
+	}
+
+	kinds := make(map[string]bool)
+	for kind := range progress.ExportedEntities {
+		kinds[kind] = true
+	}
+	for kind := range progress.ImportedEntities {
+		kinds[kind] = true
+	}
+	if len(kinds) > 0 {
+		sortedKinds := make([]string, 0, len(kinds))
+		for kind := range kinds {
+			sortedKinds = append(sortedKinds, kind)
+		}
+		sort.Strings(sortedKinds)
+		count := func(counts map[string]int, kind string) string {
+			if counts == nil {
+				return "-"
+			}
+			return strconv.Itoa(counts[kind])
+		}
+		w.Println()
+		w.Println("Entity", "Exported", "Imported")
+		for _, kind := range sortedKinds {
+			w.Println(kind, count(progress.ExportedEntities, kind), count(progress.ImportedEntities, kind))
+		}
+	}
+
+	if len(progress.Agents) > 0 {
+		w.Println()
+		w.Println("Agents", "Succeeded", "Failed", "Waiting")
+		for _, reports := range progress.Agents {
+			w.Println(reports.Phase, len(reports.Succeeded), len(reports.Failed), len(reports.Waiting))
+		}
+	}
+
+	if progress.LogsTransferred > 0 || progress.LastLogTime != "" {
+		w.Println()
+		if progress.LastLogTime != "" {
+			w.Println("Logs transferred:", fmt.Sprintf("%d (up to %s)", progress.LogsTransferred, progress.LastLogTime))
+		} else {
+			w.Println("Logs transferred:", progress.LogsTransferred)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+
+	// List the agents which failed, and those still to report in the
+	// phase the migration is in, as they are what hold it up.
+	for _, reports := range progress.Agents {
+		if len(reports.Failed) > 0 {
+			fmt.Fprintf(writer, "\nFailed in %s: %s\n", reports.Phase, strings.Join(reports.Failed, ", "))
+		}
+		if len(reports.Waiting) > 0 && reports.Phase == progress.Phase {
+			fmt.Fprintf(writer, "\nWaiting in %s for: %s\n", reports.Phase, strings.Join(reports.Waiting, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type ShowMigrationSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+	api   *fakeMigrationProgressAPI
+	start time.Time
+}
+
+var _ = gc.Suite(&ShowMigrationSuite{})
+
+func (s *ShowMigrationSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "source"
+	s.store.Controllers["source"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["source"] = jujuclient.AccountDetails{User: "admin"}
+	err := s.store.UpdateModel("source", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: modelUUID,
+		ModelType: model.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentModel("source", "admin/mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = &fakeMigrationProgressAPI{}
+	s.start = time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *ShowMigrationSuite) progress() *params.MigrationProgress {
+	start := s.start
+	return &params.MigrationProgress{
+		MigrationId:      modelUUID + ":0",
+		TargetController: "target",
+		Phase:            "VALIDATION",
+		Status:           "validating, some agents reported failure",
+		Start:            &start,
+		PhaseTimes: []params.MigrationPhaseTime{
+			{Phase: "QUIESCE", Time: start},
+			{Phase: "IMPORT", Time: start.Add(5 * time.Second)},
+			{Phase: "PROCESSRELATIONS", Time: start.Add(65 * time.Second)},
+			{Phase: "VALIDATION", Time: start.Add(66 * time.Second)},
+		},
+		ExportedEntities: map[string]int{"applications": 2, "machines": 3, "units": 4},
+		ImportedEntities: map[string]int{"applications": 2, "machines": 3, "units": 4},
+		MinionReports: []params.MigrationMinionReports{{
+			Phase:     "QUIESCE",
+			Succeeded: []string{"machine-0", "machine-1", "unit-mysql-0"},
+		}, {
+			Phase:     "VALIDATION",
+			Succeeded: []string{"machine-0"},
+			Failed:    []string{"unit-mysql-0"},
+			Waiting:   []string{"machine-1"},
+		}},
+	}
+}
+
+func (s *ShowMigrationSuite) makeCommand() cmd.Command {
+	command := modelcmd.Wrap(&showMigrationCommand{
+		api:          s.api,
+		clock:        &instantClock{now: s.start.Add(2 * time.Minute)},
+		pollInterval: time.Second,
+	})
+	command.SetClientStore(s.store)
+	return command
+}
+
+func (s *ShowMigrationSuite) TestTabular(c *gc.C) {
+	s.api.progress = []*params.MigrationProgress{s.progress()}
+	ctx, err := cmdtesting.RunCommand(c, s.makeCommand(), "mymodel", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.modelUUIDs, jc.DeepEquals, []string{modelUUID})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model:      mymodel
+Migration:  deadbeef-0bad-400d-8000-4b1d0d06f00d:0
+Target:     target
+Phase:      VALIDATION
+Status:     validating, some agents reported failure
+Started:    2020-03-01 12:00:00Z
+
+Phase             Started               Duration
+QUIESCE           2020-03-01 12:00:00Z  5s
+IMPORT            2020-03-01 12:00:05Z  1m0s
+PROCESSRELATIONS  2020-03-01 12:01:05Z  1s
+VALIDATION        2020-03-01 12:01:06Z  54s
+
+Entity        Exported  Imported
+applications  2         2
+machines      3         3
+units         4         4
+
+Agents      Succeeded  Failed  Waiting
+QUIESCE     3          0       0
+VALIDATION  1          1       1
+
+Failed in VALIDATION: unit mysql/0
+
+Waiting in VALIDATION for: machine 1
+
+`[1:])
+}
+
+func (s *ShowMigrationSuite) TestYAML(c *gc.C) {
+	progress := s.progress()
+	end := s.start.Add(10 * time.Minute)
+	lastLogTime := s.start.Add(9 * time.Minute)
+	progress.Phase = "DONE"
+	progress.Status = "successful, removing model from source controller"
+	progress.End = &end
+	progress.PhaseTimes = progress.PhaseTimes[:1]
+	progress.PhaseTimes = append(progress.PhaseTimes, params.MigrationPhaseTime{Phase: "DONE", Time: end})
+	progress.ImportedEntities = nil
+	progress.LogsTransferred = 1234
+	progress.LastLogTime = &lastLogTime
+	progress.MinionReports = nil
+	s.api.progress = []*params.MigrationProgress{progress}
+
+	ctx, err := cmdtesting.RunCommand(c, s.makeCommand(), "mymodel", "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+model: mymodel
+migration-id: deadbeef-0bad-400d-8000-4b1d0d06f00d:0
+target-controller: target
+phase: DONE
+status: successful, removing model from source controller
+start: 2020-03-01 12:00:00Z
+end: 2020-03-01 12:10:00Z
+phases:
+- phase: QUIESCE
+  started: 2020-03-01 12:00:00Z
+  duration: 10m0s
+- phase: DONE
+  started: 2020-03-01 12:10:00Z
+exported-entities:
+  applications: 2
+  machines: 3
+  units: 4
+logs-transferred: 1234
+last-log-time: 2020-03-01 12:09:00Z
+`[1:])
+}
+
+func (s *ShowMigrationSuite) TestTabularNotImported(c *gc.C) {
+	progress := s.progress()
+	progress.ImportedEntities = nil
+	progress.MinionReports = nil
+	progress.LogsTransferred = 10
+	s.api.progress = []*params.MigrationProgress{progress}
+	ctx, err := cmdtesting.RunCommand(c, s.makeCommand(), "mymodel", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `
+Entity        Exported  Imported
+applications  2         -
+machines      3         -
+units         4         -
+
+Logs transferred:  10
+`)
+}
+
+func (s *ShowMigrationSuite) TestWatch(c *gc.C) {
+	first := s.progress()
+	first.PhaseTimes = first.PhaseTimes[:1]
+	first.Phase = "QUIESCE"
+	first.MinionReports = nil
+	unchanged := *first
+	end := s.start.Add(time.Minute)
+	last := *first
+	last.Phase = "ABORTDONE"
+	last.Status = "aborted"
+	last.End = &end
+	last.PhaseTimes = append(first.PhaseTimes, params.MigrationPhaseTime{Phase: "ABORTDONE", Time: end})
+	s.api.progress = []*params.MigrationProgress{first, &unchanged, &last}
+
+	ctx, err := cmdtesting.RunCommand(c, s.makeCommand(), "mymodel", "--utc", "--watch", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.modelUUIDs, gc.HasLen, 3)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+{"model":"mymodel","migration-id":"deadbeef-0bad-400d-8000-4b1d0d06f00d:0","target-controller":"target","phase":"QUIESCE","status":"validating, some agents reported failure","start":"2020-03-01 12:00:00Z","phases":[{"phase":"QUIESCE","started":"2020-03-01 12:00:00Z","duration":"2m0s"}],"exported-entities":{"applications":2,"machines":3,"units":4},"imported-entities":{"applications":2,"machines":3,"units":4},"logs-transferred":0}
+{"model":"mymodel","migration-id":"deadbeef-0bad-400d-8000-4b1d0d06f00d:0","target-controller":"target","phase":"ABORTDONE","status":"aborted","start":"2020-03-01 12:00:00Z","end":"2020-03-01 12:01:00Z","phases":[{"phase":"QUIESCE","started":"2020-03-01 12:00:00Z","duration":"1m0s"},{"phase":"ABORTDONE","started":"2020-03-01 12:01:00Z"}],"exported-entities":{"applications":2,"machines":3,"units":4},"imported-entities":{"applications":2,"machines":3,"units":4},"logs-transferred":0}
+`[1:])
+}
+
+func (s *ShowMigrationSuite) TestNotMigrated(c *gc.C) {
+	s.api.err = errors.NotFoundf("migration")
+	_, err := cmdtesting.RunCommand(c, s.makeCommand(), "mymodel")
+	c.Assert(err, gc.ErrorMatches, `model "mymodel" has not been migrated`)
+}
+
+func (s *ShowMigrationSuite) TestTooManyArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.makeCommand(), "mymodel", "other")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["other"\]`)
+}
+
+type fakeMigrationProgressAPI struct {
+	progress   []*params.MigrationProgress
+	err        error
+	modelUUIDs []string
+}
+
+func (a *fakeMigrationProgressAPI) MigrationProgress(modelUUID string) (*params.MigrationProgress, error) {
+	a.modelUUIDs = append(a.modelUUIDs, modelUUID)
+	if a.err != nil {
+		return nil, a.err
+	}
+	progress := a.progress[0]
+	if len(a.progress) > 1 {
+		a.progress = a.progress[1:]
+	}
+	return progress, nil
+}
+
+func (a *fakeMigrationProgressAPI) Close() error {
+	return nil
+}
+
+// instantClock is a clock whose time stands still and whose timers
+// fire straight away.
+type instantClock struct {
+	clock.Clock
+	now time.Time
+}
+
+func (c *instantClock) Now() time.Time {
+	return c.now
+}
+
+func (c *instantClock) After(time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
@@ -0,0 +1,43 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ConfigDryRun is the user facing form of the result of validating a
+// config change without applying it.
+type ConfigDryRun struct {
+	Changes map[string]ConfigDryRunChange `yaml:"changes,omitempty" json:"changes,omitempty"`
+	Units   []string                      `yaml:"units-receiving-config-changed,omitempty" json:"units-receiving-config-changed,omitempty"`
+}
+
+// ConfigDryRunChange describes the change that would be made to a
+// single config key.
+type ConfigDryRunChange struct {
+	Old         interface{} `yaml:"old,omitempty" json:"old,omitempty"`
+	New         interface{} `yaml:"new,omitempty" json:"new,omitempty"`
+	CoercedFrom string      `yaml:"coerced-from,omitempty" json:"coerced-from,omitempty"`
+	Reset       bool        `yaml:"reset,omitempty" json:"reset,omitempty"`
+}
+
+// FormatConfigDryRun converts the changes and units in the given API
+// result into their user facing form. Any error in the result is left
+// to the caller to report.
+func FormatConfigDryRun(result params.ConfigDryRunResult) ConfigDryRun {
+	out := ConfigDryRun{Units: result.Units}
+	if len(result.Changes) > 0 {
+		out.Changes = make(map[string]ConfigDryRunChange, len(result.Changes))
+	}
+	for _, change := range result.Changes {
+		out.Changes[change.Key] = ConfigDryRunChange{
+			Old:         change.Old,
+			New:         change.New,
+			CoercedFrom: change.Input,
+			Reset:       change.Reset,
+		}
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+)
+
+type ConfigDryRunSuite struct{}
+
+var _ = gc.Suite(&ConfigDryRunSuite{})
+
+func (s *ConfigDryRunSuite) TestFormatConfigDryRun(c *gc.C) {
+	out := common.FormatConfigDryRun(params.ConfigDryRunResult{
+		Changes: []params.ConfigChange{
+			{Key: "debug", Old: true, New: false, Reset: true},
+			{Key: "level", Old: 1, New: int64(5), Input: "5"},
+			{Key: "name", New: "foo"},
+		},
+		Units: []string{"app/0", "app/1"},
+	})
+	data, err := goyaml.Marshal(out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, `
+changes:
+  debug:
+    old: true
+    new: false
+    reset: true
+  level:
+    old: 1
+    new: 5
+    coerced-from: "5"
+  name:
+    new: foo
+units-receiving-config-changed:
+- app/0
+- app/1
+`[1:])
+}
+
+func (s *ConfigDryRunSuite) TestFormatConfigDryRunNoChanges(c *gc.C) {
+	out := common.FormatConfigDryRun(params.ConfigDryRunResult{})
+	c.Assert(out, jc.DeepEquals, common.ConfigDryRun{})
+}
@@ -0,0 +1,52 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"time"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ConfigRevision is the user facing form of a single change to the
+// config of an application or model.
+type ConfigRevision struct {
+	Revision int                             `yaml:"revision" json:"revision"`
+	Scope    string                          `yaml:"scope" json:"scope"`
+	Time     time.Time                       `yaml:"time" json:"time"`
+	User     string                          `yaml:"user,omitempty" json:"user,omitempty"`
+	Changes  map[string]ConfigRevisionChange `yaml:"changes" json:"changes"`
+}
+
+// ConfigRevisionChange describes the change made to a single
+// config key by a revision.
+type ConfigRevisionChange struct {
+	Old   interface{} `yaml:"old,omitempty" json:"old,omitempty"`
+	New   interface{} `yaml:"new,omitempty" json:"new,omitempty"`
+	Reset bool        `yaml:"reset,omitempty" json:"reset,omitempty"`
+}
+
+// FormatConfigHistory converts the given API config revisions into
+// their user facing form, preserving their order.
+func FormatConfigHistory(revisions []params.ConfigRevision) []ConfigRevision {
+	out := make([]ConfigRevision, len(revisions))
+	for i, rev := range revisions {
+		changes := make(map[string]ConfigRevisionChange, len(rev.Changes))
+		for _, change := range rev.Changes {
+			changes[change.Key] = ConfigRevisionChange{
+				Old:   change.Old,
+				New:   change.New,
+				Reset: change.Reset,
+			}
+		}
+		out[i] = ConfigRevision{
+			Revision: rev.Revision,
+			Scope:    rev.Scope,
+			Time:     rev.Created.UTC(),
+			User:     rev.CreatedBy,
+			Changes:  changes,
+		}
+	}
+	return out
+}
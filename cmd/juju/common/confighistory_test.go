@@ -0,0 +1,64 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+)
+
+type ConfigHistorySuite struct{}
+
+var _ = gc.Suite(&ConfigHistorySuite{})
+
+func (s *ConfigHistorySuite) TestFormatConfigHistory(c *gc.C) {
+	out := common.FormatConfigHistory([]params.ConfigRevision{{
+		Revision:  2,
+		Scope:     "charm",
+		Created:   time.Date(2020, 9, 1, 3, 0, 0, 0, time.UTC),
+		CreatedBy: "bob",
+		Changes: []params.ConfigChange{
+			{Key: "debug", Old: true, Reset: true},
+			{Key: "level", Old: 1, New: 5},
+		},
+	}, {
+		Revision: 1,
+		Scope:    "application",
+		Created:  time.Date(2020, 8, 31, 12, 0, 0, 0, time.UTC),
+		Changes: []params.ConfigChange{
+			{Key: "trust", New: true},
+		},
+	}})
+	data, err := goyaml.Marshal(out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, `
+- revision: 2
+  scope: charm
+  time: 2020-09-01T03:00:00Z
+  user: bob
+  changes:
+    debug:
+      old: true
+      reset: true
+    level:
+      old: 1
+      new: 5
+- revision: 1
+  scope: application
+  time: 2020-08-31T12:00:00Z
+  changes:
+    trust:
+      new: true
+`[1:])
+}
+
+func (s *ConfigHistorySuite) TestFormatConfigHistoryEmpty(c *gc.C) {
+	c.Assert(common.FormatConfigHistory(nil), jc.DeepEquals, []common.ConfigRevision{})
+}
@@ -51,6 +51,37 @@ func UserFriendlyDuration(when, now time.Time) string {
 	return "just now"
 }
 
+// UserFriendlyTimeUntil translates a time in the future into a user
+// friendly string representation relative to the "now" time argument.
+func UserFriendlyTimeUntil(when, now time.Time) string {
+	until := when.Sub(now)
+	// if over 24 hours away, just say the date.
+	if until.Hours() >= 24 {
+		return when.Format("2006-01-02")
+	}
+	if until.Hours() >= 1 {
+		unit := "hours"
+		if int(until.Hours()) == 1 {
+			unit = "hour"
+		}
+		return fmt.Sprintf("in %d %s", int(until.Hours()), unit)
+	}
+	if until.Minutes() >= 1 {
+		unit := "minutes"
+		if int(until.Minutes()) == 1 {
+			unit = "minute"
+		}
+		return fmt.Sprintf("in %d %s", int(until.Minutes()), unit)
+	}
+	if until.Seconds() >= 2 {
+		return fmt.Sprintf("in %d seconds", int(until.Seconds()))
+	}
+	if until > 0 {
+		return "now"
+	}
+	return "expired"
+}
+
 // FormatTime returns a string with the local time formatted
 // in an arbitrary format used for status or and localized tz
 // or in UTC timezone and format RFC3339 if u is specified.
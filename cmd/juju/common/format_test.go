@@ -262,3 +262,49 @@ func (*userFriendlyDurationSuite) TestFormat(c *gc.C) {
 		c.Check(obtained, gc.Equals, test.expected)
 	}
 }
+
+type userFriendlyTimeUntilSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&userFriendlyTimeUntilSuite{})
+
+func (*userFriendlyTimeUntilSuite) TestFormat(c *gc.C) {
+	now := time.Now()
+	for _, test := range []struct {
+		other    time.Time
+		expected string
+	}{
+		{
+			other:    now.Add(-1 * time.Hour),
+			expected: "expired",
+		}, {
+			other:    now,
+			expected: "expired",
+		}, {
+			other:    now.Add(1 * time.Second),
+			expected: "now",
+		}, {
+			other:    now.Add(2 * time.Second),
+			expected: "in 2 seconds",
+		}, {
+			other:    now.Add(61 * time.Second),
+			expected: "in 1 minute",
+		}, {
+			other:    now.Add(59 * time.Minute),
+			expected: "in 59 minutes",
+		}, {
+			other:    now.Add(61 * time.Minute),
+			expected: "in 1 hour",
+		}, {
+			other:    now.Add(23 * time.Hour),
+			expected: "in 23 hours",
+		}, {
+			other:    now.Add(48 * time.Hour),
+			expected: now.Add(48 * time.Hour).Format("2006-01-02"),
+		},
+	} {
+		obtained := common.UserFriendlyTimeUntil(test.other, now)
+		c.Check(obtained, gc.Equals, test.expected)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
@@ -39,7 +40,26 @@ type ModelInfo struct {
 	SLA            string                      `json:"sla,omitempty" yaml:"sla,omitempty"`
 	SLAOwner       string                      `json:"sla-owner,omitempty" yaml:"sla-owner,omitempty"`
 	AgentVersion   string                      `json:"agent-version,omitempty" yaml:"agent-version,omitempty"`
+	Expires        string                      `json:"expires,omitempty" yaml:"expires,omitempty"`
 	Credential     *ModelCredential            `json:"credential,omitempty" yaml:"credential,omitempty"`
+	Quota          *ModelQuota                 `json:"quota,omitempty" yaml:"quota,omitempty"`
+}
+
+// ModelQuota contains the resource limits and usage of a model and of
+// the user that owns it.
+type ModelQuota struct {
+	Limits      ModelQuotaResources  `json:"limits" yaml:"limits"`
+	Usage       ModelQuotaResources  `json:"usage" yaml:"usage"`
+	OwnerLimits *ModelQuotaResources `json:"owner-limits,omitempty" yaml:"owner-limits,omitempty"`
+	OwnerUsage  *ModelQuotaResources `json:"owner-usage,omitempty" yaml:"owner-usage,omitempty"`
+}
+
+// ModelQuotaResources contains amounts of quota-limited resources.
+type ModelQuotaResources struct {
+	Machines     int    `json:"machines,omitempty" yaml:"machines,omitempty"`
+	Units        int    `json:"units,omitempty" yaml:"units,omitempty"`
+	Applications int    `json:"applications,omitempty" yaml:"applications,omitempty"`
+	Storage      string `json:"storage,omitempty" yaml:"storage,omitempty"`
 }
 
 // ModelMachineInfo contains information about a machine in a model.
@@ -109,6 +129,9 @@ func ModelInfoFromParams(info params.ModelInfo, now time.Time) (ModelInfo, error
 	if info.AgentVersion != nil {
 		modelInfo.AgentVersion = info.AgentVersion.String()
 	}
+	if info.Expiry != nil {
+		modelInfo.Expires = UserFriendlyTimeUntil(*info.Expiry, now)
+	}
 	// Although this may be more performance intensive, we have to use reflection
 	// since structs containing map[string]interface {} cannot be compared, i.e
 	// cannot use simple '==' here.
@@ -158,9 +181,41 @@ func ModelInfoFromParams(info params.ModelInfo, now time.Time) (ModelInfo, error
 		}
 	}
 
+	if info.Quota != nil {
+		modelInfo.Quota = ModelQuotaFromParams(info.Quota)
+	}
+
 	return modelInfo, nil
 }
 
+// ModelQuotaFromParams translates a params.ModelQuotaInfo to ModelQuota.
+// Owner limits and usage are only included if the owner has a quota.
+func ModelQuotaFromParams(info *params.ModelQuotaInfo) *ModelQuota {
+	out := &ModelQuota{
+		Limits: modelQuotaResourcesFromParams(info.Limits),
+		Usage:  modelQuotaResourcesFromParams(info.Usage),
+	}
+	if info.OwnerLimits != (params.QuotaResources{}) {
+		ownerLimits := modelQuotaResourcesFromParams(info.OwnerLimits)
+		ownerUsage := modelQuotaResourcesFromParams(info.OwnerUsage)
+		out.OwnerLimits = &ownerLimits
+		out.OwnerUsage = &ownerUsage
+	}
+	return out
+}
+
+func modelQuotaResourcesFromParams(r params.QuotaResources) ModelQuotaResources {
+	out := ModelQuotaResources{
+		Machines:     r.Machines,
+		Units:        r.Units,
+		Applications: r.Applications,
+	}
+	if r.StorageMiB != 0 {
+		out.Storage = humanize.IBytes(r.StorageMiB * humanize.MiByte)
+	}
+	return out
+}
+
 // ModelMachineInfoFromParams translates []params.ModelMachineInfo to a map of
 // machine ids to ModelMachineInfo.
 func ModelMachineInfoFromParams(machines []params.ModelMachineInfo) map[string]ModelMachineInfo {
@@ -6,8 +6,12 @@ package controller
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -22,6 +26,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	jujucloud "github.com/juju/juju/cloud"
 	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/application"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/cmd/output"
@@ -42,6 +47,7 @@ func NewAddModelCommand() cmd.Command {
 			return cloudapi.NewClient(caller)
 		},
 		providerRegistry: environs.GlobalProviderRegistry(),
+		deployBundle:     deployTemplateBundle,
 	}
 	command.CanClearCurrentModel = true
 	return modelcmd.WrapController(command)
@@ -55,11 +61,17 @@ type addModelCommand struct {
 	newCloudAPI      func(base.APICallCloser) CloudAPI
 	providerRegistry environs.ProviderRegistry
 
+	// deployBundle deploys the bundle of a model template
+	// into the newly added model.
+	deployBundle func(ctx *cmd.Context, store jujuclient.ClientStore, modelName, bundle string) error
+
 	Name           string
 	Owner          string
 	CredentialName string
 	CloudRegion    string
 	Config         common.ConfigFlag
+	Template       string
+	TTL            time.Duration
 	noSwitch       bool
 }
 
@@ -96,6 +108,21 @@ the same cloud/region as the controller model. If a region is specified
 without a cloud qualifier, then it is assumed to be in the same cloud
 as the controller model.
 
+A model may be created from a model template added with
+"juju add-model-template". The config, constraints, user access, firewall
+rules, spaces and disabled commands of the template are applied as the
+model is created; config specified with --config takes precedence over
+that of the template. If the template has a bundle, it is deployed once
+the model has been created; if the deployment fails, the model and its
+storage are destroyed again.
+
+A model may be given a time to live with --ttl, after which the controller
+destroys it; this is the same as setting the "model-ttl" config value. The
+TTL can be unset later with "juju model-config", or set again, in which case
+it is measured from then. The model's status warns about it for some time
+before it is destroyed. The storage of an expired model is released unless
+the model has "expiry-destroy-storage" set to true.
+
 Examples:
 
     juju add-model mymodel
@@ -103,6 +130,12 @@ Examples:
     juju add-model mymodel aws/us-east-1
     juju add-model mymodel --config my-config.yaml --config image-stream=daily
     juju add-model mymodel --credential credential_name --config authorized-keys="ssh-rsa ..."
+    juju add-model mymodel --template team
+    juju add-model ci-1234 --ttl 4h --config expiry-destroy-storage=true
+
+See also:
+    add-model-template
+    model-templates
 `
 
 func (c *addModelCommand) Info() *cmd.Info {
@@ -120,6 +153,8 @@ func (c *addModelCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.CredentialName, "credential", "", "Credential used to add the model")
 	f.Var(&c.Config, "config", "Path to YAML model configuration file or individual options (--config config.yaml [--config key=value ...])")
 	f.BoolVar(&c.noSwitch, "no-switch", false, "Do not switch to the newly created model")
+	f.StringVar(&c.Template, "template", "", "Name of the model template to create the model from")
+	f.DurationVar(&c.TTL, "ttl", 0, "Destroy the model once it is this old (e.g. 2h30m)")
 }
 
 func (c *addModelCommand) Init(args []string) error {
@@ -140,6 +175,10 @@ func (c *addModelCommand) Init(args []string) error {
 		return errors.Errorf("%q is not a valid user", c.Owner)
 	}
 
+	if c.TTL < 0 {
+		return errors.Errorf("--ttl %v cannot be negative", c.TTL)
+	}
+
 	return cmd.CheckEmpty(args)
 }
 
@@ -149,6 +188,13 @@ type AddModelAPI interface {
 		cloudCredential names.CloudCredentialTag,
 		config map[string]interface{},
 	) (base.ModelInfo, error)
+	CreateModelFromTemplate(
+		template, name, owner, cloudName, cloudRegion string,
+		cloudCredential names.CloudCredentialTag,
+		config map[string]interface{},
+	) (base.ModelInfo, error)
+	ModelTemplates() ([]params.ModelTemplate, error)
+	DestroyModel(tag names.ModelTag, destroyStorage, force *bool, maxWait *time.Duration) error
 }
 
 type CloudAPI interface {
@@ -196,6 +242,20 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	addModelClient := c.newAddModelAPI(root)
+	var template params.ModelTemplate
+	if c.Template != "" {
+		templates, err := addModelClient.ModelTemplates()
+		if errors.IsNotImplemented(err) {
+			return errors.New("model templates are not supported by this controller")
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if template, err = findModelTemplate(templates, c.Template); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	cloudClient := c.newCloudAPI(root)
 	var cloudTag names.CloudTag
 	var cloud jujucloud.Cloud
@@ -245,8 +305,12 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
-	addModelClient := c.newAddModelAPI(root)
-	model, err := addModelClient.CreateModel(c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs)
+	var model base.ModelInfo
+	if c.Template != "" {
+		model, err = addModelClient.CreateModelFromTemplate(c.Template, c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs)
+	} else {
+		model, err = addModelClient.CreateModel(c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs)
+	}
 	if err != nil {
 		if params.IsCodeUnauthorized(err) {
 			common.PermissionsMessage(ctx.Stderr, "add a model")
@@ -265,11 +329,19 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 		// Default target is the master branch.
 		details.ActiveBranch = coremodel.GenerationMaster
 	}
-	if modelOwner == accountDetails.User {
+	// previousModel is restored as the current model if the model
+	// is removed again because its template bundle couldn't be deployed.
+	var previousModel string
+	storedModel := modelOwner == accountDetails.User
+	if storedModel {
 		if err := store.UpdateModel(controllerName, c.Name, details); err != nil {
 			return errors.Trace(err)
 		}
 		if !c.noSwitch {
+			previousModel, err = store.CurrentModel(controllerName)
+			if err != nil && !errors.IsNotFound(err) {
+				return errors.Trace(err)
+			}
 			if err := store.SetCurrentModel(controllerName, c.Name); err != nil {
 				return errors.Trace(err)
 			}
@@ -301,11 +373,39 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 		messageArgs = append(messageArgs, credentialName)
 	}
 
+	if c.Template != "" {
+		messageFormat += " from template '%s'"
+		messageArgs = append(messageArgs, c.Template)
+	}
 	messageFormat += forUserSuffix
 
-	// "Added '<model>' model [on <cloud>/<region>] [with credential '<credential>'] for user '<user namePart>'"
+	// "Added '<model>' model [on <cloud>/<region>] [with credential '<credential>'] [from template '<template>'] for user '<user namePart>'"
 	ctx.Infof(messageFormat, messageArgs...)
 
+	if template.Bundle != "" {
+		modelName := controllerName + ":" + jujuclient.JoinOwnerModelName(names.NewUserTag(modelOwner), c.Name)
+		ctx.Infof("Deploying bundle from template '%s'", c.Template)
+		if err := c.deployBundle(ctx, store, modelName, template.Bundle); err != nil {
+			ctx.Infof("Deploying the bundle of template '%s' failed, removing model '%s'", c.Template, c.Name)
+			destroyStorage := true
+			if destroyErr := addModelClient.DestroyModel(names.NewModelTag(model.UUID), &destroyStorage, nil, nil); destroyErr != nil {
+				ctx.Infof("Failed to remove model '%s': %v", c.Name, destroyErr)
+				return err
+			}
+			if storedModel {
+				if err := store.RemoveModel(controllerName, c.Name); err != nil && !errors.IsNotFound(err) {
+					return errors.Trace(err)
+				}
+				if !c.noSwitch {
+					if err := store.SetCurrentModel(controllerName, previousModel); err != nil {
+						return errors.Trace(err)
+					}
+				}
+			}
+			return err
+		}
+	}
+
 	if _, ok := attrs[config.AuthorizedKeysKey]; !ok {
 		// It is not an error to have no authorized-keys when adding a
 		// model, though this should never happen since we generate
@@ -619,6 +719,12 @@ func (c *addModelCommand) getConfigValues(ctx *cmd.Context) (map[string]interfac
 	if !ok {
 		return nil, errors.New("params must contain a YAML map with string keys")
 	}
+	if c.TTL > 0 {
+		if _, ok := attrs[config.ModelTTLKey]; ok {
+			return nil, errors.Errorf("--ttl and %s config cannot both be specified", config.ModelTTLKey)
+		}
+		attrs[config.ModelTTLKey] = c.TTL.String()
+	}
 	if err := common.FinalizeAuthorizedKeys(ctx, attrs); err != nil {
 		if errors.Cause(err) != common.ErrNoAuthorizedKeys {
 			return nil, errors.Trace(err)
@@ -626,3 +732,24 @@ func (c *addModelCommand) getConfigValues(ctx *cmd.Context) (map[string]interfac
 	}
 	return attrs, nil
 }
+
+// deployTemplateBundle deploys the bundle of a model template into the
+// named model by running the deploy command.
+func deployTemplateBundle(ctx *cmd.Context, store jujuclient.ClientStore, modelName, bundle string) error {
+	dir, err := ioutil.TempDir("", "juju-model-template")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+
+	bundlePath := filepath.Join(dir, "bundle.yaml")
+	if err := ioutil.WriteFile(bundlePath, []byte(bundle), 0600); err != nil {
+		return errors.Trace(err)
+	}
+	deployCmd := application.NewDeployCommand()
+	deployCmd.SetClientStore(store)
+	if code := cmd.Main(deployCmd, ctx, []string{"-m", modelName, bundlePath}); code != 0 {
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -40,12 +41,16 @@ type AddModelSuite struct {
 	fakeProvider         *fakeProvider
 	fakeProviderRegistry *fakeProviderRegistry
 	store                *jujuclient.MemStore
+	deployed             []string
+	deployErr            error
 }
 
 var _ = gc.Suite(&AddModelSuite{})
 
 func (s *AddModelSuite) SetUpTest(c *gc.C) {
 	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.deployed = nil
+	s.deployErr = nil
 
 	agentVersion, err := version.Parse("2.55.5")
 	c.Assert(err, jc.ErrorIsNil)
@@ -114,13 +119,17 @@ func (*fakeAPIConnection) Close() error {
 }
 
 func (s *AddModelSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
-	command, _ := controller.NewAddModelCommandForTest(
+	command, addCmd := controller.NewAddModelCommandForTest(
 		&fakeAPIConnection{},
 		s.fakeAddModelAPI,
 		s.fakeCloudAPI,
 		s.store,
 		s.fakeProviderRegistry,
 	)
+	addCmd.SetDeployBundle(func(_ *cmd.Context, _ jujuclient.ClientStore, modelName, bundle string) error {
+		s.deployed = append(s.deployed, modelName+": "+bundle)
+		return s.deployErr
+	})
 	return cmdtesting.RunCommand(c, command, args...)
 }
 
@@ -169,6 +178,12 @@ func (s *AddModelSuite) TestInit(c *gc.C) {
 			args:        []string{"new-model", "cloud/region"},
 			name:        "new-model",
 			cloudRegion: "cloud/region",
+		}, {
+			args: []string{"new-model", "--ttl", "-1h"},
+			err:  `--ttl -1h0m0s cannot be negative`,
+		}, {
+			args: []string{"new-model", "--ttl", "soon"},
+			err:  `invalid value "soon" for option --ttl: .*`,
 		}, {
 			args: []string{"new-model", "cloud/region", "extra", "args"},
 			err:  `unrecognized args: \["extra" "args"\]`,
@@ -536,6 +551,18 @@ func (s *AddModelSuite) TestComandLineConfigPassedThrough(c *gc.C) {
 	c.Assert(s.fakeAddModelAPI.config["cloud"], gc.Equals, "special")
 }
 
+func (s *AddModelSuite) TestTTL(c *gc.C) {
+	_, err := s.run(c, "test", "--ttl", "90m")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.fakeAddModelAPI.config["model-ttl"], gc.Equals, "1h30m0s")
+}
+
+func (s *AddModelSuite) TestTTLConflictsWithConfig(c *gc.C) {
+	_, err := s.run(c, "test", "--ttl", "90m", "--config", "model-ttl=2h")
+	c.Assert(err, gc.ErrorMatches, "--ttl and model-ttl config cannot both be specified")
+}
+
 func (s *AddModelSuite) TestConfigFileValuesPassedThrough(c *gc.C) {
 	config := map[string]string{
 		"account": "magic",
@@ -694,6 +721,86 @@ func (s *AddModelSuite) TestNoEnvCacheOtherUser(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *AddModelSuite) TestTemplate(c *gc.C) {
+	s.fakeAddModelAPI.templates = []params.ModelTemplate{
+		{Name: "other"},
+		{Name: "team", Bundle: "applications: {}"},
+	}
+	ctx, err := s.run(c, "test", "--template", "team", "--config", "account=magic")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAddModelAPI.template, gc.Equals, "team")
+	c.Assert(s.fakeAddModelAPI.config["account"], gc.Equals, "magic")
+	c.Assert(s.deployed, jc.DeepEquals, []string{"test-master:bob/test: applications: {}"})
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "Added 'test' model from template 'team' for user 'bob'")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "Deploying bundle from template 'team'")
+}
+
+func (s *AddModelSuite) TestTemplateWithoutBundle(c *gc.C) {
+	s.fakeAddModelAPI.templates = []params.ModelTemplate{{Name: "team"}}
+	_, err := s.run(c, "test", "--template", "team")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAddModelAPI.template, gc.Equals, "team")
+	c.Assert(s.deployed, gc.HasLen, 0)
+}
+
+func (s *AddModelSuite) TestTemplateDeployFails(c *gc.C) {
+	s.fakeAddModelAPI.templates = []params.ModelTemplate{{Name: "team", Bundle: "applications: {}"}}
+	s.deployErr = cmd.ErrSilent
+	ctx, err := s.run(c, "test", "--template", "team")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "Deploying the bundle of template 'team' failed, removing model 'test'")
+
+	// The model was destroyed along with its storage, and removed
+	// from the store.
+	c.Assert(s.fakeAddModelAPI.destroyed, jc.DeepEquals, []names.ModelTag{names.NewModelTag("fake-model-uuid")})
+	c.Assert(s.fakeAddModelAPI.destroyStorage, jc.IsTrue)
+	_, err = s.store.ModelByName("test-master", "bob/test")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.store.CurrentModel("test-master")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *AddModelSuite) TestTemplateDeployFailsRestoresCurrentModel(c *gc.C) {
+	err := s.store.UpdateModel("test-master", "bob/other", jujuclient.ModelDetails{ModelUUID: "other-uuid", ModelType: model.IAAS})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentModel("test-master", "bob/other")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fakeAddModelAPI.templates = []params.ModelTemplate{{Name: "team", Bundle: "applications: {}"}}
+	s.deployErr = cmd.ErrSilent
+
+	_, err = s.run(c, "test", "--template", "team")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	current, err := s.store.CurrentModel("test-master")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(current, gc.Equals, "bob/other")
+}
+
+func (s *AddModelSuite) TestTemplateDeployFailsDestroyFails(c *gc.C) {
+	s.fakeAddModelAPI.templates = []params.ModelTemplate{{Name: "team", Bundle: "applications: {}"}}
+	s.fakeAddModelAPI.destroyErr = errors.New("boom")
+	s.deployErr = cmd.ErrSilent
+	ctx, err := s.run(c, "test", "--template", "team")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "Failed to remove model 'test': boom")
+
+	// The model is left in the store so it can be destroyed later.
+	_, err = s.store.ModelByName("test-master", "bob/test")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AddModelSuite) TestTemplateNotFound(c *gc.C) {
+	s.fakeAddModelAPI.templates = []params.ModelTemplate{{Name: "other"}}
+	_, err := s.run(c, "test", "--template", "team")
+	c.Assert(err, gc.ErrorMatches, `model template "team" not found, expected one of: other`)
+	c.Assert(s.fakeAddModelAPI.template, gc.Equals, "")
+}
+
+func (s *AddModelSuite) TestTemplateNotSupported(c *gc.C) {
+	s.fakeAddModelAPI.templatesErr = errors.NotImplementedf("ModelTemplates in version 9")
+	_, err := s.run(c, "test", "--template", "team")
+	c.Assert(err, gc.ErrorMatches, "model templates are not supported by this controller")
+}
+
 // fakeAddClient is used to mock out the behavior of the real
 // AddModel command.
 type fakeAddClient struct {
@@ -704,6 +811,12 @@ type fakeAddClient struct {
 	config          map[string]interface{}
 	err             error
 	model           base.ModelInfo
+	template        string
+	templates       []params.ModelTemplate
+	templatesErr    error
+	destroyed       []names.ModelTag
+	destroyStorage  bool
+	destroyErr      error
 }
 
 var _ controller.AddModelAPI = (*fakeAddClient)(nil)
@@ -724,6 +837,24 @@ func (f *fakeAddClient) CreateModel(name, owner, cloudName, cloudRegion string,
 	return f.model, nil
 }
 
+func (f *fakeAddClient) CreateModelFromTemplate(template, name, owner, cloudName, cloudRegion string, cloudCredential names.CloudCredentialTag, config map[string]interface{}) (base.ModelInfo, error) {
+	f.template = template
+	return f.CreateModel(name, owner, cloudName, cloudRegion, cloudCredential, config)
+}
+
+func (f *fakeAddClient) ModelTemplates() ([]params.ModelTemplate, error) {
+	return f.templates, f.templatesErr
+}
+
+func (f *fakeAddClient) DestroyModel(tag names.ModelTag, destroyStorage, force *bool, maxWait *time.Duration) error {
+	if f.destroyErr != nil {
+		return f.destroyErr
+	}
+	f.destroyed = append(f.destroyed, tag)
+	f.destroyStorage = destroyStorage != nil && *destroyStorage
+	return nil
+}
+
 // TODO(wallyworld) - improve this stub and add test asserts
 type fakeCloudAPI struct {
 	clouds map[names.CloudTag]cloud.Cloud
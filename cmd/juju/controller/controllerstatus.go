@@ -0,0 +1,423 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"path"
+	"sort"
+
+	"github.com/juju/ansiterm"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	coreannotations "github.com/juju/juju/core/annotations"
+)
+
+// NewControllerStatusCommand returns a command that reports the health
+// of every model on a controller.
+func NewControllerStatusCommand() cmd.Command {
+	return modelcmd.WrapController(&controllerStatusCommand{})
+}
+
+// ControllerStatusAPI defines the methods on the controller API that
+// the controller-status command calls.
+type ControllerStatusAPI interface {
+	Close() error
+	ModelSummaries(all bool) ([]params.ModelAbstract, error)
+}
+
+type controllerStatusCommand struct {
+	modelcmd.ControllerCommandBase
+	out      cmd.Output
+	api      ControllerStatusAPI
+	patterns []string
+	all      bool
+	color    bool
+
+	selector    string
+	annotations coreannotations.Selector
+}
+
+const controllerStatusDoc = `
+Report the status of every model on the controller in a single view.
+
+For each model the overall health, the number of machines, applications
+and units, and the number of problems is shown. Problems are machines and
+units in error, and units and applications that are blocked; each one is
+listed with its status message. Blocked applications do not change the
+health of a model.
+
+The report is built from the controller's model cache, so it is cheap to
+run even on controllers with many models.
+
+    juju controller-status [<selector> [...]]
+
+<selector> filters the report, as with 'juju status'. Wildcard characters
+(*) enable multiple models or entities to be matched at the same time.
+
+    ([<owner>/]<model>|<machine>|<unit>|<application>|<status>)[*]
+
+A selector that matches the name of a model, optionally qualified by the
+owner, reports that model with all of its problems. Use this to drill down
+into a single model, and 'juju status -m <model>' for its full status.
+Otherwise, a selector matches problems by the machine, unit or application
+that reported them, or by their status ("error" or "blocked"); only models
+with a matching problem are reported, and only the matching problems are
+listed.
+
+The '--selector' option filters the report by model annotations, which are
+treated as labels. Only models annotated with all of the given key=value
+pairs are reported.
+
+Examples:
+
+    # Report the status of all models the current user can see
+    juju controller-status
+
+    # Report the status of all models, including those of other users
+    juju controller-status --all
+
+    # Only report on models whose name starts with prod-
+    juju controller-status prod-*
+
+    # Drill down into the problems of a single model
+    juju controller-status admin/payments
+
+    # Report the units and machines in error in any model
+    juju controller-status error
+
+    # Report problems with mysql in any model
+    juju controller-status mysql mysql/*
+
+    # Only report on models annotated with team=payments
+    juju controller-status --selector team=payments
+
+See also:
+
+    models
+    status
+    show-controller
+`
+
+// Info implements Command.Info.
+func (c *controllerStatusCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "controller-status",
+		Args:    "[<selector> [...]]",
+		Purpose: "Reports the status of all models on a controller.",
+		Doc:     controllerStatusDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *controllerStatusCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.all, "all", false, "Report on all models, regardless of user accessibility (administrative users only)")
+	f.BoolVar(&c.color, "color", false, "Use ANSI color codes in tabular output")
+	f.StringVar(&c.selector, "selector", "", "Only report models annotated with all of the given key=value pairs")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *controllerStatusCommand) Init(args []string) error {
+	for _, pattern := range args {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Errorf("invalid selector %q", pattern)
+		}
+	}
+	c.patterns = args
+	if c.selector != "" {
+		selector, err := coreannotations.ParseSelector(c.selector)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.annotations = selector
+	}
+	return nil
+}
+
+func (c *controllerStatusCommand) getAPI() (ControllerStatusAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	client, err := c.NewControllerAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return summaryClient{client}, nil
+}
+
+// Run implements Command.Run.
+func (c *controllerStatusCommand) Run(ctx *cmd.Context) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	summaries, err := client.ModelSummaries(c.all)
+	if err != nil {
+		return errors.Annotate(err, "cannot get model summaries")
+	}
+
+	result := formattedControllerStatus{
+		Controller: controllerName,
+		Models:     make(map[string]modelHealth),
+	}
+	for _, summary := range summaries {
+		if summary.Removed {
+			continue
+		}
+		if c.annotations != nil && !c.annotations.Matches(summary.Annotations) {
+			continue
+		}
+		health, ok := c.filter(newModelHealth(summary), summary)
+		if !ok {
+			continue
+		}
+		name := summary.Name
+		if summary.Namespace != "" {
+			name = summary.Namespace + "/" + summary.Name
+		}
+		result.Models[name] = health
+	}
+	if len(result.Models) == 0 && (len(c.patterns) > 0 || c.annotations != nil) {
+		ctx.Infof("No models matched the specified selectors.")
+	}
+	return c.out.Write(ctx, result)
+}
+
+// filter applies the command's patterns to the model. A model whose name
+// matches a pattern is reported in full; otherwise only the problems that
+// match a pattern are kept, and the model is only reported if any do.
+func (c *controllerStatusCommand) filter(health modelHealth, summary params.ModelAbstract) (modelHealth, bool) {
+	if len(c.patterns) == 0 || c.matchesModel(summary) {
+		return health, true
+	}
+	health.MachinesInError = c.matchingProblems(health.MachinesInError)
+	health.ProblemUnits = c.matchingProblems(health.ProblemUnits)
+	health.BlockedApplications = c.matchingProblems(health.BlockedApplications)
+	return health, health.problemCount() > 0
+}
+
+// matchesModel reports whether the model summary is selected by the
+// command's patterns. Patterns without an owner match the model name alone.
+func (c *controllerStatusCommand) matchesModel(summary params.ModelAbstract) bool {
+	qualified := summary.Namespace + "/" + summary.Name
+	for _, pattern := range c.patterns {
+		if ok, _ := path.Match(pattern, summary.Name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, qualified); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchingProblems returns the problems reported by an entity, or with a
+// status, that matches any of the command's patterns. Units also match
+// the name of their application, as with status.
+func (c *controllerStatusCommand) matchingProblems(problems map[string]problem) map[string]problem {
+	var result map[string]problem
+	for entity, p := range problems {
+		candidates := []string{entity, p.Status}
+		if app, err := names.UnitApplication(entity); err == nil {
+			candidates = append(candidates, app)
+		}
+		if !c.matchesAny(candidates...) {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]problem)
+		}
+		result[entity] = p
+	}
+	return result
+}
+
+func (c *controllerStatusCommand) matchesAny(candidates ...string) bool {
+	for _, pattern := range c.patterns {
+		for _, candidate := range candidates {
+			if candidate == "" {
+				continue
+			}
+			if ok, _ := path.Match(pattern, candidate); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type formattedControllerStatus struct {
+	Controller string                 `json:"controller" yaml:"controller"`
+	Models     map[string]modelHealth `json:"models" yaml:"models"`
+}
+
+type modelHealth struct {
+	UUID                string             `json:"model-uuid" yaml:"model-uuid"`
+	Status              string             `json:"status" yaml:"status"`
+	Cloud               string             `json:"cloud,omitempty" yaml:"cloud,omitempty"`
+	Region              string             `json:"region,omitempty" yaml:"region,omitempty"`
+	Machines            int                `json:"machines" yaml:"machines"`
+	Applications        int                `json:"applications" yaml:"applications"`
+	Units               int                `json:"units" yaml:"units"`
+	MachinesInError     map[string]problem `json:"machines-in-error,omitempty" yaml:"machines-in-error,omitempty"`
+	ProblemUnits        map[string]problem `json:"problem-units,omitempty" yaml:"problem-units,omitempty"`
+	BlockedApplications map[string]problem `json:"blocked-applications,omitempty" yaml:"blocked-applications,omitempty"`
+}
+
+type problem struct {
+	Status  string `json:"status,omitempty" yaml:"status,omitempty"`
+	Message string `json:"message" yaml:"message"`
+}
+
+func (m modelHealth) problemCount() int {
+	return len(m.MachinesInError) + len(m.ProblemUnits) + len(m.BlockedApplications)
+}
+
+// newModelHealth classifies the status messages of a model summary by the
+// kind of entity that reported them.
+func newModelHealth(summary params.ModelAbstract) modelHealth {
+	result := modelHealth{
+		UUID:         summary.UUID,
+		Status:       summary.Status,
+		Cloud:        summary.Cloud,
+		Region:       summary.Region,
+		Machines:     summary.Size.Machines,
+		Applications: summary.Size.Applications,
+		Units:        summary.Size.Units,
+	}
+	add := func(m *map[string]problem, msg params.ModelSummaryMessage) {
+		if *m == nil {
+			*m = make(map[string]problem)
+		}
+		(*m)[msg.Agent] = problem{Status: msg.Status, Message: msg.Message}
+	}
+	for _, msg := range summary.Messages {
+		switch {
+		case names.IsValidMachine(msg.Agent):
+			add(&result.MachinesInError, msg)
+		case names.IsValidUnit(msg.Agent):
+			add(&result.ProblemUnits, msg)
+		}
+	}
+	for _, msg := range summary.BlockedApplications {
+		add(&result.BlockedApplications, msg)
+	}
+	return result
+}
+
+func (c *controllerStatusCommand) formatTabular(writer io.Writer, value interface{}) error {
+	status, ok := value.(formattedControllerStatus)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", status, value)
+	}
+	tw := output.TabWriter(writer)
+	if c.color {
+		tw.SetColorCapable(true)
+	}
+	w := output.Wrapper{TabWriter: tw}
+
+	modelNames := make([]string, 0, len(status.Models))
+	for name := range status.Models {
+		modelNames = append(modelNames, name)
+	}
+	sort.Strings(modelNames)
+
+	w.Println("Controller: " + status.Controller)
+	w.Println()
+	w.Println("Model", "Status", "Machines", "Apps", "Units", "Problems")
+	tw.SetColumnAlignRight(2)
+	tw.SetColumnAlignRight(3)
+	tw.SetColumnAlignRight(4)
+	for _, name := range modelNames {
+		model := status.Models[name]
+		w.Print(name)
+		w.PrintColor(healthColors[model.Status], model.Status)
+		w.Println(model.Machines, model.Applications, model.Units, model.problemCount())
+	}
+	tw.Flush()
+
+	var printedHeader bool
+	for _, name := range modelNames {
+		model := status.Models[name]
+		if model.problemCount() == 0 {
+			continue
+		}
+		if !printedHeader {
+			w.Println()
+			w.Println("Model", "Entity", "Kind", "Message")
+			printedHeader = true
+		}
+		printProblems(w, name, "machine", model.MachinesInError)
+		printProblems(w, name, "unit", model.ProblemUnits)
+		printProblems(w, name, "application", model.BlockedApplications)
+	}
+	tw.Flush()
+	return nil
+}
+
+func printProblems(w output.Wrapper, model, kind string, problems map[string]problem) {
+	entities := make([]string, 0, len(problems))
+	for entity := range problems {
+		entities = append(entities, entity)
+	}
+	sort.Strings(entities)
+	for _, entity := range entities {
+		w.Println(model, entity, kind, problems[entity].Message)
+	}
+}
+
+var healthColors = map[string]*ansiterm.Context{
+	"green":  output.GoodHighlight,
+	"yellow": output.WarningHighlight,
+	"red":    output.ErrorHighlight,
+}
+
+// summaryClient adapts the controller API client to return the current
+// model summaries rather than a watcher over them.
+type summaryClient struct {
+	*apicontroller.Client
+}
+
+// ModelSummaries returns the summaries of the models that the user can
+// see, or of all models on the controller if all is true.
+func (c summaryClient) ModelSummaries(all bool) (_ []params.ModelAbstract, err error) {
+	var watcher *apicontroller.SummaryWatcher
+	if all {
+		watcher, err = c.WatchAllModelSummaries()
+	} else {
+		watcher, err = c.WatchModelSummaries()
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if stopErr := watcher.Stop(); err == nil {
+			err = errors.Trace(stopErr)
+		}
+	}()
+	// The first result from the watcher is always the full set of
+	// models visible to the user.
+	summaries, err := watcher.Next()
+	return summaries, errors.Trace(err)
+}
@@ -0,0 +1,203 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+)
+
+type controllerStatusSuite struct {
+	baseControllerSuite
+	api   *fakeControllerStatusAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&controllerStatusSuite{})
+
+func (s *controllerStatusSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeControllerStatusAPI{
+		summaries: []params.ModelAbstract{{
+			UUID:      "model-1-uuid",
+			Namespace: "admin",
+			Name:      "payments",
+			Cloud:     "aws",
+			Status:    "red",
+			Size: params.ModelSummarySize{
+				Machines:     2,
+				Applications: 2,
+				Units:        3,
+			},
+			Messages: []params.ModelSummaryMessage{
+				{Agent: "1", Status: "error", Message: "instance not found"},
+				{Agent: "mysql/0", Status: "error", Message: "hook failed: \"install\""},
+				{Agent: "mysql/1", Status: "blocked", Message: "waiting for peer"},
+			},
+			BlockedApplications: []params.ModelSummaryMessage{
+				{Agent: "wordpress", Status: "blocked", Message: "missing database"},
+			},
+			Annotations: map[string]string{"team": "payments"},
+		}, {
+			UUID:      "model-2-uuid",
+			Namespace: "bob",
+			Name:      "staging",
+			Cloud:     "aws",
+			Status:    "green",
+			Size: params.ModelSummarySize{
+				Machines:     1,
+				Applications: 1,
+				Units:        1,
+			},
+		}, {
+			UUID:    "model-3-uuid",
+			Removed: true,
+		}},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *controllerStatusSuite) newCommand() cmd.Command {
+	return controller.NewControllerStatusCommandForTest(s.api, s.store)
+}
+
+func (s *controllerStatusSuite) TestTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.all, jc.IsFalse)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Controller: fake
+
+Model           Status  Machines  Apps  Units  Problems
+admin/payments  red            2     2      3  4
+bob/staging     green          1     1      1  0
+
+Model           Entity     Kind         Message
+admin/payments  1          machine      instance not found
+admin/payments  mysql/0    unit         hook failed: "install"
+admin/payments  mysql/1    unit         waiting for peer
+admin/payments  wordpress  application  missing database
+
+`[1:])
+}
+
+func (s *controllerStatusSuite) TestYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--all", "--format", "yaml", "staging")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.all, jc.IsTrue)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+controller: fake
+models:
+  bob/staging:
+    model-uuid: model-2-uuid
+    status: green
+    cloud: aws
+    machines: 1
+    applications: 1
+    units: 1
+`[1:])
+}
+
+func (s *controllerStatusSuite) TestSelectorWithOwner(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "json", "admin/pay*")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, `"admin/payments"`)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Not(jc.Contains), `"bob/staging"`)
+}
+
+func (s *controllerStatusSuite) TestStatusSelector(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "yaml", "blocked")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+controller: fake
+models:
+  admin/payments:
+    model-uuid: model-1-uuid
+    status: red
+    cloud: aws
+    machines: 2
+    applications: 2
+    units: 3
+    problem-units:
+      mysql/1:
+        status: blocked
+        message: waiting for peer
+    blocked-applications:
+      wordpress:
+        status: blocked
+        message: missing database
+`[1:])
+}
+
+func (s *controllerStatusSuite) TestEntitySelector(c *gc.C) {
+	// A unit matches the name of its application.
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "mysql", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Controller: fake
+
+Model           Status  Machines  Apps  Units  Problems
+admin/payments  red            2     2      3  3
+
+Model           Entity   Kind     Message
+admin/payments  1        machine  instance not found
+admin/payments  mysql/0  unit     hook failed: "install"
+admin/payments  mysql/1  unit     waiting for peer
+
+`[1:])
+}
+
+func (s *controllerStatusSuite) TestAnnotationSelector(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "json", "--selector", "team=payments")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, `"admin/payments"`)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Not(jc.Contains), `"bob/staging"`)
+}
+
+func (s *controllerStatusSuite) TestInvalidAnnotationSelector(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--selector", "team")
+	c.Assert(err, gc.ErrorMatches, `selector term "team": expected key=value not valid`)
+}
+
+func (s *controllerStatusSuite) TestNoMatches(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "prod-*")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No models matched the specified selectors.\n")
+}
+
+func (s *controllerStatusSuite) TestInvalidSelector(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "[")
+	c.Assert(err, gc.ErrorMatches, `invalid selector "\["`)
+}
+
+func (s *controllerStatusSuite) TestAPIError(c *gc.C) {
+	s.api.err = apiservererrors.ErrPerm
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--all")
+	c.Assert(err, gc.ErrorMatches, "cannot get model summaries: permission denied")
+}
+
+type fakeControllerStatusAPI struct {
+	summaries []params.ModelAbstract
+	all       bool
+	err       error
+}
+
+func (f *fakeControllerStatusAPI) Close() error {
+	return nil
+}
+
+func (f *fakeControllerStatusAPI) ModelSummaries(all bool) ([]params.ModelAbstract, error) {
+	f.all = all
+	return f.summaries, f.err
+}
@@ -62,6 +62,39 @@ func NewAddModelCommandForTest(
 	return modelcmd.WrapController(c), &AddModelCommand{c}
 }
 
+// SetDeployBundle replaces the function used to deploy the bundle of
+// a model template.
+func (c *AddModelCommand) SetDeployBundle(f func(*cmd.Context, jujuclient.ClientStore, string, string) error) {
+	c.deployBundle = f
+}
+
+// NewAddModelTemplateCommandForTest returns an add-model-template
+// command with the API provided as specified.
+func NewAddModelTemplateCommandForTest(api ModelTemplateAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &addModelTemplateCommand{}
+	c.api = api
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewModelTemplatesCommandForTest returns a model-templates command
+// with the API provided as specified.
+func NewModelTemplatesCommandForTest(api ModelTemplateAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &modelTemplatesCommand{}
+	c.api = api
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewRemoveModelTemplateCommandForTest returns a remove-model-template
+// command with the API provided as specified.
+func NewRemoveModelTemplateCommandForTest(api ModelTemplateAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &removeModelTemplateCommand{}
+	c.api = api
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewListModelsCommandForTest returns a ListModelsCommand with the API
 // and userCreds provided as specified.
 func NewListModelsCommandForTest(modelAPI ModelManagerAPI, sysAPI ModelsSysAPI, store jujuclient.ClientStore) cmd.Command {
@@ -93,6 +126,16 @@ func NewEnableDestroyControllerCommandForTest(api removeBlocksAPI, store jujucli
 	return modelcmd.WrapController(c)
 }
 
+// NewControllerStatusCommandForTest returns a controllerStatusCommand with
+// the API used to get the model summaries mocked out.
+func NewControllerStatusCommandForTest(api ControllerStatusAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &controllerStatusCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the controller and
 // client endpoints mocked out.
 func NewDestroyCommandForTest(
@@ -250,6 +250,7 @@ type ModelSummary struct {
 	SLA          string           `json:"sla,omitempty" yaml:"sla,omitempty"`
 	SLAOwner     string           `json:"sla-owner,omitempty" yaml:"sla-owner,omitempty"`
 	AgentVersion string           `json:"agent-version,omitempty" yaml:"agent-version,omitempty"`
+	Expires      string           `json:"expires,omitempty" yaml:"expires,omitempty"`
 }
 
 func (c *modelsCommand) modelSummaryFromParams(apiSummary base.UserModelSummary, now time.Time) (ModelSummary, error) {
@@ -319,6 +320,14 @@ func (c *modelsCommand) modelSummaryFromParams(apiSummary base.UserModelSummary,
 		summary.SLA = apiSummary.SLA.Level
 		summary.SLAOwner = apiSummary.SLA.Owner
 	}
+	if apiSummary.Expiry != nil {
+		if c.exactTime {
+			summary.Expires = apiSummary.Expiry.String()
+		} else {
+			summary.Expires = common.UserFriendlyTimeUntil(*apiSummary.Expiry, now)
+		}
+		c.runVars.hasExpiry = true
+	}
 	summary.Counts = map[string]int64{}
 	for _, v := range apiSummary.Counts {
 		summary.Counts[v.Entity] = v.Count
@@ -354,6 +363,7 @@ type modelsRunValues struct {
 	hasMachinesCount bool
 	hasCoresCount    bool
 	hasUnitsCount    bool
+	hasExpiry        bool
 }
 
 // formatTabular takes an interface{} to adhere to the cmd.Formatter interface
@@ -398,6 +408,10 @@ func (c *modelsCommand) tabularColumns(tw *ansiterm.TabWriter, w output.Wrapper)
 		printColumnHeader("Units", 5)
 	}
 
+	if c.runVars.hasExpiry {
+		w.Print("Expires")
+	}
+
 	w.Println("Access", "Last connection")
 }
 
@@ -450,6 +464,13 @@ func (c *modelsCommand) tabularSummaries(writer io.Writer, modelSet ModelSummary
 				w.Print("-")
 			}
 		}
+		if c.runVars.hasExpiry {
+			expires := model.Expires
+			if expires == "" {
+				expires = "-"
+			}
+			w.Print(expires)
+		}
 		access := model.UserAccess
 		if access == "" {
 			access = "-"
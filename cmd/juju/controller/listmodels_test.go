@@ -127,6 +127,7 @@ func (f *fakeModelMgrAPIClient) ListModelSummaries(user string, all bool) ([]bas
 				Since:  info.Result.Status.Since,
 			},
 			AgentVersion: info.Result.AgentVersion,
+			Expiry:       info.Result.Expiry,
 		}
 		if info.Result.Migration != nil {
 			migration := info.Result.Migration
@@ -694,6 +695,24 @@ func (s *ModelsSuiteV4) TestModelWithUnits(c *gc.C) {
 	s.checkAPICalls(c, "BestAPIVersion", "ListModels", "ModelInfo", "Close")
 }
 
+func (s *ModelsSuiteV4) TestModelWithExpiry(c *gc.C) {
+	expiry := time.Date(2100, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.api.infos[1].Result.Expiry = &expiry
+
+	context, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"Controller: fake\n"+
+		"\n"+
+		"Model                        Cloud/Region  Type   Status      Expires     Access  Last connection\n"+
+		"test-model1*                 dummy         local  active      -           read    2015-03-20\n"+
+		"carlotta/test-model2         dummy         local  active      2100-01-01  write   2015-03-01\n"+
+		"daiwik@external/test-model3  dummy         local  destroying  -           -       never connected\n"+
+		"\n")
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, "")
+	s.checkAPICalls(c, "BestAPIVersion", "ListModels", "ModelInfo", "Close")
+}
+
 func (s *ModelsSuiteV4) TestModelsJson(c *gc.C) {
 	context, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "json")
 	c.Assert(err, jc.ErrorIsNil)
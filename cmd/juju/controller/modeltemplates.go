@@ -0,0 +1,507 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/constraints"
+)
+
+// ModelTemplateAPI defines the API methods used by the model
+// template commands.
+type ModelTemplateAPI interface {
+	Close() error
+	AddModelTemplate(params.ModelTemplate) error
+	ModelTemplates() ([]params.ModelTemplate, error)
+	RemoveModelTemplate(name string) error
+}
+
+// modelTemplateCommandBase is the base for the commands that manage
+// model templates.
+type modelTemplateCommandBase struct {
+	modelcmd.ControllerCommandBase
+	api ModelTemplateAPI
+}
+
+func (c *modelTemplateCommandBase) getAPI() (ModelTemplateAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return modelmanager.NewClient(root), nil
+}
+
+// modelTemplate is the user facing form of a model template, used
+// both for the template file read by add-model-template and for the
+// output of model-templates.
+type modelTemplate struct {
+	Description   string                 `yaml:"description,omitempty" json:"description,omitempty"`
+	Config        map[string]interface{} `yaml:"config,omitempty" json:"config,omitempty"`
+	Constraints   string                 `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	Users         map[string]string      `yaml:"users,omitempty" json:"users,omitempty"`
+	FirewallRules map[string][]string    `yaml:"firewall-rules,omitempty" json:"firewall-rules,omitempty"`
+	Spaces        []string               `yaml:"spaces,omitempty" json:"spaces,omitempty"`
+	Blocks        map[string]string      `yaml:"blocks,omitempty" json:"blocks,omitempty"`
+	Bundle        string                 `yaml:"bundle,omitempty" json:"bundle,omitempty"`
+	CreatedBy     string                 `yaml:"created-by,omitempty" json:"created-by,omitempty"`
+	Created       *time.Time             `yaml:"created,omitempty" json:"created,omitempty"`
+}
+
+func (t modelTemplate) toParams(name string) (params.ModelTemplate, error) {
+	result := params.ModelTemplate{
+		Name:        name,
+		Description: t.Description,
+		Spaces:      t.Spaces,
+		Bundle:      t.Bundle,
+	}
+	if len(t.Config) > 0 {
+		coerced, err := common.ConformYAML(t.Config)
+		if err != nil {
+			return result, errors.Annotate(err, "invalid config")
+		}
+		result.Config = coerced.(map[string]interface{})
+	}
+	cons, err := constraints.Parse(t.Constraints)
+	if err != nil {
+		return result, errors.Annotate(err, "invalid constraints")
+	}
+	result.Constraints = cons
+
+	for _, user := range sortedKeys(t.Users) {
+		if !names.IsValidUser(user) {
+			return result, errors.NotValidf("user name %q", user)
+		}
+		result.Users = append(result.Users, params.ModelTemplateUser{
+			UserTag: names.NewUserTag(user).String(),
+			Access:  t.Users[user],
+		})
+	}
+	services := make([]string, 0, len(t.FirewallRules))
+	for service := range t.FirewallRules {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	for _, service := range services {
+		knownService := params.KnownServiceValue(service)
+		if err := knownService.Validate(); err != nil {
+			return result, errors.Trace(err)
+		}
+		result.FirewallRules = append(result.FirewallRules, params.FirewallRule{
+			KnownService:   knownService,
+			WhitelistCIDRS: t.FirewallRules[service],
+		})
+	}
+	for _, space := range t.Spaces {
+		if !names.IsValidSpace(space) {
+			return result, errors.NotValidf("space name %q", space)
+		}
+	}
+	if len(t.Blocks) > 0 {
+		result.Blocks = make(map[string]string, len(t.Blocks))
+	}
+	for commandSet, msg := range t.Blocks {
+		blockType, err := block.BlockTypeForCommandSet(commandSet)
+		if err != nil {
+			return result, errors.Annotate(err, "invalid block")
+		}
+		result.Blocks[blockType] = msg
+	}
+	return result, nil
+}
+
+func modelTemplateFromParams(t params.ModelTemplate) modelTemplate {
+	result := modelTemplate{
+		Description: t.Description,
+		Config:      t.Config,
+		Spaces:      t.Spaces,
+		Bundle:      t.Bundle,
+		CreatedBy:   t.CreatedBy,
+	}
+	if !constraints.IsEmpty(&t.Constraints) {
+		result.Constraints = t.Constraints.String()
+	}
+	if !t.Created.IsZero() {
+		created := t.Created.UTC()
+		result.Created = &created
+	}
+	if len(t.Users) > 0 {
+		result.Users = make(map[string]string, len(t.Users))
+	}
+	for _, user := range t.Users {
+		tag, err := names.ParseUserTag(user.UserTag)
+		if err != nil {
+			continue
+		}
+		result.Users[tag.Id()] = user.Access
+	}
+	if len(t.FirewallRules) > 0 {
+		result.FirewallRules = make(map[string][]string, len(t.FirewallRules))
+	}
+	for _, rule := range t.FirewallRules {
+		result.FirewallRules[string(rule.KnownService)] = rule.WhitelistCIDRS
+	}
+	if len(t.Blocks) > 0 {
+		result.Blocks = make(map[string]string, len(t.Blocks))
+	}
+	for blockType, msg := range t.Blocks {
+		result.Blocks[block.CommandSetForBlockType(blockType)] = msg
+	}
+	return result
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+const addModelTemplateHelpDoc = `
+Adds a named template from which models may be created with
+"juju add-model --template". The template is stored on the controller,
+so that every user creating a model from it gets the same settings.
+
+The template is read from a YAML file of the following form; every
+section is optional:
+
+    description: Standard team model
+    config:
+      logging-config: <root>=INFO
+      update-status-hook-interval: 10m
+    constraints: cores=2 mem=4G
+    users:
+      alice: admin
+      bob: write
+      carol@external: read
+    firewall-rules:
+      ssh: [10.0.0.0/8, 192.168.1.0/24]
+    spaces: [db, public]
+    blocks:
+      destroy-model: Team models are long lived
+      remove-object: ""
+    bundle: ./team-bundle.yaml
+
+The config, constraints, user access, firewall rules, spaces and blocks
+(named as for "juju disable-command") are applied as part of creating
+the model, so a model created from the template never exists without
+them. The spaces are created empty; subnets are moved into them with
+"juju move-to-space" once they have been discovered. Config passed to
+"juju add-model" takes precedence over that of the template. The
+bundle, a path relative to the template file, is read now and stored
+with the template; it is deployed into each model once the model has
+been created, and the model is destroyed again if the deployment
+fails.
+
+Only controller administrators may add model templates.
+
+Examples:
+
+    juju add-model-template team team-template.yaml
+
+See also:
+    add-model
+    model-templates
+    remove-model-template
+`
+
+// NewAddModelTemplateCommand returns a command to add a model template.
+func NewAddModelTemplateCommand() cmd.Command {
+	return modelcmd.WrapController(&addModelTemplateCommand{})
+}
+
+// addModelTemplateCommand stores a new model template on the controller.
+type addModelTemplateCommand struct {
+	modelTemplateCommandBase
+
+	name string
+	file cmd.FileVar
+}
+
+// Info implements Command.Info.
+func (c *addModelTemplateCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "add-model-template",
+		Args:    "<template name> <template file>",
+		Purpose: "Adds a template from which models may be created.",
+		Doc:     strings.TrimSpace(addModelTemplateHelpDoc),
+	})
+}
+
+// Init implements Command.Init.
+func (c *addModelTemplateCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no template name specified")
+	case 1:
+		return errors.New("no template file specified")
+	}
+	c.name = args[0]
+	if !names.IsValidModelName(c.name) {
+		return errors.Errorf("%q is not a valid template name: template names may only contain lowercase letters, digits and hyphens", c.name)
+	}
+	c.file.Path = args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements Command.Run.
+func (c *addModelTemplateCommand) Run(ctx *cmd.Context) error {
+	template, err := c.readTemplate(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := client.AddModelTemplate(template); err != nil {
+		if errors.IsNotImplemented(err) {
+			return errors.New("model templates are not supported by this controller")
+		}
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "add a model template")
+		}
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Added model template %q", c.name)
+	return nil
+}
+
+func (c *addModelTemplateCommand) readTemplate(ctx *cmd.Context) (params.ModelTemplate, error) {
+	data, err := c.file.Read(ctx)
+	if err != nil {
+		return params.ModelTemplate{}, errors.Trace(err)
+	}
+	var template modelTemplate
+	if err := yaml.UnmarshalStrict(data, &template); err != nil {
+		return params.ModelTemplate{}, errors.Annotatef(err, "cannot parse %q", c.file.Path)
+	}
+	if template.CreatedBy != "" || template.Created != nil {
+		return params.ModelTemplate{}, errors.Errorf("cannot parse %q: created and created-by are set by the controller", c.file.Path)
+	}
+	if template.Bundle != "" {
+		path := template.Bundle
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(ctx.AbsPath(c.file.Path)), path)
+		}
+		bundle, err := ioutil.ReadFile(path)
+		if err != nil {
+			return params.ModelTemplate{}, errors.Annotate(err, "cannot read bundle")
+		}
+		template.Bundle = string(bundle)
+	}
+	result, err := template.toParams(c.name)
+	if err != nil {
+		return params.ModelTemplate{}, errors.Annotatef(err, "cannot parse %q", c.file.Path)
+	}
+	return result, nil
+}
+
+const modelTemplatesHelpDoc = `
+Lists the model templates stored on the controller, from which models
+may be created with "juju add-model --template".
+
+The tabular format summarises each template; use --format yaml to see
+the full content of the templates, including their bundles.
+
+Examples:
+
+    juju model-templates
+    juju model-templates --format yaml
+
+See also:
+    add-model
+    add-model-template
+    remove-model-template
+`
+
+// NewModelTemplatesCommand returns a command to list model templates.
+func NewModelTemplatesCommand() cmd.Command {
+	return modelcmd.WrapController(&modelTemplatesCommand{})
+}
+
+// modelTemplatesCommand lists the model templates on the controller.
+type modelTemplatesCommand struct {
+	modelTemplateCommandBase
+	out cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *modelTemplatesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "model-templates",
+		Purpose: "Lists the templates from which models may be created.",
+		Doc:     strings.TrimSpace(modelTemplatesHelpDoc),
+		Aliases: []string{"list-model-templates"},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *modelTemplatesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.modelTemplateCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *modelTemplatesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	templates, err := client.ModelTemplates()
+	if errors.IsNotImplemented(err) {
+		return errors.New("model templates are not supported by this controller")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if len(templates) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No model templates to display.")
+		return nil
+	}
+	result := make(map[string]modelTemplate, len(templates))
+	for _, t := range templates {
+		result[t.Name] = modelTemplateFromParams(t)
+	}
+	return c.out.Write(ctx, result)
+}
+
+func (c *modelTemplatesCommand) formatTabular(writer io.Writer, value interface{}) error {
+	templates, ok := value.(map[string]modelTemplate)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", templates, value)
+	}
+	templateNames := make([]string, 0, len(templates))
+	for name := range templates {
+		templateNames = append(templateNames, name)
+	}
+	sort.Strings(templateNames)
+
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Name\tUsers\tBundle\tCreated by\tDescription")
+	for _, name := range templateNames {
+		t := templates[name]
+		bundle := "-"
+		if t.Bundle != "" {
+			bundle = "yes"
+		}
+		users := "-"
+		if len(t.Users) > 0 {
+			users = fmt.Sprint(len(t.Users))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", name, users, bundle, t.CreatedBy, t.Description)
+	}
+	return tw.Flush()
+}
+
+const removeModelTemplateHelpDoc = `
+Removes a model template from the controller. Models previously created
+from the template are unaffected.
+
+Only controller administrators may remove model templates.
+
+Examples:
+
+    juju remove-model-template team
+
+See also:
+    add-model-template
+    model-templates
+`
+
+// NewRemoveModelTemplateCommand returns a command to remove a model
+// template.
+func NewRemoveModelTemplateCommand() cmd.Command {
+	return modelcmd.WrapController(&removeModelTemplateCommand{})
+}
+
+// removeModelTemplateCommand removes a model template from the controller.
+type removeModelTemplateCommand struct {
+	modelTemplateCommandBase
+	name string
+}
+
+// Info implements Command.Info.
+func (c *removeModelTemplateCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove-model-template",
+		Args:    "<template name>",
+		Purpose: "Removes a model template.",
+		Doc:     strings.TrimSpace(removeModelTemplateHelpDoc),
+	})
+}
+
+// Init implements Command.Init.
+func (c *removeModelTemplateCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no template name specified")
+	}
+	c.name = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *removeModelTemplateCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := client.RemoveModelTemplate(c.name); err != nil {
+		if errors.IsNotImplemented(err) {
+			return errors.New("model templates are not supported by this controller")
+		}
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	ctx.Infof("Removed model template %q", c.name)
+	return nil
+}
+
+// findModelTemplate returns the named template from those given.
+func findModelTemplate(templates []params.ModelTemplate, name string) (params.ModelTemplate, error) {
+	templateNames := make([]string, len(templates))
+	for i, t := range templates {
+		if t.Name == name {
+			return t, nil
+		}
+		templateNames[i] = t.Name
+	}
+	if len(templateNames) == 0 {
+		return params.ModelTemplate{}, errors.NotFoundf("model template %q", name)
+	}
+	return params.ModelTemplate{}, errors.NewNotFound(nil, fmt.Sprintf(
+		"model template %q not found, expected one of: %s", name, strings.Join(templateNames, ", ")))
+}
@@ -0,0 +1,236 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/jujuclient"
+)
+
+type modelTemplatesSuite struct {
+	baseControllerSuite
+	api   *fakeModelTemplateAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&modelTemplatesSuite{})
+
+func (s *modelTemplatesSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeModelTemplateAPI{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+const teamTemplateYAML = `
+description: Standard team model
+config:
+  logging-config: <root>=INFO
+constraints: mem=4G
+users:
+  bob: write
+  carol@external: read
+firewall-rules:
+  ssh: [10.0.0.0/8]
+spaces: [db]
+blocks:
+  destroy-model: keep me
+bundle: bundle.yaml
+`
+
+func (s *modelTemplatesSuite) writeTemplate(c *gc.C, content string) string {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "bundle.yaml"), []byte("applications: {}\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	path := filepath.Join(dir, "template.yaml")
+	err = ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *modelTemplatesSuite) TestAdd(c *gc.C) {
+	path := s.writeTemplate(c, teamTemplateYAML)
+	ctx, err := cmdtesting.RunCommand(c, controller.NewAddModelTemplateCommandForTest(s.api, s.store), "team", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Added model template \"team\"\n")
+	c.Assert(s.api.added, jc.DeepEquals, []params.ModelTemplate{{
+		Name:        "team",
+		Description: "Standard team model",
+		Config:      map[string]interface{}{"logging-config": "<root>=INFO"},
+		Constraints: constraints.MustParse("mem=4G"),
+		Users: []params.ModelTemplateUser{
+			{UserTag: "user-bob", Access: "write"},
+			{UserTag: "user-carol@external", Access: "read"},
+		},
+		FirewallRules: []params.FirewallRule{
+			{KnownService: params.SSHRule, WhitelistCIDRS: []string{"10.0.0.0/8"}},
+		},
+		Spaces: []string{"db"},
+		Blocks: map[string]string{"BlockDestroy": "keep me"},
+		Bundle: "applications: {}\n",
+	}})
+}
+
+func (s *modelTemplatesSuite) TestAddInvalid(c *gc.C) {
+	for i, test := range []struct {
+		content string
+		err     string
+	}{{
+		content: "unknown: field",
+		err:     `cannot parse ".*": yaml: unmarshal errors:\n.*field unknown not found.*`,
+	}, {
+		content: "blocks:\n  everything: nope",
+		err:     `cannot parse ".*": invalid block: .*`,
+	}, {
+		content: "firewall-rules:\n  telnet: [10.0.0.0/8]",
+		err:     `cannot parse ".*": .*telnet.*`,
+	}, {
+		content: "spaces: [Not Valid]",
+		err:     `cannot parse ".*": space name "Not Valid" not valid`,
+	}, {
+		content: "created-by: admin",
+		err:     `cannot parse ".*": created and created-by are set by the controller`,
+	}, {
+		content: "bundle: missing.yaml",
+		err:     `cannot read bundle: .*`,
+	}} {
+		c.Logf("test %d: %s", i, test.content)
+		path := s.writeTemplate(c, test.content)
+		_, err := cmdtesting.RunCommand(c, controller.NewAddModelTemplateCommandForTest(s.api, s.store), "team", path)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Assert(s.api.added, gc.HasLen, 0)
+}
+
+func (s *modelTemplatesSuite) TestAddInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, controller.NewAddModelTemplateCommandForTest(s.api, s.store))
+	c.Assert(err, gc.ErrorMatches, "no template name specified")
+	_, err = cmdtesting.RunCommand(c, controller.NewAddModelTemplateCommandForTest(s.api, s.store), "team")
+	c.Assert(err, gc.ErrorMatches, "no template file specified")
+	_, err = cmdtesting.RunCommand(c, controller.NewAddModelTemplateCommandForTest(s.api, s.store), "Team", "file")
+	c.Assert(err, gc.ErrorMatches, `"Team" is not a valid template name: .*`)
+}
+
+func (s *modelTemplatesSuite) TestAddNotSupported(c *gc.C) {
+	s.api.err = errors.NotImplementedf("AddModelTemplate in version 9")
+	path := s.writeTemplate(c, "description: team")
+	_, err := cmdtesting.RunCommand(c, controller.NewAddModelTemplateCommandForTest(s.api, s.store), "team", path)
+	c.Assert(err, gc.ErrorMatches, "model templates are not supported by this controller")
+}
+
+func (s *modelTemplatesSuite) setTemplates() {
+	s.api.templates = []params.ModelTemplate{{
+		Name:        "team",
+		Description: "Standard team model",
+		Constraints: constraints.MustParse("mem=4G"),
+		Users: []params.ModelTemplateUser{
+			{UserTag: "user-bob", Access: "write"},
+		},
+		Blocks:    map[string]string{"BlockDestroy": "keep me"},
+		Bundle:    "applications: {}\n",
+		CreatedBy: "admin",
+		Created:   time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC),
+	}, {
+		Name:      "bare",
+		CreatedBy: "admin",
+		Created:   time.Date(2020, 5, 2, 10, 0, 0, 0, time.UTC),
+	}}
+}
+
+func (s *modelTemplatesSuite) TestListTabular(c *gc.C) {
+	s.setTemplates()
+	ctx, err := cmdtesting.RunCommand(c, controller.NewModelTemplatesCommandForTest(s.api, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Name  Users  Bundle  Created by  Description\n"+
+		"bare  -      -       admin       \n"+
+		"team  1      yes     admin       Standard team model\n"+
+		"\n")
+}
+
+func (s *modelTemplatesSuite) TestListYAML(c *gc.C) {
+	s.setTemplates()
+	ctx, err := cmdtesting.RunCommand(c, controller.NewModelTemplatesCommandForTest(s.api, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+bare:
+  created-by: admin
+  created: 2020-05-02T10:00:00Z
+team:
+  description: Standard team model
+  constraints: mem=4096M
+  users:
+    bob: write
+  blocks:
+    destroy-model: keep me
+  bundle: |
+    applications: {}
+  created-by: admin
+  created: 2020-05-01T10:00:00Z
+`[1:])
+}
+
+func (s *modelTemplatesSuite) TestListEmpty(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, controller.NewModelTemplatesCommandForTest(s.api, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No model templates to display.\n")
+}
+
+func (s *modelTemplatesSuite) TestRemove(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, controller.NewRemoveModelTemplateCommandForTest(s.api, s.store), "team")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Removed model template \"team\"\n")
+	c.Assert(s.api.removed, jc.DeepEquals, []string{"team"})
+}
+
+func (s *modelTemplatesSuite) TestRemoveNotFound(c *gc.C) {
+	s.api.err = &params.Error{Code: params.CodeNotFound, Message: `model template "team" not found`}
+	_, err := cmdtesting.RunCommand(c, controller.NewRemoveModelTemplateCommandForTest(s.api, s.store), "team")
+	c.Assert(err, gc.ErrorMatches, `model template "team" not found`)
+}
+
+type fakeModelTemplateAPI struct {
+	templates []params.ModelTemplate
+	added     []params.ModelTemplate
+	removed   []string
+	err       error
+}
+
+func (f *fakeModelTemplateAPI) Close() error {
+	return nil
+}
+
+func (f *fakeModelTemplateAPI) AddModelTemplate(t params.ModelTemplate) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.added = append(f.added, t)
+	return nil
+}
+
+func (f *fakeModelTemplateAPI) ModelTemplates() ([]params.ModelTemplate, error) {
+	return f.templates, f.err
+}
+
+func (f *fakeModelTemplateAPI) RemoveModelTemplate(name string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.removed = append(f.removed, name)
+	return nil
+}
@@ -5,6 +5,7 @@ package crossmodel
 
 import (
 	"fmt"
+	"io"
 	"regexp"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/jujuclient"
 )
@@ -32,6 +34,9 @@ The summary output shows one row per offer, with a count of active/total relatio
 The YAML output shows additional information about the source of connections, including
 the source model UUID.
 
+The columns shown by the tabular and summary output can be chosen with --columns,
+and the rows sorted with --sort.
+
 The output can be filtered by:
  - interface: the interface name of the endpoint
  - application: the name of the offered application
@@ -48,6 +53,7 @@ Examples:
     $ juju offers --allowed-consumer mary
     $ juju offers hosted-mysql
     $ juju offers hosted-mysql --active-only
+    $ juju offers --columns offer,user,status --sort status
 
 See also:
    find-offers   
@@ -58,7 +64,8 @@ See also:
 type listCommand struct {
 	modelcmd.ModelCommandBase
 
-	out cmd.Output
+	out   cmd.Output
+	table output.TableFlags
 
 	newAPIFunc    func() (ListAPI, error)
 	refreshModels func(jujuclient.ClientStore, string) error
@@ -122,11 +129,16 @@ func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.connectedUserName, "connected-user", "", "return results where the user has a connection to the offer")
 	f.BoolVar(&c.activeOnly, "active-only", false, "only return results where the offer is in use")
 	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
-		"yaml":    cmd.FormatYaml,
-		"json":    cmd.FormatJson,
-		"tabular": formatListTabular,
-		"summary": formatListSummary,
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+		"tabular": func(writer io.Writer, value interface{}) error {
+			return formatListTabular(writer, c.table.Options(), value)
+		},
+		"summary": func(writer io.Writer, value interface{}) error {
+			return formatListSummary(writer, c.table.Options(), value)
+		},
 	})
+	c.table.AddFlags(f)
 }
 
 // Run implements Command.Run.
@@ -203,6 +203,24 @@ zdiff-db2   fred  1            joined  server    mysql      provider
 	)
 }
 
+func (s *ListSuite) TestListTabularColumnsAndSort(c *gc.C) {
+	s.setupListTabular()
+	s.assertValidList(
+		c,
+		[]string{"--format", "tabular", "--columns", "offer,user,endpoint", "--sort", "-offer"},
+		`
+Offer       User  Endpoint
+zdiff-db2   fred  server
+            mary  server
+            mary  db
+hosted-db2  -     
+adiff-db2   mary  db
+
+`[1:],
+		"",
+	)
+}
+
 func (s *ListSuite) TestListTabularActiveOnly(c *gc.C) {
 	s.setupListTabular()
 	s.assertValidList(
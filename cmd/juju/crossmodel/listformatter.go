@@ -18,21 +18,18 @@ import (
 
 // formatListSummary returns a tabular summary of remote application offers or
 // errors out if parameter is not of expected type.
-func formatListSummary(writer io.Writer, value interface{}) error {
+func formatListSummary(writer io.Writer, opts output.TableOptions, value interface{}) error {
 	offers, ok := value.(offeredApplications)
 	if !ok {
 		return errors.Errorf("expected value of type %T, got %T", offers, value)
 	}
-	return formatListEndpointsSummary(writer, offers)
+	return formatListEndpointsSummary(writer, opts, offers)
 }
 
 type offerItems []ListOfferItem
 
 // formatListEndpointsSummary returns a tabular summary of listed applications' endpoints.
-func formatListEndpointsSummary(writer io.Writer, offers offeredApplications) error {
-	tw := output.TabWriter(writer)
-	w := output.Wrapper{tw}
-
+func formatListEndpointsSummary(writer io.Writer, opts output.TableOptions, offers offeredApplications) error {
 	// Sort offers by source then application name.
 	allOffers := offerItems{}
 	for _, offer := range offers {
@@ -40,7 +37,7 @@ func formatListEndpointsSummary(writer io.Writer, offers offeredApplications) er
 	}
 	sort.Sort(allOffers)
 
-	w.Println("Offer", "Application", "Charm", "Connected", "Store", "URL", "Endpoint", "Interface", "Role")
+	table := output.NewTable("Offer", "Application", "Charm", "Connected", "Store", "URL", "Endpoint", "Interface", "Role")
 	for _, offer := range allOffers {
 		// Sort endpoints alphabetically.
 		endpoints := []string{}
@@ -61,18 +58,17 @@ func formatListEndpointsSummary(writer io.Writer, offers offeredApplications) er
 						activeConnectedCount++
 					}
 				}
-				w.Println(offer.OfferName, offer.ApplicationName, offer.CharmURL,
+				table.AddRow(offer.OfferName, offer.ApplicationName, offer.CharmURL,
 					fmt.Sprintf("%v/%v", activeConnectedCount, totalConnectedCount),
 					offer.Source, offer.OfferURL, endpointName, endpoint.Interface, endpoint.Role)
 				continue
 			}
 			// Subsequent lines only need to display endpoint information.
 			// This will display less noise.
-			w.Println("", "", "", "", "", "", endpointName, endpoint.Interface, endpoint.Role)
+			table.AddDetailRow("", "", "", "", "", "", endpointName, endpoint.Interface, endpoint.Role)
 		}
 	}
-	tw.Flush()
-	return nil
+	return table.Write(writer, opts)
 }
 
 func (o offerItems) Len() int      { return len(o) }
@@ -81,19 +77,16 @@ func (o offerItems) Less(i, j int) bool {
 	return o[i].OfferName < o[j].OfferName
 }
 
-func formatListTabular(writer io.Writer, value interface{}) error {
+func formatListTabular(writer io.Writer, opts output.TableOptions, value interface{}) error {
 	offers, ok := value.(offeredApplications)
 	if !ok {
 		return errors.Errorf("expected value of type %T, got %T", offers, value)
 	}
-	return formatListEndpointsTabular(writer, offers)
+	return formatListEndpointsTabular(writer, opts, offers)
 }
 
 // formatListEndpointsTabular returns a tabular summary of listed applications' endpoints.
-func formatListEndpointsTabular(writer io.Writer, offers offeredApplications) error {
-	tw := output.TabWriter(writer)
-	w := output.Wrapper{tw}
-
+func formatListEndpointsTabular(writer io.Writer, opts output.TableOptions, offers offeredApplications) error {
 	// Sort offers by source then application name.
 	allOffers := offerItems{}
 	for _, offer := range offers {
@@ -101,7 +94,7 @@ func formatListEndpointsTabular(writer io.Writer, offers offeredApplications) er
 	}
 	sort.Sort(allOffers)
 
-	w.Println("Offer", "User", "Relation id", "Status", "Endpoint", "Interface", "Role", "Ingress subnets")
+	table := output.NewTable("Offer", "User", "Relation id", "Status", "Endpoint", "Interface", "Role", "Ingress subnets")
 	for _, offer := range allOffers {
 		// Sort endpoints alphabetically.
 		endpoints := []string{}
@@ -115,14 +108,13 @@ func formatListEndpointsTabular(writer io.Writer, offers offeredApplications) er
 
 		// If there are no connections, print am empty row.
 		if len(offer.Connections) == 0 {
-			w.Println(offer.OfferName, "-", "", "", "", "", "", "")
+			table.AddRow(offer.OfferName, "-")
 		}
 
 		for i, conn := range offer.Connections {
-			if i == 0 {
-				w.Print(offer.OfferName)
-			} else {
-				w.Print("")
+			addRow, offerName := table.AddRow, offer.OfferName
+			if i > 0 {
+				addRow, offerName = table.AddDetailRow, ""
 			}
 			endpoints := make(map[string]RemoteEndpoint)
 			for alias, ep := range offer.Endpoints {
@@ -131,13 +123,15 @@ func formatListEndpointsTabular(writer io.Writer, offers offeredApplications) er
 				endpoints[ep.Name] = ep
 			}
 			connEp := endpoints[conn.Endpoint]
-			w.Print(conn.Username, conn.RelationId)
-			w.PrintColor(RelationStatusColor(relation.Status(conn.Status.Current)), conn.Status.Current)
-			w.Println(connEp.Name, connEp.Interface, connEp.Role, strings.Join(conn.IngressSubnets, ","))
+			connStatus := output.Cell{
+				Value: conn.Status.Current,
+				Color: RelationStatusColor(relation.Status(conn.Status.Current)),
+			}
+			addRow(offerName, conn.Username, conn.RelationId, connStatus,
+				connEp.Name, connEp.Interface, connEp.Role, strings.Join(conn.IngressSubnets, ","))
 		}
 	}
-	tw.Flush()
-	return nil
+	return table.Write(writer, opts)
 }
 
 // RelationStatusColor returns a context used to print the status with the relevant color.
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/status"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 // statusAPI defines the API methods for the machines and show-machine commands.
@@ -35,14 +36,14 @@ type baselistMachinesCommand struct {
 	api           statusAPI
 	machineIds    []string
 	defaultFormat string
-	color         bool
+	table         output.TableFlags
 }
 
 // SetFlags sets utc and format flags based on user specified options.
 func (c *baselistMachinesCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.baseMachinesCommand.SetFlags(f)
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
-	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
+	c.table.AddFlags(f)
 	c.out.AddFlags(f, c.defaultFormat, map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
@@ -83,5 +84,5 @@ func (c *baselistMachinesCommand) Run(ctx *cmd.Context) error {
 }
 
 func (c *baselistMachinesCommand) tabular(writer io.Writer, value interface{}) error {
-	return status.FormatMachineTabular(writer, c.color, value)
+	return status.FormatMachineTabular(writer, c.table.Options(), value)
 }
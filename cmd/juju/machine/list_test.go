@@ -325,3 +325,30 @@ func (s *MachineListCommandSuite) TestListMachineArgsError(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, newMachineListCommand(), "0")
 	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["0"\]`)
 }
+
+func (s *MachineListCommandSuite) TestListMachineColumns(c *gc.C) {
+	context, err := cmdtesting.RunCommand(c, newMachineListCommand(), "--columns", "machine,inst-id,state", "--sort", "-machine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"Machine  Inst id              State\n"+
+		"1        juju-badd06-1        started\n"+
+		"1/lxd/0  juju-badd06-1-lxd-0  pending\n"+
+		"0        juju-badd06-0        started\n"+
+		"\n")
+}
+
+func (s *MachineListCommandSuite) TestListMachineWidth(c *gc.C) {
+	context, err := cmdtesting.RunCommand(c, newMachineListCommand(), "--width", "60")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"Machine  State    DNS      Inst id  Series  AZ       Message\n"+
+		"0        started  10.0.0…  juju-b…  trusty  us-eas…  \n"+
+		"1        started  10.0.0…  juju-b…  trusty           \n"+
+		"1/lxd/0  pending  10.0.0…  juju-b…  trusty           \n"+
+		"\n")
+}
+
+func (s *MachineListCommandSuite) TestListMachineUnknownColumn(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, newMachineListCommand(), "--columns", "machine,zone")
+	c.Assert(err, gc.ErrorMatches, `unknown column "zone", expected one of: machine, state, dns, inst-id, series, az, message`)
+}
@@ -0,0 +1,206 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/api/modelmanager"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/migration"
+)
+
+const backupModelHelpDoc = `
+Backs up a single model to a local archive file, which can be restored
+into the same or another controller with restore-model.
+
+The archive holds the model's description, as exported for a model
+migration, along with the charms, agent binaries and resources the model
+uses. It does not hold anything from the controller's other models, nor
+any data held by the model's workloads.
+
+The archive is a gzip compressed tar file, so a file given with
+--filename (or --output) must be named with a .tar.gz or .tgz suffix.
+If neither is used, the archive is written to a file named after the
+model and the time of the backup. The name of the archive file is
+printed when the backup is complete.
+
+export-model is an alias of backup-model, and writes the same archive.
+It is used to move a model between controllers which can't reach each
+other, so can't migrate the model directly: the archive is carried to
+a machine which can reach the target controller and loaded with
+import-model. As the archive already holds the charms, agent binaries
+and resources the model uses, neither controller needs access to the
+charm store or to any source of agent binaries.
+
+Backing up a model requires admin access to the model.
+
+Examples:
+
+    juju backup-model
+    juju backup-model -m mymodel --filename mymodel.tar.gz
+    juju export-model -m mymodel --output mymodel.tar.gz
+
+See also:
+    restore-model
+    migrate
+    dump-model
+    create-backup
+`
+
+// NewBackupModelCommand returns a fully constructed backup-model command.
+func NewBackupModelCommand() cmd.Command {
+	return modelcmd.Wrap(&backupModelCommand{})
+}
+
+type backupModelCommand struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (BackupModelAPI, ModelBinariesAPI, error)
+
+	filename string
+}
+
+// BackupModelAPI specifies the API calls used to export a model.
+type BackupModelAPI interface {
+	Close() error
+	ExportModel(names.ModelTag) ([]byte, error)
+	ControllerVersion() version.Number
+}
+
+// ModelBinariesAPI specifies the API calls used to download the
+// binaries used by a model.
+type ModelBinariesAPI interface {
+	migration.ModelBackupSource
+	Close() error
+}
+
+// Info implements Command.
+func (c *backupModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "backup-model",
+		Aliases: []string{"export-model"},
+		Purpose: "Backs up a model to a local file.",
+		Doc:     backupModelHelpDoc,
+	})
+}
+
+// SetFlags implements Command.
+func (c *backupModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.filename, "filename", "", "Write the archive to this file")
+	f.StringVar(&c.filename, "output", "", "Alias for --filename")
+}
+
+// Init implements Command.
+func (c *backupModelCommand) Init(args []string) error {
+	if c.filename != "" && !strings.HasSuffix(c.filename, ".tar.gz") && !strings.HasSuffix(c.filename, ".tgz") {
+		return errors.Errorf("archive file %q must have a .tar.gz or .tgz suffix, as the archive is gzip compressed", c.filename)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// modelExporter adds the version of the controller it is connected to
+// to the ModelManager client.
+type modelExporter struct {
+	*modelmanager.Client
+	controllerVersion version.Number
+}
+
+// ControllerVersion is part of the BackupModelAPI interface.
+func (e *modelExporter) ControllerVersion() version.Number {
+	return e.controllerVersion
+}
+
+func (c *backupModelCommand) newAPI() (BackupModelAPI, ModelBinariesAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	controllerRoot, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	controllerVersion, ok := controllerRoot.ServerVersion()
+	if !ok {
+		controllerRoot.Close()
+		return nil, nil, errors.New("controller version not known")
+	}
+	modelRoot, err := c.NewAPIRoot()
+	if err != nil {
+		controllerRoot.Close()
+		return nil, nil, errors.Trace(err)
+	}
+	exporter := &modelExporter{
+		Client:            modelmanager.NewClient(controllerRoot),
+		controllerVersion: controllerVersion,
+	}
+	return exporter, modelRoot.Client(), nil
+}
+
+// Run implements Command.
+func (c *backupModelCommand) Run(ctx *cmd.Context) (err error) {
+	exporter, binaries, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer exporter.Close()
+	defer binaries.Close()
+
+	modelName, modelDetails, err := c.ModelDetails()
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+	serialized, err := exporter.ExportModel(names.NewModelTag(modelDetails.ModelUUID))
+	if err != nil {
+		return errors.Annotate(err, "exporting model")
+	}
+
+	filename := c.filename
+	if filename == "" {
+		filename = backupModelFilename(modelName, time.Now())
+	}
+	path := ctx.AbsPath(filename)
+	archive, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Annotate(err, "creating archive file")
+	}
+	defer func() {
+		archive.Close()
+		if err != nil {
+			os.Remove(path)
+		}
+	}()
+
+	meta, err := migration.WriteModelBackup(archive, serialized, exporter.ControllerVersion(), binaries)
+	if err != nil {
+		return errors.Annotate(err, "writing model backup")
+	}
+	if err := archive.Close(); err != nil {
+		return errors.Annotate(err, "writing model backup")
+	}
+	ctx.Infof("Backed up model %q with %d charm(s), %d agent binaries and %d resource(s).",
+		modelName, len(meta.Charms), len(meta.Tools), len(meta.Resources))
+	fmt.Fprintln(ctx.Stdout, filename)
+	return nil
+}
+
+// backupModelFilename returns the default name of the archive file for
+// a backup of the named model.
+func backupModelFilename(modelName string, timestamp time.Time) string {
+	name, _, err := jujuclient.SplitModelName(modelName)
+	if err != nil {
+		name = modelName
+	}
+	return fmt.Sprintf("juju-model-backup-%s-%s.tar.gz", name, timestamp.UTC().Format("20060102-150405"))
+}
@@ -0,0 +1,219 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/description/v2"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/testing"
+)
+
+type BackupModelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	exporter fakeModelExporter
+	binaries fakeModelBinaries
+	store    *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&BackupModelSuite{})
+
+type fakeModelExporter struct {
+	gitjujutesting.Stub
+	serialized []byte
+}
+
+func (f *fakeModelExporter) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeModelExporter) ExportModel(model names.ModelTag) ([]byte, error) {
+	f.MethodCall(f, "ExportModel", model)
+	return f.serialized, f.NextErr()
+}
+
+func (f *fakeModelExporter) ControllerVersion() version.Number {
+	return version.MustParse("2.8.2")
+}
+
+type fakeModelBinaries struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeModelBinaries) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeModelBinaries) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenCharm", curl.String())
+	return ioutil.NopCloser(strings.NewReader("<charm>")), f.NextErr()
+}
+
+func (f *fakeModelBinaries) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenURI", uri)
+	return ioutil.NopCloser(strings.NewReader("<tools>")), f.NextErr()
+}
+
+func (f *fakeModelBinaries) OpenResource(app, name string) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenResource", app, name)
+	return ioutil.NopCloser(strings.NewReader("<resource>")), f.NextErr()
+}
+
+// serializedModel returns a serialized model with one application and
+// one machine.
+func serializedModel(c *gc.C) []byte {
+	m := description.NewModel(description.ModelArgs{
+		Type:  "iaas",
+		Owner: names.NewUserTag("admin"),
+		Config: map[string]interface{}{
+			"name":          "mymodel",
+			"uuid":          testing.ModelTag.Id(),
+			"agent-version": "2.8.0",
+		},
+	})
+	status := description.StatusArgs{Value: "active"}
+	m.SetStatus(status)
+	machine := m.AddMachine(description.MachineArgs{Id: names.NewMachineTag("0")})
+	machine.SetStatus(status)
+	machine.SetTools(description.AgentToolsArgs{Version: version.MustParseBinary("2.8.0-focal-amd64")})
+	app := m.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("app"),
+		CharmURL: "cs:focal/app-1",
+	})
+	app.SetStatus(status)
+	serialized, err := description.Serialize(m)
+	c.Assert(err, jc.ErrorIsNil)
+	return serialized
+}
+
+func (s *BackupModelSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.exporter = fakeModelExporter{serialized: serializedModel(c)}
+	s.binaries = fakeModelBinaries{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *BackupModelSuite) runBackup(c *gc.C, args ...string) (string, string, error) {
+	ctx, err := cmdtesting.RunCommandInDir(c, model.NewBackupModelCommandForTest(&s.exporter, &s.binaries, s.store), args, c.MkDir())
+	return cmdtesting.Stdout(ctx), cmdtesting.Stderr(ctx), err
+}
+
+func (s *BackupModelSuite) TestBackup(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "mymodel.tar.gz")
+	stdout, stderr, err := s.runBackup(c, "--filename", filename)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(stdout, gc.Equals, filename+"\n")
+	c.Check(stderr, gc.Equals, `Backed up model "admin/mymodel" with 1 charm(s), 1 agent binaries and 0 resource(s).`+"\n")
+	s.exporter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ExportModel", []interface{}{testing.ModelTag}},
+		{"Close", nil},
+	})
+	s.binaries.CheckCalls(c, []gitjujutesting.StubCall{
+		{"OpenCharm", []interface{}{"cs:focal/app-1"}},
+		{"OpenURI", []interface{}{"/tools/2.8.0-focal-amd64"}},
+		{"Close", nil},
+	})
+
+	data, err := ioutil.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	backup, err := migration.OpenModelBackup(bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	defer backup.Close()
+	c.Check(backup.Metadata.ModelName, gc.Equals, "mymodel")
+	c.Check(backup.Metadata.ControllerAgentVersion, gc.Equals, "2.8.2")
+	c.Check(backup.Serialized, jc.DeepEquals, s.exporter.serialized)
+}
+
+func (s *BackupModelSuite) TestBackupOutput(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "mymodel.tgz")
+	stdout, _, err := s.runBackup(c, "--output", filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stdout, gc.Equals, filename+"\n")
+	_, err = os.Stat(filename)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *BackupModelSuite) TestBackupFilenameNotGzip(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "mymodel.tar")
+	_, _, err := s.runBackup(c, "--output", filename)
+	c.Assert(err, gc.ErrorMatches, `archive file ".*mymodel.tar" must have a .tar.gz or .tgz suffix, as the archive is gzip compressed`)
+	_, err = os.Stat(filename)
+	c.Check(err, jc.Satisfies, os.IsNotExist)
+	s.exporter.CheckNoCalls(c)
+}
+
+func (s *BackupModelSuite) TestExportModelAlias(c *gc.C) {
+	command := model.NewBackupModelCommandForTest(&s.exporter, &s.binaries, s.store)
+	c.Check(command.Info().Aliases, jc.DeepEquals, []string{"export-model"})
+}
+
+func (s *BackupModelSuite) TestBackupDefaultFilename(c *gc.C) {
+	ctx, err := cmdtesting.RunCommandInDir(c, model.NewBackupModelCommandForTest(&s.exporter, &s.binaries, s.store), nil, c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+
+	filename := strings.TrimSpace(cmdtesting.Stdout(ctx))
+	c.Check(filename, gc.Matches, `juju-model-backup-mymodel-\d{8}-\d{6}\.tar\.gz`)
+	_, err = os.Stat(filepath.Join(ctx.Dir, filename))
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *BackupModelSuite) TestBackupExportError(c *gc.C) {
+	s.exporter.SetErrors(errors.New("boom"))
+	_, _, err := s.runBackup(c)
+	c.Assert(err, gc.ErrorMatches, "exporting model: boom")
+}
+
+func (s *BackupModelSuite) TestBackupDownloadErrorRemovesFile(c *gc.C) {
+	s.binaries.SetErrors(errors.New("boom"))
+	filename := filepath.Join(c.MkDir(), "mymodel.tar.gz")
+	_, _, err := s.runBackup(c, "--filename", filename)
+	c.Assert(err, gc.ErrorMatches, "writing model backup: charm cs:focal/app-1: boom")
+	_, err = os.Stat(filename)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *BackupModelSuite) TestBackupExistingFile(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "mymodel.tar.gz")
+	err := ioutil.WriteFile(filename, []byte("precious"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = s.runBackup(c, "--filename", filename)
+	c.Assert(err, gc.ErrorMatches, "creating archive file: .* file exists")
+	data, err := ioutil.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "precious")
+}
@@ -18,6 +18,7 @@ import (
 	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
@@ -41,6 +42,17 @@ Model config yaml can be piped from stdin from the output of the command stdout.
 Some model-config configuration are read-only, to prevent the command exiting on
 read-only fields, setting "ignore-read-only-fields" will cause it to skip over
 the fields when they're encountered.
+
+The --dry-run option validates the values being set or reset without applying
+them. It reports the values that would change, any values that would be
+converted to the type expected by the model, and any errors the model's cloud
+provider would raise for the new configuration.
+
+Each change to the model configuration is recorded as a numbered revision,
+noting when it was made, who made it and the values changed. The --history
+option displays the retained revisions, most recent first, and the --revert
+option restores the values the configuration had immediately after the given
+revision. The restoration is recorded as a new revision.
 `
 	modelConfigHelpDocKeys = `
 The following keys are available:
@@ -54,6 +66,9 @@ Examples:
     juju model-config path/to/file.yaml
     juju model-config -m othercontroller:mymodel default-series=yakkety test-mode=false
     juju model-config --reset default-series test-mode
+    juju model-config --dry-run ftp-proxy=10.0.0.1:8000 --reset test-mode
+    juju model-config --history
+    juju model-config --revert 7
 
 See also:
     models
@@ -156,6 +171,9 @@ type configCommand struct {
 	reset                []string // Holds the keys to be reset until parsed.
 	resetKeys            []string // Holds the keys to be reset once parsed.
 	setOptions           common.ConfigFlag
+	dryRun               bool
+	history              bool
+	revert               int
 	ignoreAgentVersion   bool
 	ignoreReadOnlyFields bool
 }
@@ -167,12 +185,15 @@ type configCommandAPI interface {
 	ModelGetWithMetadata() (config.ConfigValues, error)
 	ModelSet(config map[string]interface{}) error
 	ModelUnset(keys ...string) error
+	ValidateModelConfig(set map[string]interface{}, reset []string) (params.ConfigDryRunResult, error)
+	ModelConfigHistory() ([]params.ConfigRevision, error)
+	RevertModelConfig(revision int) error
 }
 
 // Info implements part of the cmd.Command interface.
 func (c *configCommand) Info() *cmd.Info {
 	info := &cmd.Info{
-		Args:    "[<model-key>[=<value>] ...]",
+		Args:    "[<model-key>[=<value>] ...] | [--history | --revert <revision>]",
 		Name:    "model-config",
 		Purpose: modelConfigSummary,
 	}
@@ -202,6 +223,9 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml":    cmd.FormatYaml,
 	})
 	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys")
+	f.BoolVar(&c.dryRun, "dry-run", false, "Report the effect of the changes without applying them")
+	f.BoolVar(&c.history, "history", false, "Display the revisions of the model configuration")
+	f.IntVar(&c.revert, "revert", 0, "Restore the configuration to its values immediately after the given revision")
 	f.BoolVar(&c.ignoreAgentVersion, "ignore-agent-version", false, "Skip the error when passing in the agent version configuration (deprecated)")
 	f.BoolVar(&c.ignoreReadOnlyFields, "ignore-read-only-fields", false, "Ignore read only fields that might cause errors to be emitted while processing yaml documents")
 }
@@ -216,14 +240,47 @@ func (c *configCommand) Init(args []string) error {
 		return errors.Trace(err)
 	}
 
+	if c.history || c.revert != 0 {
+		return c.parseHistory(args)
+	}
+
+	var err error
 	switch len(args) {
 	case 0:
-		return c.handleZeroArgs()
+		err = c.handleZeroArgs()
 	case 1:
-		return c.handleOneArg(args[0])
+		err = c.handleOneArg(args[0])
 	default:
-		return c.handleArgs(args)
+		err = c.handleArgs(args)
 	}
+	if err != nil {
+		return err
+	}
+	getting := len(c.keys) > 0 || (len(args) == 0 && len(c.resetKeys) == 0)
+	if c.dryRun && getting {
+		return errors.New("--dry-run can only be used when setting or resetting values")
+	}
+	return nil
+}
+
+// parseHistory handles the case where --history or --revert is specified,
+// neither of which may be combined with other changes.
+func (c *configCommand) parseHistory(args []string) error {
+	if c.history && c.revert != 0 {
+		return errors.New("cannot specify --history and --revert simultaneously")
+	}
+	if len(args) > 0 || len(c.resetKeys) > 0 || c.dryRun {
+		return errors.New("--history and --revert cannot be combined with other config changes")
+	}
+	if c.history {
+		c.action = c.getHistory
+		return nil
+	}
+	if c.revert < 0 {
+		return errors.Errorf("invalid revision %d", c.revert)
+	}
+	c.action = c.revertConfig
+	return nil
 }
 
 // handleZeroArgs handles the case where there are no positional args.
@@ -356,6 +413,9 @@ func (c *configCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.dryRun {
+		return c.validateConfig(client, ctx)
+	}
 	if len(c.resetKeys) > 0 {
 		err := c.resetConfig(client, ctx)
 		if err != nil {
@@ -384,10 +444,95 @@ func (c *configCommand) resetConfig(client configCommandAPI, ctx *cmd.Context) e
 
 // setConfig sets the provided key/value pairs on the model.
 func (c *configCommand) setConfig(client configCommandAPI, ctx *cmd.Context) error {
-	attrs, err := c.setOptions.ReadAttrs(ctx)
+	coerced, keys, err := c.readSetAttrs(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.verifyKnownKeys(client, keys); err != nil {
+		return errors.Trace(err)
+	}
+
+	return block.ProcessBlockedError(client.ModelSet(coerced), block.BlockChange)
+}
+
+// validateConfig reports the effect of the provided key/value pairs and
+// reset keys on the model, without applying them.
+func (c *configCommand) validateConfig(client configCommandAPI, ctx *cmd.Context) error {
+	var attrs configAttrs
+	if c.action != nil {
+		coerced, keys, err := c.readSetAttrs(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		attrs = coerced
+		if err := c.verifyKnownKeys(client, keys); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(c.resetKeys) > 0 {
+		if err := c.verifyKnownKeys(client, c.resetKeys); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	result, err := client.ValidateModelConfig(attrs, c.resetKeys)
 	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if result.Error != nil {
+		return block.ProcessBlockedError(result.Error, block.BlockChange)
+	}
+	if len(result.Changes) == 0 {
+		ctx.Infof("No changes to the model configuration.")
+		return nil
+	}
+	dryRun := common.FormatConfigDryRun(result)
+	if c.out.Name() == "tabular" {
+		// The tabular formatter only understands config values, so
+		// fall back to YAML for the changes.
+		return c.out.WriteFormatter(ctx, cmd.FormatYaml, dryRun)
+	}
+	return c.out.Write(ctx, dryRun)
+}
+
+// getHistory writes the retained revisions of the model config.
+func (c *configCommand) getHistory(client configCommandAPI, ctx *cmd.Context) error {
+	revisions, err := client.ModelConfigHistory()
+	if errors.IsNotSupported(err) {
+		return errors.New("--history is not supported by this controller")
+	} else if err != nil {
 		return errors.Trace(err)
 	}
+	if len(revisions) == 0 {
+		ctx.Infof("No model configuration history.")
+		return nil
+	}
+	history := common.FormatConfigHistory(revisions)
+	if c.out.Name() == "tabular" {
+		// The tabular formatter only understands config values, so
+		// fall back to YAML for the revisions.
+		return c.out.WriteFormatter(ctx, cmd.FormatYaml, history)
+	}
+	return c.out.Write(ctx, history)
+}
+
+// revertConfig restores the model config to its values at a revision.
+func (c *configCommand) revertConfig(client configCommandAPI, ctx *cmd.Context) error {
+	err := client.RevertModelConfig(c.revert)
+	if errors.IsNotSupported(err) {
+		return errors.New("--revert is not supported by this controller")
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+// readSetAttrs reads the key/value pairs provided to the command, checking
+// that none of them are read-only or also being reset. It returns the values
+// in the form expected by the API along with the keys that were provided.
+func (c *configCommand) readSetAttrs(ctx *cmd.Context) (configAttrs, []string, error) {
+	attrs, err := c.setOptions.ReadAttrs(ctx)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 	var keys []string
 	values := make(configAttrs)
 	for k, v := range attrs {
@@ -395,12 +540,12 @@ func (c *configCommand) setConfig(client configCommandAPI, ctx *cmd.Context) err
 			if c.ignoreAgentVersion || c.ignoreReadOnlyFields {
 				continue
 			}
-			return errors.Errorf(`"agent-version" must be set via "upgrade-model"`)
+			return nil, nil, errors.Errorf(`"agent-version" must be set via "upgrade-model"`)
 		} else if k == config.CharmhubURLKey {
 			if c.ignoreReadOnlyFields {
 				continue
 			}
-			return errors.Errorf(`"charmhub-url" must be set via "add-model"`)
+			return nil, nil, errors.Errorf(`"charmhub-url" must be set via "add-model"`)
 		}
 
 		values[k] = v
@@ -409,21 +554,16 @@ func (c *configCommand) setConfig(client configCommandAPI, ctx *cmd.Context) err
 
 	coerced, err := values.CoerceFormat()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 
 	for _, k := range c.resetKeys {
 		if _, ok := coerced[k]; ok {
-			return errors.Errorf(
+			return nil, nil, errors.Errorf(
 				"key %q cannot be both set and reset in the same command", k)
 		}
 	}
-
-	if err := c.verifyKnownKeys(client, keys); err != nil {
-		return errors.Trace(err)
-	}
-
-	return block.ProcessBlockedError(client.ModelSet(coerced), block.BlockChange)
+	return coerced, keys, nil
 }
 
 // get writes the value of a single key or the full output for the model to the cmd.Context.
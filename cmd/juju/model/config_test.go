@@ -5,13 +5,16 @@ package model_test
 import (
 	"io/ioutil"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/model"
 	"github.com/juju/juju/testing"
 )
@@ -69,6 +72,44 @@ func (s *ConfigCommandSuite) TestInit(c *gc.C) {
 			desc:   "test reset interspersed",
 			args:   []string{"--reset", "one", "special=foo", "--reset", "two"},
 			nilErr: true,
+		}, {
+			// Test dry run
+			desc:   "dry run set and reset succeeds",
+			args:   []string{"--dry-run", "--reset", "one", "special=foo"},
+			nilErr: true,
+		}, {
+			desc:       "dry run get all fails",
+			args:       []string{"--dry-run"},
+			errorMatch: "--dry-run can only be used when setting or resetting values",
+		}, {
+			desc:       "dry run get one fails",
+			args:       []string{"--dry-run", "one"},
+			errorMatch: "--dry-run can only be used when setting or resetting values",
+		}, {
+			// Test history and revert
+			desc:   "history succeeds",
+			args:   []string{"--history"},
+			nilErr: true,
+		}, {
+			desc:   "revert succeeds",
+			args:   []string{"--revert", "7"},
+			nilErr: true,
+		}, {
+			desc:       "history and revert fails",
+			args:       []string{"--history", "--revert", "7"},
+			errorMatch: "cannot specify --history and --revert simultaneously",
+		}, {
+			desc:       "revert and set fails",
+			args:       []string{"--revert", "7", "special=foo"},
+			errorMatch: "--history and --revert cannot be combined with other config changes",
+		}, {
+			desc:       "history and reset fails",
+			args:       []string{"--history", "--reset", "one"},
+			errorMatch: "--history and --revert cannot be combined with other config changes",
+		}, {
+			desc:       "revert invalid revision fails",
+			args:       []string{"--revert", "-2"},
+			errorMatch: "invalid revision -2",
 		},
 	} {
 		c.Logf("test %d: %s", i, test.desc)
@@ -364,3 +405,108 @@ func (s *ConfigCommandSuite) TestResetBlockedError(c *gc.C) {
 	_, err := s.run(c, "--reset", "special")
 	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedError.*")
 }
+
+func (s *ConfigCommandSuite) TestDryRun(c *gc.C) {
+	s.fake.dryRunResult = params.ConfigDryRunResult{
+		Changes: []params.ConfigChange{{
+			Key:   "running",
+			Old:   true,
+			New:   false,
+			Input: "false",
+		}, {
+			Key:   "special",
+			Old:   "special value",
+			Reset: true,
+		}},
+	}
+	ctx, err := s.run(c, "--dry-run", "running=false", "--reset", "special")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.dryRunSet, jc.DeepEquals, map[string]interface{}{"running": false})
+	c.Check(s.fake.resetKeys, jc.DeepEquals, []string{"special"})
+	// Nothing is applied.
+	c.Check(s.fake.values["running"], jc.IsTrue)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+changes:
+  running:
+    old: true
+    new: false
+    coerced-from: "false"
+  special:
+    old: special value
+    reset: true
+`[1:])
+}
+
+func (s *ConfigCommandSuite) TestDryRunNoChanges(c *gc.C) {
+	ctx, err := s.run(c, "--dry-run", "special=special value")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No changes to the model configuration.\n")
+}
+
+func (s *ConfigCommandSuite) TestDryRunProviderError(c *gc.C) {
+	s.fake.dryRunResult = params.ConfigDryRunResult{
+		Error: &params.Error{Message: "provider rejected config: bad region"},
+	}
+	_, err := s.run(c, "--dry-run", "special=extra")
+	c.Assert(err, gc.ErrorMatches, "provider rejected config: bad region")
+}
+
+func (s *ConfigCommandSuite) TestDryRunBlockedError(c *gc.C) {
+	s.fake.err = apiservererrors.OperationBlockedError("TestBlockedError")
+	_, err := s.run(c, "--dry-run", "special=extra")
+	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedError.*")
+}
+
+func (s *ConfigCommandSuite) TestHistory(c *gc.C) {
+	s.fake.history = []params.ConfigRevision{{
+		Revision:  7,
+		Scope:     "model",
+		Created:   time.Date(2020, 9, 1, 3, 0, 0, 0, time.UTC),
+		CreatedBy: "bob",
+		Changes: []params.ConfigChange{
+			{Key: "running", Old: true, New: false},
+			{Key: "special", Old: "special value", Reset: true},
+		},
+	}}
+	ctx, err := s.run(c, "--history")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+- revision: 7
+  scope: model
+  time: 2020-09-01T03:00:00Z
+  user: bob
+  changes:
+    running:
+      old: true
+      new: false
+    special:
+      old: special value
+      reset: true
+`[1:])
+}
+
+func (s *ConfigCommandSuite) TestHistoryEmpty(c *gc.C) {
+	ctx, err := s.run(c, "--history")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No model configuration history.\n")
+}
+
+func (s *ConfigCommandSuite) TestHistoryNotSupported(c *gc.C) {
+	s.fake.err = errors.NotSupportedf("ModelConfigHistory on v3 facade")
+	_, err := s.run(c, "--history")
+	c.Assert(err, gc.ErrorMatches, "--history is not supported by this controller")
+}
+
+func (s *ConfigCommandSuite) TestRevert(c *gc.C) {
+	_, err := s.run(c, "--revert", "7")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.revertTo, gc.Equals, 7)
+}
+
+func (s *ConfigCommandSuite) TestRevertBlockedError(c *gc.C) {
+	s.fake.err = apiservererrors.OperationBlockedError("TestBlockedError")
+	_, err := s.run(c, "--revert", "7")
+	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedError.*")
+}
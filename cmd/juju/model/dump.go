@@ -36,8 +36,12 @@ Examples:
     juju dump-model
     juju dump-model -m mymodel
 
+The YAML can't be loaded back into a controller: use export-model to
+write a model to a file which can be imported with import-model.
+
 See also:
     models
+    export-model
 `
 
 // Info implements Command.
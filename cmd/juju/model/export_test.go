@@ -66,6 +66,24 @@ func NewDumpDBCommandForTest(api DumpDBAPI, store jujuclient.ClientStore) cmd.Co
 	return modelcmd.Wrap(cmd)
 }
 
+// NewBackupModelCommandForTest returns a BackupModelCommand with the apis provided as specified.
+func NewBackupModelCommandForTest(exporter BackupModelAPI, binaries ModelBinariesAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &backupModelCommand{newAPIFunc: func() (BackupModelAPI, ModelBinariesAPI, error) {
+		return exporter, binaries, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewRestoreModelCommandForTest returns a RestoreModelCommand with the api provided as specified.
+func NewRestoreModelCommandForTest(api RestoreModelAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &restoreModelCommand{newAPIFunc: func() (RestoreModelAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
 // NewExportBundleCommandForTest returns a ExportBundleCommand with the api provided as specified.
 func NewExportBundleCommandForTest(bundleAPI ExportBundleAPI, cfgAPI ConfigAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &exportBundleCommand{newAPIFunc: func() (ExportBundleAPI, ConfigAPI, error) {
@@ -205,6 +223,14 @@ func NewCommitCommandForTest(api CommitCommandAPI, store jujuclient.ClientStore)
 	return modelcmd.Wrap(cmd)
 }
 
+func NewRevertCommitCommandForTest(api RevertCommitCommandAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &revertCommitCommand{
+		api: api,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 func NewTrackBranchCommandForTest(api TrackBranchCommandAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &trackBranchCommand{
 		api: api,
@@ -244,3 +270,11 @@ func NewShowCommitCommandForTest(api ShowCommitCommandAPI, store jujuclient.Clie
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+func NewSetQuotaCommandForTest(api SetQuotaCommandAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &setQuotaCommand{
+		api: api,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -9,6 +9,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/testing"
@@ -44,6 +45,10 @@ type fakeEnvAPI struct {
 	err           error
 	keys          []string
 	resetKeys     []string
+	dryRunSet     map[string]interface{}
+	dryRunResult  params.ConfigDryRunResult
+	history       []params.ConfigRevision
+	revertTo      int
 }
 
 func (f *fakeEnvAPI) Close() error {
@@ -72,6 +77,21 @@ func (f *fakeEnvAPI) ModelUnset(keys ...string) error {
 	return f.err
 }
 
+func (f *fakeEnvAPI) ValidateModelConfig(set map[string]interface{}, reset []string) (params.ConfigDryRunResult, error) {
+	f.dryRunSet = set
+	f.resetKeys = reset
+	return f.dryRunResult, f.err
+}
+
+func (f *fakeEnvAPI) ModelConfigHistory() ([]params.ConfigRevision, error) {
+	return f.history, f.err
+}
+
+func (f *fakeEnvAPI) RevertModelConfig(revision int) error {
+	f.revertTo = revision
+	return f.err
+}
+
 // ModelDefaults related fake environment for testing.
 
 type fakeModelDefaultEnvSuite struct {
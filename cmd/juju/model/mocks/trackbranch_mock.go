@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	model "github.com/juju/juju/core/model"
 )
 
 // MockTrackBranchCommandAPI is a mock of TrackBranchCommandAPI interface
@@ -69,3 +70,27 @@ func (m *MockTrackBranchCommandAPI) TrackBranch(arg0 string, arg1 []string, arg2
 func (mr *MockTrackBranchCommandAPIMockRecorder) TrackBranch(arg0, arg1, arg2 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackBranch", reflect.TypeOf((*MockTrackBranchCommandAPI)(nil).TrackBranch), arg0, arg1, arg2)
 }
+
+// TrackBranchProgressively mocks base method
+func (m *MockTrackBranchCommandAPI) TrackBranchProgressively(arg0 string, arg1 []string, arg2 model.BranchProgression) error {
+	ret := m.ctrl.Call(m, "TrackBranchProgressively", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrackBranchProgressively indicates an expected call of TrackBranchProgressively
+func (mr *MockTrackBranchCommandAPIMockRecorder) TrackBranchProgressively(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackBranchProgressively", reflect.TypeOf((*MockTrackBranchCommandAPI)(nil).TrackBranchProgressively), arg0, arg1, arg2)
+}
+
+// TrackBranchWithPolicy mocks base method
+func (m *MockTrackBranchCommandAPI) TrackBranchWithPolicy(arg0 string, arg1 []string, arg2 model.TrackPolicy) error {
+	ret := m.ctrl.Call(m, "TrackBranchWithPolicy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrackBranchWithPolicy indicates an expected call of TrackBranchWithPolicy
+func (mr *MockTrackBranchCommandAPIMockRecorder) TrackBranchWithPolicy(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackBranchWithPolicy", reflect.TypeOf((*MockTrackBranchCommandAPI)(nil).TrackBranchWithPolicy), arg0, arg1, arg2)
+}
@@ -0,0 +1,223 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"os"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/api/migrationtarget"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/tools"
+)
+
+const restoreModelHelpDoc = `
+Restores a model from an archive file written by backup-model, into
+the current controller or the one given with -c. The controller may be
+the one the model was backed up from, once the model has been
+destroyed, or another controller.
+
+The model is imported in the same way as a model migration: the
+controller first checks that it can accept the model, then imports the
+model's description and uploads its charms, agent binaries and
+resources. If any step fails the partly imported model is removed.
+
+The model keeps its UUID, name and owner, so there must not be a model
+with the same UUID, or the same name and owner, on the controller.
+The model's machines are not recreated; machines which still exist are
+adopted by the controller.
+
+import-model is an alias of restore-model, for loading a model
+exported with export-model from a controller which this one can't
+reach. The archive may have been decompressed on its way here. The
+model is not removed from the source controller; don't destroy it
+there, as that would also destroy the machines adopted here.
+
+Restoring a model requires superuser access to the controller.
+
+Examples:
+
+    juju restore-model juju-model-backup-mymodel-20200405-123456.tar.gz
+    juju restore-model -c other-controller mymodel.tar.gz
+    juju import-model mymodel.tar.gz
+
+See also:
+    backup-model
+    migrate
+`
+
+// NewRestoreModelCommand returns a fully constructed restore-model command.
+func NewRestoreModelCommand() cmd.Command {
+	return modelcmd.WrapController(&restoreModelCommand{})
+}
+
+type restoreModelCommand struct {
+	modelcmd.ControllerCommandBase
+	newAPIFunc func() (RestoreModelAPI, error)
+
+	filename string
+}
+
+// RestoreModelAPI specifies the API calls used to import a model into
+// a controller.
+type RestoreModelAPI interface {
+	Close() error
+	Prechecks(coremigration.ModelInfo) error
+	Import([]byte) (map[string]int, error)
+	Abort(modelUUID string) error
+	Activate(modelUUID string) error
+	AdoptResources(modelUUID string) error
+	UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error)
+	UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error)
+	UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error
+	SetPlaceholderResource(modelUUID string, res resource.Resource) error
+	SetUnitResource(modelUUID, unit string, res resource.Resource) error
+}
+
+// Info implements Command.
+func (c *restoreModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "restore-model",
+		Aliases: []string{"import-model"},
+		Args:    "<file>",
+		Purpose: "Restores a model from a backup-model archive.",
+		Doc:     restoreModelHelpDoc,
+	})
+}
+
+// Init implements Command.
+func (c *restoreModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing archive file")
+	}
+	c.filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// migrationTargetAPI adds closing the connection to the
+// MigrationTarget client.
+type migrationTargetAPI struct {
+	*migrationtarget.Client
+	io.Closer
+}
+
+func (c *restoreModelCommand) newAPI() (RestoreModelAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return migrationTargetAPI{
+		Client: migrationtarget.NewClient(root),
+		Closer: root,
+	}, nil
+}
+
+// Run implements Command.
+func (c *restoreModelCommand) Run(ctx *cmd.Context) error {
+	archive, err := os.Open(c.filename)
+	if err != nil {
+		return errors.Annotate(err, "opening archive file")
+	}
+	defer archive.Close()
+	backup, err := migration.OpenModelBackup(archive)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer backup.Close()
+	info, err := backup.ModelInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := client.Prechecks(info); err != nil {
+		return errors.Annotate(err, "controller cannot restore the model")
+	}
+	ctx.Infof("Importing model %q.", info.Name)
+	if _, err := client.Import(backup.Serialized); err != nil {
+		return errors.Annotate(err, "importing model")
+	}
+	if err := c.completeImport(ctx, client, backup, info.UUID); err != nil {
+		if abortErr := client.Abort(info.UUID); abortErr != nil {
+			logger.Errorf("removing partly restored model: %v", abortErr)
+		}
+		return errors.Trace(err)
+	}
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Restored model %q into controller %q.", info.Name, controllerName)
+	return nil
+}
+
+func (c *restoreModelCommand) completeImport(
+	ctx *cmd.Context, client RestoreModelAPI, backup *migration.ModelBackup, modelUUID string,
+) error {
+	ctx.Infof("Uploading %d charm(s), %d agent binaries and %d resource(s).",
+		len(backup.Metadata.Charms), len(backup.Metadata.Tools), len(backup.Metadata.Resources))
+	uploader := &modelUploader{client: client, modelUUID: modelUUID}
+	config, err := backup.UploadBinariesConfig(uploader, uploader, uploader)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := migration.UploadBinaries(config); err != nil {
+		return errors.Annotate(err, "uploading model binaries")
+	}
+	if err := client.AdoptResources(modelUUID); err != nil {
+		return errors.Annotate(err, "adopting model resources")
+	}
+	if err := client.Activate(modelUUID); err != nil {
+		return errors.Annotate(err, "activating model")
+	}
+	return nil
+}
+
+// modelUploader sends binaries for the model being restored.
+type modelUploader struct {
+	client    RestoreModelAPI
+	modelUUID string
+}
+
+// UploadCharm is part of migration.CharmUploader.
+func (u *modelUploader) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	return u.client.UploadCharm(u.modelUUID, curl, content)
+}
+
+// UploadTools is part of migration.ToolsUploader.
+func (u *modelUploader) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
+	return u.client.UploadTools(u.modelUUID, r, vers, additionalSeries...)
+}
+
+// UploadResource is part of migration.ResourceUploader.
+func (u *modelUploader) UploadResource(res resource.Resource, content io.ReadSeeker) error {
+	return u.client.UploadResource(u.modelUUID, res, content)
+}
+
+// SetPlaceholderResource is part of migration.ResourceUploader.
+func (u *modelUploader) SetPlaceholderResource(res resource.Resource) error {
+	return u.client.SetPlaceholderResource(u.modelUUID, res)
+}
+
+// SetUnitResource is part of migration.ResourceUploader.
+func (u *modelUploader) SetUnitResource(unitName string, res resource.Resource) error {
+	return u.client.SetUnitResource(u.modelUUID, unitName, res)
+}
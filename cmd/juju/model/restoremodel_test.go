@@ -0,0 +1,178 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type RestoreModelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api      fakeRestoreModelAPI
+	store    *jujuclient.MemStore
+	filename string
+}
+
+var _ = gc.Suite(&RestoreModelSuite{})
+
+type fakeRestoreModelAPI struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeRestoreModelAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeRestoreModelAPI) Prechecks(info coremigration.ModelInfo) error {
+	f.MethodCall(f, "Prechecks", info)
+	return f.NextErr()
+}
+
+func (f *fakeRestoreModelAPI) Import(serialized []byte) (map[string]int, error) {
+	f.MethodCall(f, "Import")
+	return nil, f.NextErr()
+}
+
+func (f *fakeRestoreModelAPI) Abort(modelUUID string) error {
+	f.MethodCall(f, "Abort", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeRestoreModelAPI) Activate(modelUUID string) error {
+	f.MethodCall(f, "Activate", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeRestoreModelAPI) AdoptResources(modelUUID string) error {
+	f.MethodCall(f, "AdoptResources", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeRestoreModelAPI) UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	data, _ := ioutil.ReadAll(content)
+	f.MethodCall(f, "UploadCharm", modelUUID, curl.String(), string(data))
+	return curl, f.NextErr()
+}
+
+func (f *fakeRestoreModelAPI) UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, _ ...string) (tools.List, error) {
+	data, _ := ioutil.ReadAll(r)
+	f.MethodCall(f, "UploadTools", modelUUID, vers.String(), string(data))
+	return nil, f.NextErr()
+}
+
+func (f *fakeRestoreModelAPI) UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error {
+	f.MethodCall(f, "UploadResource", modelUUID, res.Name)
+	return f.NextErr()
+}
+
+func (f *fakeRestoreModelAPI) SetPlaceholderResource(modelUUID string, res resource.Resource) error {
+	f.MethodCall(f, "SetPlaceholderResource", modelUUID, res.Name)
+	return f.NextErr()
+}
+
+func (f *fakeRestoreModelAPI) SetUnitResource(modelUUID, unit string, res resource.Resource) error {
+	f.MethodCall(f, "SetUnitResource", modelUUID, unit, res.Name)
+	return f.NextErr()
+}
+
+func (s *RestoreModelSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = fakeRestoreModelAPI{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	s.filename = filepath.Join(c.MkDir(), "mymodel.tar.gz")
+	archive, err := os.Create(s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	_, err = migration.WriteModelBackup(archive, serializedModel(c), version.MustParse("2.8.2"), &fakeModelBinaries{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RestoreModelSuite) runRestore(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewRestoreModelCommandForTest(&s.api, s.store), args...)
+	return cmdtesting.Stderr(ctx), err
+}
+
+func (s *RestoreModelSuite) TestInit(c *gc.C) {
+	_, err := s.runRestore(c)
+	c.Assert(err, gc.ErrorMatches, "missing archive file")
+	_, err = s.runRestore(c, "a", "b")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+}
+
+func (s *RestoreModelSuite) TestRestore(c *gc.C) {
+	stderr, err := s.runRestore(c, s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+
+	uuid := testing.ModelTag.Id()
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Prechecks", []interface{}{coremigration.ModelInfo{
+			UUID:                   uuid,
+			Owner:                  names.NewUserTag("admin"),
+			Name:                   "mymodel",
+			AgentVersion:           version.MustParse("2.8.0"),
+			ControllerAgentVersion: version.MustParse("2.8.2"),
+		}}},
+		{"Import", nil},
+		{"UploadCharm", []interface{}{uuid, "cs:focal/app-1", "<charm>"}},
+		{"UploadTools", []interface{}{uuid, "2.8.0-focal-amd64", "<tools>"}},
+		{"AdoptResources", []interface{}{uuid}},
+		{"Activate", []interface{}{uuid}},
+		{"Close", nil},
+	})
+	c.Check(stderr, gc.Equals, `
+Importing model "mymodel".
+Uploading 1 charm(s), 1 agent binaries and 0 resource(s).
+Restored model "mymodel" into controller "testing".
+`[1:])
+}
+
+func (s *RestoreModelSuite) TestRestorePrecheckFails(c *gc.C) {
+	s.api.SetErrors(errors.New("model with same UUID already exists"))
+	_, err := s.runRestore(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "controller cannot restore the model: model with same UUID already exists")
+	s.api.CheckCallNames(c, "Prechecks", "Close")
+}
+
+func (s *RestoreModelSuite) TestRestoreUploadFailsAborts(c *gc.C) {
+	s.api.SetErrors(nil, nil, errors.New("boom"))
+	_, err := s.runRestore(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "uploading model binaries: cannot upload charm: boom")
+	s.api.CheckCallNames(c, "Prechecks", "Import", "UploadCharm", "Abort", "Close")
+	s.api.CheckCall(c, 3, "Abort", testing.ModelTag.Id())
+}
+
+func (s *RestoreModelSuite) TestRestoreNotAnArchive(c *gc.C) {
+	err := ioutil.WriteFile(s.filename, []byte("<not an archive>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.runRestore(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "reading model backup: .*")
+	s.api.CheckNoCalls(c)
+}
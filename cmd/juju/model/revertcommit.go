@@ -0,0 +1,156 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/modelgeneration"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
+)
+
+const (
+	revertCommitSummary = "Reverts the configuration changes made by a commit."
+	revertCommitDoc     = `
+Revert-commit creates a new branch holding the inverse of the charm
+configuration changes made by the commit with the given id, and sets it as
+the active branch. Every application changed by the commit tracks the new
+branch; committing it rolls the changes back across the model.
+
+Changes to keys that have been changed again since the commit, by a later
+commit or otherwise, are not reverted and are reported as conflicts.
+
+By default the new branch is named "revert-<id>". Use the --branch option to
+choose another name, and the --commit option to commit the new branch
+immediately.
+
+Examples:
+    juju revert-commit 3
+    juju revert-commit 3 --branch undo-port-change
+    juju revert-commit 3 --commit
+
+See also:
+    commits
+    show-commit
+    commit
+    abort
+`
+)
+
+// NewRevertCommitCommand wraps revertCommitCommand with sane model settings.
+func NewRevertCommitCommand() cmd.Command {
+	return modelcmd.Wrap(&revertCommitCommand{})
+}
+
+// revertCommitCommand supplies the "revert-commit" CLI command used to undo
+// the changes made by a committed branch.
+type revertCommitCommand struct {
+	modelcmd.ModelCommandBase
+
+	api RevertCommitCommandAPI
+
+	generationId int
+	branchName   string
+	commit       bool
+}
+
+// RevertCommitCommandAPI defines an API interface to be used during testing.
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination ./mocks/revertcommit_mock.go github.com/juju/juju/cmd/juju/model RevertCommitCommandAPI
+type RevertCommitCommandAPI interface {
+	Close() error
+
+	// RevertCommit adds a new branch holding the inverse of the config
+	// changes made by the commit with the input generation ID,
+	// optionally committing it.
+	RevertCommit(generationId int, branchName string, commit bool) (int, []model.RevertConflict, error)
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *revertCommitCommand) Info() *cmd.Info {
+	info := &cmd.Info{
+		Name:    "revert-commit",
+		Args:    "<commit id>",
+		Purpose: revertCommitSummary,
+		Doc:     revertCommitDoc,
+	}
+	return jujucmd.Info(info)
+}
+
+// SetFlags implements part of the cmd.Command interface.
+func (c *revertCommitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.branchName, "branch", "", "Name of the branch to create")
+	f.BoolVar(&c.commit, "commit", false, "Commit the new branch immediately")
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *revertCommitCommand) Init(args []string) error {
+	if len(args) != 1 {
+		return errors.Errorf("expected exactly 1 commit id, got %d arguments", len(args))
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil || id < 1 {
+		return errors.Errorf("invalid commit id %q", args[0])
+	}
+	c.generationId = id
+
+	if c.branchName == "" {
+		c.branchName = fmt.Sprintf("revert-%d", id)
+	}
+	return model.ValidateBranchName(c.branchName)
+}
+
+// getAPI returns the API. This allows passing in a test
+// RevertCommitCommandAPI implementation.
+func (c *revertCommitCommand) getAPI() (RevertCommitCommandAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	client := modelgeneration.NewClient(api)
+	return client, nil
+}
+
+// Run implements the meaty part of the cmd.Command interface.
+func (c *revertCommitCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	newGenId, conflicts, err := client.RevertCommit(c.generationId, c.branchName, c.commit)
+	for _, conflict := range conflicts {
+		fmt.Fprintf(ctx.Stderr, "Not reverting %s %q: %s\n", conflict.Application, conflict.Key, conflict.Reason)
+	}
+	if err != nil {
+		return err
+	}
+
+	var msg string
+	if c.commit {
+		if err = c.SetActiveBranch(model.GenerationMaster); err != nil {
+			return err
+		}
+		msg = fmt.Sprintf("Branch %q committed; model is now at generation %d\n", c.branchName, newGenId)
+	} else {
+		if err = c.SetActiveBranch(c.branchName); err != nil {
+			return err
+		}
+		msg = fmt.Sprintf("Created branch %q reverting commit %d and set active\n", c.branchName, c.generationId)
+	}
+
+	_, err = ctx.Stdout.Write([]byte(msg))
+	return err
+}
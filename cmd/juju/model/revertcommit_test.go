@@ -0,0 +1,109 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/cmd/juju/model/mocks"
+	coremodel "github.com/juju/juju/core/model"
+)
+
+type revertCommitSuite struct {
+	generationBaseSuite
+}
+
+var _ = gc.Suite(&revertCommitSuite{})
+
+func (s *revertCommitSuite) TestInit(c *gc.C) {
+	err := s.runInit("3")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *revertCommitSuite) TestInitNoArg(c *gc.C) {
+	err := s.runInit()
+	c.Assert(err, gc.ErrorMatches, "expected exactly 1 commit id, got 0 arguments")
+}
+
+func (s *revertCommitSuite) TestInitInvalidId(c *gc.C) {
+	err := s.runInit("0")
+	c.Assert(err, gc.ErrorMatches, `invalid commit id "0"`)
+}
+
+func (s *revertCommitSuite) TestInitMasterBranch(c *gc.C) {
+	err := s.runInit("3", "--branch", coremodel.GenerationMaster)
+	c.Assert(err, gc.ErrorMatches, `branch name "master" not valid`)
+}
+
+func (s *revertCommitSuite) TestRunCommand(c *gc.C) {
+	ctrl, api := setUpRevertCommitMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().RevertCommit(3, "revert-3", false).Return(0, []coremodel.RevertConflict{
+		{Application: "redis", Key: "port", Reason: "changed by commit 4"},
+	}, nil)
+
+	ctx, err := s.runCommand(c, api, "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Created branch "revert-3" reverting commit 3 and set active
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+Not reverting redis "port": changed by commit 4
+`[1:])
+
+	details, err := s.store.ModelByName(
+		s.store.CurrentControllerName, s.store.Models[s.store.CurrentControllerName].CurrentModel)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(details.ActiveBranch, gc.Equals, "revert-3")
+}
+
+func (s *revertCommitSuite) TestRunCommandCommit(c *gc.C) {
+	ctrl, api := setUpRevertCommitMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().RevertCommit(3, s.branchName, true).Return(5, nil, nil)
+
+	ctx, err := s.runCommand(c, api, "3", "--branch", s.branchName, "--commit")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Branch "new-branch" committed; model is now at generation 5
+`[1:])
+
+	details, err := s.store.ModelByName(
+		s.store.CurrentControllerName, s.store.Models[s.store.CurrentControllerName].CurrentModel)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(details.ActiveBranch, gc.Equals, coremodel.GenerationMaster)
+}
+
+func (s *revertCommitSuite) TestRunCommandFail(c *gc.C) {
+	ctrl, api := setUpRevertCommitMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().RevertCommit(3, "revert-3", false).Return(0, nil, errors.Errorf("fail"))
+
+	_, err := s.runCommand(c, api, "3")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+func (s *revertCommitSuite) runInit(args ...string) error {
+	return cmdtesting.InitCommand(model.NewRevertCommitCommandForTest(nil, s.store), args)
+}
+
+func (s *revertCommitSuite) runCommand(c *gc.C, api model.RevertCommitCommandAPI, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, model.NewRevertCommitCommandForTest(api, s.store), args...)
+}
+
+func setUpRevertCommitMocks(c *gc.C) (*gomock.Controller, *mocks.MockRevertCommitCommandAPI) {
+	ctrl := gomock.NewController(c)
+	api := mocks.NewMockRevertCommitCommandAPI(ctrl)
+	api.EXPECT().Close()
+	return ctrl, api
+}
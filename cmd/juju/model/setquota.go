@@ -0,0 +1,174 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	setQuotaSummary = "Sets resource quotas for a model or user."
+	setQuotaDoc     = `
+Set-quota limits the number of machines, units and applications, and the
+total size of storage, that a model may consume. With the --user option the
+limits apply to all the models owned by the given user combined.
+
+Each limit is given as a key=value pair. Storage sizes may carry a unit
+suffix (M, G, T, ...) and default to MiB. A limit of 0 removes it. Limits
+that are not specified are also removed.
+
+Operations that would take a model or user over quota are refused. Use
+` + "`juju show-model`" + ` to see the current limits and usage of a model.
+
+Only controller administrators may set quotas.
+
+Examples:
+    juju set-quota machines=10 units=50
+    juju set-quota -m mymodel storage=500G
+    juju set-quota --user bob applications=20 storage=2T
+    juju set-quota machines=0
+
+See also:
+    show-model
+`
+)
+
+// NewSetQuotaCommand wraps setQuotaCommand with sane model settings.
+func NewSetQuotaCommand() cmd.Command {
+	return modelcmd.Wrap(&setQuotaCommand{})
+}
+
+// setQuotaCommand supplies the "set-quota" CLI command used to limit the
+// resources a model or user may consume.
+type setQuotaCommand struct {
+	modelcmd.ModelCommandBase
+
+	api SetQuotaCommandAPI
+
+	user   string
+	limits params.QuotaResources
+}
+
+// SetQuotaCommandAPI defines an API interface to be used during testing.
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination ./mocks/setquota_mock.go github.com/juju/juju/cmd/juju/model SetQuotaCommandAPI
+type SetQuotaCommandAPI interface {
+	Close() error
+
+	// SetModelQuota sets the resource limits of the given model.
+	SetModelQuota(model names.ModelTag, limits params.QuotaResources) error
+
+	// SetUserQuota sets the resource limits shared by the models
+	// owned by the given user.
+	SetUserQuota(user names.UserTag, limits params.QuotaResources) error
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *setQuotaCommand) Info() *cmd.Info {
+	info := &cmd.Info{
+		Name:    "set-quota",
+		Args:    "<resource>=<limit> ...",
+		Purpose: setQuotaSummary,
+		Doc:     setQuotaDoc,
+	}
+	return jujucmd.Info(info)
+}
+
+// SetFlags implements part of the cmd.Command interface.
+func (c *setQuotaCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.user, "user", "", "Set the quota of the models owned by this user")
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *setQuotaCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no quota limits specified")
+	}
+	if c.user != "" && !names.IsValidUser(c.user) {
+		return errors.NotValidf("user name %q", c.user)
+	}
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			return errors.Errorf("expected <resource>=<limit>, got %q", arg)
+		}
+		if err := c.setLimit(parts[0], parts[1]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (c *setQuotaCommand) setLimit(resource, value string) error {
+	if resource == "storage" {
+		size, err := utils.ParseSize(value)
+		if err != nil {
+			return errors.Annotatef(err, "invalid storage limit %q", value)
+		}
+		c.limits.StorageMiB = size
+		return nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return errors.Errorf("invalid %s limit %q", resource, value)
+	}
+	switch resource {
+	case "machines":
+		c.limits.Machines = n
+	case "units":
+		c.limits.Units = n
+	case "applications":
+		c.limits.Applications = n
+	default:
+		return errors.Errorf(
+			"unknown quota resource %q: expected machines, units, applications or storage", resource)
+	}
+	return nil
+}
+
+// getAPI returns the API. This allows passing in a test
+// SetQuotaCommandAPI implementation.
+func (c *setQuotaCommand) getAPI() (SetQuotaCommandAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return modelmanager.NewClient(api), nil
+}
+
+// Run implements the meaty part of the cmd.Command interface.
+func (c *setQuotaCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	if c.user != "" {
+		err = client.SetUserQuota(names.NewUserTag(c.user), c.limits)
+	} else {
+		_, modelDetails, detailsErr := c.ModelDetails()
+		if detailsErr != nil {
+			return errors.Trace(detailsErr)
+		}
+		err = client.SetModelQuota(names.NewModelTag(modelDetails.ModelUUID), c.limits)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,134 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/cmd/juju/model/mocks"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type setQuotaSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&setQuotaSuite{})
+
+func (s *setQuotaSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *setQuotaSuite) TestInit(c *gc.C) {
+	err := s.runInit("machines=10", "units=20", "applications=5", "storage=10G")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *setQuotaSuite) TestInitNoArgs(c *gc.C) {
+	err := s.runInit()
+	c.Assert(err, gc.ErrorMatches, "no quota limits specified")
+}
+
+func (s *setQuotaSuite) TestInitBadArgs(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"machines"},
+		err:  `expected <resource>=<limit>, got "machines"`,
+	}, {
+		args: []string{"machines=-1"},
+		err:  `invalid machines limit "-1"`,
+	}, {
+		args: []string{"units=lots"},
+		err:  `invalid units limit "lots"`,
+	}, {
+		args: []string{"storage=huge"},
+		err:  `invalid storage limit "huge": .*`,
+	}, {
+		args: []string{"cores=4"},
+		err:  `unknown quota resource "cores": expected machines, units, applications or storage`,
+	}, {
+		args: []string{"--user", "not/valid", "units=4"},
+		err:  `user name "not/valid" not valid`,
+	}} {
+		c.Logf("args: %v", test.args)
+		err := s.runInit(test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *setQuotaSuite) TestRunModelQuota(c *gc.C) {
+	ctrl, api := setUpSetQuotaMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().SetModelQuota(testing.ModelTag, params.QuotaResources{
+		Machines:   10,
+		StorageMiB: 10240,
+	}).Return(nil)
+
+	_, err := s.runCommand(c, api, "machines=10", "storage=10G")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *setQuotaSuite) TestRunUserQuota(c *gc.C) {
+	ctrl, api := setUpSetQuotaMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().SetUserQuota(names.NewUserTag("bob"), params.QuotaResources{
+		Units:        50,
+		Applications: 5,
+	}).Return(nil)
+
+	_, err := s.runCommand(c, api, "--user", "bob", "units=50", "applications=5")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *setQuotaSuite) TestRunFail(c *gc.C) {
+	ctrl, api := setUpSetQuotaMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().SetModelQuota(testing.ModelTag, params.QuotaResources{}).Return(errors.New("permission denied"))
+
+	_, err := s.runCommand(c, api, "machines=0")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *setQuotaSuite) runInit(args ...string) error {
+	return cmdtesting.InitCommand(model.NewSetQuotaCommandForTest(nil, s.store), args)
+}
+
+func (s *setQuotaSuite) runCommand(c *gc.C, api model.SetQuotaCommandAPI, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, model.NewSetQuotaCommandForTest(api, s.store), args...)
+}
+
+func setUpSetQuotaMocks(c *gc.C) (*gomock.Controller, *mocks.MockSetQuotaCommandAPI) {
+	ctrl := gomock.NewController(c)
+	api := mocks.NewMockSetQuotaCommandAPI(ctrl)
+	api.EXPECT().Close()
+	return ctrl, api
+}
@@ -570,6 +570,74 @@ basic-model:
 	s.assertShowOutput(c, "yaml")
 }
 
+func (s *ShowCommandSuite) TestShowWithQuotaYaml(c *gc.C) {
+	basicAndQuotaInfo := createBasicModelInfo()
+	basicAndQuotaInfo.Quota = &params.ModelQuotaInfo{
+		Limits:      params.QuotaResources{Machines: 10, StorageMiB: 2048},
+		Usage:       params.QuotaResources{Machines: 2, Units: 3, Applications: 1, StorageMiB: 512},
+		OwnerLimits: params.QuotaResources{Units: 20},
+		OwnerUsage:  params.QuotaResources{Machines: 4, Units: 7, Applications: 2},
+	}
+	s.fake.infos = []params.ModelInfoResult{
+		{Result: basicAndQuotaInfo},
+	}
+	s.expectedDisplay = `
+basic-model:
+  name: owner/basic-model
+  short-name: basic-model
+  model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  model-type: iaas
+  controller-uuid: deadbeef-1bad-500d-9000-4b1d0d06f00d
+  controller-name: testing
+  is-controller: false
+  owner: owner
+  cloud: altostratus
+  region: mid-level
+  life: dead
+  quota:
+    limits:
+      machines: 10
+      storage: 2.0GiB
+    usage:
+      machines: 2
+      units: 3
+      applications: 1
+      storage: 512MiB
+    owner-limits:
+      units: 20
+    owner-usage:
+      machines: 4
+      units: 7
+      applications: 2
+`[1:]
+	s.assertShowOutput(c, "yaml")
+}
+
+func (s *ShowCommandSuite) TestShowWithExpiryYaml(c *gc.C) {
+	basicAndExpiryInfo := createBasicModelInfo()
+	expiry := time.Date(2100, 1, 1, 12, 0, 0, 0, time.UTC)
+	basicAndExpiryInfo.Expiry = &expiry
+	s.fake.infos = []params.ModelInfoResult{
+		{Result: basicAndExpiryInfo},
+	}
+	s.expectedDisplay = `
+basic-model:
+  name: owner/basic-model
+  short-name: basic-model
+  model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  model-type: iaas
+  controller-uuid: deadbeef-1bad-500d-9000-4b1d0d06f00d
+  controller-name: testing
+  is-controller: false
+  owner: owner
+  cloud: altostratus
+  region: mid-level
+  life: dead
+  expires: "2100-01-01"
+`[1:]
+	s.assertShowOutput(c, "yaml")
+}
+
 func (s *ShowCommandSuite) TestShowBasicWithSLAIncompleteModelsJson(c *gc.C) {
 	basicAndSLAInfo := createBasicModelInfo()
 	basicAndSLAInfo.SLA = &params.ModelSLAInfo{
@@ -6,6 +6,7 @@ package model
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -15,6 +16,8 @@ import (
 	"github.com/juju/juju/api/modelgeneration"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/model"
 )
 
 const (
@@ -24,11 +27,28 @@ Specific units can be set to track a branch by supplying multiple unit IDs.
 All units of an application can be set to track a branch by passing an
 application name. Units can only track one branch at a time.
 
+When passing application names, the units to track can be selected with a
+policy instead:
+  --percent sets the percentage of the application's units to track,
+  --annotation limits the selection to units with the given annotation,
+  --constraints limits the selection to units on machines satisfying the
+    given constraints, such as arch, mem, tags or zones, and
+  --per-zone applies -n or --percent to the units in each availability zone.
+
+The --progressive option tracks the branch progressively. The percentage of
+units tracking the branch is increased by the given step at every interval,
+for as long as all of the tracking units are active and idle. Progression
+stops once all of the selected units track the branch.
+
 Examples:
     juju track test-branch redis/0
     juju track test-branch redis
     juju track test-branch redis -n 2
     juju track test-branch redis/0 mysql
+    juju track test-branch redis --percent 10 --per-zone
+    juju track test-branch redis --annotation canary=true
+    juju track test-branch redis --constraints "arch=arm64 zones=az1"
+    juju track test-branch redis --progressive 20 --interval 30m
 
 See also:
     add-branch
@@ -58,6 +78,18 @@ type trackBranchCommand struct {
 	// picked to track the number of units if there are more than the number
 	// requested.
 	numUnits autoIntValue
+
+	// percent, perZone, annotations and constraints describe a policy
+	// for selecting the units of applications to track.
+	percent        int
+	perZone        bool
+	annotations    map[string]string
+	constraintsStr string
+	constraints    constraints.Value
+
+	// stepPercent and interval describe progressive tracking.
+	stepPercent int
+	interval    time.Duration
 }
 
 // TrackBranchCommandAPI describes API methods required
@@ -69,6 +101,16 @@ type TrackBranchCommandAPI interface {
 	// TrackBranch sets the input units and/or applications
 	// to track changes made under the input branch name.
 	TrackBranch(branchName string, entities []string, numUnits int) error
+
+	// TrackBranchWithPolicy sets the units of the input applications
+	// selected by the input policy to track changes made under the input
+	// branch name.
+	TrackBranchWithPolicy(branchName string, entities []string, policy model.TrackPolicy) error
+
+	// TrackBranchProgressively sets the units of the input applications
+	// to track changes made under the input branch name, progressively.
+	TrackBranchProgressively(branchName string, entities []string, progression model.BranchProgression) error
+
 	HasActiveBranch(branchName string) (bool, error)
 }
 
@@ -87,6 +129,12 @@ func (c *trackBranchCommand) Info() *cmd.Info {
 func (c *trackBranchCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.Var(&c.numUnits, "n", "The number of units to track")
+	f.IntVar(&c.percent, "percent", 0, "The percentage of units to track")
+	f.BoolVar(&c.perZone, "per-zone", false, "Select units to track in each availability zone")
+	f.Var(cmd.StringMap{Mapping: &c.annotations}, "annotation", "Only track units with this annotation (key=value)")
+	f.StringVar(&c.constraintsStr, "constraints", "", "Only track units on machines satisfying these constraints")
+	f.IntVar(&c.stepPercent, "progressive", 0, "Track progressively, increasing the percentage of units by this step")
+	f.DurationVar(&c.interval, "interval", time.Hour, "The time between progressive tracking steps")
 }
 
 // Init implements part of the cmd.Command interface.
@@ -142,6 +190,25 @@ func (c *trackBranchCommand) Init(args []string) error {
 			return errors.Errorf("-n flag not allowed when specifying units")
 		}
 	}
+	if c.percent < 0 || c.percent > 100 {
+		return errors.Errorf("expected a percentage of units between 1 and 100")
+	}
+	if c.stepPercent < 0 || c.stepPercent > 100 {
+		return errors.Errorf("expected a progressive step between 1 and 100")
+	}
+	var err error
+	if c.constraints, err = constraints.Parse(c.constraintsStr); err != nil {
+		return errors.Trace(err)
+	}
+	if *c.numUnits.v > 0 && (c.percent > 0 || c.stepPercent > 0) {
+		return errors.Errorf("-n flag not allowed with --percent or --progressive")
+	}
+	if c.stepPercent > 0 && c.interval <= 0 {
+		return errors.Errorf("expected a positive progressive interval")
+	}
+	if c.hasPolicy() && numUnits > 0 {
+		return errors.Errorf("unit selection flags not allowed when specifying units")
+	}
 	c.branchName = args[0]
 	c.entities = entities
 	return nil
@@ -181,7 +248,30 @@ func (c *trackBranchCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("expected unit and/or application names(s)")
 	}
 
-	return errors.Trace(client.TrackBranch(c.branchName, c.entities, *c.numUnits.v))
+	if !c.hasPolicy() {
+		return errors.Trace(client.TrackBranch(c.branchName, c.entities, *c.numUnits.v))
+	}
+	policy := model.TrackPolicy{
+		NumUnits:    *c.numUnits.v,
+		Percent:     c.percent,
+		PerZone:     c.perZone,
+		Annotations: c.annotations,
+		Constraints: c.constraints,
+	}
+	if c.stepPercent == 0 {
+		return errors.Trace(client.TrackBranchWithPolicy(c.branchName, c.entities, policy))
+	}
+	return errors.Trace(client.TrackBranchProgressively(c.branchName, c.entities, model.BranchProgression{
+		Policy:      policy,
+		StepPercent: c.stepPercent,
+		Interval:    c.interval,
+	}))
+}
+
+// hasPolicy returns true if any of the unit selection
+// or progressive tracking flags were supplied.
+func (c *trackBranchCommand) hasPolicy() bool {
+	return c.percent > 0 || c.perZone || len(c.annotations) > 0 || c.constraintsStr != "" || c.stepPercent > 0
 }
 
 // autoIntValue allows the value of nil to mean something when attempting
@@ -4,6 +4,8 @@
 package model_test
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/juju/juju/cmd/juju/model"
 	"github.com/juju/juju/cmd/juju/model/mocks"
+	"github.com/juju/juju/core/constraints"
 	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/testing"
@@ -136,6 +139,77 @@ func (s *trackBranchSuite) TestRunCommandInValidNumUnitsAndTooManyUnits(c *gc.C)
 	c.Assert(err, gc.ErrorMatches, "-n flag not allowed when specifying units")
 }
 
+func (s *trackBranchSuite) TestRunCommandPolicy(c *gc.C) {
+	mockController, api := setUpAdvanceMocks(c)
+	defer mockController.Finish()
+
+	api.EXPECT().TrackBranchWithPolicy(s.branchName, []string{"redis"}, coremodel.TrackPolicy{
+		Percent:     10,
+		PerZone:     true,
+		Annotations: map[string]string{"canary": "true"},
+		Constraints: constraints.MustParse("arch=arm64 zones=az1"),
+	}).Return(nil)
+
+	_, err := s.runCommand(c, api, s.branchName, "redis", "--percent", "10", "--per-zone", "--annotation", "canary=true",
+		"--constraints", "arch=arm64 zones=az1")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *trackBranchSuite) TestRunCommandNumUnitsPerZone(c *gc.C) {
+	mockController, api := setUpAdvanceMocks(c)
+	defer mockController.Finish()
+
+	api.EXPECT().TrackBranchWithPolicy(s.branchName, []string{"redis"}, coremodel.TrackPolicy{
+		NumUnits: 1,
+		PerZone:  true,
+	}).Return(nil)
+
+	_, err := s.runCommand(c, api, s.branchName, "redis", "-n", "1", "--per-zone")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *trackBranchSuite) TestRunCommandProgressive(c *gc.C) {
+	mockController, api := setUpAdvanceMocks(c)
+	defer mockController.Finish()
+
+	api.EXPECT().TrackBranchProgressively(s.branchName, []string{"redis"}, coremodel.BranchProgression{
+		Policy:      coremodel.TrackPolicy{Percent: 10},
+		StepPercent: 20,
+		Interval:    30 * time.Minute,
+	}).Return(nil)
+
+	_, err := s.runCommand(c, api, s.branchName, "redis", "--percent", "10", "--progressive", "20", "--interval", "30m")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *trackBranchSuite) TestInitInvalidPercent(c *gc.C) {
+	err := s.runInit(s.branchName, "redis", "--percent", "101")
+	c.Assert(err, gc.ErrorMatches, "expected a percentage of units between 1 and 100")
+}
+
+func (s *trackBranchSuite) TestInitInvalidProgressive(c *gc.C) {
+	err := s.runInit(s.branchName, "redis", "--progressive", "-5")
+	c.Assert(err, gc.ErrorMatches, "expected a progressive step between 1 and 100")
+
+	err = s.runInit(s.branchName, "redis", "--progressive", "10", "--interval", "0s")
+	c.Assert(err, gc.ErrorMatches, "expected a positive progressive interval")
+}
+
+func (s *trackBranchSuite) TestInitInvalidConstraints(c *gc.C) {
+	err := s.runInit(s.branchName, "redis", "--constraints", "mem=lots")
+	c.Assert(err, gc.ErrorMatches, `bad "mem" constraint: .*`)
+}
+
+func (s *trackBranchSuite) TestInitNumUnitsWithPercent(c *gc.C) {
+	err := s.runInit(s.branchName, "redis", "-n", "2", "--percent", "10")
+	c.Assert(err, gc.ErrorMatches, "-n flag not allowed with --percent or --progressive")
+}
+
+func (s *trackBranchSuite) TestInitPolicyWithUnits(c *gc.C) {
+	err := s.runInit(s.branchName, "redis/0", "--annotation", "canary=true")
+	c.Assert(err, gc.ErrorMatches, "unit selection flags not allowed when specifying units")
+}
+
 func (s *trackBranchSuite) runInit(args ...string) error {
 	return cmdtesting.InitCommand(model.NewTrackBranchCommandForTest(nil, s.store), args)
 }
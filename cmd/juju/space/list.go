@@ -10,7 +10,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/gosuri/uitable"
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -31,12 +30,16 @@ type ListCommand struct {
 	SpaceCommandBase
 	Short bool
 	out   cmd.Output
+	table output.TableFlags
 }
 
 const listCommandDoc = `
 Displays all defined spaces. By default both spaces and their subnets are displayed.
 Supplying the --short option will list just the space names.
 The --output argument allows the command's output to be redirected to a file. 
+The --columns and --sort options select and order the columns of the
+tabular output; the names of the columns are their headers in lower case
+with spaces replaced by hyphens.
 
 Examples:
 
@@ -48,6 +51,10 @@ List spaces:
 
 	juju spaces --short
 
+List spaces and their subnets, most recently added space first:
+
+	juju spaces --sort -space-id
+
 See also:
 	add-space
 	reload-spaces
@@ -73,6 +80,7 @@ func (c *ListCommand) SetFlags(f *gnuflag.FlagSet) {
 		"tabular": c.printTabular,
 	})
 	f.BoolVar(&c.Short, "short", false, "only display spaces.")
+	c.table.AddFlags(f)
 }
 
 // Init is defined on the cmd.Command interface. It checks the
@@ -169,46 +177,41 @@ func (c *ListCommand) Run(ctx *cmd.Context) error {
 
 // printTabular prints the list of spaces in tabular format
 func (c *ListCommand) printTabular(writer io.Writer, value interface{}) error {
-	tw := output.TabWriter(writer)
-
-	write := printTabularLong
+	var table *output.Table
+	var err error
 	if c.Short {
-		write = printTabularShort
+		table, err = shortTable(value)
+	} else {
+		table, err = longTable(value)
 	}
-	if err := write(tw, value); err != nil {
+	if err != nil {
 		return errors.Trace(err)
 	}
-
-	return errors.Trace(tw.Flush())
+	return errors.Trace(table.Write(writer, c.table.Options()))
 }
 
-func printTabularShort(writer io.Writer, value interface{}) error {
+func shortTable(value interface{}) (*output.Table, error) {
 	list, ok := value.(formattedShortList)
 	if !ok {
-		return errors.New("unexpected value")
+		return nil, errors.New("unexpected value")
 	}
 
-	_, _ = fmt.Fprintln(writer, "Space")
+	table := output.NewTable("Space")
 	spaces := list.Spaces
 	sort.Strings(spaces)
 	for _, space := range spaces {
-		_, _ = fmt.Fprintf(writer, "%v\n", space)
+		table.AddRow(space)
 	}
-
-	return nil
+	return table, nil
 }
 
-func printTabularLong(writer io.Writer, value interface{}) error {
+func longTable(value interface{}) (*output.Table, error) {
 	list, ok := value.(formattedList)
 	if !ok {
-		return errors.New("unexpected value")
+		return nil, errors.New("unexpected value")
 	}
 
-	table := uitable.New()
-	table.MaxColWidth = 50
-	table.Wrap = true
-
-	table.AddRow("Name", "Space ID", "Subnets")
+	table := output.NewTable("Name", "Space ID", "Subnets")
 	for _, s := range list.Spaces {
 		if len(s.Subnets) == 0 {
 			table.AddRow(spaceName(s.Name), s.Id, "")
@@ -223,13 +226,10 @@ func printTabularLong(writer io.Writer, value interface{}) error {
 
 		table.AddRow(spaceName(s.Name), s.Id, cidrs[0])
 		for i := 1; i < len(cidrs); i++ {
-			table.AddRow("", "", cidrs[i])
+			table.AddDetailRow("", "", cidrs[i])
 		}
 	}
-
-	table.AddRow("", "", "")
-	_, _ = fmt.Fprint(writer, table)
-	return nil
+	return table, nil
 }
 
 const (
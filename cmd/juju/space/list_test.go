@@ -205,13 +205,13 @@ spaces:
 `, "") + "\n"
 
 	expectedTabular := `
-Name    Space ID  Subnets      
-alpha   0                      
+Name    Space ID  Subnets
+alpha   0         
 space1  1         2001:db8::/32
-                  invalid      
-space2  2         10.1.2.0/24  
-                  4.3.2.0/28   
-                               
+                  invalid
+space2  2         10.1.2.0/24
+                  4.3.2.0/28
+
 `[1:]
 
 	expectedShortTabular := `
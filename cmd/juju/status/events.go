@@ -0,0 +1,382 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/juju/osenv"
+)
+
+// NewEventsCommand returns a command that reports the changes made to
+// a model.
+func NewEventsCommand() cmd.Command {
+	return modelcmd.Wrap(&eventsCommand{})
+}
+
+// EventsAPI is the API surface for the events command.
+type EventsAPI interface {
+	StatusHistories(requests ...api.StatusHistoryRequest) ([]api.StatusHistoryResult, error)
+	WatchAll() (AllWatcher, error)
+	Close() error
+}
+
+// AllWatcher is the all-watcher surface used by the events command.
+type AllWatcher interface {
+	Next() ([]params.Delta, error)
+	Stop() error
+}
+
+type eventsCommand struct {
+	modelcmd.ModelCommandBase
+	api     EventsAPI
+	clock   clock.Clock
+	out     cmd.Output
+	follow  bool
+	limit   int
+	isoTime bool
+}
+
+const eventsDoc = `
+Report the changes made to a model, such as applications being
+deployed, configuration changes, unit and machine status transitions,
+relations joining and actions completing.
+
+Without --follow, the most recent status changes of the units and
+machines in the model and the actions run on them are shown. With
+--follow, changes are reported as they happen until the command is
+interrupted.
+
+Each event records the time it happened and who made the change: the
+tag of the agent that reported it or, for changes requested by a user
+such as retrying provisioning, the tag of that user.
+
+The json format writes one JSON document per line, which is suitable
+for piping into other tools.
+
+Examples:
+
+    juju events
+    juju events -n 50
+    juju events --follow
+    juju events --follow --format json
+
+See also:
+    status
+    show-status-log
+    debug-log
+`
+
+// Info implements Command.Info.
+func (c *eventsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "events",
+		Purpose: "Reports the changes made to a model.",
+		Doc:     eventsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *eventsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", c.formatters())
+	f.BoolVar(&c.follow, "follow", false, "Report changes as they happen")
+	f.IntVar(&c.limit, "n", 20, "Show the last N past events")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+}
+
+func (c *eventsCommand) formatters() map[string]cmd.Formatter {
+	return map[string]cmd.Formatter{
+		"json":    c.formatJSON,
+		"tabular": c.formatTabular,
+	}
+}
+
+// Init implements Command.Init.
+func (c *eventsCommand) Init(args []string) error {
+	if c.limit < 0 {
+		return errors.Errorf("-n must not be negative")
+	}
+	// If use of ISO time not specified on command line,
+	// check env var.
+	if !c.isoTime {
+		var err error
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	if c.clock == nil {
+		c.clock = clock.WallClock
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *eventsCommand) getAPI() (EventsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return eventsClient{client}, nil
+}
+
+// Run implements Command.Run.
+func (c *eventsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model")
+	}
+	var (
+		stopOnce sync.Once
+		stopped  = make(chan struct{})
+	)
+	stop := func() {
+		stopOnce.Do(func() {
+			close(stopped)
+			_ = watcher.Stop()
+		})
+	}
+	defer stop()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+	go func() {
+		select {
+		case <-interrupted:
+			stop()
+		case <-stopped:
+		}
+	}()
+
+	// The first set of deltas describes the current state of
+	// the model.
+	deltas, err := watcher.Next()
+	if err != nil {
+		return errors.Annotate(err, "cannot watch model")
+	}
+	tracker := newEventTracker()
+	tracker.update(deltas, c.clock.Now())
+
+	var past []Event
+	if c.limit > 0 {
+		if past, err = c.pastEvents(client, tracker); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	// WriteFormatter is used so that no trailing newline is added
+	// once the stream ends.
+	formatter := c.formatters()[c.out.Name()]
+	return c.out.WriteFormatter(ctx, formatter, eventStream(func(write func(Event) error) error {
+		for _, event := range past {
+			if err := write(event); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if !c.follow {
+			return nil
+		}
+		for {
+			deltas, err := watcher.Next()
+			if err != nil {
+				select {
+				case <-stopped:
+					return nil
+				default:
+				}
+				return errors.Annotate(err, "cannot watch model")
+			}
+			for _, event := range tracker.update(deltas, c.clock.Now()) {
+				if err := write(event); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+	}))
+}
+
+// eventStream passes each event to write as it becomes available, so
+// that followed events are formatted as they happen rather than once
+// the command finishes.
+type eventStream func(write func(Event) error) error
+
+// pastEvents returns the most recent events recorded in the status
+// history of the units and machines in the model, along with the
+// actions that have been run on them.
+func (c *eventsCommand) pastEvents(client EventsAPI, tracker *eventTracker) ([]Event, error) {
+	filter := status.StatusHistoryFilter{
+		Size:    c.limit,
+		Exclude: set.NewStrings(runningHookMSG),
+	}
+	type entity struct {
+		kind, name string
+	}
+	var (
+		entities []entity
+		requests []api.StatusHistoryRequest
+	)
+	for name := range tracker.units {
+		entities = append(entities, entity{"unit", name})
+		requests = append(requests, api.StatusHistoryRequest{
+			Kind:   status.KindUnit,
+			Tag:    names.NewUnitTag(name),
+			Filter: filter,
+		})
+	}
+	for id := range tracker.machines {
+		entities = append(entities, entity{"machine", id})
+		requests = append(requests, api.StatusHistoryRequest{
+			Kind:   status.KindMachine,
+			Tag:    names.NewMachineTag(id),
+			Filter: filter,
+		})
+	}
+	var events []Event
+	if len(requests) > 0 {
+		results, err := client.StatusHistories(requests...)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot get status history")
+		}
+		for i, result := range results {
+			e := entities[i]
+			if errors.IsNotFound(result.Error) || params.IsCodeNotFound(result.Error) {
+				// The entity was removed since the model was read.
+				continue
+			} else if result.Error != nil {
+				return nil, errors.Annotatef(result.Error, "cannot get status history for %s %s", e.kind, e.name)
+			}
+			for _, h := range result.History {
+				if h.Since == nil {
+					continue
+				}
+				events = append(events, historyEvent(e.kind, e.name, requests[i].Tag, h))
+			}
+		}
+	}
+	for _, action := range tracker.actions {
+		events = append(events, actionEvent(action, c.clock.Now()))
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Time.Equal(events[j].Time) {
+			return events[i].Entity < events[j].Entity
+		}
+		return events[i].Time.Before(events[j].Time)
+	})
+	if len(events) > c.limit {
+		events = events[len(events)-c.limit:]
+	}
+	return events, nil
+}
+
+// historyEvent converts a status history entry into an event. The
+// actor is the user recorded in the status data when the change was
+// made on a user's behalf, and the entity's agent otherwise.
+func historyEvent(kind, name string, tag names.Tag, h status.DetailedStatus) Event {
+	event := Event{
+		Time:    *h.Since,
+		Kind:    kind,
+		Entity:  name,
+		Type:    "agent-status",
+		Actor:   tag.String(),
+		Message: string(h.Status),
+	}
+	if user, ok := h.Data[status.DataUser].(string); ok && names.IsValidUser(user) {
+		event.Actor = names.NewUserTag(user).String()
+	}
+	if h.Kind == status.KindWorkload {
+		event.Type = "workload-status"
+	}
+	if h.Info != "" {
+		event.Message += ": " + h.Info
+	}
+	return event
+}
+
+// formatJSON writes each event as a JSON document on its own line.
+func (c *eventsCommand) formatJSON(w io.Writer, value interface{}) error {
+	stream, ok := value.(eventStream)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", stream, value)
+	}
+	encoder := json.NewEncoder(w)
+	return stream(func(event Event) error {
+		return errors.Trace(encoder.Encode(event))
+	})
+}
+
+const eventLineFormat = "%-26s %-20s %-16s %-16s %s\n"
+
+// formatTabular writes each event as a row of a table. The header is
+// written before the first event, or straight away when following.
+func (c *eventsCommand) formatTabular(w io.Writer, value interface{}) error {
+	stream, ok := value.(eventStream)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", stream, value)
+	}
+	headerWritten := false
+	writeHeader := func() {
+		if !headerWritten {
+			fmt.Fprintf(w, eventLineFormat, "Time", "Entity", "Event", "Actor", "Message")
+			headerWritten = true
+		}
+	}
+	if c.follow {
+		writeHeader()
+	}
+	return stream(func(event Event) error {
+		writeHeader()
+		actor := event.Actor
+		if actor == "" {
+			actor = "-"
+		}
+		entity := event.Entity
+		if event.Kind == "action" || event.Kind == "relation" {
+			entity = event.Kind + " " + entity
+		}
+		_, err := fmt.Fprintf(w, eventLineFormat,
+			common.FormatTime(&event.Time, c.isoTime), entity, event.Type, actor, event.Message)
+		return errors.Trace(err)
+	})
+}
+
+// eventsClient adapts the API client to return the all-watcher
+// through the AllWatcher interface.
+type eventsClient struct {
+	*api.Client
+}
+
+// WatchAll implements EventsAPI.
+func (c eventsClient) WatchAll() (AllWatcher, error) {
+	return c.Client.WatchAll()
+}
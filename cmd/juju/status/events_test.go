@@ -0,0 +1,268 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	statuscmd "github.com/juju/juju/cmd/juju/status"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/juju/osenv"
+)
+
+type EventsSuite struct {
+	testing.IsolationSuite
+	api   *fakeEventsAPI
+	clock *testclock.Clock
+	now   time.Time
+}
+
+var _ = gc.Suite(&EventsSuite{})
+
+func (s *EventsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.PatchEnvironment(osenv.JujuStatusIsoTimeEnvKey, "")
+	s.now = time.Date(2020, 10, 19, 12, 0, 0, 0, time.UTC)
+	s.clock = testclock.NewClock(s.now)
+	s.api = &fakeEventsAPI{
+		history: make(map[string]status.History),
+		watcher: &fakeAllWatcher{},
+	}
+	s.api.watcher.deltas = [][]params.Delta{{
+		{Entity: &params.ApplicationInfo{Name: "mysql", CharmURL: "cs:mysql-1", Life: life.Alive}},
+		{Entity: &params.UnitInfo{
+			Name:           "mysql/0",
+			Application:    "mysql",
+			MachineId:      "0",
+			WorkloadStatus: params.StatusInfo{Current: status.Active, Message: "ready"},
+			AgentStatus:    params.StatusInfo{Current: status.Idle},
+		}},
+		{Entity: &params.MachineInfo{Id: "0", Series: "focal", AgentStatus: params.StatusInfo{Current: status.Started}}},
+		{Entity: &params.ActionInfo{
+			Id:        "3",
+			Receiver:  "unit-mysql-0",
+			Name:      "backup",
+			Status:    "completed",
+			Completed: s.at(5),
+		}},
+	}}
+	s.api.history["unit-mysql-0"] = status.History{
+		{Kind: status.KindWorkload, Status: status.Maintenance, Info: "installing", Since: s.atPtr(1)},
+		{Kind: status.KindUnitAgent, Status: status.Idle, Since: s.atPtr(3)},
+		{Kind: status.KindWorkload, Status: status.Active, Info: "ready", Since: s.atPtr(4)},
+	}
+	s.api.history["machine-0"] = status.History{
+		{Kind: status.KindMachine, Status: status.Started, Since: s.atPtr(2)},
+	}
+}
+
+func (s *EventsSuite) at(minutes int) time.Time {
+	return s.now.Add(time.Duration(minutes-10) * time.Minute)
+}
+
+func (s *EventsSuite) atPtr(minutes int) *time.Time {
+	t := s.at(minutes)
+	return &t
+}
+
+func (s *EventsSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, statuscmd.NewTestEventsCommand(s.api, s.clock), args...)
+}
+
+func (s *EventsSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c, "--format", "yaml")
+	c.Assert(err, gc.ErrorMatches, `invalid value "yaml" for option --format: unknown format "yaml"`)
+	_, err = s.run(c, "-n", "-1")
+	c.Assert(err, gc.ErrorMatches, `-n must not be negative`)
+	_, err = s.run(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["mysql"\]`)
+}
+
+func (s *EventsSuite) TestPastEvents(c *gc.C) {
+	ctx, err := s.run(c, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                       Entity               Event            Actor            Message
+2020-10-19 11:51:00Z       mysql/0              workload-status  unit-mysql-0     maintenance: installing
+2020-10-19 11:52:00Z       0                    agent-status     machine-0        started
+2020-10-19 11:53:00Z       mysql/0              agent-status     unit-mysql-0     idle
+2020-10-19 11:54:00Z       mysql/0              workload-status  unit-mysql-0     active: ready
+2020-10-19 11:55:00Z       action 3             completed        unit-mysql-0     backup on mysql/0
+`[1:])
+	c.Assert(s.api.watcher.stopped, jc.IsTrue)
+	s.api.CheckCallNames(c, "WatchAll", "StatusHistories", "Close")
+}
+
+func (s *EventsSuite) TestPastEventsUserActor(c *gc.C) {
+	s.api.history["machine-0"] = append(s.api.history["machine-0"], status.DetailedStatus{
+		Kind:   status.KindMachine,
+		Status: status.Error,
+		Info:   "no matching image",
+		Data:   map[string]interface{}{"transient": true, status.DataUser: "fred"},
+		Since:  s.atPtr(6),
+	})
+	ctx, err := s.run(c, "--utc", "-n", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                       Entity               Event            Actor            Message
+2020-10-19 11:55:00Z       action 3             completed        unit-mysql-0     backup on mysql/0
+2020-10-19 11:56:00Z       0                    agent-status     user-fred        error: no matching image
+`[1:])
+}
+
+func (s *EventsSuite) TestPastEventsEntityRemoved(c *gc.C) {
+	s.api.errors = map[string]error{"machine-0": &params.Error{Code: params.CodeNotFound, Message: "machine 0 not found"}}
+	ctx, err := s.run(c, "--utc", "--format", "json", "-n", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+{"timestamp":"2020-10-19T11:55:00Z","kind":"action","entity":"3","type":"completed","actor":"unit-mysql-0","message":"backup on mysql/0"}
+`[1:])
+}
+
+func (s *EventsSuite) TestOutputFile(c *gc.C) {
+	ctx, err := s.run(c, "--utc", "-n", "1", "--format", "json", "-o", "events.json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	data, err := ioutil.ReadFile(ctx.AbsPath("events.json"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, `
+{"timestamp":"2020-10-19T11:55:00Z","kind":"action","entity":"3","type":"completed","actor":"unit-mysql-0","message":"backup on mysql/0"}
+`[1:])
+}
+
+func (s *EventsSuite) TestPastEventsLimit(c *gc.C) {
+	ctx, err := s.run(c, "--utc", "-n", "2", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+{"timestamp":"2020-10-19T11:54:00Z","kind":"unit","entity":"mysql/0","type":"workload-status","actor":"unit-mysql-0","message":"active: ready"}
+{"timestamp":"2020-10-19T11:55:00Z","kind":"action","entity":"3","type":"completed","actor":"unit-mysql-0","message":"backup on mysql/0"}
+`[1:])
+}
+
+func (s *EventsSuite) TestFollow(c *gc.C) {
+	s.api.watcher.deltas = append(s.api.watcher.deltas, []params.Delta{
+		{Entity: &params.ApplicationInfo{Name: "wordpress", CharmURL: "cs:wordpress-2"}},
+		{Entity: &params.ApplicationInfo{
+			Name:     "mysql",
+			CharmURL: "cs:mysql-1",
+			Config:   map[string]interface{}{"tuning-level": "fast"},
+		}},
+		{Entity: &params.RelationInfo{Key: "wordpress:db mysql:db", Id: 1}},
+	}, []params.Delta{
+		{Entity: &params.UnitInfo{
+			Name:           "mysql/0",
+			Application:    "mysql",
+			MachineId:      "0",
+			WorkloadStatus: params.StatusInfo{Current: status.Maintenance, Message: "restarting", Since: s.atPtr(12)},
+			AgentStatus:    params.StatusInfo{Current: status.Executing, Message: "running config-changed hook", Since: s.atPtr(11)},
+		}},
+		{Entity: &params.ActionInfo{
+			Id:       "4",
+			Receiver: "unit-mysql-0",
+			Name:     "restore",
+			Status:   "failed",
+			Message:  "no backup",
+			Enqueued: s.at(10),
+			Started:  s.at(11),
+		}},
+	}, []params.Delta{
+		{Removed: true, Entity: &params.RelationInfo{Key: "wordpress:db mysql:db", Id: 1}},
+	})
+	ctx, err := s.run(c, "--follow", "-n", "0", "--format", "json")
+	c.Assert(err, gc.ErrorMatches, "cannot watch model: no more deltas")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+{"timestamp":"2020-10-19T12:00:00Z","kind":"application","entity":"wordpress","type":"deployed","message":"cs:wordpress-2"}
+{"timestamp":"2020-10-19T12:00:00Z","kind":"application","entity":"mysql","type":"config-changed","message":"tuning-level"}
+{"timestamp":"2020-10-19T12:00:00Z","kind":"relation","entity":"wordpress:db mysql:db","type":"joined"}
+{"timestamp":"2020-10-19T12:01:00Z","kind":"unit","entity":"mysql/0","type":"agent-status","actor":"unit-mysql-0","message":"executing: running config-changed hook"}
+{"timestamp":"2020-10-19T12:01:00Z","kind":"action","entity":"4","type":"failed","actor":"unit-mysql-0","message":"restore on mysql/0: no backup"}
+{"timestamp":"2020-10-19T12:02:00Z","kind":"unit","entity":"mysql/0","type":"workload-status","actor":"unit-mysql-0","message":"maintenance: restarting"}
+{"timestamp":"2020-10-19T12:00:00Z","kind":"relation","entity":"wordpress:db mysql:db","type":"removed"}
+`[1:])
+	// No past events were requested.
+	s.api.CheckCallNames(c, "WatchAll", "Close")
+}
+
+func (s *EventsSuite) TestHistoryError(c *gc.C) {
+	s.api.SetErrors(nil, errors.New("boom"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "cannot get status history: boom")
+}
+
+func (s *EventsSuite) TestEntityHistoryError(c *gc.C) {
+	s.api.errors = map[string]error{"machine-0": errors.New("boom")}
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "cannot get status history for machine 0: boom")
+}
+
+type fakeEventsAPI struct {
+	testing.Stub
+	history map[string]status.History
+	errors  map[string]error
+	watcher *fakeAllWatcher
+}
+
+func (f *fakeEventsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeEventsAPI) WatchAll() (statuscmd.AllWatcher, error) {
+	f.MethodCall(f, "WatchAll")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.watcher, nil
+}
+
+func (f *fakeEventsAPI) StatusHistories(requests ...api.StatusHistoryRequest) ([]api.StatusHistoryResult, error) {
+	f.MethodCall(f, "StatusHistories", requests)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	results := make([]api.StatusHistoryResult, len(requests))
+	for i, request := range requests {
+		if err := f.errors[request.Tag.String()]; err != nil {
+			results[i].Error = err
+			continue
+		}
+		history := f.history[request.Tag.String()]
+		if len(history) > request.Filter.Size {
+			history = history[len(history)-request.Filter.Size:]
+		}
+		results[i].History = history
+	}
+	return results, nil
+}
+
+type fakeAllWatcher struct {
+	deltas  [][]params.Delta
+	stopped bool
+}
+
+func (w *fakeAllWatcher) Next() ([]params.Delta, error) {
+	if len(w.deltas) == 0 {
+		return nil, errors.New("no more deltas")
+	}
+	next := w.deltas[0]
+	w.deltas = w.deltas[1:]
+	return next, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
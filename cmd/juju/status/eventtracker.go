@@ -0,0 +1,311 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Event describes a single change to a model.
+type Event struct {
+	// Time is when the change happened. Changes that carry no
+	// timestamp of their own are stamped with the time they were seen.
+	Time time.Time `json:"timestamp"`
+
+	// Kind is the kind of entity that changed, such as "unit".
+	Kind string `json:"kind"`
+
+	// Entity is the name of the entity that changed.
+	Entity string `json:"entity"`
+
+	// Type describes the change, such as "workload-status".
+	Type string `json:"type"`
+
+	// Actor is the tag of the agent that made the change, if it is
+	// known. Changes made through the API by users have no actor.
+	Actor string `json:"actor,omitempty"`
+
+	// Message is a human readable description of the change.
+	Message string `json:"message,omitempty"`
+}
+
+// eventTracker records the entities reported by an all-watcher so
+// that subsequent deltas can be turned into events describing what
+// changed.
+type eventTracker struct {
+	applications map[string]params.ApplicationInfo
+	units        map[string]params.UnitInfo
+	machines     map[string]params.MachineInfo
+	relations    map[string]params.RelationInfo
+	actions      map[string]params.ActionInfo
+	models       map[string]params.ModelUpdate
+}
+
+func newEventTracker() *eventTracker {
+	return &eventTracker{
+		applications: make(map[string]params.ApplicationInfo),
+		units:        make(map[string]params.UnitInfo),
+		machines:     make(map[string]params.MachineInfo),
+		relations:    make(map[string]params.RelationInfo),
+		actions:      make(map[string]params.ActionInfo),
+		models:       make(map[string]params.ModelUpdate),
+	}
+}
+
+// update records the entities in deltas and returns the events
+// describing the changes, stamping those without a time of their own
+// with now.
+func (t *eventTracker) update(deltas []params.Delta, now time.Time) []Event {
+	var events []Event
+	for _, delta := range deltas {
+		switch info := delta.Entity.(type) {
+		case *params.ApplicationInfo:
+			events = append(events, t.applicationEvents(*info, delta.Removed, now)...)
+		case *params.UnitInfo:
+			events = append(events, t.unitEvents(*info, delta.Removed, now)...)
+		case *params.MachineInfo:
+			events = append(events, t.machineEvents(*info, delta.Removed, now)...)
+		case *params.RelationInfo:
+			events = append(events, t.relationEvents(*info, delta.Removed, now)...)
+		case *params.ActionInfo:
+			events = append(events, t.actionEvents(*info, delta.Removed, now)...)
+		case *params.ModelUpdate:
+			events = append(events, t.modelEvents(*info, delta.Removed, now)...)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	return events
+}
+
+func (t *eventTracker) applicationEvents(info params.ApplicationInfo, removed bool, now time.Time) []Event {
+	event := func(eventType, message string) Event {
+		return Event{Time: now, Kind: "application", Entity: info.Name, Type: eventType, Message: message}
+	}
+	old, ok := t.applications[info.Name]
+	if removed {
+		delete(t.applications, info.Name)
+		return []Event{event("removed", "")}
+	}
+	t.applications[info.Name] = info
+	if !ok {
+		return []Event{event("deployed", info.CharmURL)}
+	}
+	var events []Event
+	if old.CharmURL != info.CharmURL {
+		events = append(events, event("charm-upgraded", old.CharmURL+" -> "+info.CharmURL))
+	}
+	if changed := changedKeys(old.Config, info.Config); len(changed) > 0 {
+		events = append(events, event("config-changed", strings.Join(changed, ", ")))
+	}
+	if old.Exposed != info.Exposed {
+		eventType := "exposed"
+		if !info.Exposed {
+			eventType = "unexposed"
+		}
+		events = append(events, event(eventType, ""))
+	}
+	if statusChanged(old.Status, info.Status) {
+		e := event("status", statusMessage(info.Status))
+		e.Time = statusTime(info.Status, now)
+		events = append(events, e)
+	}
+	return events
+}
+
+func (t *eventTracker) unitEvents(info params.UnitInfo, removed bool, now time.Time) []Event {
+	event := func(eventType, message string) Event {
+		return Event{Time: now, Kind: "unit", Entity: info.Name, Type: eventType, Message: message}
+	}
+	old, ok := t.units[info.Name]
+	if removed {
+		delete(t.units, info.Name)
+		return []Event{event("removed", "")}
+	}
+	t.units[info.Name] = info
+	if !ok {
+		message := ""
+		if info.MachineId != "" {
+			message = "on machine " + info.MachineId
+		}
+		return []Event{event("added", message)}
+	}
+	var events []Event
+	if statusChanged(old.AgentStatus, info.AgentStatus) {
+		e := event("agent-status", statusMessage(info.AgentStatus))
+		e.Time = statusTime(info.AgentStatus, now)
+		e.Actor = names.NewUnitTag(info.Name).String()
+		events = append(events, e)
+	}
+	if statusChanged(old.WorkloadStatus, info.WorkloadStatus) {
+		e := event("workload-status", statusMessage(info.WorkloadStatus))
+		e.Time = statusTime(info.WorkloadStatus, now)
+		e.Actor = names.NewUnitTag(info.Name).String()
+		events = append(events, e)
+	}
+	return events
+}
+
+func (t *eventTracker) machineEvents(info params.MachineInfo, removed bool, now time.Time) []Event {
+	event := func(eventType, message string) Event {
+		return Event{Time: now, Kind: "machine", Entity: info.Id, Type: eventType, Message: message}
+	}
+	old, ok := t.machines[info.Id]
+	if removed {
+		delete(t.machines, info.Id)
+		return []Event{event("removed", "")}
+	}
+	t.machines[info.Id] = info
+	if !ok {
+		return []Event{event("added", info.Series)}
+	}
+	var events []Event
+	if statusChanged(old.AgentStatus, info.AgentStatus) {
+		e := event("agent-status", statusMessage(info.AgentStatus))
+		e.Time = statusTime(info.AgentStatus, now)
+		e.Actor = names.NewMachineTag(info.Id).String()
+		events = append(events, e)
+	}
+	if statusChanged(old.InstanceStatus, info.InstanceStatus) {
+		e := event("instance-status", statusMessage(info.InstanceStatus))
+		e.Time = statusTime(info.InstanceStatus, now)
+		events = append(events, e)
+	}
+	return events
+}
+
+func (t *eventTracker) relationEvents(info params.RelationInfo, removed bool, now time.Time) []Event {
+	event := func(eventType string) Event {
+		return Event{Time: now, Kind: "relation", Entity: info.Key, Type: eventType}
+	}
+	_, ok := t.relations[info.Key]
+	if removed {
+		delete(t.relations, info.Key)
+		return []Event{event("removed")}
+	}
+	t.relations[info.Key] = info
+	if !ok {
+		return []Event{event("joined")}
+	}
+	return nil
+}
+
+func (t *eventTracker) actionEvents(info params.ActionInfo, removed bool, now time.Time) []Event {
+	if removed {
+		delete(t.actions, info.Id)
+		return nil
+	}
+	old, ok := t.actions[info.Id]
+	t.actions[info.Id] = info
+	if ok && old.Status == info.Status {
+		return nil
+	}
+	return []Event{actionEvent(info, now)}
+}
+
+// actionEvent returns an event describing the current state of an
+// action, timed by the most recent step the action has reached.
+func actionEvent(info params.ActionInfo, now time.Time) Event {
+	event := Event{
+		Time:    now,
+		Kind:    "action",
+		Entity:  info.Id,
+		Type:    info.Status,
+		Message: fmt.Sprintf("%s on %s", info.Name, actionReceiver(info.Receiver)),
+	}
+	if info.Message != "" {
+		event.Message += ": " + info.Message
+	}
+	switch {
+	case !info.Completed.IsZero():
+		event.Time = info.Completed
+		event.Actor = info.Receiver
+	case !info.Started.IsZero():
+		event.Time = info.Started
+		event.Actor = info.Receiver
+	case !info.Enqueued.IsZero():
+		event.Time = info.Enqueued
+	}
+	return event
+}
+
+func (t *eventTracker) modelEvents(info params.ModelUpdate, removed bool, now time.Time) []Event {
+	if removed {
+		delete(t.models, info.ModelUUID)
+		return nil
+	}
+	old, ok := t.models[info.ModelUUID]
+	t.models[info.ModelUUID] = info
+	if !ok {
+		return nil
+	}
+	event := func(eventType, message string) Event {
+		return Event{Time: now, Kind: "model", Entity: info.Name, Type: eventType, Message: message}
+	}
+	var events []Event
+	if changed := changedKeys(old.Config, info.Config); len(changed) > 0 {
+		events = append(events, event("config-changed", strings.Join(changed, ", ")))
+	}
+	if statusChanged(old.Status, info.Status) {
+		e := event("status", statusMessage(info.Status))
+		e.Time = statusTime(info.Status, now)
+		events = append(events, e)
+	}
+	return events
+}
+
+// changedKeys returns the sorted keys whose values differ between the
+// two maps.
+func changedKeys(old, new map[string]interface{}) []string {
+	var changed []string
+	for key, value := range new {
+		if oldValue, ok := old[key]; !ok || !reflect.DeepEqual(oldValue, value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range old {
+		if _, ok := new[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func statusChanged(old, new params.StatusInfo) bool {
+	return old.Current != new.Current || old.Message != new.Message
+}
+
+func statusMessage(info params.StatusInfo) string {
+	if info.Message == "" {
+		return string(info.Current)
+	}
+	return fmt.Sprintf("%s: %s", info.Current, info.Message)
+}
+
+func statusTime(info params.StatusInfo, now time.Time) time.Time {
+	if info.Since != nil {
+		return *info.Since
+	}
+	return now
+}
+
+// actionReceiver returns the name of the unit or machine from the tag
+// of an action receiver.
+func actionReceiver(receiver string) string {
+	tag, err := names.ParseTag(receiver)
+	if err != nil {
+		return receiver
+	}
+	return tag.Id()
+}
@@ -4,6 +4,7 @@
 package status
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 
 	"github.com/juju/juju/cmd/juju/storage"
@@ -18,3 +19,7 @@ func NewTestStatusCommand(statusapi statusAPI, storageapi storage.StorageListAPI
 	return modelcmd.Wrap(
 		&statusCommand{statusAPI: statusapi, storageAPI: storageapi, clock: clock})
 }
+
+func NewTestEventsCommand(api EventsAPI, clock clock.Clock) cmd.Command {
+	return &eventsCommand{api: api, clock: clock}
+}
@@ -0,0 +1,637 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/core/status"
+)
+
+// The types in this file define the document written by the json-v2
+// format. They are deliberately separate from the types used by the
+// other formats, so that the versioned schema does not change when the
+// regular status output does. Any change to these types must follow
+// the rules described by StatusSchemaVersion.
+
+// formattedStatusV2 is the document written by the json-v2 format.
+type formattedStatusV2 struct {
+	SchemaVersion      int                                  `json:"schema-version"`
+	Errors             []string                             `json:"errors,omitempty"`
+	Model              modelStatusV2                        `json:"model"`
+	Machines           map[string]machineStatusV2           `json:"machines"`
+	Applications       map[string]applicationStatusV2       `json:"applications"`
+	RemoteApplications map[string]remoteApplicationStatusV2 `json:"application-endpoints,omitempty"`
+	Offers             map[string]offerStatusV2             `json:"offers,omitempty"`
+	Storage            *storageStatusV2                     `json:"storage,omitempty"`
+	Controller         *controllerStatusV2                  `json:"controller,omitempty"`
+	Branches           map[string]branchStatusV2            `json:"branches,omitempty"`
+}
+
+type errorStatusV2 struct {
+	StatusError string `json:"status-error"`
+}
+
+type modelStatusV2 struct {
+	Name             string         `json:"name"`
+	Type             string         `json:"type"`
+	Controller       string         `json:"controller"`
+	Cloud            string         `json:"cloud"`
+	CloudRegion      string         `json:"region,omitempty"`
+	Version          string         `json:"version"`
+	AvailableVersion string         `json:"upgrade-available,omitempty"`
+	Status           statusInfoV2   `json:"model-status,omitempty"`
+	MeterStatus      *meterStatusV2 `json:"meter-status,omitempty"`
+	SLA              string         `json:"sla,omitempty"`
+}
+
+type controllerStatusV2 struct {
+	Timestamp string `json:"timestamp,omitempty" jsonschema:"date-time"`
+}
+
+type meterStatusV2 struct {
+	Color   string `json:"color,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type statusInfoV2 struct {
+	Err     error         `json:"-"`
+	Current status.Status `json:"current,omitempty"`
+	Message string        `json:"message,omitempty"`
+	Since   string        `json:"since,omitempty" jsonschema:"date-time"`
+	Version string        `json:"version,omitempty"`
+	Life    string        `json:"life,omitempty"`
+}
+
+type statusInfoV2NoMarshal statusInfoV2
+
+func (s statusInfoV2) MarshalJSON() ([]byte, error) {
+	if s.Err != nil {
+		return json.Marshal(errorStatusV2{s.Err.Error()})
+	}
+	return json.Marshal(statusInfoV2NoMarshal(s))
+}
+
+type networkInterfaceV2 struct {
+	IPAddresses    []string `json:"ip-addresses"`
+	MACAddress     string   `json:"mac-address"`
+	Gateway        string   `json:"gateway,omitempty"`
+	DNSNameservers []string `json:"dns-nameservers,omitempty"`
+	Space          string   `json:"space,omitempty"`
+	IsUp           bool     `json:"is-up"`
+}
+
+type lxdProfileV2 struct {
+	Config      map[string]string            `json:"config"`
+	Description string                       `json:"description"`
+	Devices     map[string]map[string]string `json:"devices"`
+}
+
+type machineStatusV2 struct {
+	Err                error                         `json:"-"`
+	JujuStatus         statusInfoV2                  `json:"juju-status,omitempty"`
+	DNSName            string                        `json:"dns-name,omitempty"`
+	IPAddresses        []string                      `json:"ip-addresses,omitempty"`
+	InstanceId         string                        `json:"instance-id,omitempty"`
+	DisplayName        string                        `json:"display-name,omitempty"`
+	MachineStatus      statusInfoV2                  `json:"machine-status,omitempty"`
+	ModificationStatus statusInfoV2                  `json:"modification-status,omitempty"`
+	Series             string                        `json:"series,omitempty"`
+	NetworkInterfaces  map[string]networkInterfaceV2 `json:"network-interfaces,omitempty"`
+	Containers         map[string]machineStatusV2    `json:"containers,omitempty"`
+	Constraints        string                        `json:"constraints,omitempty"`
+	Hardware           string                        `json:"hardware,omitempty"`
+	HAStatus           string                        `json:"controller-member-status,omitempty"`
+	HAPrimary          bool                          `json:"ha-primary,omitempty"`
+	LXDProfiles        map[string]lxdProfileV2       `json:"lxd-profiles,omitempty"`
+}
+
+type machineStatusV2NoMarshal machineStatusV2
+
+func (s machineStatusV2) MarshalJSON() ([]byte, error) {
+	if s.Err != nil {
+		return json.Marshal(errorStatusV2{s.Err.Error()})
+	}
+	return json.Marshal(machineStatusV2NoMarshal(s))
+}
+
+type applicationStatusV2 struct {
+	Err              error                   `json:"-"`
+	Charm            string                  `json:"charm"`
+	Series           string                  `json:"series"`
+	OS               string                  `json:"os"`
+	CharmOrigin      string                  `json:"charm-origin"`
+	CharmName        string                  `json:"charm-name"`
+	CharmRev         int                     `json:"charm-rev"`
+	CharmVersion     string                  `json:"charm-version,omitempty"`
+	CharmProfile     string                  `json:"charm-profile,omitempty"`
+	CanUpgradeTo     string                  `json:"can-upgrade-to,omitempty"`
+	Scale            int                     `json:"scale,omitempty"`
+	ProviderId       string                  `json:"provider-id,omitempty"`
+	Address          string                  `json:"address,omitempty"`
+	Exposed          bool                    `json:"exposed"`
+	Life             string                  `json:"life,omitempty"`
+	StatusInfo       statusInfoV2            `json:"application-status,omitempty"`
+	Relations        map[string][]string     `json:"relations,omitempty"`
+	SubordinateTo    []string                `json:"subordinate-to,omitempty"`
+	Units            map[string]unitStatusV2 `json:"units,omitempty"`
+	Version          string                  `json:"version,omitempty"`
+	EndpointBindings map[string]string       `json:"endpoint-bindings,omitempty"`
+}
+
+type applicationStatusV2NoMarshal applicationStatusV2
+
+func (s applicationStatusV2) MarshalJSON() ([]byte, error) {
+	if s.Err != nil {
+		return json.Marshal(errorStatusV2{s.Err.Error()})
+	}
+	return json.Marshal(applicationStatusV2NoMarshal(s))
+}
+
+type unitStatusV2 struct {
+	Err                error                   `json:"-"`
+	WorkloadStatusInfo statusInfoV2            `json:"workload-status,omitempty"`
+	JujuStatusInfo     statusInfoV2            `json:"juju-status,omitempty"`
+	MeterStatus        *meterStatusV2          `json:"meter-status,omitempty"`
+	Leader             bool                    `json:"leader,omitempty"`
+	Charm              string                  `json:"upgrading-from,omitempty"`
+	Machine            string                  `json:"machine,omitempty"`
+	OpenedPorts        []string                `json:"open-ports,omitempty"`
+	PublicAddress      string                  `json:"public-address,omitempty"`
+	Address            string                  `json:"address,omitempty"`
+	ProviderId         string                  `json:"provider-id,omitempty"`
+	Subordinates       map[string]unitStatusV2 `json:"subordinates,omitempty"`
+	Branch             string                  `json:"branch,omitempty"`
+}
+
+type unitStatusV2NoMarshal unitStatusV2
+
+func (s unitStatusV2) MarshalJSON() ([]byte, error) {
+	if s.Err != nil {
+		return json.Marshal(errorStatusV2{s.Err.Error()})
+	}
+	return json.Marshal(unitStatusV2NoMarshal(s))
+}
+
+type remoteEndpointV2 struct {
+	Interface string `json:"interface"`
+	Role      string `json:"role"`
+}
+
+type remoteApplicationStatusV2 struct {
+	Err        error                       `json:"-"`
+	OfferURL   string                      `json:"url"`
+	Endpoints  map[string]remoteEndpointV2 `json:"endpoints,omitempty"`
+	Life       string                      `json:"life,omitempty"`
+	StatusInfo statusInfoV2                `json:"application-status,omitempty"`
+	Relations  map[string][]string         `json:"relations,omitempty"`
+}
+
+type remoteApplicationStatusV2NoMarshal remoteApplicationStatusV2
+
+func (s remoteApplicationStatusV2) MarshalJSON() ([]byte, error) {
+	if s.Err != nil {
+		return json.Marshal(errorStatusV2{s.Err.Error()})
+	}
+	return json.Marshal(remoteApplicationStatusV2NoMarshal(s))
+}
+
+type offerStatusV2 struct {
+	Err                  error                       `json:"-"`
+	ApplicationName      string                      `json:"application"`
+	CharmURL             string                      `json:"charm,omitempty"`
+	TotalConnectedCount  int                         `json:"total-connected-count,omitempty"`
+	ActiveConnectedCount int                         `json:"active-connected-count,omitempty"`
+	Endpoints            map[string]remoteEndpointV2 `json:"endpoints"`
+}
+
+type offerStatusV2NoMarshal offerStatusV2
+
+func (s offerStatusV2) MarshalJSON() ([]byte, error) {
+	if s.Err != nil {
+		return json.Marshal(errorStatusV2{s.Err.Error()})
+	}
+	return json.Marshal(offerStatusV2NoMarshal(s))
+}
+
+type branchStatusV2 struct {
+	Ref       string `json:"ref,omitempty"`
+	Created   string `json:"created,omitempty" jsonschema:"date-time"`
+	CreatedBy string `json:"created-by,omitempty"`
+	Active    bool   `json:"active,omitempty"`
+}
+
+type storageStatusV2 struct {
+	StorageInstances map[string]storageInstanceV2 `json:"storage,omitempty"`
+	Filesystems      map[string]filesystemV2      `json:"filesystems,omitempty"`
+	Volumes          map[string]volumeV2          `json:"volumes,omitempty"`
+}
+
+type storageEntityStatusV2 struct {
+	Current status.Status `json:"current,omitempty"`
+	Message string        `json:"message,omitempty"`
+	Since   string        `json:"since,omitempty" jsonschema:"date-time"`
+}
+
+type unitStorageAttachmentV2 struct {
+	MachineId string `json:"machine,omitempty"`
+	Location  string `json:"location,omitempty"`
+	Life      string `json:"life,omitempty"`
+}
+
+type storageInstanceV2 struct {
+	Kind        string                             `json:"kind"`
+	Life        string                             `json:"life,omitempty"`
+	Status      storageEntityStatusV2              `json:"status"`
+	Persistent  bool                               `json:"persistent"`
+	Attachments map[string]unitStorageAttachmentV2 `json:"attachments,omitempty"`
+}
+
+type filesystemAttachmentV2 struct {
+	MountPoint string `json:"mount-point"`
+	ReadOnly   bool   `json:"read-only"`
+	Life       string `json:"life,omitempty"`
+}
+
+type filesystemAttachmentsV2 struct {
+	Machines   map[string]filesystemAttachmentV2  `json:"machines,omitempty"`
+	Containers map[string]filesystemAttachmentV2  `json:"containers,omitempty"`
+	Units      map[string]unitStorageAttachmentV2 `json:"units,omitempty"`
+}
+
+type filesystemV2 struct {
+	ProviderFilesystemId string                   `json:"provider-id,omitempty"`
+	Volume               string                   `json:"volume,omitempty"`
+	Storage              string                   `json:"storage,omitempty"`
+	Attachments          *filesystemAttachmentsV2 `json:"attachments,omitempty"`
+	Pool                 string                   `json:"pool,omitempty"`
+	Size                 uint64                   `json:"size"`
+	Life                 string                   `json:"life,omitempty"`
+	Status               storageEntityStatusV2    `json:"status,omitempty"`
+}
+
+type volumeAttachmentV2 struct {
+	DeviceName string `json:"device,omitempty"`
+	DeviceLink string `json:"device-link,omitempty"`
+	BusAddress string `json:"bus-address,omitempty"`
+	ReadOnly   bool   `json:"read-only"`
+	Life       string `json:"life,omitempty"`
+}
+
+type volumeAttachmentsV2 struct {
+	Machines   map[string]volumeAttachmentV2      `json:"machines,omitempty"`
+	Containers map[string]volumeAttachmentV2      `json:"containers,omitempty"`
+	Units      map[string]unitStorageAttachmentV2 `json:"units,omitempty"`
+}
+
+type volumeV2 struct {
+	ProviderVolumeId string                `json:"provider-id,omitempty"`
+	Storage          string                `json:"storage,omitempty"`
+	Attachments      *volumeAttachmentsV2  `json:"attachments,omitempty"`
+	Pool             string                `json:"pool,omitempty"`
+	HardwareId       string                `json:"hardware-id,omitempty"`
+	WWN              string                `json:"wwn,omitempty"`
+	Size             uint64                `json:"size"`
+	Persistent       bool                  `json:"persistent"`
+	Life             string                `json:"life,omitempty"`
+	Status           storageEntityStatusV2 `json:"status,omitempty"`
+}
+
+// newFormattedStatusV2 converts the formatted status into the versioned
+// document. The formatter already writes the times of a json-v2 status
+// in RFC3339, so only the storage times, which are formatted by the
+// storage package, need converting here.
+func newFormattedStatusV2(fs formattedStatus, partialErr error) formattedStatusV2 {
+	out := formattedStatusV2{
+		SchemaVersion: StatusSchemaVersion,
+		Model: modelStatusV2{
+			Name:             fs.Model.Name,
+			Type:             fs.Model.Type,
+			Controller:       fs.Model.Controller,
+			Cloud:            fs.Model.Cloud,
+			CloudRegion:      fs.Model.CloudRegion,
+			Version:          fs.Model.Version,
+			AvailableVersion: fs.Model.AvailableVersion,
+			Status:           statusInfoToV2(fs.Model.Status),
+			MeterStatus:      meterStatusToV2(fs.Model.MeterStatus),
+			SLA:              fs.Model.SLA,
+		},
+		Machines:     make(map[string]machineStatusV2),
+		Applications: make(map[string]applicationStatusV2),
+	}
+	if partialErr != nil {
+		out.Errors = []string{partialErr.Error()}
+	}
+	for id, m := range fs.Machines {
+		out.Machines[id] = machineStatusToV2(m)
+	}
+	for name, app := range fs.Applications {
+		out.Applications[name] = applicationStatusToV2(app)
+	}
+	if len(fs.RemoteApplications) > 0 {
+		out.RemoteApplications = make(map[string]remoteApplicationStatusV2)
+		for name, app := range fs.RemoteApplications {
+			out.RemoteApplications[name] = remoteApplicationStatusV2{
+				Err:        app.Err,
+				OfferURL:   app.OfferURL,
+				Endpoints:  remoteEndpointsToV2(app.Endpoints),
+				Life:       app.Life,
+				StatusInfo: statusInfoToV2(app.StatusInfo),
+				Relations:  app.Relations,
+			}
+		}
+	}
+	if len(fs.Offers) > 0 {
+		out.Offers = make(map[string]offerStatusV2)
+		for name, offer := range fs.Offers {
+			out.Offers[name] = offerStatusV2{
+				Err:                  offer.Err,
+				ApplicationName:      offer.ApplicationName,
+				CharmURL:             offer.CharmURL,
+				TotalConnectedCount:  offer.TotalConnectedCount,
+				ActiveConnectedCount: offer.ActiveConnectedCount,
+				Endpoints:            remoteEndpointsToV2(offer.Endpoints),
+			}
+		}
+	}
+	if fs.Storage != nil {
+		out.Storage = storageToV2(fs.Storage)
+	}
+	if fs.Controller != nil {
+		out.Controller = &controllerStatusV2{Timestamp: fs.Controller.Timestamp}
+	}
+	if len(fs.Branches) > 0 {
+		out.Branches = make(map[string]branchStatusV2)
+		for name, b := range fs.Branches {
+			out.Branches[name] = branchStatusV2{
+				Ref:       b.Ref,
+				Created:   b.Created,
+				CreatedBy: b.CreatedBy,
+				Active:    b.Active,
+			}
+		}
+	}
+	return out
+}
+
+func statusInfoToV2(s statusInfoContents) statusInfoV2 {
+	return statusInfoV2{
+		Err:     s.Err,
+		Current: s.Current,
+		Message: s.Message,
+		Since:   s.Since,
+		Version: s.Version,
+		Life:    s.Life,
+	}
+}
+
+func meterStatusToV2(m *meterStatus) *meterStatusV2 {
+	if m == nil {
+		return nil
+	}
+	return &meterStatusV2{Color: m.Color, Message: m.Message}
+}
+
+func machineStatusToV2(m machineStatus) machineStatusV2 {
+	out := machineStatusV2{
+		Err:                m.Err,
+		JujuStatus:         statusInfoToV2(m.JujuStatus),
+		DNSName:            m.DNSName,
+		IPAddresses:        m.IPAddresses,
+		InstanceId:         string(m.InstanceId),
+		DisplayName:        m.DisplayName,
+		MachineStatus:      statusInfoToV2(m.MachineStatus),
+		ModificationStatus: statusInfoToV2(m.ModificationStatus),
+		Series:             m.Series,
+		Constraints:        m.Constraints,
+		Hardware:           m.Hardware,
+		HAStatus:           m.HAStatus,
+		HAPrimary:          m.HAPrimary,
+	}
+	if len(m.NetworkInterfaces) > 0 {
+		out.NetworkInterfaces = make(map[string]networkInterfaceV2)
+		for name, nic := range m.NetworkInterfaces {
+			out.NetworkInterfaces[name] = networkInterfaceV2{
+				IPAddresses:    nic.IPAddresses,
+				MACAddress:     nic.MACAddress,
+				Gateway:        nic.Gateway,
+				DNSNameservers: nic.DNSNameservers,
+				Space:          nic.Space,
+				IsUp:           nic.IsUp,
+			}
+		}
+	}
+	if len(m.Containers) > 0 {
+		out.Containers = make(map[string]machineStatusV2)
+		for id, c := range m.Containers {
+			out.Containers[id] = machineStatusToV2(c)
+		}
+	}
+	if len(m.LXDProfiles) > 0 {
+		out.LXDProfiles = make(map[string]lxdProfileV2)
+		for name, p := range m.LXDProfiles {
+			out.LXDProfiles[name] = lxdProfileV2{
+				Config:      p.Config,
+				Description: p.Description,
+				Devices:     p.Devices,
+			}
+		}
+	}
+	return out
+}
+
+func applicationStatusToV2(app applicationStatus) applicationStatusV2 {
+	out := applicationStatusV2{
+		Err:              app.Err,
+		Charm:            app.Charm,
+		Series:           app.Series,
+		OS:               app.OS,
+		CharmOrigin:      app.CharmOrigin,
+		CharmName:        app.CharmName,
+		CharmRev:         app.CharmRev,
+		CharmVersion:     app.CharmVersion,
+		CharmProfile:     app.CharmProfile,
+		CanUpgradeTo:     app.CanUpgradeTo,
+		Scale:            app.Scale,
+		ProviderId:       app.ProviderId,
+		Address:          app.Address,
+		Exposed:          app.Exposed,
+		Life:             app.Life,
+		StatusInfo:       statusInfoToV2(app.StatusInfo),
+		Relations:        app.Relations,
+		SubordinateTo:    app.SubordinateTo,
+		Units:            unitsToV2(app.Units),
+		Version:          app.Version,
+		EndpointBindings: app.EndpointBindings,
+	}
+	return out
+}
+
+func unitsToV2(units map[string]unitStatus) map[string]unitStatusV2 {
+	if len(units) == 0 {
+		return nil
+	}
+	out := make(map[string]unitStatusV2)
+	for name, u := range units {
+		out[name] = unitStatusV2{
+			Err:                u.WorkloadStatusInfo.Err,
+			WorkloadStatusInfo: statusInfoToV2(u.WorkloadStatusInfo),
+			JujuStatusInfo:     statusInfoToV2(u.JujuStatusInfo),
+			MeterStatus:        meterStatusToV2(u.MeterStatus),
+			Leader:             u.Leader,
+			Charm:              u.Charm,
+			Machine:            u.Machine,
+			OpenedPorts:        u.OpenedPorts,
+			PublicAddress:      u.PublicAddress,
+			Address:            u.Address,
+			ProviderId:         u.ProviderId,
+			Subordinates:       unitsToV2(u.Subordinates),
+			Branch:             u.Branch,
+		}
+	}
+	return out
+}
+
+func remoteEndpointsToV2(endpoints map[string]remoteEndpoint) map[string]remoteEndpointV2 {
+	if endpoints == nil {
+		return nil
+	}
+	out := make(map[string]remoteEndpointV2)
+	for name, ep := range endpoints {
+		out[name] = remoteEndpointV2{Interface: ep.Interface, Role: ep.Role}
+	}
+	return out
+}
+
+// storageTimeLayout is the layout used by the storage package to
+// format status times.
+const storageTimeLayout = "02 Jan 2006 15:04:05Z07:00"
+
+func storageStatusToV2(s storage.EntityStatus) storageEntityStatusV2 {
+	out := storageEntityStatusV2{
+		Current: s.Current,
+		Message: s.Message,
+	}
+	if s.Since != "" {
+		if t, err := time.Parse(storageTimeLayout, s.Since); err == nil {
+			out.Since = t.UTC().Format(time.RFC3339)
+		}
+	}
+	return out
+}
+
+func unitStorageAttachmentsToV2(units map[string]storage.UnitStorageAttachment) map[string]unitStorageAttachmentV2 {
+	if len(units) == 0 {
+		return nil
+	}
+	out := make(map[string]unitStorageAttachmentV2)
+	for name, a := range units {
+		out[name] = unitStorageAttachmentV2{
+			MachineId: a.MachineId,
+			Location:  a.Location,
+			Life:      a.Life,
+		}
+	}
+	return out
+}
+
+func storageToV2(in *storage.CombinedStorage) *storageStatusV2 {
+	out := &storageStatusV2{}
+	if len(in.StorageInstances) > 0 {
+		out.StorageInstances = make(map[string]storageInstanceV2)
+		for id, s := range in.StorageInstances {
+			info := storageInstanceV2{
+				Kind:       s.Kind,
+				Life:       s.Life,
+				Status:     storageStatusToV2(s.Status),
+				Persistent: s.Persistent,
+			}
+			if s.Attachments != nil {
+				info.Attachments = unitStorageAttachmentsToV2(s.Attachments.Units)
+			}
+			out.StorageInstances[id] = info
+		}
+	}
+	if len(in.Filesystems) > 0 {
+		out.Filesystems = make(map[string]filesystemV2)
+		for id, f := range in.Filesystems {
+			info := filesystemV2{
+				ProviderFilesystemId: f.ProviderFilesystemId,
+				Volume:               f.Volume,
+				Storage:              f.Storage,
+				Pool:                 f.Pool,
+				Size:                 f.Size,
+				Life:                 f.Life,
+				Status:               storageStatusToV2(f.Status),
+			}
+			if a := f.Attachments; a != nil {
+				info.Attachments = &filesystemAttachmentsV2{
+					Machines:   filesystemAttachmentsToV2(a.Machines),
+					Containers: filesystemAttachmentsToV2(a.Containers),
+					Units:      unitStorageAttachmentsToV2(a.Units),
+				}
+			}
+			out.Filesystems[id] = info
+		}
+	}
+	if len(in.Volumes) > 0 {
+		out.Volumes = make(map[string]volumeV2)
+		for id, v := range in.Volumes {
+			info := volumeV2{
+				ProviderVolumeId: v.ProviderVolumeId,
+				Storage:          v.Storage,
+				Pool:             v.Pool,
+				HardwareId:       v.HardwareId,
+				WWN:              v.WWN,
+				Size:             v.Size,
+				Persistent:       v.Persistent,
+				Life:             v.Life,
+				Status:           storageStatusToV2(v.Status),
+			}
+			if a := v.Attachments; a != nil {
+				info.Attachments = &volumeAttachmentsV2{
+					Machines:   volumeAttachmentsToV2(a.Machines),
+					Containers: volumeAttachmentsToV2(a.Containers),
+					Units:      unitStorageAttachmentsToV2(a.Units),
+				}
+			}
+			out.Volumes[id] = info
+		}
+	}
+	return out
+}
+
+func filesystemAttachmentsToV2(in map[string]storage.FilesystemAttachment) map[string]filesystemAttachmentV2 {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]filesystemAttachmentV2)
+	for id, a := range in {
+		out[id] = filesystemAttachmentV2{
+			MountPoint: a.MountPoint,
+			ReadOnly:   a.ReadOnly,
+			Life:       a.Life,
+		}
+	}
+	return out
+}
+
+func volumeAttachmentsToV2(in map[string]storage.VolumeAttachment) map[string]volumeAttachmentV2 {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]volumeAttachmentV2)
+	for id, a := range in {
+		out[id] = volumeAttachmentV2{
+			DeviceName: a.DeviceName,
+			DeviceLink: a.DeviceLink,
+			BusAddress: a.BusAddress,
+			ReadOnly:   a.ReadOnly,
+			Life:       a.Life,
+		}
+	}
+	return out
+}
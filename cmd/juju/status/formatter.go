@@ -111,7 +111,7 @@ func (sf *statusFormatter) format() (formattedStatus, error) {
 	}
 	if sf.status.ControllerTimestamp != nil {
 		out.Controller = &controllerStatus{
-			Timestamp: common.FormatTimeAsTimestamp(sf.status.ControllerTimestamp, sf.isoTime),
+			Timestamp: sf.formatTimestamp(sf.status.ControllerTimestamp),
 		}
 	}
 	for k, m := range sf.status.Machines {
@@ -366,7 +366,7 @@ func (sf *statusFormatter) getApplicationStatusInfo(application params.Applicati
 		Version: application.Status.Version,
 	}
 	if application.Status.Since != nil {
-		info.Since = common.FormatTime(application.Status.Since, sf.isoTime)
+		info.Since = sf.formatTime(application.Status.Since)
 	}
 	return info
 }
@@ -380,7 +380,7 @@ func (sf *statusFormatter) getRemoteApplicationStatusInfo(application params.Rem
 		Version: application.Status.Version,
 	}
 	if application.Status.Since != nil {
-		info.Since = common.FormatTime(application.Status.Since, sf.isoTime)
+		info.Since = sf.formatTime(application.Status.Since)
 	}
 	return info
 }
@@ -460,7 +460,7 @@ func (sf *statusFormatter) getStatusInfoContents(inst params.DetailedStatus) sta
 		Life:    string(inst.Life),
 	}
 	if inst.Since != nil {
-		info.Since = common.FormatTime(inst.Since, sf.isoTime)
+		info.Since = sf.formatTime(inst.Since)
 	}
 	return info
 }
@@ -477,7 +477,7 @@ func (sf *statusFormatter) getWorkloadStatusInfo(unit params.UnitStatus) statusI
 		Version: unit.WorkloadStatus.Version,
 	}
 	if unit.WorkloadStatus.Since != nil {
-		info.Since = common.FormatTime(unit.WorkloadStatus.Since, sf.isoTime)
+		info.Since = sf.formatTime(unit.WorkloadStatus.Since)
 	}
 	return info
 }
@@ -491,7 +491,7 @@ func (sf *statusFormatter) getAgentStatusInfo(unit params.UnitStatus) statusInfo
 		Version: unit.AgentStatus.Version,
 	}
 	if unit.AgentStatus.Since != nil {
-		info.Since = common.FormatTime(unit.AgentStatus.Since, sf.isoTime)
+		info.Since = sf.formatTime(unit.AgentStatus.Since)
 	}
 	return info
 }
@@ -518,7 +518,7 @@ func (sf *statusFormatter) formatBranch(ref int, branch params.BranchStatus, isA
 		}
 	}
 	return branchStatus{
-		Created:   common.FormatTimeAsTimestamp(&created, sf.isoTime),
+		Created:   sf.formatTimestamp(&created),
 		CreatedBy: branch.CreatedBy,
 		Active:    isActiveBranch,
 	}
@@ -577,3 +577,22 @@ func findOtherEndpoint(endpoints []params.EndpointStatus, applicationName string
 	}
 	return params.EndpointStatus{}, false
 }
+
+// formatTime formats a status "since" time. The versioned JSON output
+// always uses RFC3339 in UTC so that it can be parsed by machines,
+// regardless of the --utc flag.
+func (sf *statusFormatter) formatTime(t *time.Time) string {
+	if sf.outputName == jsonV2Format {
+		return t.UTC().Format(time.RFC3339)
+	}
+	return common.FormatTime(t, sf.isoTime)
+}
+
+// formatTimestamp formats a controller or branch timestamp. As with
+// formatTime, the versioned JSON output uses a full RFC3339 timestamp.
+func (sf *statusFormatter) formatTimestamp(t *time.Time) string {
+	if sf.outputName == jsonV2Format {
+		return t.UTC().Format(time.RFC3339)
+	}
+	return common.FormatTimeAsTimestamp(t, sf.isoTime)
+}
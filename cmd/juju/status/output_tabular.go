@@ -436,17 +436,41 @@ func getStatusAndMessageFromMachineStatus(m machineStatus) (status.Status, strin
 }
 
 // FormatMachineTabular writes a tabular summary of machine
-func FormatMachineTabular(writer io.Writer, forceColor bool, value interface{}) error {
+func FormatMachineTabular(writer io.Writer, opts output.TableOptions, value interface{}) error {
 	fs, valueConverted := value.(formattedMachineStatus)
 	if !valueConverted {
 		return errors.Errorf("expected value of type %T, got %T", fs, value)
 	}
-	tw := output.TabWriter(writer)
-	if forceColor {
-		tw.SetColorCapable(forceColor)
+	table := output.NewTable("Machine", "State", "DNS", "Inst id", "Series", "AZ", "Message")
+	for _, name := range naturalsort.Sort(stringKeysFromMap(fs.Machines)) {
+		addMachineRows(table, fs.Machines[name], false)
+	}
+	return table.Write(writer, opts)
+}
+
+// addMachineRows adds a row for the machine to the table, followed by
+// detail rows for its containers.
+func addMachineRows(table *output.Table, m machineStatus, container bool) {
+	// We want to display availability zone so extract from hardware info".
+	hw, err := instance.ParseHardware(m.Hardware)
+	if err != nil {
+		logger.Warningf("invalid hardware info %s for machine %v", m.Hardware, m)
+	}
+	az := ""
+	if hw.AvailabilityZone != nil {
+		az = *hw.AvailabilityZone
+	}
+
+	status, message := getStatusAndMessageFromMachineStatus(m)
+	addRow := table.AddRow
+	if container {
+		addRow = table.AddDetailRow
+	}
+	addRow(m.Id, status, m.DNSName, m.machineName(), m.Series, az, message)
+
+	for _, name := range naturalsort.Sort(stringKeysFromMap(m.Containers)) {
+		addMachineRows(table, m.Containers[name], true)
 	}
-	printMachines(tw, true, fs.Machines)
-	return nil
 }
 
 // agentDoing returns what hook or action, if any,
@@ -0,0 +1,168 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+//go:generate go run github.com/juju/juju/generate/statusschema schema/status-v2.json
+
+const (
+	// StatusSchemaVersion is the version of the status output produced
+	// by the json-v2 format. Fields may be added to a schema version, but
+	// existing fields are never removed, renamed or changed in type
+	// without bumping the version.
+	StatusSchemaVersion = 2
+
+	// jsonV2Format is the name of the versioned JSON output format.
+	jsonV2Format = "json-v2"
+
+	// exitCodePartialStatus is returned by the json-v2 format when only
+	// part of the status could be retrieved.
+	exitCodePartialStatus = 2
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	errorType         = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// JSONSchema returns the JSON Schema document describing the output of
+// "juju status --format json-v2". The schema is generated from the
+// formatted status types so it always matches what is written.
+func JSONSchema() map[string]interface{} {
+	gen := &schemaGenerator{
+		definitions: make(map[string]interface{}),
+	}
+	schema := gen.structSchema(reflect.TypeOf(formattedStatusV2{}))
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["title"] = "juju status"
+	schema["description"] = "Output of 'juju status --format json-v2'."
+	schema["definitions"] = gen.definitions
+	props := schema["properties"].(map[string]interface{})
+	props["schema-version"] = map[string]interface{}{
+		"type":  "integer",
+		"const": StatusSchemaVersion,
+	}
+	return schema
+}
+
+// schemaGenerator builds JSON Schema definitions by reflecting over the
+// json struct tags of the formatted status types. Named struct types are
+// recorded as definitions, which allows for recursive types such as
+// machine containers.
+type schemaGenerator struct {
+	definitions map[string]interface{}
+}
+
+func (g *schemaGenerator) typeSchema(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.typeSchema(t.Elem())
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": g.typeSchema(t.Elem()),
+		}
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": g.typeSchema(t.Elem()),
+		}
+	case reflect.Struct:
+		return g.namedStructSchema(t)
+	}
+	// Interfaces and anything else may hold any value.
+	return map[string]interface{}{}
+}
+
+func (g *schemaGenerator) namedStructSchema(t reflect.Type) map[string]interface{} {
+	name := t.Name()
+	ref := map[string]interface{}{"$ref": "#/definitions/" + name}
+	if _, ok := g.definitions[name]; ok {
+		return ref
+	}
+	// Reserve the name before recursing so that self-referencing
+	// types terminate.
+	g.definitions[name] = nil
+	schema := g.structSchema(t)
+	if t.Implements(jsonMarshalerType) {
+		// Types with a custom marshaler report failures to retrieve
+		// their status as an error document instead.
+		schema = map[string]interface{}{
+			"anyOf": []interface{}{schema, errorStatusSchema()},
+		}
+	}
+	g.definitions[name] = schema
+	return ref
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	var required []string
+	g.addFields(t, properties, &required)
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (g *schemaGenerator) addFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || field.Type == errorType {
+			continue
+		}
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			g.addFields(field.Type, properties, required)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		parts := strings.SplitN(tag, ",", 2)
+		name := field.Name
+		if parts[0] != "" {
+			name = parts[0]
+		}
+		omitEmpty := len(parts) == 2 && strings.Contains(parts[1], "omitempty")
+		schema := g.typeSchema(field.Type)
+		if format := field.Tag.Get("jsonschema"); format != "" {
+			schema["format"] = format
+		}
+		properties[name] = schema
+		if !omitEmpty {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func errorStatusSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"status-error": map[string]interface{}{"type": "string"},
+		},
+		"required": []string{"status-error"},
+	}
+}
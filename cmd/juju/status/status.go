@@ -23,6 +23,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/storage"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/juju/osenv"
 )
 
@@ -30,6 +31,7 @@ var logger = loggo.GetLogger("juju.cmd.juju.status")
 
 type statusAPI interface {
 	Status(patterns []string) (*params.FullStatus, error)
+	StatusWithSelector(patterns []string, selector string) (*params.FullStatus, error)
 	Close() error
 }
 
@@ -50,6 +52,7 @@ type statusCommand struct {
 	modelcmd.ModelCommandBase
 	out        cmd.Output
 	patterns   []string
+	selector   string
 	isoTime    bool
 	statusAPI  statusAPI
 	storageAPI storage.StorageListAPI
@@ -90,6 +93,12 @@ When an entity that matches <selector> is related to other applications, the
 status of those applications will also be presented. By default (without a 
 <selector>) the status of all applications and their units will be displayed.
 
+The '--selector' option filters the report by annotations, which are treated
+as labels. Only machines, applications and units annotated with all of the
+given key=value pairs are displayed; units also match when their application
+or machine does. Annotations may be set on applications and machines in a
+bundle.
+
 
 Altering the output format
 
@@ -117,6 +126,16 @@ The '--format' option allows you to specify how the status report is formatted.
                     Provide information in a JSON or YAML formats for 
                     programmatic use.
 
+  --format=json-v2
+                    Provide information in a versioned JSON format with
+                    stable field names, described by the JSON Schema in
+                    cmd/juju/status/schema/status-v2.json. The output
+                    includes a "schema-version" field and all timestamps
+                    are in RFC3339 format. The command exits with code 0
+                    when the full status was retrieved, 1 when it failed,
+                    and 2 when only a partial status could be retrieved;
+                    the errors are reported in the "errors" field.
+
 Examples:
 
     # Report the status of units hosted on machine 0
@@ -128,6 +147,9 @@ Examples:
     # Report the status for applications that start with nova-
     juju status nova-*
 
+    # Report the status of entities annotated with team=payments
+    juju status --selector team=payments
+
     # Include information about storage and relations in output
     juju status --storage --relations
 
@@ -164,6 +186,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.color, "color", false, "Use ANSI color codes in tabular output")
 	f.BoolVar(&c.relations, "relations", false, "Show 'relations' section in tabular output")
 	f.BoolVar(&c.storage, "storage", false, "Show 'storage' section in tabular output")
+	f.StringVar(&c.selector, "selector", "", "Only show entities whose annotations match key=value[,key=value...]")
 
 	f.IntVar(&c.retryCount, "retry-count", 3, "Number of times to retry API failures")
 	f.DurationVar(&c.retryDelay, "retry-delay", 100*time.Millisecond, "Time to wait between retry attempts")
@@ -188,6 +211,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, defaultFormat, map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
+		"json-v2": cmd.FormatJson,
 		"short":   FormatOneline,
 		"oneline": FormatOneline,
 		"line":    FormatOneline,
@@ -198,6 +222,11 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.selector != "" {
+		if _, err := annotations.ParseSelector(c.selector); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -254,6 +283,9 @@ func (c *statusCommand) getStatus() (*params.FullStatus, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if c.selector != "" {
+		return apiclient.StatusWithSelector(c.patterns, c.selector)
+	}
 	return apiclient.Status(c.patterns)
 }
 
@@ -291,6 +323,7 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
+	var partialErr error
 	if err != nil {
 		if status == nil {
 			// Status call completely failed, there is nothing to report
@@ -298,6 +331,7 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		}
 		// Display any error, but continue to print status if some was returned
 		fmt.Fprintf(ctx.Stderr, "%v\n", err)
+		partialErr = err
 	} else if status == nil {
 		return errors.Errorf("unable to obtain the current status")
 	}
@@ -355,14 +389,21 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	if err = c.out.Write(ctx, formatted); err != nil {
+	var output interface{} = formatted
+	if c.out.Name() == jsonV2Format {
+		output = newFormattedStatusV2(formatted, partialErr)
+	}
+	if err = c.out.Write(ctx, output); err != nil {
 		return err
 	}
+	if partialErr != nil && c.out.Name() == jsonV2Format {
+		return cmd.NewRcPassthroughError(exitCodePartialStatus)
+	}
 
 	if !status.IsEmpty() {
 		return nil
 	}
-	if len(c.patterns) == 0 {
+	if len(c.patterns) == 0 && c.selector == "" {
 		modelName, err := c.ModelIdentifier()
 		if err != nil {
 			return err
@@ -4746,6 +4746,7 @@ func (s *StatusSuite) setupMigrationTest(c *gc.C) *state.State {
 type fakeAPIClient struct {
 	statusReturn *params.FullStatus
 	patternsUsed []string
+	selectorUsed string
 	closeCalled  bool
 }
 
@@ -4754,6 +4755,11 @@ func (a *fakeAPIClient) Status(patterns []string) (*params.FullStatus, error) {
 	return a.statusReturn, nil
 }
 
+func (a *fakeAPIClient) StatusWithSelector(patterns []string, selector string) (*params.FullStatus, error) {
+	a.selectorUsed = selector
+	return a.Status(patterns)
+}
+
 func (a *fakeAPIClient) Close() error {
 	a.closeCalled = true
 	return nil
@@ -4,7 +4,9 @@
 package status_test
 
 import (
+	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"time"
 
 	"github.com/juju/cmd"
@@ -74,6 +76,17 @@ transcode/1   shared-fs/0   filesystem  radiance              1.0GiB  attached
 `[1:])
 }
 
+func (s *MinimalStatusSuite) TestSelector(c *gc.C) {
+	_, err := s.runStatus(c, "--selector", "team=payments")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.statusapi.selector, gc.Equals, "team=payments")
+}
+
+func (s *MinimalStatusSuite) TestInvalidSelector(c *gc.C) {
+	_, err := s.runStatus(c, "--selector", "team")
+	c.Assert(err, gc.ErrorMatches, `selector term "team": expected key=value not valid`)
+}
+
 func (s *MinimalStatusSuite) TestRetryOnError(c *gc.C) {
 	s.statusapi.errors = []error{
 		errors.New("boom"),
@@ -130,9 +143,88 @@ func (s *MinimalStatusSuite) TestRetryCountOfZero(c *gc.C) {
 	c.Assert(s.clock.waits, gc.HasLen, 0)
 }
 
+func (s *MinimalStatusSuite) TestFormatJSONV2(c *gc.C) {
+	now := time.Date(2020, 8, 4, 10, 30, 0, 0, time.FixedZone("", 3600))
+	s.statusapi.result.ControllerTimestamp = &now
+
+	context, err := s.runStatus(c, "--format", "json-v2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var out map[string]interface{}
+	err = json.Unmarshal([]byte(cmdtesting.Stdout(context)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out["schema-version"], gc.Equals, float64(status.StatusSchemaVersion))
+	c.Assert(out["controller"], jc.DeepEquals, map[string]interface{}{
+		"timestamp": "2020-08-04T09:30:00Z",
+	})
+	c.Assert(out["errors"], gc.IsNil)
+}
+
+func (s *MinimalStatusSuite) TestFormatJSONV2PartialStatus(c *gc.C) {
+	s.statusapi.partialErr = errors.New("boom")
+
+	context, err := s.runStatus(c, "--format", "json-v2", "--retry-count", "0")
+	c.Assert(err, gc.FitsTypeOf, &cmd.RcPassthroughError{})
+	c.Assert(err.(*cmd.RcPassthroughError).Code, gc.Equals, 2)
+
+	var out map[string]interface{}
+	err = json.Unmarshal([]byte(cmdtesting.Stdout(context)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out["errors"], jc.DeepEquals, []interface{}{"boom"})
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, "boom\n")
+}
+
+func (s *MinimalStatusSuite) TestFormatJSONPartialStatus(c *gc.C) {
+	s.statusapi.partialErr = errors.New("boom")
+
+	// Only the versioned format reports a partial status in the exit code.
+	_, err := s.runStatus(c, "--format", "json", "--retry-count", "0")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MinimalStatusSuite) TestFormatJSONV2StorageTimes(c *gc.C) {
+	s.storageapi.time = time.Date(2020, 8, 4, 10, 30, 0, 0, time.UTC)
+
+	context, err := s.runStatus(c, "--format", "json-v2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var out struct {
+		Storage struct {
+			Storage map[string]struct {
+				Status struct {
+					Since string `json:"since"`
+				} `json:"status"`
+			} `json:"storage"`
+		} `json:"storage"`
+	}
+	err = json.Unmarshal([]byte(cmdtesting.Stdout(context)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Storage.Storage["db-dir/1000"].Status.Since, gc.Equals, "2020-08-04T10:30:00Z")
+}
+
+func (s *MinimalStatusSuite) TestJSONSchemaUpToDate(c *gc.C) {
+	data, err := ioutil.ReadFile("schema/status-v2.json")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var published map[string]interface{}
+	err = json.Unmarshal(data, &published)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Round trip the generated schema so the types match the file.
+	generated, err := json.Marshal(status.JSONSchema())
+	c.Assert(err, jc.ErrorIsNil)
+	var expected map[string]interface{}
+	err = json.Unmarshal(generated, &expected)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(published, jc.DeepEquals, expected, gc.Commentf("run 'go generate' in cmd/juju/status"))
+}
+
 type fakeStatusAPI struct {
-	result *params.FullStatus
-	errors []error
+	result     *params.FullStatus
+	errors     []error
+	partialErr error
+	selector   string
 }
 
 func (f *fakeStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
@@ -141,7 +233,12 @@ func (f *fakeStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
 		f.errors = rest
 		return nil, err
 	}
-	return f.result, nil
+	return f.result, f.partialErr
+}
+
+func (f *fakeStatusAPI) StatusWithSelector(patterns []string, selector string) (*params.FullStatus, error) {
+	f.selector = selector
+	return f.Status(patterns)
 }
 
 func (*fakeStatusAPI) Close() error {
@@ -4,24 +4,20 @@
 package storage
 
 import (
-	"fmt"
 	"io"
 	"sort"
-	"strings"
 
 	"github.com/dustin/go-humanize"
 
 	"github.com/juju/juju/cmd/output"
 )
 
-// formatFilesystemListTabular writes a tabular summary of filesystem instances.
-func formatFilesystemListTabular(writer io.Writer, infos map[string]FilesystemInfo) error {
-	tw := output.TabWriter(writer)
-
-	print := func(values ...string) {
-		fmt.Fprintln(tw, strings.Join(values, "\t"))
-	}
+// filesystemHeaders holds the headers of every column the filesystem
+// table may have.
+var filesystemHeaders = []string{"Machine", "Unit", "Storage id", "Id", "Volume", "Provider id", "Mountpoint", "Size", "State", "Message"}
 
+// formatFilesystemListTabular writes a tabular summary of filesystem instances.
+func formatFilesystemListTabular(writer io.Writer, opts output.TableOptions, infos map[string]FilesystemInfo) error {
 	haveMachines := false
 	filesystemAttachmentInfos := make(filesystemAttachmentInfos, 0, len(infos))
 	for filesystemId, info := range infos {
@@ -68,11 +64,13 @@ func formatFilesystemListTabular(writer io.Writer, infos map[string]FilesystemIn
 	}
 	sort.Sort(filesystemAttachmentInfos)
 
+	var table *output.Table
 	if haveMachines {
-		print("Machine", "Unit", "Storage id", "Id", "Volume", "Provider id", "Mountpoint", "Size", "State", "Message")
+		table = output.NewTable(filesystemHeaders...)
 	} else {
-		print("Unit", "Storage id", "Id", "Provider id", "Mountpoint", "Size", "State", "Message")
+		table = output.NewTable("Unit", "Storage id", "Id", "Provider id", "Mountpoint", "Size", "State", "Message")
 	}
+	table.SortAsSize("Size")
 
 	for _, info := range filesystemAttachmentInfos {
 		var size string
@@ -80,23 +78,23 @@ func formatFilesystemListTabular(writer io.Writer, infos map[string]FilesystemIn
 			size = humanize.IBytes(info.Size * humanize.MiByte)
 		}
 		if haveMachines {
-			print(
+			table.AddRow(
 				info.MachineId, info.UnitId, info.Storage,
 				info.FilesystemId, info.Volume, info.ProviderFilesystemId,
 				info.FilesystemAttachment.MountPoint, size,
-				string(info.Status.Current), info.Status.Message,
+				info.Status.Current, info.Status.Message,
 			)
 		} else {
-			print(
+			table.AddRow(
 				info.UnitId, info.Storage,
 				info.FilesystemId, info.ProviderFilesystemId,
 				info.FilesystemAttachment.MountPoint, size,
-				string(info.Status.Current), info.Status.Message,
+				info.Status.Current, info.Status.Message,
 			)
 		}
 	}
 
-	return tw.Flush()
+	return table.Write(writer, opts.ForTable(table))
 }
 
 type filesystemAttachmentInfo struct {
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 // NewListCommand returns a command for listing storage instances.
@@ -27,12 +28,25 @@ func NewListCommand() cmd.Command {
 
 const listCommandDoc = `
 List information about storage.
+
+The --columns and --sort options select and order the columns of the
+tabular output; the names of the columns are their headers in lower case
+with spaces replaced by hyphens. The columns are checked against the
+table being listed: storage instances by default, or filesystems or
+volumes with --filesystem or --volume. Columns that are only shown for
+some storage, such as machine, are left out when they are not shown.
+
+Examples:
+
+    juju storage --columns unit,storage-id,status
+    juju storage --volume --sort -size
 `
 
 // listCommand returns storage instances.
 type listCommand struct {
 	StorageCommandBase
 	out        cmd.Output
+	table      output.TableFlags
 	ids        []string
 	filesystem bool
 	volume     bool
@@ -54,10 +68,13 @@ func (c *listCommand) Info() *cmd.Info {
 func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.StorageCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
-		"yaml":    cmd.FormatYaml,
-		"json":    cmd.FormatJson,
-		"tabular": formatListTabularOne,
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+		"tabular": func(writer io.Writer, value interface{}) error {
+			return formatListTabular(writer, c.table.Options(), value, false)
+		},
 	})
+	c.table.AddFlags(f)
 	// TODO(axw) deprecate these flags, and introduce separate commands
 	// for listing just filesystems or volumes.
 	f.BoolVar(&c.filesystem, "filesystem", false, "List filesystem storage")
@@ -73,7 +90,14 @@ func (c *listCommand) Init(args []string) (err error) {
 		return errors.New("specifying IDs only supported with --filesystem and --volume options")
 	}
 	c.ids = args
-	return nil
+	headers := storageInstanceHeaders
+	switch {
+	case c.filesystem:
+		headers = filesystemHeaders
+	case c.volume:
+		headers = volumeHeaders
+	}
+	return errors.Trace(c.table.Validate(headers...))
 }
 
 // Run implements Command.Run.
@@ -178,18 +202,15 @@ func (c *CombinedStorage) Empty() bool {
 	return len(c.StorageInstances) == 0 && len(c.Filesystems) == 0 && len(c.Volumes) == 0
 }
 
-// formatListTabularOne writes a tabular summary of storage instances or filesystems or volumes.
-func formatListTabularOne(writer io.Writer, value interface{}) error {
-	return formatListTabular(writer, value, false)
-}
-
-func formatListTabular(writer io.Writer, value interface{}, all bool) error {
+// formatListTabular writes a tabular summary of storage instances, filesystems
+// and volumes. If all is false, only the first kind of storage found is written.
+func formatListTabular(writer io.Writer, opts output.TableOptions, value interface{}, all bool) error {
 	combined := value.(CombinedStorage)
 	var newline bool
 	if len(combined.StorageInstances) > 0 {
 		// If we're listing storage in tabular format, we combine all
 		// of the information into a list of "storage".
-		if err := formatStorageInstancesListTabular(writer, combined); err != nil {
+		if err := formatStorageInstancesListTabular(writer, opts, combined); err != nil {
 			return errors.Trace(err)
 		}
 		if !all {
@@ -201,7 +222,7 @@ func formatListTabular(writer io.Writer, value interface{}, all bool) error {
 		if newline {
 			fmt.Fprintln(writer)
 		}
-		if err := formatFilesystemListTabular(writer, combined.Filesystems); err != nil {
+		if err := formatFilesystemListTabular(writer, opts, combined.Filesystems); err != nil {
 			return err
 		}
 		if !all {
@@ -213,7 +234,7 @@ func formatListTabular(writer io.Writer, value interface{}, all bool) error {
 		if newline {
 			fmt.Fprintln(writer)
 		}
-		if err := formatVolumeListTabular(writer, combined.Volumes); err != nil {
+		if err := formatVolumeListTabular(writer, opts, combined.Volumes); err != nil {
 			return err
 		}
 	}
@@ -222,5 +243,5 @@ func formatListTabular(writer io.Writer, value interface{}, all bool) error {
 
 // FormatListTabularAll writes a tabular summary of storage instances, filesystems and volumes.
 func FormatListTabularAll(writer io.Writer, value interface{}) error {
-	return formatListTabular(writer, value, true)
+	return formatListTabular(writer, output.TableOptions{}, value, true)
 }
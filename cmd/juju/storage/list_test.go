@@ -70,6 +70,66 @@ transcode/1   shared-fs/0   filesystem  1.0GiB  attached
 `[1:])
 }
 
+func (s *ListSuite) TestListColumnsAndSort(c *gc.C) {
+	s.assertValidList(
+		c,
+		[]string{"--columns", "storage-id,unit,status", "--sort", "-storage-id,unit"},
+		`
+Storage id    Unit          Status
+shared-fs/0   transcode/0   attached
+shared-fs/0   transcode/1   attached
+persistent/1                detached
+db-dir/1100   postgresql/0  attached
+db-dir/1000   transcode/0   pending
+
+`[1:])
+}
+
+func (s *ListSuite) TestListSortBySize(c *gc.C) {
+	s.assertValidList(
+		c,
+		[]string{"--columns", "storage-id,unit,size", "--sort", "-size,unit"},
+		`
+Storage id    Unit          Size
+shared-fs/0   transcode/0   1.0GiB
+shared-fs/0   transcode/1   1.0GiB
+db-dir/1100   postgresql/0  3.0MiB
+persistent/1                
+db-dir/1000   transcode/0   
+
+`[1:])
+}
+
+func (s *ListSuite) TestListOptionalColumn(c *gc.C) {
+	s.mockAPI.omitPool = true
+	s.assertValidList(
+		c,
+		[]string{"--columns", "storage-id,pool,size", "--sort", "pool,storage-id"},
+		`
+Storage id    Size
+db-dir/1000   
+db-dir/1100   3.0MiB
+persistent/1  
+shared-fs/0   1.0GiB
+shared-fs/0   1.0GiB
+
+`[1:])
+}
+
+func (s *ListSuite) TestListColumnsCheckedAgainstListedTable(c *gc.C) {
+	_, err := s.runList(c, []string{"--volume", "--columns", "unit,mountpoint"})
+	c.Assert(err, gc.ErrorMatches, `unknown column "mountpoint", expected one of: machine, unit, storage-id, volume-id, provider-id, device, size, state, message`)
+	_, err = s.runList(c, []string{"--filesystem", "--sort", "-device"})
+	c.Assert(err, gc.ErrorMatches, `unknown column "device", expected one of: machine, unit, storage-id, id, volume, provider-id, mountpoint, size, state, message`)
+	_, err = s.runList(c, []string{"--filesystem", "--columns", "machine,mountpoint"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ListSuite) TestListUnknownColumn(c *gc.C) {
+	_, err := s.runList(c, []string{"--columns", "unit,volume"})
+	c.Assert(err, gc.ErrorMatches, `unknown column "volume", expected one of: unit, storage-id, type, pool, size, status, message`)
+}
+
 func (s *ListSuite) TestListYAML(c *gc.C) {
 	now := time.Now()
 	s.mockAPI.time = now
@@ -15,22 +15,24 @@ import (
 	"github.com/juju/juju/cmd/output"
 )
 
-// formatStorageInstancesListTabular writes a tabular summary of storage instances.
-func formatStorageInstancesListTabular(writer io.Writer, s CombinedStorage) error {
-	tw := output.TabWriter(writer)
-	w := output.Wrapper{tw}
+// storageInstanceHeaders holds the headers of every column the storage
+// instance table may have.
+var storageInstanceHeaders = []string{"Unit", "Storage id", "Type", "Pool", "Size", "Status", "Message"}
 
+// formatStorageInstancesListTabular writes a tabular summary of storage instances.
+func formatStorageInstancesListTabular(writer io.Writer, opts output.TableOptions, s CombinedStorage) error {
 	storagePool, storageSize := getStoragePoolAndSize(s)
 	units, byUnit := sortStorageInstancesByUnitId(s)
 
-	w.Print("Unit", "Storage id", "Type")
+	headers := []string{"Unit", "Storage id", "Type"}
 	if len(storagePool) > 0 {
 		// Older versions of Juju do not include
 		// the pool name in the storage details.
 		// We omit the column in that case.
-		w.Print("Pool")
+		headers = append(headers, "Pool")
 	}
-	w.Println("Size", "Status", "Message")
+	table := output.NewTable(append(headers, "Size", "Status", "Message")...)
+	table.SortAsSize("Size")
 
 	for _, unit := range units {
 		// Then sort by storage ids
@@ -43,20 +45,19 @@ func formatStorageInstancesListTabular(writer io.Writer, s CombinedStorage) erro
 
 		for _, storageId := range storageIds {
 			info := byStorage[storageId]
-			w.Print(info.unitId)
-			w.Print(info.storageId)
-			w.Print(info.kind)
+			row := []interface{}{info.unitId, info.storageId, info.kind}
 			if len(storagePool) > 0 {
-				w.Print(storagePool[info.storageId])
+				row = append(row, storagePool[info.storageId])
 			}
-			w.Print(humanizeStorageSize(storageSize[storageId]))
-			w.PrintStatus(info.status.Current)
-			w.Println(info.status.Message)
+			row = append(row,
+				humanizeStorageSize(storageSize[storageId]),
+				info.status.Current,
+				info.status.Message,
+			)
+			table.AddRow(row...)
 		}
 	}
-	tw.Flush()
-
-	return nil
+	return table.Write(writer, opts.ForTable(table))
 }
 
 func sortStorageInstancesByUnitId(s CombinedStorage) ([]string, map[string]map[string]storageAttachmentInfo) {
@@ -4,24 +4,20 @@
 package storage
 
 import (
-	"fmt"
 	"io"
 	"sort"
-	"strings"
 
 	"github.com/dustin/go-humanize"
 
 	"github.com/juju/juju/cmd/output"
 )
 
-// formatVolumeListTabular returns a tabular summary of volume instances.
-func formatVolumeListTabular(writer io.Writer, infos map[string]VolumeInfo) error {
-	tw := output.TabWriter(writer)
-
-	print := func(values ...string) {
-		fmt.Fprintln(tw, strings.Join(values, "\t"))
-	}
+// volumeHeaders holds the headers of every column the volume table may
+// have.
+var volumeHeaders = []string{"Machine", "Unit", "Storage id", "Volume id", "Provider Id", "Device", "Size", "State", "Message"}
 
+// formatVolumeListTabular returns a tabular summary of volume instances.
+func formatVolumeListTabular(writer io.Writer, opts output.TableOptions, infos map[string]VolumeInfo) error {
 	haveMachines := false
 	volumeAttachmentInfos := make(volumeAttachmentInfos, 0, len(infos))
 	for volumeId, info := range infos {
@@ -68,11 +64,13 @@ func formatVolumeListTabular(writer io.Writer, infos map[string]VolumeInfo) erro
 	}
 	sort.Sort(volumeAttachmentInfos)
 
+	var table *output.Table
 	if haveMachines {
-		print("Machine", "Unit", "Storage id", "Volume id", "Provider Id", "Device", "Size", "State", "Message")
+		table = output.NewTable(volumeHeaders...)
 	} else {
-		print("Unit", "Storage id", "Volume id", "Provider Id", "Size", "State", "Message")
+		table = output.NewTable("Unit", "Storage id", "Volume id", "Provider Id", "Size", "State", "Message")
 	}
+	table.SortAsSize("Size")
 
 	for _, info := range volumeAttachmentInfos {
 		var size string
@@ -80,22 +78,22 @@ func formatVolumeListTabular(writer io.Writer, infos map[string]VolumeInfo) erro
 			size = humanize.IBytes(info.Size * humanize.MiByte)
 		}
 		if haveMachines {
-			print(
+			table.AddRow(
 				info.MachineId, info.UnitId, info.Storage,
 				info.VolumeId, info.ProviderVolumeId,
 				info.VolumeAttachment.DeviceName, size,
-				string(info.Status.Current), info.Status.Message,
+				info.Status.Current, info.Status.Message,
 			)
 		} else {
-			print(
+			table.AddRow(
 				info.UnitId, info.Storage,
 				info.VolumeId, info.ProviderVolumeId, size,
-				string(info.Status.Current), info.Status.Message,
+				info.Status.Current, info.Status.Message,
 			)
 		}
 	}
 
-	return tw.Flush()
+	return table.Write(writer, opts.ForTable(table))
 }
 
 type volumeAttachmentInfo struct {
@@ -46,6 +46,7 @@ var (
 		"migration-fortress",      // secondary dependency: will be inactive because depends on model-upgrader
 		"migration-inactive-flag", // secondary dependency: will be inactive because depends on model-upgrader
 		"migration-master",        // secondary dependency: will be inactive because depends on model-upgrader
+		"model-expiry",            // tertiary dependency: will be inactive because migration workers will be inactive
 		"model-upgrader",
 		"remote-relations",      // tertiary dependency: will be inactive because migration workers will be inactive
 		"state-cleaner",         // tertiary dependency: will be inactive because migration workers will be inactive
@@ -70,6 +71,7 @@ var (
 		"migration-fortress",
 		"migration-inactive-flag",
 		"migration-master",
+		"model-expiry",
 		"remote-relations",
 		"state-cleaner",
 		"status-history-pruner",
@@ -1089,6 +1089,9 @@ func (a *MachineAgent) startModelWorkers(cfg modelworkermanager.NewModelConfig)
 		CharmRevisionUpdateInterval: 24 * time.Hour,
 		StatusHistoryPrunerInterval: 5 * time.Minute,
 		ActionPrunerInterval:        24 * time.Hour,
+		ModelExpiryWarningPeriod:    time.Hour,
+		ModelExpiryMinimumWarning:   10 * time.Minute,
+		ModelExpiryRetryDelay:       10 * time.Minute,
 		Mux:                         cfg.Mux,
 		NewEnvironFunc:              newEnvirons,
 		NewContainerBrokerFunc:      newCAASBroker,
@@ -43,6 +43,8 @@ import (
 	"github.com/juju/juju/worker/apiservercertwatcher"
 	"github.com/juju/juju/worker/auditconfigupdater"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupscheduler"
+	"github.com/juju/juju/worker/branchprogression"
 	"github.com/juju/juju/worker/caasupgrader"
 	"github.com/juju/juju/worker/centralhub"
 	"github.com/juju/juju/worker/certupdater"
@@ -654,6 +656,17 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			},
 		))),
 
+		// The branch progression worker steps progressively tracked
+		// branches in all models, while their tracking units are
+		// healthy. It runs on the primary controller only.
+		branchProgressionName: ifNotMigrating(ifPrimaryController(branchprogression.Manifold(
+			branchprogression.ManifoldConfig{
+				ClockName: clockName,
+				StateName: stateName,
+				NewWorker: branchprogression.NewWorker,
+			},
+		))),
+
 		httpServerArgsName: httpserverargs.Manifold(httpserverargs.ManifoldConfig{
 			ClockName:             clockName,
 			ControllerPortName:    controllerPortName,
@@ -887,6 +900,18 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewMachineAddressWatcher: certupdater.NewMachineAddressWatcher,
 		})),
 
+		// The backup scheduler takes backups of the controller as
+		// configured in controller config. It runs on the primary
+		// controller only, so that backups aren't duplicated.
+		backupSchedulerName: ifNotMigrating(ifPrimaryController(backupscheduler.Manifold(
+			backupscheduler.ManifoldConfig{
+				AgentName: agentName,
+				ClockName: clockName,
+				StateName: stateName,
+				NewWorker: backupscheduler.NewWorker,
+			},
+		))),
+
 		// The machiner Worker will wait for the identified machine to become
 		// Dying and make it Dead; or until the machine becomes Dead by other
 		// means. This worker needs to be launched after fanconfigurer
@@ -1130,6 +1155,8 @@ const (
 	isControllerFlagName          = "is-controller-flag"
 	instanceMutaterName           = "instance-mutater"
 	txnPrunerName                 = "transaction-pruner"
+	backupSchedulerName           = "backup-scheduler"
+	branchProgressionName         = "branch-progression"
 	certificateWatcherName        = "certificate-watcher"
 	modelCacheName                = "model-cache"
 	modelCacheInitializedFlagName = "model-cache-initialized-flag"
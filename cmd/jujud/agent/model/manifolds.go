@@ -54,6 +54,7 @@ import (
 	"github.com/juju/juju/worker/metricworker"
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationmaster"
+	"github.com/juju/juju/worker/modelexpiry"
 	"github.com/juju/juju/worker/modelupgrader"
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/pruner"
@@ -113,6 +114,18 @@ type ManifoldsConfig struct {
 	// worker is run.
 	ActionPrunerInterval time.Duration
 
+	// ModelExpiryWarningPeriod controls how long before a model's
+	// TTL runs out the model expiry worker starts warning about it.
+	ModelExpiryWarningPeriod time.Duration
+
+	// ModelExpiryMinimumWarning controls the least time for which the
+	// model expiry worker warns about a model before destroying it.
+	ModelExpiryMinimumWarning time.Duration
+
+	// ModelExpiryRetryDelay controls how long the model expiry worker
+	// waits before trying again to destroy a blocked expired model.
+	ModelExpiryRetryDelay time.Duration
+
 	// NewEnvironFunc is a function opens a provider "environment"
 	// (typically environs.New).
 	NewEnvironFunc environs.NewEnvironFunc
@@ -290,6 +303,16 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			PruneInterval: config.StatusHistoryPrunerInterval,
 			Logger:        config.LoggingContext.GetLogger("juju.worker.pruner.statushistory"),
 		})),
+		modelExpiryName: ifNotMigrating(modelexpiry.Manifold(modelexpiry.ManifoldConfig{
+			APICallerName:  apiCallerName,
+			Clock:          config.Clock,
+			WarningPeriod:  config.ModelExpiryWarningPeriod,
+			MinimumWarning: config.ModelExpiryMinimumWarning,
+			RetryDelay:     config.ModelExpiryRetryDelay,
+			NewFacade:      modelexpiry.NewFacade,
+			NewWorker:      modelexpiry.NewWorker,
+			Logger:         config.LoggingContext.GetLogger("juju.worker.modelexpiry"),
+		})),
 		actionPrunerName: ifNotMigrating(pruner.Manifold(pruner.ManifoldConfig{
 			APICallerName: apiCallerName,
 			Clock:         config.Clock,
@@ -655,6 +678,7 @@ const (
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
+	modelExpiryName          = "model-expiry"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
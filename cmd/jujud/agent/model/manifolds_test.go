@@ -54,6 +54,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"migration-fortress",
 		"migration-inactive-flag",
 		"migration-master",
+		"model-expiry",
 		"model-upgrade-gate",
 		"model-upgraded-flag",
 		"model-upgrader",
@@ -99,6 +100,7 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 		"migration-fortress",
 		"migration-inactive-flag",
 		"migration-master",
+		"model-expiry",
 		"model-upgrade-gate",
 		"model-upgraded-flag",
 		"model-upgrader",
@@ -327,6 +329,16 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"model-expiry": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"model-upgrade-gate": {},
 
 	"model-upgraded-flag": {"model-upgrade-gate"},
@@ -549,6 +561,17 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"model-expiry": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+	},
+
 	"model-upgrade-gate": {},
 
 	"model-upgraded-flag": {"model-upgrade-gate"},
@@ -0,0 +1,470 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/ansiterm"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/core/status"
+)
+
+// padding is the number of spaces between columns, matching the
+// layout of TabWriter.
+const padding = 2
+
+// Cell is a single value in a table, optionally written in color.
+type Cell struct {
+	Value string
+	Color *ansiterm.Context
+}
+
+// StatusCell returns a cell holding the status in its standard color.
+func StatusCell(s status.Status) Cell {
+	return Cell{Value: string(s), Color: statusColors[s]}
+}
+
+// Table collects the rows of tabular output so that the columns that
+// are shown, the order of the rows and the width of the output can be
+// chosen when the table is written.
+type Table struct {
+	headers    []string
+	alignRight map[int]bool
+	sizes      map[int]bool
+	rows       []tableRow
+}
+
+// tableRow is a row of a table, along with the detail rows that
+// are kept with it when the table is sorted.
+type tableRow struct {
+	cells   []Cell
+	details [][]Cell
+}
+
+// NewTable returns a table with the given column headers.
+func NewTable(headers ...string) *Table {
+	return &Table{
+		headers:    headers,
+		alignRight: make(map[int]bool),
+		sizes:      make(map[int]bool),
+	}
+}
+
+// AlignRight right aligns the values of the column with the given
+// header.
+func (t *Table) AlignRight(header string) {
+	for i, h := range t.headers {
+		if h == header {
+			t.alignRight[i] = true
+		}
+	}
+}
+
+// SortAsSize sorts the values of the column with the given header by
+// the size they describe, such as 512MiB or 1.0GiB, rather than as
+// text. Values that are not sizes sort first.
+func (t *Table) SortAsSize(header string) {
+	for i, h := range t.headers {
+		if h == header {
+			t.sizes[i] = true
+		}
+	}
+}
+
+// AddRow adds a row to the table. Values which are not cells are
+// formatted with %v, and status values are written in their standard
+// color. Missing trailing values are left empty.
+func (t *Table) AddRow(values ...interface{}) {
+	t.rows = append(t.rows, tableRow{cells: t.cells(values)})
+}
+
+// AddDetailRow adds a row that belongs to the row before it, such as
+// the second endpoint of an offer. Detail rows stay with their row when
+// the table is sorted.
+func (t *Table) AddDetailRow(values ...interface{}) {
+	if len(t.rows) == 0 {
+		t.AddRow(values...)
+		return
+	}
+	last := &t.rows[len(t.rows)-1]
+	last.details = append(last.details, t.cells(values))
+}
+
+// Len returns the number of rows in the table, excluding detail rows.
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+func (t *Table) cells(values []interface{}) []Cell {
+	cells := make([]Cell, len(t.headers))
+	for i, v := range values {
+		if i >= len(cells) {
+			break
+		}
+		switch v := v.(type) {
+		case Cell:
+			cells[i] = v
+		case status.Status:
+			cells[i] = StatusCell(v)
+		default:
+			cells[i] = Cell{Value: fmt.Sprint(v)}
+		}
+	}
+	return cells
+}
+
+// TableOptions control how a table is written.
+type TableOptions struct {
+	// Columns holds the names of the columns to write, in order. If
+	// empty, all columns are written.
+	Columns []string
+
+	// SortBy holds the names of the columns to sort the rows by. A
+	// name prefixed with "-" sorts in descending order. If empty, the
+	// rows are written in the order they were added.
+	SortBy []string
+
+	// Width is the maximum width of the output. Values are truncated
+	// to fit where possible. If zero, values are never truncated.
+	Width int
+
+	// Color forces the use of ANSI color codes.
+	Color bool
+}
+
+// ForTable returns a copy of the options without the columns the table
+// does not have. It is used when the same options are applied to tables
+// with optional columns, after the columns have been checked with
+// TableFlags.Validate.
+func (opts TableOptions) ForTable(t *Table) TableOptions {
+	has := func(name string) bool {
+		_, err := t.columnIndex(strings.TrimPrefix(strings.TrimSpace(name), "-"))
+		return err == nil
+	}
+	result := opts
+	result.Columns, result.SortBy = nil, nil
+	for _, name := range opts.Columns {
+		if has(name) {
+			result.Columns = append(result.Columns, name)
+		}
+	}
+	for _, name := range opts.SortBy {
+		if has(name) {
+			result.SortBy = append(result.SortBy, name)
+		}
+	}
+	return result
+}
+
+// ColumnName returns the name used to select a column from its header:
+// the header in lower case with spaces replaced by hyphens.
+func ColumnName(header string) string {
+	return strings.Replace(strings.ToLower(header), " ", "-", -1)
+}
+
+func (t *Table) columnIndex(name string) (int, error) {
+	name = ColumnName(strings.TrimSpace(name))
+	for i, h := range t.headers {
+		if ColumnName(h) == name {
+			return i, nil
+		}
+	}
+	names := make([]string, len(t.headers))
+	for i, h := range t.headers {
+		names[i] = ColumnName(h)
+	}
+	return -1, errors.Errorf("unknown column %q, expected one of: %s", name, strings.Join(names, ", "))
+}
+
+// Write writes the table to the writer using the given options.
+func (t *Table) Write(writer io.Writer, opts TableOptions) error {
+	columns := make([]int, len(t.headers))
+	for i := range columns {
+		columns[i] = i
+	}
+	if len(opts.Columns) > 0 {
+		columns = columns[:0]
+		for _, name := range opts.Columns {
+			i, err := t.columnIndex(name)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			columns = append(columns, i)
+		}
+	}
+	rows, err := t.sortedRows(opts.SortBy)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Flatten the rows, selecting the columns to write.
+	headers := make([]Cell, len(columns))
+	for i, c := range columns {
+		headers[i] = Cell{Value: t.headers[c]}
+	}
+	lines := [][]Cell{headers}
+	selectColumns := func(cells []Cell) []Cell {
+		selected := make([]Cell, len(columns))
+		for i, c := range columns {
+			selected[i] = cells[c]
+		}
+		return selected
+	}
+	for _, row := range rows {
+		lines = append(lines, selectColumns(row.cells))
+		for _, detail := range row.details {
+			lines = append(lines, selectColumns(detail))
+		}
+	}
+	if opts.Width > 0 {
+		truncateLines(lines, opts.Width)
+	}
+
+	tw := TabWriter(writer)
+	if opts.Color {
+		tw.SetColorCapable(true)
+	}
+	for i, c := range columns {
+		if t.alignRight[c] && i != len(columns)-1 {
+			tw.SetColumnAlignRight(i)
+		}
+	}
+	for _, line := range lines {
+		for i, cell := range line {
+			if cell.Color != nil {
+				cell.Color.Fprint(tw, cell.Value)
+			} else {
+				fmt.Fprint(tw, cell.Value)
+			}
+			if i != len(line)-1 {
+				fmt.Fprint(tw, "\t")
+			}
+		}
+		fmt.Fprintln(tw)
+	}
+	return errors.Trace(tw.Flush())
+}
+
+type sortKey struct {
+	column     int
+	descending bool
+}
+
+func (t *Table) sortedRows(sortBy []string) ([]tableRow, error) {
+	rows := make([]tableRow, len(t.rows))
+	copy(rows, t.rows)
+	if len(sortBy) == 0 {
+		return rows, nil
+	}
+	keys := make([]sortKey, len(sortBy))
+	for i, name := range sortBy {
+		name = strings.TrimSpace(name)
+		if strings.HasPrefix(name, "-") {
+			keys[i].descending = true
+			name = name[1:]
+		}
+		column, err := t.columnIndex(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		keys[i].column = column
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, key := range keys {
+			a, b := rows[i].cells[key.column].Value, rows[j].cells[key.column].Value
+			if a == b {
+				continue
+			}
+			if t.sizes[key.column] {
+				sizeA, sizeB := parseSize(a), parseSize(b)
+				if sizeA != sizeB {
+					return (sizeA < sizeB) != key.descending
+				}
+			}
+			return naturalLess(a, b) != key.descending
+		}
+		return false
+	})
+	return rows, nil
+}
+
+// naturalLess reports whether a sorts before b, treating runs of digits
+// as numbers so that "machine/10" sorts after "machine/9". The strings
+// are compared in place, as this is called for every comparison made
+// while sorting.
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		if !isDigit(a[0]) || !isDigit(b[0]) {
+			if a[0] != b[0] {
+				return a[0] < b[0]
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		i, j := digitsEnd(a), digitsEnd(b)
+		numA, numB := strings.TrimLeft(a[:i], "0"), strings.TrimLeft(b[:j], "0")
+		if len(numA) != len(numB) {
+			return len(numA) < len(numB)
+		}
+		if numA != numB {
+			return numA < numB
+		}
+		if i != j {
+			// Equal numbers; fewer leading zeros sort first.
+			return i < j
+		}
+		a, b = a[i:], b[j:]
+	}
+	return len(a) < len(b)
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// digitsEnd returns the index of the first byte in s that is not a
+// digit.
+func digitsEnd(s string) int {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return i
+}
+
+// parseSize returns the number of bytes described by a size such as
+// 1.0GiB, or zero if the value is not a size.
+func parseSize(value string) uint64 {
+	size, err := humanize.ParseBytes(value)
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// truncateLines shortens the widest columns of the lines until the
+// lines fit in the given width. Columns are never made narrower than
+// their header, so the output may still be wider than requested.
+func truncateLines(lines [][]Cell, width int) {
+	if len(lines) == 0 {
+		return
+	}
+	numColumns := len(lines[0])
+	widths := make([]int, numColumns)
+	minWidths := make([]int, numColumns)
+	for _, line := range lines {
+		for i, cell := range line {
+			if w := len([]rune(cell.Value)); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	total := padding * (numColumns - 1)
+	for i, header := range lines[0] {
+		minWidths[i] = len([]rune(header.Value))
+		if minWidths[i] < 4 {
+			minWidths[i] = 4
+		}
+		if minWidths[i] > widths[i] {
+			minWidths[i] = widths[i]
+		}
+		total += widths[i]
+	}
+	for total > width {
+		widest := -1
+		for i := range widths {
+			if widths[i] > minWidths[i] && (widest < 0 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	for _, line := range lines {
+		for i := range line {
+			line[i].Value = truncate(line[i].Value, widths[i])
+		}
+	}
+}
+
+// truncate shortens the value to the width, marking values that were
+// shortened with an ellipsis.
+func truncate(value string, width int) string {
+	runes := []rune(value)
+	if len(runes) <= width {
+		return value
+	}
+	if width <= 1 {
+		return string(runes[:width])
+	}
+	return strings.TrimRight(string(runes[:width-1]), " ") + "…"
+}
+
+// TableFlags holds the command line options shared by the commands that
+// write tables.
+type TableFlags struct {
+	columns string
+	sortBy  string
+	width   int
+	color   bool
+}
+
+// AddFlags adds the table flags to the flag set.
+func (f *TableFlags) AddFlags(fs *gnuflag.FlagSet) {
+	fs.StringVar(&f.columns, "columns", "", "Comma separated list of columns to show in tabular output")
+	fs.StringVar(&f.sortBy, "sort", "", "Comma separated list of columns to sort tabular output by; prefix a column with - to reverse the order")
+	fs.IntVar(&f.width, "width", 0, "Truncate tabular output to the given width")
+	fs.BoolVar(&f.color, "color", false, "Force use of ANSI color codes")
+}
+
+// Color reports whether the use of ANSI color codes was forced.
+func (f *TableFlags) Color() bool {
+	return f.color
+}
+
+// Options returns the options for writing a table. Values are only
+// truncated when a width was specified.
+func (f *TableFlags) Options() TableOptions {
+	return TableOptions{
+		Columns: splitList(f.columns),
+		SortBy:  splitList(f.sortBy),
+		Width:   f.width,
+		Color:   f.color,
+	}
+}
+
+// Validate checks that the columns selected and sorted by are among
+// the given headers, so that an unknown column is reported before any
+// output is written.
+func (f *TableFlags) Validate(headers ...string) error {
+	t := NewTable(headers...)
+	for _, name := range splitList(f.columns) {
+		if _, err := t.columnIndex(name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, name := range splitList(f.sortBy) {
+		if _, err := t.columnIndex(strings.TrimPrefix(strings.TrimSpace(name), "-")); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
@@ -0,0 +1,199 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package output_test
+
+import (
+	"bytes"
+
+	"github.com/juju/gnuflag"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/status"
+)
+
+type TableSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&TableSuite{})
+
+func (s *TableSuite) newTable() *output.Table {
+	table := output.NewTable("Machine", "State", "Inst id", "Message")
+	table.AddRow("10", status.Started, "i-0a1b2c3d4e5f", "")
+	table.AddRow("9", status.Pending, "i-99", "waiting for the provider to allocate an instance")
+	table.AddRow("2", status.Down, "i-22", "agent is not communicating with the server")
+	table.AddDetailRow("2/lxd/0", status.Started, "juju-2-lxd-0")
+	return table
+}
+
+func (s *TableSuite) write(c *gc.C, table *output.Table, opts output.TableOptions) string {
+	var buf bytes.Buffer
+	err := table.Write(&buf, opts)
+	c.Assert(err, jc.ErrorIsNil)
+	return buf.String()
+}
+
+func (s *TableSuite) TestWrite(c *gc.C) {
+	c.Assert(s.write(c, s.newTable(), output.TableOptions{}), gc.Equals, `
+Machine  State    Inst id         Message
+10       started  i-0a1b2c3d4e5f  
+9        pending  i-99            waiting for the provider to allocate an instance
+2        down     i-22            agent is not communicating with the server
+2/lxd/0  started  juju-2-lxd-0    
+`[1:])
+}
+
+func (s *TableSuite) TestColumns(c *gc.C) {
+	opts := output.TableOptions{Columns: []string{"inst-id", "Machine"}}
+	c.Assert(s.write(c, s.newTable(), opts), gc.Equals, `
+Inst id         Machine
+i-0a1b2c3d4e5f  10
+i-99            9
+i-22            2
+juju-2-lxd-0    2/lxd/0
+`[1:])
+}
+
+func (s *TableSuite) TestUnknownColumn(c *gc.C) {
+	var buf bytes.Buffer
+	err := s.newTable().Write(&buf, output.TableOptions{Columns: []string{"dns"}})
+	c.Assert(err, gc.ErrorMatches, `unknown column "dns", expected one of: machine, state, inst-id, message`)
+	err = s.newTable().Write(&buf, output.TableOptions{SortBy: []string{"-dns"}})
+	c.Assert(err, gc.ErrorMatches, `unknown column "dns", .*`)
+}
+
+func (s *TableSuite) TestSort(c *gc.C) {
+	opts := output.TableOptions{Columns: []string{"machine", "state"}, SortBy: []string{"machine"}}
+	c.Assert(s.write(c, s.newTable(), opts), gc.Equals, `
+Machine  State
+2        down
+2/lxd/0  started
+9        pending
+10       started
+`[1:])
+
+	opts.SortBy = []string{"-state", "machine"}
+	c.Assert(s.write(c, s.newTable(), opts), gc.Equals, `
+Machine  State
+10       started
+9        pending
+2        down
+2/lxd/0  started
+`[1:])
+}
+
+func (s *TableSuite) TestSortNatural(c *gc.C) {
+	table := output.NewTable("Name")
+	for _, name := range []string{"b", "a10", "a9", "a09b", "a9b", "10", "9", "a"} {
+		table.AddRow(name)
+	}
+	opts := output.TableOptions{SortBy: []string{"name"}}
+	c.Assert(s.write(c, table, opts), gc.Equals, `
+Name
+9
+10
+a
+a9
+a9b
+a09b
+a10
+b
+`[1:])
+}
+
+func (s *TableSuite) TestSortAsSize(c *gc.C) {
+	table := output.NewTable("Id", "Size")
+	table.SortAsSize("Size")
+	table.AddRow("0", "1.0GiB")
+	table.AddRow("1", "512MiB")
+	table.AddRow("2", "")
+	table.AddRow("3", "2.5TiB")
+	opts := output.TableOptions{SortBy: []string{"size"}}
+	c.Assert(s.write(c, table, opts), gc.Equals, `
+Id  Size
+2   
+1   512MiB
+0   1.0GiB
+3   2.5TiB
+`[1:])
+}
+
+func (s *TableSuite) TestForTable(c *gc.C) {
+	opts := output.TableOptions{
+		Columns: []string{"machine", "dns", "state"},
+		SortBy:  []string{"-dns", "-state"},
+		Width:   40,
+	}
+	c.Assert(opts.ForTable(s.newTable()), jc.DeepEquals, output.TableOptions{
+		Columns: []string{"machine", "state"},
+		SortBy:  []string{"-state"},
+		Width:   40,
+	})
+}
+
+func (s *TableSuite) TestWidth(c *gc.C) {
+	opts := output.TableOptions{Width: 60}
+	c.Assert(s.write(c, s.newTable(), opts), gc.Equals, `
+Machine  State    Inst id         Message
+10       started  i-0a1b2c3d4e5f  
+9        pending  i-99            waiting for the provider…
+2        down     i-22            agent is not communicatin…
+2/lxd/0  started  juju-2-lxd-0    
+`[1:])
+}
+
+func (s *TableSuite) TestWidthNeverTruncatesHeaders(c *gc.C) {
+	opts := output.TableOptions{Width: 10}
+	c.Assert(s.write(c, s.newTable(), opts), gc.Equals, `
+Machine  State  Inst id  Message
+10       star…  i-0a1b…  
+9        pend…  i-99     waitin…
+2        down   i-22     agent…
+2/lxd/0  star…  juju-2…  
+`[1:])
+}
+
+func (s *TableSuite) TestAlignRight(c *gc.C) {
+	table := output.NewTable("Id", "Status")
+	table.AlignRight("Id")
+	table.AddRow(1, "running")
+	table.AddRow(10, "completed")
+	c.Assert(s.write(c, table, output.TableOptions{}), gc.Equals, `
+Id  Status
+ 1  running
+10  completed
+`[1:])
+}
+
+func (s *TableSuite) TestColor(c *gc.C) {
+	table := output.NewTable("Unit", "Workload")
+	table.AddRow("mysql/0", status.Error)
+	c.Assert(s.write(c, table, output.TableOptions{Color: true}), gc.Equals,
+		"Unit     Workload\nmysql/0  \x1b[31merror\x1b[0m\n")
+}
+
+func (s *TableSuite) TestFlags(c *gc.C) {
+	var flags output.TableFlags
+	fs := gnuflag.NewFlagSet("test", gnuflag.ContinueOnError)
+	flags.AddFlags(fs)
+	err := fs.Parse(true, []string{"--columns", "machine,state", "--sort", "-state", "--width", "40", "--color"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(flags.Color(), jc.IsTrue)
+	c.Assert(flags.Options(), jc.DeepEquals, output.TableOptions{
+		Columns: []string{"machine", "state"},
+		SortBy:  []string{"-state"},
+		Width:   40,
+		Color:   true,
+	})
+	c.Assert(flags.Validate("Machine", "State"), jc.ErrorIsNil)
+	c.Assert(flags.Validate("Machine", "Status"), gc.ErrorMatches, `unknown column "state", expected one of: machine, status`)
+}
+
+func (s *TableSuite) TestFlagsDoNotTruncateByDefault(c *gc.C) {
+	var flags output.TableFlags
+	c.Assert(flags.Options(), jc.DeepEquals, output.TableOptions{})
+}
@@ -199,6 +199,42 @@ const (
 	// when writing to the raft log by setting this value to true.
 	NonSyncedWritesToRaftLog = "non-synced-writes-to-raft-log"
 
+	// BackupScheduleInterval is how often the controller takes a
+	// scheduled backup. A value of zero disables scheduled backups.
+	BackupScheduleInterval = "backup-schedule-interval"
+
+	// BackupRetentionCount is the number of scheduled backups kept on
+	// the controller. A value of zero keeps them all.
+	BackupRetentionCount = "backup-retention-count"
+
+	// BackupRetentionAge is how long scheduled backups are kept on the
+	// controller. A value of zero keeps them regardless of age.
+	BackupRetentionAge = "backup-retention-age"
+
+	// BackupEncryptTo is the public key scheduled backups are
+	// encrypted to, as generated by "juju create-backup-key".
+	BackupEncryptTo = "backup-encrypt-to"
+
+	// BackupS3Endpoint is the URL of the S3-compatible service that
+	// scheduled backups are uploaded to. Backups are not uploaded if
+	// it is empty.
+	BackupS3Endpoint = "backup-s3-endpoint"
+
+	// BackupS3Bucket is the bucket scheduled backups are uploaded to.
+	BackupS3Bucket = "backup-s3-bucket"
+
+	// BackupS3Region is the region used when signing requests to the
+	// S3-compatible service.
+	BackupS3Region = "backup-s3-region"
+
+	// BackupS3AccessKey is the access key used to upload scheduled
+	// backups.
+	BackupS3AccessKey = "backup-s3-access-key"
+
+	// BackupS3SecretKey is the secret key used to upload scheduled
+	// backups.
+	BackupS3SecretKey = "backup-s3-secret-key"
+
 	// Attribute Defaults
 
 	// DefaultAgentRateLimitMax allows the first 10 agents to connect without any
@@ -292,6 +328,14 @@ const (
 	// non-synced-writes-to-raft-log value. It is set to false by default.
 	DefaultNonSyncedWritesToRaftLog = false
 
+	// DefaultBackupRetentionCount is the default number of scheduled
+	// backups kept on the controller.
+	DefaultBackupRetentionCount = 7
+
+	// DefaultBackupS3Region is the default region used when signing
+	// requests to the S3-compatible backup upload service.
+	DefaultBackupS3Region = "us-east-1"
+
 	// JujuHASpace is the network space within which the MongoDB replica-set
 	// should communicate.
 	JujuHASpace = "juju-ha-space"
@@ -361,6 +405,15 @@ var (
 		MaxCharmStateSize,
 		MaxAgentStateSize,
 		NonSyncedWritesToRaftLog,
+		BackupScheduleInterval,
+		BackupRetentionCount,
+		BackupRetentionAge,
+		BackupEncryptTo,
+		BackupS3Endpoint,
+		BackupS3Bucket,
+		BackupS3Region,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
 	}
 
 	// For backwards compatibility, we must include "anything", "juju-apiserver"
@@ -406,6 +459,15 @@ var (
 		MaxCharmStateSize,
 		MaxAgentStateSize,
 		NonSyncedWritesToRaftLog,
+		BackupScheduleInterval,
+		BackupRetentionCount,
+		BackupRetentionAge,
+		BackupEncryptTo,
+		BackupS3Endpoint,
+		BackupS3Bucket,
+		BackupS3Region,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -843,6 +905,67 @@ func (c Config) NonSyncedWritesToRaftLog() bool {
 	return DefaultNonSyncedWritesToRaftLog
 }
 
+// BackupScheduleInterval returns how often scheduled backups are
+// taken. A value of zero means backups are not scheduled.
+func (c Config) BackupScheduleInterval() time.Duration {
+	return c.durationOrDefault(BackupScheduleInterval, 0)
+}
+
+// BackupRetentionCount returns the number of scheduled backups kept on
+// the controller. A value of zero means they are all kept.
+func (c Config) BackupRetentionCount() int {
+	// Zero is a valid value here, so mustInt can't be used.
+	switch value := c[BackupRetentionCount].(type) {
+	case int:
+		return value
+	case float64:
+		return int(value)
+	}
+	return DefaultBackupRetentionCount
+}
+
+// BackupRetentionAge returns how long scheduled backups are kept on the
+// controller. A value of zero means they are kept regardless of age.
+func (c Config) BackupRetentionAge() time.Duration {
+	return c.durationOrDefault(BackupRetentionAge, 0)
+}
+
+// BackupEncryptTo returns the public key scheduled backups are
+// encrypted to, or "" if they are not encrypted.
+func (c Config) BackupEncryptTo() string {
+	return c.asString(BackupEncryptTo)
+}
+
+// BackupS3Config holds the details of where scheduled backups are
+// uploaded to.
+type BackupS3Config struct {
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+}
+
+// BackupS3 returns where scheduled backups are uploaded to, and
+// whether they are uploaded at all.
+func (c Config) BackupS3() (BackupS3Config, bool) {
+	endpoint := c.asString(BackupS3Endpoint)
+	if endpoint == "" {
+		return BackupS3Config{}, false
+	}
+	region := c.asString(BackupS3Region)
+	if region == "" {
+		region = DefaultBackupS3Region
+	}
+	return BackupS3Config{
+		Endpoint:  endpoint,
+		Bucket:    c.asString(BackupS3Bucket),
+		Region:    region,
+		AccessKey: c.asString(BackupS3AccessKey),
+		SecretKey: c.asString(BackupS3SecretKey),
+	}, true
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -1046,6 +1169,37 @@ func Validate(c Config) error {
 		return errors.Errorf("invalid max charm/agent state sizes: combined value should not exceed mongo's 16M per-document limit, got %d", maxUnitStateSize)
 	}
 
+	if err := c.validateBackupConfig(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (c Config) validateBackupConfig() error {
+	if v, ok := c[BackupScheduleInterval].(time.Duration); ok {
+		if v != 0 && v < time.Minute {
+			return errors.NotValidf("%s less than 1m", BackupScheduleInterval)
+		}
+	}
+	if v, ok := c[BackupRetentionCount].(int); ok && v < 0 {
+		return errors.NotValidf("negative %s", BackupRetentionCount)
+	}
+	if v, ok := c[BackupRetentionAge].(time.Duration); ok && v < 0 {
+		return errors.NotValidf("negative %s", BackupRetentionAge)
+	}
+	if v, ok := c[BackupS3Endpoint].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", BackupS3Endpoint)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("%s must be an http or https URL, got %q", BackupS3Endpoint, v)
+		}
+		if c.asString(BackupS3Bucket) == "" {
+			return errors.Errorf("%s must be set when %s is", BackupS3Bucket, BackupS3Endpoint)
+		}
+	}
 	return nil
 }
 
@@ -1138,6 +1292,15 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxCharmStateSize:        schema.ForceInt(),
 	MaxAgentStateSize:        schema.ForceInt(),
 	NonSyncedWritesToRaftLog: schema.Bool(),
+	BackupScheduleInterval:   schema.TimeDuration(),
+	BackupRetentionCount:     schema.ForceInt(),
+	BackupRetentionAge:       schema.TimeDuration(),
+	BackupEncryptTo:          schema.String(),
+	BackupS3Endpoint:         schema.String(),
+	BackupS3Bucket:           schema.String(),
+	BackupS3Region:           schema.String(),
+	BackupS3AccessKey:        schema.String(),
+	BackupS3SecretKey:        schema.String(),
 }, schema.Defaults{
 	AgentRateLimitMax:        schema.Omit,
 	AgentRateLimitRate:       schema.Omit,
@@ -1178,6 +1341,15 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxCharmStateSize:        DefaultMaxCharmStateSize,
 	MaxAgentStateSize:        DefaultMaxAgentStateSize,
 	NonSyncedWritesToRaftLog: DefaultNonSyncedWritesToRaftLog,
+	BackupScheduleInterval:   schema.Omit,
+	BackupRetentionCount:     schema.Omit,
+	BackupRetentionAge:       schema.Omit,
+	BackupEncryptTo:          schema.Omit,
+	BackupS3Endpoint:         schema.Omit,
+	BackupS3Bucket:           schema.Omit,
+	BackupS3Region:           schema.Omit,
+	BackupS3AccessKey:        schema.Omit,
+	BackupS3SecretKey:        schema.Omit,
 })
 
 // ConfigSchema holds information on all the fields defined by
@@ -1346,4 +1518,40 @@ Use "caas-image-repo" instead.`,
 		Type:        environschema.Tbool,
 		Description: `Do not perform fsync calls after appending entries to the raft log. Disabling sync improves performance at the cost of reliability`,
 	},
+	BackupScheduleInterval: {
+		Type:        environschema.Tstring,
+		Description: `How often the controller takes a scheduled backup (eg "24h"), or 0 to disable scheduled backups`,
+	},
+	BackupRetentionCount: {
+		Type:        environschema.Tint,
+		Description: `The number of scheduled backups kept on the controller (or 0 to keep all)`,
+	},
+	BackupRetentionAge: {
+		Type:        environschema.Tstring,
+		Description: `How long scheduled backups are kept on the controller (eg "720h"), or 0 to keep them regardless of age`,
+	},
+	BackupEncryptTo: {
+		Type:        environschema.Tstring,
+		Description: `The public key, as generated by "juju create-backup-key", that scheduled backups are encrypted to`,
+	},
+	BackupS3Endpoint: {
+		Type:        environschema.Tstring,
+		Description: `The URL of an S3-compatible service that scheduled backups are uploaded to`,
+	},
+	BackupS3Bucket: {
+		Type:        environschema.Tstring,
+		Description: `The bucket that scheduled backups are uploaded to`,
+	},
+	BackupS3Region: {
+		Type:        environschema.Tstring,
+		Description: `The region used when uploading scheduled backups`,
+	},
+	BackupS3AccessKey: {
+		Type:        environschema.Tstring,
+		Description: `The access key used when uploading scheduled backups`,
+	},
+	BackupS3SecretKey: {
+		Type:        environschema.Tstring,
+		Description: `The secret key used when uploading scheduled backups`,
+	},
 }
@@ -339,6 +339,31 @@ var newConfigTests = []struct {
 		controller.NonSyncedWritesToRaftLog: "I live dangerously",
 	},
 	expectError: `non-synced-writes-to-raft-log: expected bool, got string\("I live dangerously"\)`,
+}, {
+	about: "backup-schedule-interval too short",
+	config: controller.Config{
+		controller.BackupScheduleInterval: "30s",
+	},
+	expectError: `backup-schedule-interval less than 1m not valid`,
+}, {
+	about: "backup-retention-count cannot be negative",
+	config: controller.Config{
+		controller.BackupRetentionCount: "-1",
+	},
+	expectError: `negative backup-retention-count not valid`,
+}, {
+	about: "backup-s3-endpoint must be http",
+	config: controller.Config{
+		controller.BackupS3Endpoint: "ftp://example.com",
+		controller.BackupS3Bucket:   "backups",
+	},
+	expectError: `backup-s3-endpoint must be an http or https URL, got "ftp://example.com"`,
+}, {
+	about: "backup-s3-endpoint requires a bucket",
+	config: controller.Config{
+		controller.BackupS3Endpoint: "https://s3.example.com",
+	},
+	expectError: `backup-s3-bucket must be set when backup-s3-endpoint is`,
 }, {}}
 
 func (s *ConfigSuite) TestNewConfig(c *gc.C) {
@@ -756,3 +781,54 @@ func (s *ConfigSuite) TestJujuDBSnapChannel(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.JujuDBSnapChannel(), gc.Equals, "latest/candidate")
 }
+
+func (s *ConfigSuite) TestBackupSchedule(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupScheduleInterval(), gc.Equals, time.Duration(0))
+	c.Assert(cfg.BackupRetentionCount(), gc.Equals, controller.DefaultBackupRetentionCount)
+	c.Assert(cfg.BackupRetentionAge(), gc.Equals, time.Duration(0))
+	_, ok := cfg.BackupS3()
+	c.Assert(ok, jc.IsFalse)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-schedule-interval": "24h",
+			"backup-retention-count":   "3",
+			"backup-retention-age":     "168h",
+			"backup-s3-endpoint":       "https://s3.example.com",
+			"backup-s3-bucket":         "backups",
+			"backup-s3-access-key":     "access",
+			"backup-s3-secret-key":     "secret",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupScheduleInterval(), gc.Equals, 24*time.Hour)
+	c.Assert(cfg.BackupRetentionCount(), gc.Equals, 3)
+	c.Assert(cfg.BackupRetentionAge(), gc.Equals, 168*time.Hour)
+	s3, ok := cfg.BackupS3()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(s3, jc.DeepEquals, controller.BackupS3Config{
+		Endpoint:  "https://s3.example.com",
+		Bucket:    "backups",
+		Region:    controller.DefaultBackupS3Region,
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-retention-count": 0,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupRetentionCount(), gc.Equals, 0)
+}
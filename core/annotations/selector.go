@@ -0,0 +1,171 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package annotations
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// Selector selects entities by their annotations, which are treated as
+// labels. An entity matches a selector if it has every key=value pair
+// of the selector among its annotations.
+type Selector map[string]string
+
+// ParseSelector parses a selector of the form "key=value[,key=value...]".
+// A value containing commas or double quotes can be given as a double
+// quoted Go string, for example note="eu-west, eu-east".
+func ParseSelector(in string) (Selector, error) {
+	terms, err := splitTerms(in)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	selector := make(Selector)
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		parts := strings.SplitN(term, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.NotValidf("selector term %q: expected key=value", term)
+		}
+		key, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if strings.HasPrefix(value, `"`) {
+			if value, err = strconv.Unquote(value); err != nil {
+				return nil, errors.NotValidf("selector term %q: malformed quoted value", term)
+			}
+		}
+		if err := validateTerm(key, value); err != nil {
+			return nil, errors.NotValidf("selector term %q: %v", term, err)
+		}
+		if existing, ok := selector[key]; ok && existing != value {
+			return nil, errors.NotValidf("selector %q: conflicting values for %q", in, key)
+		}
+		selector[key] = value
+	}
+	if len(selector) == 0 {
+		return nil, errors.NotValidf("empty selector")
+	}
+	return selector, nil
+}
+
+// SelectorFromLabels returns the selector matching entities with all of
+// the given key=value labels, as returned by Labels.
+func SelectorFromLabels(labels []string) (Selector, error) {
+	selector := make(Selector)
+	for _, label := range labels {
+		parts := strings.SplitN(label, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.NotValidf("label %q: expected key=value", label)
+		}
+		key, value := parts[0], parts[1]
+		if err := validateTerm(key, value); err != nil {
+			return nil, errors.NotValidf("label %q: %v", label, err)
+		}
+		if existing, ok := selector[key]; ok && existing != value {
+			return nil, errors.NotValidf("labels %q: conflicting values for %q", labels, key)
+		}
+		selector[key] = value
+	}
+	return selector, nil
+}
+
+// validateTerm returns an error if key=value can't be a selector term.
+func validateTerm(key, value string) error {
+	if key == "" {
+		return errors.New("empty key")
+	}
+	if strings.Contains(key, ".") {
+		return errors.Errorf("key contains %q", ".")
+	}
+	if value == "" {
+		return errors.New("empty value")
+	}
+	return nil
+}
+
+// splitTerms splits in on the commas that aren't within a double quoted
+// value.
+func splitTerms(in string) ([]string, error) {
+	var terms []string
+	start := 0
+	quoted, escaped := false, false
+	for i, r := range in {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case !quoted && r == ',':
+			terms = append(terms, in[start:i])
+			start = i + 1
+		}
+	}
+	if quoted {
+		return nil, errors.NotValidf("selector %q: unterminated quoted value", in)
+	}
+	return append(terms, in[start:]), nil
+}
+
+// Matches returns whether the given annotations satisfy the selector.
+func (s Selector) Matches(annotations map[string]string) bool {
+	for key, value := range s {
+		if annotations[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Terms returns the selector's key=value pairs, sorted.
+func (s Selector) Terms() []string {
+	return Labels(s)
+}
+
+// String returns the selector in the form accepted by ParseSelector.
+func (s Selector) String() string {
+	return JoinLabels(s.Terms())
+}
+
+// JoinLabels returns the given key=value labels, as returned by Labels,
+// in the form accepted by ParseSelector, quoting values where needed.
+func JoinLabels(labels []string) string {
+	terms := make([]string, len(labels))
+	for i, label := range labels {
+		parts := strings.SplitN(label, "=", 2)
+		if len(parts) == 2 && needsQuoting(parts[1]) {
+			label = parts[0] + "=" + strconv.Quote(parts[1])
+		}
+		terms[i] = label
+	}
+	return strings.Join(terms, ",")
+}
+
+// needsQuoting returns whether the value would not be parsed back as
+// itself unless quoted.
+func needsQuoting(value string) bool {
+	return strings.ContainsAny(value, `,"`) || strings.TrimSpace(value) != value
+}
+
+// Labels returns the key=value labels of the given annotations, sorted.
+// Entities are indexed by their labels so that they can be found with a
+// selector. Annotations whose keys contain "=" can't be told apart from
+// others once labelled, so they are not labelled.
+func Labels(annotations map[string]string) []string {
+	labels := make([]string, 0, len(annotations))
+	for key, value := range annotations {
+		if value == "" || strings.Contains(key, "=") {
+			continue
+		}
+		labels = append(labels, key+"="+value)
+	}
+	sort.Strings(labels)
+	return labels
+}
@@ -0,0 +1,124 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package annotations_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	jujuannotations "github.com/juju/juju/core/annotations"
+	"github.com/juju/juju/testing"
+)
+
+type selectorSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&selectorSuite{})
+
+func (s *selectorSuite) TestParseSelector(c *gc.C) {
+	for i, test := range []struct {
+		in       string
+		expected jujuannotations.Selector
+		str      string
+	}{{
+		in:       "team=payments",
+		expected: jujuannotations.Selector{"team": "payments"},
+		str:      "team=payments",
+	}, {
+		in:       " team = payments , env=prod,",
+		expected: jujuannotations.Selector{"team": "payments", "env": "prod"},
+		str:      "env=prod,team=payments",
+	}, {
+		in:       "url=http://example.com/?a=b",
+		expected: jujuannotations.Selector{"url": "http://example.com/?a=b"},
+		str:      "url=http://example.com/?a=b",
+	}, {
+		in:       `regions="eu-west,eu-east",env=prod`,
+		expected: jujuannotations.Selector{"regions": "eu-west,eu-east", "env": "prod"},
+		str:      `env=prod,regions="eu-west,eu-east"`,
+	}, {
+		in:       `note=" say \"hi\" "`,
+		expected: jujuannotations.Selector{"note": ` say "hi" `},
+		str:      `note=" say \"hi\" "`,
+	}, {
+		in:       "team=payments,team=payments",
+		expected: jujuannotations.Selector{"team": "payments"},
+		str:      "team=payments",
+	}} {
+		c.Logf("test %d: %q", i, test.in)
+		selector, err := jujuannotations.ParseSelector(test.in)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(selector, jc.DeepEquals, test.expected)
+		c.Check(selector.String(), gc.Equals, test.str)
+	}
+}
+
+func (s *selectorSuite) TestParseSelectorInvalid(c *gc.C) {
+	for i, test := range []struct {
+		in  string
+		err string
+	}{{
+		in:  "",
+		err: "empty selector not valid",
+	}, {
+		in:  " , ",
+		err: "empty selector not valid",
+	}, {
+		in:  "team",
+		err: `selector term "team": expected key=value not valid`,
+	}, {
+		in:  "=payments",
+		err: `selector term "=payments": empty key not valid`,
+	}, {
+		in:  "team=",
+		err: `selector term "team=": empty value not valid`,
+	}, {
+		in:  "a.b=c",
+		err: `selector term "a.b=c": key contains "." not valid`,
+	}, {
+		in:  `regions="eu-west,eu-east`,
+		err: `selector "regions=\\"eu-west,eu-east": unterminated quoted value not valid`,
+	}, {
+		in:  `regions="eu-west"x`,
+		err: `selector term "regions=\\"eu-west\\"x": malformed quoted value not valid`,
+	}, {
+		in:  "team=a,team=b",
+		err: `selector "team=a,team=b": conflicting values for "team" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.in)
+		_, err := jujuannotations.ParseSelector(test.in)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *selectorSuite) TestSelectorFromLabels(c *gc.C) {
+	selector, err := jujuannotations.SelectorFromLabels([]string{"env=prod", "regions=eu-west,eu-east"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(selector, jc.DeepEquals, jujuannotations.Selector{"env": "prod", "regions": "eu-west,eu-east"})
+
+	_, err = jujuannotations.SelectorFromLabels([]string{"env"})
+	c.Check(err, gc.ErrorMatches, `label "env": expected key=value not valid`)
+}
+
+func (s *selectorSuite) TestMatches(c *gc.C) {
+	selector := jujuannotations.Selector{"team": "payments", "env": "prod"}
+	c.Check(selector.Matches(map[string]string{"team": "payments", "env": "prod", "x": "y"}), jc.IsTrue)
+	c.Check(selector.Matches(map[string]string{"team": "payments"}), jc.IsFalse)
+	c.Check(selector.Matches(map[string]string{"team": "payments", "env": "dev"}), jc.IsFalse)
+	c.Check(selector.Matches(nil), jc.IsFalse)
+}
+
+func (s *selectorSuite) TestLabels(c *gc.C) {
+	labels := jujuannotations.Labels(map[string]string{
+		"team":  "payments",
+		"env":   "prod",
+		"empty": "",
+		"a=b":   "c",
+	})
+	c.Check(labels, jc.DeepEquals, []string{"env=prod", "team=payments"})
+	c.Check(jujuannotations.Labels(nil), jc.DeepEquals, []string{})
+}
@@ -135,6 +135,7 @@ func (m *Model) summaryCopy() ModelSummary {
 	result.Admins = append([]string(nil), result.Admins...)
 	// Make a copy of the messages slice.
 	result.Messages = append([]ModelSummaryMessage(nil), result.Messages...)
+	result.BlockedApplications = append([]ModelSummaryMessage(nil), result.BlockedApplications...)
 	return result
 }
 
@@ -702,6 +703,7 @@ func (m *Model) updateSummary() {
 			overallStatus = StatusRed
 			messages = append(messages, ModelSummaryMessage{
 				Agent:   id,
+				Status:  string(st.Status),
 				Message: st.Message,
 			})
 		}
@@ -712,6 +714,7 @@ func (m *Model) updateSummary() {
 			overallStatus = StatusRed
 			messages = append(messages, ModelSummaryMessage{
 				Agent:   id,
+				Status:  string(st.Status),
 				Message: st.Message,
 			})
 		} else if st := unit.details.WorkloadStatus; st.Status == status.Blocked {
@@ -720,12 +723,27 @@ func (m *Model) updateSummary() {
 			}
 			messages = append(messages, ModelSummaryMessage{
 				Agent:   id,
+				Status:  string(st.Status),
 				Message: st.Message,
 			})
 		}
 	}
 	sort.Slice(messages, func(i, j int) bool { return messages[i].Agent < messages[j].Agent })
 
+	// Blocked applications are reported separately, and do not
+	// contribute to the overall status of the model.
+	var blocked []ModelSummaryMessage
+	for name, app := range m.applications {
+		if st := app.details.Status; st.Status == status.Blocked {
+			blocked = append(blocked, ModelSummaryMessage{
+				Agent:   name,
+				Status:  string(st.Status),
+				Message: st.Message,
+			})
+		}
+	}
+	sort.Slice(blocked, func(i, j int) bool { return blocked[i].Agent < blocked[j].Agent })
+
 	var admins []string
 	for user, access := range m.details.UserPermissions {
 		if access == permission.AdminAccess {
@@ -743,6 +761,8 @@ func (m *Model) updateSummary() {
 		Annotations: copyStringMap(m.details.Annotations),
 		Messages:    messages,
 
+		BlockedApplications: blocked,
+
 		Cloud:      m.details.Cloud,
 		Region:     m.details.CloudRegion,
 		Credential: m.details.CloudCredential,
@@ -39,6 +39,10 @@ type ModelSummary struct {
 	// Messages contain status message for any unit status in error.
 	Messages []ModelSummaryMessage
 
+	// BlockedApplications contain the status message of any application
+	// that is blocked. They do not affect the overall model status.
+	BlockedApplications []ModelSummaryMessage
+
 	Cloud        string
 	Region       string
 	Credential   string
@@ -56,6 +60,7 @@ type ModelSummary struct {
 // agent, and when that message was set.
 type ModelSummaryMessage struct {
 	Agent   string
+	Status  string
 	Message string
 }
 
@@ -267,7 +272,10 @@ func (s *ModelSummary) hash() (string, error) {
 	// Make a string representation of the summary, and hash that string.
 	var messages string
 	for _, m := range s.Messages {
-		messages += m.Agent + m.Message
+		messages += m.Agent + m.Status + m.Message
+	}
+	for _, m := range s.BlockedApplications {
+		messages += m.Agent + m.Status + m.Message
 	}
 	var annotations string
 	var keys []string
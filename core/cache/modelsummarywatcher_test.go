@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/testing"
 )
 
@@ -348,6 +349,44 @@ func (s *modelSummaryWatcherSuite) TestAddingApplicationIsChange(c *gc.C) {
 	})
 }
 
+func (s *modelSummaryWatcherSuite) TestBlockedApplicationIsReportedSeparately(c *gc.C) {
+	watcher := s.controller.WatchAllModels()
+	defer workertest.CleanKill(c, watcher)
+
+	changes := watcher.Changes()
+	// Discard the initial event.
+	_ = s.next(c, changes)
+
+	s.ProcessChange(c, cache.ApplicationChange{
+		ModelUUID: "model-2-uuid",
+		Name:      "foo",
+		Life:      life.Alive,
+		Status: status.StatusInfo{
+			Status:  status.Blocked,
+			Message: "missing relation",
+		},
+	}, s.events)
+
+	update := s.next(c, changes)
+	c.Assert(update, jc.DeepEquals, []cache.ModelSummary{
+		{
+			UUID:       "model-2-uuid",
+			Controller: "test-controller",
+			Namespace:  "bob",
+			Name:       "model-2",
+			Admins:     []string{"bob"},
+			// A blocked application does not change the model status.
+			Status: cache.StatusGreen,
+			BlockedApplications: []cache.ModelSummaryMessage{{
+				Agent:   "foo",
+				Status:  "blocked",
+				Message: "missing relation",
+			}},
+			ApplicationCount: 1,
+		},
+	})
+}
+
 func (s *modelSummaryWatcherSuite) TestRemovingApplicationIsChange(c *gc.C) {
 	watcher := s.controller.WatchAllModels()
 	defer workertest.CleanKill(c, watcher)
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/names/v4"
 	"github.com/juju/utils/arch"
 
+	"github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/core/instance"
 )
 
@@ -30,6 +31,7 @@ const (
 	RootDisk       = "root-disk"
 	RootDiskSource = "root-disk-source"
 	Tags           = "tags"
+	Labels         = "labels"
 	InstanceType   = "instance-type"
 	Spaces         = "spaces"
 	VirtType       = "virt-type"
@@ -80,6 +82,12 @@ type Value struct {
 	// empty list will override any default tags, where a nil list will not.
 	Tags *[]string `json:"tags,omitempty" yaml:"tags,omitempty"`
 
+	// Labels, if not nil, holds key=value annotations that the machine
+	// must have. Unlike tags, labels are set by Juju: existing machines
+	// are chosen by their annotations, and new machines are annotated
+	// with the labels. An empty list overrides any default labels.
+	Labels *[]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+
 	// InstanceType, if not nil, indicates that the specified cloud instance type
 	// be used. Only valid for clouds which support instance types.
 	InstanceType *string `json:"instance-type,omitempty" yaml:"instance-type,omitempty"`
@@ -198,6 +206,11 @@ func (v *Value) HasSpaces() bool {
 	return v.Spaces != nil && len(*v.Spaces) > 0
 }
 
+// HasLabels returns whether any label constraints were specified.
+func (v *Value) HasLabels() bool {
+	return v.Labels != nil && len(*v.Labels) > 0
+}
+
 // HasVirtType returns true if the constraints.Value specifies an virtual type.
 func (v *Value) HasVirtType() bool {
 	return v.VirtType != nil && *v.VirtType != ""
@@ -248,6 +261,10 @@ func (v Value) String() string {
 		s := strings.Join(*v.Tags, ",")
 		strs = append(strs, "tags="+s)
 	}
+	if v.Labels != nil {
+		s := annotations.JoinLabels(*v.Labels)
+		strs = append(strs, "labels="+s)
+	}
 	if v.Spaces != nil {
 		s := strings.Join(*v.Spaces, ",")
 		strs = append(strs, "spaces="+s)
@@ -298,6 +315,11 @@ func (v Value) GoString() string {
 	} else if v.Tags != nil {
 		values = append(values, "Tags: (*[]string)(nil)")
 	}
+	if v.Labels != nil && *v.Labels != nil {
+		values = append(values, fmt.Sprintf("Labels: %q", *v.Labels))
+	} else if v.Labels != nil {
+		values = append(values, "Labels: (*[]string)(nil)")
+	}
 	if v.Spaces != nil && *v.Spaces != nil {
 		values = append(values, fmt.Sprintf("Spaces: %q", *v.Spaces))
 	} else if v.Spaces != nil {
@@ -468,6 +490,8 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setRootDiskSource(str)
 	case Tags:
 		err = v.setTags(str)
+	case Labels:
+		err = v.setLabels(str)
 	case InstanceType:
 		err = v.setInstanceType(str)
 	case Spaces:
@@ -529,6 +553,12 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			v.RootDiskSource = &vstr
 		case Tags:
 			v.Tags, err = parseYamlStrings("tags", val)
+		case Labels:
+			var labels *[]string
+			labels, err = parseYamlStrings("labels", val)
+			if err == nil {
+				v.Labels, err = parseLabels(annotations.JoinLabels(*labels))
+			}
 		case Spaces:
 			var spaces *[]string
 			spaces, err = parseYamlStrings("spaces", val)
@@ -642,6 +672,28 @@ func (v *Value) setTags(str string) error {
 	return nil
 }
 
+func (v *Value) setLabels(str string) (err error) {
+	if v.Labels != nil {
+		return errors.Errorf("already set")
+	}
+	v.Labels, err = parseLabels(str)
+	return
+}
+
+// parseLabels returns the sorted key=value terms of the comma delimited
+// labels in s.
+func parseLabels(s string) (*[]string, error) {
+	if s == "" {
+		return &[]string{}, nil
+	}
+	selector, err := annotations.ParseSelector(s)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	labels := selector.Terms()
+	return &labels, nil
+}
+
 func (v *Value) setSpaces(str string) error {
 	if v.Spaces != nil {
 		return errors.Errorf("already set")
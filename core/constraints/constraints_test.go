@@ -352,6 +352,29 @@ var parseConstraintsTests = []struct {
 		args:    []string{"zones="},
 	},
 
+	// Labels
+	{
+		summary: "single label",
+		args:    []string{"labels=team=payments"},
+	}, {
+		summary: "multiple labels",
+		args:    []string{"labels=team=payments,env=prod"},
+	}, {
+		summary: "quoted label value",
+		args:    []string{`labels=regions="eu-west,eu-east",env=prod`},
+	}, {
+		summary: "no labels",
+		args:    []string{"labels="},
+	}, {
+		summary: "label without value",
+		args:    []string{"labels=team"},
+		err:     `bad "labels" constraint: selector term "team": expected key=value not valid`,
+	}, {
+		summary: "double set labels",
+		args:    []string{"labels=team=payments", "labels=env=prod"},
+		err:     `bad "labels" constraint: already set`,
+	},
+
 	// Everything at once.
 	{
 		summary: "kitchen sink together",
@@ -550,6 +573,19 @@ func (s *ConstraintsSuite) TestHasZones(c *gc.C) {
 	c.Check(con.HasZones(), jc.IsFalse)
 }
 
+func (s *ConstraintsSuite) TestHasLabels(c *gc.C) {
+	con := constraints.MustParse("labels=team=payments,env=prod")
+	c.Assert(con.Labels, gc.Not(gc.IsNil))
+	c.Check(*con.Labels, jc.DeepEquals, []string{"env=prod", "team=payments"})
+	c.Check(con.HasLabels(), jc.IsTrue)
+
+	con = constraints.MustParse("labels=")
+	c.Check(con.HasLabels(), jc.IsFalse)
+
+	con = constraints.MustParse("tags=foo")
+	c.Check(con.HasLabels(), jc.IsFalse)
+}
+
 func (s *ConstraintsSuite) TestHasRootDiskSource(c *gc.C) {
 	con := constraints.MustParse("root-disk-source=pilgrim")
 	c.Check(con.HasRootDiskSource(), jc.IsTrue)
@@ -639,6 +675,10 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Tags1", constraints.Value{Tags: nil}},
 	{"Tags2", constraints.Value{Tags: &[]string{}}},
 	{"Tags3", constraints.Value{Tags: &[]string{"foo", "bar"}}},
+	{"Labels1", constraints.Value{Labels: nil}},
+	{"Labels2", constraints.Value{Labels: &[]string{}}},
+	{"Labels3", constraints.Value{Labels: &[]string{"env=prod", "team=payments"}}},
+	{"Labels4", constraints.Value{Labels: &[]string{"env=prod", "regions=eu-west,eu-east"}}},
 	{"Spaces1", constraints.Value{Spaces: nil}},
 	{"Spaces2", constraints.Value{Spaces: &[]string{}}},
 	{"Spaces3", constraints.Value{Spaces: &[]string{"space1", "^space2"}}},
@@ -25,5 +25,5 @@ func (*ImportTest) TestImports(c *gc.C) {
 
 	// This package should only depend on other core packages.
 	// If this test fails with a non-core package, please check the dependencies.
-	c.Assert(found, jc.SameContents, []string{"core/instance", "core/life", "core/status"})
+	c.Assert(found, jc.SameContents, []string{"core/annotations", "core/instance", "core/life", "core/status"})
 }
@@ -14,6 +14,11 @@ const (
 	// MachineScope is a special scope name that is used
 	// for machine placement directives (e.g. --to 0).
 	MachineScope = "#"
+
+	// LabelScope is a special scope name that is used for placement
+	// on an existing machine selected by its annotations
+	// (e.g. --to label:team=payments).
+	LabelScope = "label"
 )
 
 var ErrPlacementScopeMissing = fmt.Errorf("placement scope missing")
@@ -22,8 +27,8 @@ var ErrPlacementScopeMissing = fmt.Errorf("placement scope missing")
 // and a value that is scope-specific.
 type Placement struct {
 	// Scope is the scope of the placement directive. Scope may
-	// be a container type (lxd, kvm), instance.MachineScope, or
-	// an environment name.
+	// be a container type (lxd, kvm), instance.MachineScope,
+	// instance.LabelScope, or an environment name.
 	//
 	// If Scope is empty, then it must be inferred from the context.
 	Scope string `json:"scope"`
@@ -31,7 +36,8 @@ type Placement struct {
 	// Directive is a scope-specific placement directive.
 	//
 	// For MachineScope or a container scope, this may be empty or
-	// the ID of an existing machine.
+	// the ID of an existing machine. For LabelScope, this is an
+	// annotation selector of the form key=value[,key=value...].
 	Directive string `json:"directive"`
 }
 
@@ -63,6 +69,9 @@ func ParsePlacement(directive string) (*Placement, error) {
 		if (scope == MachineScope || isContainerType(scope)) && !names.IsValidMachine(directive) {
 			return nil, fmt.Errorf("invalid value %q for %q scope: expected machine-id", directive, scope)
 		}
+		if scope == LabelScope && directive == "" {
+			return nil, fmt.Errorf("invalid value %q for %q scope: expected key=value", directive, scope)
+		}
 		return &Placement{Scope: scope, Directive: directive}, nil
 	}
 	if names.IsValidMachine(directive) {
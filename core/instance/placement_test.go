@@ -55,6 +55,13 @@ func (s *PlacementSuite) TestParsePlacement(c *gc.C) {
 		arg:             "non:standard",
 		expectScope:     "non",
 		expectDirective: "standard",
+	}, {
+		arg:             "label:team=payments,env=prod",
+		expectScope:     instance.LabelScope,
+		expectDirective: "team=payments,env=prod",
+	}, {
+		arg: "label:",
+		err: `invalid value "" for "label" scope: expected key=value`,
 	}}
 
 	for i, t := range parsePlacementTests {
@@ -51,6 +51,9 @@ type SerializedModel struct {
 
 	// Resources represents all the resources in use in the model.
 	Resources []SerializedModelResource
+
+	// Entities holds the number of each kind of entity in the model.
+	Entities map[string]int
 }
 
 // SerializedModelResource defines the resource revisions for a
@@ -85,3 +88,30 @@ func (i *ModelInfo) Validate() error {
 	}
 	return nil
 }
+
+// PhaseTime records when a migration entered a phase.
+type PhaseTime struct {
+	Phase Phase
+	Time  time.Time
+}
+
+// Progress holds the counts reported by the migrationmaster worker as
+// a migration proceeds.
+type Progress struct {
+	// ExportedEntities holds the number of each kind of entity
+	// (machines, applications, units, ...) exported from the source
+	// controller.
+	ExportedEntities map[string]int
+
+	// ImportedEntities holds the number of each kind of entity
+	// imported into the target controller.
+	ImportedEntities map[string]int
+
+	// LogsTransferred holds the number of log records transferred to
+	// the target controller so far.
+	LogsTransferred int
+
+	// LastLogTime holds the timestamp of the last log record
+	// transferred.
+	LastLogTime time.Time
+}
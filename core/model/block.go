@@ -3,6 +3,8 @@
 
 package model
 
+import "github.com/juju/errors"
+
 // BlockType values define model block type.
 type BlockType string
 
@@ -16,3 +18,46 @@ const (
 	// BlockChange type identifies change blocks.
 	BlockChange BlockType = "BlockChange"
 )
+
+// BlockOperation values define the categories of operation that may be
+// blocked on a single application, machine or relation.
+type BlockOperation string
+
+const (
+	// BlockOperationConfig identifies operations that change the
+	// configuration, constraints or bindings of an entity.
+	BlockOperationConfig BlockOperation = "config"
+
+	// BlockOperationRefresh identifies operations that upgrade the
+	// charm of an application.
+	BlockOperationRefresh BlockOperation = "refresh"
+
+	// BlockOperationScale identifies operations that add or remove
+	// units of an application.
+	BlockOperationScale BlockOperation = "scale"
+
+	// BlockOperationRemove identifies operations that remove an
+	// entity from the model.
+	BlockOperationRemove BlockOperation = "remove"
+)
+
+// AllBlockOperations returns all of the operation categories that may
+// be blocked on an entity.
+func AllBlockOperations() []BlockOperation {
+	return []BlockOperation{
+		BlockOperationConfig,
+		BlockOperationRefresh,
+		BlockOperationScale,
+		BlockOperationRemove,
+	}
+}
+
+// Validate returns an error if the operation is not a known category.
+func (op BlockOperation) Validate() error {
+	for _, known := range AllBlockOperations() {
+		if op == known {
+			return nil
+		}
+	}
+	return errors.NotValidf("block operation %q", string(op))
+}
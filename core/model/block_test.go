@@ -0,0 +1,23 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/model"
+)
+
+type blockSuite struct{}
+
+var _ = gc.Suite(&blockSuite{})
+
+func (s *blockSuite) TestValidateBlockOperation(c *gc.C) {
+	for _, op := range model.AllBlockOperations() {
+		c.Check(op.Validate(), jc.ErrorIsNil)
+	}
+	c.Check(model.BlockOperation("destroy").Validate(), gc.ErrorMatches, `block operation "destroy" not valid`)
+	c.Check(model.BlockOperation("").Validate(), gc.ErrorMatches, `block operation "" not valid`)
+}
@@ -4,9 +4,12 @@
 package model
 
 import (
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/constraints"
 )
 
 // TODO (manadart 2019-04-21) Change the nomenclature here to indicate "branch"
@@ -104,3 +107,108 @@ type GenerationSummaries = map[string]Generation
 // GenerationCommits is a type alias for a representation of each commit.
 // Keyed by the generation id
 type GenerationCommits = []GenerationCommit
+
+// RevertConflict describes a configuration change made by a committed
+// generation that could not be reverted.
+type RevertConflict struct {
+	// Application is the name of the application with the conflicting change.
+	Application string `yaml:"application"`
+
+	// Key is the charm configuration key that could not be reverted.
+	Key string `yaml:"key"`
+
+	// Reason describes why the change could not be reverted.
+	Reason string `yaml:"reason"`
+}
+
+// TrackPolicy describes how units of an application are selected to track a
+// branch. The zero value selects all of the application's units.
+type TrackPolicy struct {
+	// NumUnits is the number of additional units to track.
+	NumUnits int
+
+	// Percent is the percentage of the application's units that should
+	// track the branch, including those already tracking it.
+	Percent int
+
+	// PerZone indicates that NumUnits or Percent apply separately to the
+	// units in each availability zone, rather than to all of the units.
+	PerZone bool
+
+	// Annotations, if not empty, restricts selection to units that have
+	// all of these annotation values.
+	Annotations map[string]string
+
+	// Constraints, if not empty, restricts selection to units on machines
+	// whose hardware satisfies these constraints. Only constraints
+	// describing the hardware of a provisioned machine, or the zone it is
+	// in, can be used.
+	Constraints constraints.Value
+}
+
+// unsupportedTrackConstraints are the constraints that don't describe
+// the hardware of a provisioned machine, so can't be used to select
+// units to track a branch.
+var unsupportedTrackConstraints = []string{
+	constraints.Container,
+	constraints.InstanceType,
+	constraints.Labels,
+	constraints.RootDiskSource,
+	constraints.Spaces,
+	constraints.VirtType,
+}
+
+// Validate returns an error if the policy is not valid.
+func (p TrackPolicy) Validate() error {
+	if p.NumUnits < 0 {
+		return errors.NotValidf("negative number of units")
+	}
+	if p.Percent < 0 || p.Percent > 100 {
+		return errors.NotValidf("percentage %d", p.Percent)
+	}
+	if p.NumUnits > 0 && p.Percent > 0 {
+		return errors.NotValidf("number of units with percentage")
+	}
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(unsupportedTrackConstraints)
+	unsupported, err := validator.Validate(p.Constraints)
+	if err != nil {
+		return errors.NewNotValid(err, "invalid constraints")
+	}
+	if len(unsupported) > 0 {
+		return errors.NotValidf("selecting units by %s", strings.Join(unsupported, ", "))
+	}
+	return nil
+}
+
+// BranchProgression describes progressive tracking of a branch, where the
+// percentage of each application's units tracking the branch increases in
+// steps, for as long as all of the tracking units remain healthy.
+type BranchProgression struct {
+	// Policy selects the units to track. Its Percent is increased by
+	// StepPercent at each step, until all units track the branch.
+	Policy TrackPolicy
+
+	// StepPercent is the percentage of units added at each step.
+	StepPercent int
+
+	// Interval is the time between steps.
+	Interval time.Duration
+}
+
+// Validate returns an error if the progression is not valid.
+func (p BranchProgression) Validate() error {
+	if err := p.Policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if p.Policy.NumUnits > 0 {
+		return errors.NotValidf("progressive tracking by number of units")
+	}
+	if p.StepPercent <= 0 || p.StepPercent > 100 {
+		return errors.NotValidf("step percentage %d", p.StepPercent)
+	}
+	if p.Interval <= 0 {
+		return errors.NotValidf("step interval %v", p.Interval)
+	}
+	return nil
+}
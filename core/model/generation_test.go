@@ -0,0 +1,67 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/model"
+)
+
+type GenerationSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&GenerationSuite{})
+
+func (*GenerationSuite) TestTrackPolicyValidate(c *gc.C) {
+	for _, t := range []struct {
+		policy model.TrackPolicy
+		valid  bool
+	}{
+		{model.TrackPolicy{}, true},
+		{model.TrackPolicy{NumUnits: 2}, true},
+		{model.TrackPolicy{Percent: 10, PerZone: true}, true},
+		{model.TrackPolicy{Annotations: map[string]string{"canary": "true"}}, true},
+		{model.TrackPolicy{NumUnits: -1}, false},
+		{model.TrackPolicy{Percent: 101}, false},
+		{model.TrackPolicy{NumUnits: 2, Percent: 10}, false},
+		{model.TrackPolicy{Constraints: constraints.MustParse("arch=arm64 mem=4G zones=a,b")}, true},
+		{model.TrackPolicy{Constraints: constraints.MustParse("instance-type=m1.small")}, false},
+		{model.TrackPolicy{Constraints: constraints.MustParse("spaces=db")}, false},
+	} {
+		err := t.policy.Validate()
+		if t.valid {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, jc.Satisfies, errors.IsNotValid)
+		}
+	}
+}
+
+func (*GenerationSuite) TestBranchProgressionValidate(c *gc.C) {
+	for _, t := range []struct {
+		progression model.BranchProgression
+		valid       bool
+	}{
+		{model.BranchProgression{StepPercent: 10, Interval: time.Hour}, true},
+		{model.BranchProgression{Policy: model.TrackPolicy{Percent: 10}, StepPercent: 20, Interval: time.Hour}, true},
+		{model.BranchProgression{Policy: model.TrackPolicy{NumUnits: 1}, StepPercent: 10, Interval: time.Hour}, false},
+		{model.BranchProgression{StepPercent: 0, Interval: time.Hour}, false},
+		{model.BranchProgression{StepPercent: 10}, false},
+	} {
+		err := t.progression.Validate()
+		if t.valid {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, jc.Satisfies, errors.IsNotValid)
+		}
+	}
+}
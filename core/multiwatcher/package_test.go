@@ -26,6 +26,7 @@ func (*ImportTest) TestImports(c *gc.C) {
 	// This package should only depend on other core packages.
 	// If this test fails with a non-core package, please check the dependencies.
 	c.Assert(found, jc.SameContents, []string{
+		"core/annotations",
 		"core/constraints",
 		"core/instance",
 		"core/life",
@@ -0,0 +1,53 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package quota
+
+import (
+	"github.com/juju/errors"
+)
+
+// Resources describes an amount of the resources consumed by models.
+// When used as a quota, a zero value means that the resource is not
+// limited.
+type Resources struct {
+	Machines     int
+	Units        int
+	Applications int
+	StorageMiB   uint64
+}
+
+// IsZero returns true if none of the resources are set.
+func (r Resources) IsZero() bool {
+	return r == Resources{}
+}
+
+// Add returns the sum of the resources.
+func (r Resources) Add(other Resources) Resources {
+	return Resources{
+		Machines:     r.Machines + other.Machines,
+		Units:        r.Units + other.Units,
+		Applications: r.Applications + other.Applications,
+		StorageMiB:   r.StorageMiB + other.StorageMiB,
+	}
+}
+
+// CheckResources returns an error satisfying errors.IsQuotaLimitExceeded
+// if adding the requested resources to those in use would exceed any of
+// the limits. Resources that are not requested are not checked, so that
+// existing usage over a lowered limit does not block unrelated changes.
+// The scope describes the owner of the limits in the error message.
+func CheckResources(scope string, limits, inUse, requested Resources) error {
+	total := inUse.Add(requested)
+	switch {
+	case requested.Machines > 0 && limits.Machines > 0 && total.Machines > limits.Machines:
+		return errors.QuotaLimitExceededf("%s quota of %d machines exceeded", scope, limits.Machines)
+	case requested.Units > 0 && limits.Units > 0 && total.Units > limits.Units:
+		return errors.QuotaLimitExceededf("%s quota of %d units exceeded", scope, limits.Units)
+	case requested.Applications > 0 && limits.Applications > 0 && total.Applications > limits.Applications:
+		return errors.QuotaLimitExceededf("%s quota of %d applications exceeded", scope, limits.Applications)
+	case requested.StorageMiB > 0 && limits.StorageMiB > 0 && total.StorageMiB > limits.StorageMiB:
+		return errors.QuotaLimitExceededf("%s quota of %dMiB storage exceeded", scope, limits.StorageMiB)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package quota_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/quota"
+)
+
+var _ = gc.Suite(&ResourcesSuite{})
+
+type ResourcesSuite struct {
+}
+
+func (s *ResourcesSuite) TestAdd(c *gc.C) {
+	r := quota.Resources{Machines: 1, Units: 2, Applications: 3, StorageMiB: 4}
+	c.Assert(r.Add(r), gc.Equals, quota.Resources{Machines: 2, Units: 4, Applications: 6, StorageMiB: 8})
+	c.Assert(r.IsZero(), jc.IsFalse)
+	c.Assert(quota.Resources{}.IsZero(), jc.IsTrue)
+}
+
+func (s *ResourcesSuite) TestCheckWithinLimits(c *gc.C) {
+	limits := quota.Resources{Machines: 2, Units: 2}
+	inUse := quota.Resources{Machines: 1, Units: 1, Applications: 10}
+	err := quota.CheckResources("model", limits, inUse, quota.Resources{Machines: 1, Units: 1, Applications: 1})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ResourcesSuite) TestCheckExceeded(c *gc.C) {
+	limits := quota.Resources{Machines: 2, Units: 2, Applications: 2, StorageMiB: 1024}
+	inUse := quota.Resources{Machines: 2, Units: 2, Applications: 2, StorageMiB: 1024}
+	for i, test := range []struct {
+		requested quota.Resources
+		expect    string
+	}{{
+		quota.Resources{Machines: 1},
+		`user "bob" quota of 2 machines exceeded`,
+	}, {
+		quota.Resources{Units: 1},
+		`user "bob" quota of 2 units exceeded`,
+	}, {
+		quota.Resources{Applications: 1},
+		`user "bob" quota of 2 applications exceeded`,
+	}, {
+		quota.Resources{StorageMiB: 1},
+		`user "bob" quota of 1024MiB storage exceeded`,
+	}} {
+		c.Logf("test %d: %+v", i, test.requested)
+		err := quota.CheckResources(`user "bob"`, limits, inUse, test.requested)
+		c.Check(err, gc.ErrorMatches, test.expect)
+		c.Check(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+	}
+}
+
+func (s *ResourcesSuite) TestCheckOnlyRequested(c *gc.C) {
+	// Usage over a lowered limit doesn't block other resources.
+	limits := quota.Resources{Machines: 1}
+	inUse := quota.Resources{Machines: 5}
+	err := quota.CheckResources("model", limits, inUse, quota.Resources{Units: 1})
+	c.Assert(err, jc.ErrorIsNil)
+}
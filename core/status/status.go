@@ -28,6 +28,11 @@ type StatusInfo struct {
 	Since   *time.Time
 }
 
+// DataUser is the status data key holding the name of the user on
+// whose behalf a status was changed, when the change was not made by
+// the entity's own agent.
+const DataUser = "user"
+
 // StatusSetter represents a type whose status can be set.
 type StatusSetter interface {
 	SetStatus(StatusInfo) error
@@ -256,6 +256,17 @@ const (
 	// CharmhubURLKey is the key for the url to use for charmhub API calls
 	CharmhubURLKey = "charmhub-url"
 
+	// ModelTTLKey is the time after which the model expires and is
+	// destroyed automatically, eg "24h", measured from when it was set
+	// or the model was created. Models without a TTL never expire. It
+	// cannot be given a default value, so it only applies to the models
+	// it is set on.
+	ModelTTLKey = "model-ttl"
+
+	// ExpiryDestroyStorageKey is whether the storage of a model is
+	// destroyed, rather than released, when the model expires.
+	ExpiryDestroyStorageKey = "expiry-destroy-storage"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -648,6 +659,14 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[ModelTTLKey].(string); ok && v != "" {
+		if ttl, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid model TTL in model configuration")
+		} else if ttl < 0 {
+			return errors.Errorf("model TTL %v cannot be negative", ttl)
+		}
+	}
+
 	if v, ok := cfg.defined[UpdateStatusHookInterval].(string); ok {
 		if f, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid update status hook interval in model configuration")
@@ -1414,6 +1433,21 @@ func (c *Config) UpdateStatusHookInterval() time.Duration {
 	return val
 }
 
+// ModelTTL returns how long after it is set the model expires. It is
+// zero if the model never expires.
+func (c *Config) ModelTTL() time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.asString(ModelTTLKey))
+	return val
+}
+
+// ExpiryDestroyStorage returns whether the storage of the model is
+// destroyed, rather than released, when the model expires.
+func (c *Config) ExpiryDestroyStorage() bool {
+	value, _ := c.defined[ExpiryDestroyStorageKey].(bool)
+	return value
+}
+
 // EgressSubnets are the source addresses from which traffic from this model
 // originates if the model is deployed such that NAT or similar is in use.
 func (c *Config) EgressSubnets() []string {
@@ -1591,6 +1625,8 @@ var alwaysOptional = schema.Defaults{
 	DefaultSpace:                  schema.Omit,
 	LXDSnapChannel:                schema.Omit,
 	CharmhubURLKey:                schema.Omit,
+	ModelTTLKey:                   schema.Omit,
+	ExpiryDestroyStorageKey:       schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -2106,4 +2142,14 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	ModelTTLKey: {
+		Description: "The time after which the model expires and is destroyed, measured from when it is set, in human-readable time format (the model never expires if not set)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ExpiryDestroyStorageKey: {
+		Description: "Whether to destroy, rather than release, the storage of the model when it expires",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 }
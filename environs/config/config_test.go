@@ -1373,6 +1373,33 @@ func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigValue(c *gc.C) {
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 30*time.Minute)
 }
 
+func (s *ConfigSuite) TestModelTTLConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ModelTTL(), gc.Equals, time.Duration(0))
+	c.Assert(cfg.ExpiryDestroyStorage(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestModelTTLConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"model-ttl":              "36h",
+		"expiry-destroy-storage": true,
+	})
+	c.Assert(cfg.ModelTTL(), gc.Equals, 36*time.Hour)
+	c.Assert(cfg.ExpiryDestroyStorage(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestModelTTLConfigInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"model-ttl": "a while",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid model TTL in model configuration: .*`)
+
+	_, err = config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"model-ttl": "-1h",
+	}))
+	c.Assert(err, gc.ErrorMatches, `model TTL -1h0m0s cannot be negative`)
+}
+
 func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		"egress-subnets": "10.0.0.1/32, 192.168.1.1/16",
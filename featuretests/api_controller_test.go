@@ -47,6 +47,7 @@ func (s *ControllerSuite) TestWatchModelSummaries(c *gc.C) {
 	c.Assert(summaries, jc.DeepEquals, []params.ModelAbstract{
 		{
 			UUID:       "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Namespace:  "admin",
 			Name:       "controller",
 			Admins:     []string{"admin"},
 			Cloud:      "dummy",
@@ -71,6 +72,7 @@ func (s *ControllerSuite) TestWatchAllModelSummaries(c *gc.C) {
 	c.Assert(summaries, jc.DeepEquals, []params.ModelAbstract{
 		{
 			UUID:       "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Namespace:  "admin",
 			Name:       "controller",
 			Admins:     []string{"admin"},
 			Cloud:      "dummy",
@@ -0,0 +1,34 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/juju/cmd/juju/status"
+)
+
+// statusschema writes the JSON Schema for the versioned status output
+// to the file named by its only argument.
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "Expected one argument: filepath of json schema to save.")
+		os.Exit(1)
+	}
+
+	jsonSchema, err := json.MarshalIndent(status.JSONSchema(), "", "    ")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	err = ioutil.WriteFile(os.Args[1], append(jsonSchema, '\n'), 0644)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/description/v2"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// MaxClockSkew is the largest difference between the clocks of the
+// source and target controllers which a migration dry run accepts
+// without a warning.
+const MaxClockSkew = time.Minute
+
+// DescriptionCheckBackend defines the state queries used to check a
+// model description against a controller.
+type DescriptionCheckBackend interface {
+	Cloud(name string) (cloud.Cloud, error)
+	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
+	ProviderRegistered(cloudType string) bool
+}
+
+// DescriptionCheckShim wraps a *state.State for the controller model to
+// implement DescriptionCheckBackend.
+func DescriptionCheckShim(st *state.State) DescriptionCheckBackend {
+	return descriptionCheckShim{st}
+}
+
+// descriptionCheckShim is untested, but is simple enough to be verified by
+// inspection.
+type descriptionCheckShim struct {
+	*state.State
+}
+
+// ProviderRegistered implements DescriptionCheckBackend.
+func (descriptionCheckShim) ProviderRegistered(cloudType string) bool {
+	_, err := environs.Provider(cloudType)
+	return err == nil
+}
+
+// CheckModelDescription checks the serialized model for problems known
+// to stop it being imported into the controller, such as an unknown
+// cloud or a mismatched credential. The model isn't imported, so an
+// import may still fail for reasons not checked here. Problems which
+// would make the import fail are reported as blockers; problems which
+// wouldn't, such as applications deployed with a different series to
+// their charm, are reported as warnings. An error is returned only if
+// the checks couldn't be run.
+func CheckModelDescription(backend DescriptionCheckBackend, bytes []byte) (*PrecheckReport, error) {
+	report := &PrecheckReport{}
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		report.AddBlocker("model description can't be read: %v", err)
+		return report, nil
+	}
+	if model.Type() != "" {
+		if _, err := state.ParseModelType(model.Type()); err != nil {
+			report.AddBlocker("model type %q not supported", model.Type())
+		}
+	}
+	if _, err := config.New(config.NoDefaults, model.Config()); err != nil {
+		report.AddBlocker("model config not valid: %v", err)
+	}
+	if err := checkModelCloud(backend, model, report); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkModelCredential(backend, model, report); err != nil {
+		return nil, errors.Trace(err)
+	}
+	checkSeries(model, report)
+	return report, nil
+}
+
+func checkModelCloud(backend DescriptionCheckBackend, model description.Model, report *PrecheckReport) error {
+	modelCloud, err := backend.Cloud(model.Cloud())
+	if errors.IsNotFound(err) {
+		report.AddBlocker("cloud %q not known", model.Cloud())
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "retrieving cloud")
+	}
+	if !backend.ProviderRegistered(modelCloud.Type) {
+		report.AddBlocker("cloud type %q not supported", modelCloud.Type)
+	}
+	if region := model.CloudRegion(); region != "" {
+		if _, err := cloud.RegionByName(modelCloud.Regions, region); err != nil {
+			report.AddBlocker("cloud %q has no region %q", model.Cloud(), region)
+		}
+	}
+	return nil
+}
+
+func checkModelCredential(backend DescriptionCheckBackend, model description.Model, report *PrecheckReport) error {
+	creds := model.CloudCredential()
+	if creds == nil {
+		return nil
+	}
+	credID := creds.Cloud() + "/" + creds.Owner() + "/" + creds.Name()
+	if !names.IsValidCloudCredential(credID) {
+		report.AddBlocker("cloud credential ID %q not valid", credID)
+		return nil
+	}
+	// A credential which isn't on the target controller is added
+	// by the import; one which is must match the model's.
+	existing, err := backend.CloudCredential(names.NewCloudCredentialTag(credID))
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "retrieving cloud credential")
+	}
+	if existing.AuthType != creds.AuthType() {
+		report.AddBlocker("credential %q auth type doesn't match (%q != %q)",
+			credID, creds.AuthType(), existing.AuthType)
+	}
+	if !reflect.DeepEqual(existing.Attributes, creds.Attributes()) {
+		report.AddBlocker("credential %q attributes don't match", credID)
+	}
+	if existing.Revoked {
+		report.AddBlocker("credential %q is revoked", credID)
+	}
+	return nil
+}
+
+// checkSeries warns about applications whose series doesn't match
+// their charm, or the machines their units are on.
+func checkSeries(model description.Model, report *PrecheckReport) {
+	machineSeries := make(map[string]string)
+	var addMachines func([]description.Machine)
+	addMachines = func(machines []description.Machine) {
+		for _, machine := range machines {
+			machineSeries[machine.Id()] = machine.Series()
+			addMachines(machine.Containers())
+		}
+	}
+	addMachines(model.Machines())
+
+	apps := model.Applications()
+	sort.Slice(apps, func(i, j int) bool { return apps[i].Name() < apps[j].Name() })
+	for _, app := range apps {
+		appSeries := app.Series()
+		if appSeries == "" {
+			continue
+		}
+		curl, err := charm.ParseURL(app.CharmURL())
+		if err != nil {
+			report.AddBlocker("application %s has invalid charm URL %q", app.Name(), app.CharmURL())
+			continue
+		}
+		if curl.Series != "" && curl.Series != appSeries {
+			report.AddWarning("application %s has series %q but its charm %s is for %q",
+				app.Name(), appSeries, curl, curl.Series)
+		}
+		for _, unit := range app.Units() {
+			machineID := unit.Machine().Id()
+			series, ok := machineSeries[machineID]
+			if !ok || series == "" || series == appSeries {
+				continue
+			}
+			report.AddWarning("unit %s is on machine %s with series %q, not the application's series %q",
+				unit.Name(), machineID, series, appSeries)
+		}
+	}
+}
+
+// CheckClockSkew warns if the target controller's clock differs from
+// the source controller's by more than MaxClockSkew. The target time
+// must have been read between before and after on the source
+// controller's clock.
+func CheckClockSkew(report *PrecheckReport, before, after, target time.Time) {
+	var skew time.Duration
+	if target.Before(before) {
+		skew = before.Sub(target)
+	} else if target.After(after) {
+		skew = target.Sub(after)
+	}
+	if skew > MaxClockSkew {
+		report.AddWarning("target controller clock differs from source controller by %v",
+			skew.Round(time.Second))
+	}
+}
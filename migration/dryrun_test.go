@@ -0,0 +1,239 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/description/v2"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type CheckModelDescriptionSuite struct {
+	testing.BaseSuite
+	backend *fakeImportCheckBackend
+}
+
+var _ = gc.Suite(&CheckModelDescriptionSuite{})
+
+func (s *CheckModelDescriptionSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &fakeImportCheckBackend{
+		clouds: map[string]cloud.Cloud{
+			"dummy": {
+				Name:    "dummy",
+				Type:    "dummy",
+				Regions: []cloud.Region{{Name: "dummy-region"}},
+			},
+		},
+		credentials: make(map[string]state.Credential),
+		providers:   []string{"dummy"},
+	}
+}
+
+func (s *CheckModelDescriptionSuite) newModel() description.Model {
+	m := description.NewModel(description.ModelArgs{
+		Type:        "iaas",
+		Owner:       names.NewUserTag("admin"),
+		Config:      testing.FakeConfig(),
+		Cloud:       "dummy",
+		CloudRegion: "dummy-region",
+	})
+	m.SetStatus(description.StatusArgs{Value: "available"})
+	m.SetCloudCredential(description.CloudCredentialArgs{
+		Owner:      names.NewUserTag("admin"),
+		Cloud:      names.NewCloudTag("dummy"),
+		Name:       "default",
+		AuthType:   "userpass",
+		Attributes: map[string]string{"user": "admin"},
+	})
+	return m
+}
+
+func (s *CheckModelDescriptionSuite) checkDescription(c *gc.C, m description.Model) *migration.PrecheckReport {
+	bytes, err := description.Serialize(m)
+	c.Assert(err, jc.ErrorIsNil)
+	report, err := migration.CheckModelDescription(s.backend, bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	return report
+}
+
+func (s *CheckModelDescriptionSuite) TestSuccess(c *gc.C) {
+	report := s.checkDescription(c, s.newModel())
+	c.Check(report.Blockers, gc.HasLen, 0)
+	c.Check(report.Warnings, gc.HasLen, 0)
+}
+
+func (s *CheckModelDescriptionSuite) TestBadDescription(c *gc.C) {
+	report, err := migration.CheckModelDescription(s.backend, []byte("not: [a, model"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Blockers, gc.HasLen, 1)
+	c.Check(report.Blockers[0], gc.Matches, "model description can't be read: .*")
+}
+
+func (s *CheckModelDescriptionSuite) TestUnknownCloud(c *gc.C) {
+	delete(s.backend.clouds, "dummy")
+	report := s.checkDescription(c, s.newModel())
+	c.Check(report.Blockers, jc.DeepEquals, []string{
+		`cloud "dummy" not known`,
+	})
+}
+
+func (s *CheckModelDescriptionSuite) TestUnsupportedProviderAndRegion(c *gc.C) {
+	s.backend.providers = nil
+	s.backend.clouds["dummy"] = cloud.Cloud{Name: "dummy", Type: "dummy"}
+	report := s.checkDescription(c, s.newModel())
+	c.Check(report.Blockers, jc.DeepEquals, []string{
+		`cloud type "dummy" not supported`,
+		`cloud "dummy" has no region "dummy-region"`,
+	})
+}
+
+func (s *CheckModelDescriptionSuite) TestCloudError(c *gc.C) {
+	s.backend.cloudErr = errors.New("boom")
+	bytes, err := description.Serialize(s.newModel())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = migration.CheckModelDescription(s.backend, bytes)
+	c.Assert(err, gc.ErrorMatches, "retrieving cloud: boom")
+}
+
+func (s *CheckModelDescriptionSuite) TestCredentialMismatch(c *gc.C) {
+	s.backend.credentials["dummy/admin/default"] = credential("oauth", map[string]string{"token": "x"}, true)
+	report := s.checkDescription(c, s.newModel())
+	c.Check(report.Blockers, jc.DeepEquals, []string{
+		`credential "dummy/admin/default" auth type doesn't match ("userpass" != "oauth")`,
+		`credential "dummy/admin/default" attributes don't match`,
+		`credential "dummy/admin/default" is revoked`,
+	})
+}
+
+func (s *CheckModelDescriptionSuite) TestCredentialMatches(c *gc.C) {
+	s.backend.credentials["dummy/admin/default"] = credential("userpass", map[string]string{"user": "admin"}, false)
+	report := s.checkDescription(c, s.newModel())
+	c.Check(report.Blockers, gc.HasLen, 0)
+}
+
+func (s *CheckModelDescriptionSuite) TestSeriesMismatches(c *gc.C) {
+	m := s.newModel()
+	status := description.StatusArgs{Value: "active"}
+	tools := description.AgentToolsArgs{Version: version.MustParseBinary("2.8.0-focal-amd64")}
+	for _, args := range []description.MachineArgs{
+		{Id: names.NewMachineTag("0"), Series: "focal"},
+		{Id: names.NewMachineTag("1"), Series: "bionic"},
+	} {
+		machine := m.AddMachine(args)
+		machine.SetStatus(status)
+		machine.SetTools(tools)
+	}
+	app := m.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("app"),
+		Series:   "focal",
+		CharmURL: "cs:bionic/app-1",
+	})
+	app.SetStatus(status)
+	for i, machine := range []string{"0", "1"} {
+		unit := app.AddUnit(description.UnitArgs{
+			Tag:     names.NewUnitTag(fmt.Sprintf("app/%d", i)),
+			Machine: names.NewMachineTag(machine),
+		})
+		unit.SetAgentStatus(status)
+		unit.SetWorkloadStatus(status)
+		unit.SetTools(tools)
+	}
+	other := m.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("other"),
+		Series:   "focal",
+		CharmURL: "cs:focal/other-1",
+	})
+	other.SetStatus(status)
+
+	report := s.checkDescription(c, m)
+	c.Check(report.Blockers, gc.HasLen, 0)
+	c.Check(report.Warnings, jc.DeepEquals, []string{
+		`application app has series "focal" but its charm cs:bionic/app-1 is for "bionic"`,
+		`unit app/1 is on machine 1 with series "bionic", not the application's series "focal"`,
+	})
+}
+
+func credential(authType string, attrs map[string]string, revoked bool) state.Credential {
+	var cred state.Credential
+	cred.AuthType = authType
+	cred.Attributes = attrs
+	cred.Revoked = revoked
+	return cred
+}
+
+type fakeImportCheckBackend struct {
+	clouds      map[string]cloud.Cloud
+	cloudErr    error
+	credentials map[string]state.Credential
+	providers   []string
+}
+
+func (b *fakeImportCheckBackend) Cloud(name string) (cloud.Cloud, error) {
+	if b.cloudErr != nil {
+		return cloud.Cloud{}, b.cloudErr
+	}
+	if cld, ok := b.clouds[name]; ok {
+		return cld, nil
+	}
+	return cloud.Cloud{}, errors.NotFoundf("cloud %q", name)
+}
+
+func (b *fakeImportCheckBackend) CloudCredential(tag names.CloudCredentialTag) (state.Credential, error) {
+	if cred, ok := b.credentials[tag.Id()]; ok {
+		return cred, nil
+	}
+	return state.Credential{}, errors.NotFoundf("credential %q", tag.Id())
+}
+
+func (b *fakeImportCheckBackend) ProviderRegistered(cloudType string) bool {
+	for _, provider := range b.providers {
+		if provider == cloudType {
+			return true
+		}
+	}
+	return false
+}
+
+type CheckClockSkewSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&CheckClockSkewSuite{})
+
+func (s *CheckClockSkewSuite) TestWithinLimit(c *gc.C) {
+	before := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	after := before.Add(2 * time.Second)
+
+	report := &migration.PrecheckReport{}
+	migration.CheckClockSkew(report, before, after, before.Add(time.Second))
+	migration.CheckClockSkew(report, before, after, before.Add(-migration.MaxClockSkew))
+	migration.CheckClockSkew(report, before, after, after.Add(migration.MaxClockSkew))
+	c.Check(report.Warnings, gc.HasLen, 0)
+}
+
+func (s *CheckClockSkewSuite) TestSkewed(c *gc.C) {
+	before := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	after := before.Add(2 * time.Second)
+
+	report := &migration.PrecheckReport{}
+	migration.CheckClockSkew(report, before, after, before.Add(-5*time.Minute))
+	migration.CheckClockSkew(report, before, after, after.Add(90*time.Second))
+	c.Check(report.Warnings, jc.DeepEquals, []string{
+		"target controller clock differs from source controller by 5m0s",
+		"target controller clock differs from source controller by 1m30s",
+	})
+	c.Check(report.Blockers, gc.HasLen, 0)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/tools"
+	"github.com/juju/juju/upgrades/migrationsteps"
 )
 
 var logger = loggo.GetLogger("juju.migration")
@@ -48,6 +49,20 @@ func ExportModel(st StateExporter) ([]byte, error) {
 	return bytes, nil
 }
 
+// modelAgentVersion returns the agent version recorded in the model
+// description's config, or version.Zero if there isn't one.
+func modelAgentVersion(model description.Model) (version.Number, error) {
+	value, _ := model.Config()["agent-version"].(string)
+	if value == "" {
+		return version.Zero, nil
+	}
+	agentVersion, err := version.Parse(value)
+	if err != nil {
+		return version.Zero, errors.Annotate(err, "parsing model agent version")
+	}
+	return agentVersion, nil
+}
+
 // StateImporter describes the method needed to import a model
 // into the database.
 type StateImporter interface {
@@ -60,13 +75,23 @@ type ClaimerFunc func(string) (leadership.Claimer, error)
 
 // ImportModel deserializes a model description from the bytes, transforms
 // the model config based on information from the controller model, and then
-// imports that as a new database model.
+// imports that as a new database model. A model exported by a controller
+// running an older version of Juju has the upgrade steps for the versions
+// since applied to its description first.
 func ImportModel(importer StateImporter, getClaimer ClaimerFunc, bytes []byte) (*state.Model, *state.State, error) {
 	model, err := description.Deserialize(bytes)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
 
+	agentVersion, err := modelAgentVersion(model)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if err := migrationsteps.UpgradeModelDescription(agentVersion, model); err != nil {
+		return nil, nil, errors.Annotate(err, "upgrading model description")
+	}
+
 	dbModel, dbState, err := importer.Import(model)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -0,0 +1,498 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/charm/v7"
+	charmresource "github.com/juju/charm/v7/resource"
+	"github.com/juju/collections/set"
+	"github.com/juju/description/v2"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/version"
+
+	coremigration "github.com/juju/juju/core/migration"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/resource"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// ModelBackupFormatVersion is the version of the model backup archive
+// written by WriteModelBackup.
+const ModelBackupFormatVersion = 1
+
+// The layout of a model backup archive.
+const (
+	modelBackupMetadataFile = "metadata.json"
+	modelBackupModelFile    = "model.yaml"
+	modelBackupCharmsDir    = "charms"
+	modelBackupToolsDir     = "tools"
+	modelBackupResourcesDir = "resources"
+)
+
+// ModelBackupMetadata describes the contents of a model backup archive.
+type ModelBackupMetadata struct {
+	FormatVersion int       `json:"format-version"`
+	Created       time.Time `json:"created"`
+	JujuVersion   string    `json:"juju-version"`
+
+	ModelUUID              string `json:"model-uuid"`
+	ModelName              string `json:"model-name"`
+	Owner                  string `json:"owner"`
+	AgentVersion           string `json:"agent-version"`
+	ControllerAgentVersion string `json:"controller-agent-version"`
+
+	Charms    []string `json:"charms,omitempty"`
+	Tools     []string `json:"tools,omitempty"`
+	Resources []string `json:"resources,omitempty"`
+}
+
+// ModelBackupSource provides the binaries used by a model, typically
+// from the controller hosting it.
+type ModelBackupSource interface {
+	CharmDownloader
+	ToolsDownloader
+	ResourceDownloader
+}
+
+// WriteModelBackup writes a model backup archive to w. The archive
+// holds the serialized model, as produced by ExportModel, along with
+// the charms, agent binaries and resources the model uses, fetched
+// from source. controllerVersion is the agent version of the
+// controller hosting the model.
+func WriteModelBackup(
+	w io.Writer,
+	serialized []byte,
+	controllerVersion version.Number,
+	source ModelBackupSource,
+) (*ModelBackupMetadata, error) {
+	model, err := description.Deserialize(serialized)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading model")
+	}
+	meta := newModelBackupMetadata(model, controllerVersion)
+	binaries, err := ModelBinaries(model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	if err := writeTarFile(tw, modelBackupModelFile, bytes.NewReader(serialized)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, curlStr := range binaries.Charms {
+		curl, err := charm.ParseURL(curlStr)
+		if err != nil {
+			return nil, errors.Annotate(err, "bad charm URL")
+		}
+		if err := copyToArchive(tw, charmArchivePath(curlStr), func() (io.ReadCloser, error) {
+			return source.OpenCharm(curl)
+		}); err != nil {
+			return nil, errors.Annotatef(err, "charm %s", curlStr)
+		}
+		meta.Charms = append(meta.Charms, curlStr)
+	}
+	for v, uri := range binaries.Tools {
+		if err := copyToArchive(tw, toolsArchivePath(v), func() (io.ReadCloser, error) {
+			return source.OpenURI(uri, nil)
+		}); err != nil {
+			return nil, errors.Annotatef(err, "agent binaries %s", v)
+		}
+		meta.Tools = append(meta.Tools, v.String())
+	}
+	for _, res := range binaries.Resources {
+		rev := res.ApplicationRevision
+		if rev.IsPlaceholder() {
+			continue
+		}
+		if err := copyToArchive(tw, resourceArchivePath(rev.ApplicationID, rev.Name), func() (io.ReadCloser, error) {
+			return source.OpenResource(rev.ApplicationID, rev.Name)
+		}); err != nil {
+			return nil, errors.Annotatef(err, "resource %s/%s", rev.ApplicationID, rev.Name)
+		}
+		meta.Resources = append(meta.Resources, rev.ApplicationID+"/"+rev.Name)
+	}
+
+	// The metadata is written last as it records what was written.
+	metaData, err := json.Marshal(meta)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := writeTarFile(tw, modelBackupMetadataFile, bytes.NewReader(metaData)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := tw.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := gzw.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+func newModelBackupMetadata(model description.Model, controllerVersion version.Number) *ModelBackupMetadata {
+	config := model.Config()
+	name, _ := config["name"].(string)
+	agentVersion, _ := config["agent-version"].(string)
+	return &ModelBackupMetadata{
+		FormatVersion:          ModelBackupFormatVersion,
+		Created:                time.Now().UTC(),
+		JujuVersion:            jujuversion.Current.String(),
+		ModelUUID:              model.Tag().Id(),
+		ModelName:              name,
+		Owner:                  model.Owner().Id(),
+		AgentVersion:           agentVersion,
+		ControllerAgentVersion: controllerVersion.String(),
+	}
+}
+
+// ModelBinaries returns the charms, agent binaries and resources used
+// by the model. The agent binaries are given with the URI to download
+// them from the controller hosting the model.
+func ModelBinaries(model description.Model) (coremigration.SerializedModel, error) {
+	var out coremigration.SerializedModel
+
+	charms := set.NewStrings()
+	for _, app := range model.Applications() {
+		charms.Add(app.CharmURL())
+	}
+	out.Charms = charms.SortedValues()
+
+	if model.Type() == string(coremodel.IAAS) {
+		out.Tools = make(map[version.Binary]string)
+		var addMachineTools func(description.Machine)
+		addMachineTools = func(machine description.Machine) {
+			if tools := machine.Tools(); tools != nil {
+				out.Tools[tools.Version()] = fmt.Sprintf("/tools/%s", tools.Version())
+			}
+			for _, container := range machine.Containers() {
+				addMachineTools(container)
+			}
+		}
+		for _, machine := range model.Machines() {
+			addMachineTools(machine)
+		}
+		for _, app := range model.Applications() {
+			for _, unit := range app.Units() {
+				if tools := unit.Tools(); tools != nil {
+					out.Tools[tools.Version()] = fmt.Sprintf("/tools/%s", tools.Version())
+				}
+			}
+		}
+	}
+
+	for _, app := range model.Applications() {
+		for _, res := range app.Resources() {
+			appRev, err := resourceFromRevision(app.Name(), res.Name(), res.ApplicationRevision())
+			if err != nil {
+				return out, errors.Annotatef(err, "resource %s/%s", app.Name(), res.Name())
+			}
+			csRev, err := resourceFromRevision(app.Name(), res.Name(), res.CharmStoreRevision())
+			if err != nil {
+				return out, errors.Annotatef(err, "resource %s/%s", app.Name(), res.Name())
+			}
+			unitRevs := make(map[string]resource.Resource)
+			for _, unit := range app.Units() {
+				for _, unitRes := range unit.Resources() {
+					if unitRes.Name() != res.Name() {
+						continue
+					}
+					unitRev, err := resourceFromRevision(app.Name(), res.Name(), unitRes.Revision())
+					if err != nil {
+						return out, errors.Annotatef(err, "resource %s/%s", unit.Name(), res.Name())
+					}
+					unitRevs[unit.Name()] = unitRev
+				}
+			}
+			out.Resources = append(out.Resources, coremigration.SerializedModelResource{
+				ApplicationRevision: appRev,
+				CharmStoreRevision:  csRev,
+				UnitRevisions:       unitRevs,
+			})
+		}
+	}
+	return out, nil
+}
+
+func resourceFromRevision(app, name string, rev description.ResourceRevision) (resource.Resource, error) {
+	if rev == nil {
+		return resource.Resource{}, nil
+	}
+	resType, err := charmresource.ParseType(rev.Type())
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	origin, err := charmresource.ParseOrigin(rev.Origin())
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	var fp charmresource.Fingerprint
+	if hex := rev.FingerprintHex(); hex != "" {
+		if fp, err = charmresource.ParseFingerprint(hex); err != nil {
+			return resource.Resource{}, errors.Annotate(err, "invalid fingerprint")
+		}
+	}
+	return resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name:        name,
+				Type:        resType,
+				Path:        rev.Path(),
+				Description: rev.Description(),
+			},
+			Origin:      origin,
+			Revision:    rev.Revision(),
+			Size:        rev.Size(),
+			Fingerprint: fp,
+		},
+		ApplicationID: app,
+		Username:      rev.Username(),
+		Timestamp:     rev.Timestamp(),
+	}, nil
+}
+
+func charmArchivePath(curl string) string {
+	return path.Join(modelBackupCharmsDir, url.PathEscape(curl)+".zip")
+}
+
+func toolsArchivePath(v version.Binary) string {
+	return path.Join(modelBackupToolsDir, v.String()+".tar.gz")
+}
+
+func resourceArchivePath(app, name string) string {
+	return path.Join(modelBackupResourcesDir, url.PathEscape(app), url.PathEscape(name))
+}
+
+// copyToArchive writes the content returned by open into the archive
+// as the named file. The content is staged in a temporary file as the
+// tar header needs its size.
+func copyToArchive(tw *tar.Writer, name string, open func() (io.ReadCloser, error)) error {
+	reader, err := open()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer reader.Close()
+	content, cleanup, err := streamThroughTempFile(reader)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer cleanup()
+	return errors.Trace(writeTarFile(tw, name, content))
+}
+
+func writeTarFile(tw *tar.Writer, name string, content io.ReadSeeker) error {
+	size, err := content.Seek(0, io.SeekEnd)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    size,
+		ModTime: time.Now(),
+	}); err != nil {
+		return errors.Annotatef(err, "writing %s", name)
+	}
+	if _, err := io.Copy(tw, content); err != nil {
+		return errors.Annotatef(err, "writing %s", name)
+	}
+	return nil
+}
+
+// ModelBackup is a model backup archive unpacked into a temporary
+// directory, ready to be imported into a controller. It provides the
+// binaries stored in the archive in the same way as the source
+// controller does in a migration.
+type ModelBackup struct {
+	// Metadata describes the contents of the archive.
+	Metadata ModelBackupMetadata
+
+	// Serialized holds the serialized model.
+	Serialized []byte
+
+	dir string
+}
+
+// gzipMagic starts every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// OpenModelBackup unpacks the model backup archive read from r, which
+// may be compressed or not. The returned ModelBackup must be closed to
+// remove the unpacked files.
+func OpenModelBackup(r io.Reader) (_ *ModelBackup, err error) {
+	dir, err := ioutil.TempDir("", "juju-model-backup")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	b := &ModelBackup{dir: dir}
+	defer func() {
+		if err != nil {
+			b.Close()
+		}
+	}()
+
+	// Archives are written compressed, but may have been decompressed
+	// on their way to the target controller.
+	br := bufio.NewReader(r)
+	var archive io.Reader = br
+	if magic, _ := br.Peek(2); bytes.Equal(magic, gzipMagic) {
+		if archive, err = gzip.NewReader(br); err != nil {
+			return nil, errors.Annotate(err, "reading model backup")
+		}
+	}
+	tr := tar.NewReader(archive)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Annotate(err, "reading model backup")
+		}
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, errors.NotValidf("model backup file %q", hdr.Name)
+		}
+		if err := unpackFile(filepath.Join(dir, filepath.FromSlash(name)), tr); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	metaData, err := ioutil.ReadFile(filepath.Join(dir, modelBackupMetadataFile))
+	if os.IsNotExist(err) {
+		return nil, errors.NotValidf("model backup without metadata")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := json.Unmarshal(metaData, &b.Metadata); err != nil {
+		return nil, errors.Annotate(err, "reading model backup metadata")
+	}
+	if v := b.Metadata.FormatVersion; v != ModelBackupFormatVersion {
+		return nil, errors.NotSupportedf("model backup format version %d", v)
+	}
+	if b.Serialized, err = ioutil.ReadFile(filepath.Join(dir, modelBackupModelFile)); err != nil {
+		return nil, errors.Annotate(err, "reading model")
+	}
+	return b, nil
+}
+
+func unpackFile(filename string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0700); err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(f.Close())
+}
+
+// Close removes the unpacked archive.
+func (b *ModelBackup) Close() error {
+	return errors.Trace(os.RemoveAll(b.dir))
+}
+
+// ModelInfo returns the details of the backed up model needed to check
+// that a controller can import it.
+func (b *ModelBackup) ModelInfo() (coremigration.ModelInfo, error) {
+	var info coremigration.ModelInfo
+	meta := b.Metadata
+	if !names.IsValidUser(meta.Owner) {
+		return info, errors.NotValidf("model owner %q", meta.Owner)
+	}
+	agentVersion, err := version.Parse(meta.AgentVersion)
+	if err != nil {
+		return info, errors.Annotate(err, "model agent version")
+	}
+	controllerVersion, err := version.Parse(meta.ControllerAgentVersion)
+	if err != nil {
+		return info, errors.Annotate(err, "controller agent version")
+	}
+	return coremigration.ModelInfo{
+		UUID:                   meta.ModelUUID,
+		Owner:                  names.NewUserTag(meta.Owner),
+		Name:                   meta.ModelName,
+		AgentVersion:           agentVersion,
+		ControllerAgentVersion: controllerVersion,
+	}, nil
+}
+
+// UploadBinariesConfig returns the config for UploadBinaries to send
+// the binaries in the archive to a controller importing the model.
+func (b *ModelBackup) UploadBinariesConfig(
+	charms CharmUploader,
+	tools ToolsUploader,
+	resources ResourceUploader,
+) (UploadBinariesConfig, error) {
+	model, err := description.Deserialize(b.Serialized)
+	if err != nil {
+		return UploadBinariesConfig{}, errors.Annotate(err, "reading model")
+	}
+	binaries, err := ModelBinaries(model)
+	if err != nil {
+		return UploadBinariesConfig{}, errors.Trace(err)
+	}
+	// The agent binaries are read from the archive, not a controller.
+	for v := range binaries.Tools {
+		binaries.Tools[v] = toolsArchivePath(v)
+	}
+	return UploadBinariesConfig{
+		Charms:             binaries.Charms,
+		CharmDownloader:    b,
+		CharmUploader:      charms,
+		Tools:              binaries.Tools,
+		ToolsDownloader:    b,
+		ToolsUploader:      tools,
+		Resources:          binaries.Resources,
+		ResourceDownloader: b,
+		ResourceUploader:   resources,
+	}, nil
+}
+
+// OpenCharm is part of the CharmDownloader interface.
+func (b *ModelBackup) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return b.open(charmArchivePath(curl.String()), "charm %s", curl)
+}
+
+// OpenURI is part of the ToolsDownloader interface. The URI is the
+// path of the agent binaries in the archive.
+func (b *ModelBackup) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	return b.open(uri, "agent binaries %s", path.Base(uri))
+}
+
+// OpenResource is part of the ResourceDownloader interface.
+func (b *ModelBackup) OpenResource(app, name string) (io.ReadCloser, error) {
+	return b.open(resourceArchivePath(app, name), "resource %s/%s", app, name)
+}
+
+func (b *ModelBackup) open(name, format string, args ...interface{}) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(b.dir, filepath.FromSlash(name)))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf(format+" in model backup", args...)
+	}
+	return f, errors.Trace(err)
+}
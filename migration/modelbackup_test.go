@@ -0,0 +1,258 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/juju/charm/v7"
+	charmresource "github.com/juju/charm/v7/resource"
+	"github.com/juju/description/v2"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/migration"
+	coretesting "github.com/juju/juju/testing"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type ModelBackupSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ModelBackupSuite{})
+
+var (
+	backupMachineTools = version.MustParseBinary("2.8.0-focal-amd64")
+	backupUnitTools    = version.MustParseBinary("2.8.1-focal-amd64")
+)
+
+func (s *ModelBackupSuite) serializedModel(c *gc.C) []byte {
+	model := description.NewModel(description.ModelArgs{
+		Type:  "iaas",
+		Owner: names.NewUserTag("bob"),
+		Config: map[string]interface{}{
+			"name":          "foo",
+			"uuid":          coretesting.ModelTag.Id(),
+			"agent-version": "2.8.0",
+		},
+	})
+	status := description.StatusArgs{Value: "active"}
+	model.SetStatus(status)
+	machine := model.AddMachine(description.MachineArgs{Id: names.NewMachineTag("0")})
+	machine.SetStatus(status)
+	machine.SetTools(description.AgentToolsArgs{Version: backupMachineTools})
+
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader("blob"))
+	c.Assert(err, jc.ErrorIsNil)
+	revision := description.ResourceRevisionArgs{
+		Revision:       1,
+		Type:           "file",
+		Path:           "blob.txt",
+		Origin:         "upload",
+		FingerprintHex: fp.Hex(),
+		Size:           4,
+		Timestamp:      time.Date(2020, 4, 5, 12, 0, 0, 0, time.UTC),
+		Username:       "bob",
+	}
+	app := model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("app"),
+		CharmURL: "cs:~bob/focal/app-1",
+	})
+	app.SetStatus(status)
+	app.AddResource(description.ResourceArgs{Name: "blob"}).SetApplicationRevision(revision)
+	// A placeholder has no timestamp, as nothing was uploaded.
+	app.AddResource(description.ResourceArgs{Name: "placeholder"}).SetApplicationRevision(
+		description.ResourceRevisionArgs{Type: "file", Path: "placeholder.txt", Origin: "upload"})
+	unit := app.AddUnit(description.UnitArgs{Tag: names.NewUnitTag("app/0")})
+	unit.SetAgentStatus(status)
+	unit.SetWorkloadStatus(status)
+	unit.SetTools(description.AgentToolsArgs{Version: backupUnitTools})
+	unit.AddResource(description.UnitResourceArgs{Name: "blob", RevisionArgs: revision})
+
+	serialized, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	return serialized
+}
+
+func (s *ModelBackupSuite) writeBackup(c *gc.C) (*bytes.Buffer, *migration.ModelBackupMetadata, []byte) {
+	serialized := s.serializedModel(c)
+	var buf bytes.Buffer
+	meta, err := migration.WriteModelBackup(&buf, serialized, version.MustParse("2.8.2"), &fakeDownloader{})
+	c.Assert(err, jc.ErrorIsNil)
+	return &buf, meta, serialized
+}
+
+func (s *ModelBackupSuite) TestModelBinaries(c *gc.C) {
+	model, err := description.Deserialize(s.serializedModel(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	binaries, err := migration.ModelBinaries(model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(binaries.Charms, jc.DeepEquals, []string{"cs:~bob/focal/app-1"})
+	c.Check(binaries.Tools, jc.DeepEquals, map[version.Binary]string{
+		backupMachineTools: "/tools/2.8.0-focal-amd64",
+		backupUnitTools:    "/tools/2.8.1-focal-amd64",
+	})
+	c.Assert(binaries.Resources, gc.HasLen, 2)
+	blob := binaries.Resources[0]
+	c.Check(blob.ApplicationRevision.ApplicationID, gc.Equals, "app")
+	c.Check(blob.ApplicationRevision.Name, gc.Equals, "blob")
+	c.Check(blob.ApplicationRevision.Revision, gc.Equals, 1)
+	c.Check(blob.ApplicationRevision.IsPlaceholder(), jc.IsFalse)
+	c.Check(blob.UnitRevisions, gc.HasLen, 1)
+	c.Check(blob.UnitRevisions["app/0"].Path, gc.Equals, "blob.txt")
+	c.Check(binaries.Resources[1].ApplicationRevision.IsPlaceholder(), jc.IsTrue)
+}
+
+func (s *ModelBackupSuite) TestWriteModelBackup(c *gc.C) {
+	downloader := &fakeDownloader{}
+	var buf bytes.Buffer
+	meta, err := migration.WriteModelBackup(&buf, s.serializedModel(c), version.MustParse("2.8.2"), downloader)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(meta.FormatVersion, gc.Equals, migration.ModelBackupFormatVersion)
+	c.Check(meta.JujuVersion, gc.Equals, jujuversion.Current.String())
+	c.Check(meta.ModelUUID, gc.Equals, coretesting.ModelTag.Id())
+	c.Check(meta.ModelName, gc.Equals, "foo")
+	c.Check(meta.Owner, gc.Equals, "bob")
+	c.Check(meta.AgentVersion, gc.Equals, "2.8.0")
+	c.Check(meta.ControllerAgentVersion, gc.Equals, "2.8.2")
+	c.Check(meta.Charms, jc.DeepEquals, []string{"cs:~bob/focal/app-1"})
+	c.Check(meta.Tools, jc.SameContents, []string{"2.8.0-focal-amd64", "2.8.1-focal-amd64"})
+	c.Check(meta.Resources, jc.DeepEquals, []string{"app/blob"})
+
+	c.Check(downloader.charms, jc.DeepEquals, []string{"cs:~bob/focal/app-1"})
+	c.Check(downloader.uris, jc.SameContents, []string{"/tools/2.8.0-focal-amd64", "/tools/2.8.1-focal-amd64"})
+	c.Check(downloader.resources, jc.DeepEquals, []string{"app/blob"})
+
+	gzr, err := gzip.NewReader(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	tr := tar.NewReader(gzr)
+	var files []string
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		files = append(files, hdr.Name)
+	}
+	c.Check(files, jc.SameContents, []string{
+		"model.yaml",
+		"charms/cs:~bob%2Ffocal%2Fapp-1.zip",
+		"tools/2.8.0-focal-amd64.tar.gz",
+		"tools/2.8.1-focal-amd64.tar.gz",
+		"resources/app/blob",
+		"metadata.json",
+	})
+}
+
+func (s *ModelBackupSuite) TestOpenModelBackup(c *gc.C) {
+	archive, meta, serialized := s.writeBackup(c)
+
+	backup, err := migration.OpenModelBackup(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	defer backup.Close()
+
+	c.Check(backup.Metadata.ModelUUID, gc.Equals, meta.ModelUUID)
+	c.Check(backup.Metadata.Charms, jc.DeepEquals, meta.Charms)
+	c.Check(backup.Serialized, jc.DeepEquals, serialized)
+
+	info, err := backup.ModelInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.UUID, gc.Equals, coretesting.ModelTag.Id())
+	c.Check(info.Name, gc.Equals, "foo")
+	c.Check(info.Owner, gc.Equals, names.NewUserTag("bob"))
+	c.Check(info.AgentVersion, gc.Equals, version.MustParse("2.8.0"))
+	c.Check(info.ControllerAgentVersion, gc.Equals, version.MustParse("2.8.2"))
+
+	reader, err := backup.OpenCharm(charm.MustParseURL("cs:~bob/focal/app-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	data, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "cs:~bob/focal/app-1 content")
+
+	_, err = backup.OpenCharm(charm.MustParseURL("cs:focal/other-1"))
+	c.Check(err, gc.ErrorMatches, `charm cs:focal/other-1 in model backup not found`)
+}
+
+func (s *ModelBackupSuite) TestOpenModelBackupUncompressed(c *gc.C) {
+	archive, meta, serialized := s.writeBackup(c)
+	gzr, err := gzip.NewReader(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	uncompressed, err := ioutil.ReadAll(gzr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	backup, err := migration.OpenModelBackup(bytes.NewReader(uncompressed))
+	c.Assert(err, jc.ErrorIsNil)
+	defer backup.Close()
+	c.Check(backup.Metadata.ModelUUID, gc.Equals, meta.ModelUUID)
+	c.Check(backup.Serialized, jc.DeepEquals, serialized)
+}
+
+func (s *ModelBackupSuite) TestUploadBinaries(c *gc.C) {
+	archive, _, _ := s.writeBackup(c)
+	backup, err := migration.OpenModelBackup(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	defer backup.Close()
+	uploader := &fakeUploader{
+		tools:     make(map[version.Binary]string),
+		resources: make(map[string]string),
+	}
+
+	config, err := backup.UploadBinariesConfig(uploader, uploader, uploader)
+	c.Assert(err, jc.ErrorIsNil)
+	err = migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(uploader.charms, jc.DeepEquals, []string{"cs:~bob/focal/app-1"})
+	c.Check(uploader.tools, jc.DeepEquals, map[version.Binary]string{
+		backupMachineTools: "/tools/2.8.0-focal-amd64",
+		backupUnitTools:    "/tools/2.8.1-focal-amd64",
+	})
+	c.Check(uploader.resources, jc.DeepEquals, map[string]string{"app/blob": "blob"})
+	c.Check(uploader.unitResources, jc.DeepEquals, []string{"app/0-blob"})
+}
+
+func (s *ModelBackupSuite) TestOpenModelBackupNoMetadata(c *gc.C) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	c.Assert(tw.WriteHeader(&tar.Header{Name: "model.yaml", Mode: 0600}), jc.ErrorIsNil)
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+
+	_, err := migration.OpenModelBackup(&buf)
+	c.Check(err, gc.ErrorMatches, "model backup without metadata not valid")
+}
+
+func (s *ModelBackupSuite) TestOpenModelBackupNotArchive(c *gc.C) {
+	_, err := migration.OpenModelBackup(strings.NewReader("<not an archive>"))
+	c.Check(err, gc.ErrorMatches, "reading model backup: .*")
+}
+
+func (s *ModelBackupSuite) TestOpenModelBackupOutsideArchive(c *gc.C) {
+	for _, name := range []string{"../escape", "..", "dir/../..", "/abs"} {
+		c.Logf("file %q", name)
+		var buf bytes.Buffer
+		gzw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gzw)
+		c.Assert(tw.WriteHeader(&tar.Header{Name: name, Mode: 0600}), jc.ErrorIsNil)
+		c.Assert(tw.Close(), jc.ErrorIsNil)
+		c.Assert(gzw.Close(), jc.ErrorIsNil)
+
+		_, err := migration.OpenModelBackup(&buf)
+		c.Check(err, gc.ErrorMatches, fmt.Sprintf("model backup file %q not valid", name))
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	coremigration "github.com/juju/juju/core/migration"
+	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/resource"
@@ -35,6 +36,7 @@ type PrecheckBackend interface {
 	ControllerBackend() (PrecheckBackend, error)
 	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	AllBlocks() ([]PrecheckBlock, error)
 }
 
 // Pool defines the interface to a StatePool used by the migration
@@ -104,12 +106,57 @@ type PrecheckRelationUnit interface {
 	InScope() (bool, error)
 }
 
+// PrecheckBlock describes the state interface for a block needed by
+// migration prechecks.
+type PrecheckBlock interface {
+	Tag() (names.Tag, error)
+	Operations() []coremodel.BlockOperation
+}
+
 // ModelPresence represents the API server connections for a model.
 type ModelPresence interface {
 	// For a given non controller agent, return the Status for that agent.
 	AgentStatus(agent string) (presence.Status, error)
 }
 
+// PrecheckReport holds every problem found by the migration
+// prechecks. Blockers prevent the migration from going ahead; warnings
+// are problems the user should know about which don't.
+type PrecheckReport struct {
+	Blockers []string
+	Warnings []string
+}
+
+// Err returns the first blocker in the report as an error, or nil if
+// there are none.
+func (r *PrecheckReport) Err() error {
+	if len(r.Blockers) == 0 {
+		return nil
+	}
+	return errors.New(r.Blockers[0])
+}
+
+// AddBlocker records a problem which prevents the migration.
+func (r *PrecheckReport) AddBlocker(format string, args ...interface{}) {
+	r.Blockers = append(r.Blockers, fmt.Sprintf(format, args...))
+}
+
+// AddWarning records a problem which doesn't prevent the migration.
+func (r *PrecheckReport) AddWarning(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Merge adds the blockers and warnings from other to the report, each
+// prefixed with the label given.
+func (r *PrecheckReport) Merge(label string, other *PrecheckReport) {
+	for _, blocker := range other.Blockers {
+		r.AddBlocker("%s: %s", label, blocker)
+	}
+	for _, warning := range other.Warnings {
+		r.AddWarning("%s: %s", label, warning)
+	}
+}
+
 // SourcePrecheck checks the state of the source controller to make
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the model to be migrated.
@@ -118,45 +165,65 @@ func SourcePrecheck(
 	modelPresence ModelPresence,
 	controllerPresence ModelPresence,
 ) error {
-	ctx := precheckContext{backend, modelPresence}
-	if err := ctx.checkModel(); err != nil {
+	report, err := SourcePrecheckReport(backend, modelPresence, controllerPresence)
+	if err != nil {
 		return errors.Trace(err)
 	}
+	return report.Err()
+}
+
+// SourcePrecheckReport runs the same checks as SourcePrecheck, but
+// rather than stopping at the first problem it returns a report of
+// every problem found. An error is returned only if the checks
+// couldn't be run.
+func SourcePrecheckReport(
+	backend PrecheckBackend,
+	modelPresence ModelPresence,
+	controllerPresence ModelPresence,
+) (*PrecheckReport, error) {
+	report := &PrecheckReport{}
+	ctx := precheckContext{backend, modelPresence, report}
+	if err := ctx.checkModel(); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	if err := ctx.checkMachines(); err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	appUnits, err := ctx.checkApplications()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	if err := ctx.checkRelations(appUnits); err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
-		return errors.Annotate(err, "checking cleanups")
+		return nil, errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
-		return errors.New("cleanup needed")
+		report.AddBlocker("cleanup needed")
 	}
 
 	// Check the source controller.
 	controllerBackend, err := backend.ControllerBackend()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
-	controllerCtx := precheckContext{controllerBackend, controllerPresence}
+	controllerReport := &PrecheckReport{}
+	controllerCtx := precheckContext{controllerBackend, controllerPresence, controllerReport}
 	if err := controllerCtx.checkController(); err != nil {
-		return errors.Annotate(err, "controller")
+		return nil, errors.Annotate(err, "controller")
 	}
-	return nil
+	report.Merge("controller", controllerReport)
+	return report, nil
 }
 
 type precheckContext struct {
 	backend  PrecheckBackend
 	presence ModelPresence
+	report   *PrecheckReport
 }
 
 func (ctx *precheckContext) checkModel() error {
@@ -165,10 +232,10 @@ func (ctx *precheckContext) checkModel() error {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		ctx.report.AddBlocker("model is %s", model.Life())
 	}
 	if model.MigrationMode() == state.MigrationModeImporting {
-		return errors.New("model is being imported as part of another migration")
+		ctx.report.AddBlocker("model is being imported as part of another migration")
 	}
 	if credTag, found := model.CloudCredentialTag(); found {
 		creds, err := ctx.backend.CloudCredential(credTag)
@@ -176,9 +243,26 @@ func (ctx *precheckContext) checkModel() error {
 			return errors.Trace(err)
 		}
 		if creds.Revoked {
-			return errors.New("model has revoked credentials")
+			ctx.report.AddBlocker("model has revoked credentials")
 		}
 	}
+
+	// The model description has no representation for blocks on
+	// individual entities, so they can't be migrated.
+	blocks, err := ctx.backend.AllBlocks()
+	if err != nil {
+		return errors.Annotate(err, "retrieving blocks")
+	}
+	for _, block := range blocks {
+		if len(block.Operations()) == 0 {
+			continue
+		}
+		tag, err := block.Tag()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ctx.report.AddBlocker("%s has commands disabled, which can't be migrated; enable them first", names.ReadableString(tag))
+	}
 	return nil
 }
 
@@ -186,9 +270,27 @@ func (ctx *precheckContext) checkModel() error {
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
 func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence) error {
-	if err := modelInfo.Validate(); err != nil {
+	report, err := TargetPrecheckReport(backend, pool, modelInfo, presence)
+	if err != nil {
 		return errors.Trace(err)
 	}
+	return report.Err()
+}
+
+// TargetPrecheckReport runs the same checks as TargetPrecheck, but
+// rather than stopping at the first problem it returns a report of
+// every problem found. An error is returned only if the checks
+// couldn't be run.
+func TargetPrecheckReport(
+	backend PrecheckBackend,
+	pool Pool,
+	modelInfo coremigration.ModelInfo,
+	presence ModelPresence,
+) (*PrecheckReport, error) {
+	if err := modelInfo.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	report := &PrecheckReport{}
 
 	// This check is necessary because there is a window between the
 	// REAP phase and then end of the DONE phase where a model's
@@ -198,40 +300,50 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 	//
 	// See also https://lpad.tv/1611391
 	if migrating, err := backend.IsMigrationActive(modelInfo.UUID); err != nil {
-		return errors.Annotate(err, "checking for active migration")
+		return nil, errors.Annotate(err, "checking for active migration")
 	} else if migrating {
-		return errors.New("model is being migrated out of target controller")
+		report.AddBlocker("model is being migrated out of target controller")
 	}
 
 	controllerVersion, err := backend.AgentVersion()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model version")
+		return nil, errors.Annotate(err, "retrieving model version")
 	}
 
 	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
-		return errors.Errorf("model has higher version than target controller (%s > %s)",
+		report.AddBlocker("model has higher version than target controller (%s > %s)",
 			modelInfo.AgentVersion, controllerVersion)
 	}
 
 	if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
-		return errors.Errorf("source controller has higher version than target controller (%s > %s)",
+		report.AddBlocker("source controller has higher version than target controller (%s > %s)",
 			modelInfo.ControllerAgentVersion, controllerVersion)
 	}
 
-	controllerCtx := precheckContext{backend, presence}
+	// A model on an older minor version is upgraded as it is
+	// migrated, but there are no upgrade steps between major versions.
+	if modelInfo.AgentVersion.Major < controllerVersion.Major {
+		report.AddBlocker("model version %s cannot be migrated to a Juju %d controller",
+			modelInfo.AgentVersion, controllerVersion.Major)
+	} else if newVersion, ok := AgentUpgradeVersion(modelInfo.AgentVersion, controllerVersion); ok {
+		report.AddWarning("model agents will be upgraded from %s to %s",
+			modelInfo.AgentVersion, newVersion)
+	}
+
+	controllerCtx := precheckContext{backend, presence, report}
 	if err := controllerCtx.checkController(); err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	// Check for conflicts with existing models
 	modelUUIDs, err := backend.AllModelUUIDs()
 	if err != nil {
-		return errors.Annotate(err, "retrieving models")
+		return nil, errors.Annotate(err, "retrieving models")
 	}
 	for _, modelUUID := range modelUUIDs {
 		model, release, err := pool.GetModel(modelUUID)
 		if err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 		defer release()
 
@@ -239,14 +351,14 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 		// from a previous migration attempt. It will be removed
 		// before the next import.
 		if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
-			return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
+			report.AddBlocker("model with same UUID already exists (%s)", modelInfo.UUID)
 		}
 		if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
-			return errors.Errorf("model named %q already exists", model.Name())
+			report.AddBlocker("model named %q already exists", model.Name())
 		}
 	}
 
-	return nil
+	return report, nil
 }
 
 func controllerVersionCompatible(sourceVersion, targetVersion version.Number) bool {
@@ -259,6 +371,19 @@ func controllerVersionCompatible(sourceVersion, targetVersion version.Number) bo
 	return sourceVersion.Compare(targetVersion) <= 0
 }
 
+// AgentUpgradeVersion returns the version the agents of a model
+// migrated to a target controller should be upgraded to, and whether
+// they need upgrading at all. Agents are upgraded to the target
+// controller's version when the model is on an older major.minor
+// release, as after a migration off a controller running an older
+// version of Juju.
+func AgentUpgradeVersion(modelVersion, controllerVersion version.Number) (version.Number, bool) {
+	if versionToMajMin(modelVersion).Compare(versionToMajMin(controllerVersion)) >= 0 {
+		return version.Zero, false
+	}
+	return controllerVersion, true
+}
+
 func versionToMajMin(ver version.Number) version.Number {
 	ver.Patch = 0
 	ver.Build = 0
@@ -272,13 +397,13 @@ func (ctx *precheckContext) checkController() error {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		ctx.report.AddBlocker("model is %s", model.Life())
 	}
 
 	if upgrading, err := ctx.backend.IsUpgrading(); err != nil {
 		return errors.Annotate(err, "checking for upgrades")
 	} else if upgrading {
-		return errors.New("upgrade in progress")
+		ctx.report.AddBlocker("upgrade in progress")
 	}
 
 	return errors.Trace(ctx.checkMachines())
@@ -297,29 +422,30 @@ func (ctx *precheckContext) checkMachines() error {
 	modelPresenceContext := common.ModelPresenceContext{Presence: ctx.presence}
 	for _, machine := range machines {
 		if machine.Life() != state.Alive {
-			return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+			ctx.report.AddBlocker("machine %s is %s", machine.Id(), machine.Life())
+			continue
 		}
 
 		if statusInfo, err := machine.InstanceStatus(); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
 		} else if statusInfo.Status != status.Running {
-			return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
+			ctx.addStatusBlocker("machine %s not running", machine.Id(), statusInfo.Status)
 		}
 
 		if statusInfo, err := modelPresenceContext.MachineStatus(machine); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
 		} else if statusInfo.Status != status.Started {
-			return newStatusError("machine %s agent not functioning at this time",
+			ctx.addStatusBlocker("machine %s agent not functioning at this time",
 				machine.Id(), statusInfo.Status)
 		}
 
 		if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
 		} else if rebootAction != state.ShouldDoNothing {
-			return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
+			ctx.report.AddBlocker("machine %s is scheduled to %s", machine.Id(), rebootAction)
 		}
 
-		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
+		if err := ctx.checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -343,7 +469,8 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 	appUnits := make(map[string][]PrecheckUnit, len(apps))
 	for _, app := range apps {
 		if app.Life() != state.Alive {
-			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
+			ctx.report.AddBlocker("application %s is %s", app.Name(), app.Life())
+			continue
 		}
 		units, err := app.AllUnits()
 		if err != nil {
@@ -360,14 +487,15 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 
 func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []PrecheckUnit, modelVersion version.Number, modelType state.ModelType) error {
 	if len(units) < app.MinUnits() {
-		return errors.Errorf("application %s is below its minimum units threshold", app.Name())
+		ctx.report.AddBlocker("application %s is below its minimum units threshold", app.Name())
 	}
 
 	appCharmURL, _ := app.CharmURL()
 
 	for _, unit := range units {
 		if unit.Life() != state.Alive {
-			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
+			ctx.report.AddBlocker("unit %s is %s", unit.Name(), unit.Life())
+			continue
 		}
 
 		if err := ctx.checkUnitAgentStatus(unit); err != nil {
@@ -375,14 +503,14 @@ func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []Precheck
 		}
 
 		if modelType == state.ModelTypeIAAS {
-			if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
+			if err := ctx.checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
 				return errors.Trace(err)
 			}
 		}
 
 		unitCharmURL, _ := unit.CharmURL()
 		if appCharmURL.String() != unitCharmURL.String() {
-			return errors.Errorf("unit %s is upgrading", unit.Name())
+			ctx.report.AddBlocker("unit %s is upgrading", unit.Name())
 		}
 	}
 	return nil
@@ -399,19 +527,19 @@ func (ctx *precheckContext) checkUnitAgentStatus(unit PrecheckUnit) error {
 	case status.Idle, status.Executing:
 		// These two are fine.
 	default:
-		return newStatusError("unit %s not idle or executing", unit.Name(), agentStatus)
+		ctx.addStatusBlocker("unit %s not idle or executing", unit.Name(), agentStatus)
 	}
 	return nil
 }
 
-func checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
+func (ctx *precheckContext) checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
 	tools, err := agent.AgentTools()
 	if err != nil {
 		return errors.Annotatef(err, "retrieving agent binaries for %s", agentLabel)
 	}
 	agentVersion := tools.Version.Number
 	if agentVersion != modelVersion {
-		return errors.Errorf("%s agent binaries don't match model (%s != %s)",
+		ctx.report.AddBlocker("%s agent binaries don't match model (%s != %s)",
 			agentLabel, agentVersion, modelVersion)
 	}
 	return nil
@@ -421,12 +549,12 @@ type agentToolsGetter interface {
 	AgentTools() (*tools.Tools, error)
 }
 
-func newStatusError(format, id string, s status.Status) error {
+func (ctx *precheckContext) addStatusBlocker(format, id string, s status.Status) {
 	msg := fmt.Sprintf(format, id)
 	if s != status.Empty {
 		msg += fmt.Sprintf(" (%s)", s)
 	}
-	return errors.New(msg)
+	ctx.report.AddBlocker("%s", msg)
 }
 
 func (ctx *precheckContext) checkRelations(appUnits map[string][]PrecheckUnit) error {
@@ -463,7 +591,7 @@ func (ctx *precheckContext) checkRelations(appUnits map[string][]PrecheckUnit) e
 					return errors.Trace(err)
 				}
 				if !inScope {
-					return errors.Errorf("unit %s hasn't joined relation %s yet", unit.Name(), rel)
+					ctx.report.AddBlocker("unit %s hasn't joined relation %s yet", unit.Name(), rel)
 				}
 			}
 		}
@@ -99,6 +99,19 @@ func (s *precheckShim) AllRelations() ([]PrecheckRelation, error) {
 	return out, nil
 }
 
+// AllBlocks implements PrecheckBackend.
+func (s *precheckShim) AllBlocks() ([]PrecheckBlock, error) {
+	blocks, err := s.State.AllBlocks()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckBlock, len(blocks))
+	for i, block := range blocks {
+		out[i] = block
+	}
+	return out, nil
+}
+
 // ListPendingResources implements PrecheckBackend.
 func (s *precheckShim) ListPendingResources(app string) ([]resource.Resource, error) {
 	resources, err := s.resourcesSt.ListPendingResources(app)
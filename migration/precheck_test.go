@@ -12,6 +12,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	coremigration "github.com/juju/juju/core/migration"
+	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/migration"
@@ -86,6 +87,26 @@ func (*SourcePrecheckSuite) TestPendingResources(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (*SourcePrecheckSuite) TestEntityBlocks(c *gc.C) {
+	backend := newHappyBackend()
+	backend.blocks = []migration.PrecheckBlock{
+		&fakeBlock{tag: names.NewModelTag(modelUUID)},
+		&fakeBlock{
+			tag:        names.NewApplicationTag("mysql"),
+			operations: []coremodel.BlockOperation{coremodel.BlockOperationRemove},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "application mysql has commands disabled, which can't be migrated; enable them first")
+}
+
+func (*SourcePrecheckSuite) TestBlocksError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.allBlocksErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving blocks: boom")
+}
+
 func (*SourcePrecheckSuite) TestImportingModel(c *gc.C) {
 	backend := newFakeBackend()
 	backend.model.migrationMode = state.MigrationModeImporting
@@ -396,6 +417,45 @@ func (s *SourcePrecheckSuite) TestCrossModelUnitsNotYetInScope(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *SourcePrecheckSuite) TestReportCollectsAllProblems(c *gc.C) {
+	backend := newBackendWithDyingMachine()
+	backend.model.life = state.Dying
+	backend.cleanupNeeded = true
+	backend.apps = []migration.PrecheckApplication{
+		&fakeApp{
+			name:     "spanner",
+			charmURL: "cs:spanner-3",
+			units: []migration.PrecheckUnit{
+				&fakeUnit{name: "spanner/0", charmURL: "cs:spanner-2"},
+				&fakeUnit{name: "spanner/1", charmURL: "cs:spanner-2"},
+			},
+		},
+	}
+	backend.controllerBackend = newFakeBackend()
+	backend.controllerBackend.isUpgrading = true
+
+	report, err := migration.SourcePrecheckReport(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report.Blockers, jc.DeepEquals, []string{
+		"model is dying",
+		"machine 0 is dying",
+		"unit spanner/0 is upgrading",
+		"unit spanner/1 is upgrading",
+		"cleanup needed",
+		"controller: upgrade in progress",
+	})
+	c.Check(report.Warnings, gc.HasLen, 0)
+	c.Check(report.Err(), gc.ErrorMatches, "model is dying")
+}
+
+func (s *SourcePrecheckSuite) TestReportError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.model.life = state.Dying
+	backend.cleanupErr = errors.New("boom")
+	_, err := migration.SourcePrecheckReport(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, gc.ErrorMatches, "checking cleanups: boom")
+}
+
 type TargetPrecheckSuite struct {
 	precheckBaseSuite
 	modelInfo coremigration.ModelInfo
@@ -490,6 +550,34 @@ func (s *TargetPrecheckSuite) TestSourceControllerTagMismatch(c *gc.C) {
 	c.Assert(s.runPrecheck(backend), jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestModelMinorBehindTargetIsUpgraded(c *gc.C) {
+	s.modelInfo.AgentVersion = version.MustParse("1.1.7")
+	s.modelInfo.ControllerAgentVersion = version.MustParse("1.1.9")
+
+	report, err := migration.TargetPrecheckReport(newHappyBackend(), nil, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report.Blockers, gc.HasLen, 0)
+	c.Check(report.Warnings, jc.DeepEquals, []string{
+		"model agents will be upgraded from 1.1.7 to 1.2.3",
+	})
+}
+
+func (s *TargetPrecheckSuite) TestModelPatchBehindTargetNotUpgraded(c *gc.C) {
+	s.modelInfo.AgentVersion = version.MustParse("1.2.1")
+
+	report, err := migration.TargetPrecheckReport(newHappyBackend(), nil, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report.Blockers, gc.HasLen, 0)
+	c.Check(report.Warnings, gc.HasLen, 0)
+}
+
+func (s *TargetPrecheckSuite) TestModelMajorBehindTarget(c *gc.C) {
+	s.modelInfo.AgentVersion = version.MustParse("0.9.1")
+
+	err := s.runPrecheck(newHappyBackend())
+	c.Assert(err, gc.ErrorMatches, "model version 0.9.1 cannot be migrated to a Juju 1 controller")
+}
+
 func (s *TargetPrecheckSuite) TestDying(c *gc.C) {
 	backend := newFakeBackend()
 	backend.model.life = state.Dying
@@ -623,6 +711,34 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestReportCollectsAllProblems(c *gc.C) {
+	pool := &fakePool{
+		models: []migration.PrecheckModel{
+			&fakeModel{uuid: modelUUID, modelType: state.ModelTypeIAAS},
+			&fakeModel{
+				uuid:      "uuid",
+				name:      modelName,
+				modelType: state.ModelTypeIAAS,
+				owner:     modelOwner,
+			},
+		},
+	}
+	backend := newBackendWithRebootingMachine()
+	backend.models = pool.uuids()
+	backend.migrationActive = true
+	s.modelInfo.AgentVersion = version.MustParse("1.2.4")
+
+	report, err := migration.TargetPrecheckReport(backend, pool, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report.Blockers, jc.DeepEquals, []string{
+		"model is being migrated out of target controller",
+		"model has higher version than target controller (1.2.4 > 1.2.3)",
+		"machine 0 is scheduled to reboot",
+		"model with same UUID already exists (model-uuid)",
+		`model named "model-name" already exists`,
+	})
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -749,6 +865,9 @@ type fakeBackend struct {
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	blocks       []migration.PrecheckBlock
+	allBlocksErr error
+
 	controllerBackend *fakeBackend
 }
 
@@ -796,6 +915,10 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) AllBlocks() ([]migration.PrecheckBlock, error) {
+	return b.blocks, b.allBlocksErr
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackend, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -803,6 +926,19 @@ func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackend, error) {
 	return b.controllerBackend, nil
 }
 
+type fakeBlock struct {
+	tag        names.Tag
+	operations []coremodel.BlockOperation
+}
+
+func (b *fakeBlock) Tag() (names.Tag, error) {
+	return b.tag, nil
+}
+
+func (b *fakeBlock) Operations() []coremodel.BlockOperation {
+	return b.operations
+}
+
 type fakePool struct {
 	models []migration.PrecheckModel
 }
@@ -0,0 +1,80 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"github.com/juju/description/v2"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+// The kinds of entity counted to report the progress of a migration.
+const (
+	machineEntities           = "machines"
+	applicationEntities       = "applications"
+	unitEntities              = "units"
+	relationEntities          = "relations"
+	remoteApplicationEntities = "remote-applications"
+)
+
+// ModelEntityCounts returns the number of each kind of entity in the
+// model description, as exported from the source controller of a
+// migration. Containers are counted as machines.
+func ModelEntityCounts(model description.Model) map[string]int {
+	var machines int
+	var countMachines func([]description.Machine)
+	countMachines = func(ms []description.Machine) {
+		for _, m := range ms {
+			machines++
+			countMachines(m.Containers())
+		}
+	}
+	countMachines(model.Machines())
+
+	var units int
+	for _, app := range model.Applications() {
+		units += len(app.Units())
+	}
+	return map[string]int{
+		machineEntities:           machines,
+		applicationEntities:       len(model.Applications()),
+		unitEntities:              units,
+		relationEntities:          len(model.Relations()),
+		remoteApplicationEntities: len(model.RemoteApplications()),
+	}
+}
+
+// StateEntityCounts returns the number of each kind of entity in the
+// model, as imported into the target controller of a migration. The
+// kinds counted match those of ModelEntityCounts.
+func StateEntityCounts(st *state.State) (map[string]int, error) {
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	applications, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var units int
+	for _, app := range applications {
+		units += app.UnitCount()
+	}
+	relations, err := st.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	remoteApplications, err := st.AllRemoteApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return map[string]int{
+		machineEntities:           len(machines),
+		applicationEntities:       len(applications),
+		unitEntities:              units,
+		relationEntities:          len(relations),
+		remoteApplicationEntities: len(remoteApplications),
+	}, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"github.com/juju/description/v2"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/testing"
+)
+
+type EntityCountsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&EntityCountsSuite{})
+
+func (s *EntityCountsSuite) TestModelEntityCounts(c *gc.C) {
+	model := description.NewModel(description.ModelArgs{
+		Owner:  names.NewUserTag("bob"),
+		Config: map[string]interface{}{"uuid": testing.ModelTag.Id()},
+	})
+	m0 := model.AddMachine(description.MachineArgs{Id: names.NewMachineTag("0")})
+	m0.AddContainer(description.MachineArgs{Id: names.NewMachineTag("0/lxd/0")})
+	model.AddMachine(description.MachineArgs{Id: names.NewMachineTag("1")})
+	app := model.AddApplication(description.ApplicationArgs{Tag: names.NewApplicationTag("mysql")})
+	app.AddUnit(description.UnitArgs{Tag: names.NewUnitTag("mysql/0")})
+	app.AddUnit(description.UnitArgs{Tag: names.NewUnitTag("mysql/1")})
+	model.AddApplication(description.ApplicationArgs{Tag: names.NewApplicationTag("wordpress")})
+	model.AddRelation(description.RelationArgs{Id: 1, Key: "wordpress:db mysql:server"})
+
+	c.Check(migration.ModelEntityCounts(model), jc.DeepEquals, map[string]int{
+		"machines":            3,
+		"applications":        2,
+		"units":               2,
+		"relations":           1,
+		"remote-applications": 0,
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/storage"
 )
@@ -107,21 +108,31 @@ type HostFilesystemParams struct {
 // of the given type inside another new machine. The two given templates
 // specify the form of the child and parent respectively.
 func (st *State) AddMachineInsideNewMachine(template, parentTemplate MachineTemplate, containerType instance.ContainerType) (*Machine, error) {
+	requested := quota.Resources{Machines: 2}
+	usageOps, err := quotaOps(st.db(), st.ModelUUID(), requested)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot add a new machine")
+	}
 	mdoc, ops, err := st.addMachineInsideNewMachineOps(template, parentTemplate, containerType)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot add a new machine")
 	}
-	return st.addMachine(mdoc, ops)
+	return st.addMachine(mdoc, append(ops, usageOps...), requested)
 }
 
 // AddMachineInsideMachine adds a machine inside a container of the
 // given type on the existing machine with id=parentId.
 func (st *State) AddMachineInsideMachine(template MachineTemplate, parentId string, containerType instance.ContainerType) (*Machine, error) {
+	requested := quota.Resources{Machines: 1}
+	usageOps, err := quotaOps(st.db(), st.ModelUUID(), requested)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot add a new machine")
+	}
 	mdoc, ops, err := st.addMachineInsideMachineOps(template, parentId, containerType)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot add a new machine")
 	}
-	return st.addMachine(mdoc, ops)
+	return st.addMachine(mdoc, append(ops, usageOps...), requested)
 }
 
 // AddMachine adds a machine with the given series and jobs.
@@ -151,8 +162,12 @@ func (st *State) AddOneMachine(template MachineTemplate) (*Machine, error) {
 // given templates.
 func (st *State) AddMachines(templates ...MachineTemplate) (_ []*Machine, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add a new machine")
+	requested := quota.Resources{Machines: len(templates)}
+	ops, err := quotaOps(st.db(), st.ModelUUID(), requested)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	var ms []*Machine
-	var ops []txn.Op
 	var controllerIds []string
 	for _, template := range templates {
 		mdoc, addOps, err := st.addMachineOps(template)
@@ -176,19 +191,25 @@ func (st *State) AddMachines(templates ...MachineTemplate) (_ []*Machine, err er
 			if err := checkModelActive(st); err != nil {
 				return nil, errors.Trace(err)
 			}
+			if err := checkQuota(st.db(), st.ModelUUID(), requested); err != nil {
+				return nil, errors.Trace(err)
+			}
 		}
 		return nil, errors.Trace(err)
 	}
 	return ms, nil
 }
 
-func (st *State) addMachine(mdoc *machineDoc, ops []txn.Op) (*Machine, error) {
+func (st *State) addMachine(mdoc *machineDoc, ops []txn.Op, requested quota.Resources) (*Machine, error) {
 	ops = append([]txn.Op{assertModelActiveOp(st.ModelUUID())}, ops...)
 	if err := st.db().RunTransaction(ops); err != nil {
 		if errors.Cause(err) == txn.ErrAborted {
 			if err := checkModelActive(st); err != nil {
 				return nil, errors.Trace(err)
 			}
+			if err := checkQuota(st.db(), st.ModelUUID(), requested); err != nil {
+				return nil, errors.Annotate(err, "cannot add a new machine")
+			}
 		}
 		return nil, errors.Trace(err)
 	}
@@ -544,6 +565,13 @@ func (st *State) insertNewMachineOps(mdoc *machineDoc, template MachineTemplate)
 		modificationStatusDoc,
 		template.Constraints,
 	)
+	if template.Constraints.HasLabels() {
+		labelOp, err := labelNewMachineOp(st, mdoc.Id, *template.Constraints.Labels)
+		if err != nil {
+			return nil, txn.Op{}, errors.Trace(err)
+		}
+		prereqOps = append(prereqOps, labelOp)
+	}
 
 	sb, err := NewStorageBackend(st)
 	if err != nil {
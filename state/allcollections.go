@@ -151,6 +151,14 @@ func allCollections() CollectionSchema {
 			global: true,
 		},
 
+		// This collection holds the resource quotas of models, and of
+		// all of the models owned by a user.
+		quotasC: {global: true},
+
+		// This collection holds the named templates from which
+		// models may be created.
+		modelTemplatesC: {global: true},
+
 		// This collection holds the last time the user connected to the API server.
 		userLastLoginC: {
 			global:    true,
@@ -444,8 +452,13 @@ func allCollections() CollectionSchema {
 		// named before multi-model support.
 
 		// This collection holds user annotations for various entities. They
-		// shouldn't be written or interpreted by juju.
-		annotationsC: {},
+		// shouldn't be written by juju, but they are indexed as labels so
+		// that entities can be selected by them.
+		annotationsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "labels"},
+			}},
+		},
 
 		// This collection in particular holds an astounding number of
 		// different sorts of data: application config settings by charm version,
@@ -468,6 +481,14 @@ func allCollections() CollectionSchema {
 				Key: []string{"model-uuid", "_id"},
 			}},
 		},
+		// This collection holds the recent revisions of application
+		// and model config.
+		configHistoryC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "global-key", "-revision"},
+			}},
+		},
+
 		statusesHistoryC: {
 			rawAccess: true,
 			indexes: []mgo.Index{{
@@ -612,6 +633,7 @@ const (
 	spacesC                    = "spaces"
 	statusesC                  = "statuses"
 	statusesHistoryC           = "statuseshistory"
+	configHistoryC             = "configHistory"
 	storageAttachmentsC        = "storageattachments"
 	storageConstraintsC        = "storageconstraints"
 	deviceConstraintsC         = "deviceConstraints"
@@ -629,6 +651,8 @@ const (
 	userLastLoginC             = "userLastLogin"
 	usermodelnameC             = "usermodelname"
 	usersC                     = "users"
+	quotasC                    = "quotas"
+	modelTemplatesC            = "modelTemplates"
 	volumeAttachmentsC         = "volumeattachments"
 	volumeAttachmentPlanC      = "volumeattachmentplan"
 	volumesC                   = "volumes"
@@ -1125,6 +1125,11 @@ type backingBlock blockDoc
 
 func (a *backingBlock) updated(ctx *allWatcherContext) error {
 	allWatcherLogger.Tracef(`block "%s:%s" updated`, ctx.modelUUID, ctx.id)
+	if len(a.Operations) > 0 {
+		// BlockInfo describes blocks on the whole model, so blocks
+		// on individual entities are left out.
+		return nil
+	}
 	info := &multiwatcher.BlockInfo{
 		ModelUUID: a.ModelUUID,
 		ID:        ctx.id, // ID not in the blockDoc
@@ -1032,6 +1032,23 @@ func (s *allWatcherStateSuite) TestChangeBlocks(c *gc.C) {
 				},
 			}
 		},
+		func(c *gc.C, st *State) changeTestCase {
+			AddTestingApplication(c, st, "wordpress", AddTestingCharm(c, st, "wordpress"))
+			tag := names.NewApplicationTag("wordpress")
+			err := st.SwitchEntityBlockOn(tag, []model.BlockOperation{model.BlockOperationRemove}, "multiwatcher testing")
+			c.Assert(err, jc.ErrorIsNil)
+			b, found, err := st.GetEntityBlock(tag)
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(found, jc.IsTrue)
+
+			return changeTestCase{
+				about: "block on an entity is not added to the Store",
+				change: watcher.Change{
+					C:  blocksC,
+					Id: b.Id(),
+				},
+			}
+		},
 	}
 	s.performChangeTestCases(c, changeTestFuncs)
 }
@@ -12,6 +12,8 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	coreannotations "github.com/juju/juju/core/annotations"
 )
 
 // annotatorDoc represents the internal state of annotations for an Entity in
@@ -24,6 +26,10 @@ type annotatorDoc struct {
 	GlobalKey   string            `bson:"globalkey"`
 	Tag         string            `bson:"tag"`
 	Annotations map[string]string `bson:"annotations"`
+
+	// Labels holds the annotations as sorted key=value strings. It is
+	// indexed, so that entities can be selected by their annotations.
+	Labels []string `bson:"labels,omitempty"`
 }
 
 // SetAnnotations adds key/value pairs to annotations in MongoDB.
@@ -37,7 +43,8 @@ func (m *Model) SetAnnotations(entity GlobalEntity, annotations map[string]strin
 	toInsert := make(map[string]string)
 	toUpdate := make(bson.M)
 	for key, value := range annotations {
-		if strings.Contains(key, ".") {
+		// Keys are labelled as key=value, so can't contain "=".
+		if strings.Contains(key, ".") || strings.Contains(key, "=") {
 			return fmt.Errorf("invalid key %q", key)
 		}
 		if value == "" {
@@ -56,16 +63,33 @@ func (m *Model) SetAnnotations(entity GlobalEntity, annotations map[string]strin
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		annotations, closer := m.st.db().GetCollection(annotationsC)
 		defer closer()
-		if count, err := annotations.FindId(entity.globalKey()).Count(); err != nil {
-			return nil, err
-		} else if count == 0 {
+		var doc struct {
+			Annotations map[string]string `bson:"annotations"`
+			TxnRevno    int64             `bson:"txn-revno"`
+		}
+		err := annotations.FindId(entity.globalKey()).One(&doc)
+		if err == mgo.ErrNotFound {
 			// Check that the annotator entity was not previously destroyed.
 			if attempt != 0 {
 				return nil, fmt.Errorf("%s no longer exists", entity.Tag())
 			}
 			return insertAnnotationsOps(m.st, entity, toInsert)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// The labels are rebuilt from the updated annotations, so
+		// the update must not race with any other.
+		updated := make(map[string]string)
+		for key, value := range doc.Annotations {
+			updated[key] = value
 		}
-		return updateAnnotations(m.st, entity, toUpdate, toRemove), nil
+		for key, value := range toInsert {
+			updated[key] = value
+		}
+		for key := range toRemove {
+			delete(updated, key)
+		}
+		return updateAnnotations(m.st, entity, doc.TxnRevno, toUpdate, toRemove, updated), nil
 	}
 	return m.st.db().Run(buildTxn)
 }
@@ -96,6 +120,31 @@ func (m *Model) Annotation(entity GlobalEntity, key string) (string, error) {
 	return ann[key], nil
 }
 
+// LabelledEntities returns the tags of all the entities in the model
+// whose annotations satisfy the given selector, sorted by tag.
+func (m *Model) LabelledEntities(selector coreannotations.Selector) ([]names.Tag, error) {
+	if len(selector) == 0 {
+		return nil, errors.NotValidf("empty selector")
+	}
+	annotations, closer := m.st.db().GetCollection(annotationsC)
+	defer closer()
+	var docs []annotatorDoc
+	err := annotations.Find(bson.D{{"labels", bson.D{{"$all", selector.Terms()}}}}).
+		Select(bson.D{{"tag", 1}}).Sort("tag").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot select entities by %q", selector)
+	}
+	tags := make([]names.Tag, 0, len(docs))
+	for _, doc := range docs {
+		tag, err := names.ParseTag(doc.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
 // insertAnnotationsOps returns the operations required to insert annotations in MongoDB.
 func insertAnnotationsOps(st *State, entity GlobalEntity, toInsert map[string]string) ([]txn.Op, error) {
 	tag := entity.Tag()
@@ -107,6 +156,7 @@ func insertAnnotationsOps(st *State, entity GlobalEntity, toInsert map[string]st
 			GlobalKey:   entity.globalKey(),
 			Tag:         tag.String(),
 			Annotations: toInsert,
+			Labels:      coreannotations.Labels(toInsert),
 		},
 	}}
 
@@ -133,13 +183,43 @@ func insertAnnotationsOps(st *State, entity GlobalEntity, toInsert map[string]st
 	}), nil
 }
 
-// updateAnnotations returns the operations required to update or remove annotations in MongoDB.
-func updateAnnotations(mb modelBackend, entity GlobalEntity, toUpdate, toRemove bson.M) []txn.Op {
+// labelNewMachineOp returns the operation to annotate a new machine with
+// the labels of its constraints, so that it satisfies them.
+func labelNewMachineOp(st *State, machineId string, labels []string) (txn.Op, error) {
+	selector, err := coreannotations.SelectorFromLabels(labels)
+	if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	globalKey := machineGlobalKey(machineId)
+	return txn.Op{
+		C:      annotationsC,
+		Id:     st.docID(globalKey),
+		Assert: txn.DocMissing,
+		Insert: &annotatorDoc{
+			GlobalKey:   globalKey,
+			Tag:         names.NewMachineTag(machineId).String(),
+			Annotations: selector,
+			Labels:      selector.Terms(),
+		},
+	}, nil
+}
+
+// updateAnnotations returns the operations required to update or remove
+// annotations in MongoDB, given the full set of annotations that results.
+func updateAnnotations(
+	mb modelBackend, entity GlobalEntity, txnRevno int64,
+	toUpdate, toRemove bson.M, updated map[string]string,
+) []txn.Op {
+	update := setUnsetUpdateAnnotations(toUpdate, toRemove)
+	update = append(update, bson.DocElem{
+		Name:  "$set",
+		Value: bson.D{{"labels", coreannotations.Labels(updated)}},
+	})
 	return []txn.Op{{
 		C:      annotationsC,
 		Id:     mb.docID(entity.globalKey()),
-		Assert: txn.DocExists,
-		Update: setUnsetUpdateAnnotations(toUpdate, toRemove),
+		Assert: bson.D{{"txn-revno", txnRevno}},
+		Update: update,
 	}}
 }
 
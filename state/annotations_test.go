@@ -10,6 +10,7 @@ import (
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 
+	coreannotations "github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
@@ -39,6 +40,11 @@ func (s *AnnotationsSuite) TestSetAnnotationsInvalidKey(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, ".*invalid key.*")
 }
 
+func (s *AnnotationsSuite) TestSetAnnotationsKeyWithEquals(c *gc.C) {
+	err := s.setAnnotationResult(c, "team=payments", "true")
+	c.Assert(errors.Cause(err), gc.ErrorMatches, ".*invalid key.*")
+}
+
 func (s *AnnotationsSuite) TestSetAnnotationsCreate(c *gc.C) {
 	s.createTestAnnotation(c)
 }
@@ -134,6 +140,41 @@ func (s *AnnotationsSuite) TestSetAnnotationsConcurrently(c *gc.C) {
 	assertAnnotation(c, s.Model, s.testEntity, key, last)
 }
 
+func (s *AnnotationsSuite) TestLabelledEntities(c *gc.C) {
+	other, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.Factory.MakeApplication(c, nil)
+
+	err = s.Model.SetAnnotations(s.testEntity, map[string]string{"team": "payments", "env": "prod"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetAnnotations(other, map[string]string{"team": "payments", "env": "dev"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetAnnotations(app, map[string]string{"team": "payments"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	tags, err := s.Model.LabelledEntities(coreannotations.Selector{"team": "payments"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, jc.DeepEquals, []names.Tag{app.Tag(), s.testEntity.Tag(), other.Tag()})
+
+	tags, err = s.Model.LabelledEntities(coreannotations.Selector{"team": "payments", "env": "prod"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, jc.DeepEquals, []names.Tag{s.testEntity.Tag()})
+
+	// Labels follow changes to the annotations.
+	err = s.Model.SetAnnotations(s.testEntity, map[string]string{"env": ""})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetAnnotations(other, map[string]string{"env": "prod"})
+	c.Assert(err, jc.ErrorIsNil)
+	tags, err = s.Model.LabelledEntities(coreannotations.Selector{"team": "payments", "env": "prod"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, jc.DeepEquals, []names.Tag{other.Tag()})
+}
+
+func (s *AnnotationsSuite) TestLabelledEntitiesEmptySelector(c *gc.C) {
+	_, err := s.Model.LabelledEntities(nil)
+	c.Assert(err, gc.ErrorMatches, "empty selector not valid")
+}
+
 type AnnotationsModelSuite struct {
 	ConnSuite
 }
@@ -30,6 +30,8 @@ import (
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/quota"
+	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/core/status"
 	mgoutils "github.com/juju/juju/mongo/utils"
 	stateerrors "github.com/juju/juju/state/errors"
@@ -542,7 +544,11 @@ func (op *DestroyApplicationOperation) unassignBranchOps() ([]txn.Op, error) {
 		// assumption: branches from applicationBranches will
 		// ALWAYS have the appName in assigned-units, but not
 		// always in config.
-		ops = append(ops, b.unassignAppOps(appName)...)
+		branchOps, err := b.unassignAppOps(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, branchOps...)
 	}
 	return ops, nil
 }
@@ -624,6 +630,8 @@ func (a *Application) removeOps(asserts bson.D, op *ForcedOperation) ([]txn.Op,
 		removeSettingsOp(settingsC, a.applicationConfigKey()),
 		removeModelApplicationRefOp(a.st, name),
 		removePodSpecOp(a.ApplicationTag()),
+		removeEntityBlockOp(a.st, a.ApplicationTag()),
+		newCleanupOp(cleanupConfigHistory, globalKey),
 	)
 	return ops, nil
 }
@@ -691,6 +699,28 @@ func (a *Application) CharmURL() (curl *charm.URL, force bool) {
 	return a.doc.CharmURL, a.doc.ForceCharm
 }
 
+// CharmURLForUnit returns the charm URL that the unit with the input name
+// should run, and whether it should upgrade to that charm even if it is in
+// an error state. This is the charm staged for the application under the
+// branch that the unit tracks, if there is one, or else the application's
+// charm.
+func (a *Application) CharmURLForUnit(unitName string) (*charm.URL, bool, error) {
+	m, err := a.st.Model()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	branch, err := m.unitBranch(unitName)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if branch != nil {
+		if staged, ok := branch.CharmFor(a.doc.Name); ok {
+			return staged.URL, staged.ForceUnits, nil
+		}
+	}
+	return a.doc.CharmURL, a.doc.ForceCharm, nil
+}
+
 // Channel identifies the charm store channel from which the application's
 // charm was deployed. It is only needed when interacting with the charm
 // store.
@@ -897,19 +927,25 @@ func (a *Application) checkStorageUpgrade(newMeta, oldMeta *charm.Meta, units []
 }
 
 // changeCharmOps returns the operations necessary to set a application's
-// charm URL to a new value.
+// charm URL to a new value. Any config changes are applied to the old
+// settings before they are carried over to the new charm. If haveRef is
+// true, the caller already holds a reference to the new charm and its
+// settings, which the application takes over.
 func (a *Application) changeCharmOps(
 	ch *Charm,
 	channel string,
 	updatedSettings charm.Settings,
+	configChanges settings.ItemChanges,
 	forceUnits bool,
 	resourceIDs map[string]string,
 	updatedStorageConstraints map[string]StorageConstraints,
+	haveRef bool,
 ) ([]txn.Op, error) {
 	// Build the new application config from what can be used of the old one.
 	var newSettings charm.Settings
 	oldKey, err := readSettings(a.st.db(), settingsC, a.charmConfigKey())
 	if err == nil {
+		oldKey.applyChanges(configChanges)
 		// Filter the old settings through to get the new settings.
 		newSettings = ch.Config().FilterSettings(oldKey.Map())
 		for k, v := range updatedSettings {
@@ -968,9 +1004,12 @@ func (a *Application) changeCharmOps(
 
 	// Add or create a reference to the new charm, settings,
 	// and storage constraints docs.
-	incOps, err := appCharmIncRefOps(a.st, a.doc.Name, ch.URL(), true)
-	if err != nil {
-		return nil, errors.Trace(err)
+	var incOps []txn.Op
+	if !haveRef {
+		incOps, err = appCharmIncRefOps(a.st, a.doc.Name, ch.URL(), true)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 	var decOps []txn.Op
 	// Drop the references to the old settings, storage constraints,
@@ -1283,22 +1322,191 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	defer errors.DeferredAnnotatef(
 		&err, "cannot upgrade application %q to charm %q", a, cfg.Charm,
 	)
+	updatedSettings, err := a.validateSetCharm(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var newCharmModifiedVersion int
+	channel := string(cfg.Channel)
+	acopy := &Application{a.st, a.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		a := acopy
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+
+		// Record the current value of charmModifiedVersion, so we can
+		// set the value on the method receiver's in-memory document
+		// structure. We increment the version only when we change the
+		// charm URL.
+		newCharmModifiedVersion = a.doc.CharmModifiedVersion
+		if a.doc.CharmURL.String() != cfg.Charm.URL().String() {
+			newCharmModifiedVersion++
+		}
+		return a.setCharmOps(cfg, updatedSettings, nil, false)
+	}
+
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return err
+	}
+	a.doc.CharmURL = cfg.Charm.URL()
+	a.doc.Channel = channel
+	a.doc.ForceCharm = cfg.ForceUnits
+	a.doc.CharmModifiedVersion = newCharmModifiedVersion
+	return nil
+}
+
+// setCharmOps returns the operations that change the application's charm
+// as described by cfg, given the config settings validated for it. The
+// config changes and haveRef are passed on to changeCharmOps if the charm
+// URL changes.
+func (a *Application) setCharmOps(
+	cfg SetCharmConfig,
+	updatedSettings charm.Settings,
+	configChanges settings.ItemChanges,
+	haveRef bool,
+) ([]txn.Op, error) {
+	channel := string(cfg.Channel)
+
+	// NOTE: We're explicitly allowing SetCharm to succeed
+	// when the application is Dying, because application/charm
+	// upgrades should still be allowed to apply to dying
+	// applications and units, so that bugs in departed/broken
+	// hooks can be addressed at runtime.
+	if a.Life() == Dead {
+		return nil, stateerrors.ErrDead
+	}
+
+	ops := []txn.Op{{
+		C:  applicationsC,
+		Id: a.doc.DocID,
+		Assert: append(notDeadDoc, bson.DocElem{
+			"charmmodifiedversion", a.doc.CharmModifiedVersion,
+		}),
+	}}
+
+	if a.doc.CharmURL.String() == cfg.Charm.URL().String() {
+		// Charm URL already set; just update the force flag and channel.
+		ops = append(ops, txn.Op{
+			C:  applicationsC,
+			Id: a.doc.DocID,
+			Update: bson.D{{"$set", bson.D{
+				{"cs-channel", channel},
+				{"forcecharm", cfg.ForceUnits},
+			}}},
+		})
+	} else {
+		// Check if the new charm specifies a relation max limit
+		// that cannot be satisfied by the currently established
+		// relation count.
+		quotaErr := a.preUpgradeRelationLimitCheck(cfg.Charm)
+
+		// If the operator specified --force, we still allow
+		// the upgrade to continue with a warning.
+		if errors.IsQuotaLimitExceeded(quotaErr) && cfg.Force {
+			logger.Warningf("%v; allowing upgrade to proceed as the operator specified --force", quotaErr)
+		} else if quotaErr != nil {
+			return nil, errors.Trace(quotaErr)
+		}
+
+		chng, err := a.changeCharmOps(
+			cfg.Charm,
+			channel,
+			updatedSettings,
+			configChanges,
+			cfg.ForceUnits,
+			cfg.ResourceIDs,
+			cfg.StorageConstraints,
+			haveRef,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, chng...)
+	}
+
+	// Always update bindings regardless of whether we upgrade to a
+	// new version or stay at the previous version.
+	currentMap, txnRevno, err := readEndpointBindings(a.st, a.globalKey())
+	if err != nil && !errors.IsNotFound(err) {
+		return ops, errors.Trace(err)
+	}
+	b, err := a.bindingsForOps(currentMap)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	endpointBindingsOps, err := b.updateOps(txnRevno, cfg.EndpointBindings, cfg.Charm.Meta(), cfg.Force)
+	if err == nil {
+		ops = append(ops, endpointBindingsOps...)
+	} else if !errors.IsNotFound(err) && err != jujutxn.ErrNoOperations {
+		// If endpoint bindings do not exist this most likely means the application
+		// itself no longer exists, which will be caught soon enough anyway.
+		// ErrNoOperations on the other hand means there's nothing to update.
+		return nil, errors.Trace(err)
+	}
+
+	return ops, nil
+}
+
+// SetBranchCharm stages an upgrade of the application to the charm in the
+// input config under the branch with the input name. Units tracking the
+// branch are upgraded to the charm straight away; the application and its
+// other units are upgraded, and any resources in the config activated,
+// when the branch is committed. Staging the application's current charm
+// removes any upgrade staged for it.
+func (a *Application) SetBranchCharm(branchName string, cfg SetCharmConfig) (err error) {
+	defer errors.DeferredAnnotatef(
+		&err, "cannot upgrade application %q to charm %q in branch %q", a, cfg.Charm, branchName,
+	)
+	if len(cfg.ConfigSettings) > 0 {
+		return errors.NotSupportedf("changing config settings with a charm upgrade in a branch")
+	}
+	if len(cfg.StorageConstraints) > 0 {
+		return errors.NotSupportedf("changing storage constraints in a branch")
+	}
+	if len(cfg.EndpointBindings) > 0 {
+		return errors.NotSupportedf("changing endpoint bindings in a branch")
+	}
+	if _, err := a.validateSetCharm(cfg); err != nil {
+		return errors.Trace(err)
+	}
+	if a.doc.CharmURL.String() != cfg.Charm.URL().String() {
+		quotaErr := a.preUpgradeRelationLimitCheck(cfg.Charm)
+		if errors.IsQuotaLimitExceeded(quotaErr) && cfg.Force {
+			logger.Warningf("%v; allowing upgrade to proceed as the operator specified --force", quotaErr)
+		} else if quotaErr != nil {
+			return errors.Trace(quotaErr)
+		}
+	}
+	branch, err := a.st.Branch(branchName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(branch.updateCharm(a, cfg))
+}
+
+// validateSetCharm checks that the application can be upgraded to the
+// charm in the input config, returning the validated config settings.
+func (a *Application) validateSetCharm(cfg SetCharmConfig) (charm.Settings, error) {
 	if cfg.Charm.Meta().Subordinate != a.doc.Subordinate {
-		return errors.Errorf("cannot change an application's subordinacy")
+		return nil, errors.Errorf("cannot change an application's subordinacy")
 	}
 	currentCharm, err := a.st.Charm(a.doc.CharmURL)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 	if cfg.Charm.Meta().Deployment != currentCharm.Meta().Deployment {
 		if currentCharm.Meta().Deployment == nil || currentCharm.Meta().Deployment == nil {
-			return errors.New("cannot change a charm's deployment info")
+			return nil, errors.New("cannot change a charm's deployment info")
 		}
 		if cfg.Charm.Meta().Deployment.DeploymentType != currentCharm.Meta().Deployment.DeploymentType {
-			return errors.New("cannot change a charm's deployment type")
+			return nil, errors.New("cannot change a charm's deployment type")
 		}
 		if cfg.Charm.Meta().Deployment.DeploymentMode != currentCharm.Meta().Deployment.DeploymentMode {
-			return errors.New("cannot change a charm's deployment mode")
+			return nil, errors.New("cannot change a charm's deployment mode")
 		}
 	}
 	// For old style charms written for only one series, we still retain
@@ -1306,7 +1514,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	// with series = "".
 	if cfg.Charm.URL().Series != "" {
 		if cfg.Charm.URL().Series != a.doc.Series {
-			return errors.Errorf("cannot change an application's series")
+			return nil, errors.Errorf("cannot change an application's series")
 		}
 	} else if !cfg.ForceSeries {
 		supported := false
@@ -1321,7 +1529,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 			if len(cfg.Charm.Meta().Series) > 0 {
 				supportedSeries = strings.Join(cfg.Charm.Meta().Series, ", ")
 			}
-			return errors.Errorf("only these series are supported: %v", supportedSeries)
+			return nil, errors.Errorf("only these series are supported: %v", supportedSeries)
 		}
 	} else {
 		// Even with forceSeries=true, we do not allow a charm to be used which is for
@@ -1331,14 +1539,14 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 		if err != nil {
 			// We don't expect an error here but there's not much we can
 			// do to recover.
-			return err
+			return nil, err
 		}
 		supportedOS := false
 		supportedSeries := cfg.Charm.Meta().Series
 		for _, chSeries := range supportedSeries {
 			charmSeriesOS, err := series.GetOSFromSeries(chSeries)
 			if err != nil {
-				return nil
+				return nil, nil
 			}
 			if currentOS == charmSeriesOS {
 				supportedOS = true
@@ -1346,13 +1554,13 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 			}
 		}
 		if !supportedOS && len(supportedSeries) > 0 {
-			return errors.Errorf("OS %q not supported by charm", currentOS)
+			return nil, errors.Errorf("OS %q not supported by charm", currentOS)
 		}
 	}
 
 	updatedSettings, err := cfg.Charm.Config().ValidateSettings(cfg.ConfigSettings)
 	if err != nil {
-		return errors.Annotate(err, "validating config settings")
+		return nil, errors.Annotate(err, "validating config settings")
 	}
 
 	// we don't need to check that this is a charm.LXDProfiler, as we can
@@ -1361,114 +1569,11 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 		// Validate the config devices, to ensure we don't apply an invalid
 		// profile, if we know it's never going to work.
 		if err := profile.ValidateConfigDevices(); err != nil && !cfg.Force {
-			return errors.Annotate(err, "validating lxd profile")
-		}
-	}
-
-	var newCharmModifiedVersion int
-	channel := string(cfg.Channel)
-	acopy := &Application{a.st, a.doc}
-	buildTxn := func(attempt int) ([]txn.Op, error) {
-		a := acopy
-		if attempt > 0 {
-			if err := a.Refresh(); err != nil {
-				return nil, errors.Trace(err)
-			}
+			return nil, errors.Annotate(err, "validating lxd profile")
 		}
-
-		// NOTE: We're explicitly allowing SetCharm to succeed
-		// when the application is Dying, because application/charm
-		// upgrades should still be allowed to apply to dying
-		// applications and units, so that bugs in departed/broken
-		// hooks can be addressed at runtime.
-		if a.Life() == Dead {
-			return nil, stateerrors.ErrDead
-		}
-
-		// Record the current value of charmModifiedVersion, so we can
-		// set the value on the method receiver's in-memory document
-		// structure. We increment the version only when we change the
-		// charm URL.
-		newCharmModifiedVersion = a.doc.CharmModifiedVersion
-
-		ops := []txn.Op{{
-			C:  applicationsC,
-			Id: a.doc.DocID,
-			Assert: append(notDeadDoc, bson.DocElem{
-				"charmmodifiedversion", a.doc.CharmModifiedVersion,
-			}),
-		}}
-
-		if a.doc.CharmURL.String() == cfg.Charm.URL().String() {
-			// Charm URL already set; just update the force flag and channel.
-			ops = append(ops, txn.Op{
-				C:  applicationsC,
-				Id: a.doc.DocID,
-				Update: bson.D{{"$set", bson.D{
-					{"cs-channel", channel},
-					{"forcecharm", cfg.ForceUnits},
-				}}},
-			})
-		} else {
-			// Check if the new charm specifies a relation max limit
-			// that cannot be satisfied by the currently established
-			// relation count.
-			quotaErr := a.preUpgradeRelationLimitCheck(cfg.Charm)
-
-			// If the operator specified --force, we still allow
-			// the upgrade to continue with a warning.
-			if errors.IsQuotaLimitExceeded(quotaErr) && cfg.Force {
-				logger.Warningf("%v; allowing upgrade to proceed as the operator specified --force", quotaErr)
-			} else if quotaErr != nil {
-				return nil, errors.Trace(quotaErr)
-			}
-
-			chng, err := a.changeCharmOps(
-				cfg.Charm,
-				channel,
-				updatedSettings,
-				cfg.ForceUnits,
-				cfg.ResourceIDs,
-				cfg.StorageConstraints,
-			)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			ops = append(ops, chng...)
-			newCharmModifiedVersion++
-		}
-
-		// Always update bindings regardless of whether we upgrade to a
-		// new version or stay at the previous version.
-		currentMap, txnRevno, err := readEndpointBindings(a.st, a.globalKey())
-		if err != nil && !errors.IsNotFound(err) {
-			return ops, errors.Trace(err)
-		}
-		b, err := a.bindingsForOps(currentMap)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		endpointBindingsOps, err := b.updateOps(txnRevno, cfg.EndpointBindings, cfg.Charm.Meta(), cfg.Force)
-		if err == nil {
-			ops = append(ops, endpointBindingsOps...)
-		} else if !errors.IsNotFound(err) && err != jujutxn.ErrNoOperations {
-			// If endpoint bindings do not exist this most likely means the application
-			// itself no longer exists, which will be caught soon enough anyway.
-			// ErrNoOperations on the other hand means there's nothing to update.
-			return nil, errors.Trace(err)
-		}
-
-		return ops, nil
 	}
 
-	if err := a.st.db().Run(buildTxn); err != nil {
-		return err
-	}
-	a.doc.CharmURL = cfg.Charm.URL()
-	a.doc.Channel = channel
-	a.doc.ForceCharm = cfg.ForceUnits
-	a.doc.CharmModifiedVersion = newCharmModifiedVersion
-	return nil
+	return updatedSettings, nil
 }
 
 // preUpgradeRelationLimitCheck ensures that the already established relation
@@ -2231,10 +2336,20 @@ type AddUnitParams struct {
 // AddUnit adds a new principal unit to the application.
 func (a *Application) AddUnit(args AddUnitParams) (unit *Unit, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add unit to application %q", a)
+	storageCons, err := a.StorageConstraints()
+	if err != nil {
+		return nil, err
+	}
+	requested := quota.Resources{Units: 1, StorageMiB: storageQuotaSize(storageCons)}
+	usageOps, err := quotaOps(a.st.db(), a.st.ModelUUID(), requested)
+	if err != nil {
+		return nil, err
+	}
 	name, ops, err := a.addUnitOps("", args, nil)
 	if err != nil {
 		return nil, err
 	}
+	ops = append(ops, usageOps...)
 
 	if err := a.st.db().RunTransaction(ops); err == txn.ErrAborted {
 		if alive, err := isAlive(a.st, applicationsC, a.doc.DocID); err != nil {
@@ -2242,6 +2357,9 @@ func (a *Application) AddUnit(args AddUnitParams) (unit *Unit, err error) {
 		} else if !alive {
 			return nil, applicationNotAliveErr
 		}
+		if err := checkQuota(a.st.db(), a.st.ModelUUID(), requested); err != nil {
+			return nil, err
+		}
 		return nil, errors.New("inconsistent state")
 	} else if err != nil {
 		return nil, err
@@ -2475,6 +2593,13 @@ func branchCharmSettings(st *State, cURL *charm.URL, appName, branchName string)
 // UpdateCharmConfig changes a application's charm config settings. Values set
 // to nil will be deleted; unknown and invalid values will return an error.
 func (a *Application) UpdateCharmConfig(branchName string, changes charm.Settings) error {
+	return a.UpdateCharmConfigByUser("", branchName, changes)
+}
+
+// UpdateCharmConfigByUser changes the application's charm config as
+// UpdateCharmConfig does. Changes made to the master branch are recorded
+// in the application's config history as made by the input user.
+func (a *Application) UpdateCharmConfigByUser(userName, branchName string, changes charm.Settings) error {
 	ch, _, err := a.Charm()
 	if err != nil {
 		return errors.Trace(err)
@@ -2494,14 +2619,25 @@ func (a *Application) UpdateCharmConfig(branchName string, changes charm.Setting
 	}
 
 	if branchName == model.GenerationMaster {
-		return errors.Trace(a.updateMasterConfig(current, changes))
+		return errors.Trace(a.updateMasterConfig(current, changes, userName))
 	}
 	return errors.Trace(a.updateBranchConfig(branchName, current, changes))
 }
 
 // TODO (manadart 2019-04-03): Implement master config changes as
 // instantly committed branches.
-func (a *Application) updateMasterConfig(current *Settings, validChanges charm.Settings) error {
+func (a *Application) updateMasterConfig(current *Settings, validChanges charm.Settings, userName string) error {
+	applyCharmSettings(current, validChanges)
+	ops, err := a.configWriteOps(current, CharmConfigScope, userName)
+	if err != nil || len(ops) == 0 {
+		return errors.Trace(err)
+	}
+	return errors.Trace(current.write(ops))
+}
+
+// applyCharmSettings sets the input charm config values on the settings,
+// deleting those that are nil.
+func applyCharmSettings(current *Settings, validChanges charm.Settings) {
 	for name, value := range validChanges {
 		if value == nil {
 			current.Delete(name)
@@ -2509,8 +2645,18 @@ func (a *Application) updateMasterConfig(current *Settings, validChanges charm.S
 			current.Set(name, value)
 		}
 	}
-	_, err := current.Write()
-	return errors.Trace(err)
+}
+
+// configWriteOps returns the operations that write the changes made to
+// the application's config settings, recording them in its config history
+// as made by the input user.
+func (a *Application) configWriteOps(s *Settings, scope ConfigScope, userName string) ([]txn.Op, error) {
+	changes, ops := s.settingsUpdateOps()
+	historyOps, err := configRevisionOps(a.st, a.globalKey(), scope, userName, changes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, historyOps...), nil
 }
 
 // updateBranchConfig compares the incoming charm settings to the current
@@ -2544,11 +2690,43 @@ func (a *Application) UpdateApplicationConfig(
 	schema environschema.Fields,
 	defaults schema.Defaults,
 ) error {
+	return a.UpdateApplicationConfigByUser("", changes, reset, schema, defaults)
+}
+
+// UpdateApplicationConfigByUser changes the application's config settings
+// as UpdateApplicationConfig does, recording the changes in the
+// application's config history as made by the input user.
+func (a *Application) UpdateApplicationConfigByUser(
+	userName string,
+	changes application.ConfigAttributes,
+	reset []string,
+	schema environschema.Fields,
+	defaults schema.Defaults,
+) error {
+	node, err := a.updatedApplicationConfig(changes, reset, schema, defaults)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops, err := a.configWriteOps(node, ApplicationConfigScope, userName)
+	if err != nil || len(ops) == 0 {
+		return errors.Trace(err)
+	}
+	return node.write(ops)
+}
+
+// updatedApplicationConfig returns the application's config settings with
+// the input changes and resets applied and validated, but not written.
+func (a *Application) updatedApplicationConfig(
+	changes application.ConfigAttributes,
+	reset []string,
+	schema environschema.Fields,
+	defaults schema.Defaults,
+) (*Settings, error) {
 	node, err := readSettings(a.st.db(), settingsC, a.applicationConfigKey())
 	if errors.IsNotFound(err) {
-		return errors.Errorf("cannot update application config since no config exists for application %v", a.doc.Name)
+		return nil, errors.Errorf("cannot update application config since no config exists for application %v", a.doc.Name)
 	} else if err != nil {
-		return errors.Annotatef(err, "application config for application %q", a.doc.Name)
+		return nil, errors.Annotatef(err, "application config for application %q", a.doc.Name)
 	}
 	resetKeys := set.NewStrings(reset...)
 	for name, value := range changes {
@@ -2562,18 +2740,17 @@ func (a *Application) UpdateApplicationConfig(
 	}
 	newConfig, err := application.NewConfig(node.Map(), schema, defaults)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 	if err := newConfig.Validate(); err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 	// Update node so it gets coerced values with correct types.
 	coerced := newConfig.Attributes()
 	for _, key := range node.Keys() {
 		node.Set(key, coerced[key])
 	}
-	_, err = node.Write()
-	return err
+	return node, nil
 }
 
 // LeaderSettings returns a application's leader settings. If nothing has been set
@@ -2652,13 +2829,42 @@ func (a *Application) SetConstraints(cons constraints.Value) (err error) {
 	if a.doc.Life != Alive {
 		return applicationNotAliveErr
 	}
-	ops := []txn.Op{{
+	return onAbort(a.st.db().RunTransaction(a.setConstraintsOps(cons)), applicationNotAliveErr)
+}
+
+// setConstraintsOps returns the operations that replace the application's
+// constraints, so long as the application is alive.
+func (a *Application) setConstraintsOps(cons constraints.Value) []txn.Op {
+	return []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-	}}
-	ops = append(ops, setConstraintsOp(a.globalKey(), cons))
-	return onAbort(a.st.db().RunTransaction(ops), applicationNotAliveErr)
+	}, setConstraintsOp(a.globalKey(), cons)}
+}
+
+// SetBranchConstraints stages constraints for the application under the
+// branch with the input name. They replace the application's constraints
+// when the branch is committed.
+func (a *Application) SetBranchConstraints(branchName string, cons constraints.Value) (err error) {
+	unsupported, err := a.st.validateConstraints(cons)
+	if len(unsupported) > 0 {
+		logger.Warningf(
+			"setting constraints on application %q: unsupported constraints: %v", a.Name(), strings.Join(unsupported, ","))
+	} else if err != nil {
+		return err
+	}
+	if a.doc.Subordinate {
+		return ErrSubordinateConstraints
+	}
+	defer errors.DeferredAnnotatef(&err, "cannot set constraints in branch %q", branchName)
+	if a.doc.Life != Alive {
+		return applicationNotAliveErr
+	}
+	branch, err := a.st.Branch(branchName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(branch.updateConstraints(a.doc.Name, cons))
 }
 
 // EndpointBindings returns the mapping for each endpoint name and the space
@@ -942,6 +942,33 @@ func (s *assignCleanSuite) TestAssignUsingConstraintsToMachine(c *gc.C) {
 	}
 }
 
+func (s *assignCleanSuite) TestAssignUsingLabelConstraints(c *gc.C) {
+	err := s.wordpress.SetConstraints(constraints.MustParse("labels=team=payments"))
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	unlabelled, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetAnnotations(other, map[string]string{"team": "search"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.assignUnit(unit)
+	c.Assert(err, gc.ErrorMatches, eligibleMachinesInUse)
+
+	labelled, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetAnnotations(labelled, map[string]string{"team": "payments", "env": "prod"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.assignUnit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Id(), gc.Equals, labelled.Id())
+	c.Assert(m.Id(), gc.Not(gc.Equals), unlabelled.Id())
+}
+
 func (s *assignCleanSuite) TestAssignUnitWithRemovedApplication(c *gc.C) {
 	_, err := s.State.AddMachine("quantal", state.JobManageModel) // bootstrap machine
 	c.Assert(err, jc.ErrorIsNil)
@@ -39,6 +39,7 @@ import (
 	"io"
 	"os"
 	"path"
+	"sort"
 	"strings"
 	"time"
 
@@ -46,6 +47,8 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
 	"github.com/juju/utils/filestorage"
+
+	"github.com/juju/juju/mongo"
 )
 
 const (
@@ -87,7 +90,8 @@ func StoreArchive(stor filestorage.FileStorage, meta *Metadata, file io.Reader)
 // Backups is an abstraction around all juju backup-related functionality.
 type Backups interface {
 	// Create creates a new juju backup archive. It updates
-	// the provided metadata.
+	// the provided metadata. If the metadata records an encryption
+	// recipient, the archive is encrypted to that public key.
 	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error)
 
 	// Add stores the backup archive and returns its new ID.
@@ -126,12 +130,50 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
 
+	// Record where in the oplog this backup starts, so that a later
+	// incremental backup can follow it. An incremental backup itself
+	// only dumps the oplog entries since the backup it follows.
+	meta.OplogTimestamp = dbInfo.Oplog.Last
+	if dbInfo.Incremental {
+		base, err := b.incrementalBase()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		if dbInfo.Oplog.First == 0 || dbInfo.Oplog.First > base.OplogTimestamp {
+			return "", errors.Errorf("oplog no longer holds the changes since backup %q, a full backup is needed", base.ID())
+		}
+		meta.BaseID = base.ID()
+		incremental := *dbInfo
+		incremental.since = base.OplogTimestamp
+		dbInfo = &incremental
+	}
+	// Incremental dumps are small, so they are always staged.
+	stream := dbInfo.Stream && !dbInfo.Incremental
+	if stream && dbInfo.MongoVersion.NewerThan(mongo.Mongo32wt) < 0 {
+		return "", errors.NotSupportedf("streaming backups with mongo %s", dbInfo.MongoVersion)
+	}
+
+	// The archive is encrypted if the metadata names a recipient. The
+	// metadata file inside the archive describes the plain archive it
+	// will be found in once decrypted, so it records no encryption.
+	var encryptTo *PublicKey
+	innerMeta := *meta
+	if meta.Encryption != nil {
+		recipient, err := ParsePublicKey(meta.Encryption.Recipient)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		encryptTo = &recipient
+		meta.Encryption.Scheme = EncryptionScheme
+		innerMeta.Encryption = nil
+	}
+
 	// The metadata file will not contain the ID or the "finished" data.
 	// However, that information is not as critical. The alternatives
 	// are either adding the metadata file to the archive after the fact
 	// or adding placeholders here for the finished data and filling
 	// them in afterward.  Neither is particularly trivial.
-	metadataFile, err := meta.AsJSONBuffer()
+	metadataFile, err := innerMeta.AsJSONBuffer()
 	if err != nil {
 		return "", errors.Annotate(err, "while preparing the metadata")
 	}
@@ -147,7 +189,15 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 		return "", errors.Annotate(err, "while preparing for DB dump")
 	}
 
-	args := createArgs{paths.BackupDir, filesToBackUp, dumper, metadataFile, noDownload}
+	args := createArgs{
+		backupDir:      paths.BackupDir,
+		filesToBackUp:  filesToBackUp,
+		db:             dumper,
+		metadataReader: metadataFile,
+		noDownload:     noDownload,
+		encryptTo:      encryptTo,
+		stream:         stream,
+	}
 	result, err := runCreate(&args)
 	if err != nil {
 		return "", errors.Annotate(err, "while creating backup archive")
@@ -171,6 +221,30 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 	return result.filename, nil
 }
 
+// incrementalBase returns the stored backup with the most recent
+// oplog position, which is the one an incremental backup follows.
+// Encrypted backups can't be unpacked when restoring, so they are
+// never chosen.
+func (b *backups) incrementalBase() (*Metadata, error) {
+	all, err := b.List()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var base *Metadata
+	for _, meta := range all {
+		if meta.OplogTimestamp == 0 || meta.Encryption != nil {
+			continue
+		}
+		if base == nil || meta.OplogTimestamp > base.OplogTimestamp {
+			base = meta
+		}
+	}
+	if base == nil {
+		return nil, errors.NotFoundf("backup for an incremental backup to follow")
+	}
+	return base, nil
+}
+
 // Add stores the backup archive and returns its new ID.
 func (b *backups) Add(archive io.Reader, meta *Metadata) (string, error) {
 	// Store the archive.
@@ -251,7 +325,24 @@ func (b *backups) List() ([]*Metadata, error) {
 	return result, nil
 }
 
-// Remove deletes the backup from storage.
+// Remove deletes the backup from storage. A backup followed by
+// incremental backups cannot be removed until they have been, as they
+// cannot be restored without it.
 func (b *backups) Remove(id string) error {
+	all, err := b.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var dependants []string
+	for _, meta := range all {
+		if meta.BaseID == id {
+			dependants = append(dependants, meta.ID())
+		}
+	}
+	if len(dependants) > 0 {
+		sort.Strings(dependants)
+		return errors.Errorf("backup %q is needed to restore incremental backups %s, remove them first",
+			id, strings.Join(dependants, ", "))
+	}
 	return errors.Trace(b.storage.Remove(id))
 }
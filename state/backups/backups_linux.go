@@ -76,12 +76,32 @@ func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error)
 
 	defer backupReader.Close()
 
+	// Only the holder of the private key can decrypt the archive,
+	// so it has to be restored from a decrypted copy.
+	if meta.Encryption != nil {
+		return nil, errors.Errorf("backup %q is encrypted, restore it from a downloaded file", backupId)
+	}
+
 	workspace, err := NewArchiveWorkspaceReader(backupReader)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot unpack backup file")
 	}
 	defer workspace.Close()
 
+	// An incremental backup is restored by restoring the full backup
+	// it ultimately follows, then replaying the oplog entries of each
+	// incremental backup in turn.
+	bases, err := b.unpackBases(meta)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot unpack the backups an incremental backup follows")
+	}
+	defer closeWorkspaces(bases)
+	var dumpDirs []string
+	for _, base := range bases {
+		dumpDirs = append(dumpDirs, base.DBDumpDir)
+	}
+	dumpDirs = append(dumpDirs, workspace.DBDumpDir)
+
 	// This might actually work, but we don't have a guarantee so we don't allow it.
 	if meta.Origin.Series != args.NewInstSeries {
 		return nil, errors.Errorf("cannot restore a backup made in a machine with series %q into a machine with series %q, %#v", meta.Origin.Series, args.NewInstSeries, meta)
@@ -220,8 +240,10 @@ func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error)
 	if err != nil {
 		return nil, errors.Annotate(err, "error preparing for restore")
 	}
-	if err := restorer.Restore(workspace.DBDumpDir, oldDialInfo); err != nil {
-		return nil, errors.Annotate(err, "error restoring state from backup")
+	for _, dumpDir := range dumpDirs {
+		if err := restorer.Restore(dumpDir, oldDialInfo); err != nil {
+			return nil, errors.Annotate(err, "error restoring state from backup")
+		}
 	}
 
 	// Re-start replicaset with the new value for server address
@@ -327,3 +349,41 @@ func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error)
 
 	return backupMachine, nil
 }
+
+// unpackBases unpacks the backups an incremental backup follows, oldest
+// first, starting with the full backup the chain is based on. There are
+// none for a full backup.
+func (b *backups) unpackBases(meta *Metadata) (_ []*ArchiveWorkspace, err error) {
+	var workspaces []*ArchiveWorkspace
+	defer func() {
+		if err != nil {
+			closeWorkspaces(workspaces)
+		}
+	}()
+	for id := meta.BaseID; id != ""; {
+		base, archive, err := b.Get(id)
+		if err != nil {
+			return nil, errors.Annotatef(err, "could not fetch backup %q", id)
+		}
+		if base.Encryption != nil {
+			archive.Close()
+			return nil, errors.Errorf("backup %q is encrypted", id)
+		}
+		workspace, err := NewArchiveWorkspaceReader(archive)
+		archive.Close()
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot unpack backup %q", id)
+		}
+		workspaces = append([]*ArchiveWorkspace{workspace}, workspaces...)
+		id = base.BaseID
+	}
+	return workspaces, nil
+}
+
+func closeWorkspaces(workspaces []*ArchiveWorkspace) {
+	for _, workspace := range workspaces {
+		if err := workspace.Close(); err != nil {
+			logger.Errorf("cannot remove backup workspace: %v", err)
+		}
+	}
+}
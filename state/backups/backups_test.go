@@ -14,6 +14,7 @@ import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/filestorage"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/mongo"
@@ -58,7 +59,13 @@ func (s *backupsSuite) checkFailure(c *gc.C, expected string) {
 
 	paths := backups.Paths{DataDir: "/var/lib/juju"}
 	targets := set.NewStrings("juju", "admin")
-	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt}
+	dbInfo := backups.DBInfo{
+		Address:      "a",
+		Username:     "b",
+		Password:     "c",
+		Targets:      targets,
+		MongoVersion: mongo.Mongo32wt,
+	}
 	meta := backupstesting.NewMetadataStarted()
 	meta.Notes = "some notes"
 
@@ -114,7 +121,13 @@ func (s *backupsSuite) testCreateOkay(c *gc.C, keepCopy, noDownload bool) {
 	// Run the backup.
 	paths := backups.Paths{BackupDir: backupDir, DataDir: dataDir}
 	targets := set.NewStrings("juju", "admin")
-	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt}
+	dbInfo := backups.DBInfo{
+		Address:      "a",
+		Username:     "b",
+		Password:     "c",
+		Targets:      targets,
+		MongoVersion: mongo.Mongo32wt,
+	}
 	meta := backupstesting.NewMetadataStarted()
 	backupstesting.SetOrigin(meta, "<model ID>", "<machine ID>", "<hostname>")
 	meta.Notes = "some notes"
@@ -165,6 +178,118 @@ func (s *backupsSuite) testCreateOkay(c *gc.C, keepCopy, noDownload bool) {
 	}
 }
 
+func (s *backupsSuite) storedWithOplog(id string, ts int64) *backups.Metadata {
+	meta := backupstesting.NewMetadataStarted()
+	meta.SetID(id)
+	meta.OplogTimestamp = ts
+	return meta
+}
+
+func (s *backupsSuite) TestCreateIncremental(c *gc.C) {
+	received, testCreate := backups.NewTestCreate(nil)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{}, nil
+	})
+	var receivedDBInfo *backups.DBInfo
+	s.PatchValue(backups.GetDBDumper, func(info *backups.DBInfo) (backups.DBDumper, error) {
+		receivedDBInfo = info
+		return &fakeDumper{}, nil
+	})
+	s.Storage.MetaList = []filestorage.Metadata{
+		s.storedWithOplog("full", 10),
+		s.storedWithOplog("incr", 20),
+		s.storedWithOplog("legacy", 0),
+	}
+
+	paths := backups.Paths{BackupDir: c.MkDir(), DataDir: c.MkDir()}
+	dbInfo := backups.DBInfo{
+		Targets:      set.NewStrings("juju"),
+		MongoVersion: mongo.Mongo32wt,
+		Oplog:        backups.OplogRange{First: 5, Last: 30},
+		Stream:       true,
+		Incremental:  true,
+	}
+	meta := backupstesting.NewMetadataStarted()
+	_, err := s.api.Create(meta, &paths, &dbInfo, false, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(meta.BaseID, gc.Equals, "incr")
+	c.Check(meta.OplogTimestamp, gc.Equals, int64(30))
+	c.Check(backups.IncrementalSince(receivedDBInfo), gc.Equals, int64(20))
+	c.Check(backups.IncrementalSince(&dbInfo), gc.Equals, int64(0))
+	// Incremental dumps are never streamed.
+	c.Check(backups.ExposeCreateStream(received), jc.IsFalse)
+}
+
+func (s *backupsSuite) TestCreateIncrementalSkipsEncryptedBase(c *gc.C) {
+	_, testCreate := backups.NewTestCreate(nil)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{}, nil
+	})
+	var receivedDBInfo *backups.DBInfo
+	s.PatchValue(backups.GetDBDumper, func(info *backups.DBInfo) (backups.DBDumper, error) {
+		receivedDBInfo = info
+		return &fakeDumper{}, nil
+	})
+	encrypted := s.storedWithOplog("encrypted", 20)
+	encrypted.Encryption = &backups.Encryption{Scheme: backups.EncryptionScheme}
+	s.Storage.MetaList = []filestorage.Metadata{
+		s.storedWithOplog("full", 10),
+		encrypted,
+	}
+
+	paths := backups.Paths{BackupDir: c.MkDir(), DataDir: c.MkDir()}
+	dbInfo := backups.DBInfo{
+		Targets:     set.NewStrings("juju"),
+		Oplog:       backups.OplogRange{First: 5, Last: 30},
+		Incremental: true,
+	}
+	meta := backupstesting.NewMetadataStarted()
+	_, err := s.api.Create(meta, &paths, &dbInfo, false, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(meta.BaseID, gc.Equals, "full")
+	c.Check(backups.IncrementalSince(receivedDBInfo), gc.Equals, int64(10))
+}
+
+func (s *backupsSuite) TestCreateIncrementalNoBase(c *gc.C) {
+	s.Storage.MetaList = []filestorage.Metadata{
+		s.storedWithOplog("legacy", 0),
+	}
+	paths := backups.Paths{}
+	dbInfo := backups.DBInfo{
+		Oplog:       backups.OplogRange{First: 5, Last: 30},
+		Incremental: true,
+	}
+	_, err := s.api.Create(backupstesting.NewMetadataStarted(), &paths, &dbInfo, false, true)
+	c.Check(err, gc.ErrorMatches, "backup for an incremental backup to follow not found")
+}
+
+func (s *backupsSuite) TestCreateIncrementalOplogGap(c *gc.C) {
+	s.Storage.MetaList = []filestorage.Metadata{
+		s.storedWithOplog("full", 10),
+	}
+	paths := backups.Paths{}
+	dbInfo := backups.DBInfo{
+		Oplog:       backups.OplogRange{First: 15, Last: 30},
+		Incremental: true,
+	}
+	_, err := s.api.Create(backupstesting.NewMetadataStarted(), &paths, &dbInfo, false, true)
+	c.Check(err, gc.ErrorMatches, `oplog no longer holds the changes since backup "full", a full backup is needed`)
+}
+
+func (s *backupsSuite) TestCreateStreamOldMongo(c *gc.C) {
+	paths := backups.Paths{}
+	dbInfo := backups.DBInfo{
+		MongoVersion: mongo.Mongo24,
+		Stream:       true,
+	}
+	_, err := s.api.Create(backupstesting.NewMetadataStarted(), &paths, &dbInfo, false, true)
+	c.Check(err, gc.ErrorMatches, "streaming backups with mongo .* not supported")
+}
+
 func (s *backupsSuite) TestCreateFailToListFiles(c *gc.C) {
 	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
 		return nil, errors.New("failed!")
@@ -243,3 +368,24 @@ func (s *backupsSuite) TestGetFileName(c *gc.C) {
 	_, err = ioutil.ReadDir(backupDir)
 	c.Assert(err, gc.ErrorMatches, fmt.Sprintf("open %s: no such file or directory", backupDir))
 }
+
+func (s *backupsSuite) TestRemove(c *gc.C) {
+	s.Storage.MetaList = []filestorage.Metadata{
+		s.storedWithOplog("full", 10),
+	}
+	err := s.api.Remove("full")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Storage.Calls, jc.DeepEquals, []string{"List", "Remove"})
+	c.Check(s.Storage.IDArg, gc.Equals, "full")
+}
+
+func (s *backupsSuite) TestRemoveBaseOfIncremental(c *gc.C) {
+	full := s.storedWithOplog("full", 10)
+	incr := s.storedWithOplog("incr", 20)
+	incr.BaseID = "full"
+	s.Storage.MetaList = []filestorage.Metadata{full, incr}
+
+	err := s.api.Remove("full")
+	c.Assert(err, gc.ErrorMatches, `backup "full" is needed to restore incremental backups incr, remove them first`)
+	c.Check(s.Storage.Calls, jc.DeepEquals, []string{"List"})
+}
@@ -4,12 +4,14 @@
 package backups
 
 import (
+	archivetar "archive/tar"
 	"compress/gzip"
 	"crypto/sha1"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 
 	"github.com/juju/errors"
@@ -33,6 +35,8 @@ type createArgs struct {
 	db             DBDumper
 	metadataReader io.Reader
 	noDownload     bool
+	encryptTo      *PublicKey
+	stream         bool
 }
 
 type createResult struct {
@@ -50,6 +54,8 @@ func create(args *createArgs) (_ *createResult, err error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	builder.encryptTo = args.encryptTo
+	builder.stream = args.stream
 	defer func() {
 		if cerr := builder.cleanUp(args.noDownload); cerr != nil {
 			cerr.Log(logger)
@@ -105,6 +111,12 @@ type builder struct {
 	// bundleFile is the inner archive file containing all the juju
 	// state-related files gathered during backup.
 	bundleFile io.WriteCloser
+	// encryptTo is the public key the archive is encrypted to, if
+	// it is encrypted.
+	encryptTo *PublicKey
+	// stream is whether the DB dump is written straight into the
+	// archive rather than being staged in the workspace.
+	stream bool
 }
 
 // newBuilder returns a new backup archive builder.  It creates the temp
@@ -289,10 +301,50 @@ func (b *builder) buildDBDump() error {
 	return nil
 }
 
+// streamDBDump writes the dump of each database straight into the
+// archive, so that no staging copy of the databases is needed. The
+// oplog entries made while they were being dumped are then dumped to
+// the workspace, to go into the archive with the other staged files.
+func (b *builder) streamDBDump(out io.Writer) error {
+	logger.Infof("streaming database dump")
+	streamer, ok := b.db.(DBStreamer)
+	if !ok {
+		return errors.NotSupportedf("streaming database dump")
+	}
+
+	tarw := archivetar.NewWriter(out)
+	dumpDir := NewCanonicalArchivePaths().DBDumpDir
+	for _, name := range streamer.Databases() {
+		w := newChunkWriter(tarw, path.Join(dumpDir, name+dumpArchiveSuffix))
+		if err := streamer.DumpDatabase(name, w); err != nil {
+			return errors.Annotate(err, "while streaming juju state database")
+		}
+		if err := w.Close(); err != nil {
+			return errors.Annotate(err, "while streaming juju state database")
+		}
+	}
+	// The staged files follow in the same tar stream, so the writer
+	// is flushed rather than closed, which would end the stream.
+	if err := tarw.Flush(); err != nil {
+		return errors.Annotate(err, "while streaming juju state database")
+	}
+
+	if err := streamer.DumpOplog(b.archivePaths.DBDumpDir); err != nil {
+		return errors.Annotate(err, "while dumping juju state database oplog")
+	}
+	return nil
+}
+
 func (b *builder) buildArchive(outFile io.Writer) error {
 	tarball := gzip.NewWriter(outFile)
 	defer tarball.Close()
 
+	if b.stream {
+		if err := b.streamDBDump(tarball); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	// We add a trailing slash (or whatever) to root so that everything
 	// in the path up to and including that slash is stripped off when
 	// each file is added to the tar file.
@@ -316,9 +368,25 @@ func (b *builder) buildArchiveAndChecksum() error {
 	// than to the uncompressed contents of the tarball.  This is so
 	// that users can compare the published checksum against the
 	// checksum of the file without having to decompress it first.
+	// Likewise, the checksum of an encrypted archive is that of the
+	// encrypted file.
 	hasher := hash.NewHashingWriter(b.archiveFile, sha1.New())
-	if err := b.buildArchive(hasher); err != nil {
-		return errors.Trace(err)
+	if b.encryptTo == nil {
+		if err := b.buildArchive(hasher); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		logger.Infof("encrypting archive to %s", b.encryptTo)
+		encrypter, err := NewEncryptingWriter(hasher, *b.encryptTo)
+		if err != nil {
+			return errors.Annotate(err, "while encrypting archive")
+		}
+		if err := b.buildArchive(encrypter); err != nil {
+			return errors.Trace(err)
+		}
+		if err := encrypter.Close(); err != nil {
+			return errors.Annotate(err, "while encrypting archive")
+		}
 	}
 
 	// Save the SHA1 checksum.
@@ -336,9 +404,11 @@ func (b *builder) buildAll() error {
 		return errors.Trace(err)
 	}
 
-	// Dump the database.
-	if err := b.buildDBDump(); err != nil {
-		return errors.Trace(err)
+	// Dump the database, unless it is to be streamed into the archive.
+	if !b.stream {
+		if err := b.buildDBDump(); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	// Bundle it all into a tarball.
@@ -4,9 +4,15 @@
 package backups_test
 
 import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
 	"runtime"
+	"sort"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -58,6 +64,146 @@ func (s *createSuite) TestLegacy(c *gc.C) {
 	s.checkArchive(c, file, expected)
 }
 
+func (s *createSuite) TestEncrypted(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently does not work on windows, see comments inside backups.create function")
+	}
+	pub, priv, err := backups.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	backupDir := c.MkDir()
+	_, testFiles, expected := s.createTestFiles(c)
+
+	args := backups.NewTestCreateArgs(backupDir, testFiles, &TestDBDumper{}, metadataFile, true)
+	backups.EncryptCreateArgs(args, pub)
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archiveFile, size, checksum, _ := backups.ExposeCreateResult(result)
+	file, ok := archiveFile.(*os.File)
+	c.Assert(ok, jc.IsTrue)
+
+	// The size and checksum are those of the encrypted archive.
+	s.checkSize(c, file, size)
+	s.checkChecksum(c, file, checksum)
+	c.Check(backups.IsEncrypted(bufio.NewReader(file)), jc.IsTrue)
+	resetFile(c, file)
+
+	plain, err := backups.NewDecryptingReader(file, priv)
+	c.Assert(err, jc.ErrorIsNil)
+	decrypted, err := os.Create(path.Join(c.MkDir(), "decrypted.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer decrypted.Close()
+	_, err = io.Copy(decrypted, plain)
+	c.Assert(err, jc.ErrorIsNil)
+	resetFile(c, decrypted)
+	s.checkArchive(c, decrypted, expected)
+}
+
+type TestDBStreamer struct {
+	TestDBDumper
+	Dumps map[string]string
+}
+
+func (d *TestDBStreamer) Databases() []string {
+	var names []string
+	for name := range d.Dumps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (d *TestDBStreamer) DumpDatabase(name string, w io.Writer) error {
+	_, err := io.WriteString(w, d.Dumps[name])
+	return err
+}
+
+func (d *TestDBStreamer) DumpOplog(dumpDir string) error {
+	return ioutil.WriteFile(filepath.Join(dumpDir, "oplog.bson"), []byte("<oplog>"), 0600)
+}
+
+func (s *createSuite) TestStreamed(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently does not work on windows, see comments inside backups.create function")
+	}
+	s.PatchValue(backups.DumpChunkSize, 4)
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	backupDir := c.MkDir()
+	_, testFiles, expected := s.createTestFiles(c)
+
+	dumper := &TestDBStreamer{Dumps: map[string]string{
+		"juju": "<juju dump>",
+		"logs": "<logs>",
+	}}
+	args := backups.NewTestCreateArgs(backupDir, testFiles, dumper, metadataFile, true)
+	backups.StreamCreateArgs(args)
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing was dumped to the workspace besides the oplog.
+	c.Check(dumper.DumpDir, gc.Equals, "")
+
+	archiveFile, size, checksum, _ := backups.ExposeCreateResult(result)
+	file, ok := archiveFile.(*os.File)
+	c.Assert(ok, jc.IsTrue)
+	s.checkSize(c, file, size)
+	s.checkChecksum(c, file, checksum)
+	s.checkArchive(c, file, expected)
+
+	tarFile, err := gzip.NewReader(file)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkTarContents(c, tarFile, []tarContent{
+		{"juju-backup/dump/juju.archive.0000", "<juj", nil},
+		{"juju-backup/dump/juju.archive.0001", "u du", nil},
+		{"juju-backup/dump/juju.archive.0002", "mp>", nil},
+		{"juju-backup/dump/logs.archive.0000", "<log", nil},
+		{"juju-backup/dump/logs.archive.0001", "s>", nil},
+		{"juju-backup/dump/oplog.bson", "<oplog>", nil},
+	})
+	resetFile(c, file)
+
+	// Restoring joins the chunks of each database dump back up.
+	workspace, err := backups.NewArchiveWorkspaceReader(file)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workspace.Close()
+	targetDir := filepath.Join(workspace.RootDir, "archives")
+	archives, err := backups.AssembleDumpArchives(workspace.DBDumpDir, targetDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archives, jc.DeepEquals, []string{
+		filepath.Join(targetDir, "juju.archive"),
+		filepath.Join(targetDir, "logs.archive"),
+	})
+	data, err := ioutil.ReadFile(archives[0])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<juju dump>")
+	data, err = ioutil.ReadFile(archives[1])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<logs>")
+
+	// Only the oplog is left in the dump dir.
+	infos, err := ioutil.ReadDir(workspace.DBDumpDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, gc.HasLen, 1)
+	c.Check(infos[0].Name(), gc.Equals, "oplog.bson")
+}
+
+func (s *createSuite) TestStreamedNotSupported(c *gc.C) {
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	_, testFiles, _ := s.createTestFiles(c)
+
+	args := backups.NewTestCreateArgs(c.MkDir(), testFiles, &TestDBDumper{}, metadataFile, true)
+	backups.StreamCreateArgs(args)
+	_, err = backups.Create(args)
+	c.Assert(err, gc.ErrorMatches, "streaming database dump not supported")
+}
+
 func (s *createSuite) TestMetadataFileMissing(c *gc.C) {
 	var backupDir string
 	var testFiles []string
@@ -5,6 +5,7 @@ package backups
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
@@ -28,7 +29,10 @@ import (
 // low-level details publicly.  Thus the backups implementation remains
 // oblivious to the underlying DB implementation.
 
-var runCommandFn = runCommand
+var (
+	runCommandFn       = runCommand
+	runStreamCommandFn = runStreamCommand
+)
 
 // DBInfo wraps all the DB-specific information backups needs to dump
 // the database. This includes a simplification of the information in
@@ -44,6 +48,20 @@ type DBInfo struct {
 	Targets set.Strings
 	// MongoVersion the version of the running mongo db.
 	MongoVersion mongo.Version
+	// Oplog is the range of the mongo oplog when the backup was
+	// started. It is zero if the range is not known, in which case
+	// the backup cannot be the base of an incremental backup.
+	Oplog OplogRange
+	// Stream, if true, writes the database dump straight into the
+	// backup archive rather than staging it on disk first. Incremental
+	// dumps are small, so they are always staged.
+	Stream bool
+	// Incremental, if true, dumps only the oplog entries since the
+	// most recent backup instead of the whole database.
+	Incremental bool
+
+	// since is the oplog timestamp an incremental dump starts after.
+	since int64
 }
 
 // ignoredDatabases is the list of databases that should not be
@@ -93,6 +111,42 @@ func getBackupTargetDatabases(session DBSession) (set.Strings, error) {
 	return targets, nil
 }
 
+// OplogRange holds the timestamps of the oldest and newest entries
+// in the mongo oplog.
+type OplogRange struct {
+	First int64
+	Last  int64
+}
+
+// GetOplogRange returns the range of the oplog of the replica set the
+// session is connected to. A zero range is returned if there is no
+// oplog.
+func GetOplogRange(session *mgo.Session) (OplogRange, error) {
+	var doc struct {
+		Timestamp bson.MongoTimestamp `bson:"ts"`
+	}
+	oplog := session.DB(oplogDBName).C(oplogCollection)
+	query := oplog.Find(nil).Select(bson.M{"ts": 1})
+	var result OplogRange
+	if err := query.Sort("$natural").One(&doc); err == mgo.ErrNotFound {
+		return OplogRange{}, nil
+	} else if err != nil {
+		return OplogRange{}, errors.Annotate(err, "reading oplog")
+	}
+	result.First = int64(doc.Timestamp)
+	if err := query.Sort("-$natural").One(&doc); err != nil {
+		return OplogRange{}, errors.Annotate(err, "reading oplog")
+	}
+	result.Last = int64(doc.Timestamp)
+	return result, nil
+}
+
+const (
+	oplogDBName     = "local"
+	oplogCollection = "oplog.rs"
+	oplogFile       = "oplog.bson"
+)
+
 const (
 	dumpName    = "mongodump"
 	restoreName = "mongorestore"
@@ -104,6 +158,24 @@ type DBDumper interface {
 	Dump(dumpDir string) error
 }
 
+// DBStreamer is a DBDumper which can also write the dump of each
+// database straight to a writer, so that no staging copy of the dump
+// is needed on disk.
+type DBStreamer interface {
+	DBDumper
+
+	// Databases returns the names of the databases to stream.
+	Databases() []string
+
+	// DumpDatabase writes an archive of the named database to w.
+	DumpDatabase(name string, w io.Writer) error
+
+	// DumpOplog dumps the oplog entries made since the streaming
+	// started to dumpDir. Replaying them when restoring makes the
+	// separately streamed databases consistent.
+	DumpOplog(dumpDir string) error
+}
+
 var getMongodumpPath = func() (string, error) {
 	return getMongoToolPath(dumpName, os.Stat, exec.LookPath)
 }
@@ -154,17 +226,36 @@ func NewDBDumper(info *DBInfo) (DBDumper, error) {
 	return &dumper, nil
 }
 
-func (md *mongoDumper) options(dumpDir string) []string {
-	options := []string{
+func (md *mongoDumper) connectionOptions() []string {
+	return []string{
 		"--ssl",
 		"--sslAllowInvalidCertificates",
 		"--authenticationDatabase", "admin",
 		"--host", md.Address,
 		"--username", md.Username,
 		"--password", md.Password,
+	}
+}
+
+func (md *mongoDumper) options(dumpDir string) []string {
+	options := append(md.connectionOptions(),
 		"--out", dumpDir,
 		"--oplog",
-	}
+	)
+	return options
+}
+
+func (md *mongoDumper) oplogOptions(dumpDir string, since int64) []string {
+	// The timestamp is split into its seconds and increment parts
+	// for mongodump's extended JSON query.
+	query := fmt.Sprintf(`{"ts": {"$gt": {"$timestamp": {"t": %d, "i": %d}}}}`,
+		uint64(since)>>32, uint64(since)&0xffffffff)
+	options := append(md.connectionOptions(),
+		"--db", oplogDBName,
+		"--collection", oplogCollection,
+		"--query", query,
+		"--out", dumpDir,
+	)
 	return options
 }
 
@@ -176,9 +267,30 @@ func (md *mongoDumper) dump(dumpDir string) error {
 	return nil
 }
 
+// dumpOplog dumps the oplog entries after the given timestamp to
+// oplog.bson in dumpDir, where mongorestore expects to find them when
+// replaying the oplog.
+func (md *mongoDumper) dumpOplog(dumpDir string, since int64) error {
+	options := md.oplogOptions(dumpDir, since)
+	if err := runCommandFn(md.binPath, options...); err != nil {
+		return errors.Annotate(err, "error dumping oplog")
+	}
+	dumped := filepath.Join(dumpDir, oplogDBName)
+	err := os.Rename(filepath.Join(dumped, oplogCollection+".bson"), filepath.Join(dumpDir, oplogFile))
+	if err != nil {
+		return errors.Annotate(err, "while moving oplog dump")
+	}
+	return errors.Trace(os.RemoveAll(dumped))
+}
+
 // Dump dumps the juju state-related databases.  To do this we dump all
 // databases and then remove any ignored databases from the dump results.
+// An incremental dump only contains the oplog entries since the backup
+// it follows.
 func (md *mongoDumper) Dump(baseDumpDir string) error {
+	if md.since != 0 {
+		return errors.Trace(md.dumpOplog(baseDumpDir, md.since))
+	}
 	if err := md.dump(baseDumpDir); err != nil {
 		return errors.Trace(err)
 	}
@@ -199,6 +311,32 @@ func (md *mongoDumper) Dump(baseDumpDir string) error {
 	return errors.Trace(err)
 }
 
+// Databases is part of the DBStreamer interface. The local and config
+// databases hold the replica set's own state and are never dumped.
+func (md *mongoDumper) Databases() []string {
+	return md.Targets.Difference(set.NewStrings(oplogDBName, "config")).SortedValues()
+}
+
+// DumpDatabase is part of the DBStreamer interface.
+func (md *mongoDumper) DumpDatabase(name string, w io.Writer) error {
+	options := append(md.connectionOptions(),
+		"--db", name,
+		"--archive",
+	)
+	if err := runStreamCommandFn(w, md.binPath, options...); err != nil {
+		return errors.Annotatef(err, "error dumping database %q", name)
+	}
+	return nil
+}
+
+// DumpOplog is part of the DBStreamer interface.
+func (md *mongoDumper) DumpOplog(dumpDir string) error {
+	if md.Oplog.Last == 0 {
+		return errors.New("oplog position unknown")
+	}
+	return errors.Trace(md.dumpOplog(dumpDir, md.Oplog.Last))
+}
+
 // stripIgnored removes the ignored DBs from the mongo dump files.
 // This involves deleting DB-specific directories.
 //
@@ -288,6 +426,11 @@ func (md *mongoRestorer24) options(dumpDir string) []string {
 }
 
 func (md *mongoRestorer24) Restore(dumpDir string, _ *mgo.DialInfo) error {
+	if archives, err := assembleDumpArchives(dumpDir, filepath.Join(filepath.Dir(dumpDir), "archives")); err != nil {
+		return errors.Trace(err)
+	} else if len(archives) > 0 {
+		return errors.NotSupportedf("restoring a streamed backup with mongo 2.4")
+	}
 	logger.Debugf("stopping mongo service for restore")
 	if err := md.stopMongo(); err != nil {
 		return errors.Annotate(err, "cannot stop mongo to replace files")
@@ -506,12 +649,40 @@ func (md *mongoRestorer32) ensureTagUser() error {
 	return nil
 }
 
+func (md *mongoRestorer32) archiveOptions(archive string) []string {
+	options := []string{
+		"--ssl",
+		"--sslAllowInvalidCertificates",
+		"--authenticationDatabase", "admin",
+		"--host", md.Addrs[0],
+		"--username", md.Username,
+		"--password", md.Password,
+		"--drop",
+		"--batchSize", "10",
+		"--archive=" + archive,
+	}
+	return options
+}
+
 func (md *mongoRestorer32) Restore(dumpDir string, dialInfo *mgo.DialInfo) error {
 	logger.Debugf("start restore, dumpDir %s", dumpDir)
 	if err := md.ensureOplogPermissions(dialInfo); err != nil {
 		return errors.Annotate(err, "setting special user permission in db")
 	}
 
+	// A streamed dump holds an archive of each database, which are
+	// restored before the oplog entries in the dump dir are replayed.
+	archives, err := assembleDumpArchives(dumpDir, filepath.Join(filepath.Dir(dumpDir), "archives"))
+	if err != nil {
+		return errors.Annotate(err, "error assembling streamed database dump")
+	}
+	for _, archive := range archives {
+		logger.Infof("restoring database from %s", filepath.Base(archive))
+		if err := md.runCommandFn(md.binPath, md.archiveOptions(archive)...); err != nil {
+			return errors.Annotate(err, "error restoring database")
+		}
+	}
+
 	options := md.options(dumpDir)
 	logger.Infof("restoring database with params %v", options)
 	if err := md.runCommandFn(md.binPath, options...); err != nil {
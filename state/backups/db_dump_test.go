@@ -4,6 +4,9 @@
 package backups_test
 
 import (
+	"bytes"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -31,9 +34,16 @@ func (s *dumpSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
 	targets := set.NewStrings("juju", "admin")
-	s.dbInfo = &backups.DBInfo{"a", "b", "c", targets, mongo.Mongo24}
+	s.dbInfo = &backups.DBInfo{
+		Address:      "a",
+		Username:     "b",
+		Password:     "c",
+		Targets:      targets,
+		MongoVersion: mongo.Mongo24,
+	}
 	s.targets = targets
 	s.dumpDir = c.MkDir()
+	s.ranCommand = false
 }
 
 func (s *dumpSuite) patch(c *gc.C) {
@@ -155,3 +165,103 @@ func (s *dumpSuite) TestDumpNothingIgnored(c *gc.C) {
 
 	s.checkDBs(c, "juju", "admin")
 }
+
+func (s *dumpSuite) patchOplogDump(c *gc.C) *[]string {
+	var args []string
+	s.PatchValue(backups.GetMongodumpPath, func() (string, error) {
+		return "bogusmongodump", nil
+	})
+	s.PatchValue(backups.RunCommand, func(cmd string, cmdArgs ...string) error {
+		args = cmdArgs
+		dumped := s.prepDB(c, "local")
+		return ioutil.WriteFile(filepath.Join(dumped, "oplog.rs.bson"), []byte("<oplog>"), 0600)
+	})
+	return &args
+}
+
+func (s *dumpSuite) checkOplogDump(c *gc.C, args []string, query string) {
+	c.Check(args, jc.DeepEquals, []string{
+		"--ssl",
+		"--sslAllowInvalidCertificates",
+		"--authenticationDatabase", "admin",
+		"--host", "a",
+		"--username", "b",
+		"--password", "c",
+		"--db", "local",
+		"--collection", "oplog.rs",
+		"--query", query,
+		"--out", s.dumpDir,
+	})
+	data, err := ioutil.ReadFile(filepath.Join(s.dumpDir, "oplog.bson"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<oplog>")
+	s.checkStripped(c, "local")
+}
+
+func (s *dumpSuite) TestDumpIncremental(c *gc.C) {
+	args := s.patchOplogDump(c)
+	backups.SetIncrementalSince(s.dbInfo, 1415926535<<32|7)
+	dumper := s.prep(c)
+
+	err := dumper.Dump(s.dumpDir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.checkOplogDump(c, *args, `{"ts": {"$gt": {"$timestamp": {"t": 1415926535, "i": 7}}}}`)
+}
+
+func (s *dumpSuite) TestStreamDatabases(c *gc.C) {
+	s.patch(c)
+	s.dbInfo.Targets = set.NewStrings("juju", "logs", "local", "config")
+	dumper := s.prep(c)
+
+	streamer, ok := dumper.(backups.DBStreamer)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(streamer.Databases(), jc.DeepEquals, []string{"juju", "logs"})
+}
+
+func (s *dumpSuite) TestStreamDumpDatabase(c *gc.C) {
+	s.patch(c)
+	var args []string
+	s.PatchValue(backups.RunStreamCommand, func(stdout io.Writer, cmd string, cmdArgs ...string) error {
+		args = cmdArgs
+		_, err := io.WriteString(stdout, "<archive>")
+		return err
+	})
+	streamer := s.prep(c).(backups.DBStreamer)
+
+	var buf bytes.Buffer
+	err := streamer.DumpDatabase("juju", &buf)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(buf.String(), gc.Equals, "<archive>")
+	c.Check(args, jc.DeepEquals, []string{
+		"--ssl",
+		"--sslAllowInvalidCertificates",
+		"--authenticationDatabase", "admin",
+		"--host", "a",
+		"--username", "b",
+		"--password", "c",
+		"--db", "juju",
+		"--archive",
+	})
+}
+
+func (s *dumpSuite) TestStreamDumpOplog(c *gc.C) {
+	args := s.patchOplogDump(c)
+	s.dbInfo.Oplog = backups.OplogRange{First: 1 << 32, Last: 3 << 32}
+	streamer := s.prep(c).(backups.DBStreamer)
+
+	err := streamer.DumpOplog(s.dumpDir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.checkOplogDump(c, *args, `{"ts": {"$gt": {"$timestamp": {"t": 3, "i": 0}}}}`)
+}
+
+func (s *dumpSuite) TestStreamDumpOplogUnknown(c *gc.C) {
+	s.patch(c)
+	streamer := s.prep(c).(backups.DBStreamer)
+
+	err := streamer.DumpOplog(s.dumpDir)
+	c.Assert(err, gc.ErrorMatches, "oplog position unknown")
+	c.Check(s.ranCommand, jc.IsFalse)
+}
@@ -0,0 +1,163 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/juju/errors"
+)
+
+// Encrypted backup archives are age files (https://age-encryption.org)
+// encrypted to a single X25519 recipient, so they can also be decrypted
+// with the age tool and the private key.
+
+const (
+	// EncryptionScheme identifies the scheme used to encrypt backup
+	// archives.
+	EncryptionScheme = "age-x25519"
+
+	// EncryptedFilenameSuffix is added to the names of downloaded
+	// archives which are still encrypted.
+	EncryptedFilenameSuffix = ".enc"
+
+	// ageHeader starts every age encrypted file.
+	ageHeader = "age-encryption.org/v1\n"
+)
+
+// Encryption describes how a backup archive was encrypted.
+type Encryption struct {
+	// Scheme identifies the encryption scheme.
+	Scheme string
+
+	// Recipient is the public key the archive was encrypted to.
+	Recipient string
+}
+
+// PublicKey is the public half of a backup encryption key pair.
+// Archives encrypted to a public key can only be decrypted with the
+// matching private key.
+type PublicKey struct {
+	recipient string
+}
+
+// String returns the key in the form accepted by ParsePublicKey, which
+// is that of an age recipient.
+func (k PublicKey) String() string {
+	return k.recipient
+}
+
+// ParsePublicKey parses a public key in the form returned by
+// PublicKey.String.
+func ParsePublicKey(s string) (PublicKey, error) {
+	recipient, err := age.ParseX25519Recipient(strings.TrimSpace(s))
+	if err != nil {
+		return PublicKey{}, errors.Annotate(err, "invalid backup public key")
+	}
+	return PublicKey{recipient: recipient.String()}, nil
+}
+
+// PrivateKey is the private half of a backup encryption key pair.
+type PrivateKey struct {
+	identity string
+}
+
+// String returns the key in the form accepted by ParsePrivateKey, which
+// is that of an age identity.
+func (k PrivateKey) String() string {
+	return k.identity
+}
+
+// ParsePrivateKey parses a private key in the form returned by
+// PrivateKey.String.
+func ParsePrivateKey(s string) (PrivateKey, error) {
+	identity, err := age.ParseX25519Identity(strings.TrimSpace(s))
+	if err != nil {
+		return PrivateKey{}, errors.Annotate(err, "invalid backup private key")
+	}
+	return PrivateKey{identity: identity.String()}, nil
+}
+
+// PublicKey returns the public key matching the private key.
+func (k PrivateKey) PublicKey() (PublicKey, error) {
+	identity, err := age.ParseX25519Identity(k.identity)
+	if err != nil {
+		return PublicKey{}, errors.Annotate(err, "invalid backup private key")
+	}
+	return PublicKey{recipient: identity.Recipient().String()}, nil
+}
+
+// GenerateKeyPair returns a new key pair for encrypting backup
+// archives.
+func GenerateKeyPair() (PublicKey, PrivateKey, error) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		return PublicKey{}, PrivateKey{}, errors.Trace(err)
+	}
+	return PublicKey{recipient: identity.Recipient().String()},
+		PrivateKey{identity: identity.String()}, nil
+}
+
+// IsEncrypted reports whether the archive read by r is encrypted,
+// without consuming any of it.
+func IsEncrypted(r *bufio.Reader) bool {
+	header, _ := r.Peek(len(ageHeader))
+	return string(header) == ageHeader
+}
+
+// NewEncryptingWriter returns a writer which encrypts everything
+// written to it to the recipient, writing the result to w. The writer
+// must be closed to write the final chunk; closing it does not close w.
+func NewEncryptingWriter(w io.Writer, recipient PublicKey) (io.WriteCloser, error) {
+	r, err := age.ParseX25519Recipient(recipient.recipient)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid recipient")
+	}
+	encrypter, err := age.Encrypt(w, r)
+	return encrypter, errors.Trace(err)
+}
+
+// NewDecryptingReader returns a reader of the plain contents of the
+// encrypted archive read from r, which must have been encrypted to the
+// public key matching key. An error is returned when reading if the
+// archive has been tampered with or truncated.
+func NewDecryptingReader(r io.Reader, key PrivateKey) (io.Reader, error) {
+	identity, err := age.ParseX25519Identity(key.identity)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid backup private key")
+	}
+	buffered := bufio.NewReader(r)
+	if !IsEncrypted(buffered) {
+		return nil, errors.NotValidf("encrypted backup archive")
+	}
+	plain, err := age.Decrypt(buffered, identity)
+	if _, ok := err.(*age.NoIdentityMatchError); ok {
+		return nil, errors.New("cannot decrypt backup archive: it was encrypted to a different key")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot decrypt backup archive")
+	}
+	return &decryptingReader{r: plain}, nil
+}
+
+// decryptingReader describes the errors met while decrypting in terms
+// of backup archives.
+type decryptingReader struct {
+	r io.Reader
+}
+
+// Read implements io.Reader.
+func (d *decryptingReader) Read(p []byte) (int, error) {
+	n, err := d.r.Read(p)
+	switch err {
+	case nil, io.EOF:
+		return n, err
+	case io.ErrUnexpectedEOF:
+		return n, errors.New("encrypted backup archive is truncated")
+	default:
+		return n, errors.Annotate(err, "cannot decrypt backup archive")
+	}
+}
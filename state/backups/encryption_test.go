@@ -0,0 +1,125 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+)
+
+type encryptionSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&encryptionSuite{})
+
+func (s *encryptionSuite) encrypt(c *gc.C, pub backups.PublicKey, data []byte) []byte {
+	var buf bytes.Buffer
+	w, err := backups.NewEncryptingWriter(&buf, pub)
+	c.Assert(err, jc.ErrorIsNil)
+	// Write in uneven pieces to exercise the chunking.
+	for len(data) > 0 {
+		n := 1000
+		if n > len(data) {
+			n = len(data)
+		}
+		_, err := w.Write(data[:n])
+		c.Assert(err, jc.ErrorIsNil)
+		data = data[n:]
+	}
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func (s *encryptionSuite) decrypt(priv backups.PrivateKey, data []byte) ([]byte, error) {
+	r, err := backups.NewDecryptingReader(bytes.NewReader(data), priv)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(r)
+}
+
+func (s *encryptionSuite) TestRoundTrip(c *gc.C) {
+	pub, priv, err := backups.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, size := range []int{0, 1, 64 * 1024, 200 * 1024} {
+		c.Logf("size %d", size)
+		plain := bytes.Repeat([]byte("x"), size)
+		encrypted := s.encrypt(c, pub, plain)
+		c.Check(bytes.Contains(encrypted, []byte("xxxxxxxx")), jc.IsFalse)
+		c.Check(backups.IsEncrypted(bufio.NewReader(bytes.NewReader(encrypted))), jc.IsTrue)
+
+		decrypted, err := s.decrypt(priv, encrypted)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(decrypted, jc.DeepEquals, plain)
+	}
+}
+
+func (s *encryptionSuite) TestIsEncryptedPlain(c *gc.C) {
+	c.Check(backups.IsEncrypted(bufio.NewReader(strings.NewReader("\x1f\x8b plain gzip"))), jc.IsFalse)
+}
+
+func (s *encryptionSuite) TestWrongKey(c *gc.C) {
+	pub, _, err := backups.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	_, other, err := backups.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.decrypt(other, s.encrypt(c, pub, []byte("secret")))
+	c.Assert(err, gc.ErrorMatches, "cannot decrypt backup archive: it was encrypted to a different key")
+}
+
+func (s *encryptionSuite) TestTruncated(c *gc.C) {
+	pub, priv, err := backups.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	encrypted := s.encrypt(c, pub, bytes.Repeat([]byte("x"), 100*1024))
+	// Drop the final chunk entirely.
+	_, err = s.decrypt(priv, encrypted[:len(encrypted)-(100*1024-64*1024)-16])
+	c.Assert(err, gc.ErrorMatches, "encrypted backup archive is truncated")
+}
+
+func (s *encryptionSuite) TestTampered(c *gc.C) {
+	pub, priv, err := backups.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	encrypted := s.encrypt(c, pub, []byte("secret"))
+	encrypted[len(encrypted)-1] ^= 1
+	_, err = s.decrypt(priv, encrypted)
+	c.Assert(err, gc.ErrorMatches, "cannot decrypt backup archive: .*")
+}
+
+func (s *encryptionSuite) TestNotEncrypted(c *gc.C) {
+	_, priv, err := backups.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.decrypt(priv, []byte("\x1f\x8b plain gzip"))
+	c.Assert(err, gc.ErrorMatches, "encrypted backup archive not valid")
+}
+
+func (s *encryptionSuite) TestParseKeys(c *gc.C) {
+	pub, priv, err := backups.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(pub.String(), jc.HasPrefix, "age1")
+	c.Check(priv.String(), jc.HasPrefix, "AGE-SECRET-KEY-1")
+
+	parsedPub, err := backups.ParsePublicKey(pub.String() + "\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(parsedPub, gc.Equals, pub)
+	parsedPriv, err := backups.ParsePrivateKey(priv.String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(parsedPriv, gc.Equals, priv)
+	derived, err := parsedPriv.PublicKey()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(derived, gc.Equals, pub)
+
+	_, err = backups.ParsePublicKey(priv.String())
+	c.Check(err, gc.ErrorMatches, `invalid backup public key: malformed recipient .*`)
+	_, err = backups.ParsePrivateKey(pub.String())
+	c.Check(err, gc.ErrorMatches, `invalid backup private key: malformed secret key: .*`)
+}
@@ -4,6 +4,8 @@
 package backups
 
 import (
+	"bytes"
+	"io"
 	"os/exec"
 	"strings"
 
@@ -27,3 +29,25 @@ func runCommand(cmd string, args ...string) error {
 	}
 	return errors.Annotatef(err, "error executing %q", cmd)
 }
+
+// runStreamCommand execs the provided command, writing its standard
+// output to stdout. It exists here so it can be overridden in
+// export_test.go
+func runStreamCommand(stdout io.Writer, cmd string, args ...string) error {
+	var stderr bytes.Buffer
+	command := exec.Command(cmd, args...)
+	command.Stdout = stdout
+	command.Stderr = &stderr
+	err := command.Run()
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*exec.ExitError); ok && stderr.Len() > 0 {
+		return errors.Errorf(
+			"error executing %q: %s",
+			cmd,
+			strings.Replace(strings.TrimSpace(stderr.String()), "\n", "; ", -1),
+		)
+	}
+	return errors.Annotatef(err, "error executing %q", cmd)
+}
@@ -28,8 +28,13 @@ var (
 	GetMongodumpPath      = &getMongodumpPath
 	GetMongorestorePath   = &getMongorestorePath
 	RunCommand            = &runCommandFn
+	RunStreamCommand      = &runStreamCommandFn
+	DumpChunkSize         = &dumpChunkSize
+	AssembleDumpArchives  = assembleDumpArchives
 	ReplaceableFolders    = &replaceableFolders
 	MongoInstalledVersion = &mongoInstalledVersion
+	WaitUntilReady        = &waitUntilReady
+	GetOplogRangeRef      = &getOplogRange
 )
 
 var _ filestorage.DocStorage = (*backupsDocStorage)(nil)
@@ -169,3 +174,37 @@ func NewTestArchiveStorer(failure string) func(filestorage.FileStorage, *Metadat
 
 // Export for patching in tests
 var RestorePath = &getMongorestorePath
+
+// EncryptCreateArgs sets the public key the archive built with the
+// args is encrypted to.
+func EncryptCreateArgs(args *createArgs, key PublicKey) {
+	args.encryptTo = &key
+}
+
+// StreamCreateArgs makes the archive built with the args stream the
+// DB dump.
+func StreamCreateArgs(args *createArgs) {
+	args.stream = true
+}
+
+// ExposeCreateStream reports whether a create() args value streams
+// the DB dump.
+func ExposeCreateStream(args *createArgs) bool {
+	return args.stream
+}
+
+// SetIncrementalSince makes a dumper built with the info dump only the
+// oplog entries after the timestamp.
+func SetIncrementalSince(info *DBInfo, since int64) {
+	info.since = since
+}
+
+// IncrementalSince returns the timestamp after which a dumper built
+// with the info dumps oplog entries.
+func IncrementalSince(info *DBInfo) int64 {
+	return info.since
+}
+
+// RestoreCheck allows the scratch restore made when verifying a
+// backup to be patched.
+var RestoreCheck = &restoreCheck
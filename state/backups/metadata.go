@@ -89,6 +89,20 @@ type Metadata struct {
 	// Controller contains metadata about the controller where the backup was taken.
 	Controller ControllerMetadata
 
+	// Encryption describes how the archive was encrypted, or is nil
+	// if the archive is not encrypted.
+	Encryption *Encryption
+
+	// OplogTimestamp is the timestamp of the newest mongo oplog entry
+	// when the backup was started. An incremental backup following
+	// this one contains the oplog entries after it. It is zero if it
+	// is not known.
+	OplogTimestamp int64
+
+	// BaseID is the ID of the backup an incremental backup follows,
+	// which must be restored before it. It is empty for full backups.
+	BaseID string
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -119,8 +133,34 @@ type ControllerMetadata struct {
 }
 
 // All un-versioned metadata is considered to be version 0,
-// so the versions start with 1.
-const currentFormatVersion = 1
+// so the versions start with 1. Later versions are only used for the
+// archives that need them, so that older clients can still read the
+// metadata of every other archive.
+const (
+	currentFormatVersion = 1
+
+	// encryptedFormatVersion is used for encrypted archives.
+	encryptedFormatVersion = 2
+
+	// incrementalFormatVersion is used for incremental archives, which
+	// cannot be restored without the backup they follow. The oplog
+	// position recorded by full backups is ignored by older clients,
+	// so it needs no later version.
+	incrementalFormatVersion = 3
+)
+
+// archiveFormatVersion returns the format version the metadata is
+// written with: the oldest version able to describe the archive.
+func (m *Metadata) archiveFormatVersion() int64 {
+	version := m.FormatVersion
+	if m.Encryption != nil && version < encryptedFormatVersion {
+		version = encryptedFormatVersion
+	}
+	if m.BaseID != "" && version < incrementalFormatVersion {
+		version = incrementalFormatVersion
+	}
+	return version
+}
 
 // NewMetadata returns a new Metadata for a state backup archive,
 //in the most current format.
@@ -246,10 +286,13 @@ func (flat *flatMetadataV0) inflate() (*Metadata, error) {
 	return meta, nil
 }
 
-// flatMetadata contains the latest format of the backup.
-// NOTE If any changes need to be made here, rename this struct to
-// reflect version 1, for example flatMetadataV1 and construct
-// new flatMetadata with desired modifications.
+// flatMetadata contains the latest format of the backup. Versions 2
+// and 3 only add optional fields to version 1, so all three are read
+// with it.
+// NOTE If any changes need to be made here other than adding optional
+// fields, rename this struct to reflect version 3, for example
+// flatMetadataV3 and construct new flatMetadata with desired
+// modifications.
 type flatMetadata struct {
 	ID            string
 	FormatVersion int64
@@ -277,6 +320,16 @@ type flatMetadata struct {
 	ControllerMachineInstanceID string
 	CACert                      string
 	CAPrivateKey                string
+
+	// encryption
+
+	EncryptionScheme    string `json:",omitempty"`
+	EncryptionRecipient string `json:",omitempty"`
+
+	// incremental
+
+	OplogTimestamp int64  `json:",omitempty"`
+	BaseID         string `json:",omitempty"`
 }
 
 func (m *Metadata) flat() flatMetadata {
@@ -294,11 +347,17 @@ func (m *Metadata) flat() flatMetadata {
 		Series:                      m.Origin.Series,
 		CACert:                      m.CACert,
 		CAPrivateKey:                m.CAPrivateKey,
-		FormatVersion:               m.FormatVersion,
+		FormatVersion:               m.archiveFormatVersion(),
 		ControllerUUID:              m.Controller.UUID,
 		ControllerMachineID:         m.Controller.MachineID,
 		ControllerMachineInstanceID: m.Controller.MachineInstanceID,
 		HANodes:                     m.Controller.HANodes,
+		OplogTimestamp:              m.OplogTimestamp,
+		BaseID:                      m.BaseID,
+	}
+	if m.Encryption != nil {
+		flat.EncryptionScheme = m.Encryption.Scheme
+		flat.EncryptionRecipient = m.Encryption.Recipient
 	}
 	stored := m.Stored()
 	if stored != nil {
@@ -349,6 +408,15 @@ func (flat *flatMetadata) inflate() (*Metadata, error) {
 	meta.CACert = flat.CACert
 	meta.CAPrivateKey = flat.CAPrivateKey
 
+	if flat.EncryptionScheme != "" {
+		meta.Encryption = &Encryption{
+			Scheme:    flat.EncryptionScheme,
+			Recipient: flat.EncryptionRecipient,
+		}
+	}
+	meta.OplogTimestamp = flat.OplogTimestamp
+	meta.BaseID = flat.BaseID
+
 	return meta, nil
 }
 
@@ -384,7 +452,7 @@ func NewMetadataJSONReader(in io.Reader) (*Metadata, error) {
 			}
 			return v0.inflate()
 		}
-	case 1:
+	case currentFormatVersion, encryptedFormatVersion, incrementalFormatVersion:
 		return flat.inflate()
 	default:
 		return nil, errors.NotSupportedf("backup format %d", flat.FormatVersion)
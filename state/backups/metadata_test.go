@@ -147,6 +147,68 @@ func (s *metadataSuite) TestAsJSONBufferV1HA(c *gc.C) {
 		`}`+"\n")
 }
 
+func (s *metadataSuite) TestNewMetadataFormatVersion(c *gc.C) {
+	// Archives which need no later format are readable by clients
+	// which only know version 1.
+	c.Check(backups.NewMetadata().FormatVersion, gc.Equals, int64(1))
+}
+
+func (s *metadataSuite) TestAsJSONBufferEncrypted(c *gc.C) {
+	meta := s.createTestMetadata(c)
+	meta.Controller = backups.ControllerMetadata{
+		UUID:              "controller-uuid",
+		MachineInstanceID: "inst-10101010",
+		MachineID:         "10",
+	}
+	meta.Encryption = &backups.Encryption{
+		Scheme:    backups.EncryptionScheme,
+		Recipient: "age1abc",
+	}
+	s.assertMetadata(c, meta, `{`+
+		`"ID":"20140909-115934.asdf-zxcv-qwe",`+
+		`"FormatVersion":2,`+
+		`"Checksum":"123af2cef",`+
+		`"ChecksumFormat":"SHA-1, base64 encoded",`+
+		`"Size":10,`+
+		`"Stored":"0001-01-01T00:00:00Z",`+
+		`"Started":"2014-09-09T11:59:34Z",`+
+		`"Finished":"2014-09-09T12:00:34Z",`+
+		`"Notes":"",`+
+		`"ModelUUID":"asdf-zxcv-qwe",`+
+		`"Machine":"0",`+
+		`"Hostname":"myhost",`+
+		`"Version":"1.21-alpha3",`+
+		`"Series":"trusty",`+
+		`"ControllerUUID":"controller-uuid",`+
+		`"HANodes":0,`+
+		`"ControllerMachineID":"10",`+
+		`"ControllerMachineInstanceID":"inst-10101010",`+
+		`"CACert":"ca-cert",`+
+		`"CAPrivateKey":"ca-private-key",`+
+		`"EncryptionScheme":"age-x25519",`+
+		`"EncryptionRecipient":"age1abc"`+
+		`}`+"\n")
+}
+
+func (s *metadataSuite) TestAsJSONBufferIncrementalFormatVersion(c *gc.C) {
+	meta := s.createTestMetadata(c)
+	meta.OplogTimestamp = 6082893392612016129
+	// Full backups recording their oplog position stay readable by
+	// clients which only know version 1.
+	s.checkFormatVersion(c, meta, 1)
+
+	meta.BaseID = "20140909-105934.asdf-zxcv-qwe"
+	s.checkFormatVersion(c, meta, 3)
+}
+
+func (s *metadataSuite) checkFormatVersion(c *gc.C, meta *backups.Metadata, expected int64) {
+	buf, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	written, err := backups.NewMetadataJSONReader(buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(written.FormatVersion, gc.Equals, expected)
+}
+
 func (s *metadataSuite) TestNewMetadataJSONReaderV0(c *gc.C) {
 	file := bytes.NewBufferString(`{` +
 		`"ID":"20140909-115934.asdf-zxcv-qwe",` +
@@ -226,13 +288,69 @@ func (s *metadataSuite) TestNewMetadataJSONReaderV1(c *gc.C) {
 	c.Check(meta.Controller.MachineID, gc.Equals, "10")
 }
 
-func (s *metadataSuite) TestNewMetadataJSONReaderUnsupported(c *gc.C) {
+func (s *metadataSuite) TestNewMetadataJSONReaderV2(c *gc.C) {
 	file := bytes.NewBufferString(`{` +
 		`"ID":"20140909-115934.asdf-zxcv-qwe",` +
 		`"FormatVersion":2,` +
 		`"Checksum":"123af2cef",` +
 		`"ChecksumFormat":"SHA-1, base64 encoded",` +
 		`"Size":10,` +
+		`"Started":"2014-09-09T11:59:34Z",` +
+		`"Finished":"2014-09-09T12:00:34Z",` +
+		`"ModelUUID":"asdf-zxcv-qwe",` +
+		`"Machine":"0",` +
+		`"Hostname":"myhost",` +
+		`"Version":"1.21-alpha3",` +
+		`"ControllerUUID":"controller-uuid",` +
+		`"EncryptionScheme":"age-x25519",` +
+		`"EncryptionRecipient":"age1abc"` +
+		`}` + "\n")
+	meta, err := backups.NewMetadataJSONReader(file)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(meta.ID(), gc.Equals, "20140909-115934.asdf-zxcv-qwe")
+	c.Check(meta.FormatVersion, gc.Equals, int64(2))
+	c.Check(meta.Controller.UUID, gc.Equals, "controller-uuid")
+	c.Check(meta.Encryption, jc.DeepEquals, &backups.Encryption{
+		Scheme:    "age-x25519",
+		Recipient: "age1abc",
+	})
+}
+
+func (s *metadataSuite) TestNewMetadataJSONReaderV3(c *gc.C) {
+	file := bytes.NewBufferString(`{` +
+		`"ID":"20140909-115934.asdf-zxcv-qwe",` +
+		`"FormatVersion":3,` +
+		`"Checksum":"123af2cef",` +
+		`"ChecksumFormat":"SHA-1, base64 encoded",` +
+		`"Size":10,` +
+		`"Started":"2014-09-09T11:59:34Z",` +
+		`"Finished":"2014-09-09T12:00:34Z",` +
+		`"ModelUUID":"asdf-zxcv-qwe",` +
+		`"Machine":"0",` +
+		`"Hostname":"myhost",` +
+		`"Version":"1.21-alpha3",` +
+		`"ControllerUUID":"controller-uuid",` +
+		`"OplogTimestamp":6082893392612016129,` +
+		`"BaseID":"20140909-105934.asdf-zxcv-qwe"` +
+		`}` + "\n")
+	meta, err := backups.NewMetadataJSONReader(file)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(meta.ID(), gc.Equals, "20140909-115934.asdf-zxcv-qwe")
+	c.Check(meta.FormatVersion, gc.Equals, int64(3))
+	c.Check(meta.Encryption, gc.IsNil)
+	c.Check(meta.OplogTimestamp, gc.Equals, int64(6082893392612016129))
+	c.Check(meta.BaseID, gc.Equals, "20140909-105934.asdf-zxcv-qwe")
+}
+
+func (s *metadataSuite) TestNewMetadataJSONReaderUnsupported(c *gc.C) {
+	file := bytes.NewBufferString(`{` +
+		`"ID":"20140909-115934.asdf-zxcv-qwe",` +
+		`"FormatVersion":4,` +
+		`"Checksum":"123af2cef",` +
+		`"ChecksumFormat":"SHA-1, base64 encoded",` +
+		`"Size":10,` +
 		`"Stored":"0001-01-01T00:00:00Z",` +
 		`"Started":"2014-09-09T11:59:34Z",` +
 		`"Finished":"2014-09-09T12:00:34Z",` +
@@ -0,0 +1,114 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+)
+
+var (
+	waitUntilReady = replicaset.WaitUntilReady
+	getOplogRange  = GetOplogRange
+)
+
+// ControllerBackend exposes the state needed to prepare a backup of
+// the controller.
+type ControllerBackend interface {
+	DB
+
+	// MongoVersion is the version of the mongo being backed up.
+	MongoVersion() (string, error)
+
+	// ControllerNodes returns the controller nodes in HA.
+	ControllerNodes() ([]state.ControllerNode, error)
+}
+
+// ControllerMachine is the controller machine a backup is taken on.
+type ControllerMachine interface {
+	InstanceId() (instance.Id, error)
+	Series() string
+}
+
+// CreateArgs describes a backup of the controller to create.
+type CreateArgs struct {
+	// MongoInfo holds the details used to connect to mongo when
+	// dumping the database.
+	MongoInfo *mongo.MongoInfo
+
+	// MachineID and Machine identify the controller machine the
+	// backup is taken on.
+	MachineID string
+	Machine   ControllerMachine
+
+	// Notes is a user-supplied annotation for the backup.
+	Notes string
+
+	// EncryptTo is the public key to encrypt the archive to, if any.
+	EncryptTo string
+
+	// Stream and Incremental are copied to the DBInfo.
+	Stream      bool
+	Incremental bool
+}
+
+// PrepareCreate waits for the controller's replica set to be ready,
+// then returns the metadata and database info for the backup described
+// by args, ready to pass to Backups.Create.
+func PrepareCreate(backend ControllerBackend, args CreateArgs) (*Metadata, *DBInfo, error) {
+	session := backend.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready.
+	if err := waitUntilReady(session, 60); err != nil {
+		return nil, nil, errors.Annotatef(err, "HA not ready; try again later")
+	}
+
+	v, err := backend.MongoVersion()
+	if err != nil {
+		return nil, nil, errors.Annotatef(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	dbInfo, err := NewDBInfo(args.MongoInfo, session, mongoVersion)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	// Recording the oplog position lets an incremental backup follow
+	// this one.
+	dbInfo.Oplog, err = getOplogRange(session)
+	if err != nil {
+		return nil, nil, errors.Annotatef(err, "getting oplog position")
+	}
+	dbInfo.Stream = args.Stream
+	dbInfo.Incremental = args.Incremental
+
+	meta, err := NewMetadataState(backend, args.MachineID, args.Machine.Series())
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	meta.Notes = args.Notes
+	if args.EncryptTo != "" {
+		meta.Encryption = &Encryption{Recipient: args.EncryptTo}
+	}
+	meta.Controller.MachineID = args.MachineID
+	instanceID, err := args.Machine.InstanceId()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	meta.Controller.MachineInstanceID = string(instanceID)
+
+	nodes, err := backend.ControllerNodes()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	meta.Controller.HANodes = int64(len(nodes))
+	return meta, dbInfo, nil
+}
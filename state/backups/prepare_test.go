@@ -0,0 +1,107 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type prepareSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&prepareSuite{})
+
+type prepareBackend struct {
+	*state.State
+	model *state.Model
+}
+
+func (b *prepareBackend) ModelTag() names.ModelTag {
+	return b.model.ModelTag()
+}
+
+func (b *prepareBackend) ModelConfig() (*config.Config, error) {
+	return b.model.ModelConfig()
+}
+
+func (b *prepareBackend) StateServingInfo() (controller.StateServingInfo, error) {
+	return controller.StateServingInfo{CAPrivateKey: "ca-private-key"}, nil
+}
+
+func (b *prepareBackend) ControllerNodes() ([]state.ControllerNode, error) {
+	return make([]state.ControllerNode, 3), nil
+}
+
+type prepareMachine struct{}
+
+func (prepareMachine) InstanceId() (instance.Id, error) {
+	return "inst-0", nil
+}
+
+func (prepareMachine) Series() string {
+	return "focal"
+}
+
+func (s *prepareSuite) args() backups.CreateArgs {
+	return backups.CreateArgs{
+		MongoInfo: &mongo.MongoInfo{
+			Info:     mongo.Info{Addrs: []string{"localhost:37017"}},
+			Tag:      names.NewMachineTag("0"),
+			Password: "eggs",
+		},
+		MachineID: "0",
+		Machine:   prepareMachine{},
+		Notes:     "some notes",
+		EncryptTo: "juju-backup-public-key:abc",
+		Stream:    true,
+	}
+}
+
+func (s *prepareSuite) TestPrepareCreate(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady, func(*mgo.Session, int) error { return nil })
+	oplog := backups.OplogRange{First: 1 << 32, Last: 5 << 32}
+	s.PatchValue(backups.GetOplogRangeRef, func(*mgo.Session) (backups.OplogRange, error) { return oplog, nil })
+
+	backend := &prepareBackend{State: s.State, model: s.Model}
+	meta, dbInfo, err := backups.PrepareCreate(backend, s.args())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(dbInfo.Address, gc.Equals, "localhost:37017")
+	c.Check(dbInfo.Username, gc.Equals, "machine-0")
+	c.Check(dbInfo.Password, gc.Equals, "eggs")
+	c.Check(dbInfo.Oplog, gc.Equals, oplog)
+	c.Check(dbInfo.Stream, jc.IsTrue)
+	c.Check(dbInfo.Incremental, jc.IsFalse)
+
+	c.Check(meta.Origin.Model, gc.Equals, s.Model.UUID())
+	c.Check(meta.Origin.Machine, gc.Equals, "0")
+	c.Check(meta.Origin.Series, gc.Equals, "focal")
+	c.Check(meta.Notes, gc.Equals, "some notes")
+	c.Check(meta.Encryption, jc.DeepEquals, &backups.Encryption{Recipient: "juju-backup-public-key:abc"})
+	c.Check(meta.CAPrivateKey, gc.Equals, "ca-private-key")
+	c.Check(meta.Controller.MachineID, gc.Equals, "0")
+	c.Check(meta.Controller.MachineInstanceID, gc.Equals, "inst-0")
+	c.Check(meta.Controller.HANodes, gc.Equals, int64(3))
+}
+
+func (s *prepareSuite) TestPrepareCreateHANotReady(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady, func(*mgo.Session, int) error { return errors.New("boom") })
+
+	backend := &prepareBackend{State: s.State, model: s.Model}
+	_, _, err := backups.PrepareCreate(backend, s.args())
+	c.Assert(err, gc.ErrorMatches, "HA not ready; try again later: boom")
+}
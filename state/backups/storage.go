@@ -54,6 +54,16 @@ type storageMetaDoc struct {
 	Hostname string         `bson:"hostname"`
 	Version  version.Number `bson:"version"`
 	Series   string         `bson:"series"`
+
+	// encryption
+
+	EncryptionScheme    string `bson:"encryption-scheme,omitempty"`
+	EncryptionRecipient string `bson:"encryption-recipient,omitempty"`
+
+	// incremental
+
+	OplogTimestamp int64  `bson:"oplog-timestamp,omitempty"`
+	BaseID         string `bson:"base-id,omitempty"`
 }
 
 func (doc *storageMetaDoc) isFileInfoComplete() bool {
@@ -151,6 +161,16 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 		meta.SetStored(&stored)
 	}
 
+	if doc.EncryptionScheme != "" {
+		meta.Encryption = &Encryption{
+			Scheme:    doc.EncryptionScheme,
+			Recipient: doc.EncryptionRecipient,
+		}
+	}
+	meta.OplogTimestamp = doc.OplogTimestamp
+	meta.BaseID = doc.BaseID
+	meta.FormatVersion = meta.archiveFormatVersion()
+
 	return meta
 }
 
@@ -181,6 +201,13 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 	doc.Version = meta.Origin.Version
 	doc.Series = meta.Origin.Series
 
+	if meta.Encryption != nil {
+		doc.EncryptionScheme = meta.Encryption.Scheme
+		doc.EncryptionRecipient = meta.Encryption.Recipient
+	}
+	doc.OplogTimestamp = meta.OplogTimestamp
+	doc.BaseID = meta.BaseID
+
 	return doc
 }
 
@@ -0,0 +1,162 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// dumpArchiveSuffix is added to the name of a database to name the
+// chunks of its streamed dump in a backup archive. Each chunk has a
+// further suffix with its sequence number.
+const dumpArchiveSuffix = ".archive"
+
+// dumpChunkSize is the most of a streamed database dump which is held
+// in memory before being written to the archive.
+var dumpChunkSize = 16 << 20
+
+// chunkWriter writes a stream of unknown length to a tar archive as a
+// sequence of numbered entries of at most dumpChunkSize bytes. Tar
+// entries must be sized up front, so this is what lets a database dump
+// go into the archive without first being staged on disk.
+type chunkWriter struct {
+	tarw *tar.Writer
+	name string
+	next int
+	buf  bytes.Buffer
+}
+
+func newChunkWriter(tarw *tar.Writer, name string) *chunkWriter {
+	return &chunkWriter{tarw: tarw, name: name}
+}
+
+// Write implements io.Writer.
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := dumpChunkSize - w.buf.Len()
+		if n > len(p) {
+			n = len(p)
+		}
+		w.buf.Write(p[:n])
+		p = p[n:]
+		written += n
+		if w.buf.Len() == dumpChunkSize {
+			if err := w.flush(); err != nil {
+				return written, errors.Trace(err)
+			}
+		}
+	}
+	return written, nil
+}
+
+// Close writes any buffered data to the archive. It does not close
+// the underlying tar writer.
+func (w *chunkWriter) Close() error {
+	return errors.Trace(w.flush())
+}
+
+func (w *chunkWriter) flush() error {
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     fmt.Sprintf("%s.%04d", w.name, w.next),
+		Mode:     0600,
+		Size:     int64(w.buf.Len()),
+		ModTime:  time.Now(),
+	}
+	if err := w.tarw.WriteHeader(header); err != nil {
+		return errors.Annotatef(err, "while writing header for %q", header.Name)
+	}
+	if _, err := w.tarw.Write(w.buf.Bytes()); err != nil {
+		return errors.Annotatef(err, "while writing %q", header.Name)
+	}
+	w.buf.Reset()
+	w.next++
+	return nil
+}
+
+// assembleDumpArchives joins the chunks of each streamed database dump
+// found in dumpDir into a single archive file in targetDir, removing
+// the chunks as it goes so that the dump is never held on disk twice.
+// It returns the paths of the archive files, which is empty if the
+// dump was not streamed.
+func assembleDumpArchives(dumpDir, targetDir string) ([]string, error) {
+	infos, err := ioutil.ReadDir(dumpDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	chunks := make(map[string][]string)
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(info.Name())
+		name := strings.TrimSuffix(info.Name(), ext)
+		if _, err := strconv.Atoi(strings.TrimPrefix(ext, ".")); err != nil {
+			continue
+		}
+		if !strings.HasSuffix(name, dumpArchiveSuffix) {
+			continue
+		}
+		chunks[name] = append(chunks[name], info.Name())
+	}
+	if len(chunks) == 0 {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(targetDir, 0700); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var archives []string
+	for name, names := range chunks {
+		// The sequence numbers are zero padded, so they sort.
+		sort.Strings(names)
+		archive := filepath.Join(targetDir, name)
+		if err := joinChunks(archive, dumpDir, names); err != nil {
+			return nil, errors.Annotatef(err, "while assembling %q", name)
+		}
+		archives = append(archives, archive)
+	}
+	sort.Strings(archives)
+	return archives, nil
+}
+
+func joinChunks(target, dir string, names []string) error {
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer out.Close()
+	for _, name := range names {
+		chunk := filepath.Join(dir, name)
+		in, err := os.Open(chunk)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		_, err = io.Copy(out, in)
+		in.Close()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := os.Remove(chunk); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(out.Close())
+}
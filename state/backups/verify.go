@@ -0,0 +1,597 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/utils/hash"
+	"github.com/juju/utils/tar"
+	"github.com/juju/version"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/core/paths"
+)
+
+// The names of the checks made when verifying a backup archive.
+const (
+	CheckArchive     = "archive"
+	CheckMetadata    = "metadata"
+	CheckChecksum    = "checksum"
+	CheckDBDump      = "database dump"
+	CheckAgentConfig = "agent config"
+	CheckRestore     = "restore"
+)
+
+// mongodumpArchiveMagic starts every archive written by
+// "mongodump --archive".
+var mongodumpArchiveMagic = []byte{0x6d, 0xe2, 0x99, 0x81}
+
+// maxBSONDocumentSize is the largest document mongo will store, with
+// some allowance for the oplog entries of documents that size.
+const maxBSONDocumentSize = 16*1024*1024 + 16*1024
+
+// VerifyArgs holds what is needed to verify a backup archive.
+type VerifyArgs struct {
+	// Expected is the metadata stored for the backup, if it is known.
+	// The archive's size and checksum are checked against it.
+	Expected *Metadata
+
+	// Key decrypts the archive if it is encrypted.
+	Key *PrivateKey
+
+	// Restore requests that the database dump is restored into a
+	// scratch local mongod and the restored collections checked.
+	Restore bool
+}
+
+// VerifyCheck is the outcome of one of the checks made when verifying
+// a backup archive.
+type VerifyCheck struct {
+	// Name identifies the check.
+	Name string
+
+	// Skipped says why the check was not made, if it was not.
+	Skipped string
+
+	// Err says why the check failed. It is nil if the check passed
+	// or was skipped.
+	Err error
+}
+
+// VerifyReport holds the outcome of verifying a backup archive.
+type VerifyReport struct {
+	// Metadata is the metadata read from the archive, if it could be.
+	Metadata *Metadata
+
+	// Checks holds the outcome of each check, in the order made.
+	Checks []VerifyCheck
+}
+
+// Passed reports whether none of the checks failed.
+func (r *VerifyReport) Passed() bool {
+	for _, check := range r.Checks {
+		if check.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *VerifyReport) add(name string, err error) {
+	r.Checks = append(r.Checks, VerifyCheck{Name: name, Err: err})
+}
+
+func (r *VerifyReport) skip(name, reason string) {
+	r.Checks = append(r.Checks, VerifyCheck{Name: name, Skipped: reason})
+}
+
+// countingWriter counts the bytes written to it.
+type countingWriter struct {
+	n int64
+}
+
+// Write implements io.Writer.
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// Verify checks that the backup archive read from archive is complete
+// and restorable, as far as can be told without restoring it onto a
+// controller. Failed checks are recorded in the report rather than
+// returned as errors; checks which depend on a failed one are skipped.
+func Verify(archive io.Reader, args VerifyArgs) (*VerifyReport, error) {
+	report := &VerifyReport{}
+
+	// The checksum is of the archive file as stored, which for an
+	// encrypted archive is the encrypted file.
+	hasher := hash.NewHashingWriter(ioutil.Discard, sha1.New())
+	var size countingWriter
+	source := bufio.NewReader(io.TeeReader(archive, io.MultiWriter(hasher, &size)))
+	var plain io.Reader = source
+	if IsEncrypted(source) {
+		if args.Key == nil {
+			report.add(CheckArchive, errors.New("archive is encrypted and no private key was given"))
+			return report, nil
+		}
+		var err error
+		if plain, err = NewDecryptingReader(source, *args.Key); err != nil {
+			report.add(CheckArchive, errors.Trace(err))
+			return report, nil
+		}
+	}
+	ws, err := NewArchiveWorkspaceReader(plain)
+	if ws != nil {
+		defer ws.Close()
+	}
+	if err == nil {
+		// Make sure the whole file contributes to the checksum.
+		_, err = io.Copy(ioutil.Discard, source)
+	}
+	report.add(CheckArchive, errors.Trace(err))
+	if err != nil {
+		return report, nil
+	}
+
+	meta, err := ws.Metadata()
+	if os.IsNotExist(errors.Cause(err)) {
+		err = errors.New("archive has no metadata file")
+	}
+	if err == nil {
+		err = checkMetadata(meta, args.Expected)
+		report.Metadata = meta
+	}
+	report.add(CheckMetadata, err)
+
+	if args.Expected == nil || args.Expected.Checksum() == "" {
+		report.skip(CheckChecksum, "no stored checksum to compare with")
+	} else {
+		report.add(CheckChecksum, checkChecksum(args.Expected, hasher.Base64Sum(), size.n))
+	}
+
+	if report.Metadata == nil {
+		report.skip(CheckDBDump, "metadata not available")
+		report.skip(CheckAgentConfig, "metadata not available")
+		report.skip(CheckRestore, "metadata not available")
+		return report, nil
+	}
+	incremental := meta.BaseID != ""
+	dumpErr := checkDBDump(ws.DBDumpDir, incremental)
+	report.add(CheckDBDump, dumpErr)
+	report.add(CheckAgentConfig, checkAgentConfig(ws, meta))
+
+	switch {
+	case !args.Restore:
+		report.skip(CheckRestore, "not requested")
+	case incremental:
+		report.skip(CheckRestore, "an incremental backup cannot be restored without the backups it follows")
+	case dumpErr != nil:
+		report.skip(CheckRestore, "database dump is not valid")
+	default:
+		report.add(CheckRestore, restoreCheck(ws.DBDumpDir, meta))
+	}
+	return report, nil
+}
+
+func checkMetadata(meta, expected *Metadata) error {
+	if !known(meta.Origin.Model) {
+		return errors.New("no model UUID recorded")
+	}
+	if !names.IsValidMachine(meta.Origin.Machine) {
+		return errors.Errorf("machine ID %q not valid", meta.Origin.Machine)
+	}
+	if meta.Origin.Version == version.Zero || meta.Origin.Version == UnknownVersion {
+		return errors.New("no juju version recorded")
+	}
+	if meta.Started.IsZero() {
+		return errors.New("no start time recorded")
+	}
+	if expected == nil {
+		return nil
+	}
+	if known(expected.Origin.Model) && expected.Origin.Model != meta.Origin.Model {
+		return errors.Errorf("archive is of model %q, expected %q", meta.Origin.Model, expected.Origin.Model)
+	}
+	// Stored metadata only records the start time to the second.
+	if !expected.Started.IsZero() && expected.Started.Unix() != meta.Started.Unix() {
+		return errors.Errorf("archive was started at %v, expected %v", meta.Started, expected.Started)
+	}
+	return nil
+}
+
+// known reports whether the metadata value is set to a known value.
+func known(value string) bool {
+	return value != "" && value != UnknownString
+}
+
+func checkChecksum(expected *Metadata, checksum string, size int64) error {
+	if format := expected.ChecksumFormat(); format != "" && format != checksumFormat {
+		return errors.NotSupportedf("checksum format %q", format)
+	}
+	if expected.Size() != 0 && expected.Size() != size {
+		return errors.Errorf("archive is %d bytes, expected %d", size, expected.Size())
+	}
+	if checksum != expected.Checksum() {
+		return errors.Errorf("archive checksum is %q, expected %q", checksum, expected.Checksum())
+	}
+	return nil
+}
+
+// checkDBDump checks that the database dump in dumpDir holds the juju
+// database, in either a staged or streamed dump, and that each dumped
+// collection is made of whole BSON documents. An incremental dump only
+// holds the oplog entries since the backup it follows.
+func checkDBDump(dumpDir string, incremental bool) error {
+	infos, err := ioutil.ReadDir(dumpDir)
+	if err != nil {
+		return errors.Annotate(err, "archive has no database dump")
+	}
+	databases := make(map[string]bool)
+	chunks := make(map[string][]string)
+	hasOplog := false
+	for _, info := range infos {
+		name := info.Name()
+		switch {
+		case info.IsDir():
+			if err := checkDumpedDatabase(filepath.Join(dumpDir, name)); err != nil {
+				return errors.Annotatef(err, "database %q", name)
+			}
+			databases[name] = true
+		case name == oplogFile:
+			if err := checkBSONFile(filepath.Join(dumpDir, name)); err != nil {
+				return errors.Annotate(err, "oplog")
+			}
+			hasOplog = true
+		default:
+			ext := filepath.Ext(name)
+			base := strings.TrimSuffix(name, ext)
+			if !strings.HasSuffix(base, dumpArchiveSuffix) {
+				continue
+			}
+			chunks[base] = append(chunks[base], name)
+		}
+	}
+	for base, names := range chunks {
+		database := strings.TrimSuffix(base, dumpArchiveSuffix)
+		if err := checkDumpChunks(dumpDir, base, names); err != nil {
+			return errors.Annotatef(err, "database %q", database)
+		}
+		databases[database] = true
+	}
+
+	if incremental {
+		if !hasOplog {
+			return errors.New("incremental backup has no oplog")
+		}
+		return nil
+	}
+	if !databases["juju"] {
+		return errors.New("juju database not dumped")
+	}
+	if len(chunks) > 0 && !hasOplog {
+		return errors.New("streamed backup has no oplog")
+	}
+	return nil
+}
+
+// checkDumpedDatabase checks each collection in a database dumped by
+// mongodump into dir.
+func checkDumpedDatabase(dir string) error {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	metadataFiles := make(map[string]bool)
+	var collections []string
+	for _, info := range infos {
+		name := info.Name()
+		switch {
+		case strings.HasSuffix(name, ".metadata.json"):
+			metadataFiles[strings.TrimSuffix(name, ".metadata.json")] = true
+			data, err := ioutil.ReadFile(filepath.Join(dir, name))
+			if err != nil {
+				return errors.Trace(err)
+			}
+			var metadata map[string]interface{}
+			if err := json.Unmarshal(data, &metadata); err != nil {
+				return errors.Annotatef(err, "collection metadata %q", name)
+			}
+		case strings.HasSuffix(name, ".bson"):
+			collection := strings.TrimSuffix(name, ".bson")
+			if err := checkBSONFile(filepath.Join(dir, name)); err != nil {
+				return errors.Annotatef(err, "collection %q", collection)
+			}
+			collections = append(collections, collection)
+		}
+	}
+	for _, collection := range collections {
+		// Mongo 2.4 dumps the indexes as a collection of their own.
+		if collection == "system.indexes" {
+			continue
+		}
+		if !metadataFiles[collection] {
+			return errors.Errorf("collection %q has no metadata", collection)
+		}
+	}
+	return nil
+}
+
+// checkBSONFile checks that the file is a sequence of whole BSON
+// documents, as written by mongodump.
+func checkBSONFile(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	for count := 0; ; count++ {
+		var length int32
+		if err := binary.Read(r, binary.LittleEndian, &length); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Annotatef(err, "document %d truncated", count)
+		}
+		if length < 5 || length > maxBSONDocumentSize {
+			return errors.Errorf("document %d has invalid length %d", count, length)
+		}
+		doc := make([]byte, length)
+		binary.LittleEndian.PutUint32(doc, uint32(length))
+		if _, err := io.ReadFull(r, doc[4:]); err != nil {
+			return errors.Errorf("document %d truncated", count)
+		}
+		var raw bson.D
+		if err := bson.Unmarshal(doc, &raw); err != nil {
+			return errors.Annotatef(err, "document %d", count)
+		}
+	}
+}
+
+// checkDumpChunks checks that the chunks of a streamed database dump
+// are all present and that they hold a mongodump archive.
+func checkDumpChunks(dumpDir, base string, names []string) error {
+	sort.Strings(names)
+	for i, name := range names {
+		seq, err := strconv.Atoi(strings.TrimPrefix(filepath.Ext(name), "."))
+		if err != nil {
+			return errors.Errorf("unexpected dump file %q", name)
+		}
+		if seq != i {
+			return errors.Errorf("chunk %d of the dump is missing", i)
+		}
+	}
+	f, err := os.Open(filepath.Join(dumpDir, names[0]))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	magic := make([]byte, len(mongodumpArchiveMagic))
+	if _, err := io.ReadFull(f, magic); err != nil || !bytes.Equal(magic, mongodumpArchiveMagic) {
+		return errors.Errorf("dump %q is not a mongodump archive", base)
+	}
+	return nil
+}
+
+// checkAgentConfig checks that the files in the archive include the
+// agent config of the controller machine that was backed up, as
+// restoring needs it.
+func checkAgentConfig(ws *ArchiveWorkspace, meta *Metadata) error {
+	if !names.IsValidMachine(meta.Origin.Machine) {
+		return errors.Errorf("machine ID %q not valid", meta.Origin.Machine)
+	}
+	tag := names.NewMachineTag(meta.Origin.Machine)
+	dir := dataDir
+	if meta.Origin.Series != "" {
+		if seriesDir, err := paths.DataDir(meta.Origin.Series); err == nil {
+			dir = seriesDir
+		}
+	}
+	bundled := strings.TrimPrefix(filepath.ToSlash(agent.ConfigPath(dir, tag)), "/")
+	data, err := readBundledFile(ws, bundled)
+	if errors.IsNotFound(err) {
+		return errors.Errorf("archive has no agent config for %s", names.ReadableString(tag))
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// The agent config can only be parsed from a file.
+	configFile := filepath.Join(ws.RootDir, "agent.conf")
+	if err := ioutil.WriteFile(configFile, data, 0600); err != nil {
+		return errors.Trace(err)
+	}
+	conf, err := agent.ReadConfig(configFile)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if conf.Tag() != tag {
+		return errors.Errorf("agent config is for %s, expected %s",
+			names.ReadableString(conf.Tag()), names.ReadableString(tag))
+	}
+	if _, ok := conf.StateServingInfo(); !ok {
+		return errors.New("agent config has no state serving info")
+	}
+	if known(meta.Controller.UUID) && conf.Controller().Id() != meta.Controller.UUID {
+		return errors.Errorf("agent config is for controller %q, expected %q", conf.Controller().Id(), meta.Controller.UUID)
+	}
+	return nil
+}
+
+func readBundledFile(ws *ArchiveWorkspace, filename string) ([]byte, error) {
+	bundle, err := os.Open(ws.FilesBundle)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer bundle.Close()
+	_, file, err := tar.FindFile(bundle, filename)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, err := ioutil.ReadAll(file)
+	return data, errors.Trace(err)
+}
+
+// restoreCheck restores the database dump into a scratch local mongod
+// and checks the restored collections.
+var restoreCheck = func(dumpDir string, meta *Metadata) error {
+	mongodPath, err := getMongodPath()
+	if err != nil {
+		return errors.Annotate(err, "mongod not available")
+	}
+	mongorestorePath, err := getMongorestorePath()
+	if err != nil {
+		return errors.Annotate(err, "mongorestore not available")
+	}
+	scratch, err := startScratchMongo(mongodPath)
+	if err != nil {
+		return errors.Annotate(err, "cannot start scratch mongod")
+	}
+	defer scratch.Close()
+
+	archives, err := assembleDumpArchives(dumpDir, filepath.Join(filepath.Dir(dumpDir), "archives"))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, archive := range archives {
+		if err := runCommandFn(mongorestorePath, "--host", scratch.addr, "--archive="+archive); err != nil {
+			return errors.Annotatef(err, "cannot restore %q", filepath.Base(archive))
+		}
+	}
+	options := []string{"--host", scratch.addr}
+	if _, err := os.Stat(filepath.Join(dumpDir, oplogFile)); err == nil {
+		options = append(options, "--oplogReplay")
+	}
+	if err := runCommandFn(mongorestorePath, append(options, dumpDir)...); err != nil {
+		return errors.Annotate(err, "cannot restore database dump")
+	}
+
+	session, err := mgo.DialWithTimeout(scratch.addr, time.Minute)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer session.Close()
+	return errors.Trace(checkRestoredDatabases(session, meta))
+}
+
+// checkRestoredDatabases validates every restored collection and
+// checks that the model that was backed up is there.
+func checkRestoredDatabases(session *mgo.Session, meta *Metadata) error {
+	databases, err := session.DatabaseNames()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range databases {
+		switch name {
+		case "admin", oplogDBName, "config":
+			continue
+		}
+		db := session.DB(name)
+		collections, err := db.CollectionNames()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, collection := range collections {
+			var result struct {
+				Valid  bool     `bson:"valid"`
+				Errors []string `bson:"errors"`
+			}
+			if err := db.Run(bson.D{{"validate", collection}}, &result); err != nil {
+				return errors.Annotatef(err, "cannot validate %s.%s", name, collection)
+			}
+			if !result.Valid {
+				return errors.Errorf("%s.%s not valid: %s", name, collection, strings.Join(result.Errors, "; "))
+			}
+		}
+	}
+
+	count, err := session.DB("juju").C("models").FindId(meta.Origin.Model).Count()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if count == 0 {
+		return errors.Errorf("model %q not restored", meta.Origin.Model)
+	}
+	return nil
+}
+
+// scratchMongo is a throwaway mongod listening on localhost.
+type scratchMongo struct {
+	cmd  *exec.Cmd
+	dir  string
+	addr string
+}
+
+func startScratchMongo(mongodPath string) (*scratchMongo, error) {
+	dir, err := ioutil.TempDir("", "juju-backup-verify-")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	port, err := freePort()
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, errors.Trace(err)
+	}
+	var output bytes.Buffer
+	cmd := exec.Command(mongodPath,
+		"--dbpath", dir,
+		"--port", strconv.Itoa(port),
+		"--bind_ip", "127.0.0.1",
+		"--nounixsocket",
+	)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(dir)
+		return nil, errors.Trace(err)
+	}
+	m := &scratchMongo{
+		cmd:  cmd,
+		dir:  dir,
+		addr: net.JoinHostPort("127.0.0.1", strconv.Itoa(port)),
+	}
+	session, err := mgo.DialWithTimeout(m.addr, time.Minute)
+	if err != nil {
+		m.Close()
+		return nil, errors.Annotatef(err, "mongod did not start: %s", strings.TrimSpace(output.String()))
+	}
+	session.Close()
+	return m, nil
+}
+
+// Close stops the mongod and removes its files.
+func (m *scratchMongo) Close() error {
+	if err := m.cmd.Process.Kill(); err != nil {
+		logger.Warningf("cannot stop scratch mongod: %v", err)
+	}
+	m.cmd.Wait()
+	return errors.Trace(os.RemoveAll(m.dir))
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,356 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	bt "github.com/juju/juju/state/backups/testing"
+	coretesting "github.com/juju/juju/testing"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type verifySuite struct {
+	testing.IsolationSuite
+
+	meta  *backups.Metadata
+	files []bt.File
+	dump  []bt.File
+}
+
+var _ = gc.Suite(&verifySuite{})
+
+func (s *verifySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.meta = bt.NewMetadataStarted()
+	s.meta.Controller.UUID = coretesting.ControllerTag.Id()
+	s.files = []bt.File{{
+		Name:    "var/lib/juju/agents/machine-0/agent.conf",
+		Content: agentConfig(c, names.NewMachineTag("0")),
+	}}
+	s.dump = []bt.File{
+		{Name: "juju", IsDir: true},
+		{Name: "juju/models.bson", Content: bsonDocs(c, bson.M{"_id": s.meta.Origin.Model})},
+		{Name: "juju/models.metadata.json", Content: `{"indexes":[]}`},
+		{Name: "oplog.bson", Content: bsonDocs(c)},
+	}
+	s.PatchValue(backups.RestoreCheck, func(string, *backups.Metadata) error {
+		c.Fatalf("unexpected restore")
+		return nil
+	})
+}
+
+func agentConfig(c *gc.C, tag names.Tag) string {
+	dataDir := c.MkDir()
+	conf, err := agent.NewStateMachineConfig(agent.AgentConfigParams{
+		Paths:             agent.Paths{DataDir: dataDir},
+		UpgradedToVersion: jujuversion.Current,
+		Tag:               tag,
+		Controller:        coretesting.ControllerTag,
+		Model:             coretesting.ModelTag,
+		Password:          "placeholder",
+		Nonce:             "dummyNonce",
+		APIAddresses:      []string{"localhost:17070"},
+		CACert:            coretesting.CACert,
+	}, controller.StateServingInfo{
+		APIPort:        17070,
+		StatePort:      37017,
+		Cert:           coretesting.ServerCert,
+		PrivateKey:     coretesting.ServerKey,
+		CAPrivateKey:   coretesting.CAKey,
+		SharedSecret:   "a secret",
+		SystemIdentity: "an identity",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conf.Write(), jc.ErrorIsNil)
+	data, err := ioutil.ReadFile(agent.ConfigPath(dataDir, tag))
+	c.Assert(err, jc.ErrorIsNil)
+	return string(data)
+}
+
+func bsonDocs(c *gc.C, docs ...interface{}) string {
+	var buf bytes.Buffer
+	for _, doc := range docs {
+		data, err := bson.Marshal(doc)
+		c.Assert(err, jc.ErrorIsNil)
+		buf.Write(data)
+	}
+	return buf.String()
+}
+
+func (s *verifySuite) archive(c *gc.C) *bytes.Buffer {
+	archive, err := bt.NewArchive(s.meta, s.files, s.dump)
+	c.Assert(err, jc.ErrorIsNil)
+	return archive
+}
+
+func (s *verifySuite) verify(c *gc.C, args backups.VerifyArgs) *backups.VerifyReport {
+	report, err := backups.Verify(s.archive(c), args)
+	c.Assert(err, jc.ErrorIsNil)
+	return report
+}
+
+func (s *verifySuite) replaceDump(name, content string) {
+	for i, f := range s.dump {
+		if f.Name == name {
+			s.dump[i].Content = content
+		}
+	}
+}
+
+func checkOutcome(c *gc.C, report *backups.VerifyReport, name, outcome string) {
+	for _, check := range report.Checks {
+		if check.Name != name {
+			continue
+		}
+		switch {
+		case check.Err != nil:
+			c.Check(check.Err, gc.ErrorMatches, outcome)
+		case check.Skipped != "":
+			c.Check("skipped: "+check.Skipped, gc.Matches, outcome)
+		default:
+			c.Check("pass", gc.Equals, outcome)
+		}
+		return
+	}
+	c.Errorf("no %q check made", name)
+}
+
+func (s *verifySuite) TestVerify(c *gc.C) {
+	report := s.verify(c, backups.VerifyArgs{})
+
+	c.Check(report.Passed(), jc.IsTrue)
+	c.Check(report.Metadata.Origin.Model, gc.Equals, s.meta.Origin.Model)
+	var names []string
+	for _, check := range report.Checks {
+		names = append(names, check.Name)
+	}
+	c.Check(names, jc.DeepEquals, []string{
+		backups.CheckArchive,
+		backups.CheckMetadata,
+		backups.CheckChecksum,
+		backups.CheckDBDump,
+		backups.CheckAgentConfig,
+		backups.CheckRestore,
+	})
+	checkOutcome(c, report, backups.CheckArchive, "pass")
+	checkOutcome(c, report, backups.CheckMetadata, "pass")
+	checkOutcome(c, report, backups.CheckChecksum, "skipped: no stored checksum to compare with")
+	checkOutcome(c, report, backups.CheckDBDump, "pass")
+	checkOutcome(c, report, backups.CheckAgentConfig, "pass")
+	checkOutcome(c, report, backups.CheckRestore, "skipped: not requested")
+}
+
+func (s *verifySuite) TestVerifyChecksum(c *gc.C) {
+	archive := s.archive(c)
+	sum := sha1.Sum(archive.Bytes())
+	expected := bt.NewMetadataStarted()
+	expected.Started = s.meta.Started
+	err := expected.MarkComplete(int64(archive.Len()), base64.StdEncoding.EncodeToString(sum[:]))
+	c.Assert(err, jc.ErrorIsNil)
+
+	report, err := backups.Verify(archive, backups.VerifyArgs{Expected: expected})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report.Passed(), jc.IsTrue)
+	checkOutcome(c, report, backups.CheckChecksum, "pass")
+}
+
+func (s *verifySuite) TestVerifyChecksumMismatch(c *gc.C) {
+	archive := s.archive(c)
+	expected := bt.NewMetadataStarted()
+	expected.Started = s.meta.Started
+	err := expected.MarkComplete(int64(archive.Len()), "bogus")
+	c.Assert(err, jc.ErrorIsNil)
+
+	report, err := backups.Verify(archive, backups.VerifyArgs{Expected: expected})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report.Passed(), jc.IsFalse)
+	checkOutcome(c, report, backups.CheckChecksum, `archive checksum is ".*", expected "bogus"`)
+}
+
+func (s *verifySuite) TestVerifyOtherModel(c *gc.C) {
+	expected := bt.NewMetadataStarted()
+	expected.Origin.Model = "another-model"
+
+	report := s.verify(c, backups.VerifyArgs{Expected: expected})
+	checkOutcome(c, report, backups.CheckMetadata, `archive is of model ".*", expected "another-model"`)
+}
+
+func (s *verifySuite) TestVerifyNotAnArchive(c *gc.C) {
+	report, err := backups.Verify(bytes.NewBufferString("<not an archive>"), backups.VerifyArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(report.Passed(), jc.IsFalse)
+	c.Assert(report.Checks, gc.HasLen, 1)
+	checkOutcome(c, report, backups.CheckArchive, "while uncompressing archive file: .*")
+}
+
+func (s *verifySuite) TestVerifyEncrypted(c *gc.C) {
+	public, private, err := backups.GenerateKeyPair()
+	c.Assert(err, jc.ErrorIsNil)
+	var encrypted bytes.Buffer
+	w, err := backups.NewEncryptingWriter(&encrypted, public)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write(s.archive(c).Bytes())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	sum := sha1.Sum(encrypted.Bytes())
+	expected := bt.NewMetadataStarted()
+	expected.Started = s.meta.Started
+	err = expected.MarkComplete(int64(encrypted.Len()), base64.StdEncoding.EncodeToString(sum[:]))
+	c.Assert(err, jc.ErrorIsNil)
+
+	report, err := backups.Verify(bytes.NewReader(encrypted.Bytes()), backups.VerifyArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report.Passed(), jc.IsFalse)
+	checkOutcome(c, report, backups.CheckArchive, "archive is encrypted and no private key was given")
+
+	report, err = backups.Verify(&encrypted, backups.VerifyArgs{Expected: expected, Key: &private})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report.Passed(), jc.IsTrue)
+	checkOutcome(c, report, backups.CheckChecksum, "pass")
+}
+
+func (s *verifySuite) TestVerifyNoMetadata(c *gc.C) {
+	archive, err := bt.NewArchive(nil, s.files, s.dump)
+	c.Assert(err, jc.ErrorIsNil)
+
+	report, err := backups.Verify(archive, backups.VerifyArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report.Passed(), jc.IsFalse)
+	checkOutcome(c, report, backups.CheckMetadata, "archive has no metadata file")
+	checkOutcome(c, report, backups.CheckDBDump, "skipped: metadata not available")
+}
+
+func (s *verifySuite) TestVerifyTruncatedCollection(c *gc.C) {
+	docs := bsonDocs(c, bson.M{"_id": "a"}, bson.M{"_id": "b"})
+	s.replaceDump("juju/models.bson", docs[:len(docs)-3])
+
+	report := s.verify(c, backups.VerifyArgs{})
+	checkOutcome(c, report, backups.CheckDBDump, `database "juju": collection "models": document 1 truncated`)
+}
+
+func (s *verifySuite) TestVerifyCollectionWithoutMetadata(c *gc.C) {
+	s.dump = append(s.dump, bt.File{Name: "juju/machines.bson", Content: bsonDocs(c)})
+
+	report := s.verify(c, backups.VerifyArgs{})
+	checkOutcome(c, report, backups.CheckDBDump, `database "juju": collection "machines" has no metadata`)
+}
+
+func (s *verifySuite) TestVerifyNoJujuDatabase(c *gc.C) {
+	s.dump = []bt.File{{Name: "admin", IsDir: true}}
+
+	report := s.verify(c, backups.VerifyArgs{})
+	checkOutcome(c, report, backups.CheckDBDump, "juju database not dumped")
+}
+
+func (s *verifySuite) TestVerifyStreamed(c *gc.C) {
+	s.dump = []bt.File{
+		{Name: "juju.archive.0000", Content: "\x6d\xe2\x99\x81<archive>"},
+		{Name: "juju.archive.0001", Content: "<more archive>"},
+		{Name: "oplog.bson", Content: bsonDocs(c)},
+	}
+
+	report := s.verify(c, backups.VerifyArgs{})
+	checkOutcome(c, report, backups.CheckDBDump, "pass")
+}
+
+func (s *verifySuite) TestVerifyStreamedChunkMissing(c *gc.C) {
+	s.dump = []bt.File{
+		{Name: "juju.archive.0000", Content: "\x6d\xe2\x99\x81<archive>"},
+		{Name: "juju.archive.0002", Content: "<more archive>"},
+		{Name: "oplog.bson", Content: bsonDocs(c)},
+	}
+
+	report := s.verify(c, backups.VerifyArgs{})
+	checkOutcome(c, report, backups.CheckDBDump, `database "juju": chunk 1 of the dump is missing`)
+}
+
+func (s *verifySuite) TestVerifyStreamedNotArchive(c *gc.C) {
+	s.dump = []bt.File{
+		{Name: "juju.archive.0000", Content: "<archive>"},
+		{Name: "oplog.bson", Content: bsonDocs(c)},
+	}
+
+	report := s.verify(c, backups.VerifyArgs{})
+	checkOutcome(c, report, backups.CheckDBDump, `database "juju": dump "juju.archive" is not a mongodump archive`)
+}
+
+func (s *verifySuite) TestVerifyIncremental(c *gc.C) {
+	s.meta.BaseID = "base"
+	s.dump = []bt.File{{Name: "oplog.bson", Content: bsonDocs(c, bson.M{"ts": 1})}}
+
+	report := s.verify(c, backups.VerifyArgs{Restore: true})
+	c.Check(report.Passed(), jc.IsTrue)
+	checkOutcome(c, report, backups.CheckDBDump, "pass")
+	checkOutcome(c, report, backups.CheckRestore, "skipped: an incremental backup cannot be restored without the backups it follows")
+}
+
+func (s *verifySuite) TestVerifyIncrementalNoOplog(c *gc.C) {
+	s.meta.BaseID = "base"
+	s.dump = nil
+
+	report := s.verify(c, backups.VerifyArgs{})
+	checkOutcome(c, report, backups.CheckDBDump, "incremental backup has no oplog")
+}
+
+func (s *verifySuite) TestVerifyNoAgentConfig(c *gc.C) {
+	s.files = []bt.File{{Name: "var/lib/juju/system-identity", Content: "<key>"}}
+
+	report := s.verify(c, backups.VerifyArgs{})
+	checkOutcome(c, report, backups.CheckAgentConfig, "archive has no agent config for machine 0")
+}
+
+func (s *verifySuite) TestVerifyAgentConfigOtherController(c *gc.C) {
+	s.meta.Controller.UUID = "another-controller"
+
+	report := s.verify(c, backups.VerifyArgs{})
+	checkOutcome(c, report, backups.CheckAgentConfig, `agent config is for controller ".*", expected "another-controller"`)
+}
+
+func (s *verifySuite) TestVerifyRestore(c *gc.C) {
+	var restoredDir string
+	s.PatchValue(backups.RestoreCheck, func(dumpDir string, meta *backups.Metadata) error {
+		restoredDir = dumpDir
+		c.Check(meta.Origin.Model, gc.Equals, s.meta.Origin.Model)
+		_, err := ioutil.ReadFile(filepath.Join(dumpDir, "juju", "models.bson"))
+		return err
+	})
+
+	report := s.verify(c, backups.VerifyArgs{Restore: true})
+	c.Check(report.Passed(), jc.IsTrue)
+	c.Check(restoredDir, gc.Not(gc.Equals), "")
+	checkOutcome(c, report, backups.CheckRestore, "pass")
+}
+
+func (s *verifySuite) TestVerifyRestoreFails(c *gc.C) {
+	s.PatchValue(backups.RestoreCheck, func(string, *backups.Metadata) error {
+		return errors.New("juju.models not valid: bad")
+	})
+
+	report := s.verify(c, backups.VerifyArgs{Restore: true})
+	c.Check(report.Passed(), jc.IsFalse)
+	checkOutcome(c, report, backups.CheckRestore, "juju.models not valid: bad")
+}
+
+func (s *verifySuite) TestVerifyRestoreSkippedForBadDump(c *gc.C) {
+	s.dump = nil
+
+	report := s.verify(c, backups.VerifyArgs{Restore: true})
+	checkOutcome(c, report, backups.CheckRestore, "skipped: database dump is not valid")
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
+	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -33,6 +34,10 @@ type Block interface {
 	// Message returns explanation that accompanies this block.
 	Message() string
 
+	// Operations returns the categories of operation blocked on the
+	// entity. It is empty for blocks that apply to the whole model.
+	Operations() []model.BlockOperation
+
 	updateMessageOp(string) ([]txn.Op, error)
 }
 
@@ -117,6 +122,10 @@ type blockDoc struct {
 	Tag       string    `bson:"tag"`
 	Type      BlockType `bson:"type"`
 	Message   string    `bson:"message,omitempty"`
+
+	// Operations is only set for blocks on an application,
+	// machine or relation.
+	Operations []model.BlockOperation `bson:"operations,omitempty"`
 }
 
 func (b *block) updateMessageOp(message string) ([]txn.Op, error) {
@@ -157,6 +166,11 @@ func (b *block) Type() BlockType {
 	return b.doc.Type
 }
 
+// Operations is part of the state.Block interface.
+func (b *block) Operations() []model.BlockOperation {
+	return b.doc.Operations
+}
+
 // SwitchBlockOn enables block of specified type for the
 // current model.
 func (st *State) SwitchBlockOn(t BlockType, msg string) error {
@@ -182,8 +196,13 @@ func getBlockForType(mb modelBackend, t BlockType) (Block, bool, error) {
 	all, closer := mb.db().GetCollection(blocksC)
 	defer closer()
 
+	// Blocks on individual entities are also stored with a type,
+	// so only match blocks on the model itself.
 	doc := blockDoc{}
-	err := all.Find(bson.D{{"type", t}}).One(&doc)
+	err := all.Find(bson.D{
+		{"type", t},
+		{"tag", names.NewModelTag(mb.modelUUID()).String()},
+	}).One(&doc)
 
 	switch err {
 	case nil:
@@ -195,7 +214,8 @@ func getBlockForType(mb modelBackend, t BlockType) (Block, bool, error) {
 	}
 }
 
-// AllBlocks returns all blocks in the model.
+// AllBlocks returns all blocks in the model, including those on
+// individual entities.
 func (st *State) AllBlocks() ([]Block, error) {
 	blocksCollection, closer := st.db().GetCollection(blocksC)
 	defer closer()
@@ -213,7 +233,7 @@ func (st *State) AllBlocks() ([]Block, error) {
 }
 
 // AllBlocksForController returns all blocks in any models on
-// the controller.
+// the controller, including those on individual entities.
 func (st *State) AllBlocksForController() ([]Block, error) {
 	blocksCollection, closer := st.db().GetRawCollection(blocksC)
 	defer closer()
@@ -328,3 +348,127 @@ func RemoveModelBlockOps(st *State, t BlockType) ([]txn.Op, error) {
 	// If the block doesn't exist, we're all good.
 	return nil, nil
 }
+
+// entityBlockKey returns the key of the block on the entity
+// with the given tag. Unlike model blocks, there may only be
+// one block per entity, so the key is derived from the tag.
+func entityBlockKey(tag names.Tag) string {
+	return "entity#" + tag.String()
+}
+
+func validateEntityBlockTag(tag names.Tag) error {
+	switch tag.Kind() {
+	case names.ApplicationTagKind, names.MachineTagKind, names.RelationTagKind:
+		return nil
+	}
+	return errors.NotValidf("block on %s %q", tag.Kind(), tag.Id())
+}
+
+// GetEntityBlock returns the Block on the application, machine or
+// relation with the given tag where
+//     not found -> nil, false, nil
+//     found -> block, true, nil
+//     error -> nil, false, err
+func (st *State) GetEntityBlock(tag names.Tag) (Block, bool, error) {
+	blocks, closer := st.db().GetCollection(blocksC)
+	defer closer()
+
+	doc := blockDoc{}
+	err := blocks.FindId(entityBlockKey(tag)).One(&doc)
+	switch err {
+	case nil:
+		return &block{doc}, true, nil
+	case mgo.ErrNotFound:
+		return nil, false, nil
+	default:
+		return nil, false, errors.Annotatef(err, "cannot get block on %s", names.ReadableString(tag))
+	}
+}
+
+// SwitchEntityBlockOn blocks the given categories of operation on the
+// application, machine or relation with the given tag. Any operations
+// previously blocked on the entity are replaced.
+func (st *State) SwitchEntityBlockOn(tag names.Tag, ops []model.BlockOperation, msg string) error {
+	if err := validateEntityBlockTag(tag); err != nil {
+		return errors.Trace(err)
+	}
+	if len(ops) == 0 {
+		return errors.NotValidf("block with no operations")
+	}
+	for _, op := range ops {
+		if err := op.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	entity, err := st.FindEntity(tag)
+	if err != nil {
+		return errors.Annotatef(err, "cannot block %s", names.ReadableString(tag))
+	}
+	if lifer, ok := entity.(Lifer); ok && lifer.Life() != Alive {
+		return errors.Errorf("cannot block %s: not alive", names.ReadableString(tag))
+	}
+
+	docID := st.docID(entityBlockKey(tag))
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		_, exists, err := st.GetEntityBlock(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if exists {
+			return []txn.Op{{
+				C:      blocksC,
+				Id:     docID,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"operations", ops},
+					{"message", msg},
+				}}},
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      blocksC,
+			Id:     docID,
+			Assert: txn.DocMissing,
+			Insert: &blockDoc{
+				DocID:      docID,
+				ModelUUID:  st.ModelUUID(),
+				Tag:        tag.String(),
+				Type:       ChangeBlock,
+				Message:    msg,
+				Operations: ops,
+			},
+		}}, nil
+	}
+	return errors.Annotatef(st.db().Run(buildTxn), "cannot block %s", names.ReadableString(tag))
+}
+
+// SwitchEntityBlockOff removes any block on the application, machine or
+// relation with the given tag.
+func (st *State) SwitchEntityBlockOff(tag names.Tag) error {
+	if err := validateEntityBlockTag(tag); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		_, exists, err := st.GetEntityBlock(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !exists {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{removeEntityBlockOp(st, tag)}, nil
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
+
+// removeEntityBlockOp returns an operation that removes the block on
+// the entity with the given tag, if there is one. It is included in
+// the removal of applications, machines and relations so that a block
+// does not outlive its entity.
+func removeEntityBlockOp(mb modelBackend, tag names.Tag) txn.Op {
+	return txn.Op{
+		C:      blocksC,
+		Id:     mb.docID(entityBlockKey(tag)),
+		Remove: true,
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
@@ -208,3 +209,80 @@ func (s *blockSuite) TestConcurrentBlocked(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertModelHasBlock(c, s.State, t, msg)
 }
+
+func (s *blockSuite) TestEntityBlock(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	ops := []model.BlockOperation{model.BlockOperationConfig, model.BlockOperationRemove}
+	err := s.State.SwitchEntityBlockOn(app.ApplicationTag(), ops, "frozen")
+	c.Assert(err, jc.ErrorIsNil)
+
+	block, found, err := s.State.GetEntityBlock(app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsTrue)
+	c.Assert(block.Operations(), jc.DeepEquals, ops)
+	c.Assert(block.Message(), gc.Equals, "frozen")
+	tag, err := block.Tag()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tag, gc.Equals, app.ApplicationTag())
+
+	// Entity blocks don't block the whole model.
+	s.assertNoTypedBlock(c, state.ChangeBlock)
+
+	all, err := s.State.AllBlocks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+}
+
+func (s *blockSuite) TestEntityBlockReplacesOperations(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	err := s.State.SwitchEntityBlockOn(machine.MachineTag(), []model.BlockOperation{model.BlockOperationRemove}, "first")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SwitchEntityBlockOn(machine.MachineTag(), []model.BlockOperation{model.BlockOperationConfig}, "second")
+	c.Assert(err, jc.ErrorIsNil)
+
+	block, found, err := s.State.GetEntityBlock(machine.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsTrue)
+	c.Assert(block.Operations(), jc.DeepEquals, []model.BlockOperation{model.BlockOperationConfig})
+	c.Assert(block.Message(), gc.Equals, "second")
+}
+
+func (s *blockSuite) TestSwitchEntityBlockOff(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.State.SwitchEntityBlockOn(app.ApplicationTag(), []model.BlockOperation{model.BlockOperationScale}, "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SwitchEntityBlockOff(app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, found, err := s.State.GetEntityBlock(app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsFalse)
+
+	// Switching off a missing block is fine.
+	err = s.State.SwitchEntityBlockOff(app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *blockSuite) TestEntityBlockInvalid(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.State.SwitchEntityBlockOn(names.NewUserTag("bob"), []model.BlockOperation{model.BlockOperationConfig}, "")
+	c.Assert(err, gc.ErrorMatches, `block on user "bob" not valid`)
+	err = s.State.SwitchEntityBlockOn(app.ApplicationTag(), nil, "")
+	c.Assert(err, gc.ErrorMatches, `block with no operations not valid`)
+	err = s.State.SwitchEntityBlockOn(app.ApplicationTag(), []model.BlockOperation{"destroy"}, "")
+	c.Assert(err, gc.ErrorMatches, `block operation "destroy" not valid`)
+	err = s.State.SwitchEntityBlockOn(names.NewApplicationTag("missing"), []model.BlockOperation{model.BlockOperationConfig}, "")
+	c.Assert(err, gc.ErrorMatches, `cannot block application "missing": application "missing" not found`)
+}
+
+func (s *blockSuite) TestEntityBlockRemovedWithEntity(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	err := s.State.SwitchEntityBlockOn(app.ApplicationTag(), []model.BlockOperation{model.BlockOperationConfig}, "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, found, err := s.State.GetEntityBlock(app.ApplicationTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsFalse)
+}
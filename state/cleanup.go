@@ -61,6 +61,9 @@ const (
 	cleanupStorageForDyingModel  cleanupKind = "modelStorage"
 	cleanupForceStorage          cleanupKind = "forceStorage"
 	cleanupBranchesForDyingModel cleanupKind = "branches"
+
+	// Removed applications require their config history to be removed.
+	cleanupConfigHistory cleanupKind = "configHistory"
 )
 
 // cleanupDoc originally represented a set of documents that should be
@@ -206,6 +209,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupForceStorage(args)
 		case cleanupBranchesForDyingModel:
 			err = st.cleanupBranchesForDyingModel(args)
+		case cleanupConfigHistory:
+			err = removeConfigHistory(st, doc.Prefix)
 		default:
 			err = errors.Errorf("unknown cleanup kind %q", doc.Kind)
 		}
@@ -0,0 +1,346 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/settings"
+	"github.com/juju/juju/environs/config"
+)
+
+// maxConfigRevisions is the number of config revisions retained for each
+// application and for the model. Older revisions are pruned as new ones
+// are recorded.
+const maxConfigRevisions = 100
+
+// ConfigScope identifies the config changed by a config revision.
+type ConfigScope string
+
+const (
+	// CharmConfigScope is the scope of changes to the
+	// charm config of an application.
+	CharmConfigScope ConfigScope = "charm"
+
+	// ApplicationConfigScope is the scope of changes to the
+	// application config of an application.
+	ApplicationConfigScope ConfigScope = "application"
+
+	// ModelConfigScope is the scope of changes to model config.
+	ModelConfigScope ConfigScope = "model"
+)
+
+// ConfigRevision describes a single change to the config of an
+// application or model.
+type ConfigRevision struct {
+	// Revision identifies the change. Revisions increase
+	// with each change to the config of an entity.
+	Revision int
+
+	// Scope identifies the config that was changed.
+	Scope ConfigScope
+
+	// Created is when the change was made.
+	Created time.Time
+
+	// CreatedBy is the name of the user that made the change.
+	// It is empty for changes made by Juju itself.
+	CreatedBy string
+
+	// Changes are the keys that were added, modified or deleted.
+	Changes settings.ItemChanges
+}
+
+// configRevisionDoc records a change to the config of
+// an application or model in the configHistory collection.
+type configRevisionDoc struct {
+	DocID     string       `bson:"_id"`
+	ModelUUID string       `bson:"model-uuid"`
+	GlobalKey string       `bson:"global-key"`
+	Revision  int          `bson:"revision"`
+	Scope     string       `bson:"scope"`
+	Created   int64        `bson:"created"`
+	CreatedBy string       `bson:"created-by"`
+	Changes   []itemChange `bson:"changes"`
+}
+
+func configRevisionSequence(globalKey string) string {
+	return "configrevision#" + globalKey
+}
+
+// configRevisionOps returns the operations that record the input changes
+// to the config of the entity with the input global key as a new revision,
+// and prune the revisions that are no longer retained. They belong in the
+// transaction that writes the changes, so that none are left unrecorded.
+func configRevisionOps(st *State, globalKey string, scope ConfigScope, userName string, changes settings.ItemChanges) ([]txn.Op, error) {
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	revision, err := sequenceWithMin(st, configRevisionSequence(globalKey), 1)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Sort(changes)
+	doc := &configRevisionDoc{
+		DocID:     st.docID(configRevisionId(globalKey, revision)),
+		ModelUUID: st.ModelUUID(),
+		GlobalKey: globalKey,
+		Revision:  revision,
+		Scope:     string(scope),
+		Created:   st.clock().Now().UnixNano(),
+		CreatedBy: userName,
+		Changes:   makeItemChanges(changes),
+	}
+	ops := []txn.Op{{
+		C:      configHistoryC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	if revision <= maxConfigRevisions {
+		return ops, nil
+	}
+	pruneOps, err := configHistoryRemoveOps(st, bson.D{
+		{"global-key", globalKey},
+		{"revision", bson.D{{"$lte", revision - maxConfigRevisions}}},
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "pruning config history")
+	}
+	return append(ops, pruneOps...), nil
+}
+
+func configRevisionId(globalKey string, revision int) string {
+	return fmt.Sprintf("%s#%d", globalKey, revision)
+}
+
+// configHistoryRemoveOps returns the operations that remove the config
+// revisions matching the input query.
+func configHistoryRemoveOps(st *State, query bson.D) ([]txn.Op, error) {
+	history, closer := st.db().GetCollection(configHistoryC)
+	defer closer()
+
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	if err := history.Find(query).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      configHistoryC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// configHistory returns the retained config revisions of the entity
+// with the input global key, most recent first.
+func configHistory(st *State, globalKey string) ([]ConfigRevision, error) {
+	history, closer := st.db().GetCollection(configHistoryC)
+	defer closer()
+
+	var docs []configRevisionDoc
+	err := history.Find(bson.D{{"global-key", globalKey}}).Sort("-revision").All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading config history")
+	}
+	revisions := make([]ConfigRevision, len(docs))
+	for i, doc := range docs {
+		changes := make(settings.ItemChanges, len(doc.Changes))
+		for j, c := range doc.Changes {
+			changes[j] = c.coreChange()
+		}
+		revisions[i] = ConfigRevision{
+			Revision:  doc.Revision,
+			Scope:     ConfigScope(doc.Scope),
+			Created:   time.Unix(0, doc.Created).UTC(),
+			CreatedBy: doc.CreatedBy,
+			Changes:   changes,
+		}
+	}
+	return revisions, nil
+}
+
+// removeConfigHistory removes all of the config revisions of the
+// entity with the input global key.
+func removeConfigHistory(st *State, globalKey string) error {
+	ops, err := configHistoryRemoveOps(st, bson.D{{"global-key", globalKey}})
+	if err != nil {
+		return errors.Annotate(err, "removing config history")
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	return errors.Annotate(st.db().RunTransaction(ops), "removing config history")
+}
+
+// configAtRevision returns, for each config scope, the values that the keys
+// changed since the input revision had immediately after it. A nil value
+// indicates that the key was unset. It is an error if the revision is no
+// longer retained.
+func configAtRevision(revisions []ConfigRevision, revision int) (map[ConfigScope]map[string]interface{}, error) {
+	found := false
+	result := make(map[ConfigScope]map[string]interface{})
+	// Revisions are most recent first, so the last change seen to
+	// each key is the earliest made to it after the target revision.
+	for _, rev := range revisions {
+		if rev.Revision == revision {
+			found = true
+		}
+		if rev.Revision <= revision {
+			continue
+		}
+		if result[rev.Scope] == nil {
+			result[rev.Scope] = make(map[string]interface{})
+		}
+		for _, change := range rev.Changes {
+			result[rev.Scope][change.Key] = change.OldValue
+		}
+	}
+	if !found {
+		return nil, errors.NotFoundf("config revision %d", revision)
+	}
+	return result, nil
+}
+
+// ConfigHistory returns the retained revisions of the application's
+// charm and application config, most recent first. Changes made under
+// a branch are recorded when the branch is committed.
+func (a *Application) ConfigHistory() ([]ConfigRevision, error) {
+	revisions, err := configHistory(a.st, a.globalKey())
+	return revisions, errors.Annotatef(err, "application %q", a.doc.Name)
+}
+
+// RevertConfig restores the application's charm and application config to
+// the values they had immediately after the input revision, recording the
+// restoration as a new revision made by the input user. The application
+// config schema and defaults are as for UpdateApplicationConfig.
+func (a *Application) RevertConfig(
+	revision int,
+	userName string,
+	schema environschema.Fields,
+	defaults schema.Defaults,
+) error {
+	revisions, err := a.ConfigHistory()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	restore, err := configAtRevision(revisions, revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Both configs are restored in a single transaction,
+	// so that the application never has just one of them.
+	var ops []txn.Op
+	if values := restore[ApplicationConfigScope]; len(values) > 0 {
+		changes := make(application.ConfigAttributes)
+		var reset []string
+		for k, v := range values {
+			if v == nil {
+				reset = append(reset, k)
+			} else {
+				changes[k] = v
+			}
+		}
+		node, err := a.updatedApplicationConfig(changes, reset, schema, defaults)
+		if err != nil {
+			return errors.Annotate(err, "restoring application config")
+		}
+		writeOps, err := a.configWriteOps(node, ApplicationConfigScope, userName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, writeOps...)
+	}
+
+	if values := restore[CharmConfigScope]; len(values) > 0 {
+		ch, _, err := a.Charm()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		changes := make(charm.Settings)
+		for k, v := range values {
+			if _, ok := ch.Config().Options[k]; !ok {
+				return errors.Errorf("cannot restore %q: no longer defined by the application's charm", k)
+			}
+			changes[k] = v
+		}
+		changes, err = ch.Config().ValidateSettings(changes)
+		if err != nil {
+			return errors.Annotate(err, "restoring charm config")
+		}
+		current, err := readSettings(a.st.db(), settingsC, a.charmConfigKey())
+		if err != nil {
+			return errors.Annotatef(err, "charm config for application %q", a.doc.Name)
+		}
+		applyCharmSettings(current, changes)
+		writeOps, err := a.configWriteOps(current, CharmConfigScope, userName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, writeOps...)
+	}
+
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := a.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("config settings for application %q", a.doc.Name)
+	} else if err != nil {
+		return errors.Annotate(err, "restoring config")
+	}
+	return nil
+}
+
+// ConfigHistory returns the retained revisions of the model's
+// config, most recent first.
+func (m *Model) ConfigHistory() ([]ConfigRevision, error) {
+	revisions, err := configHistory(m.st, modelGlobalKey)
+	return revisions, errors.Annotatef(err, "model %q", m.UUID())
+}
+
+// RevertConfig restores the model's config to the values it had
+// immediately after the input revision, recording the restoration as a
+// new revision made by the input user. Keys that are unset by the
+// restoration take their inherited values, if any. The agent version and
+// charmhub URL can't be set by users, so they are never restored. The
+// restored config is validated as by UpdateModelConfig.
+func (m *Model) RevertConfig(revision int, userName string, additionalValidation ...ValidateConfigFunc) error {
+	revisions, err := m.ConfigHistory()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	restore, err := configAtRevision(revisions, revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	updates := make(map[string]interface{})
+	var removes []string
+	for k, v := range restore[ModelConfigScope] {
+		switch {
+		case k == config.AgentVersionKey, k == config.CharmhubURLKey:
+		case v == nil:
+			removes = append(removes, k)
+		default:
+			updates[k] = v
+		}
+	}
+	sort.Strings(removes)
+	return errors.Trace(m.UpdateModelConfigByUser(userName, updates, removes, additionalValidation...))
+}
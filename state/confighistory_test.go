@@ -0,0 +1,219 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/charm/v7"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/settings"
+	"github.com/juju/juju/state"
+)
+
+type ConfigHistorySuite struct {
+	ConnSuite
+	charm *state.Charm
+	app   *state.Application
+}
+
+var _ = gc.Suite(&ConfigHistorySuite{})
+
+func (s *ConfigHistorySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.charm = s.AddTestingCharm(c, "dummy")
+	s.app = s.AddTestingApplication(c, "dummy", s.charm)
+}
+
+func (s *ConfigHistorySuite) TestCharmConfigHistory(c *gc.C) {
+	err := s.app.UpdateCharmConfigByUser("bob", model.GenerationMaster, charm.Settings{"title": "one"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"title": "two", "username": "admin"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	revisions, err := s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions, gc.HasLen, 2)
+
+	c.Check(revisions[0].Revision, gc.Equals, 2)
+	c.Check(revisions[0].Scope, gc.Equals, state.CharmConfigScope)
+	c.Check(revisions[0].CreatedBy, gc.Equals, "")
+	c.Check(revisions[0].Changes, jc.DeepEquals, settings.ItemChanges{
+		settings.MakeModification("title", "one", "two"),
+		settings.MakeAddition("username", "admin"),
+	})
+
+	c.Check(revisions[1].Revision, gc.Equals, 1)
+	c.Check(revisions[1].CreatedBy, gc.Equals, "bob")
+	c.Check(revisions[1].Created.IsZero(), jc.IsFalse)
+	c.Check(revisions[1].Changes, jc.DeepEquals, settings.ItemChanges{
+		settings.MakeAddition("title", "one"),
+	})
+}
+
+func (s *ConfigHistorySuite) TestNoChangesNoRevision(c *gc.C) {
+	err := s.app.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"title": "one"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"title": "one"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	revisions, err := s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revisions, gc.HasLen, 1)
+}
+
+func (s *ConfigHistorySuite) TestBranchChangesRecordedOnCommit(c *gc.C) {
+	c.Assert(s.Model.AddBranch("new-branch", "alice"), jc.ErrorIsNil)
+	branch, err := s.Model.Branch("new-branch")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch.AssignApplication(s.app.Name()), jc.ErrorIsNil)
+	err = s.app.UpdateCharmConfigByUser("bob", "new-branch", charm.Settings{"title": "branched"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	revisions, err := s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revisions, gc.HasLen, 0)
+
+	_, err = branch.Commit("carol")
+	c.Assert(err, jc.ErrorIsNil)
+
+	revisions, err = s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions, gc.HasLen, 1)
+	c.Check(revisions[0].CreatedBy, gc.Equals, "carol")
+	c.Check(revisions[0].Changes, jc.DeepEquals, settings.ItemChanges{
+		settings.MakeAddition("title", "branched"),
+	})
+}
+
+func (s *ConfigHistorySuite) TestApplicationConfigHistory(c *gc.C) {
+	err := s.app.UpdateApplicationConfigByUser(
+		"bob", application.ConfigAttributes{"skill-level": 3}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	revisions, err := s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions, gc.HasLen, 1)
+	c.Check(revisions[0].Scope, gc.Equals, state.ApplicationConfigScope)
+	c.Check(revisions[0].CreatedBy, gc.Equals, "bob")
+	c.Check(revisions[0].Changes, jc.DeepEquals, settings.ItemChanges{
+		settings.MakeAddition("skill-level", 3),
+	})
+}
+
+func (s *ConfigHistorySuite) TestRevertApplicationConfig(c *gc.C) {
+	err := s.app.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"title": "one"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.UpdateApplicationConfig(
+		application.ConfigAttributes{"skill-level": 3}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"title": "two", "username": "admin"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.UpdateApplicationConfig(
+		application.ConfigAttributes{"skill-level": 5}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.app.RevertConfig(2, "bob", sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.app.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg["title"], gc.Equals, "one")
+	c.Check(cfg["username"], gc.Equals, "admin001")
+	appCfg, err := s.app.ApplicationConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(appCfg["skill-level"], gc.Equals, 3)
+
+	revisions, err := s.app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions, gc.HasLen, 6)
+	for _, rev := range revisions[:2] {
+		c.Check(rev.CreatedBy, gc.Equals, "bob")
+	}
+}
+
+func (s *ConfigHistorySuite) TestHistoryWrittenWithConfig(c *gc.C) {
+	defer state.SetAfterHooks(c, s.State, func() {
+		revisions, err := s.app.ConfigHistory()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(revisions, gc.HasLen, 1)
+	}).Check()
+
+	err := s.app.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"title": "one"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigHistorySuite) TestRevertApplicationConfigSingleTransaction(c *gc.C) {
+	err := s.app.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"title": "one"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.UpdateApplicationConfig(
+		application.ConfigAttributes{"skill-level": 3}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"title": "two"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.UpdateApplicationConfig(
+		application.ConfigAttributes{"skill-level": 5}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Both configs, and their revisions, are written by the first
+	// transaction.
+	defer state.SetAfterHooks(c, s.State, func() {
+		cfg, err := s.app.CharmConfig(model.GenerationMaster)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(cfg["title"], gc.Equals, "one")
+		appCfg, err := s.app.ApplicationConfig()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(appCfg["skill-level"], gc.Equals, 3)
+		revisions, err := s.app.ConfigHistory()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(revisions, gc.HasLen, 6)
+	}).Check()
+
+	err = s.app.RevertConfig(2, "bob", sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigHistorySuite) TestRevertConfigRevisionNotFound(c *gc.C) {
+	err := s.app.RevertConfig(7, "bob", sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, "config revision 7 not found")
+}
+
+func (s *ConfigHistorySuite) TestHistoryRemovedWithApplication(c *gc.C) {
+	err := s.app.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"title": "one"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.Destroy(), jc.ErrorIsNil)
+	c.Assert(s.State.Cleanup(), jc.ErrorIsNil)
+
+	app := s.AddTestingApplication(c, "dummy", s.charm)
+	revisions, err := app.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revisions, gc.HasLen, 0)
+}
+
+func (s *ConfigHistorySuite) TestModelConfigHistory(c *gc.C) {
+	err := s.Model.UpdateModelConfigByUser("bob", map[string]interface{}{"default-series": "trusty"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.UpdateModelConfigByUser("alice", map[string]interface{}{"default-series": "xenial"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	revisions, err := s.Model.ConfigHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(len(revisions) >= 2, jc.IsTrue)
+	c.Check(revisions[0].Scope, gc.Equals, state.ModelConfigScope)
+	c.Check(revisions[0].CreatedBy, gc.Equals, "alice")
+	c.Check(revisions[0].Changes, jc.DeepEquals, settings.ItemChanges{
+		settings.MakeModification("default-series", "trusty", "xenial"),
+	})
+
+	err = s.Model.RevertConfig(revisions[1].Revision, "carol")
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := s.Model.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	series, _ := cfg.DefaultSeries()
+	c.Check(series, gc.Equals, "trusty")
+}
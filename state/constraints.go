@@ -28,6 +28,7 @@ type constraintsDoc struct {
 	InstanceType   *string
 	Container      *instance.ContainerType
 	Tags           *[]string
+	Labels         *[]string
 	Spaces         *[]string
 	VirtType       *string
 	Zones          *[]string
@@ -45,6 +46,7 @@ func newConstraintsDoc(cons constraints.Value, id string) constraintsDoc {
 		InstanceType:   cons.InstanceType,
 		Container:      cons.Container,
 		Tags:           cons.Tags,
+		Labels:         cons.Labels,
 		Spaces:         cons.Spaces,
 		VirtType:       cons.VirtType,
 		Zones:          cons.Zones,
@@ -63,6 +65,7 @@ func (doc constraintsDoc) value() constraints.Value {
 		InstanceType:   doc.InstanceType,
 		Container:      doc.Container,
 		Tags:           doc.Tags,
+		Labels:         doc.Labels,
 		Spaces:         doc.Spaces,
 		VirtType:       doc.VirtType,
 		Zones:          doc.Zones,
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"fmt"
+
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -397,14 +399,105 @@ func (st *State) modelSetupOps(controllerUUID string, args ModelArgs, inherited
 			args.CloudCredential,
 			args.MigrationMode,
 			args.EnvironVersion,
+			modelExpiry(st.nowToTheSecond(), args.Config),
 		),
 		createUniqueOwnerModelNameOp(args.Owner, args.Config.Name()),
 		st.createDefaultSpaceOp(),
 	)
 	ops = append(ops, modelUserOps...)
+	for _, user := range args.Users {
+		createdBy := user.CreatedBy
+		if createdBy == (names.UserTag{}) {
+			createdBy = args.Owner
+		}
+		ops = append(ops, createModelUserOps(
+			modelUUID, user.User, createdBy, user.DisplayName, st.nowToTheSecond(), user.Access,
+		)...)
+	}
+	for _, rule := range args.FirewallRules {
+		service := string(rule.WellKnownService())
+		ops = append(ops, txn.Op{
+			C:      firewallRulesC,
+			Id:     service,
+			Assert: txn.DocMissing,
+			Insert: &firewallRulesDoc{
+				Id:               service,
+				WellKnownService: service,
+				WhitelistCIDRS:   rule.WhitelistCIDRs(),
+			},
+		})
+	}
+	ops = append(ops, st.initialSpaceOps(args.Spaces)...)
+	ops = append(ops, st.initialBlockOps(args.Blocks)...)
 	return ops, modelStatusDoc, nil
 }
 
+// initialSpaceOps returns the transactions necessary to create the
+// given spaces, which have no subnets, in a new model. As with blocks,
+// the space sequence is created along with them.
+func (st *State) initialSpaceOps(spaces []string) []txn.Op {
+	if len(spaces) == 0 {
+		return nil
+	}
+	var ops []txn.Op
+	// Space with ID zero is the default space; start at 1.
+	for i, name := range spaces {
+		ops = append(ops, st.addSpaceTxnOps(fmt.Sprint(i+1), name, "", true)...)
+	}
+	return append(ops, txn.Op{
+		C:      sequenceC,
+		Id:     st.docID("space"),
+		Assert: txn.DocMissing,
+		Insert: &sequenceDoc{
+			DocID:     st.docID("space"),
+			Name:      "space",
+			ModelUUID: st.ModelUUID(),
+			Counter:   len(spaces) + 1,
+		},
+	})
+}
+
+// initialBlockOps returns the transactions necessary to create the
+// given blocks in a new model. The block sequence is created along
+// with them, so that no sequence is left behind if the model cannot
+// be created.
+func (st *State) initialBlockOps(blocks map[BlockType]string) []txn.Op {
+	if len(blocks) == 0 {
+		return nil
+	}
+	var ops []txn.Op
+	for _, t := range AllTypes() {
+		msg, ok := blocks[t]
+		if !ok {
+			continue
+		}
+		id := fmt.Sprint(len(ops))
+		ops = append(ops, txn.Op{
+			C:      blocksC,
+			Id:     st.docID(id),
+			Assert: txn.DocMissing,
+			Insert: &blockDoc{
+				DocID:     st.docID(id),
+				ModelUUID: st.ModelUUID(),
+				Tag:       names.NewModelTag(st.ModelUUID()).String(),
+				Type:      t,
+				Message:   msg,
+			},
+		})
+	}
+	return append(ops, txn.Op{
+		C:      sequenceC,
+		Id:     st.docID("block"),
+		Assert: txn.DocMissing,
+		Insert: &sequenceDoc{
+			DocID:     st.docID("block"),
+			Name:      "block",
+			ModelUUID: st.ModelUUID(),
+			Counter:   len(ops),
+		},
+	})
+}
+
 func (st *State) createDefaultStoragePoolsOps(registry storage.ProviderRegistry) ([]txn.Op, error) {
 	m := poolmanager.MemSettings{make(map[string]map[string]interface{})}
 	pm := poolmanager.New(m, registry)
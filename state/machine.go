@@ -1041,6 +1041,7 @@ func (m *Machine) removeOps() ([]txn.Op, error) {
 		removeModelMachineRefOp(m.st, m.Id()),
 		removeSSHHostKeyOp(m.globalKey()),
 		removeInstanceDataOp(m.doc.DocID),
+		removeEntityBlockOp(m.st, m.MachineTag()),
 	}
 	linkLayerDevicesOps, err := m.removeAllLinkLayerDevicesOps()
 	if err != nil {
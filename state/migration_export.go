@@ -310,6 +310,13 @@ func (e *exporter) readBlocks() (map[string]string, error) {
 
 	result := make(map[string]string)
 	for _, doc := range docs {
+		if len(doc.Operations) > 0 {
+			// The model description has no representation for
+			// blocks on individual entities, and silently
+			// dropping them would remove their protection. The
+			// migration prechecks should have caught this.
+			return nil, errors.NotSupportedf("migrating commands disabled on %q", doc.Tag)
+		}
 		// We don't care about the id, uuid, or tag.
 		// The uuid and tag both refer to the model uuid, and the
 		// id is opaque - even though it is sequence generated.
@@ -2015,6 +2022,10 @@ func (e *exporter) constraintsArgs(globalKey string) (description.ConstraintsArg
 		e.logger.Tracef("no constraints found for key %q", globalKey)
 		return description.ConstraintsArgs{}, nil
 	}
+	// The model description has no representation for labels.
+	if _, found := doc["labels"]; found {
+		return description.ConstraintsArgs{}, errors.NotSupportedf("migrating label constraints on %q", globalKey)
+	}
 	// We capture any type error using a closure to avoid having to return
 	// multiple values from the optional functions. This does mean that we will
 	// only report on the last one, but that is fine as there shouldn't be any.
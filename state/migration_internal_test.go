@@ -115,6 +115,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		guisettingsC,
 		// Users aren't migrated.
 		usersC,
+		// Quotas are controller policy, and aren't migrated.
+		quotasC,
+		// Model templates are controller policy, and aren't migrated.
+		modelTemplatesC,
 		userLastLoginC,
 		// Controller users contain extra data about users therefore
 		// are not migrated either.
@@ -123,6 +127,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		usermodelnameC,
 		// Metrics aren't migrated.
 		metricsC,
+		// Config history records changes made through the source
+		// controller, and isn't migrated.
+		configHistoryC,
 		// Backup and restore information is not migrated.
 		restoreInfoC,
 		// reference counts are implementation details that should be
@@ -251,6 +258,9 @@ func (s *MigrationSuite) TestModelDocFields(c *gc.C) {
 		// ForceDestroyed is only relevant for models that are being
 		// removed.
 		"ForceDestroyed",
+		// Expiry is set from the model's TTL when the model is
+		// imported, so the TTL restarts in the target controller.
+		"Expiry",
 		// ControllerUUID is recreated when the new model is created
 		// in the new controller (yay name changes).
 		"ControllerUUID",
@@ -528,6 +538,9 @@ func (s *MigrationSuite) TestAnnotatorDocFields(c *gc.C) {
 		"GlobalKey",
 		"Tag",
 		"Annotations",
+		// Labels are derived from the annotations, and are
+		// regenerated when the annotations are imported.
+		"Labels",
 	)
 	s.AssertExportedFields(c, annotatorDoc{}, fields)
 }
@@ -579,6 +592,8 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"InstanceType",
 		"Container",
 		"Tags",
+		// Labels can't be migrated, so export fails if they're set.
+		"Labels",
 		"Spaces",
 		"VirtType",
 		"Zones",
@@ -124,6 +124,11 @@ type modelDoc struct {
 	// this model. It only has any meaning when the model is dying or
 	// dead.
 	ForceDestroyed bool `bson:"force-destroyed,omitempty"`
+
+	// Expiry is when the model expires, if it has a TTL. The TTL is
+	// measured from when it was last set, or from when the model was
+	// created or imported into this controller.
+	Expiry time.Time `bson:"expiry,omitempty"`
 }
 
 // slaLevel enumerates the support levels available to a model.
@@ -287,6 +292,20 @@ type ModelArgs struct {
 
 	// PasswordHash is used by the caas model operator.
 	PasswordHash string
+
+	// Users holds the users, other than the owner, that are
+	// granted access to the model when it is created.
+	Users []UserAccessSpec
+
+	// FirewallRules holds the initial firewall rules of the model.
+	FirewallRules []FirewallRule
+
+	// Spaces holds the names of the spaces created
+	// in the model, in addition to the default space.
+	Spaces []string
+
+	// Blocks holds the initial blocks of the model, and their messages.
+	Blocks map[BlockType]string
 }
 
 // Validate validates the ModelArgs.
@@ -311,7 +330,21 @@ func (m ModelArgs) Validate() error {
 	default:
 		return errors.NotValidf("initial migration mode %q", m.MigrationMode)
 	}
-	return nil
+	for _, user := range m.Users {
+		if user.User.Id() == m.Owner.Id() {
+			return errors.NotValidf("access for owner %q", m.Owner.Id())
+		}
+		if err := permission.ValidateModelAccess(user.Access); err != nil {
+			return errors.Annotatef(err, "user %q", user.User.Id())
+		}
+	}
+	if err := validateFirewallRules(m.FirewallRules); err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateSpaceNames(m.Spaces); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(validateBlocks(m.Blocks))
 }
 
 // NewModel creates a new model with its own UUID and
@@ -376,6 +409,14 @@ func (ctlr *Controller) NewModel(args ModelArgs) (_ *Model, _ *State, err error)
 			return nil, nil, errors.Annotate(err, "cannot create model")
 		}
 	}
+	for _, user := range args.Users {
+		if !user.User.IsLocal() {
+			continue
+		}
+		if _, err := st.User(user.User); err != nil {
+			return nil, nil, errors.Annotate(err, "cannot create model")
+		}
+	}
 
 	uuid := args.Config.UUID()
 	session := st.session.Copy()
@@ -1583,6 +1624,7 @@ func createModelOp(
 	cloudCredential names.CloudCredentialTag,
 	migrationMode MigrationMode,
 	environVersion int,
+	expiry time.Time,
 ) txn.Op {
 	doc := &modelDoc{
 		Type:            modelType,
@@ -1597,6 +1639,7 @@ func createModelOp(
 		CloudRegion:     cloudRegion,
 		CloudCredential: cloudCredential.Id(),
 		PasswordHash:    passwordHash,
+		Expiry:          expiry,
 	}
 	return txn.Op{
 		C:      modelsC,
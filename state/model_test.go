@@ -6,6 +6,7 @@ package state_test
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/juju/charm/v7"
 	"github.com/juju/clock"
@@ -411,6 +412,72 @@ func (s *ModelSuite) TestModelExistsNoModel(c *gc.C) {
 	c.Check(modelExists, jc.IsFalse)
 }
 
+func (s *ModelSuite) TestExpiry(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	_, expires := model.Expiry()
+	c.Assert(expires, jc.IsFalse)
+
+	set := s.Clock.Now().Round(time.Second).UTC()
+	err = model.UpdateModelConfig(map[string]interface{}{"model-ttl": "2h"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Refresh(), jc.ErrorIsNil)
+	expiry, expires := model.Expiry()
+	c.Assert(expires, jc.IsTrue)
+	c.Assert(expiry, gc.Equals, set.Add(2*time.Hour))
+
+	// Setting the TTL again restarts it.
+	s.Clock.Advance(time.Hour)
+	err = model.UpdateModelConfig(map[string]interface{}{"model-ttl": "2h"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Refresh(), jc.ErrorIsNil)
+	expiry, expires = model.Expiry()
+	c.Assert(expires, jc.IsTrue)
+	c.Assert(expiry, gc.Equals, set.Add(3*time.Hour))
+
+	// Changing other config leaves it alone.
+	s.Clock.Advance(time.Hour)
+	err = model.UpdateModelConfig(map[string]interface{}{"expiry-destroy-storage": true}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Refresh(), jc.ErrorIsNil)
+	expiry, _ = model.Expiry()
+	c.Assert(expiry, gc.Equals, set.Add(3*time.Hour))
+
+	err = model.UpdateModelConfig(nil, []string{"model-ttl"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Refresh(), jc.ErrorIsNil)
+	_, expires = model.Expiry()
+	c.Assert(expires, jc.IsFalse)
+}
+
+func (s *ModelSuite) TestExpiryMeasuredFromCreation(c *gc.C) {
+	created := s.Clock.Now().Round(time.Second).UTC()
+	st := s.Factory.MakeModel(c, &factory.ModelParams{
+		ConfigAttrs: testing.Attrs{"model-ttl": "4h"},
+	})
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	expiry, expires := model.Expiry()
+	c.Assert(expires, jc.IsTrue)
+	c.Assert(expiry, gc.Equals, created.Add(4*time.Hour))
+}
+
+func (s *ModelSuite) TestControllerModelNeverExpires(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"model-ttl": "2h"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.Model.Refresh(), jc.ErrorIsNil)
+	_, expires := s.Model.Expiry()
+	c.Assert(expires, jc.IsFalse)
+}
+
+func (s *ModelSuite) TestModelTTLNotInherited(c *gc.C) {
+	err := s.State.UpdateModelConfigDefaultValues(map[string]interface{}{"model-ttl": "2h"}, nil, nil)
+	c.Assert(err, gc.ErrorMatches, `model-ttl cannot have a default value`)
+}
+
 func (s *ModelSuite) TestSLA(c *gc.C) {
 	cfg, _ := s.createTestModelConfig(c)
 	owner := names.NewUserTag("test@remote")
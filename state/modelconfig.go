@@ -153,6 +153,11 @@ func (model *Model) modelConfigValues(modelCfg attrValues) (config.ConfigValues,
 
 // UpdateModelConfigDefaultValues updates the inherited settings used when creating a new model.
 func (st *State) UpdateModelConfigDefaultValues(updateAttrs map[string]interface{}, removeAttrs []string, regionSpec *environscloudspec.CloudRegionSpec) error {
+	// A TTL is only given to models explicitly.
+	if _, ok := updateAttrs[config.ModelTTLKey]; ok {
+		return errors.Errorf("%s cannot have a default value", config.ModelTTLKey)
+	}
+
 	var key string
 
 	if regionSpec != nil {
@@ -279,7 +284,7 @@ func (st *State) ModelConfigDefaultValues(cloudName string) (config.ModelDefault
 
 // checkControllerInheritedConfig returns an error if the shared local cloud config is definitely invalid.
 func checkControllerInheritedConfig(attrs attrValues) error {
-	disallowedCloudConfigAttrs := append(disallowedModelConfigAttrs[:], config.AgentVersionKey)
+	disallowedCloudConfigAttrs := append(disallowedModelConfigAttrs[:], config.AgentVersionKey, config.ModelTTLKey)
 	for _, attr := range disallowedCloudConfigAttrs {
 		if _, ok := attrs[attr]; ok {
 			return errors.Errorf("local cloud config cannot contain " + attr)
@@ -316,21 +321,96 @@ type ValidateConfigFunc func(updateAttrs map[string]interface{}, removeAttrs []s
 // configuration of the model with the provided updateAttrs and
 // removeAttrs.
 func (m *Model) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttrs []string, additionalValidation ...ValidateConfigFunc) error {
+	return m.UpdateModelConfigByUser("", updateAttrs, removeAttrs, additionalValidation...)
+}
+
+// UpdateModelConfigByUser updates the configuration of the model as
+// UpdateModelConfig does, recording the changes in the model's config
+// history as made by the input user.
+func (m *Model) UpdateModelConfigByUser(
+	userName string, updateAttrs map[string]interface{}, removeAttrs []string, additionalValidation ...ValidateConfigFunc,
+) error {
 	if len(updateAttrs)+len(removeAttrs) == 0 {
 		return nil
 	}
 
+	// TODO(axw) 2013-12-6 #1167616
+	// Ensure that the settings on disk have not changed
+	// underneath us. The settings changes are actually
+	// applied as a delta to what's on disk; if there has
+	// been a concurrent update, the change may not be what
+	// the user asked for.
+
+	modelSettings, err := readSettings(m.State().db(), settingsC, modelGlobalKey)
+	if err != nil {
+		return errors.Annotatef(err, "model %q", m.UUID())
+	}
+
+	oldConfig, validCfg, err := m.ValidateModelConfigUpdate(updateAttrs, removeAttrs, additionalValidation...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	validAttrs := validCfg.AllAttrs()
+	for k := range oldConfig.AllAttrs() {
+		if _, ok := validAttrs[k]; !ok {
+			modelSettings.Delete(k)
+		}
+	}
+	// Some values require marshalling before storage.
+	validAttrs = config.CoerceForStorage(validAttrs)
+
+	modelSettings.Update(validAttrs)
+	changes, ops := modelSettings.settingsUpdateOps()
+	historyOps, err := configRevisionOps(m.st, modelGlobalKey, ModelConfigScope, userName, changes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops = append(ops, historyOps...)
+	if modelTTLChanged(updateAttrs, removeAttrs) {
+		// Setting the TTL, even to its current value, restarts it.
+		ops = append(ops, modelExpiryOp(m.UUID(), m.st.nowToTheSecond(), validCfg))
+	}
+	return errors.Trace(modelSettings.write(ops))
+}
+
+// modelTTLChanged returns whether the model TTL is set or removed by
+// the update.
+func modelTTLChanged(updateAttrs map[string]interface{}, removeAttrs []string) bool {
+	if _, ok := updateAttrs[config.ModelTTLKey]; ok {
+		return true
+	}
+	for _, attr := range removeAttrs {
+		if attr == config.ModelTTLKey {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateModelConfigUpdate applies updateAttrs and removeAttrs to the
+// current configuration of the model and validates the result exactly as
+// UpdateModelConfig would, but without writing anything. It returns the
+// current configuration along with the configuration the update would
+// produce.
+func (m *Model) ValidateModelConfigUpdate(
+	updateAttrs map[string]interface{}, removeAttrs []string, additionalValidation ...ValidateConfigFunc,
+) (oldConfig, newConfig *config.Config, err error) {
 	st := m.State()
 	if len(removeAttrs) > 0 {
 		var removed []string
-		if updateAttrs == nil {
-			updateAttrs = make(map[string]interface{})
+		// Copy the update attributes so that inherited values
+		// don't leak back into the caller's map.
+		attrs := make(map[string]interface{}, len(updateAttrs))
+		for k, v := range updateAttrs {
+			attrs[k] = v
 		}
+		updateAttrs = attrs
 		// For each removed attribute, pick up any inherited value
 		// and if there's one, use that.
 		inherited, err := st.inheritedConfigAttributes()
 		if err != nil {
-			return errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 		for _, attr := range removeAttrs {
 			// We we are updating an attribute, that takes
@@ -346,45 +426,22 @@ func (m *Model) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttr
 		}
 		removeAttrs = removed
 	}
-	// TODO(axw) 2013-12-6 #1167616
-	// Ensure that the settings on disk have not changed
-	// underneath us. The settings changes are actually
-	// applied as a delta to what's on disk; if there has
-	// been a concurrent update, the change may not be what
-	// the user asked for.
-
-	modelSettings, err := readSettings(st.db(), settingsC, modelGlobalKey)
-	if err != nil {
-		return errors.Annotatef(err, "model %q", m.UUID())
-	}
 
-	oldConfig, err := m.ModelConfig()
+	oldConfig, err = m.ModelConfig()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 	for _, additionalValidationFunc := range additionalValidation {
 		err = additionalValidationFunc(updateAttrs, removeAttrs, oldConfig)
 		if err != nil {
-			return errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 	}
-	validCfg, err := st.buildAndValidateModelConfig(updateAttrs, removeAttrs, oldConfig)
+	newConfig, err = st.buildAndValidateModelConfig(updateAttrs, removeAttrs, oldConfig)
 	if err != nil {
-		return errors.Trace(err)
-	}
-
-	validAttrs := validCfg.AllAttrs()
-	for k := range oldConfig.AllAttrs() {
-		if _, ok := validAttrs[k]; !ok {
-			modelSettings.Delete(k)
-		}
+		return nil, nil, errors.Trace(err)
 	}
-	// Some values require marshalling before storage.
-	validAttrs = config.CoerceForStorage(validAttrs)
-
-	modelSettings.Update(validAttrs)
-	_, ops := modelSettings.settingsUpdateOps()
-	return modelSettings.write(ops)
+	return oldConfig, newConfig, nil
 }
 
 type modelConfigSourceFunc func() (attrValues, error)
@@ -276,6 +276,45 @@ func (s *ModelConfigSuite) TestUpdateModelConfigPreferredOverRemove(c *gc.C) {
 	c.Assert(ok, jc.IsFalse)
 }
 
+func (s *ModelConfigSuite) TestValidateModelConfigUpdate(c *gc.C) {
+	before, err := s.Model.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+
+	oldCfg, newCfg, err := s.Model.ValidateModelConfigUpdate(map[string]interface{}{
+		"apt-mirror":    "http://different-mirror",
+		"arbitrary-key": "shazam!",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(oldCfg.AllAttrs(), jc.DeepEquals, before.AllAttrs())
+	c.Assert(newCfg.AllAttrs()["apt-mirror"], gc.Equals, "http://different-mirror")
+	c.Assert(newCfg.AllAttrs()["arbitrary-key"], gc.Equals, "shazam!")
+
+	// Nothing is written.
+	after, err := s.Model.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(after.AllAttrs(), jc.DeepEquals, before.AllAttrs())
+}
+
+func (s *ModelConfigSuite) TestValidateModelConfigUpdateRemoveInherited(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"apt-mirror": "http://different-mirror"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newCfg, err := s.Model.ValidateModelConfigUpdate(nil, []string{"apt-mirror"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newCfg.AllAttrs()["apt-mirror"], gc.Equals, "http://cloud-mirror")
+}
+
+func (s *ModelConfigSuite) TestValidateModelConfigUpdateRejectsInvalid(c *gc.C) {
+	_, _, err := s.Model.ValidateModelConfigUpdate(map[string]interface{}{"api-port": 1234}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot set controller attribute "api-port" on a model`)
+
+	_, _, err = s.Model.ValidateModelConfigUpdate(map[string]interface{}{"apt-mirror": "x"}, nil,
+		func(map[string]interface{}, []string, *config.Config) error {
+			return errors.New("boom")
+		})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
 type ModelConfigSourceSuite struct {
 	ConnSuite
 }
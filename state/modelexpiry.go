@@ -0,0 +1,50 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// Expiry returns when the model expires, which is the TTL in its config
+// after the TTL was last set. The boolean result is false if the model
+// never expires; the controller model never does.
+func (m *Model) Expiry() (time.Time, bool) {
+	if m.IsControllerModel() || m.doc.Expiry.IsZero() {
+		return time.Time{}, false
+	}
+	return m.doc.Expiry.UTC(), true
+}
+
+// modelExpiry returns when a model whose TTL is set, at the given time,
+// to that of its config expires. The time is zero if the model never
+// expires.
+func modelExpiry(set time.Time, cfg *config.Config) time.Time {
+	ttl := cfg.ModelTTL()
+	if ttl == 0 {
+		return time.Time{}
+	}
+	return set.Add(ttl).UTC()
+}
+
+// modelExpiryOp returns the operation that restarts the TTL of the
+// model at the given time, after its TTL has been set or removed in
+// its config.
+func modelExpiryOp(modelUUID string, set time.Time, cfg *config.Config) txn.Op {
+	update := bson.D{{"$unset", bson.D{{"expiry", nil}}}}
+	if expiry := modelExpiry(set, cfg); !expiry.IsZero() {
+		update = bson.D{{"$set", bson.D{{"expiry", expiry}}}}
+	}
+	return txn.Op{
+		C:      modelsC,
+		Id:     modelUUID,
+		Assert: txn.DocExists,
+		Update: update,
+	}
+}
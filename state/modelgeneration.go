@@ -5,11 +5,14 @@ package state
 
 import (
 	"fmt"
+	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/juju/charm/v7"
+	csparams "github.com/juju/charmrepo/v5/csclient/params"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -18,6 +21,9 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/mongo/utils"
 	stateerrors "github.com/juju/juju/state/errors"
@@ -71,7 +77,16 @@ type generationDoc struct {
 	// Config is all changes made to charm configuration under this branch.
 	Config map[string][]itemChange `bson:"charm-config"`
 
-	// TODO (manadart 2019-04-02): CharmURLs, Resources.
+	// Charms holds the charm upgrade staged under this branch for each
+	// application, keyed by application name. Units tracking the branch
+	// run the staged charm; the other units are upgraded to it when the
+	// branch is committed.
+	Charms map[string]branchCharmDoc `bson:"charms,omitempty"`
+
+	// Constraints holds the constraints staged under this branch for
+	// each application, keyed by application name. They are set on the
+	// application when the branch is committed.
+	Constraints map[string]string `bson:"constraints,omitempty"`
 
 	// Created is a Unix timestamp indicating when this generation was created.
 	Created int64 `bson:"created"`
@@ -85,6 +100,64 @@ type generationDoc struct {
 
 	// CompletedBy is the user who committed this generation to the model.
 	CompletedBy string `bson:"completed-by"`
+
+	// Progression, if set, describes the progressive tracking of the branch
+	// by the units of its applications.
+	Progression *progressionDoc `bson:"progression,omitempty"`
+}
+
+// progressionDoc is the state representation of a core BranchProgression.
+type progressionDoc struct {
+	Percent     int               `bson:"percent"`
+	PerZone     bool              `bson:"per-zone,omitempty"`
+	Annotations map[string]string `bson:"annotations,omitempty"`
+	Constraints *constraintsDoc   `bson:"constraints,omitempty"`
+	StepPercent int               `bson:"step-percent"`
+	Interval    time.Duration     `bson:"interval"`
+
+	// NextStep is the Unix timestamp after which the
+	// next step of the progression is due.
+	NextStep int64 `bson:"next-step"`
+}
+
+// BranchProgression describes the progressive tracking of a branch.
+type BranchProgression struct {
+	model.BranchProgression
+
+	// NextStep is the time after which the next step is due.
+	NextStep time.Time
+}
+
+// branchCharmDoc is the state representation of a charm upgrade
+// staged for an application under a branch.
+type branchCharmDoc struct {
+	CharmURL    *charm.URL        `bson:"charm-url"`
+	Channel     string            `bson:"cs-channel,omitempty"`
+	ForceUnits  bool              `bson:"force-units,omitempty"`
+	ForceSeries bool              `bson:"force-series,omitempty"`
+	Force       bool              `bson:"force,omitempty"`
+	ResourceIDs map[string]string `bson:"resource-ids,omitempty"`
+}
+
+// BranchCharm describes a charm upgrade staged for an application
+// under a branch.
+type BranchCharm struct {
+	// URL identifies the charm that the application is upgraded to.
+	URL *charm.URL
+
+	// Channel is the charm store channel from which the charm came.
+	Channel csparams.Channel
+
+	// ForceUnits, ForceSeries and Force have the same meaning as the
+	// fields of SetCharmConfig, and are used when the upgrade is
+	// applied to the application.
+	ForceUnits  bool
+	ForceSeries bool
+	Force       bool
+
+	// ResourceIDs maps resource names to the IDs of the pending
+	// resources that are activated when the branch is committed.
+	ResourceIDs map[string]string
 }
 
 // Generation represents the state of a model generation.
@@ -129,6 +202,37 @@ func (g *Generation) Config() map[string]settings.ItemChanges {
 	return changes
 }
 
+// Charms returns the charm upgrades staged under the branch,
+// keyed by application name.
+func (g *Generation) Charms() map[string]BranchCharm {
+	charms := make(map[string]BranchCharm, len(g.doc.Charms))
+	for appName, doc := range g.doc.Charms {
+		charms[appName] = BranchCharm{
+			URL:         doc.CharmURL,
+			Channel:     csparams.Channel(doc.Channel),
+			ForceUnits:  doc.ForceUnits,
+			ForceSeries: doc.ForceSeries,
+			Force:       doc.Force,
+			ResourceIDs: doc.ResourceIDs,
+		}
+	}
+	return charms
+}
+
+// Constraints returns the application constraints staged under the
+// branch, keyed by application name.
+func (g *Generation) Constraints() (map[string]constraints.Value, error) {
+	result := make(map[string]constraints.Value, len(g.doc.Constraints))
+	for appName, cons := range g.doc.Constraints {
+		value, err := constraints.Parse(cons)
+		if err != nil {
+			return nil, errors.Annotatef(err, "constraints for application %q", appName)
+		}
+		result[appName] = value
+	}
+	return result, nil
+}
+
 // Created returns the Unix timestamp at generation creation.
 func (g *Generation) Created() int64 {
 	return g.doc.Created
@@ -203,7 +307,18 @@ func (g *Generation) AssignAllUnits(appName string) error {
 	return g.AssignUnits(appName, 0)
 }
 
+// AssignUnits indicates that the input number of the input application's
+// units, or all of them if zero, are tracking this branch.
 func (g *Generation) AssignUnits(appName string, numUnits int) error {
+	return g.AssignUnitsWithPolicy(appName, model.TrackPolicy{NumUnits: numUnits})
+}
+
+// AssignUnitsWithPolicy indicates that the input application's units
+// selected by the input policy are tracking this branch.
+func (g *Generation) AssignUnitsWithPolicy(appName string, policy model.TrackPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if attempt > 0 {
 			if err := g.Refresh(); err != nil {
@@ -231,30 +346,319 @@ func (g *Generation) AssignUnits(appName string, numUnits int) error {
 				},
 			},
 		}
-		// Ensure we sort the unitNames so that when we ask for the numUnits
-		// to track, they're going to be predictable results.
-		sort.Strings(unitNames)
+		units, err := g.trackCandidates(unitNames, policy)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		selected := selectTrackingUnits(units, set.NewStrings(g.doc.AssignedUnits[appName]...), policy)
+		// If there are no units to add to the generation, quit here.
+		if len(selected) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		for _, unit := range selected {
+			ops = append(ops, assignGenerationUnitTxnOps(g.doc.DocId, appName, unit)...)
+		}
+		// Units now tracking the branch must pick up any charm staged
+		// under it.
+		if _, ok := g.doc.Charms[appName]; ok {
+			ops = append(ops, touchApplicationOp(app))
+		}
+		return ops, nil
+	}
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
 
-		var assigned int
-		assignedUnits := set.NewStrings(g.doc.AssignedUnits[appName]...)
-		for _, name := range unitNames {
-			if !assignedUnits.Contains(name) {
-				if numUnits > 0 && numUnits == assigned {
-					break
-				}
-				unit, err := g.st.Unit(name)
-				if err != nil {
-					return nil, errors.Trace(err)
+// trackCandidate is a unit that may be selected to track a branch.
+type trackCandidate struct {
+	unit *Unit
+	zone string
+}
+
+// trackCandidates returns the units with the input names that may be
+// selected by the input policy, in name order so that selection is
+// predictable. Units without the policy's annotations, and units not on
+// provisioned machines satisfying the policy's constraints, are excluded.
+// Availability zones are only looked up if the policy needs them.
+func (g *Generation) trackCandidates(unitNames []string, policy model.TrackPolicy) ([]trackCandidate, error) {
+	sort.Strings(unitNames)
+
+	var m *Model
+	if len(policy.Annotations) > 0 {
+		var err error
+		if m, err = g.st.Model(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	var candidates []trackCandidate
+	for _, name := range unitNames {
+		unit, err := g.st.Unit(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if m != nil {
+			annotations, err := m.Annotations(unit)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if !matchesAnnotations(annotations, policy.Annotations) {
+				continue
+			}
+		}
+		if !constraints.IsEmpty(&policy.Constraints) {
+			hw, err := unitHardware(unit)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if hw == nil || !matchesConstraints(*hw, policy.Constraints) {
+				continue
+			}
+		}
+		candidate := trackCandidate{unit: unit}
+		if policy.PerZone {
+			// Units not on a provisioned machine have no zone,
+			// and are grouped together.
+			candidate.zone, err = unit.AvailabilityZone()
+			if err != nil && !errors.IsNotFound(err) && !errors.IsNotAssigned(err) && !errors.IsNotProvisioned(err) {
+				return nil, errors.Trace(err)
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+// matchesAnnotations returns true if the input annotations
+// include all of the wanted key/value pairs.
+func matchesAnnotations(annotations, wanted map[string]string) bool {
+	for k, v := range wanted {
+		if annotations[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// unitHardware returns the hardware characteristics of the machine the
+// input unit is assigned to, or nil if it isn't on a provisioned machine.
+func unitHardware(unit *Unit) (*instance.HardwareCharacteristics, error) {
+	machineId, err := unit.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine, err := unit.st.Machine(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	hw, err := machine.HardwareCharacteristics()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	return hw, errors.Trace(err)
+}
+
+// matchesConstraints returns true if the input hardware satisfies the
+// input constraints. Hardware which isn't known doesn't satisfy a
+// constraint on it.
+func matchesConstraints(hw instance.HardwareCharacteristics, cons constraints.Value) bool {
+	if cons.HasArch() && (hw.Arch == nil || *hw.Arch != *cons.Arch) {
+		return false
+	}
+	atLeast := func(have, want *uint64) bool {
+		return want == nil || have != nil && *have >= *want
+	}
+	if !atLeast(hw.CpuCores, cons.CpuCores) || !atLeast(hw.CpuPower, cons.CpuPower) ||
+		!atLeast(hw.Mem, cons.Mem) || !atLeast(hw.RootDisk, cons.RootDisk) {
+		return false
+	}
+	if cons.Tags != nil {
+		tags := set.NewStrings()
+		if hw.Tags != nil {
+			tags = set.NewStrings(*hw.Tags...)
+		}
+		for _, tag := range *cons.Tags {
+			// Tags starting with ^ must not be present.
+			if strings.HasPrefix(tag, "^") {
+				if tags.Contains(tag[1:]) {
+					return false
 				}
-				ops = append(ops, assignGenerationUnitTxnOps(g.doc.DocId, appName, unit)...)
-				assigned++
+			} else if !tags.Contains(tag) {
+				return false
 			}
 		}
-		// If there are no units to add to the generation, quit here.
-		if assigned == 0 {
+	}
+	if cons.HasZones() {
+		if hw.AvailabilityZone == nil || !set.NewStrings(*cons.Zones...).Contains(*hw.AvailabilityZone) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectTrackingUnits returns the input candidates, not already assigned,
+// that the input policy selects to track a branch.
+func selectTrackingUnits(candidates []trackCandidate, assigned set.Strings, policy model.TrackPolicy) []*Unit {
+	groups := map[string][]trackCandidate{}
+	var zones []string
+	for _, c := range candidates {
+		zone := ""
+		if policy.PerZone {
+			zone = c.zone
+		}
+		if _, ok := groups[zone]; !ok {
+			zones = append(zones, zone)
+		}
+		groups[zone] = append(groups[zone], c)
+	}
+
+	var selected []*Unit
+	for _, zone := range zones {
+		group := groups[zone]
+		var tracking int
+		for _, c := range group {
+			if assigned.Contains(c.unit.Name()) {
+				tracking++
+			}
+		}
+
+		want := len(group)
+		switch {
+		case policy.Percent > 0:
+			// Round up, so that a non-zero percentage
+			// always selects at least one unit.
+			want = (len(group)*policy.Percent+99)/100 - tracking
+		case policy.NumUnits > 0:
+			want = policy.NumUnits
+		}
+
+		for _, c := range group {
+			if want <= 0 {
+				break
+			}
+			if assigned.Contains(c.unit.Name()) {
+				continue
+			}
+			selected = append(selected, c.unit)
+			want--
+		}
+	}
+	return selected
+}
+
+// Progression returns the progressive tracking of this branch,
+// and false if the branch is not tracked progressively.
+func (g *Generation) Progression() (BranchProgression, bool) {
+	p := g.doc.Progression
+	if p == nil {
+		return BranchProgression{}, false
+	}
+	var cons constraints.Value
+	if p.Constraints != nil {
+		cons = p.Constraints.value()
+	}
+	return BranchProgression{
+		BranchProgression: model.BranchProgression{
+			Policy: model.TrackPolicy{
+				Percent:     p.Percent,
+				PerZone:     p.PerZone,
+				Annotations: p.Annotations,
+				Constraints: cons,
+			},
+			StepPercent: p.StepPercent,
+			Interval:    p.Interval,
+		},
+		NextStep: time.Unix(p.NextStep, 0),
+	}, true
+}
+
+// SetProgression sets this branch to be tracked progressively,
+// with the first step due after the progression's interval.
+// The units selected by the progression's policy should already
+// have been assigned to the branch.
+func (g *Generation) SetProgression(progression model.BranchProgression) error {
+	if err := progression.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	doc := &progressionDoc{
+		Percent:     progression.Policy.Percent,
+		PerZone:     progression.Policy.PerZone,
+		Annotations: progression.Policy.Annotations,
+		StepPercent: progression.StepPercent,
+		Interval:    progression.Interval,
+		NextStep:    g.st.clock().Now().Add(progression.Interval).Unix(),
+	}
+	if !constraints.IsEmpty(&progression.Policy.Constraints) {
+		consDoc := newConstraintsDoc(progression.Policy.Constraints, "")
+		doc.Constraints = &consDoc
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{{"progression", doc}}}},
+		}}, nil
+	}
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// StepProgression takes the next step of this branch's progressive
+// tracking, increasing the percentage of each of its applications' units
+// that track it. When all units are tracking, progression stops.
+func (g *Generation) StepProgression() error {
+	if err := g.CheckNotComplete(); err != nil {
+		return errors.Trace(err)
+	}
+	progression, ok := g.Progression()
+	if !ok {
+		return errors.NotFoundf("progression for branch %q", g.doc.Name)
+	}
+
+	policy := progression.Policy
+	policy.Percent += progression.StepPercent
+	if policy.Percent > 100 {
+		policy.Percent = 100
+	}
+	for appName := range g.doc.AssignedUnits {
+		if err := g.AssignUnitsWithPolicy(appName, policy); err != nil {
+			return errors.Annotatef(err, "tracking units of %q", appName)
+		}
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := g.Refresh(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if g.doc.Progression == nil {
 			return nil, jujutxn.ErrNoOperations
 		}
-		return ops, nil
+		update := bson.D{{"$unset", bson.D{{"progression", 1}}}}
+		if policy.Percent < 100 {
+			update = bson.D{{"$set", bson.D{
+				{"progression.percent", policy.Percent},
+				{"progression.next-step", g.st.clock().Now().Add(progression.Interval).Unix()},
+			}}}
+		}
+		return []txn.Op{{
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
+			Update: update,
+		}}, nil
 	}
 	return errors.Trace(g.st.db().Run(buildTxn))
 }
@@ -283,7 +687,15 @@ func (g *Generation) AssignUnit(unitName string) error {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		return assignGenerationUnitTxnOps(g.doc.DocId, appName, unit), nil
+		ops := assignGenerationUnitTxnOps(g.doc.DocId, appName, unit)
+		if _, ok := g.doc.Charms[appName]; ok {
+			app, err := g.st.Application(appName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, touchApplicationOp(app))
+		}
+		return ops, nil
 	}
 
 	return errors.Trace(g.st.db().Run(buildTxn))
@@ -372,11 +784,158 @@ func (g *Generation) UpdateCharmConfig(appName string, master *Settings, validCh
 	return errors.Trace(g.st.db().Run(buildTxn))
 }
 
+// updateCharm stages an upgrade of the input application to the charm in
+// the input config under this branch. The config is assumed to have been
+// validated. Staging the application's current charm removes any upgrade
+// staged for it.
+func (g *Generation) updateCharm(app *Application, cfg SetCharmConfig) error {
+	appName := app.Name()
+	curl := cfg.Charm.URL()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := app.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() == Dead {
+			return nil, stateerrors.ErrDead
+		}
+
+		staged, hasStaged := g.doc.Charms[appName]
+		var ops []txn.Op
+		if !hasStaged || staged.CharmURL.String() != curl.String() {
+			if hasStaged {
+				decOps, err := g.charmDecRefOps(appName, staged.CharmURL)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, decOps...)
+			}
+			if curl.String() != app.doc.CharmURL.String() {
+				incOps, err := branchCharmIncRefOps(app, cfg.Charm)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, incOps...)
+			}
+		}
+
+		update := bson.D{{"$unset", bson.D{{"charms." + appName, 1}}}}
+		if curl.String() != app.doc.CharmURL.String() {
+			update = bson.D{{"$set", bson.D{{"charms." + appName, branchCharmDoc{
+				CharmURL:    curl,
+				Channel:     string(cfg.Channel),
+				ForceUnits:  cfg.ForceUnits,
+				ForceSeries: cfg.ForceSeries,
+				Force:       cfg.Force,
+				ResourceIDs: cfg.ResourceIDs,
+			}}}}}
+		}
+		ops = append(ops, txn.Op{
+			C:  generationsC,
+			Id: g.doc.DocId,
+			Assert: bson.D{{"$and", []bson.D{
+				{{"completed", 0}},
+				{{"txn-revno", g.doc.TxnRevno}},
+			}}},
+			Update: update,
+		})
+		return append(ops, touchApplicationOp(app)), nil
+	}
+
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// branchCharmIncRefOps returns the operations that record a reference
+// from a branch to the input charm for the application. The application's
+// settings for the charm are created from its current settings if they
+// do not yet exist, so that units tracking the branch can be upgraded.
+func branchCharmIncRefOps(app *Application, ch *Charm) ([]txn.Op, error) {
+	var ops []txn.Op
+	settingsKey := applicationCharmConfigKey(app.Name(), ch.URL())
+	if _, err := readSettings(app.st.db(), settingsC, settingsKey); errors.IsNotFound(err) {
+		current, err := readSettings(app.st.db(), settingsC, app.charmConfigKey())
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", app.Name())
+		}
+		newSettings := ch.Config().FilterSettings(current.Map())
+		ops = append(ops, createSettingsOp(settingsC, settingsKey, newSettings))
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "application %q", app.Name())
+	}
+	incOps, err := appCharmIncRefOps(app.st, app.Name(), ch.URL(), true)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, incOps...), nil
+}
+
+// charmDecRefOps returns the operations that drop the reference from this
+// branch to the input charm for the application.
+func (g *Generation) charmDecRefOps(appName string, curl *charm.URL) ([]txn.Op, error) {
+	op := &ForcedOperation{Force: true}
+	ops, err := appCharmDecRefOps(g.st, appName, curl, true, op)
+	if err != nil {
+		return nil, errors.Annotatef(err, "could not remove branch references to charm %v", curl)
+	}
+	if len(op.Errors) != 0 {
+		logger.Errorf("could not remove branch references to charm %v: %v", curl, op.Errors)
+	}
+	return ops, nil
+}
+
+// touchApplicationOp returns an operation that rewrites the application's
+// charm URL unchanged. Unit agents watch the application document, so
+// this causes them to check again which charm they should be running,
+// which depends on the branch that they track.
+func touchApplicationOp(app *Application) txn.Op {
+	return txn.Op{
+		C:      applicationsC,
+		Id:     app.doc.DocID,
+		Assert: bson.D{{"charmurl", app.doc.CharmURL}},
+		Update: bson.D{{"$set", bson.D{{"charmurl", app.doc.CharmURL}}}},
+	}
+}
+
+// updateConstraints stages the input constraints for the application with
+// the input name under this branch. The constraints are assumed to have
+// been validated.
+func (g *Generation) updateConstraints(appName string, cons constraints.Value) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:  generationsC,
+			Id: g.doc.DocId,
+			Assert: bson.D{{"$and", []bson.D{
+				{{"completed", 0}},
+				{{"txn-revno", g.doc.TxnRevno}},
+			}}},
+			Update: bson.D{
+				{"$set", bson.D{{"constraints." + appName, cons.String()}}},
+			},
+		}}, nil
+	}
+
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
 // Commit marks the generation as completed and assigns it the next value from
 // the generation sequence. The new generation ID is returned.
 func (g *Generation) Commit(userName string) (int, error) {
 	var newGenId int
-
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if attempt > 0 {
 			if err := g.Refresh(); err != nil {
@@ -399,10 +958,25 @@ func (g *Generation) Commit(userName string) (int, error) {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		ops, err := g.commitConfigTxnOps()
+		ops, changes, upgraded, err := g.commitApplicationTxnOps()
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		configOps, err := g.commitConfigTxnOps(upgraded, changes)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, configOps...)
+		historyOps, err := g.commitHistoryTxnOps(userName, changes)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, historyOps...)
+		decOps, err := g.releaseCharmsTxnOps(upgraded)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, decOps...)
 
 		// Get the new sequence as late as we can.
 		// If assigned is empty, indicating no changes under this branch,
@@ -456,14 +1030,106 @@ func (g *Generation) assignedWithAllUnits() (map[string][]string, error) {
 	return assigned, nil
 }
 
+// commitApplicationTxnOps returns the operations that upgrade the charms
+// and set the constraints of the applications with changes staged under
+// the branch. Charm upgrades are applied as by Application.SetCharm, so
+// that units not tracking the branch are upgraded and any staged
+// resources are activated. Applications whose charm URL changes take over
+// the branch's reference to their new charm, and any config changes
+// staged for them are carried over to it; they are returned along with
+// the changes made to their charm config.
+func (g *Generation) commitApplicationTxnOps() ([]txn.Op, map[string]settings.ItemChanges, map[string]bool, error) {
+	var ops []txn.Op
+	changes := make(map[string]settings.ItemChanges)
+	upgraded := make(map[string]bool)
+	config := g.Config()
+	for appName, staged := range g.Charms() {
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		ch, err := g.st.Charm(staged.URL)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		cfg := SetCharmConfig{
+			Charm:       ch,
+			Channel:     staged.Channel,
+			ForceUnits:  staged.ForceUnits,
+			ForceSeries: staged.ForceSeries,
+			Force:       staged.Force,
+			ResourceIDs: staged.ResourceIDs,
+		}
+		updatedSettings, err := app.validateSetCharm(cfg)
+		if err != nil {
+			return nil, nil, nil, errors.Annotatef(err, "cannot upgrade application %q to charm %q", appName, staged.URL)
+		}
+		isUpgrade := app.doc.CharmURL.String() != staged.URL.String()
+		var delta settings.ItemChanges
+		if isUpgrade {
+			delta = config[appName]
+		}
+		appOps, err := app.setCharmOps(cfg, updatedSettings, delta, isUpgrade)
+		if err != nil {
+			return nil, nil, nil, errors.Annotatef(err, "cannot upgrade application %q to charm %q", appName, staged.URL)
+		}
+		ops = append(ops, appOps...)
+		if isUpgrade {
+			upgraded[appName] = true
+			if len(delta) > 0 {
+				changes[appName] = delta
+			}
+		}
+	}
+
+	cons, err := g.Constraints()
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	for appName, value := range cons {
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		if app.doc.Subordinate {
+			return nil, nil, nil, errors.Annotatef(ErrSubordinateConstraints, "application %q", appName)
+		}
+		if app.Life() != Alive {
+			return nil, nil, nil, errors.Annotatef(applicationNotAliveErr, "application %q", appName)
+		}
+		ops = append(ops, app.setConstraintsOps(value)...)
+	}
+	return ops, changes, upgraded, nil
+}
+
+// releaseCharmsTxnOps returns the operations that drop the branch's
+// references to the charms staged under it, other than those taken over
+// by the upgraded applications.
+func (g *Generation) releaseCharmsTxnOps(upgraded map[string]bool) ([]txn.Op, error) {
+	var ops []txn.Op
+	for appName, staged := range g.doc.Charms {
+		if upgraded[appName] {
+			continue
+		}
+		decOps, err := g.charmDecRefOps(appName, staged.CharmURL)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, decOps...)
+	}
+	return ops, nil
+}
+
 // commitConfigTxnOps iterates over all the applications with configuration
-// deltas, determines their effective new settings, then gathers the
-// operations representing the changes so that they can all be applied in a
-// single transaction.
-func (g *Generation) commitConfigTxnOps() ([]txn.Op, error) {
+// deltas, other than the upgraded applications whose deltas are applied
+// with their charm upgrade, determines their effective new settings, then
+// gathers the operations representing the changes so that they can all be
+// applied in a single transaction. The changes that the operations make to
+// the charm config of each application are added to changes.
+func (g *Generation) commitConfigTxnOps(upgraded map[string]bool, changes map[string]settings.ItemChanges) ([]txn.Op, error) {
 	var ops []txn.Op
 	for appName, delta := range g.Config() {
-		if len(delta) == 0 {
+		if len(delta) == 0 || upgraded[appName] {
 			continue
 		}
 		app, err := g.st.Application(appName)
@@ -478,13 +1144,35 @@ func (g *Generation) commitConfigTxnOps() ([]txn.Op, error) {
 		}
 		cfg.applyChanges(delta)
 
-		_, updates := cfg.settingsUpdateOps()
+		appChanges, updates := cfg.settingsUpdateOps()
 		// Assert that the settings document has not changed underneath us
 		// in addition to appending the field changes.
 		if len(updates) > 0 {
 			ops = append(ops, cfg.assertUnchangedOp())
 			ops = append(ops, updates...)
+			changes[appName] = appChanges
+		}
+	}
+	return ops, nil
+}
+
+// commitHistoryTxnOps returns the operations that record the charm config
+// changes committed to each application in its config history.
+func (g *Generation) commitHistoryTxnOps(userName string, changes map[string]settings.ItemChanges) ([]txn.Op, error) {
+	appNames := make([]string, 0, len(changes))
+	for appName := range changes {
+		appNames = append(appNames, appName)
+	}
+	sort.Strings(appNames)
+
+	var ops []txn.Op
+	for _, appName := range appNames {
+		historyOps, err := configRevisionOps(
+			g.st, applicationGlobalKey(appName), CharmConfigScope, userName, changes[appName])
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
+		ops = append(ops, historyOps...)
 	}
 	return ops, nil
 }
@@ -514,10 +1202,13 @@ func (g *Generation) Abort(userName string) error {
 			}
 		}
 
-		// Must not have upgraded charm of tracked application.
-		// TODO (hml) 2019-06-26
-		// Implement cannot abort branch where tracked application has
-		// been upgraded.
+		// With no units tracking the branch, no unit can be running a
+		// charm staged under it, so the branch's references to the
+		// staged charms can simply be dropped.
+		ops, err := g.releaseCharmsTxnOps(nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 
 		now, err := g.st.ControllerTimestamp()
 		if err != nil {
@@ -526,7 +1217,7 @@ func (g *Generation) Abort(userName string) error {
 		// As a proxy for checking that the generation has not changed,
 		// Assert that the txn rev-no has not changed since we materialised
 		// this generation object.
-		ops := []txn.Op{{
+		ops = append(ops, txn.Op{
 			C:      generationsC,
 			Id:     g.doc.DocId,
 			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
@@ -536,7 +1227,7 @@ func (g *Generation) Abort(userName string) error {
 					{"completed-by", userName},
 				}},
 			},
-		}}
+		})
 		return ops, nil
 	}
 
@@ -598,16 +1289,29 @@ func (g *Generation) unassignUnitOps(unitName, appName string) []txn.Op {
 	}}
 }
 
-// HasChangesFor returns true when the generation has config changes for
-// the provided application.
+// HasChangesFor returns true when the generation has config, charm or
+// constraints changes for the provided application.
 func (g *Generation) HasChangesFor(appName string) bool {
-	_, ok := g.doc.Config[appName]
+	if _, ok := g.doc.Config[appName]; ok {
+		return true
+	}
+	if _, ok := g.doc.Charms[appName]; ok {
+		return true
+	}
+	_, ok := g.doc.Constraints[appName]
 	return ok
 }
 
-// unassignAppOps returns operations to remove the tracking and config data
-// for the application from the generation.
-func (g *Generation) unassignAppOps(appName string) []txn.Op {
+// CharmFor returns the charm upgrade staged for the application with the
+// input name under this branch, and whether there is one.
+func (g *Generation) CharmFor(appName string) (BranchCharm, bool) {
+	staged, ok := g.Charms()[appName]
+	return staged, ok
+}
+
+// unassignAppOps returns operations to remove the tracking, config, charm
+// and constraints data for the application from the generation.
+func (g *Generation) unassignAppOps(appName string) ([]txn.Op, error) {
 	assigned := g.doc.AssignedUnits
 	delete(assigned, appName)
 	ops := []txn.Op{{
@@ -636,7 +1340,27 @@ func (g *Generation) unassignAppOps(appName string) []txn.Op {
 			},
 		})
 	}
-	return ops
+	if staged, ok := g.doc.Charms[appName]; ok {
+		decOps, err := g.charmDecRefOps(appName, staged.CharmURL)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, decOps...)
+	}
+	if len(g.doc.Charms) > 0 || len(g.doc.Constraints) > 0 {
+		ops = append(ops, txn.Op{
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
+			Update: bson.D{
+				{"$unset", bson.D{
+					{"charms." + appName, 1},
+					{"constraints." + appName, 1},
+				}},
+			},
+		})
+	}
+	return ops, nil
 }
 
 // AddBranch creates a new branch in the current model.
@@ -677,22 +1401,25 @@ func (st *State) AddBranch(branchName, userName string) error {
 }
 
 func insertGenerationTxnOps(id, branchName, userName string, now *time.Time) []txn.Op {
-	doc := &generationDoc{
-		Name:          branchName,
-		AssignedUnits: map[string][]string{},
-		Created:       now.Unix(),
-		CreatedBy:     userName,
-	}
-
 	return []txn.Op{
 		{
 			C:      generationsC,
 			Id:     id,
-			Insert: doc,
+			Insert: newGenerationDoc(branchName, userName, now),
 		},
 	}
 }
 
+// newGenerationDoc returns the document for a new branch with no changes.
+func newGenerationDoc(branchName, userName string, now *time.Time) *generationDoc {
+	return &generationDoc{
+		Name:          branchName,
+		AssignedUnits: map[string][]string{},
+		Created:       now.Unix(),
+		CreatedBy:     userName,
+	}
+}
+
 // Generations returns all committed  branches.
 func (m *Model) Generations() ([]*Generation, error) {
 	b, err := m.st.CommittedBranches()
@@ -754,6 +1481,188 @@ func (m *Model) Generation(id int) (*Generation, error) {
 	return gen, errors.Trace(err)
 }
 
+// RevertCommit adds a new branch with the input name, staging the inverse of
+// the charm configuration changes made by the committed generation with the
+// input ID. Every application with a reverted change is assigned to the new
+// branch, so that committing it rolls the change back across the model.
+// Changes to keys that have been changed again since the commit, by a later
+// commit or otherwise, are not reverted and are returned as conflicts.
+func (m *Model) RevertCommit(generationId int, branchName, userName string) ([]model.RevertConflict, error) {
+	gen, err := m.Generation(generationId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	committed, err := m.Generations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var later []*Generation
+	for _, c := range committed {
+		if c.GenerationId() > generationId {
+			later = append(later, c)
+		}
+	}
+	sort.Slice(later, func(i, j int) bool {
+		return later[i].GenerationId() > later[j].GenerationId()
+	})
+
+	inverse, conflicts, err := m.st.revertChanges(gen, later)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(inverse) == 0 {
+		return conflicts, errors.Errorf("commit %d has no changes that can be reverted", generationId)
+	}
+
+	id, err := sequence(m.st, "branch")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := m.st.Branch(branchName); err == nil {
+			return nil, errors.Errorf("model already has branch %q", branchName)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Annotatef(err, "checking for existing branch")
+		}
+		now, err := m.st.ControllerTimestamp()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops, err := m.st.revertBranchTxnOps(strconv.Itoa(id), branchName, userName, now, inverse)
+		return ops, errors.Trace(err)
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return conflicts, nil
+}
+
+// revertBranchTxnOps returns the operations that add a branch staging the
+// input charm settings for each application, with the applications
+// assigned to it. The settings are staged as changes from the current
+// master settings, which are asserted not to change.
+func (st *State) revertBranchTxnOps(
+	id, branchName, userName string, now *time.Time, inverse map[string]charm.Settings,
+) ([]txn.Op, error) {
+	doc := newGenerationDoc(branchName, userName, now)
+	doc.Config = make(map[string][]itemChange)
+	var ops []txn.Op
+	for appName, changes := range inverse {
+		app, err := st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		changes, err = ch.Config().ValidateSettings(changes)
+		if err != nil {
+			return nil, errors.Annotatef(err, "reverting config for application %q", appName)
+		}
+		master, err := readSettings(st.db(), settingsC, app.charmConfigKey())
+		if err != nil {
+			return nil, errors.Annotatef(err, "charm config for application %q", appName)
+		}
+		for k, v := range changes {
+			if v == nil {
+				master.Delete(k)
+			} else {
+				master.Set(k, v)
+			}
+		}
+		doc.AssignedUnits[appName] = []string{}
+		doc.Config[appName] = makeItemChanges(master.changes())
+		ops = append(ops, master.assertUnchangedOp())
+	}
+	return append(ops, txn.Op{
+		C:      generationsC,
+		Id:     id,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}), nil
+}
+
+// revertChanges returns, for each application, the charm settings that undo
+// the config changes made by the input committed generation.
+// Keys whose current master value is not the one set by the commit are
+// reported as conflicts, citing the most recent of the input later commits
+// to have changed them, if any.
+func (st *State) revertChanges(
+	gen *Generation, later []*Generation,
+) (map[string]charm.Settings, []model.RevertConflict, error) {
+	inverse := make(map[string]charm.Settings)
+	var conflicts []model.RevertConflict
+	deltas := gen.Config()
+	appNames := set.NewStrings()
+	for appName := range deltas {
+		appNames.Add(appName)
+	}
+	for _, appName := range appNames.SortedValues() {
+		conflict := func(key, reason string) {
+			conflicts = append(conflicts, model.RevertConflict{
+				Application: appName,
+				Key:         key,
+				Reason:      reason,
+			})
+		}
+
+		app, err := st.Application(appName)
+		if errors.IsNotFound(err) {
+			for _, delta := range deltas[appName] {
+				conflict(delta.Key, "application no longer exists")
+			}
+			continue
+		} else if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		current, err := readSettings(st.db(), settingsC, app.charmConfigKey())
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "charm config for application %q", appName)
+		}
+
+		for _, delta := range deltas[appName] {
+			if _, ok := ch.Config().Options[delta.Key]; !ok {
+				conflict(delta.Key, "no longer defined by the application's charm")
+				continue
+			}
+			value, found := current.Get(delta.Key)
+			if delta.IsDeletion() && found || !delta.IsDeletion() && !reflect.DeepEqual(value, delta.NewValue) {
+				reason := fmt.Sprintf("changed since commit %d", gen.GenerationId())
+				if id := lastChangedBy(later, appName, delta.Key); id > 0 {
+					reason = fmt.Sprintf("changed by commit %d", id)
+				}
+				conflict(delta.Key, reason)
+				continue
+			}
+
+			if inverse[appName] == nil {
+				inverse[appName] = make(charm.Settings)
+			}
+			// A nil value resets the key, undoing an addition.
+			inverse[appName][delta.Key] = delta.OldValue
+		}
+	}
+	return inverse, conflicts, nil
+}
+
+// lastChangedBy returns the ID of the first of the input generations to
+// have changed the input application config key, or zero if none did.
+func lastChangedBy(gens []*Generation, appName, key string) int {
+	for _, g := range gens {
+		for _, ch := range g.Config()[appName] {
+			if ch.Key == key {
+				return g.GenerationId()
+			}
+		}
+	}
+	return 0
+}
+
 func (m *Model) applicationBranches(appName string) ([]*Generation, error) {
 	branches, err := m.Branches()
 	if err != nil {
@@ -4,6 +4,7 @@
 package state_test
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/charm/v7"
@@ -12,10 +13,13 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 const (
@@ -216,6 +220,128 @@ func (s *generationSuite) TestAssignNumUnitsSelectAll(c *gc.C) {
 	c.Check(gen.AssignedUnits()["riak"], jc.SameContents, expected)
 }
 
+func (s *generationSuite) TestAssignUnitsWithPolicyPercent(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	c.Assert(gen.AssignUnitsWithPolicy("riak", model.TrackPolicy{Percent: 25}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits()["riak"], gc.HasLen, 1)
+
+	// Percentages include the units already tracking, and round up.
+	c.Assert(gen.AssignUnitsWithPolicy("riak", model.TrackPolicy{Percent: 30}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits()["riak"], gc.HasLen, 2)
+
+	c.Assert(gen.AssignUnitsWithPolicy("riak", model.TrackPolicy{Percent: 100}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits()["riak"], jc.SameContents, []string{"riak/0", "riak/1", "riak/2", "riak/3"})
+}
+
+func (s *generationSuite) TestAssignUnitsWithPolicyAnnotations(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	for _, name := range []string{"riak/1", "riak/3"} {
+		unit, err := s.State.Unit(name)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(s.Model.SetAnnotations(unit, map[string]string{"canary": "true"}), jc.ErrorIsNil)
+	}
+
+	policy := model.TrackPolicy{Annotations: map[string]string{"canary": "true"}}
+	c.Assert(gen.AssignUnitsWithPolicy("riak", policy), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits()["riak"], jc.SameContents, []string{"riak/1", "riak/3"})
+}
+
+func (s *generationSuite) TestAssignUnitsWithPolicyConstraints(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	// riak/3 is not on a provisioned machine, so can't be selected.
+	for i, arch := range []string{"amd64", "arm64", "arm64"} {
+		arch := arch
+		unit, err := s.State.Unit(fmt.Sprintf("riak/%d", i))
+		c.Assert(err, jc.ErrorIsNil)
+		m := s.Factory.MakeMachine(c, &factory.MachineParams{
+			Characteristics: &instance.HardwareCharacteristics{Arch: &arch},
+		})
+		c.Assert(unit.AssignToMachine(m), jc.ErrorIsNil)
+	}
+
+	policy := model.TrackPolicy{Constraints: constraints.MustParse("arch=arm64")}
+	c.Assert(gen.AssignUnitsWithPolicy("riak", policy), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits()["riak"], jc.SameContents, []string{"riak/1", "riak/2"})
+}
+
+func (s *generationSuite) TestAssignUnitsWithPolicyInvalid(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	err := gen.AssignUnitsWithPolicy("riak", model.TrackPolicy{Percent: 120})
+	c.Assert(err, gc.ErrorMatches, "percentage 120 not valid")
+}
+
+func (s *generationSuite) TestProgression(c *gc.C) {
+	clock := testclock.NewClock(testing.NonZeroTime())
+	c.Assert(s.State.SetClockForTesting(clock), jc.ErrorIsNil)
+	gen := s.setupAssignAllUnits(c)
+
+	_, ok := gen.Progression()
+	c.Check(ok, jc.IsFalse)
+
+	progression := model.BranchProgression{
+		Policy:      model.TrackPolicy{Percent: 25},
+		StepPercent: 25,
+		Interval:    time.Hour,
+	}
+	c.Assert(gen.AssignUnitsWithPolicy("riak", progression.Policy), jc.ErrorIsNil)
+	c.Assert(gen.SetProgression(progression), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	got, ok := gen.Progression()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(got.BranchProgression, jc.DeepEquals, progression)
+	c.Check(got.NextStep.Unix(), gc.Equals, clock.Now().Add(time.Hour).Unix())
+
+	clock.Advance(time.Hour)
+	c.Assert(gen.StepProgression(), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits()["riak"], gc.HasLen, 2)
+	got, ok = gen.Progression()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(got.Policy.Percent, gc.Equals, 50)
+	c.Check(got.NextStep.Unix(), gc.Equals, clock.Now().Add(time.Hour).Unix())
+
+	// Progression stops once all of the units track the branch.
+	c.Assert(gen.StepProgression(), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Assert(gen.StepProgression(), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits()["riak"], gc.HasLen, 4)
+	_, ok = gen.Progression()
+	c.Check(ok, jc.IsFalse)
+
+	err := gen.StepProgression()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *generationSuite) TestProgressionConstraints(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	progression := model.BranchProgression{
+		Policy: model.TrackPolicy{
+			Percent:     25,
+			Constraints: constraints.MustParse("arch=arm64 zones=az1"),
+		},
+		StepPercent: 25,
+		Interval:    time.Hour,
+	}
+	c.Assert(gen.SetProgression(progression), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	got, ok := gen.Progression()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(got.BranchProgression, jc.DeepEquals, progression)
+}
+
 func (s *generationSuite) TestAssignAllUnitsCompletedError(c *gc.C) {
 	s.setupTestingClock(c)
 	gen := s.setupAssignAllUnits(c)
@@ -284,6 +410,199 @@ func (s *generationSuite) TestCommitAppliesConfigDeltas(c *gc.C) {
 	c.Check(cfg, gc.DeepEquals, charm.Settings(newCfg))
 }
 
+func (s *generationSuite) TestBranchCharmOnlyForTrackedUnits(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	newCh := s.AddConfigCharm(c, "riak", riakConfigYAML, 667)
+	c.Assert(app.SetBranchCharm(newBranchName, state.SetCharmConfig{Charm: newCh}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	staged, ok := gen.CharmFor("riak")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(staged.URL, gc.DeepEquals, newCh.URL())
+
+	curl, _, err := app.CharmURLForUnit("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(curl, gc.DeepEquals, newCh.URL())
+
+	curl, _, err = app.CharmURLForUnit("riak/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(curl, gc.DeepEquals, s.ch.URL())
+}
+
+func (s *generationSuite) TestCommitAppliesBranchCharmAndConstraints(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	newCh := s.AddConfigCharm(c, "riak", riakConfigYAML, 667)
+	c.Assert(app.SetBranchCharm(newBranchName, state.SetCharmConfig{Charm: newCh}), jc.ErrorIsNil)
+	cons := constraints.MustParse("mem=4G")
+	c.Assert(app.SetBranchConstraints(newBranchName, cons), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	// Nothing is applied to the application until the branch is committed.
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Check(curl, gc.DeepEquals, s.ch.URL())
+
+	_, err = gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	curl, _ = app.CharmURL()
+	c.Check(curl, gc.DeepEquals, newCh.URL())
+	appCons, err := app.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(appCons, gc.DeepEquals, cons)
+}
+
+func (s *generationSuite) TestCommitAppliesConfigDeltasToBranchCharm(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	newCh := s.AddConfigCharm(c, "riak", riakConfigYAML, 667)
+	c.Assert(app.SetBranchCharm(newBranchName, state.SetCharmConfig{Charm: newCh}), jc.ErrorIsNil)
+	newCfg := map[string]interface{}{"http_port": int64(9999)}
+	c.Assert(app.UpdateCharmConfig(newBranchName, newCfg), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	_, err = gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Check(curl, gc.DeepEquals, newCh.URL())
+	cfg, err := app.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg, gc.DeepEquals, charm.Settings(newCfg))
+}
+
+func (s *generationSuite) TestCommitAppliesNothingOnFailure(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	newCh := s.AddConfigCharm(c, "riak", riakConfigYAML, 667)
+	c.Assert(app.SetBranchCharm(newBranchName, state.SetCharmConfig{Charm: newCh}), jc.ErrorIsNil)
+	c.Assert(app.SetBranchConstraints(newBranchName, constraints.MustParse("mem=4G")), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	// The application is dying, so its constraints can't be set.
+	c.Assert(app.Destroy(), jc.ErrorIsNil)
+
+	_, err = gen.Commit(branchCommitter)
+	c.Assert(err, gc.ErrorMatches, `application "riak": application is not found or not alive`)
+
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Check(curl, gc.DeepEquals, s.ch.URL())
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.IsCompleted(), jc.IsFalse)
+}
+
+func (s *generationSuite) TestAbortReleasesBranchCharm(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignUnits(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	newCh := s.AddConfigCharm(c, "riak", riakConfigYAML, 667)
+	c.Assert(app.SetBranchCharm(newBranchName, state.SetCharmConfig{Charm: newCh}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	c.Assert(gen.Abort(branchCommitter), jc.ErrorIsNil)
+
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Check(curl, gc.DeepEquals, s.ch.URL())
+}
+
+func (s *generationSuite) TestRevertCommit(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.UpdateCharmConfig(newBranchName, charm.Settings{"http_port": int64(9999)}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	genId, err := gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	conflicts, err := s.Model.RevertCommit(genId, "revert", branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(conflicts, gc.HasLen, 0)
+
+	revert, err := s.Model.Branch("revert")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revert.AssignedUnits(), gc.DeepEquals, map[string][]string{"riak": {}})
+
+	_, err = revert.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := app.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg, gc.DeepEquals, charm.Settings{"http_port": int64(8089)})
+}
+
+func (s *generationSuite) TestRevertCommitExistingBranch(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.UpdateCharmConfig(newBranchName, charm.Settings{"http_port": int64(9999)}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	genId, err := gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	existing := s.addBranch(c)
+	_, err = s.Model.RevertCommit(genId, newBranchName, branchCommitter)
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf("model already has branch %q", newBranchName))
+
+	// The existing branch is left as it was.
+	c.Assert(existing.Refresh(), jc.ErrorIsNil)
+	c.Check(existing.AssignedUnits(), gc.HasLen, 0)
+	c.Check(existing.Config(), gc.HasLen, 0)
+}
+
+func (s *generationSuite) TestRevertCommitConflict(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.UpdateCharmConfig(newBranchName, charm.Settings{"http_port": int64(9999)}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	genId, err := gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	gen = s.addBranch(c)
+	c.Assert(app.UpdateCharmConfig(newBranchName, charm.Settings{"http_port": int64(7777)}), jc.ErrorIsNil)
+	c.Assert(gen.AssignApplication("riak"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	laterId, err := gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	conflicts, err := s.Model.RevertCommit(genId, "revert", branchCommitter)
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf("commit %d has no changes that can be reverted", genId))
+	c.Check(conflicts, gc.DeepEquals, []model.RevertConflict{{
+		Application: "riak",
+		Key:         "http_port",
+		Reason:      fmt.Sprintf("changed by commit %d", laterId),
+	}})
+
+	_, err = s.Model.Branch("revert")
+	c.Check(errors.IsNotFound(err), jc.IsTrue)
+}
+
 func (s *generationSuite) TestAbortSuccess(c *gc.C) {
 	s.setupTestingClock(c)
 
@@ -508,12 +827,13 @@ func (s *generationSuite) TestDestroyCleansupBranches(c *gc.C) {
 	c.Check(branches, gc.HasLen, 0)
 }
 
-func (s *generationSuite) setupAssignAllUnits(c *gc.C) *state.Generation {
-	var cfgYAML = `
+const riakConfigYAML = `
 options:
   http_port: {default: 8089, description: HTTP Port, type: int}
 `
-	s.ch = s.AddConfigCharm(c, "riak", cfgYAML, 666)
+
+func (s *generationSuite) setupAssignAllUnits(c *gc.C) *state.Generation {
+	s.ch = s.AddConfigCharm(c, "riak", riakConfigYAML, 666)
 
 	riak := s.AddTestingApplication(c, "riak", s.ch)
 	for i := 0; i < 4; i++ {
@@ -525,11 +845,7 @@ options:
 }
 
 func (s *generationSuite) setupAssignUnits(c *gc.C) *state.Generation {
-	var cfgYAML = `
-options:
-  http_port: {default: 8089, description: HTTP Port, type: int}
-`
-	s.ch = s.AddConfigCharm(c, "riak", cfgYAML, 666)
+	s.ch = s.AddConfigCharm(c, "riak", riakConfigYAML, 666)
 
 	s.AddTestingApplication(c, "riak", s.ch)
 
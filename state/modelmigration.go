@@ -76,6 +76,18 @@ type ModelMigration interface {
 	// current progress of the migration.
 	SetStatusMessage(text string) error
 
+	// PhaseTimes returns the time the migration entered each of the
+	// phases it has been through, in order.
+	PhaseTimes() []migration.PhaseTime
+
+	// Progress returns the entity and log transfer counts recorded
+	// for the migration.
+	Progress() migration.Progress
+
+	// SetProgress records the entity and log transfer counts for the
+	// migration. Counts which are nil or zero are left unchanged.
+	SetProgress(progress migration.Progress) error
+
 	// SubmitMinionReport records a report from a migration minion
 	// worker about the success or failure to complete its actions for
 	// a given migration phase.
@@ -86,6 +98,11 @@ type ModelMigration interface {
 	// those which are yet to report.
 	MinionReports() (*MinionReports, error)
 
+	// MinionReportsForPhase returns details of the minions that have
+	// reported success or failure for the given migration phase, as
+	// well as those which are yet to report.
+	MinionReportsForPhase(phase migration.Phase) (*MinionReports, error)
+
 	// WatchMinionReports returns a notify watcher which triggers when
 	// a migration minion has reported back about the success or failure
 	// of its actions for the current migration phase.
@@ -205,6 +222,28 @@ type modelMigStatusDoc struct {
 	// StatusMessage holds a human readable message about the
 	// migration's progress.
 	StatusMessage string `bson:"status-message"`
+
+	// PhaseTimes records when the migration entered each phase.
+	PhaseTimes []modelMigPhaseTimeDoc `bson:"phase-times,omitempty"`
+
+	// ExportedEntities and ImportedEntities hold the number of each
+	// kind of entity exported from the source and imported into the
+	// target controller.
+	ExportedEntities map[string]int `bson:"exported-entities,omitempty"`
+	ImportedEntities map[string]int `bson:"imported-entities,omitempty"`
+
+	// LogsTransferred holds the number of log records transferred to
+	// the target controller.
+	LogsTransferred int `bson:"logs-transferred,omitempty"`
+
+	// LastLogTime holds the timestamp of the last log record
+	// transferred (stored as per UnixNano).
+	LastLogTime int64 `bson:"last-log-time,omitempty"`
+}
+
+type modelMigPhaseTimeDoc struct {
+	Phase string `bson:"phase"`
+	Time  int64  `bson:"time"`
 }
 
 type modelMigMinionSyncDoc struct {
@@ -265,6 +304,29 @@ func (mig *modelMigration) StatusMessage() string {
 	return mig.statusDoc.StatusMessage
 }
 
+// PhaseTimes implements ModelMigration.
+func (mig *modelMigration) PhaseTimes() []migration.PhaseTime {
+	times := make([]migration.PhaseTime, 0, len(mig.statusDoc.PhaseTimes))
+	for _, doc := range mig.statusDoc.PhaseTimes {
+		phase, _ := migration.ParsePhase(doc.Phase)
+		times = append(times, migration.PhaseTime{
+			Phase: phase,
+			Time:  unixNanoToTime0(doc.Time),
+		})
+	}
+	return times
+}
+
+// Progress implements ModelMigration.
+func (mig *modelMigration) Progress() migration.Progress {
+	return migration.Progress{
+		ExportedEntities: mig.statusDoc.ExportedEntities,
+		ImportedEntities: mig.statusDoc.ImportedEntities,
+		LogsTransferred:  mig.statusDoc.LogsTransferred,
+		LastLogTime:      unixNanoToTime0(mig.statusDoc.LastLogTime),
+	}
+}
+
 // InitiatedBy implements ModelMigration.
 func (mig *modelMigration) InitiatedBy() string {
 	return mig.doc.InitiatedBy
@@ -307,9 +369,11 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 		return errors.Errorf("illegal phase change: %s -> %s", phase, nextPhase)
 	}
 
+	phaseTime := modelMigPhaseTimeDoc{Phase: nextPhase.String(), Time: now}
 	nextDoc := mig.statusDoc
 	nextDoc.Phase = nextPhase.String()
 	nextDoc.PhaseChangedTime = now
+	nextDoc.PhaseTimes = append(append([]modelMigPhaseTimeDoc(nil), mig.statusDoc.PhaseTimes...), phaseTime)
 	update := bson.M{
 		"phase":              nextDoc.Phase,
 		"phase-changed-time": now,
@@ -350,9 +414,12 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 	}
 
 	ops = append(ops, txn.Op{
-		C:      migrationsStatusC,
-		Id:     mig.statusDoc.Id,
-		Update: bson.M{"$set": update},
+		C:  migrationsStatusC,
+		Id: mig.statusDoc.Id,
+		Update: bson.M{
+			"$set":  update,
+			"$push": bson.M{"phase-times": phaseTime},
+		},
 		// Ensure phase hasn't changed underneath us
 		Assert: bson.M{"phase": mig.statusDoc.Phase},
 	})
@@ -427,6 +494,44 @@ func (mig *modelMigration) SetStatusMessage(text string) error {
 	return nil
 }
 
+// SetProgress implements ModelMigration.
+func (mig *modelMigration) SetProgress(progress migration.Progress) error {
+	// Counts which haven't been reported are left alone, so that the
+	// entity counts aren't lost when only log progress is reported.
+	nextDoc := mig.statusDoc
+	update := bson.M{}
+	if progress.ExportedEntities != nil {
+		nextDoc.ExportedEntities = progress.ExportedEntities
+		update["exported-entities"] = progress.ExportedEntities
+	}
+	if progress.ImportedEntities != nil {
+		nextDoc.ImportedEntities = progress.ImportedEntities
+		update["imported-entities"] = progress.ImportedEntities
+	}
+	if progress.LogsTransferred != 0 {
+		nextDoc.LogsTransferred = progress.LogsTransferred
+		update["logs-transferred"] = progress.LogsTransferred
+	}
+	if !progress.LastLogTime.IsZero() {
+		nextDoc.LastLogTime = progress.LastLogTime.UnixNano()
+		update["last-log-time"] = nextDoc.LastLogTime
+	}
+	if len(update) == 0 {
+		return nil
+	}
+	ops := []txn.Op{{
+		C:      migrationsStatusC,
+		Id:     mig.statusDoc.Id,
+		Assert: txn.DocExists,
+		Update: bson.M{"$set": update},
+	}}
+	if err := mig.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotate(err, "failed to set migration progress")
+	}
+	mig.statusDoc = nextDoc
+	return nil
+}
+
 // SubmitMinionReport implements ModelMigration.
 func (mig *modelMigration) SubmitMinionReport(tag names.Tag, phase migration.Phase, success bool) error {
 	globalKey, err := agentTagToGlobalKey(tag)
@@ -470,14 +575,19 @@ func (mig *modelMigration) SubmitMinionReport(tag names.Tag, phase migration.Pha
 
 // MinionReports implements ModelMigration.
 func (mig *modelMigration) MinionReports() (*MinionReports, error) {
-	all, err := mig.getAllAgents()
+	phase, err := mig.Phase()
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, errors.Annotate(err, "retrieving phase")
 	}
+	reports, err := mig.MinionReportsForPhase(phase)
+	return reports, errors.Trace(err)
+}
 
-	phase, err := mig.Phase()
+// MinionReportsForPhase implements ModelMigration.
+func (mig *modelMigration) MinionReportsForPhase(phase migration.Phase) (*MinionReports, error) {
+	all, err := mig.getAllAgents()
 	if err != nil {
-		return nil, errors.Annotate(err, "retrieving phase")
+		return nil, errors.Trace(err)
 	}
 
 	coll, closer := mig.st.db().GetCollection(migrationsMinionSyncC)
@@ -726,6 +836,10 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			Phase:            migration.QUIESCE.String(),
 			PhaseChangedTime: now,
 			StatusMessage:    msg,
+			PhaseTimes: []modelMigPhaseTimeDoc{{
+				Phase: migration.QUIESCE.String(),
+				Time:  now,
+			}},
 		}
 
 		ops := append(ops, []txn.Op{{
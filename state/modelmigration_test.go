@@ -525,6 +525,67 @@ func (s *MigrationSuite) TestStatusMessage(c *gc.C) {
 	c.Check(mig2.StatusMessage(), gc.Equals, "foo bar")
 }
 
+func (s *MigrationSuite) TestPhaseTimes(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	start := s.Clock.Now()
+
+	s.Clock.Advance(time.Second)
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+	s.Clock.Advance(time.Minute)
+	c.Assert(mig.SetPhase(migration.ABORT), jc.ErrorIsNil)
+
+	expected := []migration.PhaseTime{
+		{Phase: migration.QUIESCE, Time: start},
+		{Phase: migration.IMPORT, Time: start.Add(time.Second)},
+		{Phase: migration.ABORT, Time: start.Add(time.Second + time.Minute)},
+	}
+	assertPhaseTimes := func(times []migration.PhaseTime) {
+		c.Assert(times, gc.HasLen, len(expected))
+		for i, t := range times {
+			c.Check(t.Phase, gc.Equals, expected[i].Phase)
+			c.Check(t.Time.Equal(expected[i].Time), jc.IsTrue)
+		}
+	}
+	assertPhaseTimes(mig.PhaseTimes())
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	assertPhaseTimes(mig2.PhaseTimes())
+}
+
+func (s *MigrationSuite) TestProgress(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.Progress(), jc.DeepEquals, migration.Progress{})
+
+	lastLogTime := time.Date(2020, 3, 1, 12, 30, 0, 0, time.UTC)
+	progress := migration.Progress{
+		ExportedEntities: map[string]int{"machines": 2, "units": 3},
+		ImportedEntities: map[string]int{"machines": 2},
+		LogsTransferred:  42,
+		LastLogTime:      lastLogTime,
+	}
+	c.Assert(mig.SetProgress(progress), jc.ErrorIsNil)
+	c.Check(mig.Progress().LogsTransferred, gc.Equals, 42)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	got := mig2.Progress()
+	c.Check(got.ExportedEntities, jc.DeepEquals, progress.ExportedEntities)
+	c.Check(got.ImportedEntities, jc.DeepEquals, progress.ImportedEntities)
+	c.Check(got.LogsTransferred, gc.Equals, 42)
+	c.Check(got.LastLogTime.Equal(lastLogTime), jc.IsTrue)
+
+	// Reporting only log progress leaves the entity counts alone.
+	c.Assert(mig.SetProgress(migration.Progress{LogsTransferred: 50}), jc.ErrorIsNil)
+	c.Assert(mig2.Refresh(), jc.ErrorIsNil)
+	got = mig2.Progress()
+	c.Check(got.ExportedEntities, jc.DeepEquals, progress.ExportedEntities)
+	c.Check(got.LogsTransferred, gc.Equals, 50)
+	c.Check(got.LastLogTime.Equal(lastLogTime), jc.IsTrue)
+}
+
 func (s *MigrationSuite) TestWatchForMigration(c *gc.C) {
 	// Start watching for migration.
 	w, wc := s.createMigrationWatcher(c, s.State2)
@@ -694,6 +755,28 @@ func (s *MigrationSuite) TestMinionReports(c *gc.C) {
 	c.Check(reports.Unknown, jc.SameContents, []names.Tag{m2.Tag()})
 }
 
+func (s *MigrationSuite) TestMinionReportsForPhase(c *gc.C) {
+	factory2 := factory.NewFactory(s.State2, s.StatePool)
+	m0 := factory2.MakeMachine(c, nil)
+	m1 := factory2.MakeMachine(c, nil)
+
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SubmitMinionReport(m0.Tag(), migration.QUIESCE, true), jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+
+	reports, err := mig.MinionReportsForPhase(migration.QUIESCE)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports.Succeeded, jc.SameContents, []names.Tag{m0.Tag()})
+	c.Check(reports.Failed, gc.HasLen, 0)
+	c.Check(reports.Unknown, jc.SameContents, []names.Tag{m1.Tag()})
+
+	reports, err = mig.MinionReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reports.Succeeded, gc.HasLen, 0)
+	c.Check(reports.Unknown, jc.SameContents, []names.Tag{m0.Tag(), m1.Tag()})
+}
+
 func (s *MigrationSuite) TestMinionReportsCAAS(c *gc.C) {
 	// Create some machines and units to report with.
 	st := s.Factory.MakeCAASModel(c, nil)
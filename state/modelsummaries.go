@@ -58,6 +58,8 @@ type ModelSummary struct {
 	ProviderType  string
 	DefaultSeries string
 	AgentVersion  *version.Number
+	// Expiry is when the model expires, if it has a TTL.
+	Expiry *time.Time
 
 	// Needs Statuses collection
 	Status status.StatusInfo
@@ -124,6 +126,10 @@ func newProcessorFromModelDocs(st *State, modelDocs []modelDoc, user names.UserT
 			/// Users:              make(map[string]UserAccessInfo),
 			/// Machines:           make(map[string]MachineModelInfo),
 		}
+		if !doc.Expiry.IsZero() && !p.summaries[i].IsController {
+			expiry := doc.Expiry.UTC()
+			p.summaries[i].Expiry = &expiry
+		}
 		p.indexByUUID[doc.UUID] = i
 		p.modelUUIDs[i] = doc.UUID
 	}
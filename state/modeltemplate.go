@@ -0,0 +1,320 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"net"
+	"sort"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/firewall"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/permission"
+)
+
+// ModelTemplate describes a named set of settings that are applied
+// to a model when it is created.
+type ModelTemplate struct {
+	// Name uniquely identifies the template on the controller.
+	Name string
+
+	// Description is a free form description of the template.
+	Description string
+
+	// Config holds the model config attributes of the template.
+	// Attributes passed explicitly when adding a model take
+	// precedence over these.
+	Config map[string]interface{}
+
+	// Constraints holds the initial constraints of the model.
+	Constraints constraints.Value
+
+	// Users holds the users that are granted access to the model.
+	Users []ModelTemplateUser
+
+	// FirewallRules holds the initial firewall rules of the model.
+	FirewallRules []FirewallRule
+
+	// Spaces holds the names of the spaces created in the model, in
+	// addition to the default space. Subnets are moved into them
+	// once they have been discovered.
+	Spaces []string
+
+	// Blocks holds the initial blocks of the model, and their messages.
+	Blocks map[BlockType]string
+
+	// Bundle holds the content of a bundle to deploy to the model once
+	// it has been created. It is empty if there is no bundle.
+	Bundle string
+
+	// CreatedBy is the name of the user that added the template.
+	CreatedBy string
+
+	// Created is when the template was added.
+	Created time.Time
+}
+
+// ModelTemplateUser describes a user granted access to models created
+// from a template.
+type ModelTemplateUser struct {
+	Name   string
+	Access permission.Access
+}
+
+// Validate returns an error if the template is not valid.
+func (t ModelTemplate) Validate() error {
+	if !names.IsValidModelName(t.Name) {
+		return errors.NotValidf("model template name %q", t.Name)
+	}
+	seen := make(map[string]bool)
+	for _, user := range t.Users {
+		if !names.IsValidUser(user.Name) {
+			return errors.NotValidf("user name %q", user.Name)
+		}
+		if err := permission.ValidateModelAccess(user.Access); err != nil {
+			return errors.Annotatef(err, "user %q", user.Name)
+		}
+		id := names.NewUserTag(user.Name).Id()
+		if seen[id] {
+			return errors.NotValidf("duplicate user %q", user.Name)
+		}
+		seen[id] = true
+	}
+	if err := validateFirewallRules(t.FirewallRules); err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateSpaceNames(t.Spaces); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(validateBlocks(t.Blocks))
+}
+
+func validateFirewallRules(rules []FirewallRule) error {
+	seen := make(map[firewall.WellKnownServiceType]bool)
+	for _, rule := range rules {
+		if err := rule.WellKnownService().Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if seen[rule.WellKnownService()] {
+			return errors.NotValidf("duplicate firewall rule for service %q", rule.WellKnownService())
+		}
+		seen[rule.WellKnownService()] = true
+		for _, cidr := range rule.WhitelistCIDRs() {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q", cidr)
+			}
+		}
+	}
+	return nil
+}
+
+func validateSpaceNames(spaces []string) error {
+	seen := set.NewStrings()
+	for _, name := range spaces {
+		if !names.IsValidSpace(name) {
+			return errors.NotValidf("space name %q", name)
+		}
+		if name == network.AlphaSpaceName || seen.Contains(name) {
+			return errors.NotValidf("duplicate space %q", name)
+		}
+		seen.Add(name)
+	}
+	return nil
+}
+
+func validateBlocks(blocks map[BlockType]string) error {
+	for t := range blocks {
+		if _, ok := typeNames[t]; !ok {
+			return errors.NotValidf("block type %d", int(t))
+		}
+	}
+	return nil
+}
+
+// modelTemplateDoc is the persistent form of a ModelTemplate.
+type modelTemplateDoc struct {
+	Name          string                     `bson:"_id"`
+	Description   string                     `bson:"description,omitempty"`
+	Config        map[string]interface{}     `bson:"config,omitempty"`
+	Constraints   string                     `bson:"constraints,omitempty"`
+	Users         []modelTemplateUserDoc     `bson:"users,omitempty"`
+	FirewallRules []modelTemplateFirewallDoc `bson:"firewall-rules,omitempty"`
+	Spaces        []string                   `bson:"spaces,omitempty"`
+	Blocks        []modelTemplateBlockDoc    `bson:"blocks,omitempty"`
+	Bundle        string                     `bson:"bundle,omitempty"`
+	CreatedBy     string                     `bson:"created-by"`
+	Created       time.Time                  `bson:"created"`
+}
+
+type modelTemplateUserDoc struct {
+	Name   string `bson:"name"`
+	Access string `bson:"access"`
+}
+
+type modelTemplateFirewallDoc struct {
+	Service        string   `bson:"known-service"`
+	WhitelistCIDRs []string `bson:"whitelist-cidrs"`
+}
+
+type modelTemplateBlockDoc struct {
+	Type    string `bson:"type"`
+	Message string `bson:"message"`
+}
+
+func newModelTemplateDoc(t ModelTemplate) modelTemplateDoc {
+	doc := modelTemplateDoc{
+		Name:        t.Name,
+		Description: t.Description,
+		Config:      t.Config,
+		Spaces:      t.Spaces,
+		Bundle:      t.Bundle,
+		CreatedBy:   t.CreatedBy,
+		Created:     t.Created,
+	}
+	if !constraints.IsEmpty(&t.Constraints) {
+		doc.Constraints = t.Constraints.String()
+	}
+	for _, user := range t.Users {
+		doc.Users = append(doc.Users, modelTemplateUserDoc{
+			Name:   names.NewUserTag(user.Name).Id(),
+			Access: string(user.Access),
+		})
+	}
+	for _, rule := range t.FirewallRules {
+		doc.FirewallRules = append(doc.FirewallRules, modelTemplateFirewallDoc{
+			Service:        string(rule.WellKnownService()),
+			WhitelistCIDRs: rule.WhitelistCIDRs(),
+		})
+	}
+	for _, blockType := range AllTypes() {
+		if msg, ok := t.Blocks[blockType]; ok {
+			doc.Blocks = append(doc.Blocks, modelTemplateBlockDoc{
+				Type:    blockType.String(),
+				Message: msg,
+			})
+		}
+	}
+	return doc
+}
+
+func (doc modelTemplateDoc) toTemplate() (ModelTemplate, error) {
+	cons, err := constraints.Parse(doc.Constraints)
+	if err != nil {
+		return ModelTemplate{}, errors.Annotatef(err, "model template %q constraints", doc.Name)
+	}
+	t := ModelTemplate{
+		Name:        doc.Name,
+		Description: doc.Description,
+		Config:      doc.Config,
+		Constraints: cons,
+		Spaces:      doc.Spaces,
+		Bundle:      doc.Bundle,
+		CreatedBy:   doc.CreatedBy,
+		Created:     doc.Created.UTC(),
+	}
+	for _, user := range doc.Users {
+		t.Users = append(t.Users, ModelTemplateUser{
+			Name:   user.Name,
+			Access: permission.Access(user.Access),
+		})
+	}
+	for _, rule := range doc.FirewallRules {
+		t.FirewallRules = append(t.FirewallRules, NewFirewallRule(
+			firewall.WellKnownServiceType(rule.Service), rule.WhitelistCIDRs,
+		))
+	}
+	if len(doc.Blocks) > 0 {
+		t.Blocks = make(map[BlockType]string, len(doc.Blocks))
+		for _, block := range doc.Blocks {
+			t.Blocks[ParseBlockType(block.Type)] = block.Message
+		}
+	}
+	return t, nil
+}
+
+// AddModelTemplate stores a new model template on the controller. An
+// error satisfying errors.IsAlreadyExists is returned if a template
+// with the same name already exists.
+func (st *State) AddModelTemplate(t ModelTemplate) error {
+	if err := t.Validate(); err != nil {
+		return errors.Annotate(err, "cannot add model template")
+	}
+	if t.Created.IsZero() {
+		t.Created = st.nowToTheSecond()
+	}
+	doc := newModelTemplateDoc(t)
+	ops := []txn.Op{{
+		C:      modelTemplatesC,
+		Id:     doc.Name,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.AlreadyExistsf("model template %q", t.Name)
+	}
+	return errors.Annotate(err, "cannot add model template")
+}
+
+// RemoveModelTemplate removes the named model template. Models
+// previously created from the template are unaffected.
+func (st *State) RemoveModelTemplate(name string) error {
+	ops := []txn.Op{{
+		C:      modelTemplatesC,
+		Id:     name,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("model template %q", name)
+	}
+	return errors.Annotate(err, "cannot remove model template")
+}
+
+// ModelTemplate returns the named model template.
+func (st *State) ModelTemplate(name string) (ModelTemplate, error) {
+	coll, closer := st.db().GetCollection(modelTemplatesC)
+	defer closer()
+
+	var doc modelTemplateDoc
+	err := coll.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ModelTemplate{}, errors.NotFoundf("model template %q", name)
+	} else if err != nil {
+		return ModelTemplate{}, errors.Annotate(err, "getting model template")
+	}
+	return doc.toTemplate()
+}
+
+// ModelTemplates returns all of the model templates on the
+// controller, sorted by name.
+func (st *State) ModelTemplates() ([]ModelTemplate, error) {
+	coll, closer := st.db().GetCollection(modelTemplatesC)
+	defer closer()
+
+	var docs []modelTemplateDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "getting model templates")
+	}
+	templates := make([]ModelTemplate, len(docs))
+	for i, doc := range docs {
+		t, err := doc.toTemplate()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		templates[i] = t
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
@@ -0,0 +1,205 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/firewall"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ModelTemplateSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelTemplateSuite{})
+
+func (s *ModelTemplateSuite) template() state.ModelTemplate {
+	return state.ModelTemplate{
+		Name:        "team",
+		Description: "standard team model",
+		Config:      map[string]interface{}{"logging-config": "<root>=DEBUG"},
+		Constraints: constraints.MustParse("mem=4G"),
+		Users: []state.ModelTemplateUser{
+			{Name: "bob", Access: permission.WriteAccess},
+		},
+		FirewallRules: []state.FirewallRule{
+			state.NewFirewallRule(firewall.SSHRule, []string{"10.0.0.0/8"}),
+		},
+		Spaces: []string{"db", "public"},
+		Blocks: map[state.BlockType]string{
+			state.DestroyBlock: "keep me",
+		},
+		Bundle:    "applications: {}",
+		CreatedBy: "admin",
+	}
+}
+
+func (s *ModelTemplateSuite) TestAddModelTemplate(c *gc.C) {
+	err := s.State.AddModelTemplate(s.template())
+	c.Assert(err, jc.ErrorIsNil)
+
+	t, err := s.State.ModelTemplate("team")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(t.Created.IsZero(), jc.IsFalse)
+	t.Created = s.template().Created
+	c.Check(t, jc.DeepEquals, s.template())
+
+	templates, err := s.State.ModelTemplates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(templates, gc.HasLen, 1)
+	c.Check(templates[0].Name, gc.Equals, "team")
+}
+
+func (s *ModelTemplateSuite) TestAddModelTemplateAlreadyExists(c *gc.C) {
+	err := s.State.AddModelTemplate(s.template())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddModelTemplate(s.template())
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `model template "team" already exists`)
+}
+
+func (s *ModelTemplateSuite) TestAddModelTemplateInvalid(c *gc.C) {
+	t := s.template()
+	t.Name = "Not Valid"
+	err := s.State.AddModelTemplate(t)
+	c.Assert(err, gc.ErrorMatches, `cannot add model template: model template name "Not Valid" not valid`)
+
+	t = s.template()
+	t.Users[0].Access = permission.SuperuserAccess
+	err = s.State.AddModelTemplate(t)
+	c.Assert(err, gc.ErrorMatches, `cannot add model template: user "bob": .*`)
+
+	t = s.template()
+	t.FirewallRules = []state.FirewallRule{
+		state.NewFirewallRule(firewall.SSHRule, []string{"nope"}),
+	}
+	err = s.State.AddModelTemplate(t)
+	c.Assert(err, gc.ErrorMatches, `cannot add model template: CIDR "nope" not valid`)
+
+	t = s.template()
+	t.Spaces = []string{"Not Valid"}
+	err = s.State.AddModelTemplate(t)
+	c.Assert(err, gc.ErrorMatches, `cannot add model template: space name "Not Valid" not valid`)
+
+	t = s.template()
+	t.Spaces = []string{"db", "db"}
+	err = s.State.AddModelTemplate(t)
+	c.Assert(err, gc.ErrorMatches, `cannot add model template: duplicate space "db" not valid`)
+
+	t = s.template()
+	t.Spaces = []string{network.AlphaSpaceName}
+	err = s.State.AddModelTemplate(t)
+	c.Assert(err, gc.ErrorMatches, `cannot add model template: duplicate space "alpha" not valid`)
+}
+
+func (s *ModelTemplateSuite) TestRemoveModelTemplate(c *gc.C) {
+	err := s.State.AddModelTemplate(s.template())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveModelTemplate("team")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.ModelTemplate("team")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.RemoveModelTemplate("team")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelTemplateSuite) TestNewModelWithTemplateSettings(c *gc.C) {
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	cfg, uuid := createTestModelConfig(c, s.State.ControllerUUID())
+	owner := names.NewUserTag("test@remote")
+
+	_, st, err := s.Controller.NewModel(state.ModelArgs{
+		Type:                    state.ModelTypeIAAS,
+		CloudName:               "dummy",
+		CloudRegion:             "dummy-region",
+		Config:                  cfg,
+		Owner:                   owner,
+		StorageProviderRegistry: storage.StaticProviderRegistry{},
+		Constraints:             constraints.MustParse("mem=4G"),
+		Users: []state.UserAccessSpec{{
+			User:        names.NewUserTag("bob"),
+			CreatedBy:   names.NewUserTag("admin"),
+			DisplayName: "bob",
+			Access:      permission.WriteAccess,
+		}},
+		FirewallRules: []state.FirewallRule{
+			state.NewFirewallRule(firewall.SSHRule, []string{"10.0.0.0/8"}),
+		},
+		Spaces: []string{"db", "public"},
+		Blocks: map[state.BlockType]string{
+			state.DestroyBlock: "keep me",
+			state.RemoveBlock:  "",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	cons, err := st.ModelConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cons, jc.DeepEquals, constraints.MustParse("mem=4G"))
+
+	access, err := st.UserAccess(names.NewUserTag("bob"), names.NewModelTag(uuid))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(access.Access, gc.Equals, permission.WriteAccess)
+	c.Check(access.CreatedBy, gc.Equals, names.NewUserTag("admin"))
+
+	rule, err := state.NewFirewallRules(st).Rule(firewall.SSHRule)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rule.WhitelistCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	spaces, err := st.AllSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+	spaceNames := make([]string, len(spaces))
+	for i, space := range spaces {
+		spaceNames[i] = space.Name()
+	}
+	c.Check(spaceNames, jc.SameContents, []string{network.AlphaSpaceName, "db", "public"})
+
+	// Spaces added later continue the space sequence.
+	_, err = st.AddSpace("later", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	blocks, err := st.AllBlocks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blocks, gc.HasLen, 2)
+	block, found, err := st.GetBlockForType(state.DestroyBlock)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsTrue)
+	c.Check(block.Message(), gc.Equals, "keep me")
+
+	// Blocks added later continue the block sequence.
+	err = st.SwitchBlockOn(state.ChangeBlock, "")
+	c.Assert(err, jc.ErrorIsNil)
+	blocks, err = st.AllBlocks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blocks, gc.HasLen, 3)
+}
+
+func (s *ModelTemplateSuite) TestNewModelUnknownUser(c *gc.C) {
+	cfg, _ := createTestModelConfig(c, s.State.ControllerUUID())
+	_, _, err := s.Controller.NewModel(state.ModelArgs{
+		Type:                    state.ModelTypeIAAS,
+		CloudName:               "dummy",
+		CloudRegion:             "dummy-region",
+		Config:                  cfg,
+		Owner:                   names.NewUserTag("test@remote"),
+		StorageProviderRegistry: storage.StaticProviderRegistry{},
+		Users: []state.UserAccessSpec{{
+			User:   names.NewUserTag("nobody"),
+			Access: permission.ReadAccess,
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot create model: user "nobody" not found`)
+}
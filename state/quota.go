@@ -0,0 +1,393 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/quota"
+)
+
+// quotaDoc records the resource limits of a model, or of all of the
+// models owned by a user. Zero values mean that the resource is not
+// limited.
+type quotaDoc struct {
+	DocID        string `bson:"_id"`
+	Machines     int    `bson:"machines"`
+	Units        int    `bson:"units"`
+	Applications int    `bson:"applications"`
+	StorageMiB   uint64 `bson:"storage-mib"`
+}
+
+func modelQuotaKey(modelUUID string) string {
+	return "model#" + modelUUID
+}
+
+func userQuotaKey(userID string) string {
+	return "user#" + strings.ToLower(userID)
+}
+
+// Quota returns the resource limits of the model.
+func (m *Model) Quota() (quota.Resources, error) {
+	return getQuota(m.st.db(), modelQuotaKey(m.UUID()))
+}
+
+// SetQuota sets the resource limits of the model. Zero values
+// remove the limit on that resource.
+func (m *Model) SetQuota(limits quota.Resources) error {
+	return errors.Trace(setQuota(m.st.db(), modelQuotaKey(m.UUID()), limits))
+}
+
+// QuotaUsage returns the resources used by the model.
+func (m *Model) QuotaUsage() (quota.Resources, error) {
+	return quotaUsage(m.st.db(), []string{m.UUID()})
+}
+
+// UserQuota returns the resource limits of all of the models
+// owned by the user.
+func (st *State) UserQuota(user names.UserTag) (quota.Resources, error) {
+	return getQuota(st.db(), userQuotaKey(user.Id()))
+}
+
+// SetUserQuota sets the resource limits of all of the models owned by
+// the user. Zero values remove the limit on that resource.
+func (st *State) SetUserQuota(user names.UserTag, limits quota.Resources) error {
+	return errors.Trace(setQuota(st.db(), userQuotaKey(user.Id()), limits))
+}
+
+// UserQuotaUsage returns the resources used by all of the
+// models owned by the user.
+func (st *State) UserQuotaUsage(user names.UserTag) (quota.Resources, error) {
+	modelUUIDs, err := ownedModelUUIDs(st.db(), user.Id())
+	if err != nil {
+		return quota.Resources{}, errors.Trace(err)
+	}
+	return quotaUsage(st.db(), modelUUIDs)
+}
+
+func getQuota(db Database, key string) (quota.Resources, error) {
+	coll, closer := db.GetCollection(quotasC)
+	defer closer()
+
+	var doc quotaDoc
+	if err := coll.FindId(key).One(&doc); err == mgo.ErrNotFound {
+		return quota.Resources{}, nil
+	} else if err != nil {
+		return quota.Resources{}, errors.Annotate(err, "getting quota")
+	}
+	return quota.Resources{
+		Machines:     doc.Machines,
+		Units:        doc.Units,
+		Applications: doc.Applications,
+		StorageMiB:   doc.StorageMiB,
+	}, nil
+}
+
+func setQuota(db Database, key string, limits quota.Resources) error {
+	if limits.Machines < 0 || limits.Units < 0 || limits.Applications < 0 {
+		return errors.NotValidf("negative quota")
+	}
+	doc := quotaDoc{
+		DocID:        key,
+		Machines:     limits.Machines,
+		Units:        limits.Units,
+		Applications: limits.Applications,
+		StorageMiB:   limits.StorageMiB,
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		coll, closer := db.GetCollection(quotasC)
+		defer closer()
+
+		n, err := coll.FindId(key).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch {
+		case n == 0 && limits.IsZero():
+			return nil, jujutxn.ErrNoOperations
+		case n == 0:
+			return []txn.Op{{
+				C:      quotasC,
+				Id:     key,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			}}, nil
+		case limits.IsZero():
+			return []txn.Op{{
+				C:      quotasC,
+				Id:     key,
+				Assert: txn.DocExists,
+				Remove: true,
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      quotasC,
+			Id:     key,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"machines", doc.Machines},
+				{"units", doc.Units},
+				{"applications", doc.Applications},
+				{"storage-mib", doc.StorageMiB},
+			}}},
+		}}, nil
+	}
+	return errors.Trace(db.Run(buildTxn))
+}
+
+// quotaUsage returns the resources used by the models. Dead entities,
+// which are about to be removed, and subordinate units, which don't
+// take resources of their own, are not counted.
+func quotaUsage(db Database, modelUUIDs []string) (quota.Resources, error) {
+	var usage quota.Resources
+	if len(modelUUIDs) == 0 {
+		return usage, nil
+	}
+	sel := bson.D{
+		{"model-uuid", bson.D{{"$in", modelUUIDs}}},
+		{"life", bson.D{{"$ne", Dead}}},
+	}
+	count := func(name string, sel bson.D) (int, error) {
+		coll, closer := db.GetRawCollection(name)
+		defer closer()
+		n, err := coll.Find(sel).Count()
+		return n, errors.Annotatef(err, "counting %s", name)
+	}
+
+	var err error
+	if usage.Machines, err = count(machinesC, sel); err != nil {
+		return quota.Resources{}, errors.Trace(err)
+	}
+	principals := append(bson.D{{"principal", ""}}, sel...)
+	if usage.Units, err = count(unitsC, principals); err != nil {
+		return quota.Resources{}, errors.Trace(err)
+	}
+	if usage.Applications, err = count(applicationsC, sel); err != nil {
+		return quota.Resources{}, errors.Trace(err)
+	}
+
+	coll, closer := db.GetRawCollection(storageInstancesC)
+	defer closer()
+	var doc storageInstanceDoc
+	iter := coll.Find(sel).Select(bson.D{{"constraints.size", 1}}).Iter()
+	for iter.Next(&doc) {
+		usage.StorageMiB += doc.Constraints.Size
+	}
+	if err := iter.Close(); err != nil {
+		return quota.Resources{}, errors.Annotate(err, "summing storage")
+	}
+	return usage, nil
+}
+
+// ownedModelUUIDs returns the UUIDs of the models owned by the user.
+func ownedModelUUIDs(db Database, userID string) ([]string, error) {
+	models, closer := db.GetCollection(modelsC)
+	defer closer()
+
+	var docs []struct {
+		UUID string `bson:"_id"`
+	}
+	err := models.Find(bson.D{{"owner", userID}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting owned models")
+	}
+	uuids := make([]string, len(docs))
+	for i, doc := range docs {
+		uuids[i] = doc.UUID
+	}
+	return uuids, nil
+}
+
+// quotaUsageDoc records the resources used by a model, or by all of
+// the models owned by a user, as of the last transaction that added
+// resources to them while they had a quota. Removals don't update it,
+// so it may count more than is in use.
+type quotaUsageDoc struct {
+	DocID        string `bson:"_id"`
+	TxnRevno     int64  `bson:"txn-revno"`
+	Machines     int    `bson:"machines"`
+	Units        int    `bson:"units"`
+	Applications int    `bson:"applications"`
+	StorageMiB   uint64 `bson:"storage-mib"`
+}
+
+func (doc quotaUsageDoc) resources() quota.Resources {
+	return quota.Resources{
+		Machines:     doc.Machines,
+		Units:        doc.Units,
+		Applications: doc.Applications,
+		StorageMiB:   doc.StorageMiB,
+	}
+}
+
+func quotaUsageKey(quotaKey string) string {
+	return "usage#" + quotaKey
+}
+
+// quotaOps returns the operations that record the requested resources
+// against the usage of the model, and of the models owned by the
+// model's owner, where they have quotas. The operations assert that
+// the recorded usage leaves room for the requested resources, so that
+// concurrent transactions can't together exceed the limits. An error
+// satisfying errors.IsQuotaLimitExceeded is returned if the resources
+// in use leave no room for those requested.
+func quotaOps(db Database, modelUUID string, requested quota.Resources) ([]txn.Op, error) {
+	models, closer := db.GetCollection(modelsC)
+	defer closer()
+
+	var doc struct {
+		Name  string `bson:"name"`
+		Owner string `bson:"owner"`
+	}
+	if err := models.FindId(modelUUID).One(&doc); err == mgo.ErrNotFound {
+		// The transactions adding resources assert
+		// that the model is alive, so leave it to them.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var ops []txn.Op
+	modelOps, err := scopeQuotaOps(
+		db, modelQuotaKey(modelUUID), fmt.Sprintf("model %q", doc.Name), requested,
+		func() ([]string, error) { return []string{modelUUID}, nil },
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, modelOps...)
+
+	userOps, err := scopeQuotaOps(
+		db, userQuotaKey(doc.Owner), fmt.Sprintf("user %q", doc.Owner), requested,
+		func() ([]string, error) { return ownedModelUUIDs(db, doc.Owner) },
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, userOps...), nil
+}
+
+// scopeQuotaOps returns the operations that record the requested
+// resources against the usage of the quota with the given key, which
+// limits the resources of the models with the UUIDs returned by
+// modelUUIDs. No operations are returned if there is no such quota.
+func scopeQuotaOps(
+	db Database, key, scope string, requested quota.Resources, modelUUIDs func() ([]string, error),
+) ([]txn.Op, error) {
+	limits, err := getQuota(db, key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if limits.IsZero() {
+		return nil, nil
+	}
+	uuids, err := modelUUIDs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	inUse, err := quotaUsage(db, uuids)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := quota.CheckResources(scope, limits, inUse, requested); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	coll, closer := db.GetCollection(quotasC)
+	defer closer()
+
+	usageKey := quotaUsageKey(key)
+	total := inUse.Add(requested)
+	var doc quotaUsageDoc
+	if err := coll.FindId(usageKey).One(&doc); err == mgo.ErrNotFound {
+		return []txn.Op{{
+			C:      quotasC,
+			Id:     usageKey,
+			Assert: txn.DocMissing,
+			Insert: quotaUsageDoc{
+				DocID:        usageKey,
+				Machines:     total.Machines,
+				Units:        total.Units,
+				Applications: total.Applications,
+				StorageMiB:   total.StorageMiB,
+			},
+		}}, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if doc.resources() != inUse {
+		// Resources have been removed since the usage was last
+		// recorded, so record it afresh, provided that nothing
+		// has been added in the meantime.
+		return []txn.Op{{
+			C:      quotasC,
+			Id:     usageKey,
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{
+				{"machines", total.Machines},
+				{"units", total.Units},
+				{"applications", total.Applications},
+				{"storage-mib", total.StorageMiB},
+			}}},
+		}}, nil
+	}
+
+	// Only the limited resources that are requested are asserted,
+	// as with quota.CheckResources.
+	assert := bson.D{}
+	if requested.Machines > 0 && limits.Machines > 0 {
+		assert = append(assert, bson.DocElem{"machines", bson.D{{"$lt", limits.Machines - requested.Machines + 1}}})
+	}
+	if requested.Units > 0 && limits.Units > 0 {
+		assert = append(assert, bson.DocElem{"units", bson.D{{"$lt", limits.Units - requested.Units + 1}}})
+	}
+	if requested.Applications > 0 && limits.Applications > 0 {
+		assert = append(assert, bson.DocElem{"applications", bson.D{{"$lt", limits.Applications - requested.Applications + 1}}})
+	}
+	if requested.StorageMiB > 0 && limits.StorageMiB > 0 {
+		assert = append(assert, bson.DocElem{"storage-mib", bson.D{{"$lt", limits.StorageMiB - requested.StorageMiB + 1}}})
+	}
+	if len(assert) == 0 {
+		return nil, nil
+	}
+	return []txn.Op{{
+		C:      quotasC,
+		Id:     usageKey,
+		Assert: assert,
+		Update: bson.D{{"$inc", bson.D{
+			{"machines", requested.Machines},
+			{"units", requested.Units},
+			{"applications", requested.Applications},
+			{"storage-mib", requested.StorageMiB},
+		}}},
+	}}, nil
+}
+
+// checkQuota returns an error satisfying errors.IsQuotaLimitExceeded if
+// adding the requested resources to the model would exceed the limits of
+// the model, or of the models owned by the model's owner. It is used to
+// explain why a transaction including quotaOps was aborted.
+func checkQuota(db Database, modelUUID string, requested quota.Resources) error {
+	_, err := quotaOps(db, modelUUID, requested)
+	return errors.Trace(err)
+}
+
+// storageQuotaSize returns the total size, in MiB, of the storage
+// described by the constraints.
+func storageQuotaSize(cons map[string]StorageConstraints) uint64 {
+	var size uint64
+	for _, c := range cons {
+		size += c.Size * c.Count
+	}
+	return size
+}
@@ -0,0 +1,253 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/quota"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type QuotaSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&QuotaSuite{})
+
+func (s *QuotaSuite) TestModelQuota(c *gc.C) {
+	limits, err := s.Model.Quota()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(limits.IsZero(), jc.IsTrue)
+
+	expected := quota.Resources{Machines: 2, Units: 3, Applications: 1, StorageMiB: 4096}
+	c.Assert(s.Model.SetQuota(expected), jc.ErrorIsNil)
+	limits, err = s.Model.Quota()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(limits, gc.Equals, expected)
+
+	expected = quota.Resources{Units: 5}
+	c.Assert(s.Model.SetQuota(expected), jc.ErrorIsNil)
+	limits, err = s.Model.Quota()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(limits, gc.Equals, expected)
+
+	// Zero limits remove the quota.
+	c.Assert(s.Model.SetQuota(quota.Resources{}), jc.ErrorIsNil)
+	limits, err = s.Model.Quota()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(limits.IsZero(), jc.IsTrue)
+	c.Assert(s.Model.SetQuota(quota.Resources{}), jc.ErrorIsNil)
+}
+
+func (s *QuotaSuite) TestSetQuotaNegative(c *gc.C) {
+	err := s.Model.SetQuota(quota.Resources{Machines: -1})
+	c.Assert(err, gc.ErrorMatches, "negative quota not valid")
+}
+
+func (s *QuotaSuite) TestQuotaUsage(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	app := s.AddTestingApplicationWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop", 1024, 1),
+	})
+	for i := 0; i < 2; i++ {
+		_, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.Factory.MakeMachine(c, nil)
+
+	usage, err := s.Model.QuotaUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage, gc.Equals, quota.Resources{
+		Machines:     1,
+		Units:        2,
+		Applications: 1,
+		StorageMiB:   2048,
+	})
+}
+
+func (s *QuotaSuite) TestAddMachineExceedsQuota(c *gc.C) {
+	c.Assert(s.Model.SetQuota(quota.Resources{Machines: 2}), jc.ErrorIsNil)
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddMachines(
+		state.MachineTemplate{Series: "quantal", Jobs: []state.MachineJob{state.JobHostUnits}},
+		state.MachineTemplate{Series: "quantal", Jobs: []state.MachineJob{state.JobHostUnits}},
+	)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: model "testmodel" quota of 2 machines exceeded`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsQuotaLimitExceeded)
+
+	_, err = s.State.AddMachineInsideNewMachine(
+		state.MachineTemplate{Series: "quantal", Jobs: []state.MachineJob{state.JobHostUnits}},
+		state.MachineTemplate{Series: "quantal", Jobs: []state.MachineJob{state.JobHostUnits}},
+		instance.LXD,
+	)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: model "testmodel" quota of 2 machines exceeded`)
+
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *QuotaSuite) TestAddUnitExceedsQuota(c *gc.C) {
+	c.Assert(s.Model.SetQuota(quota.Resources{Units: 1}), jc.ErrorIsNil)
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	_, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "wordpress": model "testmodel" quota of 1 units exceeded`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsQuotaLimitExceeded)
+}
+
+func (s *QuotaSuite) TestAssignToNewMachineExceedsQuota(c *gc.C) {
+	c.Assert(s.Model.SetQuota(quota.Resources{Machines: 1}), jc.ErrorIsNil)
+	s.Factory.MakeMachine(c, nil)
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = unit.AssignToNewMachine()
+	c.Assert(err, gc.ErrorMatches, `.*model "testmodel" quota of 1 machines exceeded`)
+}
+
+func (s *QuotaSuite) TestAddApplicationExceedsQuota(c *gc.C) {
+	c.Assert(s.Model.SetQuota(quota.Resources{Applications: 1}), jc.ErrorIsNil)
+	ch := s.AddTestingCharm(c, "wordpress")
+	s.AddTestingApplication(c, "wordpress", ch)
+
+	_, err := s.State.AddApplication(state.AddApplicationArgs{Name: "wordpress2", Charm: ch})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "wordpress2": model "testmodel" quota of 1 applications exceeded`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsQuotaLimitExceeded)
+}
+
+func (s *QuotaSuite) TestAddStorageExceedsQuota(c *gc.C) {
+	c.Assert(s.Model.SetQuota(quota.Resources{StorageMiB: 1536}), jc.ErrorIsNil)
+	ch := s.AddTestingCharm(c, "storage-block")
+	app := s.AddTestingApplicationWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop", 1024, 1),
+	})
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Adding a unit would add another 1024MiB of storage.
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, gc.ErrorMatches, `.*model "testmodel" quota of 1536MiB storage exceeded`)
+
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = sb.AddStorageForUnit(unit.UnitTag(), "allecto", makeStorageCons("loop", 1024, 1))
+	c.Assert(err, gc.ErrorMatches, `.*model "testmodel" quota of 1536MiB storage exceeded`)
+	_, err = sb.AddStorageForUnit(unit.UnitTag(), "allecto", makeStorageCons("loop", 512, 1))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *QuotaSuite) TestUserQuota(c *gc.C) {
+	bob := names.NewUserTag("bob")
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	c.Assert(s.State.SetUserQuota(bob, quota.Resources{Machines: 2}), jc.ErrorIsNil)
+	limits, err := s.State.UserQuota(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(limits, gc.Equals, quota.Resources{Machines: 2})
+
+	// The quota applies to all of the models bob owns.
+	st1 := s.Factory.MakeModel(c, &factory.ModelParams{Name: "one", Owner: bob})
+	defer st1.Close()
+	st2 := s.Factory.MakeModel(c, &factory.ModelParams{Name: "two", Owner: bob})
+	defer st2.Close()
+
+	_, err = st1.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st2.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st2.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: user "bob" quota of 2 machines exceeded`)
+
+	usage, err := s.State.UserQuotaUsage(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage, gc.Equals, quota.Resources{Machines: 2})
+
+	// Other users' models aren't affected.
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *QuotaSuite) TestQuotaUsageExcludesDeadAndSubordinates(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err := s.State.InferEndpoints("logging", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ru.EnterScope(nil), jc.ErrorIsNil)
+
+	s.Factory.MakeMachine(c, nil)
+	dead := s.Factory.MakeMachine(c, nil)
+	c.Assert(dead.EnsureDead(), jc.ErrorIsNil)
+
+	usage, err := s.Model.QuotaUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage, gc.Equals, quota.Resources{
+		Machines:     1,
+		Units:        1,
+		Applications: 2,
+	})
+}
+
+func (s *QuotaSuite) TestConcurrentAddMachineExceedsQuota(c *gc.C) {
+	c.Assert(s.Model.SetQuota(quota.Resources{Machines: 2}), jc.ErrorIsNil)
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: model "testmodel" quota of 2 machines exceeded`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsQuotaLimitExceeded)
+
+	usage, err := s.Model.QuotaUsage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(usage.Machines, gc.Equals, 2)
+}
+
+func (s *QuotaSuite) TestConcurrentAddUnitExceedsQuota(c *gc.C) {
+	c.Assert(s.Model.SetQuota(quota.Resources{Units: 1}), jc.ErrorIsNil)
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "wordpress": model "testmodel" quota of 1 units exceeded`)
+}
+
+func (s *QuotaSuite) TestAddMachineAfterRemoval(c *gc.C) {
+	c.Assert(s.Model.SetQuota(quota.Resources{Machines: 2}), jc.ErrorIsNil)
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Dead machines no longer count against the quota.
+	c.Assert(m.EnsureDead(), jc.ErrorIsNil)
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: model "testmodel" quota of 2 machines exceeded`)
+}
@@ -461,7 +461,7 @@ func (r *Relation) removeOps(ignoreApplication string, departingUnitName string,
 	} else {
 		relOp.Assert = bson.D{{"life", Alive}, {"unitcount", 0}}
 	}
-	ops := []txn.Op{relOp}
+	ops := []txn.Op{relOp, removeEntityBlockOp(r.st, r.Tag())}
 	for _, ep := range r.doc.Endpoints {
 		if ep.ApplicationName == ignoreApplication {
 			continue
@@ -60,6 +60,22 @@ func (st rawState) Units(applicationID string) (tags []names.UnitTag, err error)
 	return tags, nil
 }
 
+// BranchPendingResourceID returns the pending ID of the named resource
+// staged for the application with a charm upgrade under the branch that
+// the unit tracks, or an empty string if there is no such resource.
+func (st rawState) BranchPendingResourceID(unitName, applicationID, name string) (string, error) {
+	m, err := st.base.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	branch, err := m.unitBranch(unitName)
+	if err != nil || branch == nil {
+		return "", errors.Trace(err)
+	}
+	staged, _ := branch.CharmFor(applicationID)
+	return staged.ResourceIDs[name], nil
+}
+
 // VerifyApplication implements resource/state.RawState.
 func (st rawState) VerifyApplication(id string) error {
 	app, err := st.base.Application(id)
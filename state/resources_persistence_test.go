@@ -188,6 +188,23 @@ func (s *ResourcePersistenceSuite) TestGetResourceOkay(c *gc.C) {
 	c.Check(storagePath, gc.Equals, expected.storagePath)
 }
 
+func (s *ResourcePersistenceSuite) TestGetPendingResourceOkay(c *gc.C) {
+	expected, doc := newPersistenceResource(c, "a-application", "spam")
+	expected.PendingID = "some-unique-ID"
+	doc.DocID += "#pending-some-unique-ID"
+	doc.PendingID = "some-unique-ID"
+	s.base.ReturnOne = doc
+	p := NewResourcePersistence(s.base)
+
+	res, storagePath, err := p.GetPendingResource("a-application/spam", "some-unique-ID")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "One")
+	s.stub.CheckCall(c, 0, "One", "resources", "resource#a-application/spam#pending-some-unique-ID", &doc)
+	c.Check(res, jc.DeepEquals, expected.Resource)
+	c.Check(storagePath, gc.Equals, expected.storagePath)
+}
+
 func (s *ResourcePersistenceSuite) TestStageResourceOkay(c *gc.C) {
 	res, doc := newPersistenceResource(c, "a-application", "spam")
 	doc.DocID += "#staged"
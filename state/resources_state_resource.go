@@ -34,6 +34,10 @@ type resourcePersistence interface {
 	// non-pending resource.
 	GetResource(id string) (res resource.Resource, storagePath string, _ error)
 
+	// GetPendingResource returns the extended, model-related info for
+	// the pending resource with the given pending ID.
+	GetPendingResource(id, pendingID string) (res resource.Resource, storagePath string, _ error)
+
 	// StageResource adds the resource in a separate staging area
 	// if the resource isn't already staged. If the resource already
 	// exists then it is treated as unavailable as long as the new one
@@ -376,7 +380,21 @@ func (st resourceState) OpenResourceForUniter(unit resource.Unit, name string) (
 		return resource.Resource{}, nil, errors.Trace(err)
 	}
 
-	resourceInfo, resourceReader, err := st.OpenResource(applicationID, name)
+	// A unit tracking a branch gets the revision of the resource staged
+	// with a charm upgrade under the branch, if there is one.
+	branchPendingID, err := st.raw.BranchPendingResourceID(unit.Name(), applicationID, name)
+	if err != nil {
+		return resource.Resource{}, nil, errors.Trace(err)
+	}
+	var (
+		resourceInfo   resource.Resource
+		resourceReader io.ReadCloser
+	)
+	if branchPendingID != "" {
+		resourceInfo, resourceReader, err = st.openPendingResource(applicationID, name, branchPendingID)
+	} else {
+		resourceInfo, resourceReader, err = st.OpenResource(applicationID, name)
+	}
 	if err != nil {
 		return resource.Resource{}, nil, errors.Trace(err)
 	}
@@ -401,6 +419,31 @@ func (st resourceState) OpenResourceForUniter(unit resource.Unit, name string) (
 	return resourceInfo, resourceReader, nil
 }
 
+// openPendingResource returns metadata about the identified pending file
+// resource and a reader for its data. The returned metadata is that of
+// the resource as it will be once activated.
+func (st resourceState) openPendingResource(applicationID, name, pendingID string) (resource.Resource, io.ReadCloser, error) {
+	id := newResourceID(applicationID, name)
+	resourceInfo, storagePath, err := st.persist.GetPendingResource(id, pendingID)
+	if err != nil {
+		return resource.Resource{}, nil, errors.Annotate(err, "while getting pending resource info")
+	}
+	if resourceInfo.Type != charmresource.TypeFile {
+		return resource.Resource{}, nil, errors.NotSupportedf("%s resource %q in a branch", resourceInfo.Type, name)
+	}
+	resourceReader, resSize, err := st.storage.Get(storagePath)
+	if err != nil {
+		return resource.Resource{}, nil, errors.Annotate(err, "while retrieving resource data")
+	}
+	if resSize != resourceInfo.Size {
+		resourceReader.Close()
+		msg := "storage returned a size (%d) which doesn't match resource metadata (%d)"
+		return resource.Resource{}, nil, errors.Errorf(msg, resSize, resourceInfo.Size)
+	}
+	resourceInfo.PendingID = ""
+	return resourceInfo, resourceReader, nil
+}
+
 // SetCharmStoreResources sets the "polled" resources for the
 // application to the provided values.
 func (st resourceState) SetCharmStoreResources(applicationID string, info []charmresource.Resource, lastPolled time.Time) error {
@@ -32,6 +32,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	coreannotations "github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
@@ -39,6 +40,7 @@ import (
 	"github.com/juju/juju/core/network"
 	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/core/raftlease"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/mongo"
@@ -1213,12 +1215,22 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 				return nil, errSameNameRemoteApplicationExists
 			}
 		}
+		// The quota ops assert that the model's recorded usage
+		// leaves room for the application and its units.
+		usageOps, err := quotaOps(st.db(), st.ModelUUID(), quota.Resources{
+			Applications: 1,
+			Units:        args.NumUnits,
+			StorageMiB:   storageQuotaSize(args.Storage) * uint64(args.NumUnits),
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 		// The addApplicationOps does not include the model alive assertion,
 		// so we add it here.
-		ops := []txn.Op{
+		ops := append([]txn.Op{
 			assertModelActiveOp(st.ModelUUID()),
 			endpointBindingsOp,
-		}
+		}, usageOps...)
 		addOps, err := addApplicationOps(st, app, addApplicationOpsArgs{
 			applicationDoc:    appDoc,
 			statusDoc:         statusDoc,
@@ -1613,11 +1625,80 @@ func (st *State) parsePlacement(placement *instance.Placement) (*placementData,
 		return &placementData{directive: placement.Directive}, nil
 	case instance.MachineScope:
 		return &placementData{machineId: placement.Directive}, nil
+	case instance.LabelScope:
+		machineId, err := st.labelledMachineId(placement.Directive)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &placementData{machineId: machineId}, nil
 	default:
 		return nil, errors.Errorf("placement scope: invalid model UUID %q", placement.Scope)
 	}
 }
 
+// labelledMachineId returns the id of the alive machine, among those
+// whose annotations match the selector, that hosts the fewest units.
+func (st *State) labelledMachineId(in string) (string, error) {
+	selector, err := coreannotations.ParseSelector(in)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	ids, err := st.labelledMachineIds(selector.Terms())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var (
+		machineId string
+		fewest    int
+	)
+	for _, id := range ids {
+		machine, err := st.Machine(id)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return "", errors.Trace(err)
+		}
+		if machine.Life() != Alive {
+			continue
+		}
+		units, err := machine.Units()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		if machineId == "" || len(units) < fewest {
+			machineId, fewest = machine.Id(), len(units)
+		}
+	}
+	if machineId == "" {
+		return "", errors.NotFoundf("machine matching selector %q", selector)
+	}
+	return machineId, nil
+}
+
+// labelledMachineIds returns the ids of the machines annotated with all
+// of the given key=value labels.
+func (st *State) labelledMachineIds(labels []string) ([]string, error) {
+	selector, err := coreannotations.SelectorFromLabels(labels)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tags, err := m.LabelledEntities(selector)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag.Kind() == names.MachineTagKind {
+			ids = append(ids, tag.Id())
+		}
+	}
+	return ids, nil
+}
+
 // addMachineWithPlacement finds a machine that matches the given
 // placement directive for the given unit.
 func (st *State) addMachineWithPlacement(unit *Unit, data *placementData) (*Machine, error) {
@@ -32,6 +32,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller"
+	coreannotations "github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
@@ -955,6 +956,25 @@ func (s *StateSuite) TestAddMachineExtraConstraints(c *gc.C) {
 	c.Assert(mcons, gc.DeepEquals, expectedCons)
 }
 
+func (s *StateSuite) TestAddMachineLabelConstraints(c *gc.C) {
+	err := s.State.SetModelConstraints(constraints.MustParse("labels=team=payments"))
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:      "quantal",
+		Constraints: constraints.MustParse("cores=4"),
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The new machine is annotated with the labels, so it satisfies them.
+	annotations, err := s.Model.Annotations(m)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(annotations, jc.DeepEquals, map[string]string{"team": "payments"})
+	tags, err := s.Model.LabelledEntities(coreannotations.Selector{"team": "payments"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, jc.DeepEquals, []names.Tag{m.Tag()})
+}
+
 func (s *StateSuite) TestAddMachinePlacementIgnoresModelConstraints(c *gc.C) {
 	err := s.State.SetModelConstraints(constraints.MustParse("mem=4G tags=foo"))
 	c.Assert(err, jc.ErrorIsNil)
@@ -19,6 +19,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
@@ -2146,6 +2147,12 @@ func (sb *storageBackend) addStorageForUnitOps(
 	if cons.Count == 0 {
 		return nil, nil, errors.NotValidf("adding storage where instance count is 0")
 	}
+	requested := quota.Resources{StorageMiB: cons.Size * cons.Count}
+	usageOps, err := quotaOps(sb.mb.db(), sb.mb.modelUUID(), requested)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	ops = append(ops, usageOps...)
 
 	tags, addUnitStorageOps, err := sb.addUnitStorageOps(charmMeta, u, storageName, cons, -1)
 	if err != nil {
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/quota"
 	"github.com/juju/juju/core/status"
 	mgoutils "github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/network"
@@ -1945,10 +1946,18 @@ func (u *Unit) assignToNewMachineOps(
 	template.principals = []string{u.doc.Name}
 	template.Dirty = true
 
+	newMachines := quota.Resources{Machines: 1}
+	if parentId == "" && containerType != "" {
+		newMachines.Machines = 2
+	}
+	usageOps, err := quotaOps(u.st.db(), u.st.ModelUUID(), newMachines)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
 	var (
 		mdoc *machineDoc
 		ops  []txn.Op
-		err  error
 	)
 	switch {
 	case parentId == "" && containerType == "":
@@ -1968,6 +1977,7 @@ func (u *Unit) assignToNewMachineOps(
 	if err != nil {
 		return nil, nil, err
 	}
+	ops = append(ops, usageOps...)
 
 	// Ensure the host machine is really clean.
 	if parentId != "" {
@@ -2461,12 +2471,22 @@ func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value)
 		omitMachineIds = append(omitMachineIds, cIds...)
 	}
 
+	machineIdTerm := bson.D{{"$nin", omitMachineIds}}
+	// Only machines annotated with all of the labels are suitable.
+	if cons.HasLabels() {
+		labelledIds, err := u.st.labelledMachineIds(*cons.Labels)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineIdTerm = append(machineIdTerm, bson.DocElem{"$in", labelledIds})
+	}
+
 	terms := bson.D{
 		{"life", Alive},
 		{"series", u.doc.Series},
 		{"jobs", []MachineJob{JobHostUnits}},
 		{"clean", true},
-		{"machineid", bson.D{{"$nin", omitMachineIds}}},
+		{"machineid", machineIdTerm},
 	}
 	// Add the container filter term if necessary.
 	var containerType instance.ContainerType
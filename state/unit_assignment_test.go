@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
 )
 
 type UnitAssignmentSuite struct {
@@ -221,6 +222,60 @@ func (s *UnitAssignmentSuite) TestAssignUnitWithPlacementDirective(c *gc.C) {
 	c.Assert(machine.Placement(), gc.Equals, "zone=test")
 }
 
+func (s *UnitAssignmentSuite) TestAssignUnitWithLabelPlacement(c *gc.C) {
+	busy, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	idle, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	unlabelled, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Machine: busy})
+
+	for _, m := range []*state.Machine{busy, idle} {
+		err := s.Model.SetAnnotations(m, map[string]string{"team": "payments"})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// As in --to label:team=payments
+	charm := s.AddTestingCharm(c, "dummy")
+	placement := instance.Placement{Scope: instance.LabelScope, Directive: "team=payments"}
+	app, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:      "dummy",
+		Charm:     charm,
+		NumUnits:  1,
+		Placement: []*instance.Placement{&placement},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	unit := units[0]
+
+	err = s.State.AssignUnitWithPlacement(unit, &placement)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The labelled machine with the fewest units is chosen.
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, idle.Id())
+	c.Assert(machineId, gc.Not(gc.Equals), unlabelled.Id())
+}
+
+func (s *UnitAssignmentSuite) TestAddApplicationLabelPlacementNoMatch(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	charm := s.AddTestingCharm(c, "dummy")
+	_, err = s.State.AddApplication(state.AddApplicationArgs{
+		Name:      "dummy",
+		Charm:     charm,
+		NumUnits:  1,
+		Placement: []*instance.Placement{{Scope: instance.LabelScope, Directive: "team=payments"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `.*machine matching selector "team=payments" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *UnitAssignmentSuite) TestAssignUnitCleanMachineUpgradeSeriesLockError(c *gc.C) {
 	s.addLockedMachine(c, true)
 
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller"
+	coreannotations "github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/status"
@@ -2961,3 +2962,39 @@ func AddCharmhubToModelConfig(pool *StatePool) error {
 		return false, nil
 	}))
 }
+
+// AddAnnotationLabels adds the indexed labels field to annotation
+// documents created before annotations could be used as selectors.
+func AddAnnotationLabels(pool *StatePool) error {
+	st := pool.SystemState()
+	coll, closer := st.db().GetRawCollection(annotationsC)
+	defer closer()
+
+	var ops []txn.Op
+	iter := coll.Find(bson.D{{"labels", bson.D{{"$exists", false}}}}).Iter()
+	defer iter.Close()
+	for {
+		var doc bson.M
+		if !iter.Next(&doc) {
+			break
+		}
+		annotations := make(map[string]string)
+		if raw, ok := doc["annotations"].(bson.M); ok {
+			for key, value := range raw {
+				if value, ok := value.(string); ok {
+					annotations[key] = value
+				}
+			}
+		}
+		ops = append(ops, txn.Op{
+			C:      annotationsC,
+			Id:     doc["_id"],
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"labels", coreannotations.Labels(annotations)}}}},
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(st.runRawTransaction(ops))
+}
@@ -4447,3 +4447,57 @@ type docById []bson.M
 func (d docById) Len() int           { return len(d) }
 func (d docById) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
 func (d docById) Less(i, j int) bool { return d[i]["_id"].(string) < d[j]["_id"].(string) }
+
+func (s *upgradesSuite) TestAddAnnotationLabels(c *gc.C) {
+	uuid := utils.MustNewUUID().String()
+
+	coll, closer := s.state.db().GetRawCollection(annotationsC)
+	defer closer()
+
+	err := coll.Insert(bson.M{
+		"_id":         uuid + ":a#mysql",
+		"model-uuid":  uuid,
+		"globalkey":   "a#mysql",
+		"tag":         "application-mysql",
+		"annotations": bson.M{"team": "payments", "env": "prod"},
+	}, bson.M{
+		"_id":         uuid + ":m#0",
+		"model-uuid":  uuid,
+		"globalkey":   "m#0",
+		"tag":         "machine-0",
+		"annotations": bson.M{},
+	}, bson.M{
+		"_id":         uuid + ":m#1",
+		"model-uuid":  uuid,
+		"globalkey":   "m#1",
+		"tag":         "machine-1",
+		"annotations": bson.M{"team": "search"},
+		"labels":      []interface{}{"team=search"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := []bson.M{{
+		"_id":         uuid + ":a#mysql",
+		"model-uuid":  uuid,
+		"globalkey":   "a#mysql",
+		"tag":         "application-mysql",
+		"annotations": bson.M{"team": "payments", "env": "prod"},
+		"labels":      []interface{}{"env=prod", "team=payments"},
+	}, {
+		"_id":         uuid + ":m#0",
+		"model-uuid":  uuid,
+		"globalkey":   "m#0",
+		"tag":         "machine-0",
+		"annotations": bson.M{},
+		"labels":      []interface{}{},
+	}, {
+		"_id":         uuid + ":m#1",
+		"model-uuid":  uuid,
+		"globalkey":   "m#1",
+		"tag":         "machine-1",
+		"annotations": bson.M{"team": "search"},
+		"labels":      []interface{}{"team=search"},
+	}}
+	s.assertUpgradedData(c, AddAnnotationLabels,
+		upgradedDataWithFilter(coll, expected, bson.D{{"model-uuid", uuid}}))
+}
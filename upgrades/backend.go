@@ -85,6 +85,7 @@ type StateBackend interface {
 	AddBakeryConfig() error
 	ReplaceNeverSetWithUnset() error
 	AddCharmhubToModelConfig() error
+	AddAnnotationLabels() error
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -353,3 +354,7 @@ func (s stateBackend) ReplaceNeverSetWithUnset() error {
 func (s stateBackend) AddCharmhubToModelConfig() error {
 	return state.AddCharmhubToModelConfig(s.pool)
 }
+
+func (s stateBackend) AddAnnotationLabels() error {
+	return state.AddAnnotationLabels(s.pool)
+}
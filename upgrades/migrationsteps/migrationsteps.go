@@ -0,0 +1,77 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package migrationsteps holds the upgrade steps applied to a model
+// description exported by a controller running an older version of
+// Juju, so that the model can be migrated to a newer controller
+// without first being upgraded.
+//
+// The database upgrade steps in the upgrades package are run against
+// every model in a controller when the controller is upgraded, so a
+// model imported into an already upgraded controller would miss them.
+// Each step here makes the equivalent change to the model description
+// before it is imported.
+package migrationsteps
+
+import (
+	"github.com/juju/description/v2"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/version"
+
+	jujuversion "github.com/juju/juju/version"
+)
+
+var logger = loggo.GetLogger("juju.upgrade.migrationsteps")
+
+// step is an idempotent change made to a model description.
+type step struct {
+	description string
+	run         func(description.Model) error
+}
+
+// operation holds the steps needed to upgrade a model description
+// from any prior version of Juju to targetVersion.
+type operation struct {
+	targetVersion version.Number
+	steps         []step
+}
+
+// operations returns the model description upgrade operations, ordered
+// by target version.
+var operations = func() []operation {
+	return []operation{
+		{version.MustParse("2.9.0"), stepsFor29()},
+	}
+}
+
+// UpgradeModelDescription applies to the model description every step
+// needed to bring a model running the given agent version up to the
+// version of Juju running this controller, oldest first.
+func UpgradeModelDescription(from version.Number, model description.Model) error {
+	return errors.Trace(upgradeModelDescription(from, jujuversion.Current, operations(), model))
+}
+
+func upgradeModelDescription(from, to version.Number, ops []operation, model description.Model) error {
+	// As with the upgrades package, an unknown version is taken to be
+	// 1.16 and the steps for a pre-release of the target version are
+	// run if the model is on an earlier version.
+	if from == version.Zero {
+		from = version.MustParse("1.16.0")
+	}
+	if from.Compare(to) != 0 {
+		to.Tag = ""
+	}
+	for _, op := range ops {
+		if op.targetVersion.Compare(from) <= 0 || op.targetVersion.Compare(to) > 0 {
+			continue
+		}
+		for _, step := range op.steps {
+			logger.Infof("running model description upgrade step for %s: %s", op.targetVersion, step.description)
+			if err := step.run(model); err != nil {
+				return errors.Annotatef(err, "%s", step.description)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migrationsteps
+
+import (
+	stdtesting "testing"
+
+	"github.com/juju/description/v2"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/charmhub"
+)
+
+func TestPackage(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+type migrationStepsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&migrationStepsSuite{})
+
+func (s *migrationStepsSuite) recordingOps(ran *[]string) []operation {
+	record := func(name string) step {
+		return step{
+			description: name,
+			run: func(description.Model) error {
+				*ran = append(*ran, name)
+				return nil
+			},
+		}
+	}
+	return []operation{
+		{version.MustParse("2.7.0"), []step{record("2.7.0")}},
+		{version.MustParse("2.8.0"), []step{record("2.8.0 a"), record("2.8.0 b")}},
+		{version.MustParse("2.9.0"), []step{record("2.9.0")}},
+	}
+}
+
+func (s *migrationStepsSuite) TestStepsAfterFromUpToTarget(c *gc.C) {
+	var ran []string
+	model := description.NewModel(description.ModelArgs{})
+	err := upgradeModelDescription(
+		version.MustParse("2.7.0"), version.MustParse("2.8.1"), s.recordingOps(&ran), model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ran, jc.DeepEquals, []string{"2.8.0 a", "2.8.0 b"})
+}
+
+func (s *migrationStepsSuite) TestNoStepsForSameVersion(c *gc.C) {
+	var ran []string
+	model := description.NewModel(description.ModelArgs{})
+	err := upgradeModelDescription(
+		version.MustParse("2.9.0"), version.MustParse("2.9.0"), s.recordingOps(&ran), model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ran, gc.HasLen, 0)
+}
+
+func (s *migrationStepsSuite) TestPreReleaseTargetRunsItsSteps(c *gc.C) {
+	var ran []string
+	model := description.NewModel(description.ModelArgs{})
+	err := upgradeModelDescription(
+		version.MustParse("2.8.1"), version.MustParse("2.9-beta1"), s.recordingOps(&ran), model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ran, jc.DeepEquals, []string{"2.9.0"})
+}
+
+func (s *migrationStepsSuite) TestStepError(c *gc.C) {
+	ops := []operation{{version.MustParse("2.9.0"), []step{{
+		description: "break things",
+		run: func(description.Model) error {
+			return errors.New("boom")
+		},
+	}}}}
+	model := description.NewModel(description.ModelArgs{})
+	err := upgradeModelDescription(
+		version.MustParse("2.8.0"), version.MustParse("2.9.0"), ops, model)
+	c.Assert(err, gc.ErrorMatches, "break things: boom")
+}
+
+func (s *migrationStepsSuite) TestAddCharmhubToModelConfig(c *gc.C) {
+	model := description.NewModel(description.ModelArgs{
+		Config: map[string]interface{}{"name": "foo"},
+	})
+	err := addCharmhubToModelConfig(model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Config(), jc.DeepEquals, map[string]interface{}{
+		"name":         "foo",
+		"charmhub-url": charmhub.CharmhubServerURL,
+	})
+}
+
+func (s *migrationStepsSuite) TestAddCharmhubToModelConfigKeepsExisting(c *gc.C) {
+	model := description.NewModel(description.ModelArgs{
+		Config: map[string]interface{}{"charmhub-url": "https://charmhub.example.com"},
+	})
+	err := addCharmhubToModelConfig(model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Config()["charmhub-url"], gc.Equals, "https://charmhub.example.com")
+}
+
+func (s *migrationStepsSuite) TestUpgradeModelDescription(c *gc.C) {
+	model := description.NewModel(description.ModelArgs{
+		Config: map[string]interface{}{"name": "foo"},
+	})
+	err := UpgradeModelDescription(version.MustParse("2.8.1"), model)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Config()["charmhub-url"], gc.Equals, charmhub.CharmhubServerURL)
+}
@@ -0,0 +1,29 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migrationsteps
+
+import (
+	"github.com/juju/description/v2"
+
+	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/environs/config"
+)
+
+// stepsFor29 returns model description upgrade steps for Juju 2.9.0.
+func stepsFor29() []step {
+	return []step{{
+		description: "add charmhub-url to model config",
+		run:         addCharmhubToModelConfig,
+	}}
+}
+
+func addCharmhubToModelConfig(model description.Model) error {
+	if value, ok := model.Config()[config.CharmhubURLKey]; ok && value != "" {
+		return nil
+	}
+	model.UpdateConfig(map[string]interface{}{
+		config.CharmhubURLKey: charmhub.CharmhubServerURL,
+	})
+	return nil
+}
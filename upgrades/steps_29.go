@@ -13,5 +13,12 @@ func stateStepsFor29() []Step {
 				return context.State().AddCharmhubToModelConfig()
 			},
 		},
+		&upgradeStep{
+			description: "add labels to annotations",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().AddAnnotationLabels()
+			},
+		},
 	}
 }
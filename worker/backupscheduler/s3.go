@@ -0,0 +1,54 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+
+	"github.com/juju/juju/controller"
+)
+
+// NewS3Uploader returns an Uploader which puts archives into the
+// configured bucket of an S3-compatible service, using path-style
+// requests signed with AWS signature version 4.
+func NewS3Uploader(config controller.BackupS3Config) (Uploader, error) {
+	if _, err := url.Parse(config.Endpoint); err != nil {
+		return nil, errors.Annotate(err, "invalid S3 endpoint")
+	}
+	if config.Bucket == "" {
+		return nil, errors.NotValidf("empty S3 bucket")
+	}
+	auth := aws.Auth{
+		AccessKey: config.AccessKey,
+		SecretKey: config.SecretKey,
+	}
+	region := aws.Region{
+		Name:       config.Region,
+		S3Endpoint: strings.TrimSuffix(config.Endpoint, "/"),
+	}
+	bucket, err := s3.New(auth, region).Bucket(config.Bucket)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &s3Uploader{bucket: bucket}, nil
+}
+
+type s3Uploader struct {
+	bucket *s3.Bucket
+}
+
+// Upload is part of the Uploader interface.
+func (u *s3Uploader) Upload(name string, archive io.Reader, size int64) error {
+	err := u.bucket.PutReader(name, archive, size, "application/octet-stream", s3.Private)
+	if s3err, ok := err.(*s3.Error); ok && s3err.Code != "" {
+		return errors.Errorf("S3 upload failed: %s: %s", s3err.Code, s3err.Message)
+	}
+	return errors.Annotate(err, "S3 upload failed")
+}
@@ -0,0 +1,139 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type S3Suite struct {
+	testing.IsolationSuite
+
+	store  *fakeS3
+	server *httptest.Server
+	config controller.BackupS3Config
+}
+
+var _ = gc.Suite(&S3Suite{})
+
+func (s *S3Suite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.store = &fakeS3{objects: make(map[string]s3Object)}
+	s.server = httptest.NewServer(s.store)
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	s.config = controller.BackupS3Config{
+		Endpoint:  s.server.URL,
+		Bucket:    "backups",
+		Region:    "us-east-1",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}
+}
+
+func (s *S3Suite) TestUpload(c *gc.C) {
+	uploader, err := backupscheduler.NewS3Uploader(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = uploader.Upload("juju-backup-1.tar.gz", strings.NewReader("archive"), 7)
+	c.Assert(err, jc.ErrorIsNil)
+
+	obj, ok := s.store.get("/backups/juju-backup-1.tar.gz")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(obj.data, gc.Equals, "archive")
+	c.Check(obj.size, gc.Equals, int64(7))
+	c.Check(obj.header.Get("Content-Type"), gc.Equals, "application/octet-stream")
+	c.Check(obj.header.Get("X-Amz-Date"), gc.Matches, "[0-9]{8}T[0-9]{6}Z")
+	sum := sha256.Sum256([]byte("archive"))
+	c.Check(obj.header.Get("X-Amz-Content-Sha256"), gc.Equals, hex.EncodeToString(sum[:]))
+	c.Check(obj.header.Get("Authorization"), gc.Matches,
+		"AWS4-HMAC-SHA256 "+
+			"Credential=access/[0-9]{8}/us-east-1/s3/aws4_request, "+
+			"SignedHeaders=[a-z0-9;-]*x-amz-content-sha256;x-amz-date, "+
+			"Signature=[0-9a-f]{64}",
+	)
+}
+
+func (s *S3Suite) TestUploadEndpointPath(c *gc.C) {
+	s.config.Endpoint = s.server.URL + "/s3/"
+	uploader, err := backupscheduler.NewS3Uploader(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = uploader.Upload("juju-backup-1.tar.gz", strings.NewReader("archive"), 7)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.store.get("/s3/backups/juju-backup-1.tar.gz")
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *S3Suite) TestUploadError(c *gc.C) {
+	s.store.fail = true
+	uploader, err := backupscheduler.NewS3Uploader(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = uploader.Upload("juju-backup-1.tar.gz", strings.NewReader("archive"), 7)
+	c.Assert(err, gc.ErrorMatches, "S3 upload failed: AccessDenied: Access Denied")
+}
+
+func (s *S3Suite) TestNoBucket(c *gc.C) {
+	s.config.Bucket = ""
+	_, err := backupscheduler.NewS3Uploader(s.config)
+	c.Assert(err, gc.ErrorMatches, "empty S3 bucket not valid")
+}
+
+type s3Object struct {
+	header http.Header
+	size   int64
+	data   string
+}
+
+// fakeS3 stands in for an S3-compatible service such as MinIO,
+// storing objects put to it by path.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]s3Object
+	fail    bool
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "PUT" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.fail {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>\n"))
+		return
+	}
+	data, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[req.URL.Path] = s3Object{
+		header: req.Header.Clone(),
+		size:   req.ContentLength,
+		data:   string(data),
+	}
+}
+
+func (s *fakeS3) get(path string) (s3Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[path]
+	return obj, ok
+}
@@ -0,0 +1,160 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"io"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// NewStateBackups returns Backups which takes backups of the controller
+// the agent is running on, prepared in the same way as those taken
+// through the Backups facade.
+func NewStateBackups(st *state.State, agentConfig agent.Config) Backups {
+	return &stateBackups{st: st, agentConfig: agentConfig}
+}
+
+type stateBackups struct {
+	st          *state.State
+	agentConfig agent.Config
+}
+
+type stateShim struct {
+	*state.State
+	*state.Model
+}
+
+// ModelTag disambiguates the ModelTag method pending further
+// refactoring to separate model functionality from state functionality.
+func (s *stateShim) ModelTag() names.ModelTag {
+	return s.Model.ModelTag()
+}
+
+// ControllerNodes returns the controller nodes in HA.
+func (s *stateShim) ControllerNodes() ([]state.ControllerNode, error) {
+	nodes, err := s.State.ControllerNodes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]state.ControllerNode, len(nodes))
+	for i, n := range nodes {
+		result[i] = n
+	}
+	return result, nil
+}
+
+func (b *stateBackups) shim() (*stateShim, error) {
+	model, err := b.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &stateShim{b.st, model}, nil
+}
+
+// Create is part of the Backups interface.
+func (b *stateBackups) Create(notes, encryptTo string) (*backups.Metadata, error) {
+	shim, err := b.shim()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stor := backups.NewStorage(shim)
+	defer stor.Close()
+
+	mgoInfo, ok := b.agentConfig.MongoInfo()
+	if !ok {
+		return nil, errors.New("no mongo info in agent config")
+	}
+	machineID := b.agentConfig.Tag().Id()
+	machine, err := b.st.Machine(machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, dbInfo, err := backups.PrepareCreate(shim, backups.CreateArgs{
+		MongoInfo: mgoInfo,
+		MachineID: machineID,
+		Machine:   machine,
+		Notes:     notes,
+		EncryptTo: encryptTo,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	modelConfig, err := shim.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	paths := &backups.Paths{
+		BackupDir: modelConfig.BackupDir(),
+		DataDir:   b.agentConfig.DataDir(),
+		LogsDir:   b.agentConfig.LogDir(),
+	}
+	if _, err := backups.NewBackups(stor).Create(meta, paths, dbInfo, true, true); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// List is part of the Backups interface.
+func (b *stateBackups) List() ([]*backups.Metadata, error) {
+	shim, err := b.shim()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stor := backups.NewStorage(shim)
+	defer stor.Close()
+	return backups.NewBackups(stor).List()
+}
+
+// Get is part of the Backups interface.
+func (b *stateBackups) Get(id string) (*backups.Metadata, io.ReadCloser, error) {
+	shim, err := b.shim()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	stor := backups.NewStorage(shim)
+	meta, archive, err := backups.NewBackups(stor).Get(id)
+	if err != nil {
+		stor.Close()
+		return nil, nil, errors.Trace(err)
+	}
+	return meta, &closers{archive, stor}, nil
+}
+
+// Remove is part of the Backups interface.
+func (b *stateBackups) Remove(id string) error {
+	shim, err := b.shim()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	stor := backups.NewStorage(shim)
+	defer stor.Close()
+	return backups.NewBackups(stor).Remove(id)
+}
+
+// closers closes the archive and then the storage it was read from.
+type closers struct {
+	io.ReadCloser
+	stor io.Closer
+}
+
+// Close implements io.Closer.
+func (c *closers) Close() error {
+	err := c.ReadCloser.Close()
+	if storErr := c.stor.Close(); err == nil {
+		err = storErr
+	}
+	return err
+}
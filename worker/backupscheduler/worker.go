@@ -0,0 +1,308 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"io"
+	"sort"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// ScheduledNotes are the notes recorded against scheduled backups.
+// Only backups with these notes are pruned.
+const ScheduledNotes = "scheduled backup"
+
+// RetryDelay is how long the worker waits before trying again after
+// a scheduled backup fails.
+const RetryDelay = 10 * time.Minute
+
+// Backups exposes the backup operations used by the worker.
+type Backups interface {
+	// Create takes a backup with the given notes and keeps it on the
+	// controller, encrypting it to the public key if one is given.
+	Create(notes, encryptTo string) (*backups.Metadata, error)
+
+	// List returns the metadata of the backups kept on the controller.
+	List() ([]*backups.Metadata, error)
+
+	// Get returns the metadata and archive of the identified backup.
+	Get(id string) (*backups.Metadata, io.ReadCloser, error)
+
+	// Remove removes the identified backup from the controller.
+	Remove(id string) error
+}
+
+// ConfigSource lets us get notifications of changes to controller
+// configuration, and then get the changed config. (Primary
+// implementation is State.)
+type ConfigSource interface {
+	WatchControllerConfig() state.NotifyWatcher
+	ControllerConfig() (controller.Config, error)
+}
+
+// Uploader copies backup archives off the controller.
+type Uploader interface {
+	// Upload stores the archive, of the given size, under the name.
+	Upload(name string, archive io.Reader, size int64) error
+}
+
+// Config holds the dependencies and configuration of the backup
+// scheduler worker.
+type Config struct {
+	ConfigSource ConfigSource
+	Backups      Backups
+	Clock        clock.Clock
+	NewUploader  func(controller.BackupS3Config) (Uploader, error)
+}
+
+// Validate returns an error if the config cannot be used to start a
+// worker.
+func (config Config) Validate() error {
+	if config.ConfigSource == nil {
+		return errors.NotValidf("nil ConfigSource")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.NewUploader == nil {
+		return errors.NotValidf("nil NewUploader")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which takes backups of the controller on
+// the schedule set in controller config, prunes old scheduled backups
+// and uploads new ones to S3-compatible storage if configured.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &scheduler{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type scheduler struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *scheduler) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *scheduler) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *scheduler) loop() error {
+	watcher := w.config.ConfigSource.WatchControllerConfig()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		cfg         controller.Config
+		timer       <-chan time.Time
+		uploadTimer <-chan time.Time
+		// pending is the ID of a backup which has yet to be
+		// uploaded, so that a failed upload can be retried
+		// without taking another backup.
+		pending string
+	)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.Errorf("watcher channel closed")
+			}
+			var err error
+			if cfg, err = w.config.ConfigSource.ControllerConfig(); err != nil {
+				return errors.Annotate(err, "getting controller config")
+			}
+			if timer, err = w.schedule(cfg); err != nil {
+				return errors.Trace(err)
+			}
+		case <-timer:
+			id, err := w.backup(cfg)
+			if err != nil {
+				logger.Errorf("scheduled backup failed, retrying in %v: %v", RetryDelay, err)
+				timer = w.config.Clock.After(RetryDelay)
+				continue
+			}
+			pending, uploadTimer = w.uploadPending(cfg, id)
+			if err := w.prune(cfg); err != nil {
+				logger.Errorf("pruning scheduled backups: %v", err)
+			}
+			if timer, err = w.schedule(cfg); err != nil {
+				return errors.Trace(err)
+			}
+		case <-uploadTimer:
+			pending, uploadTimer = w.uploadPending(cfg, pending)
+		}
+	}
+}
+
+// schedule returns a channel which fires when the next scheduled backup
+// is due, or nil if backups are not scheduled.
+func (w *scheduler) schedule(cfg controller.Config) (<-chan time.Time, error) {
+	interval := cfg.BackupScheduleInterval()
+	if interval == 0 {
+		logger.Debugf("scheduled backups disabled")
+		return nil, nil
+	}
+	scheduled, err := w.scheduled()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var delay time.Duration
+	if len(scheduled) > 0 {
+		due := scheduled[0].Started.Add(interval)
+		if delay = due.Sub(w.config.Clock.Now()); delay < 0 {
+			delay = 0
+		}
+	}
+	logger.Debugf("next scheduled backup in %v", delay)
+	return w.config.Clock.After(delay), nil
+}
+
+// scheduled returns the scheduled backups kept on the controller,
+// newest first.
+func (w *scheduler) scheduled() ([]*backups.Metadata, error) {
+	all, err := w.config.Backups.List()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing backups")
+	}
+	return scheduledBackups(all), nil
+}
+
+// scheduledBackups returns the scheduled backups from all, newest
+// first.
+func scheduledBackups(all []*backups.Metadata) []*backups.Metadata {
+	var scheduled []*backups.Metadata
+	for _, meta := range all {
+		if meta.Notes == ScheduledNotes {
+			scheduled = append(scheduled, meta)
+		}
+	}
+	sort.Slice(scheduled, func(i, j int) bool {
+		return scheduled[i].Started.After(scheduled[j].Started)
+	})
+	return scheduled
+}
+
+// backup takes a scheduled backup and returns its ID.
+func (w *scheduler) backup(cfg controller.Config) (string, error) {
+	meta, err := w.config.Backups.Create(ScheduledNotes, cfg.BackupEncryptTo())
+	if err != nil {
+		return "", errors.Annotate(err, "creating backup")
+	}
+	logger.Infof("created scheduled backup %s", meta.ID())
+	return meta.ID(), nil
+}
+
+// uploadPending uploads the identified backup if uploads are
+// configured. If the upload fails, it returns the ID with a channel
+// which fires when the upload should be retried.
+func (w *scheduler) uploadPending(cfg controller.Config, id string) (string, <-chan time.Time) {
+	s3Config, ok := cfg.BackupS3()
+	if !ok {
+		return "", nil
+	}
+	err := w.upload(s3Config, id)
+	if errors.IsNotFound(err) {
+		logger.Warningf("scheduled backup %s removed before it was uploaded", id)
+		return "", nil
+	} else if err != nil {
+		logger.Errorf("uploading scheduled backup %s failed, retrying in %v: %v", id, RetryDelay, err)
+		return id, w.config.Clock.After(RetryDelay)
+	}
+	return "", nil
+}
+
+func (w *scheduler) upload(s3Config controller.BackupS3Config, id string) error {
+	uploader, err := w.config.NewUploader(s3Config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	meta, archive, err := w.config.Backups.Get(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+
+	name := backups.FilenamePrefix + id + ".tar.gz"
+	if meta.Encryption != nil {
+		name += backups.EncryptedFilenameSuffix
+	}
+	if err := uploader.Upload(name, archive, meta.Size()); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("uploaded scheduled backup %s to %s/%s", id, s3Config.Bucket, name)
+	return nil
+}
+
+// prune removes the scheduled backups beyond the retention count or
+// older than the retention age. The newest backup is always kept, as
+// are backups followed by incremental backups, which cannot be
+// restored without them.
+func (w *scheduler) prune(cfg controller.Config) error {
+	count := cfg.BackupRetentionCount()
+	age := cfg.BackupRetentionAge()
+	all, err := w.config.Backups.List()
+	if err != nil {
+		return errors.Annotate(err, "listing backups")
+	}
+	dependants := make(map[string]int)
+	for _, meta := range all {
+		if meta.BaseID != "" {
+			dependants[meta.BaseID]++
+		}
+	}
+	now := w.config.Clock.Now()
+	for i, meta := range scheduledBackups(all) {
+		if i == 0 {
+			continue
+		}
+		expired := count > 0 && i >= count
+		expired = expired || age > 0 && now.Sub(meta.Started) > age
+		if !expired {
+			continue
+		}
+		if dependants[meta.ID()] > 0 {
+			logger.Debugf("keeping scheduled backup %s, followed by incremental backups", meta.ID())
+			continue
+		}
+		if err := w.config.Backups.Remove(meta.ID()); err != nil {
+			return errors.Annotatef(err, "removing backup %s", meta.ID())
+		}
+		logger.Infof("removed scheduled backup %s", meta.ID())
+	}
+	return nil
+}
@@ -0,0 +1,491 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock    *testclock.Clock
+	source   *fakeConfigSource
+	backups  *fakeBackups
+	uploader *fakeUploader
+	config   backupscheduler.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC))
+	s.source = &fakeConfigSource{
+		changes: make(chan struct{}, 1),
+		config: controller.Config{
+			controller.BackupScheduleInterval: time.Hour,
+		},
+	}
+	s.source.changes <- struct{}{}
+	s.backups = &fakeBackups{
+		clock: s.clock,
+		calls: make(chan string, 10),
+	}
+	s.uploader = &fakeUploader{uploads: make(chan string, 10)}
+	s.config = backupscheduler.Config{
+		ConfigSource: s.source,
+		Backups:      s.backups,
+		Clock:        s.clock,
+		NewUploader: func(config controller.BackupS3Config) (backupscheduler.Uploader, error) {
+			s.uploader.config = config
+			return s.uploader, nil
+		},
+	}
+}
+
+func (s *WorkerSuite) newWorker(c *gc.C) worker.Worker {
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	type test struct {
+		f      func(*backupscheduler.Config)
+		expect string
+	}
+	tests := []test{{
+		func(cfg *backupscheduler.Config) { cfg.ConfigSource = nil },
+		"nil ConfigSource not valid",
+	}, {
+		func(cfg *backupscheduler.Config) { cfg.Backups = nil },
+		"nil Backups not valid",
+	}, {
+		func(cfg *backupscheduler.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *backupscheduler.Config) { cfg.NewUploader = nil },
+		"nil NewUploader not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test #%d (%s)", i, test.expect)
+		config := s.config
+		test.f(&config)
+		w, err := backupscheduler.NewWorker(config)
+		c.Check(err, gc.ErrorMatches, test.expect)
+		c.Check(w, gc.IsNil)
+	}
+}
+
+func (s *WorkerSuite) TestBackupImmediatelyWithoutScheduledBackups(c *gc.C) {
+	s.backups.add(c, "manual", "", s.clock.Now().Add(-time.Minute))
+	s.newWorker(c)
+	s.waitCall(c, "Create 2")
+	s.backups.CheckCall(c, 1, "Create", backupscheduler.ScheduledNotes, "")
+}
+
+func (s *WorkerSuite) TestBackupOnInterval(c *gc.C) {
+	s.newWorker(c)
+	s.waitCall(c, "Create 1")
+
+	err := s.clock.WaitAdvance(59*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoCall(c)
+
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCall(c, "Create 2")
+}
+
+func (s *WorkerSuite) TestBackupDueFromLatestScheduled(c *gc.C) {
+	s.backups.add(c, backupscheduler.ScheduledNotes, "", s.clock.Now().Add(-2*time.Hour))
+	s.backups.add(c, backupscheduler.ScheduledNotes, "", s.clock.Now().Add(-20*time.Minute))
+	s.newWorker(c)
+
+	err := s.clock.WaitAdvance(39*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoCall(c)
+
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCall(c, "Create 3")
+}
+
+func (s *WorkerSuite) TestDisabled(c *gc.C) {
+	s.source.setConfig(controller.Config{})
+	w := s.newWorker(c)
+	workertest.CheckAlive(c, w)
+	s.assertNoCall(c)
+	c.Assert(s.backups.Calls(), gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestEnabledByConfigChange(c *gc.C) {
+	s.source.setConfig(controller.Config{})
+	s.newWorker(c)
+	s.assertNoCall(c)
+
+	s.source.setConfig(controller.Config{
+		controller.BackupScheduleInterval: time.Hour,
+	})
+	s.source.changes <- struct{}{}
+	s.waitCall(c, "Create 1")
+}
+
+func (s *WorkerSuite) TestEncrypted(c *gc.C) {
+	s.source.setConfig(controller.Config{
+		controller.BackupScheduleInterval: time.Hour,
+		controller.BackupEncryptTo:        "public-key",
+	})
+	s.newWorker(c)
+	s.waitCall(c, "Create 1")
+	s.backups.CheckCall(c, 1, "Create", backupscheduler.ScheduledNotes, "public-key")
+}
+
+func (s *WorkerSuite) TestPruneByCount(c *gc.C) {
+	s.source.setConfig(controller.Config{
+		controller.BackupScheduleInterval: time.Hour,
+		controller.BackupRetentionCount:   2,
+	})
+	s.backups.add(c, "manual", "", s.clock.Now().Add(-10*time.Hour))
+	s.backups.add(c, backupscheduler.ScheduledNotes, "", s.clock.Now().Add(-3*time.Hour))
+	s.backups.add(c, backupscheduler.ScheduledNotes, "", s.clock.Now().Add(-2*time.Hour))
+	s.backups.add(c, backupscheduler.ScheduledNotes, "", s.clock.Now().Add(-time.Hour))
+	s.newWorker(c)
+
+	s.waitCall(c, "Create 5")
+	s.waitCall(c, "Remove 3")
+	s.waitCall(c, "Remove 2")
+	c.Assert(s.backups.ids(), jc.SameContents, []string{"1", "4", "5"})
+}
+
+func (s *WorkerSuite) TestPruneByAge(c *gc.C) {
+	s.source.setConfig(controller.Config{
+		controller.BackupScheduleInterval: time.Hour,
+		controller.BackupRetentionAge:     24 * time.Hour,
+	})
+	s.backups.add(c, "manual", "", s.clock.Now().Add(-72*time.Hour))
+	s.backups.add(c, backupscheduler.ScheduledNotes, "", s.clock.Now().Add(-48*time.Hour))
+	s.backups.add(c, backupscheduler.ScheduledNotes, "", s.clock.Now().Add(-12*time.Hour))
+	s.newWorker(c)
+
+	s.waitCall(c, "Create 4")
+	s.waitCall(c, "Remove 2")
+	c.Assert(s.backups.ids(), jc.SameContents, []string{"1", "3", "4"})
+}
+
+func (s *WorkerSuite) TestIncrementalBaseNotPruned(c *gc.C) {
+	s.source.setConfig(controller.Config{
+		controller.BackupScheduleInterval: time.Hour,
+		controller.BackupRetentionCount:   1,
+	})
+	s.backups.add(c, backupscheduler.ScheduledNotes, "", s.clock.Now().Add(-3*time.Hour))
+	s.backups.add(c, backupscheduler.ScheduledNotes, "", s.clock.Now().Add(-2*time.Hour))
+	incremental := s.backups.add(c, "manual", "", s.clock.Now().Add(-90*time.Minute))
+	incremental.BaseID = "2"
+	s.newWorker(c)
+
+	s.waitCall(c, "Create 4")
+	s.waitCall(c, "Remove 1")
+	s.assertNoCall(c)
+	c.Assert(s.backups.ids(), jc.SameContents, []string{"2", "3", "4"})
+}
+
+func (s *WorkerSuite) TestNewestNeverPruned(c *gc.C) {
+	s.source.setConfig(controller.Config{
+		controller.BackupScheduleInterval: time.Hour,
+		controller.BackupRetentionCount:   1,
+		controller.BackupRetentionAge:     time.Minute,
+	})
+	s.newWorker(c)
+	s.waitCall(c, "Create 1")
+
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCall(c, "Create 2")
+	s.waitCall(c, "Remove 1")
+	c.Assert(s.backups.ids(), jc.DeepEquals, []string{"2"})
+}
+
+func (s *WorkerSuite) TestUpload(c *gc.C) {
+	s.source.setConfig(controller.Config{
+		controller.BackupScheduleInterval: time.Hour,
+		controller.BackupS3Endpoint:       "http://minio.example.com:9000",
+		controller.BackupS3Bucket:         "backups",
+		controller.BackupS3AccessKey:      "access",
+		controller.BackupS3SecretKey:      "secret",
+	})
+	s.newWorker(c)
+	s.waitCall(c, "Create 1")
+
+	select {
+	case name := <-s.uploader.uploads:
+		c.Assert(name, gc.Equals, backups.FilenamePrefix+"1.tar.gz")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for upload")
+	}
+	c.Assert(s.uploader.config, jc.DeepEquals, controller.BackupS3Config{
+		Endpoint:  "http://minio.example.com:9000",
+		Bucket:    "backups",
+		Region:    controller.DefaultBackupS3Region,
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+	c.Assert(s.uploader.content, gc.Equals, "archive 1")
+	c.Assert(s.uploader.size, gc.Equals, int64(len("archive 1")))
+}
+
+func (s *WorkerSuite) TestUploadEncrypted(c *gc.C) {
+	s.source.setConfig(controller.Config{
+		controller.BackupScheduleInterval: time.Hour,
+		controller.BackupEncryptTo:        "public-key",
+		controller.BackupS3Endpoint:       "http://minio.example.com:9000",
+		controller.BackupS3Bucket:         "backups",
+	})
+	s.newWorker(c)
+
+	select {
+	case name := <-s.uploader.uploads:
+		c.Assert(name, gc.Equals, backups.FilenamePrefix+"1.tar.gz"+backups.EncryptedFilenameSuffix)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for upload")
+	}
+}
+
+func (s *WorkerSuite) TestUploadRetriedWithoutNewBackup(c *gc.C) {
+	s.source.setConfig(controller.Config{
+		controller.BackupScheduleInterval: time.Hour,
+		controller.BackupS3Endpoint:       "http://minio.example.com:9000",
+		controller.BackupS3Bucket:         "backups",
+	})
+	s.uploader.SetErrors(errors.New("boom"))
+	s.newWorker(c)
+	s.waitCall(c, "Create 1")
+	s.waitUpload(c, "failed")
+
+	err := s.clock.WaitAdvance(backupscheduler.RetryDelay, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitUpload(c, backups.FilenamePrefix+"1.tar.gz")
+	s.assertNoCall(c)
+	c.Assert(s.backups.ids(), jc.DeepEquals, []string{"1"})
+}
+
+func (s *WorkerSuite) TestPruneAfterFailedUpload(c *gc.C) {
+	s.source.setConfig(controller.Config{
+		controller.BackupScheduleInterval: time.Hour,
+		controller.BackupRetentionCount:   1,
+		controller.BackupS3Endpoint:       "http://minio.example.com:9000",
+		controller.BackupS3Bucket:         "backups",
+	})
+	s.backups.add(c, backupscheduler.ScheduledNotes, "", s.clock.Now().Add(-2*time.Hour))
+	s.uploader.SetErrors(errors.New("boom"))
+	s.newWorker(c)
+
+	s.waitCall(c, "Create 2")
+	s.waitUpload(c, "failed")
+	s.waitCall(c, "Remove 1")
+	c.Assert(s.backups.ids(), jc.DeepEquals, []string{"2"})
+}
+
+func (s *WorkerSuite) TestRetryAfterFailure(c *gc.C) {
+	s.backups.SetErrors(nil, errors.New("boom"))
+	w := s.newWorker(c)
+	s.waitCall(c, "Create failed")
+
+	err := s.clock.WaitAdvance(backupscheduler.RetryDelay, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCall(c, "Create 1")
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C, expect string) {
+	select {
+	case call := <-s.backups.calls:
+		c.Assert(call, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for %q", expect)
+	}
+}
+
+func (s *WorkerSuite) waitUpload(c *gc.C, expect string) {
+	select {
+	case name := <-s.uploader.uploads:
+		c.Assert(name, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for upload %q", expect)
+	}
+}
+
+func (s *WorkerSuite) assertNoCall(c *gc.C) {
+	select {
+	case call := <-s.backups.calls:
+		c.Fatalf("unexpected call %q", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeConfigSource struct {
+	mu      sync.Mutex
+	changes chan struct{}
+	config  controller.Config
+}
+
+func (s *fakeConfigSource) setConfig(config controller.Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = config
+}
+
+func (s *fakeConfigSource) WatchControllerConfig() state.NotifyWatcher {
+	return watchertest.NewNotifyWatcher(s.changes)
+}
+
+func (s *fakeConfigSource) ControllerConfig() (controller.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.config, nil
+}
+
+type fakeBackups struct {
+	testing.Stub
+
+	mu     sync.Mutex
+	clock  *testclock.Clock
+	calls  chan string
+	metas  []*backups.Metadata
+	nextID int
+}
+
+func (b *fakeBackups) add(c *gc.C, notes, encryptTo string, started time.Time) *backups.Metadata {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nextID++
+	id := fmt.Sprint(b.nextID)
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Notes = notes
+	meta.Started = started
+	if encryptTo != "" {
+		meta.Encryption = &backups.Encryption{Recipient: encryptTo}
+	}
+	err := meta.MarkComplete(int64(len("archive "+id)), "checksum")
+	c.Assert(err, jc.ErrorIsNil)
+	b.metas = append(b.metas, meta)
+	return meta
+}
+
+func (b *fakeBackups) ids() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var ids []string
+	for _, meta := range b.metas {
+		ids = append(ids, meta.ID())
+	}
+	return ids
+}
+
+func (b *fakeBackups) Create(notes, encryptTo string) (*backups.Metadata, error) {
+	b.MethodCall(b, "Create", notes, encryptTo)
+	if err := b.NextErr(); err != nil {
+		b.calls <- "Create failed"
+		return nil, err
+	}
+	b.mu.Lock()
+	b.nextID++
+	id := fmt.Sprint(b.nextID)
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Notes = notes
+	meta.Started = b.clock.Now()
+	if encryptTo != "" {
+		meta.Encryption = &backups.Encryption{Recipient: encryptTo}
+	}
+	if err := meta.MarkComplete(int64(len("archive "+id)), "checksum"); err != nil {
+		b.mu.Unlock()
+		return nil, err
+	}
+	b.metas = append(b.metas, meta)
+	b.mu.Unlock()
+	b.calls <- "Create " + id
+	return meta, nil
+}
+
+func (b *fakeBackups) List() ([]*backups.Metadata, error) {
+	b.MethodCall(b, "List")
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*backups.Metadata(nil), b.metas...), b.NextErr()
+}
+
+func (b *fakeBackups) Get(id string) (*backups.Metadata, io.ReadCloser, error) {
+	b.MethodCall(b, "Get", id)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, meta := range b.metas {
+		if meta.ID() == id {
+			return meta, ioutil.NopCloser(strings.NewReader("archive " + id)), nil
+		}
+	}
+	return nil, nil, errors.NotFoundf("backup %q", id)
+}
+
+func (b *fakeBackups) Remove(id string) error {
+	b.MethodCall(b, "Remove", id)
+	b.mu.Lock()
+	for i, meta := range b.metas {
+		if meta.ID() == id {
+			b.metas = append(b.metas[:i], b.metas[i+1:]...)
+			break
+		}
+	}
+	b.mu.Unlock()
+	b.calls <- "Remove " + id
+	return b.NextErr()
+}
+
+type fakeUploader struct {
+	testing.Stub
+
+	config  controller.BackupS3Config
+	content string
+	size    int64
+	uploads chan string
+}
+
+func (u *fakeUploader) Upload(name string, archive io.Reader, size int64) error {
+	u.MethodCall(u, "Upload", name)
+	if err := u.NextErr(); err != nil {
+		u.uploads <- "failed"
+		return err
+	}
+	data, err := ioutil.ReadAll(archive)
+	if err != nil {
+		return err
+	}
+	u.content = string(data)
+	u.size = size
+	u.uploads <- name
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchprogression
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// NewStatePoolBackend returns a Backend that gets the controller's models
+// from the input state pool.
+func NewStatePoolBackend(pool *state.StatePool) Backend {
+	return &poolBackend{pool: pool}
+}
+
+type poolBackend struct {
+	pool *state.StatePool
+}
+
+// AllModelUUIDs is part of the Backend interface.
+func (b *poolBackend) AllModelUUIDs() ([]string, error) {
+	return b.pool.SystemState().AllModelUUIDs()
+}
+
+// Model is part of the Backend interface.
+func (b *poolBackend) Model(modelUUID string) (Model, error) {
+	st, err := b.pool.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := st.Model()
+	if err != nil {
+		st.Release()
+		return nil, errors.Trace(err)
+	}
+	return &modelShim{st: st, model: model}, nil
+}
+
+type modelShim struct {
+	st    *state.PooledState
+	model *state.Model
+}
+
+// Branches is part of the Model interface.
+func (m *modelShim) Branches() ([]Branch, error) {
+	branches, err := m.model.Branches()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Branch, len(branches))
+	for i, branch := range branches {
+		result[i] = branch
+	}
+	return result, nil
+}
+
+// UnitStatus is part of the Model interface.
+func (m *modelShim) UnitStatus(unitName string) (status.Status, status.Status, error) {
+	unit, err := m.st.Unit(unitName)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	agent, err := unit.AgentStatus()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	workload, err := unit.Status()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	return agent.Status, workload.Status, nil
+}
+
+// Release is part of the Model interface.
+func (m *modelShim) Release() {
+	m.st.Release()
+}
@@ -0,0 +1,212 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchprogression
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.worker.branchprogression")
+
+// DefaultPollInterval is how often the worker checks for progressively
+// tracked branches that are due another step.
+const DefaultPollInterval = time.Minute
+
+// Backend provides access to the branches of the controller's models.
+type Backend interface {
+	// AllModelUUIDs returns the UUIDs of all of the controller's models.
+	AllModelUUIDs() ([]string, error)
+
+	// Model returns the branches and units of the identified model.
+	Model(modelUUID string) (Model, error)
+}
+
+// Model provides access to the branches and units of a model.
+type Model interface {
+	// Branches returns the model's in-flight branches.
+	Branches() ([]Branch, error)
+
+	// UnitStatus returns the agent and workload status of the unit.
+	UnitStatus(unitName string) (agent, workload status.Status, err error)
+
+	// Release indicates that the model is no longer used.
+	Release()
+}
+
+// Branch is a model branch that may be tracked progressively.
+type Branch interface {
+	// BranchName returns the name of the branch.
+	BranchName() string
+
+	// AssignedUnits returns the names of the units tracking the branch,
+	// keyed by application name.
+	AssignedUnits() map[string][]string
+
+	// Progression returns the progressive tracking of the branch,
+	// and false if it is not tracked progressively.
+	Progression() (state.BranchProgression, bool)
+
+	// StepProgression takes the next step of the branch's
+	// progressive tracking.
+	StepProgression() error
+}
+
+// Config holds the dependencies and configuration of the branch
+// progression worker.
+type Config struct {
+	Backend      Backend
+	Clock        clock.Clock
+	PollInterval time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start a
+// worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which steps the progressive tracking of
+// branches when it is due, provided that all of the units already
+// tracking the branch are active and idle.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &progressor{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type progressor struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *progressor) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *progressor) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *progressor) loop() error {
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.PollInterval):
+			if err := w.progress(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// progress steps every branch in the controller that is due.
+// Failing to step the branches of one model does not prevent
+// the branches of the other models being stepped.
+func (w *progressor) progress() error {
+	modelUUIDs, err := w.config.Backend.AllModelUUIDs()
+	if err != nil {
+		return errors.Annotate(err, "getting models")
+	}
+	for _, modelUUID := range modelUUIDs {
+		if err := w.progressModel(modelUUID); err != nil {
+			logger.Errorf("progressing branches of model %q: %v", modelUUID, err)
+		}
+	}
+	return nil
+}
+
+func (w *progressor) progressModel(modelUUID string) error {
+	model, err := w.config.Backend.Model(modelUUID)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	defer model.Release()
+
+	branches, err := model.Branches()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := w.config.Clock.Now()
+	for _, branch := range branches {
+		// Failing to step one branch does not prevent
+		// the model's other branches being stepped.
+		if err := progressBranch(model, branch, now); err != nil {
+			logger.Errorf("progressing branch %q of model %q: %v", branch.BranchName(), modelUUID, err)
+		}
+	}
+	return nil
+}
+
+// progressBranch steps the progression of the branch if it is due
+// and all of the units tracking it are healthy.
+func progressBranch(model Model, branch Branch, now time.Time) error {
+	progression, ok := branch.Progression()
+	if !ok || progression.NextStep.After(now) {
+		return nil
+	}
+	healthy, err := unitsHealthy(model, branch)
+	if err != nil {
+		return errors.Annotate(err, "checking units")
+	}
+	if !healthy {
+		// Try again at the next poll; progression
+		// resumes once the tracking units settle.
+		return nil
+	}
+	logger.Infof("stepping progression of branch %q", branch.BranchName())
+	return errors.Trace(branch.StepProgression())
+}
+
+// unitsHealthy returns true if all of the units tracking
+// the branch have an idle agent and an active workload.
+func unitsHealthy(model Model, branch Branch) (bool, error) {
+	for _, unitNames := range branch.AssignedUnits() {
+		for _, unitName := range unitNames {
+			agent, workload, err := model.UnitStatus(unitName)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return false, errors.Trace(err)
+			}
+			if agent != status.Idle || workload != status.Active {
+				logger.Debugf("holding progression of branch %q: unit %q is %s/%s",
+					branch.BranchName(), unitName, workload, agent)
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,320 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchprogression_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/branchprogression"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	steps   chan string
+	backend *fakeBackend
+	model   *fakeModel
+	config  branchprogression.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC))
+	s.steps = make(chan string, 10)
+	s.model = &fakeModel{
+		statuses: map[string]status.Status{
+			"redis/0": status.Active,
+			"redis/1": status.Active,
+		},
+	}
+	s.model.branches = []branchprogression.Branch{
+		s.newBranch("canary", s.clock.Now().Add(-time.Minute), "redis/0", "redis/1"),
+	}
+	s.backend = &fakeBackend{models: map[string]*fakeModel{"model-1": s.model}}
+	s.config = branchprogression.Config{
+		Backend:      s.backend,
+		Clock:        s.clock,
+		PollInterval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) newBranch(name string, nextStep time.Time, unitNames ...string) *fakeBranch {
+	return &fakeBranch{
+		name:     name,
+		units:    map[string][]string{"redis": unitNames},
+		nextStep: nextStep,
+		steps:    s.steps,
+	}
+}
+
+func (s *WorkerSuite) newWorker(c *gc.C) worker.Worker {
+	w, err := branchprogression.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	type test struct {
+		f      func(*branchprogression.Config)
+		expect string
+	}
+	tests := []test{{
+		func(cfg *branchprogression.Config) { cfg.Backend = nil },
+		"nil Backend not valid",
+	}, {
+		func(cfg *branchprogression.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *branchprogression.Config) { cfg.PollInterval = 0 },
+		"non-positive PollInterval not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test #%d (%s)", i, test.expect)
+		config := s.config
+		test.f(&config)
+		w, err := branchprogression.NewWorker(config)
+		c.Check(err, gc.ErrorMatches, test.expect)
+		c.Check(w, gc.IsNil)
+	}
+}
+
+func (s *WorkerSuite) TestStepsDueBranch(c *gc.C) {
+	s.newWorker(c)
+	s.advance(c)
+	s.waitStep(c, "canary")
+
+	// Once the worker is waiting again, the model has been released.
+	err := s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.model.releases(), gc.Equals, 1)
+}
+
+func (s *WorkerSuite) TestSkipsBranchNotDue(c *gc.C) {
+	s.model.branches = []branchprogression.Branch{
+		s.newBranch("canary", s.clock.Now().Add(time.Hour), "redis/0"),
+	}
+	s.newWorker(c)
+	s.advance(c)
+	s.assertNoStep(c)
+}
+
+func (s *WorkerSuite) TestSkipsBranchNotProgressive(c *gc.C) {
+	branch := s.newBranch("canary", s.clock.Now(), "redis/0")
+	branch.manual = true
+	s.model.branches = []branchprogression.Branch{branch}
+	s.newWorker(c)
+	s.advance(c)
+	s.assertNoStep(c)
+}
+
+func (s *WorkerSuite) TestHoldsWhileUnitsNotHealthy(c *gc.C) {
+	s.model.setStatus("redis/1", status.Executing)
+	s.newWorker(c)
+	s.advance(c)
+	s.assertNoStep(c)
+
+	s.model.setStatus("redis/1", status.Active)
+	s.advance(c)
+	s.waitStep(c, "canary")
+}
+
+func (s *WorkerSuite) TestHoldsWhileWorkloadBlocked(c *gc.C) {
+	s.model.setStatus("redis/0", status.Blocked)
+	s.newWorker(c)
+	s.advance(c)
+	s.assertNoStep(c)
+}
+
+func (s *WorkerSuite) TestIgnoresRemovedUnits(c *gc.C) {
+	s.model.branches = []branchprogression.Branch{
+		s.newBranch("canary", s.clock.Now(), "redis/0", "redis/2"),
+	}
+	s.newWorker(c)
+	s.advance(c)
+	s.waitStep(c, "canary")
+}
+
+func (s *WorkerSuite) TestModelErrorDoesNotStopOtherModels(c *gc.C) {
+	s.backend.models["model-0"] = &fakeModel{err: errors.New("boom")}
+	w := s.newWorker(c)
+	s.advance(c)
+	s.waitStep(c, "canary")
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestBranchErrorDoesNotStopOtherBranches(c *gc.C) {
+	broken := s.newBranch("broken", s.clock.Now(), "redis/0")
+	broken.stepErr = errors.New("boom")
+	s.model.branches = []branchprogression.Branch{
+		broken,
+		s.newBranch("canary", s.clock.Now(), "redis/1"),
+	}
+	w := s.newWorker(c)
+	s.advance(c)
+	s.waitStep(c, "canary")
+	workertest.CheckAlive(c, w)
+	c.Check(c.GetTestLog(), jc.Contains, `progressing branch "broken" of model "model-1": boom`)
+}
+
+func (s *WorkerSuite) TestBackendErrorKillsWorker(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	w, err := branchprogression.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+	s.advance(c)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting models: boom")
+}
+
+func (s *WorkerSuite) advance(c *gc.C) {
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) waitStep(c *gc.C, expect string) {
+	select {
+	case branchName := <-s.steps:
+		c.Assert(branchName, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for step of %q", expect)
+	}
+}
+
+func (s *WorkerSuite) assertNoStep(c *gc.C) {
+	select {
+	case branchName := <-s.steps:
+		c.Fatalf("unexpected step of %q", branchName)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeBackend struct {
+	models map[string]*fakeModel
+	err    error
+}
+
+func (b *fakeBackend) AllModelUUIDs() ([]string, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	var uuids []string
+	for _, uuid := range []string{"model-0", "model-1"} {
+		if _, ok := b.models[uuid]; ok {
+			uuids = append(uuids, uuid)
+		}
+	}
+	return uuids, nil
+}
+
+func (b *fakeBackend) Model(modelUUID string) (branchprogression.Model, error) {
+	m, ok := b.models[modelUUID]
+	if !ok {
+		return nil, errors.NotFoundf("model %q", modelUUID)
+	}
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m, nil
+}
+
+type fakeModel struct {
+	mu       sync.Mutex
+	branches []branchprogression.Branch
+	statuses map[string]status.Status
+	released int
+	err      error
+}
+
+func (m *fakeModel) setStatus(unitName string, s status.Status) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses[unitName] = s
+}
+
+func (m *fakeModel) releases() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.released
+}
+
+func (m *fakeModel) Branches() ([]branchprogression.Branch, error) {
+	return m.branches, nil
+}
+
+// UnitStatus reports an executing agent for units with a status of
+// executing, and an idle agent with that workload status otherwise.
+func (m *fakeModel) UnitStatus(unitName string) (status.Status, status.Status, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.statuses[unitName]
+	if !ok {
+		return "", "", errors.NotFoundf("unit %q", unitName)
+	}
+	if s == status.Executing {
+		return status.Executing, status.Active, nil
+	}
+	return status.Idle, s, nil
+}
+
+func (m *fakeModel) Release() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.released++
+}
+
+type fakeBranch struct {
+	name     string
+	units    map[string][]string
+	nextStep time.Time
+	manual   bool
+	stepErr  error
+	steps    chan<- string
+}
+
+func (b *fakeBranch) BranchName() string {
+	return b.name
+}
+
+func (b *fakeBranch) AssignedUnits() map[string][]string {
+	return b.units
+}
+
+func (b *fakeBranch) Progression() (state.BranchProgression, bool) {
+	if b.manual {
+		return state.BranchProgression{}, false
+	}
+	return state.BranchProgression{
+		BranchProgression: model.BranchProgression{
+			Policy:      model.TrackPolicy{Percent: 10},
+			StepPercent: 10,
+			Interval:    time.Hour,
+		},
+		NextStep: b.nextStep,
+	}, true
+}
+
+func (b *fakeBranch) StepProgression() error {
+	if b.stepErr != nil {
+		return b.stepErr
+	}
+	b.steps <- b.name
+	return nil
+}
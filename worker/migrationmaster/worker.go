@@ -78,6 +78,10 @@ type Facade interface {
 	// progress of a migration.
 	SetStatusMessage(string) error
 
+	// SetProgress records the counts of entities exported and
+	// imported and of logs transferred so far by a migration.
+	SetProgress(coremigration.Progress) error
+
 	// Prechecks performs pre-migration checks on the model and
 	// (source) controller.
 	Prechecks() error
@@ -303,6 +307,15 @@ func (w *Worker) setStatus(message string) error {
 	return errors.Annotate(err, "failed to set status message")
 }
 
+func (w *Worker) setProgress(progress coremigration.Progress) {
+	// Like the status message, progress is only informational.
+	if err := w.config.Facade.SetProgress(progress); errors.IsNotSupported(err) {
+		w.logger.Debugf("not recording migration progress: %v", err)
+	} else if err != nil {
+		w.logger.Warningf("failed to set migration progress: %v", err)
+	}
+}
+
 func (w *Worker) doQUIESCE(status coremigration.MigrationStatus) (coremigration.Phase, error) {
 	// Run prechecks before waiting for minions to report back. This
 	// short-circuits the long timeout in the case of an agent being
@@ -410,10 +423,14 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 	}
 	defer conn.Close()
 	targetClient := migrationtarget.NewClient(conn)
-	err = targetClient.Import(serialized.Bytes)
+	imported, err := targetClient.Import(serialized.Bytes)
 	if err != nil {
 		return errors.Annotate(err, "failed to import model into target controller")
 	}
+	w.setProgress(coremigration.Progress{
+		ExportedEntities: serialized.Entities,
+		ImportedEntities: imported,
+	})
 
 	if wrench.IsActive("migrationmaster", "die-in-export") {
 		// Simulate a abort causing failure to test last status not over written.
@@ -555,12 +572,17 @@ func (w *Worker) doLOGTRANSFER(targetInfo coremigration.TargetInfo, modelUUID st
 
 func (w *Worker) transferLogs(targetInfo coremigration.TargetInfo, modelUUID string) error {
 	sent := 0
+	var lastLogTime time.Time
 	reportProgress := func(finished bool, sent int) {
 		verb := "transferring"
 		if finished {
 			verb = "transferred"
 		}
 		w.setInfoStatus("successful, %s logs to target controller (%d sent)", verb, sent)
+		w.setProgress(coremigration.Progress{
+			LogsTransferred: sent,
+			LastLogTime:     lastLogTime,
+		})
 	}
 	reportProgress(false, sent)
 
@@ -616,6 +638,7 @@ func (w *Worker) transferLogs(targetInfo coremigration.TargetInfo, modelUUID str
 				return errors.Trace(err)
 			}
 			sent++
+			lastLogTime = msg.Timestamp
 
 			if throwWrench && sent == 500 {
 				// Simulate a connection drop to test restartability.
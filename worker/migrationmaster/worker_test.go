@@ -290,6 +290,9 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 			{"facade.SetPhase", []interface{}{coremigration.DONE}},
 		}),
 	)
+	c.Check(s.facade.progress[0], jc.DeepEquals, coremigration.Progress{
+		ExportedEntities: map[string]int{"machines": 2, "units": 3},
+	})
 }
 
 func (s *Suite) TestMigrationResume(c *gc.C) {
@@ -935,6 +938,10 @@ func (s *Suite) TestLogTransferSendsRecords(c *gc.C) {
 		},
 	})
 	c.Assert(s.connection.logStream.closeCount, gc.Equals, 1)
+	c.Check(s.facade.progress[len(s.facade.progress)-1], jc.DeepEquals, coremigration.Progress{
+		LogsTransferred: 3,
+		LastLogTime:     t1,
+	})
 }
 
 func (s *Suite) TestLogTransferReportsProgress(c *gc.C) {
@@ -1108,6 +1115,7 @@ type stubMasterFacade struct {
 	exportedResources []coremigration.SerializedModelResource
 
 	statuses []string
+	progress []coremigration.Progress
 }
 
 func (f *stubMasterFacade) triggerWatcher() {
@@ -1209,6 +1217,7 @@ func (f *stubMasterFacade) Export() (coremigration.SerializedModel, error) {
 			version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0",
 		},
 		Resources: f.exportedResources,
+		Entities:  map[string]int{"machines": 2, "units": 3},
 	}, nil
 }
 
@@ -1230,6 +1239,11 @@ func (f *stubMasterFacade) SetStatusMessage(message string) error {
 	return nil
 }
 
+func (f *stubMasterFacade) SetProgress(progress coremigration.Progress) error {
+	f.progress = append(f.progress, progress)
+	return nil
+}
+
 func (f *stubMasterFacade) Reap() error {
 	f.stub.AddCall("facade.Reap")
 	return nil
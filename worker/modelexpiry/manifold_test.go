@@ -0,0 +1,124 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelexpiry_test
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+	dt "github.com/juju/worker/v2/dependency/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker/modelexpiry"
+)
+
+type ManifoldConfigSuite struct {
+	testing.IsolationSuite
+	config modelexpiry.ManifoldConfig
+}
+
+var _ = gc.Suite(&ManifoldConfigSuite{})
+
+func (s *ManifoldConfigSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.config = s.validConfig()
+}
+
+func (s *ManifoldConfigSuite) validConfig() modelexpiry.ManifoldConfig {
+	return modelexpiry.ManifoldConfig{
+		APICallerName:  "api-caller",
+		Clock:          clock.WallClock,
+		Logger:         loggo.GetLogger("test"),
+		WarningPeriod:  time.Hour,
+		MinimumWarning: 10 * time.Minute,
+		RetryDelay:     time.Minute,
+		NewFacade:      func(base.APICaller) (modelexpiry.Facade, error) { return nil, nil },
+		NewWorker:      func(modelexpiry.Config) (worker.Worker, error) { return nil, nil },
+	}
+}
+
+func (s *ManifoldConfigSuite) TestValid(c *gc.C) {
+	c.Check(s.config.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ManifoldConfigSuite) TestMissingAPICallerName(c *gc.C) {
+	s.config.APICallerName = ""
+	s.checkNotValid(c, "empty APICallerName not valid")
+}
+
+func (s *ManifoldConfigSuite) TestMissingClock(c *gc.C) {
+	s.config.Clock = nil
+	s.checkNotValid(c, "nil Clock not valid")
+}
+
+func (s *ManifoldConfigSuite) TestMissingLogger(c *gc.C) {
+	s.config.Logger = nil
+	s.checkNotValid(c, "nil Logger not valid")
+}
+
+func (s *ManifoldConfigSuite) TestMissingNewFacade(c *gc.C) {
+	s.config.NewFacade = nil
+	s.checkNotValid(c, "nil NewFacade not valid")
+}
+
+func (s *ManifoldConfigSuite) TestMissingNewWorker(c *gc.C) {
+	s.config.NewWorker = nil
+	s.checkNotValid(c, "nil NewWorker not valid")
+}
+
+func (s *ManifoldConfigSuite) checkNotValid(c *gc.C, expect string) {
+	err := s.config.Validate()
+	c.Check(err, gc.ErrorMatches, expect)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ManifoldConfigSuite) TestInputs(c *gc.C) {
+	manifold := modelexpiry.Manifold(s.config)
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"api-caller"})
+}
+
+func (s *ManifoldConfigSuite) TestStartMissingAPICaller(c *gc.C) {
+	manifold := modelexpiry.Manifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": dependency.ErrMissing,
+	})
+	w, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	c.Check(w, gc.IsNil)
+}
+
+func (s *ManifoldConfigSuite) TestStartPassesConfig(c *gc.C) {
+	apiCaller := &fakeAPICaller{}
+	facade := &fakeFacade{}
+	s.config.NewFacade = func(caller base.APICaller) (modelexpiry.Facade, error) {
+		c.Check(caller, gc.Equals, apiCaller)
+		return facade, nil
+	}
+	s.config.NewWorker = func(config modelexpiry.Config) (worker.Worker, error) {
+		c.Check(config.Facade, gc.Equals, facade)
+		c.Check(config.Clock, gc.Equals, s.config.Clock)
+		c.Check(config.WarningPeriod, gc.Equals, time.Hour)
+		c.Check(config.MinimumWarning, gc.Equals, 10*time.Minute)
+		c.Check(config.RetryDelay, gc.Equals, time.Minute)
+		return nil, errors.New("splot")
+	}
+	manifold := modelexpiry.Manifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": apiCaller,
+	})
+	w, err := manifold.Start(context)
+	c.Check(err, gc.ErrorMatches, "splot")
+	c.Check(w, gc.IsNil)
+}
+
+type fakeAPICaller struct {
+	base.APICaller
+}
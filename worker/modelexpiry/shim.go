@@ -0,0 +1,14 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelexpiry
+
+import (
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/modelexpiry"
+)
+
+// NewFacade returns a Facade backed by the ModelExpiry API.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return modelexpiry.NewFacade(apiCaller), nil
+}
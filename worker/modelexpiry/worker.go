@@ -0,0 +1,284 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelexpiry
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/api/modelexpiry"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Facade defines the API methods used by the model expiry worker.
+type Facade interface {
+	WatchModel() (watcher.NotifyWatcher, error)
+	ModelExpiry() (modelexpiry.Expiry, error)
+	SetExpiryWarning(destroy time.Time) error
+	DestroyExpiredModel() error
+}
+
+// Logger defines the methods used by the model expiry worker for
+// logging.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// Config holds the dependencies and configuration of a model expiry
+// worker.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+	Logger Logger
+
+	// WarningPeriod is how long before the model expires that a
+	// warning is logged and set in the model's status.
+	WarningPeriod time.Duration
+
+	// MinimumWarning is the least time for which a model is warned
+	// about before it is destroyed. A model whose TTL is set to less
+	// than WarningPeriod, or which expired while the worker wasn't
+	// running, is destroyed MinimumWarning after the warning.
+	MinimumWarning time.Duration
+
+	// RetryDelay is how long the worker waits before trying again
+	// to destroy an expired model whose destruction is blocked.
+	RetryDelay time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start
+// a worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.WarningPeriod < 0 {
+		return errors.NotValidf("negative WarningPeriod")
+	}
+	if config.MinimumWarning <= 0 {
+		return errors.NotValidf("non-positive MinimumWarning")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that destroys the model once its TTL has
+// passed, warning about it beforehand in the log and the model's
+// status. The model is
+// destroyed as if by "juju destroy-model", so the undertaker cleans
+// it up once all of its entities have been removed.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &expiryWorker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type expiryWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// expiry is the model's expiry, as last read.
+	expiry modelexpiry.Expiry
+
+	// destroyAt is when the model is destroyed. It is later than the
+	// model's expiry if the model wasn't warned about for long enough
+	// before it expired.
+	destroyAt time.Time
+
+	// warned is whether the model has been warned about for its
+	// current expiry.
+	warned bool
+
+	warnTimer   clock.Timer
+	expireTimer clock.Timer
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *expiryWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *expiryWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *expiryWorker) loop() error {
+	modelWatcher, err := w.config.Facade.WatchModel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(modelWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	defer w.stopTimers()
+
+	// Any warning left by a previous run of the worker is set again
+	// if it still applies.
+	if err := w.config.Facade.SetExpiryWarning(time.Time{}); err != nil {
+		return errors.Annotate(err, "clearing expiry warning")
+	}
+
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+
+		case _, ok := <-modelWatcher.Changes():
+			if !ok {
+				return errors.New("model watcher closed")
+			}
+			expiry, err := w.config.Facade.ModelExpiry()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if err := w.schedule(expiry); err != nil {
+				return errors.Trace(err)
+			}
+
+		case <-w.timerChannel(w.warnTimer):
+			w.warnTimer = nil
+			if err := w.warn(); err != nil {
+				return errors.Trace(err)
+			}
+
+		case <-w.timerChannel(w.expireTimer):
+			w.expireTimer = nil
+			if err := w.destroy(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (w *expiryWorker) warn() error {
+	w.config.Logger.Warningf(
+		"model expires at %s and will be destroyed at %s; set model-ttl again to extend it",
+		w.expiry.Time.Format(time.RFC3339), w.destroyAt.Format(time.RFC3339),
+	)
+	if err := w.config.Facade.SetExpiryWarning(w.destroyAt); err != nil {
+		return errors.Annotate(err, "setting expiry warning")
+	}
+	w.warned = true
+	return nil
+}
+
+func (w *expiryWorker) destroy() error {
+	// The expiry is read again, as the model watcher doesn't report
+	// changes to whether the storage is destroyed, and may not have
+	// reported a change to the TTL yet.
+	expiry, err := w.config.Facade.ModelExpiry()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !expiry.Time.Equal(w.expiry.Time) {
+		return errors.Trace(w.schedule(expiry))
+	}
+	w.expiry = expiry
+
+	err = w.config.Facade.DestroyExpiredModel()
+	if params.IsCodeOperationBlocked(err) {
+		w.config.Logger.Warningf(
+			"model expired at %s but cannot be destroyed (%v); trying again in %s",
+			expiry.Time.Format(time.RFC3339), err, w.config.RetryDelay,
+		)
+		w.expireTimer = w.config.Clock.NewTimer(w.config.RetryDelay)
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "destroying expired model")
+	}
+	storage := "released"
+	if expiry.DestroyStorage {
+		storage = "destroyed"
+	}
+	w.config.Logger.Infof(
+		"model expired at %s and is being destroyed; its storage will be %s",
+		expiry.Time.Format(time.RFC3339), storage,
+	)
+	return nil
+}
+
+// schedule sets the timers for warning about and destroying a model
+// with the given expiry, replacing any previously set. The model is
+// warned about WarningPeriod before it expires, and is destroyed no
+// sooner than MinimumWarning after the warning.
+func (w *expiryWorker) schedule(expiry modelexpiry.Expiry) error {
+	w.stopTimers()
+	if w.warned && !expiry.Time.Equal(w.expiry.Time) {
+		// The TTL has been set again or removed since the warning.
+		if err := w.config.Facade.SetExpiryWarning(time.Time{}); err != nil {
+			return errors.Annotate(err, "clearing expiry warning")
+		}
+		w.warned = false
+	}
+	w.expiry = expiry
+	if expiry.Time.IsZero() {
+		w.config.Logger.Debugf("model does not expire")
+		return nil
+	}
+	w.config.Logger.Debugf("model expires at %s", expiry.Time.Format(time.RFC3339))
+
+	now := w.config.Clock.Now()
+	if !w.warned {
+		warnAt := expiry.Time.Add(-w.config.WarningPeriod)
+		if warnAt.Before(now) {
+			warnAt = now
+		}
+		w.destroyAt = expiry.Time
+		if earliest := warnAt.Add(w.config.MinimumWarning); w.destroyAt.Before(earliest) {
+			w.destroyAt = earliest
+		}
+		w.warnTimer = w.config.Clock.NewTimer(warnAt.Sub(now))
+	}
+	untilDestroy := w.destroyAt.Sub(now)
+	if untilDestroy < 0 {
+		untilDestroy = 0
+	}
+	w.expireTimer = w.config.Clock.NewTimer(untilDestroy)
+	return nil
+}
+
+func (w *expiryWorker) stopTimers() {
+	if w.warnTimer != nil {
+		w.warnTimer.Stop()
+		w.warnTimer = nil
+	}
+	if w.expireTimer != nil {
+		w.expireTimer.Stop()
+		w.expireTimer = nil
+	}
+}
+
+// timerChannel returns the channel of the timer, or nil if there is
+// no timer, so that it can be used in a select.
+func (w *expiryWorker) timerChannel(timer clock.Timer) <-chan time.Time {
+	if timer == nil {
+		return nil
+	}
+	return timer.Chan()
+}
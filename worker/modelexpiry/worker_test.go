@@ -0,0 +1,346 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelexpiry_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	apimodelexpiry "github.com/juju/juju/api/modelexpiry"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/modelexpiry"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	facade *fakeFacade
+	logger *fakeLogger
+	config modelexpiry.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.facade = &fakeFacade{
+		changes:   make(chan struct{}),
+		warnings:  make(chan time.Time, 10),
+		destroyed: make(chan struct{}, 10),
+	}
+	s.logger = &fakeLogger{messages: make(chan string, 20)}
+	s.config = modelexpiry.Config{
+		Facade:         s.facade,
+		Clock:          s.clock,
+		Logger:         s.logger,
+		WarningPeriod:  time.Hour,
+		MinimumWarning: 10 * time.Minute,
+		RetryDelay:     10 * time.Minute,
+	}
+}
+
+func (s *WorkerSuite) newWorker(c *gc.C) worker.Worker {
+	w, err := modelexpiry.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	type test struct {
+		f      func(*modelexpiry.Config)
+		expect string
+	}
+	tests := []test{{
+		func(cfg *modelexpiry.Config) { cfg.Facade = nil },
+		"nil Facade not valid",
+	}, {
+		func(cfg *modelexpiry.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *modelexpiry.Config) { cfg.Logger = nil },
+		"nil Logger not valid",
+	}, {
+		func(cfg *modelexpiry.Config) { cfg.WarningPeriod = -time.Second },
+		"negative WarningPeriod not valid",
+	}, {
+		func(cfg *modelexpiry.Config) { cfg.MinimumWarning = 0 },
+		"non-positive MinimumWarning not valid",
+	}, {
+		func(cfg *modelexpiry.Config) { cfg.RetryDelay = 0 },
+		"non-positive RetryDelay not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test #%d (%s)", i, test.expect)
+		config := s.config
+		test.f(&config)
+		w, err := modelexpiry.NewWorker(config)
+		c.Check(err, gc.ErrorMatches, test.expect)
+		c.Check(w, gc.IsNil)
+	}
+}
+
+func (s *WorkerSuite) TestNoExpiry(c *gc.C) {
+	s.newWorker(c)
+	s.changeModel(c, apimodelexpiry.Expiry{})
+	s.waitMessage(c, "DEBUG model does not expire")
+	s.assertNotDestroyed(c)
+}
+
+func (s *WorkerSuite) TestClearsWarningOnStart(c *gc.C) {
+	s.newWorker(c)
+	s.waitWarning(c, time.Time{})
+}
+
+func (s *WorkerSuite) TestWarnsThenDestroys(c *gc.C) {
+	s.newWorker(c)
+	s.waitWarning(c, time.Time{})
+	s.changeModel(c, apimodelexpiry.Expiry{Time: s.clock.Now().Add(3 * time.Hour)})
+	s.waitMessage(c, "DEBUG model expires at 2020-06-01T15:00:00Z")
+
+	s.advance(c, 2*time.Hour, 2)
+	s.waitMessage(c, "WARNING model expires at 2020-06-01T15:00:00Z and will be destroyed at 2020-06-01T15:00:00Z; set model-ttl again to extend it")
+	s.waitWarning(c, s.clock.Now().Add(time.Hour))
+	s.assertNotDestroyed(c)
+
+	s.advance(c, time.Hour, 1)
+	s.waitDestroyed(c)
+	s.waitMessage(c, "INFO model expired at 2020-06-01T15:00:00Z and is being destroyed; its storage will be released")
+}
+
+func (s *WorkerSuite) TestDestroyStorage(c *gc.C) {
+	s.newWorker(c)
+	s.changeModel(c, apimodelexpiry.Expiry{Time: s.clock.Now(), DestroyStorage: true})
+	s.advance(c, 10*time.Minute, 1)
+	s.waitDestroyed(c)
+	s.waitMessage(c, "INFO model expired at 2020-06-01T12:00:00Z and is being destroyed; its storage will be destroyed")
+}
+
+func (s *WorkerSuite) TestAlreadyExpired(c *gc.C) {
+	s.newWorker(c)
+	s.waitWarning(c, time.Time{})
+	s.changeModel(c, apimodelexpiry.Expiry{Time: s.clock.Now().Add(-time.Hour)})
+
+	// The model is warned about for at least the minimum warning
+	// period before it is destroyed.
+	s.waitMessage(c, "WARNING model expires at 2020-06-01T11:00:00Z and will be destroyed at 2020-06-01T12:10:00Z; .*")
+	s.waitWarning(c, s.clock.Now().Add(10*time.Minute))
+	s.assertNotDestroyed(c)
+
+	s.advance(c, 10*time.Minute, 1)
+	s.waitDestroyed(c)
+}
+
+func (s *WorkerSuite) TestWithinWarningPeriod(c *gc.C) {
+	s.newWorker(c)
+	s.changeModel(c, apimodelexpiry.Expiry{Time: s.clock.Now().Add(30 * time.Minute)})
+	s.waitMessage(c, "WARNING model expires at 2020-06-01T12:30:00Z and will be destroyed at 2020-06-01T12:30:00Z; .*")
+	s.assertNotDestroyed(c)
+
+	s.advance(c, 30*time.Minute, 1)
+	s.waitDestroyed(c)
+}
+
+func (s *WorkerSuite) TestTTLShorterThanMinimumWarning(c *gc.C) {
+	s.newWorker(c)
+	s.changeModel(c, apimodelexpiry.Expiry{Time: s.clock.Now().Add(time.Minute)})
+	s.waitMessage(c, "WARNING model expires at 2020-06-01T12:01:00Z and will be destroyed at 2020-06-01T12:10:00Z; .*")
+
+	s.advance(c, time.Minute, 1)
+	s.assertNotDestroyed(c)
+	s.advance(c, 9*time.Minute, 1)
+	s.waitDestroyed(c)
+}
+
+func (s *WorkerSuite) TestModelChangeReschedules(c *gc.C) {
+	s.newWorker(c)
+	s.changeModel(c, apimodelexpiry.Expiry{Time: s.clock.Now().Add(3 * time.Hour)})
+	s.waitMessage(c, "DEBUG model expires at 2020-06-01T15:00:00Z")
+
+	// The TTL is removed, so the model no longer expires.
+	s.changeModel(c, apimodelexpiry.Expiry{})
+	s.waitMessage(c, "DEBUG model does not expire")
+	s.clock.Advance(4 * time.Hour)
+	s.assertNotDestroyed(c)
+}
+
+func (s *WorkerSuite) TestTTLSetAgainRemovesWarning(c *gc.C) {
+	s.newWorker(c)
+	s.waitWarning(c, time.Time{})
+	s.changeModel(c, apimodelexpiry.Expiry{Time: s.clock.Now().Add(30 * time.Minute)})
+	s.waitWarning(c, s.clock.Now().Add(30*time.Minute))
+
+	// Setting the TTL again restarts it, so the warning is removed
+	// until the model is about to expire again.
+	s.changeModel(c, apimodelexpiry.Expiry{Time: s.clock.Now().Add(3 * time.Hour)})
+	s.waitWarning(c, time.Time{})
+	s.advance(c, 30*time.Minute, 2)
+	s.assertNotDestroyed(c)
+}
+
+func (s *WorkerSuite) TestTTLChangedBeforeDestroy(c *gc.C) {
+	s.newWorker(c)
+	s.changeModel(c, apimodelexpiry.Expiry{Time: s.clock.Now().Add(30 * time.Minute)})
+	s.waitMessage(c, "WARNING model expires at 2020-06-01T12:30:00Z .*")
+
+	// The TTL is set again, but the change hasn't been reported yet
+	// when the model would have been destroyed.
+	s.facade.setExpiry(apimodelexpiry.Expiry{Time: s.clock.Now().Add(3 * time.Hour)})
+	s.advance(c, 30*time.Minute, 1)
+	s.waitMessage(c, "DEBUG model expires at 2020-06-01T15:00:00Z")
+	s.assertNotDestroyed(c)
+}
+
+func (s *WorkerSuite) TestDestroyBlockedRetries(c *gc.C) {
+	s.facade.SetErrors(&params.Error{Code: params.CodeOperationBlocked, Message: "keep it"})
+	s.newWorker(c)
+	s.changeModel(c, apimodelexpiry.Expiry{Time: s.clock.Now()})
+	s.advance(c, 10*time.Minute, 1)
+	s.waitDestroyed(c)
+	s.waitMessage(c, "WARNING model expired at 2020-06-01T12:00:00Z but cannot be destroyed \\(keep it\\); trying again in 10m0s")
+
+	s.advance(c, 10*time.Minute, 1)
+	s.waitDestroyed(c)
+	s.waitMessage(c, "INFO model expired at .*")
+}
+
+func (s *WorkerSuite) TestDestroyError(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+	w := s.newWorker(c)
+	s.changeModel(c, apimodelexpiry.Expiry{Time: s.clock.Now()})
+	s.advance(c, 10*time.Minute, 1)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "destroying expired model: boom")
+}
+
+func (s *WorkerSuite) changeModel(c *gc.C, expiry apimodelexpiry.Expiry) {
+	s.facade.setExpiry(expiry)
+	select {
+	case s.facade.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending model change")
+	}
+}
+
+func (s *WorkerSuite) advance(c *gc.C, d time.Duration, timers int) {
+	err := s.clock.WaitAdvance(d, coretesting.LongWait, timers)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) waitDestroyed(c *gc.C) {
+	select {
+	case <-s.facade.destroyed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for model to be destroyed")
+	}
+}
+
+func (s *WorkerSuite) assertNotDestroyed(c *gc.C) {
+	select {
+	case <-s.facade.destroyed:
+		c.Fatalf("model unexpectedly destroyed")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) waitWarning(c *gc.C, destroy time.Time) {
+	select {
+	case warning := <-s.facade.warnings:
+		c.Assert(warning, gc.Equals, destroy)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for expiry warning")
+	}
+}
+
+func (s *WorkerSuite) waitMessage(c *gc.C, expect string) {
+	re := regexp.MustCompile("^" + expect + "$")
+	timeout := time.After(coretesting.LongWait)
+	for {
+		select {
+		case msg := <-s.logger.messages:
+			if re.MatchString(msg) {
+				return
+			}
+			c.Logf("ignoring %q", msg)
+		case <-timeout:
+			c.Fatalf("timed out waiting for %q", expect)
+		}
+	}
+}
+
+type fakeFacade struct {
+	testing.Stub
+
+	mu        sync.Mutex
+	expiry    apimodelexpiry.Expiry
+	changes   chan struct{}
+	warnings  chan time.Time
+	destroyed chan struct{}
+}
+
+func (f *fakeFacade) setExpiry(expiry apimodelexpiry.Expiry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.expiry = expiry
+}
+
+func (f *fakeFacade) WatchModel() (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchModel")
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
+
+func (f *fakeFacade) ModelExpiry() (apimodelexpiry.Expiry, error) {
+	f.MethodCall(f, "ModelExpiry")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.expiry, nil
+}
+
+func (f *fakeFacade) SetExpiryWarning(destroy time.Time) error {
+	f.MethodCall(f, "SetExpiryWarning", destroy)
+	f.warnings <- destroy
+	return nil
+}
+
+func (f *fakeFacade) DestroyExpiredModel() error {
+	f.MethodCall(f, "DestroyExpiredModel")
+	f.destroyed <- struct{}{}
+	return f.NextErr()
+}
+
+type fakeLogger struct {
+	messages chan string
+}
+
+func (l *fakeLogger) log(level, format string, args ...interface{}) {
+	l.messages <- strings.TrimSpace(level + " " + fmt.Sprintf(format, args...))
+}
+
+func (l *fakeLogger) Debugf(format string, args ...interface{}) {
+	l.log("DEBUG", format, args...)
+}
+
+func (l *fakeLogger) Infof(format string, args ...interface{}) {
+	l.log("INFO", format, args...)
+}
+
+func (l *fakeLogger) Warningf(format string, args ...interface{}) {
+	l.log("WARNING", format, args...)
+}